@@ -0,0 +1,489 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package breaking detects changes between two versions of a protocol buffer
+// schema that break compatibility with data or programs using the old version.
+//
+// Each kind of change is detected by a rule, identified by an ID such as
+// FIELD_TYPE_CHANGED. Rules are in the Wire category if the change breaks
+// the binary wire format, and in the JSON category if it only breaks
+// the JSON format.
+package breaking
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/internal/finding"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// A Category is the kind of compatibility broken by a change.
+type Category string
+
+const (
+	// Wire changes break the binary wire format, or programs
+	// using the generated code or the RPC interface.
+	Wire Category = "wire"
+	// JSON changes only break the JSON format.
+	JSON Category = "json"
+)
+
+// A Rule detects a kind of breaking change.
+type Rule struct {
+	ID          string
+	Category    Category
+	Description string
+}
+
+// IDs of the rules.
+const (
+	MessageRemoved           = "MESSAGE_REMOVED"
+	EnumRemoved              = "ENUM_REMOVED"
+	ServiceRemoved           = "SERVICE_REMOVED"
+	ExtensionRemoved         = "EXTENSION_REMOVED"
+	ExtensionNumberChanged   = "EXTENSION_NUMBER_CHANGED"
+	ExtensionExtendeeChanged = "EXTENSION_EXTENDEE_CHANGED"
+	FieldRemoved             = "FIELD_REMOVED"
+	FieldNumberReused        = "FIELD_NUMBER_REUSED"
+	FieldTypeChanged         = "FIELD_TYPE_CHANGED"
+	FieldCardinalityChanged  = "FIELD_CARDINALITY_CHANGED"
+	FieldOneofChanged        = "FIELD_ONEOF_CHANGED"
+	FieldRequiredAdded       = "FIELD_REQUIRED_ADDED"
+	FieldRequiredRemoved     = "FIELD_REQUIRED_REMOVED"
+	FieldJSONTypeChanged     = "FIELD_JSON_TYPE_CHANGED"
+	FieldJSONNameChanged     = "FIELD_JSON_NAME_CHANGED"
+	EnumValueRemoved         = "ENUM_VALUE_REMOVED"
+	EnumValueNameChanged     = "ENUM_VALUE_NAME_CHANGED"
+	MethodRemoved            = "METHOD_REMOVED"
+	MethodTypeChanged        = "METHOD_TYPE_CHANGED"
+	MethodStreamingChanged   = "METHOD_STREAMING_CHANGED"
+)
+
+// Rules lists all the rules.
+var Rules = []Rule{
+	{MessageRemoved, Wire, "a message was removed"},
+	{EnumRemoved, Wire, "an enum was removed"},
+	{ServiceRemoved, Wire, "a service was removed"},
+	{ExtensionRemoved, Wire, "an extension was removed"},
+	{ExtensionNumberChanged, Wire, "the field number of an extension changed"},
+	{ExtensionExtendeeChanged, Wire, "an extension now extends a different message"},
+	{FieldRemoved, Wire, "a field was removed without reserving its number"},
+	{FieldNumberReused, Wire, "a field number was reused by a different field"},
+	{FieldTypeChanged, Wire, "the type of a field changed to one with a different wire encoding"},
+	{FieldCardinalityChanged, Wire, "a field changed between singular and repeated"},
+	{FieldOneofChanged, Wire, "a field moved into, out of or between oneofs"},
+	{FieldRequiredAdded, Wire, "a required field was added, or a field became required"},
+	{FieldRequiredRemoved, Wire, "a field is no longer required"},
+	{FieldJSONTypeChanged, JSON, "the type of a field changed to one with a different JSON encoding"},
+	{FieldJSONNameChanged, JSON, "the JSON name of a field changed"},
+	{EnumValueRemoved, Wire, "an enum value was removed without reserving its number"},
+	{EnumValueNameChanged, JSON, "the name of an enum value changed"},
+	{MethodRemoved, Wire, "a method was removed"},
+	{MethodTypeChanged, Wire, "the request or response type of a method changed"},
+	{MethodStreamingChanged, Wire, "a method changed between streaming and unary"},
+}
+
+func ruleCategory(id string) Category {
+	for _, r := range Rules {
+		if r.ID == id {
+			return r.Category
+		}
+	}
+	panic("breaking: unknown rule " + id)
+}
+
+// A Location is a position in a .proto source file.
+// Line and Column are 1-based, and zero if unknown.
+type Location = finding.Location
+
+// A Finding is a breaking change. Its Element is the full name of the
+// changed element, and its Location the location of the element in the
+// new schema, or of its parent if the element was removed. It is in the
+// old schema if the parent was removed as well.
+type Finding struct {
+	finding.Finding
+	Category Category `json:"category"`
+}
+
+// A Checker compares two versions of a schema.
+// The zero value only checks for changes that break the wire format.
+type Checker struct {
+	// JSON specifies whether changes that only break
+	// the JSON format are also reported.
+	JSON bool
+
+	// Ignore lists the IDs of rules that are not checked.
+	Ignore []string
+}
+
+// CompareSets compares the old and new versions of a schema,
+// each made of a self-contained set of files.
+func (c *Checker) CompareSets(old, new *descriptorpb.FileDescriptorSet) ([]Finding, error) {
+	oldFiles, err := protodesc.NewFiles(old)
+	if err != nil {
+		return nil, fmt.Errorf("breaking: old schema: %v", err)
+	}
+	newFiles, err := protodesc.NewFiles(new)
+	if err != nil {
+		return nil, fmt.Errorf("breaking: new schema: %v", err)
+	}
+	return c.Compare(oldFiles, newFiles), nil
+}
+
+// Compare compares the old and new versions of a schema,
+// and returns the breaking changes sorted by location.
+func (c *Checker) Compare(old, new *protoregistry.Files) []Finding {
+	r := &comparison{Checker: c}
+	oldDecls := collect(old)
+	newDecls := collect(new)
+	for _, name := range oldDecls.names {
+		od := oldDecls.byName[name]
+		nd := newDecls.byName[name]
+		switch od := od.(type) {
+		case protoreflect.MessageDescriptor:
+			if nd, ok := nd.(protoreflect.MessageDescriptor); ok {
+				r.compareMessages(od, nd)
+			} else {
+				r.report(MessageRemoved, od, r.parentOf(od, newDecls), "message %v was removed", od.FullName())
+			}
+		case protoreflect.EnumDescriptor:
+			if nd, ok := nd.(protoreflect.EnumDescriptor); ok {
+				r.compareEnums(od, nd)
+			} else {
+				r.report(EnumRemoved, od, r.parentOf(od, newDecls), "enum %v was removed", od.FullName())
+			}
+		case protoreflect.ServiceDescriptor:
+			if nd, ok := nd.(protoreflect.ServiceDescriptor); ok {
+				r.compareServices(od, nd)
+			} else {
+				r.report(ServiceRemoved, od, nil, "service %v was removed", od.FullName())
+			}
+		case protoreflect.ExtensionDescriptor:
+			if nd, ok := nd.(protoreflect.ExtensionDescriptor); ok {
+				r.compareExtensions(od, nd)
+			} else {
+				r.report(ExtensionRemoved, od, r.parentOf(od, newDecls), "extension %v was removed", od.FullName())
+			}
+		}
+	}
+	sort.SliceStable(r.findings, func(i, j int) bool {
+		return finding.Less(r.findings[i].Finding, r.findings[j].Finding)
+	})
+	return r.findings
+}
+
+// decls holds the top-level and nested declarations of a schema.
+type decls struct {
+	names  []protoreflect.FullName // in declaration order
+	byName map[protoreflect.FullName]protoreflect.Descriptor
+}
+
+func collect(files *protoregistry.Files) *decls {
+	ds := &decls{byName: make(map[protoreflect.FullName]protoreflect.Descriptor)}
+	add := func(d protoreflect.Descriptor) {
+		ds.names = append(ds.names, d.FullName())
+		ds.byName[d.FullName()] = d
+	}
+	var addTypes func(messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors, exts protoreflect.ExtensionDescriptors)
+	addTypes = func(messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors, exts protoreflect.ExtensionDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			md := messages.Get(i)
+			if md.IsMapEntry() {
+				continue // compared as part of the map field
+			}
+			add(md)
+			addTypes(md.Messages(), md.Enums(), md.Extensions())
+		}
+		for i := 0; i < enums.Len(); i++ {
+			add(enums.Get(i))
+		}
+		for i := 0; i < exts.Len(); i++ {
+			add(exts.Get(i))
+		}
+	}
+	var fds []protoreflect.FileDescriptor
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		fds = append(fds, fd)
+		return true
+	})
+	sort.Slice(fds, func(i, j int) bool { return fds[i].Path() < fds[j].Path() })
+	for _, fd := range fds {
+		addTypes(fd.Messages(), fd.Enums(), fd.Extensions())
+		for i := 0; i < fd.Services().Len(); i++ {
+			add(fd.Services().Get(i))
+		}
+	}
+	return ds
+}
+
+type comparison struct {
+	*Checker
+	findings []Finding
+}
+
+func (r *comparison) enabled(rule string) bool {
+	if ruleCategory(rule) == JSON && !r.JSON {
+		return false
+	}
+	for _, id := range r.Ignore {
+		if id == rule {
+			return false
+		}
+	}
+	return true
+}
+
+// report records a finding for the element d. The finding is located
+// at loc if not nil, and at d otherwise.
+func (r *comparison) report(rule string, d, loc protoreflect.Descriptor, format string, args ...interface{}) {
+	if !r.enabled(rule) {
+		return
+	}
+	if loc == nil {
+		loc = d
+	}
+	r.findings = append(r.findings, Finding{
+		Finding: finding.Finding{
+			Rule:     rule,
+			Element:  string(d.FullName()),
+			Message:  fmt.Sprintf(format, args...),
+			Location: location(loc),
+		},
+		Category: ruleCategory(rule),
+	})
+}
+
+// parentOf returns the declaration in the new schema enclosing the
+// removed declaration d, if it still exists.
+func (r *comparison) parentOf(d protoreflect.Descriptor, newDecls *decls) protoreflect.Descriptor {
+	if _, ok := d.Parent().(protoreflect.FileDescriptor); ok {
+		return nil
+	}
+	return newDecls.byName[d.Parent().FullName()]
+}
+
+func location(d protoreflect.Descriptor) Location {
+	fd := d.ParentFile()
+	if fd == nil {
+		return Location{}
+	}
+	l := Location{File: fd.Path()}
+	if loc := fd.SourceLocations().ByDescriptor(d); loc.Path != nil {
+		l.Line = loc.StartLine + 1
+		l.Column = loc.StartColumn + 1
+	}
+	return l
+}
+
+func (r *comparison) compareMessages(old, new protoreflect.MessageDescriptor) {
+	oldFields := old.Fields()
+	newFields := new.Fields()
+	for i := 0; i < oldFields.Len(); i++ {
+		of := oldFields.Get(i)
+		nf := newFields.ByNumber(of.Number())
+		switch {
+		case nf == nil && !new.ReservedRanges().Has(of.Number()):
+			r.report(FieldRemoved, of, new, "field %v with number %d was removed without reserving its number", of.FullName(), of.Number())
+		case nf == nil:
+		case nf.Name() != of.Name() && newFields.ByName(of.Name()) != nil:
+			r.report(FieldNumberReused, nf, nil, "field number %d of %v was reused by field %v", of.Number(), of.FullName(), nf.Name())
+		default:
+			r.compareFields(of, nf)
+		}
+	}
+	for i := 0; i < newFields.Len(); i++ {
+		nf := newFields.Get(i)
+		if oldFields.ByNumber(nf.Number()) != nil {
+			continue
+		}
+		if old.ReservedRanges().Has(nf.Number()) {
+			r.report(FieldNumberReused, nf, nil, "field %v uses the reserved number %d", nf.FullName(), nf.Number())
+		}
+		if nf.Cardinality() == protoreflect.Required {
+			r.report(FieldRequiredAdded, nf, nil, "required field %v was added", nf.FullName())
+		}
+	}
+}
+
+func (r *comparison) compareFields(old, new protoreflect.FieldDescriptor) {
+	oldType, newType := typeName(old), typeName(new)
+	switch {
+	case (old.IsList() || old.IsMap()) != (new.IsList() || new.IsMap()):
+		r.report(FieldCardinalityChanged, new, nil, "field %v changed from %v to %v", new.FullName(), cardinality(old), cardinality(new))
+	case !wireCompatible(old, new):
+		r.report(FieldTypeChanged, new, nil, "field %v changed type from %v to %v", new.FullName(), oldType, newType)
+	case oldType != newType || old.IsMap() != new.IsMap():
+		r.report(FieldJSONTypeChanged, new, nil, "field %v changed type from %v to %v", new.FullName(), oldType, newType)
+	}
+
+	oldRequired := old.Cardinality() == protoreflect.Required
+	newRequired := new.Cardinality() == protoreflect.Required
+	switch {
+	case !oldRequired && newRequired:
+		r.report(FieldRequiredAdded, new, nil, "field %v became required", new.FullName())
+	case oldRequired && !newRequired:
+		r.report(FieldRequiredRemoved, new, nil, "field %v is no longer required", new.FullName())
+	}
+
+	if oldOneof, newOneof := oneofName(old), oneofName(new); oldOneof != newOneof {
+		switch {
+		case oldOneof == "":
+			r.report(FieldOneofChanged, new, nil, "field %v moved into oneof %v", new.FullName(), newOneof)
+		case newOneof == "":
+			r.report(FieldOneofChanged, new, nil, "field %v moved out of oneof %v", new.FullName(), oldOneof)
+		default:
+			r.report(FieldOneofChanged, new, nil, "field %v moved from oneof %v to %v", new.FullName(), oldOneof, newOneof)
+		}
+	}
+
+	if !new.IsExtension() && old.JSONName() != new.JSONName() {
+		r.report(FieldJSONNameChanged, new, nil, "field %v changed JSON name from %q to %q", new.FullName(), old.JSONName(), new.JSONName())
+	}
+}
+
+func (r *comparison) compareExtensions(old, new protoreflect.ExtensionDescriptor) {
+	if old.Number() != new.Number() {
+		r.report(ExtensionNumberChanged, new, nil, "extension %v changed number from %d to %d", new.FullName(), old.Number(), new.Number())
+	}
+	if oldMsg, newMsg := old.ContainingMessage().FullName(), new.ContainingMessage().FullName(); oldMsg != newMsg {
+		r.report(ExtensionExtendeeChanged, new, nil, "extension %v changed from extending %v to %v", new.FullName(), oldMsg, newMsg)
+	}
+	r.compareFields(old, new)
+}
+
+func cardinality(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return "map"
+	case fd.IsList():
+		return "repeated"
+	}
+	return "singular"
+}
+
+func oneofName(fd protoreflect.FieldDescriptor) protoreflect.Name {
+	od := fd.ContainingOneof()
+	if od == nil || od.IsSynthetic() {
+		return ""
+	}
+	return od.Name()
+}
+
+// typeName returns the name of the type of the field fd.
+func typeName(fd protoreflect.FieldDescriptor) string {
+	if fd.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", typeName(fd.MapKey()), typeName(fd.MapValue()))
+	}
+	switch {
+	case fd.Enum() != nil:
+		return string(fd.Enum().FullName())
+	case fd.Message() != nil:
+		return string(fd.Message().FullName())
+	}
+	return fd.Kind().String()
+}
+
+// wireClass groups the kinds of fields that are encoded identically
+// on the wire.
+func wireClass(fd protoreflect.FieldDescriptor) string {
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind,
+		protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+		protoreflect.BoolKind:
+		return "varint"
+	case protoreflect.EnumKind:
+		// Enums are encoded as int32 values, but changing the enum type
+		// changes the meaning of the values.
+		return "enum " + string(fd.Enum().FullName())
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		return "zigzag"
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind:
+		return "fixed32"
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind:
+		return "fixed64"
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return "bytes"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return strings.ToLower(fd.Kind().String()) + " " + string(fd.Message().FullName())
+	}
+	return fd.Kind().String()
+}
+
+func wireCompatible(old, new protoreflect.FieldDescriptor) bool {
+	if old.IsMap() && new.IsMap() {
+		return wireClass(old.MapKey()) == wireClass(new.MapKey()) &&
+			wireClass(old.MapValue()) == wireClass(new.MapValue())
+	}
+	if old.IsMap() || new.IsMap() {
+		// A map is encoded as a list of entry messages.
+		return wireClass(old) == wireClass(new) ||
+			old.Kind() == protoreflect.MessageKind && new.Kind() == protoreflect.MessageKind &&
+				entryFieldsCompatible(old.Message(), new.Message())
+	}
+	return wireClass(old) == wireClass(new)
+}
+
+// entryFieldsCompatible reports whether the key and value fields of two
+// messages used as map entries are encoded identically.
+func entryFieldsCompatible(old, new protoreflect.MessageDescriptor) bool {
+	for _, n := range []protoreflect.FieldNumber{1, 2} {
+		of, nf := old.Fields().ByNumber(n), new.Fields().ByNumber(n)
+		if of == nil || nf == nil || wireClass(of) != wireClass(nf) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *comparison) compareEnums(old, new protoreflect.EnumDescriptor) {
+	oldValues := old.Values()
+	newValues := new.Values()
+	for i := 0; i < oldValues.Len(); i++ {
+		ov := oldValues.Get(i)
+		nv := newValues.ByNumber(ov.Number())
+		switch {
+		case nv == nil && !new.ReservedRanges().Has(ov.Number()):
+			r.report(EnumValueRemoved, ov, new, "enum value %v with number %d was removed without reserving its number", ov.FullName(), ov.Number())
+		case nv == nil:
+		case newValues.ByName(ov.Name()) == nil || newValues.ByName(ov.Name()).Number() != ov.Number():
+			r.report(EnumValueNameChanged, ov, nv, "enum value %d changed name from %v to %v", ov.Number(), ov.Name(), nv.Name())
+		}
+	}
+}
+
+func (r *comparison) compareServices(old, new protoreflect.ServiceDescriptor) {
+	for i := 0; i < old.Methods().Len(); i++ {
+		om := old.Methods().Get(i)
+		nm := new.Methods().ByName(om.Name())
+		if nm == nil {
+			r.report(MethodRemoved, om, new, "method %v was removed", om.FullName())
+			continue
+		}
+		if om.Input().FullName() != nm.Input().FullName() || om.Output().FullName() != nm.Output().FullName() {
+			r.report(MethodTypeChanged, nm, nil, "method %v changed from (%v) returns (%v) to (%v) returns (%v)",
+				nm.FullName(), om.Input().FullName(), om.Output().FullName(), nm.Input().FullName(), nm.Output().FullName())
+		}
+		if om.IsStreamingClient() != nm.IsStreamingClient() || om.IsStreamingServer() != nm.IsStreamingServer() {
+			r.report(MethodStreamingChanged, nm, nil, "method %v changed from %v to %v", nm.FullName(), streaming(om), streaming(nm))
+		}
+	}
+}
+
+func streaming(md protoreflect.MethodDescriptor) string {
+	switch {
+	case md.IsStreamingClient() && md.IsStreamingServer():
+		return "bidirectional streaming"
+	case md.IsStreamingClient():
+		return "client streaming"
+	case md.IsStreamingServer():
+		return "server streaming"
+	}
+	return "unary"
+}
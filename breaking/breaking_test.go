@@ -0,0 +1,283 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package breaking_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/breaking"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// schema returns a set holding a single file with the given contents.
+func schema(t *testing.T, contents string) *descpb.FileDescriptorSet {
+	fd := new(descpb.FileDescriptorProto)
+	if err := proto.UnmarshalText(`name: "test.proto" package: "test" `+contents, fd); err != nil {
+		t.Fatal(err)
+	}
+	return &descpb.FileDescriptorSet{File: []*descpb.FileDescriptorProto{fd}}
+}
+
+const proto3 = `syntax: "proto3" `
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		desc     string
+		old, new string
+		json     bool
+		ignore   []string
+		want     []string
+	}{{
+		desc: "no changes",
+		old:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		new:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+	}, {
+		desc: "compatible type change",
+		old:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		new:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT64 } }`,
+	}, {
+		desc: "compatible type change breaking JSON",
+		old:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		new:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT64 } }`,
+		json: true,
+		want: []string{breaking.FieldJSONTypeChanged},
+	}, {
+		desc: "incompatible type change",
+		old:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		new:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING } }`,
+		json: true,
+		want: []string{breaking.FieldTypeChanged},
+	}, {
+		desc:   "ignored rule",
+		old:    `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		new:    `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING } }`,
+		ignore: []string{breaking.FieldTypeChanged},
+	}, {
+		desc: "message type change",
+		old: `message_type { name: "A" } message_type { name: "B" }
+			message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".test.A" } }`,
+		new: `message_type { name: "A" } message_type { name: "B" }
+			message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".test.B" } }`,
+		want: []string{breaking.FieldTypeChanged},
+	}, {
+		desc: "cardinality change",
+		old:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		new:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_REPEATED type: TYPE_INT32 } }`,
+		want: []string{breaking.FieldCardinalityChanged},
+	}, {
+		desc: "field removed",
+		old: `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			field { name: "b" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		new:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		want: []string{breaking.FieldRemoved},
+	}, {
+		desc: "field removed and reserved",
+		old: `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			field { name: "b" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		new: `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			reserved_range { start: 2 end: 3 } reserved_name: "b" }`,
+	}, {
+		desc: "reserved number reused",
+		old: `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			reserved_range { start: 2 end: 3 } }`,
+		new: `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			field { name: "c" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING } }`,
+		want: []string{breaking.FieldNumberReused},
+	}, {
+		desc: "field numbers swapped",
+		old: `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			field { name: "b" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		new: `message_type { name: "M" field { name: "b" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			field { name: "a" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		want: []string{breaking.FieldNumberReused, breaking.FieldNumberReused},
+	}, {
+		desc: "field renamed",
+		old:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 json_name: "a" } }`,
+		new:  `message_type { name: "M" field { name: "b" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 json_name: "b" } }`,
+		json: true,
+		want: []string{breaking.FieldJSONNameChanged},
+	}, {
+		desc: "json_name changed",
+		old:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 json_name: "a" } }`,
+		new:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 json_name: "alpha" } }`,
+		json: true,
+		want: []string{breaking.FieldJSONNameChanged},
+	}, {
+		desc: "field moved into oneof",
+		old: `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			field { name: "b" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 oneof_index: 0 } oneof_decl { name: "o" } }`,
+		new: `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 oneof_index: 0 }
+			field { name: "b" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 oneof_index: 0 } oneof_decl { name: "o" } }`,
+		want: []string{breaking.FieldOneofChanged},
+	}, {
+		desc: "proto3 optional added",
+		old:  `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 } }`,
+		new: `message_type { name: "M" field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 oneof_index: 0 proto3_optional: true }
+			oneof_decl { name: "_a" } }`,
+	}, {
+		desc: "map changed to repeated entries",
+		old: `message_type { name: "M" field { name: "m" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".test.M.MEntry" }
+			nested_type { name: "MEntry" options { map_entry: true }
+				field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+				field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 } } }
+			message_type { name: "Entry" field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+				field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_INT64 } }`,
+		new: `message_type { name: "M" field { name: "m" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".test.Entry" } }
+			message_type { name: "Entry" field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+				field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_INT64 } }`,
+		json: true,
+		want: []string{breaking.FieldJSONTypeChanged},
+	}, {
+		desc: "enum values",
+		old: `enum_type { name: "E" value { name: "E_ZERO" number: 0 } value { name: "E_ONE" number: 1 }
+			value { name: "E_TWO" number: 2 } value { name: "E_THREE" number: 3 } }`,
+		new: `enum_type { name: "E" value { name: "E_ZERO" number: 0 } value { name: "E_UNO" number: 1 }
+			value { name: "E_DOS" number: 2 } reserved_range { start: 3 end: 3 } }`,
+		json: true,
+		want: []string{breaking.EnumValueNameChanged, breaking.EnumValueNameChanged},
+	}, {
+		desc: "enum value removed",
+		old:  `enum_type { name: "E" value { name: "E_ZERO" number: 0 } value { name: "E_ONE" number: 1 } }`,
+		new:  `enum_type { name: "E" value { name: "E_ZERO" number: 0 } }`,
+		want: []string{breaking.EnumValueRemoved},
+	}, {
+		desc: "types removed",
+		old: `message_type { name: "M" nested_type { name: "N" } enum_type { name: "E" value { name: "E_ZERO" number: 0 } } }
+			service { name: "S" }`,
+		new:  `message_type { name: "M" }`,
+		want: []string{breaking.EnumRemoved, breaking.MessageRemoved, breaking.ServiceRemoved},
+	}, {
+		desc: "methods",
+		old: `message_type { name: "A" } message_type { name: "B" } service { name: "S"
+			method { name: "Get" input_type: ".test.A" output_type: ".test.A" }
+			method { name: "List" input_type: ".test.A" output_type: ".test.A" server_streaming: true }
+			method { name: "Delete" input_type: ".test.A" output_type: ".test.A" } }`,
+		new: `message_type { name: "A" } message_type { name: "B" } service { name: "S"
+			method { name: "Get" input_type: ".test.A" output_type: ".test.B" }
+			method { name: "List" input_type: ".test.A" output_type: ".test.A" } }`,
+		want: []string{breaking.MethodRemoved, breaking.MethodTypeChanged, breaking.MethodStreamingChanged},
+	}}
+
+	for _, tt := range tests {
+		c := &breaking.Checker{JSON: tt.json, Ignore: tt.ignore}
+		findings, err := c.CompareSets(schema(t, proto3+tt.old), schema(t, proto3+tt.new))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+			continue
+		}
+		var got []string
+		for _, f := range findings {
+			got = append(got, f.Rule)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s: rules mismatch (-want +got):\n%s\nfindings: %v", tt.desc, diff, findings)
+		}
+	}
+}
+
+func TestCompareProto2(t *testing.T) {
+	oldSet := schema(t, `syntax: "proto2" message_type { name: "M"
+		field { name: "a" number: 1 label: LABEL_REQUIRED type: TYPE_INT32 }
+		field { name: "b" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 } }`)
+	newSet := schema(t, `syntax: "proto2" message_type { name: "M"
+		field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+		field { name: "b" number: 2 label: LABEL_REQUIRED type: TYPE_INT32 }
+		field { name: "c" number: 3 label: LABEL_REQUIRED type: TYPE_INT32 } }`)
+	findings, err := new(breaking.Checker).CompareSets(oldSet, newSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Element+" "+f.Rule)
+	}
+	want := []string{
+		"test.M.a " + breaking.FieldRequiredRemoved,
+		"test.M.b " + breaking.FieldRequiredAdded,
+		"test.M.c " + breaking.FieldRequiredAdded,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("findings mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCompareExtensions(t *testing.T) {
+	const messages = `syntax: "proto2"
+		message_type { name: "A" extension_range { start: 100 end: 200 } }
+		message_type { name: "B" extension_range { start: 100 end: 200 } } `
+	tests := []struct {
+		desc     string
+		old, new string
+		want     []string
+	}{{
+		desc: "no changes",
+		old:  `extension { name: "x" number: 100 label: LABEL_OPTIONAL type: TYPE_INT32 extendee: ".test.A" }`,
+		new:  `extension { name: "x" number: 100 label: LABEL_OPTIONAL type: TYPE_INT32 extendee: ".test.A" }`,
+	}, {
+		desc: "number changed",
+		old:  `extension { name: "x" number: 100 label: LABEL_OPTIONAL type: TYPE_INT32 extendee: ".test.A" }`,
+		new:  `extension { name: "x" number: 101 label: LABEL_OPTIONAL type: TYPE_INT32 extendee: ".test.A" }`,
+		want: []string{"test.x " + breaking.ExtensionNumberChanged},
+	}, {
+		desc: "extendee changed",
+		old:  `extension { name: "x" number: 100 label: LABEL_OPTIONAL type: TYPE_INT32 extendee: ".test.A" }`,
+		new:  `extension { name: "x" number: 100 label: LABEL_OPTIONAL type: TYPE_INT32 extendee: ".test.B" }`,
+		want: []string{"test.x " + breaking.ExtensionExtendeeChanged},
+	}, {
+		desc: "nested extension changed",
+		old:  `message_type { name: "M" extension { name: "x" number: 100 label: LABEL_OPTIONAL type: TYPE_INT32 extendee: ".test.A" } }`,
+		new:  `message_type { name: "M" extension { name: "x" number: 101 label: LABEL_OPTIONAL type: TYPE_STRING extendee: ".test.B" } }`,
+		want: []string{
+			"test.M.x " + breaking.ExtensionExtendeeChanged,
+			"test.M.x " + breaking.ExtensionNumberChanged,
+			"test.M.x " + breaking.FieldTypeChanged,
+		},
+	}}
+
+	for _, tt := range tests {
+		findings, err := new(breaking.Checker).CompareSets(schema(t, messages+tt.old), schema(t, messages+tt.new))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+			continue
+		}
+		var got []string
+		for _, f := range findings {
+			got = append(got, f.Element+" "+f.Rule)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s: findings mismatch (-want +got):\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func TestFindingLocation(t *testing.T) {
+	oldSet := schema(t, proto3+`message_type { name: "M"
+		field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+		field { name: "b" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 } }`)
+	newSet := schema(t, proto3+`message_type { name: "M"
+		field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING } }
+		source_code_info {
+			location { path: [4, 0] span: [4, 0, 7, 1] }
+			location { path: [4, 0, 2, 0] span: [5, 2, 18] }
+		}`)
+	findings, err := new(breaking.Checker).CompareSets(oldSet, newSet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.String())
+	}
+	want := []string{
+		"test.proto:5:1: FIELD_REMOVED: field test.M.b with number 2 was removed without reserving its number",
+		"test.proto:6:3: FIELD_TYPE_CHANGED: field test.M.a changed type from int32 to string",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("findings mismatch (-want +got):\n%s", diff)
+	}
+}
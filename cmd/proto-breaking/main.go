@@ -0,0 +1,134 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// proto-breaking reports changes between two versions of a protocol buffer
+// schema that break compatibility with the old version.
+//
+// Each version is a FileDescriptorSet holding all the files of the schema
+// and their dependencies, as produced by:
+//
+//	protoc --include_imports --include_source_info -o schema.pb path/to/*.proto
+//
+// It is invoked as:
+//
+//	proto-breaking [-json_rules] [-ignore=RULE,...] [-format=text|json] old.pb new.pb
+//
+// The exit status is 1 if any breaking change is found, and 2 on error.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/breaking"
+	"github.com/golang/protobuf/proto"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func main() {
+	var (
+		checkJSON = flag.Bool("json_rules", false, "also report changes that only break the JSON format")
+		ignore    = flag.String("ignore", "", "comma-separated list of rules to ignore")
+		format    = flag.String("format", "text", "output format (text or json)")
+		listRules = flag.Bool("rules", false, "list the rules and exit")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: proto-breaking [flags] old.pb new.pb\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *listRules {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		for _, r := range breaking.Rules {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", r.ID, r.Category, r.Description)
+		}
+		tw.Flush()
+		return
+	}
+	if flag.NArg() != 2 || (*format != "text" && *format != "json") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var ignored []string
+	if *ignore != "" {
+		ignored = strings.Split(*ignore, ",")
+		if err := checkRuleIDs(ignored); err != nil {
+			fatalf("-ignore: %v", err)
+		}
+	}
+
+	oldSet, err := readSet(flag.Arg(0))
+	if err != nil {
+		fatalf("%v", err)
+	}
+	newSet, err := readSet(flag.Arg(1))
+	if err != nil {
+		fatalf("%v", err)
+	}
+	c := &breaking.Checker{JSON: *checkJSON, Ignore: ignored}
+	findings, err := c.CompareSets(oldSet, newSet)
+	if err != nil {
+		fatalf("%v", err)
+	}
+
+	switch *format {
+	case "json":
+		if findings == nil {
+			findings = []breaking.Finding{}
+		}
+		b, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			fatalf("%v", err)
+		}
+		fmt.Printf("%s\n", b)
+	default:
+		for _, f := range findings {
+			fmt.Println(f)
+		}
+	}
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
+
+// checkRuleIDs reports an error if ids holds an unknown rule ID.
+func checkRuleIDs(ids []string) error {
+	var valid []string
+	known := make(map[string]bool)
+	for _, r := range breaking.Rules {
+		valid = append(valid, r.ID)
+		known[r.ID] = true
+	}
+	for _, id := range ids {
+		if !known[id] {
+			return fmt.Errorf("unknown rule %q (valid rules: %s)", id, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
+func readSet(path string) (*descriptorpb.FileDescriptorSet, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fds := new(descriptorpb.FileDescriptorSet)
+	if err := proto.Unmarshal(b, fds); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return fds, nil
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "proto-breaking: "+format+"\n", args...)
+	os.Exit(2)
+}
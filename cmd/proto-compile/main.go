@@ -0,0 +1,151 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// proto-compile parses .proto files without protoc, writing their
+// descriptors or running a code generator plugin on them.
+//
+// It is invoked as:
+//
+//	proto-compile [-I=DIR ...] [-include_imports] [-include_source_info] -o=set.pb FILE.proto...
+//	proto-compile [-I=DIR ...] -plugin=protoc-gen-go [-param=PARAMETER] [-out=DIR] FILE.proto...
+//
+// The -I flags name the directories searched for the files and their
+// imports, as protoc's --proto_path. The -o flag writes a FileDescriptorSet,
+// as protoc's --descriptor_set_out. The -plugin flag runs a plugin,
+// which is passed the request protoc would pass it, and writes the files
+// it generates in the -out directory.
+//
+// Errors in the files are reported as protoc reports them.
+// The exit status is 2 on error.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoparse"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// pathList is a flag which may be given several times.
+type pathList []string
+
+func (l *pathList) String() string     { return strings.Join(*l, string(filepath.ListSeparator)) }
+func (l *pathList) Set(s string) error { *l = append(*l, s); return nil }
+
+func main() {
+	var (
+		importPaths       pathList
+		out               = flag.String("o", "", "write a FileDescriptorSet to the named file")
+		includeImports    = flag.Bool("include_imports", false, "include the imported files in the FileDescriptorSet")
+		includeSourceInfo = flag.Bool("include_source_info", false, "include the source code info in the FileDescriptorSet")
+		pluginPath        = flag.String("plugin", "", "run the named plugin")
+		param             = flag.String("param", "", "parameter passed to the plugin")
+		outDir            = flag.String("out", ".", "directory of the files generated by the plugin")
+	)
+	flag.Var(&importPaths, "I", "directory searched for imports (may be repeated)")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: proto-compile [flags] FILE.proto...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || (*out == "") == (*pluginPath == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	p := &protoparse.Parser{
+		ImportPaths:           importPaths,
+		IncludeSourceCodeInfo: *includeSourceInfo,
+	}
+	if *out != "" {
+		fds := new(descriptorpb.FileDescriptorSet)
+		var err error
+		if *includeImports {
+			fds, err = p.ParseFileSet(flag.Args()...)
+		} else {
+			fds.File, err = p.ParseFiles(flag.Args()...)
+		}
+		check(err)
+		b, err := proto.Marshal(fds)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		if err := ioutil.WriteFile(*out, b, 0666); err != nil {
+			fatalf("%v", err)
+		}
+		return
+	}
+
+	req, err := p.CodeGeneratorRequest(*param, flag.Args()...)
+	check(err)
+	resp, err := run(*pluginPath, req)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	if resp.Error != nil {
+		fatalf("%s: %s", *pluginPath, resp.GetError())
+	}
+	for _, f := range resp.File {
+		if f.GetInsertionPoint() != "" {
+			fatalf("%s: insertion points are not supported", f.GetName())
+		}
+		name := filepath.Join(*outDir, filepath.FromSlash(f.GetName()))
+		if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+			fatalf("%v", err)
+		}
+		if err := ioutil.WriteFile(name, []byte(f.GetContent()), 0666); err != nil {
+			fatalf("%v", err)
+		}
+	}
+}
+
+// run runs a plugin, passing it a request and returning its response.
+func run(path string, req *plugin.CodeGeneratorRequest) (*plugin.CodeGeneratorResponse, error) {
+	in, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var stdout bytes.Buffer
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	resp := new(plugin.CodeGeneratorResponse)
+	if err := proto.Unmarshal(stdout.Bytes(), resp); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return resp, nil
+}
+
+// check exits if parsing failed, reporting the errors in the files
+// as protoc does.
+func check(err error) {
+	if errs, ok := err.(protoparse.ErrorList); ok {
+		for _, e := range errs {
+			fmt.Fprintln(os.Stderr, e)
+		}
+		os.Exit(2)
+	}
+	if err != nil {
+		fatalf("%v", err)
+	}
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "proto-compile: "+format+"\n", args...)
+	os.Exit(2)
+}
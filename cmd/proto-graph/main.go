@@ -0,0 +1,137 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// proto-graph analyzes the references between the declarations of a protocol
+// buffer schema.
+//
+// The schema is a FileDescriptorSet holding all the files of the schema
+// and their dependencies, as produced by:
+//
+//	protoc --include_imports --include_source_info -o schema.pb path/to/*.proto
+//
+// It is invoked as:
+//
+//	proto-graph [-format=report|dot|json] [-exclude=PREFIX,...] schema.pb
+//	proto-graph -refs=NAME schema.pb
+//
+// The report format lists the unused imports, the orphaned messages
+// and the recursive types of the schema, except for those declared in files
+// whose paths start with an excluded prefix. The dot and json formats
+// write the whole graph. The -refs flag lists the declarations referencing
+// the type with the given full name.
+//
+// The exit status is 2 on error.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/schemagraph"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func main() {
+	var (
+		format  = flag.String("format", "report", "output format (report, dot or json)")
+		exclude = flag.String("exclude", "google/protobuf/", "comma-separated list of path prefixes of the files excluded from the report")
+		refs    = flag.String("refs", "", "list the references to the type with the given full name")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: proto-graph [flags] schema.pb\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || (*format != "report" && *format != "dot" && *format != "json") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	b, err := ioutil.ReadFile(flag.Arg(0))
+	if err != nil {
+		fatalf("%v", err)
+	}
+	fds := new(descriptorpb.FileDescriptorSet)
+	if err := proto.Unmarshal(b, fds); err != nil {
+		fatalf("%s: %v", flag.Arg(0), err)
+	}
+	g, err := schemagraph.FromSet(fds)
+	if err != nil {
+		fatalf("%v", err)
+	}
+
+	if *refs != "" {
+		if _, ok := g.Node(*refs); !ok {
+			fatalf("%s not found", *refs)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		for _, e := range g.References(*refs) {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", e.From, e.Kind, e.Via)
+		}
+		tw.Flush()
+		return
+	}
+
+	switch *format {
+	case "dot":
+		err = g.WriteDOT(os.Stdout)
+	case "json":
+		err = g.WriteJSON(os.Stdout)
+	default:
+		var prefixes []string
+		if *exclude != "" {
+			prefixes = strings.Split(*exclude, ",")
+		}
+		err = report(g, prefixes)
+	}
+	if err != nil {
+		fatalf("%v", err)
+	}
+}
+
+// report prints the unused imports, orphaned messages and recursive types
+// of the files whose paths do not start with any of the prefixes.
+func report(g *schemagraph.Graph, prefixes []string) error {
+	excluded := func(file string) bool {
+		for _, p := range prefixes {
+			if strings.HasPrefix(file, p) {
+				return true
+			}
+		}
+		return false
+	}
+
+	unused, err := g.UnusedImports()
+	if err != nil {
+		return err
+	}
+	for _, u := range unused {
+		if !excluded(u.File) {
+			fmt.Println(u)
+		}
+	}
+	for _, name := range g.Orphans() {
+		if n, _ := g.Node(name); !excluded(n.File) {
+			fmt.Printf("%s: orphaned message %s\n", n.File, name)
+		}
+	}
+	for _, group := range g.Recursive() {
+		if n, _ := g.Node(group[0]); excluded(n.File) {
+			continue
+		}
+		fmt.Printf("recursive types: %s\n", strings.Join(group, ", "))
+	}
+	return nil
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "proto-graph: "+format+"\n", args...)
+	os.Exit(2)
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// proto-lint checks a protocol buffer schema for style problems and for
+// declarations that are unsafe to generate Go code for.
+//
+// The schema is a FileDescriptorSet holding the files to check and their
+// dependencies, as produced by:
+//
+//	protoc --include_imports --include_source_info -o schema.pb path/to/*.proto
+//
+// It is invoked as:
+//
+//	proto-lint [-disable=RULE,...] [-format=text|json] schema.pb [FILE.proto...]
+//
+// If files are named, only they are checked. Otherwise all the files of
+// the set are. Findings are suppressed by lint:ignore comments, as described
+// in the documentation of the lint package.
+//
+// The exit status is 1 if any finding of error severity is reported, and
+// 2 on error.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/lint"
+	"github.com/golang/protobuf/proto"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func main() {
+	var (
+		disable   = flag.String("disable", "", "comma-separated list of rules to disable")
+		format    = flag.String("format", "text", "output format (text or json)")
+		listRules = flag.Bool("rules", false, "list the rules and exit")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: proto-lint [flags] schema.pb [FILE.proto...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *listRules {
+		tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		for _, r := range lint.Rules {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", r.ID, r.Severity, r.Description)
+		}
+		tw.Flush()
+		return
+	}
+	if flag.NArg() < 1 || (*format != "text" && *format != "json") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	b, err := ioutil.ReadFile(flag.Arg(0))
+	if err != nil {
+		fatalf("%v", err)
+	}
+	set := new(descriptorpb.FileDescriptorSet)
+	if err := proto.Unmarshal(b, set); err != nil {
+		fatalf("%s: %v", flag.Arg(0), err)
+	}
+	l := new(lint.Linter)
+	if *disable != "" {
+		l.Disable = strings.Split(*disable, ",")
+	}
+	findings, err := l.LintSet(set, flag.Args()[1:]...)
+	if err != nil {
+		fatalf("%v", err)
+	}
+
+	switch *format {
+	case "json":
+		if findings == nil {
+			findings = []lint.Finding{}
+		}
+		b, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			fatalf("%v", err)
+		}
+		fmt.Printf("%s\n", b)
+	default:
+		for _, f := range findings {
+			fmt.Println(f)
+		}
+	}
+	for _, f := range findings {
+		if f.Severity == lint.Error {
+			os.Exit(1)
+		}
+	}
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "proto-lint: "+format+"\n", args...)
+	os.Exit(2)
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// protoc-gen-doc is a plugin for the Google protocol buffer compiler to
+// generate documentation of .proto files. Install it by building this
+// program and making it accessible within your PATH with the name:
+//
+//	protoc-gen-doc
+//
+// It is invoked as:
+//
+//	protoc --doc_out=format=html,paths=source_relative:. path/to/file.proto
+//
+// This generates a page for each file, in Markdown (format=markdown, the
+// default) or HTML (format=html), written to path/to/file.md or
+// path/to/file.html. The page documents the messages, enums, extensions
+// and services of the file with their comments, with field tables giving
+// the type, label, number and JSON name of each field.
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/golang/protobuf/internal/gendoc"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func main() {
+	var (
+		flags  flag.FlagSet
+		format = flags.String("format", string(gendoc.Markdown), "format of the documentation (markdown or html)")
+	)
+	protogen.Options{
+		ParamFunc: flags.Set,
+	}.Run(func(gen *protogen.Plugin) error {
+		f := gendoc.Format(*format)
+		if f != gendoc.Markdown && f != gendoc.HTML {
+			return fmt.Errorf("protoc-gen-doc: unknown format %q", *format)
+		}
+		for _, file := range gen.Files {
+			if file.Generate {
+				gendoc.GenerateFile(gen, file, f)
+			}
+		}
+		return nil
+	})
+}
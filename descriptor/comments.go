@@ -0,0 +1,178 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// Comments are the comments attached to a declaration in its .proto source.
+// Each comment retains the text between the comment markers, including
+// any leading spaces and the trailing newline.
+type Comments struct {
+	// Leading is the comment immediately preceding the declaration.
+	Leading string
+
+	// Trailing is the comment immediately following the declaration,
+	// on the same line or on the next one.
+	Trailing string
+
+	// LeadingDetached are the comments preceding the declaration
+	// that are separated from it and from each other by blank lines.
+	LeadingDetached []string
+}
+
+// SourceComments returns the comments attached to the declaration d.
+// It reports false if the source information of the file declaring d
+// is unavailable or has no location for d.
+//
+// The source information is obtained from the file descriptor itself,
+// from the source information registered with RegisterSourceCodeInfo,
+// or from the raw descriptor registered with proto.RegisterFile.
+// Generated code only provides it if protoc-gen-go is run with
+// the source_code_info parameter.
+func SourceComments(d protoreflect.Descriptor) (Comments, bool) {
+	fd := d.ParentFile()
+	path, ok := sourcePath(d)
+	if fd == nil || !ok {
+		return Comments{}, false
+	}
+	if loc := fd.SourceLocations().ByPath(path); loc.Path != nil {
+		return Comments{
+			Leading:         loc.LeadingComments,
+			Trailing:        loc.TrailingComments,
+			LeadingDetached: loc.LeadingDetachedComments,
+		}, true
+	}
+	loc := sourceLocations(fd.Path())[pathKey(path)]
+	if loc == nil {
+		return Comments{}, false
+	}
+	return Comments{
+		Leading:         loc.GetLeadingComments(),
+		Trailing:        loc.GetTrailingComments(),
+		LeadingDetached: loc.LeadingDetachedComments,
+	}, true
+}
+
+// RegisterSourceCodeInfo is called from generated code to register
+// the compressed SourceCodeInfo of the proto source file with the given path,
+// which is not retained by the raw descriptor of the file.
+func RegisterSourceCodeInfo(path string, gzipped []byte) {
+	sourceInfoGZIP.Store(path, gzipped)
+	sourceIndexes.Delete(path)
+}
+
+var (
+	sourceInfoGZIP sync.Map // map[string][]byte
+	sourceIndexes  sync.Map // map[string]map[string]*descriptorpb.SourceCodeInfo_Location
+)
+
+// sourceLocations returns the source locations of the file with the given
+// path, indexed by pathKey, or nil if its source information is unavailable.
+func sourceLocations(path string) map[string]*descriptorpb.SourceCodeInfo_Location {
+	if v, ok := sourceIndexes.Load(path); ok {
+		return v.(map[string]*descriptorpb.SourceCodeInfo_Location)
+	}
+
+	var info *descriptorpb.SourceCodeInfo
+	if v, ok := sourceInfoGZIP.Load(path); ok {
+		var err error
+		if info, err = decompressSourceCodeInfo(v.([]byte)); err != nil {
+			panic(fmt.Sprintf("descriptor: invalid source information for %q: %v", path, err))
+		}
+	} else if rawDesc := proto.FileDescriptor(path); len(rawDesc) > 0 {
+		info = deriveFileDescriptor(rawDesc).GetSourceCodeInfo()
+	}
+
+	var index map[string]*descriptorpb.SourceCodeInfo_Location
+	if locs := info.GetLocation(); len(locs) > 0 {
+		index = make(map[string]*descriptorpb.SourceCodeInfo_Location)
+		for _, loc := range locs {
+			// The first location of a path is that of the declaration.
+			if k := pathKey(loc.Path); index[k] == nil {
+				index[k] = loc
+			}
+		}
+	}
+	v, _ := sourceIndexes.LoadOrStore(path, index)
+	return v.(map[string]*descriptorpb.SourceCodeInfo_Location)
+}
+
+func decompressSourceCodeInfo(gzipped []byte) (*descriptorpb.SourceCodeInfo, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(gzipped))
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	info := new(descriptorpb.SourceCodeInfo)
+	if err := proto.Unmarshal(b, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// sourcePath returns the path of the declaration d within the
+// FileDescriptorProto of its file, as used by SourceCodeInfo.
+func sourcePath(d protoreflect.Descriptor) (protoreflect.SourcePath, bool) {
+	var path []int32
+	for {
+		parent := d.Parent()
+		var tag int32
+		switch d := d.(type) {
+		case protoreflect.FileDescriptor:
+			// Reverse the path since it was constructed in reverse.
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path, len(path) > 0
+		case protoreflect.MessageDescriptor:
+			tag = fileMessageTag
+			if _, ok := parent.(protoreflect.MessageDescriptor); ok {
+				tag = messageNestedTag
+			}
+		case protoreflect.FieldDescriptor:
+			tag = messageFieldTag
+			if d.IsExtension() {
+				tag = messageExtensionTag
+				if _, ok := parent.(protoreflect.FileDescriptor); ok {
+					tag = fileExtensionTag
+				}
+			}
+		case protoreflect.OneofDescriptor:
+			tag = messageOneofTag
+		case protoreflect.EnumDescriptor:
+			tag = fileEnumTag
+			if _, ok := parent.(protoreflect.MessageDescriptor); ok {
+				tag = messageEnumTag
+			}
+		case protoreflect.EnumValueDescriptor:
+			tag = enumValueTag
+		case protoreflect.ServiceDescriptor:
+			tag = fileServiceTag
+		case protoreflect.MethodDescriptor:
+			tag = serviceMethodTag
+		default:
+			return nil, false
+		}
+		if parent == nil {
+			return nil, false
+		}
+		path = append(path, int32(d.Index()), tag)
+		d = parent
+	}
+}
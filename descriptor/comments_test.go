@@ -0,0 +1,154 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/runtime/protoimpl"
+
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestSourceComments(t *testing.T) {
+	fd := parseFile(t, `
+		name: "comments_test/test.proto"
+		package: "comments.test"
+		message_type {
+			name: "Outer"
+			field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 oneof_index: 0 }
+			field { name: "b" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			nested_type { name: "Inner" }
+			enum_type { name: "Kind" value { name: "KIND_UNKNOWN" number: 0 } }
+			extension_range { start: 100 end: 200 }
+			extension { name: "inner_ext" number: 100 label: LABEL_OPTIONAL type: TYPE_INT32 extendee: ".comments.test.Outer" }
+			oneof_decl { name: "choice" }
+		}
+		enum_type { name: "Color" value { name: "RED" number: 0 } value { name: "GREEN" number: 1 } }
+		service {
+			name: "Service"
+			method { name: "Do" input_type: ".comments.test.Outer" output_type: ".comments.test.Outer" }
+		}
+		extension { name: "outer_ext" number: 101 label: LABEL_OPTIONAL type: TYPE_INT32 extendee: ".comments.test.Outer" }
+		source_code_info {
+			location { path: [4, 0] span: [3, 0, 20, 1] leading_comments: " Outer.\n" leading_detached_comments: " Detached.\n" }
+			location { path: [4, 0, 2, 0] span: [5, 2, 16] trailing_comments: " a.\n" }
+			location { path: [4, 0, 2, 1] span: [6, 2, 16] leading_comments: " b.\n" }
+			location { path: [4, 0, 8, 0] span: [7, 2, 9, 3] leading_comments: " choice.\n" }
+			location { path: [4, 0, 3, 0] span: [10, 2, 17] leading_comments: " Inner.\n" }
+			location { path: [4, 0, 4, 0] span: [11, 2, 13, 3] leading_comments: " Kind.\n" }
+			location { path: [4, 0, 4, 0, 2, 0] span: [12, 4, 20] leading_comments: " KIND_UNKNOWN.\n" }
+			location { path: [4, 0, 6, 0] span: [14, 2, 30] leading_comments: " inner_ext.\n" }
+			location { path: [5, 0] span: [21, 0, 23, 1] leading_comments: " Color.\n" }
+			location { path: [5, 0, 2, 0] span: [22, 2, 10] leading_comments: " RED.\n" }
+			location { path: [6, 0] span: [24, 0, 26, 1] leading_comments: " Service.\n" }
+			location { path: [6, 0, 2, 0] span: [25, 2, 30] leading_comments: " Do.\n" }
+			location { path: [7, 0] span: [27, 0, 30] leading_comments: " outer_ext.\n" }
+		}
+	`)
+	tests := []struct {
+		name protoreflect.FullName
+		want *Comments // nil if no comments are found
+	}{
+		{"comments.test.Outer", &Comments{Leading: " Outer.\n", LeadingDetached: []string{" Detached.\n"}}},
+		{"comments.test.Outer.a", &Comments{Trailing: " a.\n"}},
+		{"comments.test.Outer.b", &Comments{Leading: " b.\n"}},
+		{"comments.test.Outer.choice", &Comments{Leading: " choice.\n"}},
+		{"comments.test.Outer.Inner", &Comments{Leading: " Inner.\n"}},
+		{"comments.test.Outer.Kind", &Comments{Leading: " Kind.\n"}},
+		{"comments.test.Outer.KIND_UNKNOWN", &Comments{Leading: " KIND_UNKNOWN.\n"}},
+		{"comments.test.Outer.inner_ext", &Comments{Leading: " inner_ext.\n"}},
+		{"comments.test.Color", &Comments{Leading: " Color.\n"}},
+		{"comments.test.RED", &Comments{Leading: " RED.\n"}},
+		{"comments.test.GREEN", nil},
+		{"comments.test.Service", &Comments{Leading: " Service.\n"}},
+		{"comments.test.Service.Do", &Comments{Leading: " Do.\n"}},
+		{"comments.test.outer_ext", &Comments{Leading: " outer_ext.\n"}},
+	}
+
+	check := func(desc string, files *protoregistry.Files) {
+		for _, tt := range tests {
+			d, err := files.FindDescriptorByName(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := SourceComments(d)
+			if tt.want == nil {
+				if ok {
+					t.Errorf("%s: SourceComments(%v) = %+v, want none", desc, tt.name, got)
+				}
+				continue
+			}
+			if !ok {
+				t.Errorf("%s: SourceComments(%v) found no comments", desc, tt.name)
+				continue
+			}
+			if diff := cmp.Diff(*tt.want, got); diff != "" {
+				t.Errorf("%s: SourceComments(%v) mismatch (-want +got):\n%s", desc, tt.name, diff)
+			}
+		}
+	}
+	newFiles := func(fd *descpb.FileDescriptorProto) *protoregistry.Files {
+		file, err := protodesc.NewFile(fd, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files := new(protoregistry.Files)
+		if err := files.RegisterFile(file); err != nil {
+			t.Fatal(err)
+		}
+		return files
+	}
+
+	check("descriptor", newFiles(fd))
+
+	// Strip the source information from the descriptor and register it instead.
+	info := fd.SourceCodeInfo
+	fd.SourceCodeInfo = nil
+	fd.Name = proto.String("comments_test/registered.proto")
+	files := newFiles(fd)
+	outer, err := files.FindDescriptorByName("comments.test.Outer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := SourceComments(outer); ok {
+		t.Errorf("SourceComments() found comments before they were registered")
+	}
+	b, err := proto.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	RegisterSourceCodeInfo(fd.GetName(), protoimpl.X.CompressGZIP(b))
+	check("registered", files)
+}
+
+func TestSourceCommentsUnavailable(t *testing.T) {
+	// The generated test files are built without source information.
+	d := proto.MessageReflect(&descpb.FileDescriptorProto{}).Descriptor()
+	if got, ok := SourceComments(d); ok {
+		t.Errorf("SourceComments(%v) = %+v, want none", d.FullName(), got)
+	}
+	if _, ok := SourceComments(d.ParentFile()); ok {
+		t.Errorf("SourceComments() reported comments for a file")
+	}
+}
+
+func TestSourceCommentsRegisteredFile(t *testing.T) {
+	// The raw descriptor of descriptor_test/fileset.proto retains its source information.
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName("descriptor_test.Holder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := SourceComments(d)
+	want := Comments{Leading: " Holder holds things.\n"}
+	if diff := cmp.Diff(want, got); !ok || diff != "" {
+		t.Errorf("SourceComments(%v) = %+v, %v, want %+v, true", d.FullName(), got, ok, want)
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/internal/dynamicext"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// An Envelope bundles a marshaled message with the schema needed to decode
+// it, so that it remains readable without the Go types of the message.
+//
+// The schema is either embedded in the envelope or referenced by its hash,
+// in which case it is obtained from a SchemaStore. An envelope is encoded
+// as the following message:
+//
+//	message Envelope {
+//	  string type_name = 1;                          // full name of the message
+//	  bytes payload = 2;                             // marshaled message
+//	  google.protobuf.FileDescriptorSet schema = 3;  // optional
+//	  bytes schema_hash = 4;                         // see SchemaHash
+//	}
+type Envelope struct {
+	TypeName   string
+	Payload    []byte
+	Schema     *descriptorpb.FileDescriptorSet
+	SchemaHash []byte
+}
+
+// Field numbers of the envelope format.
+const (
+	envelopeTypeNameNumber   = 1
+	envelopePayloadNumber    = 2
+	envelopeSchemaNumber     = 3
+	envelopeSchemaHashNumber = 4
+)
+
+// A SchemaStore stores schemas referenced by envelopes by their hash.
+type SchemaStore interface {
+	StoreSchema(hash []byte, fds *descriptorpb.FileDescriptorSet) error
+	FindSchema(hash []byte) (*descriptorpb.FileDescriptorSet, error)
+}
+
+// MemorySchemaStore is a SchemaStore that holds schemas in memory.
+// The zero value is ready to use.
+type MemorySchemaStore struct {
+	mu      sync.Mutex
+	schemas map[string]*descriptorpb.FileDescriptorSet
+}
+
+// StoreSchema stores the schema fds with the given hash.
+func (s *MemorySchemaStore) StoreSchema(hash []byte, fds *descriptorpb.FileDescriptorSet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.schemas == nil {
+		s.schemas = make(map[string]*descriptorpb.FileDescriptorSet)
+	}
+	s.schemas[string(hash)] = fds
+	return nil
+}
+
+// FindSchema returns the schema with the given hash.
+func (s *MemorySchemaStore) FindSchema(hash []byte) (*descriptorpb.FileDescriptorSet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if fds, ok := s.schemas[string(hash)]; ok {
+		return fds, nil
+	}
+	return nil, fmt.Errorf("descriptor: schema %x not found", hash)
+}
+
+// SchemaHash returns the SHA-256 hash of the deterministic
+// binary encoding of fds.
+func SchemaHash(fds *descriptorpb.FileDescriptorSet) ([]byte, error) {
+	b, err := protoV2.MarshalOptions{Deterministic: true}.Marshal(fds)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b)
+	return sum[:], nil
+}
+
+// EnvelopeOptions configures the creation of envelopes.
+type EnvelopeOptions struct {
+	// IncludeSourceCodeInfo specifies whether the schema retains
+	// the SourceCodeInfo of its files, when available.
+	IncludeSourceCodeInfo bool
+
+	// Schemas, if not nil, receives the schema of each wrapped message,
+	// which is then referenced by hash instead of embedded in the envelope.
+	Schemas SchemaStore
+}
+
+// Wrap returns an envelope holding the message m and its schema:
+// the file declaring the message and its transitive dependencies.
+func (o EnvelopeOptions) Wrap(m proto.Message) (*Envelope, error) {
+	md := proto.MessageReflect(m).Descriptor()
+	fds, err := FileSetOptions{IncludeSourceCodeInfo: o.IncludeSourceCodeInfo}.ForDescriptor(md)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := SchemaHash(fds)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := proto.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	e := &Envelope{
+		TypeName:   string(md.FullName()),
+		Payload:    payload,
+		Schema:     fds,
+		SchemaHash: hash,
+	}
+	if o.Schemas != nil {
+		if err := o.Schemas.StoreSchema(hash, fds); err != nil {
+			return nil, err
+		}
+		e.Schema = nil
+	}
+	return e, nil
+}
+
+// Marshal returns the encoding of the envelope.
+func (e *Envelope) Marshal() ([]byte, error) {
+	var b []byte
+	b = protowire.AppendTag(b, envelopeTypeNameNumber, protowire.BytesType)
+	b = protowire.AppendString(b, e.TypeName)
+	b = protowire.AppendTag(b, envelopePayloadNumber, protowire.BytesType)
+	b = protowire.AppendBytes(b, e.Payload)
+	if e.Schema != nil {
+		schema, err := protoV2.MarshalOptions{Deterministic: true}.Marshal(e.Schema)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, envelopeSchemaNumber, protowire.BytesType)
+		b = protowire.AppendBytes(b, schema)
+	}
+	if len(e.SchemaHash) > 0 {
+		b = protowire.AppendTag(b, envelopeSchemaHashNumber, protowire.BytesType)
+		b = protowire.AppendBytes(b, e.SchemaHash)
+	}
+	return b, nil
+}
+
+// UnmarshalEnvelope parses the encoding of an envelope.
+// Unknown fields are ignored.
+func UnmarshalEnvelope(b []byte) (*Envelope, error) {
+	e := new(Envelope)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, errEnvelope(n)
+		}
+		b = b[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return nil, errEnvelope(n)
+			}
+			b = b[n:]
+			continue
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return nil, errEnvelope(n)
+		}
+		b = b[n:]
+		switch num {
+		case envelopeTypeNameNumber:
+			e.TypeName = string(v)
+		case envelopePayloadNumber:
+			e.Payload = append([]byte(nil), v...)
+		case envelopeSchemaNumber:
+			e.Schema = new(descriptorpb.FileDescriptorSet)
+			if err := proto.Unmarshal(v, e.Schema); err != nil {
+				return nil, fmt.Errorf("descriptor: invalid envelope schema: %v", err)
+			}
+		case envelopeSchemaHashNumber:
+			e.SchemaHash = append([]byte(nil), v...)
+		}
+	}
+	if e.TypeName == "" {
+		return nil, errors.New("descriptor: envelope has no type name")
+	}
+	return e, nil
+}
+
+func errEnvelope(n int) error {
+	return fmt.Errorf("descriptor: invalid envelope: %v", protowire.ParseError(n))
+}
+
+// Message decodes the payload of the envelope as a dynamic message,
+// which may be printed with the text format or the jsonpb package.
+// The store is used to find a schema referenced by hash, and may be nil
+// if the schema is embedded. An embedded schema must match the hash.
+func (e *Envelope) Message(store SchemaStore) (proto.Message, error) {
+	fds := e.Schema
+	switch {
+	case fds != nil && len(e.SchemaHash) > 0:
+		hash, err := SchemaHash(fds)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(hash, e.SchemaHash) {
+			return nil, errors.New("descriptor: envelope schema does not match its hash")
+		}
+	case fds == nil && len(e.SchemaHash) == 0:
+		return nil, errors.New("descriptor: envelope has no schema")
+	case fds == nil && store == nil:
+		return nil, fmt.Errorf("descriptor: envelope references schema %x, but no store was provided", e.SchemaHash)
+	case fds == nil:
+		var err error
+		if fds, err = store.FindSchema(e.SchemaHash); err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("descriptor: invalid envelope schema: %v", err)
+	}
+	d, err := files.FindDescriptorByName(protoreflect.FullName(e.TypeName))
+	if err != nil {
+		return nil, fmt.Errorf("descriptor: envelope type %v: %v", e.TypeName, err)
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("descriptor: envelope type %v is not a message", e.TypeName)
+	}
+
+	// Resolve the extensions declared in the schema.
+	types, err := dynamicext.Types(files)
+	if err != nil {
+		return nil, err
+	}
+
+	m := dynamicpb.NewMessage(md)
+	if err := (protoV2.UnmarshalOptions{Resolver: types}).Unmarshal(e.Payload, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	pb2 "github.com/golang/protobuf/internal/testprotos/proto2_proto"
+	pb3 "github.com/golang/protobuf/internal/testprotos/proto3_proto"
+)
+
+func TestEnvelope(t *testing.T) {
+	m2 := &pb2.MyMessage{
+		Count:     proto.Int32(42),
+		Name:      proto.String("Dave"),
+		Bikeshed:  pb2.MyMessage_GREEN.Enum(),
+		Somegroup: &pb2.MyMessage_SomeGroup{GroupField: proto.Int32(8)},
+	}
+	if err := proto.SetExtension(m2, pb2.E_Ext_More, &pb2.Ext{Data: proto.String("more")}); err != nil {
+		t.Fatal(err)
+	}
+	m3 := &pb3.Message{
+		Name:      "Rabbit",
+		Hilarity:  pb3.Message_PUNS,
+		Key:       []uint64{1, 2},
+		Nested:    &pb3.Nested{Bunny: "Monty", Cute: true},
+		Terrain:   map[string]*pb3.Nested{"meadow": {Bunny: "Flopsy"}},
+		StringMap: map[string]string{"a": "b"},
+	}
+
+	for _, m := range []proto.Message{m2, m3} {
+		e, err := EnvelopeOptions{}.Wrap(m)
+		if err != nil {
+			t.Fatalf("Wrap(%T) error: %v", m, err)
+		}
+		b, err := e.Marshal()
+		if err != nil {
+			t.Fatalf("Marshal() error: %v", err)
+		}
+		e, err = UnmarshalEnvelope(b)
+		if err != nil {
+			t.Fatalf("UnmarshalEnvelope() error: %v", err)
+		}
+		if e.Schema == nil {
+			t.Fatalf("envelope of %T has no embedded schema", m)
+		}
+		got, err := e.Message(nil)
+		if err != nil {
+			t.Fatalf("Message() error: %v", err)
+		}
+
+		if diff := cmp.Diff(proto.MarshalTextString(m), proto.MarshalTextString(got)); diff != "" {
+			t.Errorf("text of %T mismatch (-want +got):\n%s", m, diff)
+		}
+		want, err := new(jsonpb.Marshaler).MarshalToString(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gotJSON, err := new(jsonpb.Marshaler).MarshalToString(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, gotJSON); diff != "" {
+			t.Errorf("JSON of %T mismatch (-want +got):\n%s", m, diff)
+		}
+	}
+}
+
+func TestEnvelopeSchemaStore(t *testing.T) {
+	m := &pb3.Message{Name: "Rabbit", Nested: &pb3.Nested{Bunny: "Monty"}}
+	store := new(MemorySchemaStore)
+	e, err := EnvelopeOptions{Schemas: store}.Wrap(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := e.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err = UnmarshalEnvelope(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Schema != nil {
+		t.Errorf("envelope embeds the schema stored in a SchemaStore")
+	}
+	if _, err := e.Message(nil); err == nil {
+		t.Errorf("Message(nil) succeeded without a schema")
+	}
+	if _, err := e.Message(new(MemorySchemaStore)); err == nil {
+		t.Errorf("Message() succeeded with a store lacking the schema")
+	}
+	got, err := e.Message(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(proto.MarshalTextString(m), proto.MarshalTextString(got)); diff != "" {
+		t.Errorf("text mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEnvelopeErrors(t *testing.T) {
+	e, err := EnvelopeOptions{}.Wrap(&pb3.Nested{Bunny: "Monty"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.SchemaHash[0] ^= 0xff
+	if _, err := e.Message(nil); err == nil {
+		t.Errorf("Message() succeeded with a schema not matching its hash")
+	}
+
+	e.SchemaHash = nil
+	e.TypeName = "proto3_test.Missing"
+	if _, err := e.Message(nil); err == nil {
+		t.Errorf("Message() succeeded for an unknown type")
+	}
+
+	for _, b := range [][]byte{
+		{0x0a},             // truncated type name
+		{0x12, 0x01, 0x00}, // no type name
+	} {
+		if _, err := UnmarshalEnvelope(b); err == nil {
+			t.Errorf("UnmarshalEnvelope(%x) succeeded", b)
+		}
+	}
+}
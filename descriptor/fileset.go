@@ -0,0 +1,147 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// FileSetOptions configures the construction of a FileDescriptorSet
+// holding a file together with all of its transitive dependencies.
+//
+// The files of the set are topologically sorted so that every file
+// follows the files it imports, as expected by a protocol compiler
+// or a schema registry. The files are obtained from the descriptors
+// of generated types and the files registered with proto.RegisterFile.
+type FileSetOptions struct {
+	// IncludeSourceCodeInfo specifies whether the SourceCodeInfo
+	// of each file, when available, is retained in the set.
+	// By default it is stripped.
+	IncludeSourceCodeInfo bool
+}
+
+// ForMessage returns the set of files needed to describe the message m:
+// the file declaring it and all of its transitive dependencies.
+func (o FileSetOptions) ForMessage(m proto.GeneratedMessage) (*descriptorpb.FileDescriptorSet, error) {
+	rawDesc, _ := MessageRawDescriptor(m)
+	if rawDesc == nil {
+		return nil, fmt.Errorf("descriptor: no file descriptor for message %T", m)
+	}
+	return o.build(deriveFileDescriptor(rawDesc), findFile)
+}
+
+// ForEnum returns the set of files needed to describe the enum e:
+// the file declaring it and all of its transitive dependencies.
+func (o FileSetOptions) ForEnum(e proto.GeneratedEnum) (*descriptorpb.FileDescriptorSet, error) {
+	rawDesc, _ := EnumRawDescriptor(e)
+	if rawDesc == nil {
+		return nil, fmt.Errorf("descriptor: no file descriptor for enum %T", e)
+	}
+	return o.build(deriveFileDescriptor(rawDesc), findFile)
+}
+
+// ForFile returns the set of files needed to describe the file
+// with the given path: the file itself and all of its transitive dependencies.
+func (o FileSetOptions) ForFile(path string) (*descriptorpb.FileDescriptorSet, error) {
+	fd, err := findFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return o.build(fd, findFile)
+}
+
+// ForDescriptor returns the set of files needed to describe the declaration d:
+// the file declaring it and all of its transitive dependencies. The files are
+// derived from the descriptors linked to d, which need not be registered.
+func (o FileSetOptions) ForDescriptor(d protoreflect.Descriptor) (*descriptorpb.FileDescriptorSet, error) {
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if _, ok := files[fd.Path()]; ok || fd.IsPlaceholder() {
+			return
+		}
+		files[fd.Path()] = protodesc.ToFileDescriptorProto(fd)
+		for i := 0; i < fd.Imports().Len(); i++ {
+			add(fd.Imports().Get(i).FileDescriptor)
+		}
+	}
+	root := d.ParentFile()
+	if root == nil {
+		return nil, fmt.Errorf("descriptor: %v is not declared in a file", d.FullName())
+	}
+	add(root)
+	return o.build(files[root.Path()], func(path string) (*descriptorpb.FileDescriptorProto, error) {
+		if fd, ok := files[path]; ok {
+			return fd, nil
+		}
+		return nil, fmt.Errorf("descriptor: file %q is not linked", path)
+	})
+}
+
+// findFile returns the file descriptor proto for the file with the given path.
+func findFile(path string) (*descriptorpb.FileDescriptorProto, error) {
+	rawDesc := proto.FileDescriptor(path)
+	if len(rawDesc) == 0 {
+		return nil, fmt.Errorf("descriptor: file %q is not registered", path)
+	}
+	return deriveFileDescriptor(rawDesc), nil
+}
+
+// build returns the set of files holding fd and its transitive dependencies,
+// each of which is ordered after the files it imports. The dependencies
+// are found using find.
+func (o FileSetOptions) build(fd *descriptorpb.FileDescriptorProto, find func(path string) (*descriptorpb.FileDescriptorProto, error)) (*descriptorpb.FileDescriptorSet, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	fds := new(descriptorpb.FileDescriptorSet)
+	var visit func(fd *descriptorpb.FileDescriptorProto) error
+	visit = func(fd *descriptorpb.FileDescriptorProto) error {
+		state[fd.GetName()] = visiting
+		weak := make(map[int32]bool)
+		for _, i := range fd.WeakDependency {
+			weak[i] = true
+		}
+		for i, path := range fd.Dependency {
+			switch state[path] {
+			case visiting:
+				return fmt.Errorf("descriptor: import cycle through file %q", path)
+			case visited:
+				continue
+			}
+			dep, err := find(path)
+			if err != nil {
+				if weak[int32(i)] {
+					continue // weak dependencies need not be linked in
+				}
+				return fmt.Errorf("descriptor: dependency of %q: %v", fd.GetName(), err)
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[fd.GetName()] = visited
+
+		// The cached descriptors must not be mutated.
+		fd = proto.Clone(fd).(*descriptorpb.FileDescriptorProto)
+		if !o.IncludeSourceCodeInfo {
+			fd.SourceCodeInfo = nil
+		}
+		fds.File = append(fds.File, fd)
+		return nil
+	}
+	if err := visit(fd); err != nil {
+		return nil, err
+	}
+	return fds, nil
+}
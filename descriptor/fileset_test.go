@@ -0,0 +1,146 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/runtime/protoimpl"
+
+	pb3 "github.com/golang/protobuf/internal/testprotos/proto3_proto"
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func init() {
+	fd := &descpb.FileDescriptorProto{
+		Name:       proto.String("descriptor_test/fileset.proto"),
+		Package:    proto.String("descriptor_test"),
+		Dependency: []string{"proto3_proto/test.proto", "google/protobuf/descriptor.proto"},
+		MessageType: []*descpb.DescriptorProto{{
+			Name: proto.String("Holder"),
+			Field: []*descpb.FieldDescriptorProto{{
+				Name:     proto.String("message"),
+				Number:   proto.Int32(1),
+				Label:    descpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".proto3_test.Message"),
+			}, {
+				Name:     proto.String("file"),
+				Number:   proto.Int32(2),
+				Label:    descpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String(".google.protobuf.FileDescriptorProto"),
+			}},
+		}},
+		SourceCodeInfo: &descpb.SourceCodeInfo{
+			Location: []*descpb.SourceCodeInfo_Location{{
+				Path:            []int32{4, 0},
+				Span:            []int32{3, 0, 6, 1},
+				LeadingComments: proto.String(" Holder holds things.\n"),
+			}},
+		},
+	}
+	b, err := proto.Marshal(fd)
+	if err != nil {
+		panic(err)
+	}
+	proto.RegisterFile("descriptor_test/fileset.proto", protoimpl.X.CompressGZIP(b))
+}
+
+func fileNames(fds *descpb.FileDescriptorSet) []string {
+	var names []string
+	for _, fd := range fds.File {
+		names = append(names, fd.GetName())
+	}
+	return names
+}
+
+func TestFileSet(t *testing.T) {
+	tests := []struct {
+		desc  string
+		build func(FileSetOptions) (*descpb.FileDescriptorSet, error)
+		want  []string
+	}{{
+		desc: "message",
+		build: func(o FileSetOptions) (*descpb.FileDescriptorSet, error) {
+			return o.ForMessage(&pb3.Message{})
+		},
+		want: []string{
+			"google/protobuf/any.proto",
+			"proto2_proto/test.proto",
+			"proto3_proto/test.proto",
+		},
+	}, {
+		desc: "enum",
+		build: func(o FileSetOptions) (*descpb.FileDescriptorSet, error) {
+			return o.ForEnum(descpb.FieldDescriptorProto_Type(0))
+		},
+		want: []string{"google/protobuf/descriptor.proto"},
+	}, {
+		desc: "registered file",
+		build: func(o FileSetOptions) (*descpb.FileDescriptorSet, error) {
+			return o.ForFile("descriptor_test/fileset.proto")
+		},
+		want: []string{
+			"google/protobuf/any.proto",
+			"proto2_proto/test.proto",
+			"proto3_proto/test.proto",
+			"google/protobuf/descriptor.proto",
+			"descriptor_test/fileset.proto",
+		},
+	}}
+
+	for _, tt := range tests {
+		fds, err := tt.build(FileSetOptions{})
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, fileNames(fds)); diff != "" {
+			t.Errorf("%s: file mismatch (-want +got):\n%v", tt.desc, diff)
+		}
+		if _, err := protodesc.NewFiles(fds); err != nil {
+			t.Errorf("%s: protodesc.NewFiles error: %v", tt.desc, err)
+		}
+	}
+}
+
+func TestFileSetSourceCodeInfo(t *testing.T) {
+	const path = "descriptor_test/fileset.proto"
+	fds, err := FileSetOptions{}.ForFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fd := range fds.File {
+		if fd.SourceCodeInfo != nil {
+			t.Errorf("file %q: SourceCodeInfo not stripped", fd.GetName())
+		}
+	}
+
+	fds, err = FileSetOptions{IncludeSourceCodeInfo: true}.ForFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := fds.File[len(fds.File)-1]
+	if got := fd.GetSourceCodeInfo().GetLocation(); len(got) != 1 || got[0].GetLeadingComments() != " Holder holds things.\n" {
+		t.Errorf("SourceCodeInfo = %v, want the registered locations", fd.GetSourceCodeInfo())
+	}
+
+	// The returned files are copies that may be mutated.
+	fd.SourceCodeInfo = nil
+	fds, _ = FileSetOptions{IncludeSourceCodeInfo: true}.ForFile(path)
+	if fds.File[len(fds.File)-1].SourceCodeInfo == nil {
+		t.Errorf("mutation of a returned file changed the cached descriptor")
+	}
+}
+
+func TestFileSetNotFound(t *testing.T) {
+	if _, err := (FileSetOptions{}).ForFile("does/not/exist.proto"); err == nil {
+		t.Errorf("ForFile succeeded for an unregistered file")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// A StreamKind is the kind of streaming of a method.
+type StreamKind int
+
+const (
+	Unary StreamKind = iota
+	ClientStreaming
+	ServerStreaming
+	BidiStreaming
+)
+
+var streamKindNames = [...]string{
+	Unary:           "unary",
+	ClientStreaming: "client streaming",
+	ServerStreaming: "server streaming",
+	BidiStreaming:   "bidirectional streaming",
+}
+
+func (k StreamKind) String() string {
+	if k >= 0 && int(k) < len(streamKindNames) {
+		return streamKindNames[k]
+	}
+	return fmt.Sprintf("StreamKind(%d)", int(k))
+}
+
+// MethodInfo describes a method of a service.
+type MethodInfo struct {
+	// FullMethod is the full name of the method as used by gRPC,
+	// such as "/package.Service/Method".
+	FullMethod string
+
+	Descriptor protoreflect.MethodDescriptor
+
+	// Input and Output are the types of the request and response messages.
+	// They are the generated types if registered in protoregistry.GlobalTypes,
+	// and dynamic types otherwise.
+	Input, Output protoreflect.MessageType
+
+	StreamKind       StreamKind
+	IdempotencyLevel descriptorpb.MethodOptions_IdempotencyLevel
+	Deprecated       bool
+}
+
+// NewMethodInfo returns the description of a method.
+func NewMethodInfo(md protoreflect.MethodDescriptor) *MethodInfo {
+	m := &MethodInfo{
+		FullMethod: fmt.Sprintf("/%s/%s", md.Parent().FullName(), md.Name()),
+		Descriptor: md,
+		Input:      messageType(md.Input()),
+		Output:     messageType(md.Output()),
+	}
+	switch {
+	case md.IsStreamingClient() && md.IsStreamingServer():
+		m.StreamKind = BidiStreaming
+	case md.IsStreamingClient():
+		m.StreamKind = ClientStreaming
+	case md.IsStreamingServer():
+		m.StreamKind = ServerStreaming
+	}
+	if opts, ok := md.Options().(*descriptorpb.MethodOptions); ok {
+		m.IdempotencyLevel = opts.GetIdempotencyLevel()
+		m.Deprecated = opts.GetDeprecated()
+	}
+	return m
+}
+
+func messageType(md protoreflect.MessageDescriptor) protoreflect.MessageType {
+	// The types of the messages generated by protoc-gen-go/generator have
+	// descriptors of their own, equivalent to those of the registered files.
+	if mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName()); err == nil &&
+		(mt.Descriptor() == md || mt.Descriptor().ParentFile().Path() == md.ParentFile().Path()) {
+		return mt
+	}
+	return dynamicpb.NewMessageType(md)
+}
+
+// Option returns the value of the custom option xd set on the method,
+// as returned by GetOption.
+func (m *MethodInfo) Option(xd *proto.ExtensionDesc) (interface{}, error) {
+	return GetOption(m.Descriptor, xd)
+}
+
+// HasOption reports whether the custom option xd is set on the method.
+func (m *MethodInfo) HasOption(xd *proto.ExtensionDesc) bool {
+	return HasOption(m.Descriptor, xd)
+}
+
+// A MethodTable describes the methods of a service by their full names,
+// such as given to gRPC interceptors in the FullMethod field of
+// grpc.UnaryServerInfo and grpc.StreamServerInfo.
+//
+// protoc-gen-go with plugins=grpc and the grpc.method_tables=true parameter
+// declares a MethodTable for each service, as does protoc-gen-go/grpc with
+// grpc.method_tables=true.
+type MethodTable struct {
+	service func() protoreflect.ServiceDescriptor
+
+	once    sync.Once
+	sd      protoreflect.ServiceDescriptor
+	methods []*MethodInfo
+	byName  map[string]*MethodInfo
+}
+
+// NewMethodTable returns the table of the methods of the service returned
+// by service, which is called once, when the table is first used.
+// This allows generated code to declare the table of a service before its
+// descriptor is initialized.
+func NewMethodTable(service func() protoreflect.ServiceDescriptor) *MethodTable {
+	return &MethodTable{service: service}
+}
+
+func (t *MethodTable) init() {
+	t.once.Do(func() {
+		t.sd = t.service()
+		mds := t.sd.Methods()
+		t.byName = make(map[string]*MethodInfo, mds.Len())
+		for i := 0; i < mds.Len(); i++ {
+			m := NewMethodInfo(mds.Get(i))
+			t.methods = append(t.methods, m)
+			t.byName[m.FullMethod] = m
+		}
+	})
+}
+
+// Service returns the descriptor of the service.
+func (t *MethodTable) Service() protoreflect.ServiceDescriptor {
+	t.init()
+	return t.sd
+}
+
+// Lookup returns the method with a full name such as "/package.Service/Method",
+// or nil if the service has no such method.
+func (t *MethodTable) Lookup(fullMethod string) *MethodInfo {
+	t.init()
+	return t.byName[fullMethod]
+}
+
+// Methods returns the methods of the service, in order of declaration.
+// The returned slice must not be modified.
+func (t *MethodTable) Methods() []*MethodInfo {
+	t.init()
+	return t.methods
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	pb "github.com/golang/protobuf/internal/testprotos/httprpc_proto"
+	legacypb "github.com/golang/protobuf/internal/testprotos/proto3_proto"
+	"github.com/golang/protobuf/proto"
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestMethodTable(t *testing.T) {
+	fd := parseFile(t, `
+		name: "methods_test/a.proto"
+		package: "methods.test"
+		message_type { name: "Request" }
+		message_type { name: "Response" }
+		service {
+			name: "Store"
+			method { name: "Get" input_type: ".methods.test.Request" output_type: ".methods.test.Response" options { idempotency_level: NO_SIDE_EFFECTS } }
+			method { name: "Put" input_type: ".methods.test.Request" output_type: ".methods.test.Response" client_streaming: true options { deprecated: true } }
+			method { name: "Watch" input_type: ".methods.test.Request" output_type: ".methods.test.Response" server_streaming: true }
+			method { name: "Sync" input_type: ".methods.test.Request" output_type: ".methods.test.Response" client_streaming: true server_streaming: true options { idempotency_level: IDEMPOTENT } }
+		}
+	`)
+	file, err := protodesc.NewFile(fd, new(protoregistry.Files))
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	table := NewMethodTable(func() protoreflect.ServiceDescriptor {
+		calls++
+		return file.Services().ByName("Store")
+	})
+
+	type method struct {
+		FullMethod       string
+		StreamKind       StreamKind
+		IdempotencyLevel descpb.MethodOptions_IdempotencyLevel
+		Deprecated       bool
+	}
+	var got []method
+	for _, m := range table.Methods() {
+		got = append(got, method{m.FullMethod, m.StreamKind, m.IdempotencyLevel, m.Deprecated})
+		if table.Lookup(m.FullMethod) != m {
+			t.Errorf("Lookup(%q) does not return the method", m.FullMethod)
+		}
+		if _, ok := m.Input.New().Interface().(*dynamicpb.Message); !ok || m.Input.Descriptor() != m.Descriptor.Input() {
+			t.Errorf("%s: Input = %T for %v, want the dynamic type of %v", m.FullMethod, m.Input.New().Interface(), m.Input.Descriptor().FullName(), m.Descriptor.Input().FullName())
+		}
+	}
+	want := []method{
+		{"/methods.test.Store/Get", Unary, descpb.MethodOptions_NO_SIDE_EFFECTS, false},
+		{"/methods.test.Store/Put", ClientStreaming, descpb.MethodOptions_IDEMPOTENCY_UNKNOWN, true},
+		{"/methods.test.Store/Watch", ServerStreaming, descpb.MethodOptions_IDEMPOTENCY_UNKNOWN, false},
+		{"/methods.test.Store/Sync", BidiStreaming, descpb.MethodOptions_IDEMPOTENT, false},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Methods mismatch (-want +got):\n%s", diff)
+	}
+	if m := table.Lookup("/methods.test.Store/Delete"); m != nil {
+		t.Errorf("Lookup of an unknown method = %v, want nil", m.FullMethod)
+	}
+	if got := table.Service().FullName(); got != "methods.test.Store" {
+		t.Errorf("Service = %v, want methods.test.Store", got)
+	}
+	if calls != 1 {
+		t.Errorf("service called %d times, want 1", calls)
+	}
+}
+
+func TestMethodInfoGeneratedTypes(t *testing.T) {
+	sd := pb.File_httprpc_proto_test_proto.Services().ByName("Library")
+	m := NewMethodInfo(sd.Methods().ByName("GetBook"))
+	if got, want := m.Input, (*pb.GetBookRequest)(nil).ProtoReflect().Type(); got != want {
+		t.Errorf("Input = %v, want the generated type %v", got, want)
+	}
+	if got, want := m.Output, (*pb.Book)(nil).ProtoReflect().Type(); got != want {
+		t.Errorf("Output = %v, want the generated type %v", got, want)
+	}
+}
+
+func TestMethodInfoLegacyTypes(t *testing.T) {
+	// The messages generated by protoc-gen-go/generator have descriptors
+	// distinct from those of the registered files.
+	fd := parseFile(t, `
+		name: "methods_test/legacy.proto"
+		package: "methods.test"
+		dependency: "proto3_proto/test.proto"
+		service {
+			name: "Legacy"
+			method { name: "Get" input_type: ".proto3_test.Message" output_type: ".proto3_test.Nested" }
+		}
+	`)
+	file, err := protodesc.NewFile(fd, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMethodInfo(file.Services().Get(0).Methods().Get(0))
+	if got, want := m.Input, proto.MessageReflect((*legacypb.Message)(nil)).Type(); got != want {
+		t.Errorf("Input = %v, want the generated type %v", got, want)
+	}
+	if got, want := m.Output, proto.MessageReflect((*legacypb.Nested)(nil)).Type(); got != want {
+		t.Errorf("Output = %v, want the generated type %v", got, want)
+	}
+}
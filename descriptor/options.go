@@ -0,0 +1,238 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// GetOption returns the value of the custom option xd set on the descriptor d,
+// with the same Go type as returned by proto.GetExtension for the options
+// message of d. If the option is not set, then its default value is returned
+// (if one is specified), otherwise proto.ErrMissingExtension is reported.
+//
+// The options message of d is not modified.
+func GetOption(d protoreflect.Descriptor, xd *proto.ExtensionDesc) (interface{}, error) {
+	m, err := OptionReader{}.resolve(d, xd)
+	if err != nil {
+		return nil, err
+	}
+	return proto.GetExtension(proto.MessageV1(m.Interface()), xd)
+}
+
+// HasOption reports whether the custom option xd is set on the descriptor d.
+func HasOption(d protoreflect.Descriptor, xd *proto.ExtensionDesc) bool {
+	_, ok, err := OptionReader{}.Get(d, xd)
+	return ok && err == nil
+}
+
+// OptionReader reads the custom options of descriptors,
+// which are the extension fields of their options messages.
+type OptionReader struct {
+	// Resolver is used to resolve custom options stored as unknown fields
+	// of the options messages, such as options that were unknown when
+	// the descriptors were built. If nil, protoregistry.GlobalTypes is used.
+	Resolver protoregistry.ExtensionTypeResolver
+}
+
+// Get returns the value of the custom option xt set on the descriptor d.
+// It reports false if the option is not set.
+func (r OptionReader) Get(d protoreflect.Descriptor, xt protoreflect.ExtensionType) (protoreflect.Value, bool, error) {
+	m, err := r.resolve(d, xt)
+	if err != nil {
+		return protoreflect.Value{}, false, err
+	}
+	xtd := xt.TypeDescriptor()
+	if !m.Has(xtd) {
+		return protoreflect.Value{}, false, nil
+	}
+	return m.Get(xtd), true, nil
+}
+
+// Range calls f for each custom option set on the descriptor d, in order
+// of field number, until f returns false. Options that cannot be resolved
+// are skipped.
+func (r OptionReader) Range(d protoreflect.Descriptor, f func(protoreflect.ExtensionType, protoreflect.Value) bool) error {
+	m, err := r.resolve(d, nil)
+	if err != nil {
+		return err
+	}
+	var xtds []protoreflect.ExtensionTypeDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if xtd, ok := fd.(protoreflect.ExtensionTypeDescriptor); ok {
+			xtds = append(xtds, xtd)
+		}
+		return true
+	})
+	sort.Slice(xtds, func(i, j int) bool { return xtds[i].Number() < xtds[j].Number() })
+	for _, xtd := range xtds {
+		if !f(xtd.Type(), m.Get(xtd)) {
+			break
+		}
+	}
+	return nil
+}
+
+// An OptionUse is an element of a file set carrying a custom option.
+type OptionUse struct {
+	Descriptor protoreflect.Descriptor
+	Value      protoreflect.Value
+}
+
+// Find returns every element of the files carrying the custom option xt.
+// The files are visited in order of path, and the elements of each file
+// in order of declaration.
+func (r OptionReader) Find(files *protoregistry.Files, xt protoreflect.ExtensionType) ([]OptionUse, error) {
+	var paths []string
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		paths = append(paths, fd.Path())
+		return true
+	})
+	sort.Strings(paths)
+
+	extendee := xt.TypeDescriptor().ContainingMessage().FullName()
+	var uses []OptionUse
+	var visit func(d protoreflect.Descriptor) error
+	visit = func(d protoreflect.Descriptor) error {
+		if d.Options().ProtoReflect().Descriptor().FullName() == extendee {
+			v, ok, err := r.Get(d, xt)
+			if err != nil {
+				return err
+			}
+			if ok {
+				uses = append(uses, OptionUse{Descriptor: d, Value: v})
+			}
+		}
+		return rangeDeclarations(d, visit)
+	}
+	for _, path := range paths {
+		fd, err := files.FindFileByPath(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := visit(fd); err != nil {
+			return nil, err
+		}
+	}
+	return uses, nil
+}
+
+// rangeDeclarations calls f for each declaration directly nested in d,
+// in order of declaration, until f returns an error.
+func rangeDeclarations(d protoreflect.Descriptor, f func(protoreflect.Descriptor) error) error {
+	var ds []protoreflect.Descriptor
+	switch d := d.(type) {
+	case protoreflect.FileDescriptor:
+		ds = appendMessages(ds, d.Messages())
+		ds = appendEnums(ds, d.Enums())
+		for i := 0; i < d.Services().Len(); i++ {
+			ds = append(ds, d.Services().Get(i))
+		}
+		ds = appendFields(ds, d.Extensions())
+	case protoreflect.MessageDescriptor:
+		ds = appendFields(ds, d.Fields())
+		for i := 0; i < d.Oneofs().Len(); i++ {
+			ds = append(ds, d.Oneofs().Get(i))
+		}
+		ds = appendMessages(ds, d.Messages())
+		ds = appendEnums(ds, d.Enums())
+		ds = appendFields(ds, d.Extensions())
+	case protoreflect.EnumDescriptor:
+		for i := 0; i < d.Values().Len(); i++ {
+			ds = append(ds, d.Values().Get(i))
+		}
+	case protoreflect.ServiceDescriptor:
+		for i := 0; i < d.Methods().Len(); i++ {
+			ds = append(ds, d.Methods().Get(i))
+		}
+	}
+	for _, d := range ds {
+		if err := f(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendMessages(ds []protoreflect.Descriptor, mds protoreflect.MessageDescriptors) []protoreflect.Descriptor {
+	for i := 0; i < mds.Len(); i++ {
+		ds = append(ds, mds.Get(i))
+	}
+	return ds
+}
+
+func appendEnums(ds []protoreflect.Descriptor, eds protoreflect.EnumDescriptors) []protoreflect.Descriptor {
+	for i := 0; i < eds.Len(); i++ {
+		ds = append(ds, eds.Get(i))
+	}
+	return ds
+}
+
+func appendFields(ds []protoreflect.Descriptor, fds protoreflect.ExtensionDescriptors) []protoreflect.Descriptor {
+	for i := 0; i < fds.Len(); i++ {
+		ds = append(ds, fds.Get(i))
+	}
+	return ds
+}
+
+// resolve returns a copy of the options message of the descriptor d
+// in which the custom options are parsed using the resolver,
+// except for xt, if not nil, which takes precedence over the resolver.
+func (r OptionReader) resolve(d protoreflect.Descriptor, xt protoreflect.ExtensionType) (protoreflect.Message, error) {
+	m := d.Options().ProtoReflect()
+	if xt != nil {
+		if want := xt.TypeDescriptor().ContainingMessage().FullName(); want != m.Descriptor().FullName() {
+			return nil, fmt.Errorf("descriptor: option %v extends %v, not the options of %v", xt.TypeDescriptor().FullName(), want, d.FullName())
+		}
+	}
+	resolver := r.Resolver
+	if resolver == nil {
+		resolver = protoregistry.GlobalTypes
+	}
+	return resolveOptions(m, optionResolver{xt, resolver})
+}
+
+// resolveOptions returns a copy of the options message m in which
+// the custom options are parsed using the resolver.
+func resolveOptions(m protoreflect.Message, resolver protoregistry.ExtensionTypeResolver) (protoreflect.Message, error) {
+	b, err := protoV2.MarshalOptions{AllowPartial: true}.Marshal(m.Interface())
+	if err != nil {
+		return nil, err
+	}
+	m2 := m.Type().New()
+	if err := (protoV2.UnmarshalOptions{AllowPartial: true, Resolver: resolver}).Unmarshal(b, m2.Interface()); err != nil {
+		return nil, fmt.Errorf("descriptor: invalid %v: %v", m.Descriptor().FullName(), err)
+	}
+	return m2, nil
+}
+
+// optionResolver is an extension resolver holding a single extension type,
+// if not nil, that takes precedence over another resolver.
+type optionResolver struct {
+	xt   protoreflect.ExtensionType
+	next protoregistry.ExtensionTypeResolver
+}
+
+func (r optionResolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	if r.xt != nil && r.xt.TypeDescriptor().FullName() == field {
+		return r.xt, nil
+	}
+	return r.next.FindExtensionByName(field)
+}
+
+func (r optionResolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	if r.xt != nil {
+		if xtd := r.xt.TypeDescriptor(); xtd.ContainingMessage().FullName() == message && xtd.Number() == field {
+			return r.xt, nil
+		}
+	}
+	return r.next.FindExtensionByNumber(message, field)
+}
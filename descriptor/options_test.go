@@ -0,0 +1,171 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// noteOption is the source.test.note option declared as by protoc-gen-go.
+var noteOption = &proto.ExtensionDesc{
+	ExtendedType:  (*descpb.FieldOptions)(nil),
+	ExtensionType: (*string)(nil),
+	Field:         50000,
+	Name:          "source.test.note",
+	Tag:           "bytes,50000,opt,name=note",
+	Filename:      "source_test/options.proto",
+}
+
+// optionsFiles returns files whose custom options are kept as unknown fields.
+func optionsFiles(t *testing.T) *protoregistry.Files {
+	fd1 := parseFile(t, `
+		name: "options_test/a.proto"
+		package: "options.test"
+		message_type {
+			name: "Account"
+			field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING options {} }
+			field { name: "password" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING options {} }
+			nested_type {
+				name: "Key"
+				field { name: "secret" number: 1 label: LABEL_OPTIONAL type: TYPE_BYTES options {} }
+			}
+			options {}
+		}
+	`)
+	fd2 := parseFile(t, `
+		name: "options_test/b.proto"
+		package: "options.test"
+		message_type {
+			name: "Token"
+			field { name: "value" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING options {} }
+		}
+	`)
+
+	note := func(s string) []byte {
+		b := protowire.AppendTag(nil, 50000, protowire.BytesType)
+		return protowire.AppendString(b, s)
+	}
+	var info []byte
+	info = protowire.AppendTag(info, 1, protowire.BytesType)
+	info = protowire.AppendString(info, "security")
+	md := fd1.MessageType[0]
+	md.Options.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, 50001, protowire.BytesType), info))
+	md.Field[1].Options.ProtoReflect().SetUnknown(note("hashed"))
+	md.NestedType[0].Field[0].Options.ProtoReflect().SetUnknown(note("never logged"))
+	fd2.MessageType[0].Field[0].Options.ProtoReflect().SetUnknown(note("short-lived"))
+
+	files := new(protoregistry.Files)
+	for _, fd := range []*descpb.FileDescriptorProto{fd2, fd1} {
+		file, err := protodesc.NewFile(fd, files)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := files.RegisterFile(file); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return files
+}
+
+func findDescriptor(t *testing.T, files *protoregistry.Files, name protoreflect.FullName) protoreflect.Descriptor {
+	d, err := files.FindDescriptorByName(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestGetOption(t *testing.T) {
+	files := optionsFiles(t)
+	password := findDescriptor(t, files, "options.test.Account.password")
+	id := findDescriptor(t, files, "options.test.Account.id")
+
+	v, err := GetOption(password, noteOption)
+	if err != nil {
+		t.Fatalf("GetOption() error: %v", err)
+	}
+	if got, ok := v.(*string); !ok || *got != "hashed" {
+		t.Errorf("GetOption() = %#v, want \"hashed\"", v)
+	}
+	if !HasOption(password, noteOption) {
+		t.Errorf("HasOption(password) = false, want true")
+	}
+	if _, err := GetOption(id, noteOption); err != proto.ErrMissingExtension {
+		t.Errorf("GetOption(id) error = %v, want %v", err, proto.ErrMissingExtension)
+	}
+	if HasOption(id, noteOption) {
+		t.Errorf("HasOption(id) = true, want false")
+	}
+	if _, err := GetOption(findDescriptor(t, files, "options.test.Account"), noteOption); err == nil {
+		t.Errorf("GetOption() succeeded for an option of another kind of element")
+	}
+
+	// The options of the descriptor are left untouched.
+	opts := password.Options().(*descpb.FieldOptions)
+	if len(opts.ProtoReflect().GetUnknown()) == 0 {
+		t.Errorf("GetOption() modified the options of the descriptor")
+	}
+}
+
+func TestOptionReader(t *testing.T) {
+	files := optionsFiles(t)
+	account := findDescriptor(t, files, "options.test.Account")
+	r := OptionReader{Resolver: optionsResolver(t)}
+
+	var got []string
+	err := r.Range(account, func(xt protoreflect.ExtensionType, v protoreflect.Value) bool {
+		got = append(got, string(xt.TypeDescriptor().FullName())+": "+proto.CompactTextString(proto.MessageV1(v.Message().Interface())))
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Range() error: %v", err)
+	}
+	want := []string{`source.test.info: owner:"security" `}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Range() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Options unknown to the resolver are skipped.
+	got = nil
+	if err := (OptionReader{}).Range(account, func(xt protoreflect.ExtensionType, v protoreflect.Value) bool {
+		got = append(got, string(xt.TypeDescriptor().FullName()))
+		return true
+	}); err != nil {
+		t.Fatalf("Range() error: %v", err)
+	}
+	if len(got) > 0 {
+		t.Errorf("Range() without a resolver reported %v", got)
+	}
+
+	xt, err := r.Resolver.FindExtensionByName("source.test.note")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uses, err := r.Find(files, xt)
+	if err != nil {
+		t.Fatalf("Find() error: %v", err)
+	}
+	got = nil
+	for _, u := range uses {
+		got = append(got, string(u.Descriptor.FullName())+": "+u.Value.String())
+	}
+	want = []string{
+		"options.test.Account.password: hashed",
+		"options.test.Account.Key.secret: never logged",
+		"options.test.Token.value: short-lived",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Find() mismatch (-want +got):\n%s", diff)
+	}
+}
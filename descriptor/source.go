@@ -0,0 +1,988 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// A SourcePrinter formats a FileDescriptorProto as the source of a .proto file.
+//
+// Compiling the printed source yields the original descriptor, except that
+// source locations are not preserved and json_name is populated for every
+// field. Comments are printed from the SourceCodeInfo of the file, if present,
+// in which case declarations are also printed in their original order.
+type SourcePrinter struct {
+	// Indent is the string used to indent nested declarations.
+	// If empty, two spaces are used.
+	Indent string
+
+	// Resolver is used to resolve custom options stored as unknown fields
+	// of the options messages. If nil, protoregistry.GlobalTypes is used.
+	// It is an error for an option to remain unresolved.
+	Resolver protoregistry.ExtensionTypeResolver
+}
+
+// Print writes the source of the file fd to out.
+func (p *SourcePrinter) Print(out io.Writer, fd *descriptorpb.FileDescriptorProto) error {
+	w := newSourceWriter(p, fd)
+	w.writeFile()
+	if w.err != nil {
+		return w.err
+	}
+	_, err := out.Write(w.buf.Bytes())
+	return err
+}
+
+// Source returns the source of the file fd.
+func (p *SourcePrinter) Source(fd *descriptorpb.FileDescriptorProto) (string, error) {
+	var buf bytes.Buffer
+	if err := p.Print(&buf, fd); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Paths of the elements of descriptor protos, as used by SourceCodeInfo.
+const (
+	filePackageTag    = 2
+	fileDependencyTag = 3
+	fileMessageTag    = 4
+	fileEnumTag       = 5
+	fileServiceTag    = 6
+	fileExtensionTag  = 7
+	fileOptionsTag    = 8
+	fileSyntaxTag     = 12
+
+	messageFieldTag          = 2
+	messageNestedTag         = 3
+	messageEnumTag           = 4
+	messageExtensionRangeTag = 5
+	messageExtensionTag      = 6
+	messageOptionsTag        = 7
+	messageOneofTag          = 8
+	messageReservedRangeTag  = 9
+	messageReservedNameTag   = 10
+
+	oneofOptionsTag = 2
+
+	enumValueTag         = 2
+	enumOptionsTag       = 3
+	enumReservedRangeTag = 4
+	enumReservedNameTag  = 5
+
+	serviceMethodTag  = 2
+	serviceOptionsTag = 3
+
+	methodOptionsTag = 4
+)
+
+// maxFieldNumber is one more than the largest field number,
+// the exclusive end of a range of field numbers that extends to max.
+const maxFieldNumber = 1 << 29
+
+// uninterpretedOptionNumber is the field number of the uninterpreted_option
+// field common to all options messages.
+const uninterpretedOptionNumber = 999
+
+type sourceWriter struct {
+	*SourcePrinter
+	buf      bytes.Buffer
+	indent   int
+	file     *descriptorpb.FileDescriptorProto
+	locs     map[string]*descriptorpb.SourceCodeInfo_Location
+	types    map[string]bool // full names of the types declared in the file
+	packages map[string]bool // the package of the file and its parents
+	err      error
+}
+
+func newSourceWriter(p *SourcePrinter, fd *descriptorpb.FileDescriptorProto) *sourceWriter {
+	w := &sourceWriter{
+		SourcePrinter: p,
+		file:          fd,
+		locs:          make(map[string]*descriptorpb.SourceCodeInfo_Location),
+		types:         make(map[string]bool),
+		packages:      make(map[string]bool),
+	}
+	for _, loc := range fd.GetSourceCodeInfo().GetLocation() {
+		k := pathKey(loc.Path)
+		if _, ok := w.locs[k]; !ok {
+			w.locs[k] = loc
+		}
+	}
+	for s := fd.GetPackage(); s != ""; s = parentScope(s) {
+		w.packages[s] = true
+	}
+	var addMessages func(scope string, mds []*descriptorpb.DescriptorProto)
+	addMessages = func(scope string, mds []*descriptorpb.DescriptorProto) {
+		for _, md := range mds {
+			name := fullName(scope, md.GetName())
+			w.types[name] = true
+			for _, ed := range md.EnumType {
+				w.types[fullName(name, ed.GetName())] = true
+			}
+			addMessages(name, md.NestedType)
+		}
+	}
+	addMessages(fd.GetPackage(), fd.MessageType)
+	for _, ed := range fd.EnumType {
+		w.types[fullName(fd.GetPackage(), ed.GetName())] = true
+	}
+	for _, sd := range fd.Service {
+		w.types[fullName(fd.GetPackage(), sd.GetName())] = true
+	}
+	return w
+}
+
+func (w *sourceWriter) fail(format string, args ...interface{}) {
+	if w.err == nil {
+		w.err = fmt.Errorf("descriptor: "+format, args...)
+	}
+}
+
+func pathKey(path []int32) string {
+	return fmt.Sprint(path)
+}
+
+// subPath returns a new path consisting of path followed by elems.
+func subPath(path []int32, elems ...int32) []int32 {
+	p := make([]int32, 0, len(path)+len(elems))
+	return append(append(p, path...), elems...)
+}
+
+func fullName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func parentScope(scope string) string {
+	if i := strings.LastIndexByte(scope, '.'); i >= 0 {
+		return scope[:i]
+	}
+	return ""
+}
+
+func (w *sourceWriter) loc(path []int32) *descriptorpb.SourceCodeInfo_Location {
+	if path == nil {
+		return nil
+	}
+	return w.locs[pathKey(path)]
+}
+
+func (w *sourceWriter) writeIndent() {
+	indent := w.Indent
+	if indent == "" {
+		indent = "  "
+	}
+	for i := 0; i < w.indent; i++ {
+		w.buf.WriteString(indent)
+	}
+}
+
+// blank writes a blank line, unless the output is empty or already ends
+// with a blank line.
+func (w *sourceWriter) blank() {
+	b := w.buf.Bytes()
+	if len(b) == 0 || bytes.HasSuffix(b, []byte("\n\n")) {
+		return
+	}
+	w.buf.WriteByte('\n')
+}
+
+func commentLines(c string) []string {
+	if c == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(c, "\n"), "\n")
+}
+
+func (w *sourceWriter) writeComment(c string) {
+	for _, line := range commentLines(c) {
+		w.writeIndent()
+		w.buf.WriteString("//")
+		w.buf.WriteString(line)
+		w.buf.WriteByte('\n')
+	}
+}
+
+// line writes a line of source for the element at path, followed by
+// the trailing comments of the element.
+func (w *sourceWriter) line(path []int32, format string, args ...interface{}) {
+	w.writeIndent()
+	text := fmt.Sprintf(format, args...)
+	w.buf.WriteString(text)
+	lines := commentLines(w.loc(path).GetTrailingComments())
+	if len(lines) == 1 {
+		w.buf.WriteString(" //")
+		w.buf.WriteString(lines[0])
+	}
+	w.buf.WriteByte('\n')
+	if len(lines) > 1 {
+		// A comment on the lines following a declaration is only attached
+		// to it if it is followed by a blank line.
+		if strings.HasSuffix(text, "{") {
+			w.indent++
+			defer func() { w.indent-- }()
+		}
+		w.writeComment(w.loc(path).GetTrailingComments())
+		w.buf.WriteByte('\n')
+	}
+}
+
+// writeLeadingComments writes the detached and leading comments
+// of the element at path.
+func (w *sourceWriter) writeLeadingComments(path []int32) {
+	loc := w.loc(path)
+	for _, c := range loc.GetLeadingDetachedComments() {
+		w.blank()
+		w.writeComment(c)
+		w.buf.WriteByte('\n')
+	}
+	w.writeComment(loc.GetLeadingComments())
+}
+
+// A decl is a declaration within a file, message, enum, service or oneof.
+type decl struct {
+	path     []int32
+	extendee string // the extended message, for an extension
+	block    bool   // whether the declaration spans multiple lines
+	write    func()
+}
+
+// sortDecls sorts declarations in the order they appear in the source file,
+// if the locations of all of them are known.
+func (w *sourceWriter) sortDecls(decls []decl) {
+	for _, d := range decls {
+		if len(w.loc(d.path).GetSpan()) < 2 {
+			return
+		}
+	}
+	sort.SliceStable(decls, func(i, j int) bool {
+		si := w.loc(decls[i].path).GetSpan()
+		sj := w.loc(decls[j].path).GetSpan()
+		if si[0] != sj[0] {
+			return si[0] < sj[0]
+		}
+		return si[1] < sj[1]
+	})
+}
+
+// writeDecls writes the declarations of a scope. Consecutive extensions
+// of the same message are grouped in an extend block.
+func (w *sourceWriter) writeDecls(decls []decl, scope string) {
+	w.sortDecls(decls)
+	prevBlock := false
+	for i := 0; i < len(decls); {
+		d := decls[i]
+		if d.extendee == "" {
+			w.writeDecl(d, i > 0 && (prevBlock || d.block))
+			prevBlock = d.block
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j < len(decls) && decls[j].extendee == d.extendee {
+			j++
+		}
+		if i > 0 {
+			w.blank()
+		}
+		w.line(nil, "extend %s {", w.typeName(d.extendee, scope))
+		w.indent++
+		for k := i; k < j; k++ {
+			w.writeDecl(decls[k], k > i && (decls[k-1].block || decls[k].block))
+		}
+		w.indent--
+		w.line(nil, "}")
+		prevBlock = true
+		i = j
+	}
+}
+
+func (w *sourceWriter) writeDecl(d decl, separate bool) {
+	loc := w.loc(d.path)
+	if separate || loc.GetLeadingComments() != "" || len(loc.GetLeadingDetachedComments()) > 0 {
+		if separate || !bytes.HasSuffix(w.buf.Bytes(), []byte("{\n")) {
+			w.blank()
+		}
+	}
+	w.writeLeadingComments(d.path)
+	d.write()
+}
+
+func (w *sourceWriter) writeFile() {
+	fd := w.file
+	pkg := fd.GetPackage()
+	if fd.Syntax != nil {
+		path := []int32{fileSyntaxTag}
+		w.writeLeadingComments(path)
+		w.line(path, "syntax = %s;", quoteString(fd.GetSyntax(), true))
+		w.blank()
+	}
+	if fd.Package != nil {
+		path := []int32{filePackageTag}
+		w.writeLeadingComments(path)
+		w.line(path, "package %s;", pkg)
+		w.blank()
+	}
+	if len(fd.Dependency) > 0 {
+		modifiers := make(map[int32]string)
+		for _, i := range fd.PublicDependency {
+			modifiers[i] = "public "
+		}
+		for _, i := range fd.WeakDependency {
+			modifiers[i] = "weak "
+		}
+		for i, dep := range fd.Dependency {
+			path := []int32{fileDependencyTag, int32(i)}
+			w.writeLeadingComments(path)
+			w.line(path, "import %s%s;", modifiers[int32(i)], quoteString(dep, true))
+		}
+		w.blank()
+	}
+	if w.writeOptions(fd.GetOptions(), []int32{fileOptionsTag}, pkg) {
+		w.blank()
+	}
+
+	// Extensions are declared first to find the messages declared by groups.
+	var decls, xdecls []decl
+	skip := make(map[int]bool)
+	for i, xd := range fd.Extension {
+		path := []int32{fileExtensionTag, int32(i)}
+		xdecls = append(xdecls, w.fieldDecl(xd, path, pkg, fd.MessageType, []int32{fileMessageTag}, skip, false))
+	}
+	for i, md := range fd.MessageType {
+		if !skip[i] {
+			decls = append(decls, w.messageDecl(md, []int32{fileMessageTag, int32(i)}, pkg))
+		}
+	}
+	for i, ed := range fd.EnumType {
+		decls = append(decls, w.enumDecl(ed, []int32{fileEnumTag, int32(i)}, pkg))
+	}
+	decls = append(decls, xdecls...)
+	for i, sd := range fd.Service {
+		decls = append(decls, w.serviceDecl(sd, []int32{fileServiceTag, int32(i)}, pkg))
+	}
+	w.writeDecls(decls, pkg)
+}
+
+func (w *sourceWriter) messageDecl(md *descriptorpb.DescriptorProto, path []int32, scope string) decl {
+	return decl{path: path, block: true, write: func() {
+		w.line(path, "message %s {", md.GetName())
+		w.indent++
+		w.writeMessageBody(md, path, fullName(scope, md.GetName()))
+		w.indent--
+		w.line(nil, "}")
+	}}
+}
+
+// writeMessageBody writes the declarations within the message md,
+// whose full name is name.
+func (w *sourceWriter) writeMessageBody(md *descriptorpb.DescriptorProto, path []int32, name string) {
+	if w.writeOptions(md.GetOptions(), subPath(path, messageOptionsTag), name) {
+		w.blank()
+	}
+
+	var decls []decl
+	skip := make(map[int]bool) // nested types declared by map fields and groups
+	nestedPath := subPath(path, messageNestedTag)
+	oneofs := make(map[int32]bool)
+	for i, fd := range md.Field {
+		if fd.OneofIndex != nil && !fd.GetProto3Optional() {
+			oi := fd.GetOneofIndex()
+			if !oneofs[oi] && int(oi) < len(md.OneofDecl) {
+				oneofs[oi] = true
+				decls = append(decls, w.oneofDecl(md, oi, path, name, skip))
+			}
+			continue
+		}
+		decls = append(decls, w.fieldDecl(fd, subPath(path, messageFieldTag, int32(i)), name, md.NestedType, nestedPath, skip, false))
+	}
+	max := int32(maxFieldNumber)
+	if md.GetOptions().GetMessageSetWireFormat() {
+		max = math.MaxInt32
+	}
+	for i, r := range md.ExtensionRange {
+		r, rpath := r, subPath(path, messageExtensionRangeTag, int32(i))
+		decls = append(decls, decl{path: rpath, write: func() {
+			opts := w.compactOptions(nil, r.GetOptions(), name)
+			w.line(rpath, "extensions %s%s;", formatRange(r.GetStart(), r.GetEnd()-1, max-1), opts)
+		}})
+	}
+	if len(md.ReservedRange) > 0 {
+		rpath := subPath(path, messageReservedRangeTag, 0)
+		decls = append(decls, decl{path: rpath, write: func() {
+			var ranges []string
+			for _, r := range md.ReservedRange {
+				ranges = append(ranges, formatRange(r.GetStart(), r.GetEnd()-1, maxFieldNumber-1))
+			}
+			w.line(rpath, "reserved %s;", strings.Join(ranges, ", "))
+		}})
+	}
+	if len(md.ReservedName) > 0 {
+		rpath := subPath(path, messageReservedNameTag, 0)
+		decls = append(decls, decl{path: rpath, write: func() {
+			w.line(rpath, "reserved %s;", formatNames(md.ReservedName))
+		}})
+	}
+	for i, xd := range md.Extension {
+		decls = append(decls, w.fieldDecl(xd, subPath(path, messageExtensionTag, int32(i)), name, md.NestedType, nestedPath, skip, false))
+	}
+	for i, nd := range md.NestedType {
+		if !skip[i] {
+			decls = append(decls, w.messageDecl(nd, subPath(nestedPath, int32(i)), name))
+		}
+	}
+	for i, ed := range md.EnumType {
+		decls = append(decls, w.enumDecl(ed, subPath(path, messageEnumTag, int32(i)), name))
+	}
+	w.writeDecls(decls, name)
+}
+
+// oneofDecl returns the declaration of the oneof with index oi in md,
+// which holds the declarations of all of its fields.
+func (w *sourceWriter) oneofDecl(md *descriptorpb.DescriptorProto, oi int32, path []int32, scope string, skip map[int]bool) decl {
+	od := md.OneofDecl[oi]
+	opath := subPath(path, messageOneofTag, oi)
+	var decls []decl
+	for i, fd := range md.Field {
+		if fd.OneofIndex != nil && fd.GetOneofIndex() == oi {
+			decls = append(decls, w.fieldDecl(fd, subPath(path, messageFieldTag, int32(i)), scope, md.NestedType, subPath(path, messageNestedTag), skip, true))
+		}
+	}
+	return decl{path: opath, block: true, write: func() {
+		w.line(opath, "oneof %s {", od.GetName())
+		w.indent++
+		w.writeOptions(od.GetOptions(), subPath(opath, oneofOptionsTag), scope)
+		w.writeDecls(decls, scope)
+		w.indent--
+		w.line(nil, "}")
+	}}
+}
+
+// fieldDecl returns the declaration of the field or extension fd declared
+// within scope. The nested types of the scope are used to find the types
+// declared by map fields and groups, which are marked in skip.
+func (w *sourceWriter) fieldDecl(fd *descriptorpb.FieldDescriptorProto, path []int32, scope string, nested []*descriptorpb.DescriptorProto, nestedPath []int32, skip map[int]bool, inOneof bool) decl {
+	d := decl{path: path, extendee: fd.GetExtendee()}
+	idx := -1
+	for i, nd := range nested {
+		if "."+fullName(scope, nd.GetName()) == fd.GetTypeName() {
+			idx = i
+			break
+		}
+	}
+	switch {
+	case idx >= 0 && fd.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		skip[idx] = true
+		d.block = true
+		d.write = func() {
+			gd := nested[idx]
+			w.line(path, "%sgroup %s = %d%s {", w.label(fd, inOneof), gd.GetName(), fd.GetNumber(), w.fieldOptions(fd, scope))
+			w.indent++
+			w.writeMessageBody(gd, subPath(nestedPath, int32(idx)), fullName(scope, gd.GetName()))
+			w.indent--
+			w.line(nil, "}")
+		}
+	case idx >= 0 && nested[idx].GetOptions().GetMapEntry() && fd.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED:
+		skip[idx] = true
+		d.write = func() {
+			var kd, vd *descriptorpb.FieldDescriptorProto
+			for _, ed := range nested[idx].Field {
+				switch ed.GetNumber() {
+				case 1:
+					kd = ed
+				case 2:
+					vd = ed
+				}
+			}
+			if kd == nil || vd == nil {
+				w.fail("map entry %v lacks a key or value field", fd.GetTypeName())
+				return
+			}
+			w.line(path, "map<%s, %s> %s = %d%s;", w.fieldType(kd, scope), w.fieldType(vd, scope), fd.GetName(), fd.GetNumber(), w.fieldOptions(fd, scope))
+		}
+	default:
+		d.write = func() {
+			w.line(path, "%s%s %s = %d%s;", w.label(fd, inOneof), w.fieldType(fd, scope), fd.GetName(), fd.GetNumber(), w.fieldOptions(fd, scope))
+		}
+	}
+	return d
+}
+
+func (w *sourceWriter) label(fd *descriptorpb.FieldDescriptorProto, inOneof bool) string {
+	if inOneof {
+		return ""
+	}
+	switch fd.GetLabel() {
+	case descriptorpb.FieldDescriptorProto_LABEL_REPEATED:
+		return "repeated "
+	case descriptorpb.FieldDescriptorProto_LABEL_REQUIRED:
+		return "required "
+	}
+	if w.file.GetSyntax() == "proto3" && !fd.GetProto3Optional() {
+		return ""
+	}
+	return "optional "
+}
+
+func (w *sourceWriter) fieldType(fd *descriptorpb.FieldDescriptorProto, scope string) string {
+	switch fd.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptorpb.FieldDescriptorProto_TYPE_ENUM,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		return w.typeName(fd.GetTypeName(), scope)
+	}
+	if fd.Type == nil {
+		return w.typeName(fd.GetTypeName(), scope)
+	}
+	return strings.ToLower(strings.TrimPrefix(fd.GetType().String(), "TYPE_"))
+}
+
+// fieldOptions returns the options of the field fd in brackets,
+// including the default and json_name pseudo-options.
+func (w *sourceWriter) fieldOptions(fd *descriptorpb.FieldDescriptorProto, scope string) string {
+	var opts []string
+	if fd.DefaultValue != nil {
+		opts = append(opts, "default = "+formatDefault(fd))
+	}
+	if fd.JsonName != nil && fd.Extendee == nil && fd.GetJsonName() != jsonCamelCase(fd.GetName()) {
+		opts = append(opts, "json_name = "+quoteString(fd.GetJsonName(), true))
+	}
+	return w.compactOptions(opts, fd.GetOptions(), scope)
+}
+
+// compactOptions returns opts followed by the options in m, in brackets.
+func (w *sourceWriter) compactOptions(opts []string, m protoreflect.ProtoMessage, scope string) string {
+	for _, o := range w.options(m, scope) {
+		opts = append(opts, o.name+" = "+o.value)
+	}
+	if len(opts) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(opts, ", ") + "]"
+}
+
+func (w *sourceWriter) enumDecl(ed *descriptorpb.EnumDescriptorProto, path []int32, scope string) decl {
+	return decl{path: path, block: true, write: func() {
+		w.line(path, "enum %s {", ed.GetName())
+		w.indent++
+		if w.writeOptions(ed.GetOptions(), subPath(path, enumOptionsTag), fullName(scope, ed.GetName())) {
+			w.blank()
+		}
+		var decls []decl
+		for i, vd := range ed.Value {
+			vd, vpath := vd, subPath(path, enumValueTag, int32(i))
+			decls = append(decls, decl{path: vpath, write: func() {
+				w.line(vpath, "%s = %d%s;", vd.GetName(), vd.GetNumber(), w.compactOptions(nil, vd.GetOptions(), scope))
+			}})
+		}
+		if len(ed.ReservedRange) > 0 {
+			rpath := subPath(path, enumReservedRangeTag, 0)
+			decls = append(decls, decl{path: rpath, write: func() {
+				var ranges []string
+				for _, r := range ed.ReservedRange {
+					ranges = append(ranges, formatRange(r.GetStart(), r.GetEnd(), math.MaxInt32))
+				}
+				w.line(rpath, "reserved %s;", strings.Join(ranges, ", "))
+			}})
+		}
+		if len(ed.ReservedName) > 0 {
+			rpath := subPath(path, enumReservedNameTag, 0)
+			decls = append(decls, decl{path: rpath, write: func() {
+				w.line(rpath, "reserved %s;", formatNames(ed.ReservedName))
+			}})
+		}
+		w.writeDecls(decls, scope)
+		w.indent--
+		w.line(nil, "}")
+	}}
+}
+
+func (w *sourceWriter) serviceDecl(sd *descriptorpb.ServiceDescriptorProto, path []int32, scope string) decl {
+	return decl{path: path, block: true, write: func() {
+		w.line(path, "service %s {", sd.GetName())
+		w.indent++
+		if w.writeOptions(sd.GetOptions(), subPath(path, serviceOptionsTag), scope) {
+			w.blank()
+		}
+		var decls []decl
+		for i, md := range sd.Method {
+			md, mpath := md, subPath(path, serviceMethodTag, int32(i))
+			d := decl{path: mpath}
+			opts := w.options(md.GetOptions(), scope)
+			d.block = len(opts) > 0
+			d.write = func() {
+				var in, out string
+				if md.GetClientStreaming() {
+					in = "stream "
+				}
+				if md.GetServerStreaming() {
+					out = "stream "
+				}
+				sig := fmt.Sprintf("rpc %s(%s%s) returns (%s%s)", md.GetName(),
+					in, w.typeName(md.GetInputType(), scope),
+					out, w.typeName(md.GetOutputType(), scope))
+				if len(opts) == 0 {
+					w.line(mpath, "%s;", sig)
+					return
+				}
+				w.line(mpath, "%s {", sig)
+				w.indent++
+				w.writeOptions(md.GetOptions(), subPath(mpath, methodOptionsTag), scope)
+				w.indent--
+				w.line(nil, "}")
+			}
+			decls = append(decls, d)
+		}
+		w.writeDecls(decls, scope)
+		w.indent--
+		w.line(nil, "}")
+	}}
+}
+
+// writeOptions writes an option statement for each option in m,
+// and reports whether there were any.
+func (w *sourceWriter) writeOptions(m protoreflect.ProtoMessage, path []int32, scope string) bool {
+	opts := w.options(m, scope)
+	var prev int32
+	for _, o := range opts {
+		// Only the first statement setting a repeated option has a location.
+		var opath []int32
+		if o.number != prev {
+			opath = subPath(path, o.number)
+		}
+		prev = o.number
+		w.writeLeadingComments(opath)
+		w.line(opath, "option %s = %s;", o.name, o.value)
+	}
+	return len(opts) > 0
+}
+
+type sourceOption struct {
+	number int32
+	name   string
+	value  string
+}
+
+// options returns the options set in the options message m,
+// ordered by field number.
+func (w *sourceWriter) options(m protoreflect.ProtoMessage, scope string) []sourceOption {
+	mr := m.ProtoReflect()
+	if !mr.IsValid() {
+		return nil
+	}
+	if len(mr.GetUnknown()) > 0 {
+		mr = w.resolveOptions(mr)
+	}
+
+	var fds []protoreflect.FieldDescriptor
+	mr.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.Number() != uninterpretedOptionNumber {
+			fds = append(fds, fd)
+		}
+		return true
+	})
+	sort.Slice(fds, func(i, j int) bool { return fds[i].Number() < fds[j].Number() })
+
+	var opts []sourceOption
+	for _, fd := range fds {
+		name := string(fd.Name())
+		if fd.IsExtension() {
+			name = "(" + w.typeName("."+string(fd.FullName()), scope) + ")"
+		}
+		v := mr.Get(fd)
+		if fd.IsList() {
+			lv := v.List()
+			for i := 0; i < lv.Len(); i++ {
+				opts = append(opts, sourceOption{int32(fd.Number()), name, w.formatValue(fd, lv.Get(i))})
+			}
+			continue
+		}
+		opts = append(opts, sourceOption{int32(fd.Number()), name, w.formatValue(fd, v)})
+	}
+	return opts
+}
+
+// resolveOptions parses the unknown fields of the options message m
+// as the custom options known to the resolver.
+func (w *sourceWriter) resolveOptions(m protoreflect.Message) protoreflect.Message {
+	resolver := w.Resolver
+	if resolver == nil {
+		resolver = protoregistry.GlobalTypes
+	}
+	m2, err := resolveOptions(m, resolver)
+	if err != nil {
+		w.fail("%v", err)
+		return m
+	}
+	if b := m2.GetUnknown(); len(b) > 0 {
+		var nums []string
+		for len(b) > 0 {
+			num, _, n := protowire.ConsumeField(b)
+			if n < 0 {
+				break
+			}
+			nums = append(nums, strconv.Itoa(int(num)))
+			b = b[n:]
+		}
+		w.fail("unresolved %v options with field numbers %s", m.Descriptor().FullName(), strings.Join(nums, ", "))
+	}
+	return m2
+}
+
+// formatValue formats the value v of the field fd as it appears in an option.
+func (w *sourceWriter) formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return w.formatAggregate(v.Message())
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return strconv.Itoa(int(v.Enum()))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(v.Uint(), 10)
+	case protoreflect.FloatKind:
+		return formatFloat(v.Float(), 32)
+	case protoreflect.DoubleKind:
+		return formatFloat(v.Float(), 64)
+	case protoreflect.StringKind:
+		return quoteString(v.String(), true)
+	case protoreflect.BytesKind:
+		return quoteString(string(v.Bytes()), false)
+	}
+	w.fail("invalid kind %v of option %v", fd.Kind(), fd.FullName())
+	return ""
+}
+
+// formatAggregate formats the message m in the text format,
+// as the value of an option.
+func (w *sourceWriter) formatAggregate(m protoreflect.Message) string {
+	if len(m.GetUnknown()) > 0 {
+		m = w.resolveOptions(m)
+	}
+	var fds []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fds = append(fds, fd)
+		return true
+	})
+	sort.Slice(fds, func(i, j int) bool { return fds[i].Number() < fds[j].Number() })
+
+	var fields []string
+	for _, fd := range fds {
+		name := string(fd.Name())
+		switch {
+		case fd.IsExtension():
+			name = "[" + string(fd.FullName()) + "]"
+		case fd.Kind() == protoreflect.GroupKind:
+			name = string(fd.Message().Name())
+		}
+		field := func(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+			if fd.Message() != nil {
+				return name + " " + w.formatValue(fd, v)
+			}
+			return name + ": " + w.formatValue(fd, v)
+		}
+		v := m.Get(fd)
+		switch {
+		case fd.IsList():
+			lv := v.List()
+			for i := 0; i < lv.Len(); i++ {
+				fields = append(fields, field(fd, lv.Get(i)))
+			}
+		case fd.IsMap():
+			kfd, vfd := fd.MapKey(), fd.MapValue()
+			var keys []protoreflect.MapKey
+			v.Map().Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, k)
+				return true
+			})
+			sort.Slice(keys, func(i, j int) bool {
+				return w.formatValue(kfd, keys[i].Value()) < w.formatValue(kfd, keys[j].Value())
+			})
+			for _, k := range keys {
+				entry := "key: " + w.formatValue(kfd, k.Value())
+				if vfd.Message() != nil {
+					entry += " value " + w.formatValue(vfd, v.Map().Get(k))
+				} else {
+					entry += " value: " + w.formatValue(vfd, v.Map().Get(k))
+				}
+				fields = append(fields, name+" { "+entry+" }")
+			}
+		default:
+			fields = append(fields, field(fd, v))
+		}
+	}
+	if len(fields) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(fields, " ") + " }"
+}
+
+// typeName returns the name by which the type or extension with the
+// fully-qualified name (which starts with a dot) is referred to from scope.
+// The name is relative to the innermost enclosing scope within the package
+// of the file that declares it, if that resolves to the same declaration.
+func (w *sourceWriter) typeName(name, scope string) string {
+	if !strings.HasPrefix(name, ".") {
+		return name // not fully-qualified
+	}
+	full := name[1:]
+	pkg := w.file.GetPackage()
+	for s := scope; ; s = parentScope(s) {
+		if s != "" && strings.HasPrefix(full, s+".") {
+			if rel := full[len(s)+1:]; !w.shadowed(rel, scope, s) {
+				return rel
+			}
+		}
+		if s == pkg || s == "" {
+			break
+		}
+	}
+	if !w.shadowed(full, scope, "") {
+		return full
+	}
+	return name
+}
+
+// shadowed reports whether the first component of the relative name rel
+// may be found in a scope enclosed by stop when looked up from scope.
+func (w *sourceWriter) shadowed(rel, scope, stop string) bool {
+	first := rel
+	if i := strings.IndexByte(rel, '.'); i >= 0 {
+		first = rel[:i]
+	}
+	for s := scope; s != stop && s != ""; s = parentScope(s) {
+		if c := s + "." + first; w.types[c] || w.packages[c] {
+			return true
+		}
+	}
+	return false
+}
+
+// formatRange formats the inclusive range of numbers from start to end,
+// where max is the largest valid number.
+func formatRange(start, end, max int32) string {
+	switch {
+	case start == end:
+		return strconv.Itoa(int(start))
+	case end == max:
+		return fmt.Sprintf("%d to max", start)
+	default:
+		return fmt.Sprintf("%d to %d", start, end)
+	}
+}
+
+func formatNames(names []string) string {
+	var quoted []string
+	for _, s := range names {
+		quoted = append(quoted, quoteString(s, true))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func formatFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsInf(f, +1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	case math.IsNaN(f):
+		return "nan"
+	}
+	return strconv.FormatFloat(f, 'g', -1, bitSize)
+}
+
+// formatDefault formats the default value of the field fd.
+func formatDefault(fd *descriptorpb.FieldDescriptorProto) string {
+	switch fd.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		return quoteString(fd.GetDefaultValue(), true)
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		// The default value of a bytes field is already escaped.
+		return `"` + fd.GetDefaultValue() + `"`
+	}
+	return fd.GetDefaultValue()
+}
+
+// quoteString quotes s as a string literal. Non-printable bytes are escaped,
+// as are all non-ASCII bytes unless utf8OK is set and s is valid UTF-8.
+func quoteString(s string, utf8OK bool) string {
+	utf8OK = utf8OK && utf8.ValidString(s)
+	var b []byte
+	b = append(b, '"')
+	for i := 0; i < len(s); {
+		r, n := rune(s[i]), 1
+		if utf8OK && r >= utf8.RuneSelf {
+			r, n = utf8.DecodeRuneInString(s[i:])
+		}
+		switch {
+		case r == '\n':
+			b = append(b, `\n`...)
+		case r == '\r':
+			b = append(b, `\r`...)
+		case r == '\t':
+			b = append(b, `\t`...)
+		case r == '"':
+			b = append(b, `\"`...)
+		case r == '\\':
+			b = append(b, `\\`...)
+		case r < utf8.RuneSelf && r >= 0x20 && r < 0x7f:
+			b = append(b, byte(r))
+		case utf8OK && r >= utf8.RuneSelf && unicode.IsPrint(r):
+			b = append(b, s[i:i+n]...)
+		default:
+			for _, c := range []byte(s[i : i+n]) {
+				b = append(b, fmt.Sprintf(`\%03o`, c)...)
+			}
+		}
+		i += n
+	}
+	return string(append(b, '"'))
+}
+
+// jsonCamelCase returns the default JSON name of the field with the given name.
+func jsonCamelCase(s string) string {
+	var b []byte
+	upper := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_':
+			upper = true
+		case upper && 'a' <= c && c <= 'z':
+			b = append(b, c-'a'+'A')
+			upper = false
+		default:
+			b = append(b, c)
+			upper = false
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,421 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package descriptor
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoparse"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func parseFile(t *testing.T, s string) *descpb.FileDescriptorProto {
+	fd := new(descpb.FileDescriptorProto)
+	if err := proto.UnmarshalText(s, fd); err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+// optionsResolver returns a resolver for the custom options declared
+// in source_test/options.proto.
+func optionsResolver(t *testing.T) *protoregistry.Types {
+	fd := parseFile(t, `
+		name: "source_test/options.proto"
+		package: "source.test"
+		dependency: "google/protobuf/descriptor.proto"
+		message_type {
+			name: "Info"
+			field { name: "owner" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+			field { name: "tags" number: 2 label: LABEL_REPEATED type: TYPE_STRING }
+		}
+		extension { name: "note" number: 50000 label: LABEL_OPTIONAL type: TYPE_STRING extendee: ".google.protobuf.FieldOptions" }
+		extension { name: "info" number: 50001 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".source.test.Info" extendee: ".google.protobuf.MessageOptions" }
+	`)
+	file, err := protodesc.NewFile(fd, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := new(protoregistry.Types)
+	for i := 0; i < file.Extensions().Len(); i++ {
+		if err := types.RegisterExtension(dynamicpb.NewExtensionType(file.Extensions().Get(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return types
+}
+
+func TestSourcePrinter(t *testing.T) {
+	fd := parseFile(t, `
+		name: "source_test/test.proto"
+		package: "source.test"
+		syntax: "proto3"
+		dependency: ["google/protobuf/any.proto", "source_test/options.proto"]
+		public_dependency: 0
+		options { go_package: "example.com/source/test" }
+		message_type {
+			name: "Request"
+			field { name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "name" }
+			field { name: "count" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 oneof_index: 1 proto3_optional: true json_name: "count" }
+			field { name: "items" number: 3 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".source.test.Request.ItemsEntry" json_name: "items" }
+			field { name: "any" number: 4 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".google.protobuf.Any" oneof_index: 0 json_name: "any" }
+			field { name: "item" number: 5 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".source.test.Request.Item" oneof_index: 0 json_name: "item" }
+			field { name: "ids" number: 6 label: LABEL_REPEATED type: TYPE_INT64 json_name: "identifiers" options { packed: false } }
+			field { name: "state" number: 7 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".source.test.Request.State" json_name: "state" }
+			nested_type {
+				name: "ItemsEntry"
+				field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "key" }
+				field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".source.test.Request.Item" json_name: "value" }
+				options { map_entry: true }
+			}
+			nested_type {
+				name: "Item"
+				field { name: "id" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "id" }
+			}
+			enum_type {
+				name: "State"
+				value { name: "STATE_UNSPECIFIED" number: 0 }
+				value { name: "STATE_OK" number: 1 }
+				value { name: "STATE_FINE" number: 1 options { deprecated: true } }
+				options { allow_alias: true }
+				reserved_range { start: 5 end: 10 }
+				reserved_range { start: 20 end: 2147483647 }
+				reserved_name: "STATE_OLD"
+			}
+			oneof_decl { name: "kind" }
+			oneof_decl { name: "_count" }
+			reserved_range { start: 8 end: 9 }
+			reserved_range { start: 10 end: 13 }
+			reserved_name: ["legacy", "old"]
+		}
+		service {
+			name: "Store"
+			method { name: "Get" input_type: ".source.test.Request" output_type: ".source.test.Request.Item" }
+			method {
+				name: "Watch" input_type: ".source.test.Request" output_type: ".google.protobuf.Any"
+				client_streaming: true server_streaming: true options { deprecated: true }
+			}
+		}
+	`)
+	// Set custom options unknown to the global registry.
+	md := fd.MessageType[0]
+	var b []byte
+	b = protowire.AppendTag(b, 50000, protowire.BytesType)
+	b = protowire.AppendString(b, "internal \"only\"")
+	md.Field[5].Options.ProtoReflect().SetUnknown(b)
+	var info []byte
+	info = protowire.AppendTag(info, 1, protowire.BytesType)
+	info = protowire.AppendString(info, "platform")
+	info = protowire.AppendTag(info, 2, protowire.BytesType)
+	info = protowire.AppendString(info, "a")
+	info = protowire.AppendTag(info, 2, protowire.BytesType)
+	info = protowire.AppendString(info, "b")
+	md.Options = new(descpb.MessageOptions)
+	md.Options.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, 50001, protowire.BytesType), info))
+
+	p := &SourcePrinter{Resolver: optionsResolver(t)}
+	got, err := p.Source(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `syntax = "proto3";
+
+package source.test;
+
+import public "google/protobuf/any.proto";
+import "source_test/options.proto";
+
+option go_package = "example.com/source/test";
+
+message Request {
+  option (info) = { owner: "platform" tags: "a" tags: "b" };
+
+  string name = 1;
+  optional int32 count = 2;
+  map<string, Item> items = 3;
+
+  oneof kind {
+    google.protobuf.Any any = 4;
+    Item item = 5;
+  }
+
+  repeated int64 ids = 6 [json_name = "identifiers", packed = false, (note) = "internal \"only\""];
+  State state = 7;
+  reserved 8, 10 to 12;
+  reserved "legacy", "old";
+
+  message Item {
+    string id = 1;
+  }
+
+  enum State {
+    option allow_alias = true;
+
+    STATE_UNSPECIFIED = 0;
+    STATE_OK = 1;
+    STATE_FINE = 1 [deprecated = true];
+    reserved 5 to 10, 20 to max;
+    reserved "STATE_OLD";
+  }
+}
+
+service Store {
+  rpc Get(Request) returns (Request.Item);
+
+  rpc Watch(stream Request) returns (stream google.protobuf.Any) {
+    option deprecated = true;
+  }
+}
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Source() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Without a resolver for them, custom options are an error.
+	if _, err := new(SourcePrinter).Source(fd); err == nil || !strings.Contains(err.Error(), "50001") {
+		t.Errorf("Source() with unresolved options: got error %v, want error about field 50001", err)
+	}
+}
+
+func TestSourcePrinterProto2(t *testing.T) {
+	fd := parseFile(t, `
+		name: "source_test/proto2.proto"
+		package: "source.test"
+		syntax: "proto2"
+		dependency: "google/protobuf/descriptor.proto"
+		message_type {
+			name: "Container"
+			field { name: "id" number: 1 label: LABEL_REQUIRED type: TYPE_INT64 }
+			field { name: "label" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING default_value: "caf\303\251\n" }
+			field { name: "blob" number: 3 label: LABEL_OPTIONAL type: TYPE_BYTES default_value: "\\000\\377" }
+			field { name: "ratio" number: 4 label: LABEL_OPTIONAL type: TYPE_FLOAT default_value: "-inf" }
+			field { name: "kind" number: 5 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".source.test.Kind" default_value: "KIND_B" }
+			field { name: "entry" number: 6 label: LABEL_REPEATED type: TYPE_GROUP type_name: ".source.test.Container.Entry" }
+			field { name: "choice" number: 7 label: LABEL_OPTIONAL type: TYPE_GROUP type_name: ".source.test.Container.Choice" oneof_index: 0 }
+			nested_type {
+				name: "Entry"
+				field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+			}
+			nested_type {
+				name: "Choice"
+				field { name: "value" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			}
+			oneof_decl { name: "pick" }
+			extension_range { start: 100 end: 200 options { } }
+			extension_range { start: 1000 end: 536870912 }
+		}
+		message_type {
+			name: "Set"
+			options { message_set_wire_format: true }
+			extension_range { start: 4 end: 2147483647 }
+		}
+		message_type {
+			name: "Extra"
+			field { name: "note" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+		}
+		enum_type {
+			name: "Kind"
+			value { name: "KIND_A" number: 1 }
+			value { name: "KIND_B" number: 2 }
+		}
+		extension { name: "extra" number: 100 label: LABEL_OPTIONAL type: TYPE_GROUP type_name: ".source.test.Extra" extendee: ".source.test.Container" }
+		extension { name: "tag" number: 101 label: LABEL_REPEATED type: TYPE_STRING extendee: ".source.test.Container" }
+		extension { name: "weight" number: 50100 label: LABEL_OPTIONAL type: TYPE_DOUBLE extendee: ".google.protobuf.FieldOptions" }
+	`)
+	got, err := new(SourcePrinter).Source(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `syntax = "proto2";
+
+package source.test;
+
+import "google/protobuf/descriptor.proto";
+
+message Container {
+  required int64 id = 1;
+  optional string label = 2 [default = "café\n"];
+  optional bytes blob = 3 [default = "\000\377"];
+  optional float ratio = 4 [default = -inf];
+  optional Kind kind = 5 [default = KIND_B];
+
+  repeated group Entry = 6 {
+    optional string key = 1;
+  }
+
+  oneof pick {
+    group Choice = 7 {
+      optional int32 value = 1;
+    }
+  }
+
+  extensions 100 to 199;
+  extensions 1000 to max;
+}
+
+message Set {
+  option message_set_wire_format = true;
+
+  extensions 4 to max;
+}
+
+enum Kind {
+  KIND_A = 1;
+  KIND_B = 2;
+}
+
+extend Container {
+  optional group Extra = 100 {
+    optional string note = 1;
+  }
+
+  repeated string tag = 101;
+}
+
+extend google.protobuf.FieldOptions {
+  optional double weight = 50100;
+}
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Source() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSourcePrinterComments(t *testing.T) {
+	fd := parseFile(t, `
+		name: "source_test/comments.proto"
+		package: "source.test"
+		syntax: "proto3"
+		message_type {
+			name: "Message"
+			field { name: "a" number: 1 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			field { name: "b" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			field { name: "c" number: 3 label: LABEL_OPTIONAL type: TYPE_INT32 }
+		}
+		enum_type {
+			name: "Enum"
+			value { name: "ZERO" number: 0 }
+		}
+		source_code_info {
+			location { path: [12] span: [0, 0, 18] leading_detached_comments: " Copyright notice.\n" }
+			location { path: [2] span: [2, 0, 20] leading_comments: " The package.\n" }
+			location { path: [4, 0] span: [9, 0, 16, 1] leading_comments: " A message.\n Second line.\n" trailing_comments: " Opening.\n" }
+			location { path: [4, 0, 2, 0] span: [10, 2, 12] trailing_comments: " Same line.\n" }
+			location { path: [4, 0, 2, 1] span: [11, 2, 12] trailing_comments: " Following\n lines.\n" }
+			location { path: [4, 0, 2, 2] span: [14, 2, 12] leading_detached_comments: " Detached.\n" leading_comments: " Leading.\n" }
+			location { path: [5, 0] span: [4, 0, 6, 1] leading_comments: " The enum comes first.\n" }
+			location { path: [5, 0, 2, 0] span: [5, 2, 11] }
+		}
+	`)
+	got, err := new(SourcePrinter).Source(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `// Copyright notice.
+
+syntax = "proto3";
+
+// The package.
+package source.test;
+
+// The enum comes first.
+enum Enum {
+  ZERO = 0;
+}
+
+// A message.
+// Second line.
+message Message { // Opening.
+  int32 a = 1; // Same line.
+  int32 b = 2;
+  // Following
+  // lines.
+
+  // Detached.
+
+  // Leading.
+  int32 c = 3;
+}
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Source() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSourcePrinterRegisteredFiles(t *testing.T) {
+	protoregistry.GlobalFiles.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		if _, err := new(SourcePrinter).Source(protodesc.ToFileDescriptorProto(file)); err != nil {
+			t.Errorf("Source(%q) error: %v", file.Path(), err)
+		}
+		return true
+	})
+}
+
+func TestSourcePrinterRoundTrip(t *testing.T) {
+	sources := make(map[string]string)
+	var names []string
+	protoregistry.GlobalFiles.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		src, err := new(SourcePrinter).Source(protodesc.ToFileDescriptorProto(file))
+		if err != nil {
+			t.Fatalf("Source(%q) error: %v", file.Path(), err)
+		}
+		sources[file.Path()] = src
+		names = append(names, file.Path())
+		return true
+	})
+	sort.Strings(names)
+
+	p := &protoparse.Parser{
+		Accessor: func(name string) (io.ReadCloser, error) {
+			src, ok := sources[name]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return ioutil.NopCloser(strings.NewReader(src)), nil
+		},
+	}
+	for _, name := range names {
+		fds, err := p.ParseFiles(name)
+		if err != nil {
+			t.Errorf("parsing the source of %q: %v", name, err)
+			continue
+		}
+		file, _ := protoregistry.GlobalFiles.FindFileByPath(name)
+		want := protodesc.ToFileDescriptorProto(file)
+		// The printed source populates json_name for every field.
+		setJSONNames(want.MessageType, file.Messages())
+		if diff := cmp.Diff(want, fds[0], protocmp.Transform(), protocmp.IgnoreFields(want, "source_code_info")); diff != "" {
+			t.Errorf("parsed source of %q mismatch (-want +got):\n%s", name, diff)
+		}
+	}
+}
+
+// setJSONNames populates the json_name of the fields of the messages mds
+// described by ms.
+func setJSONNames(mds []*descpb.DescriptorProto, ms protoreflect.MessageDescriptors) {
+	for i, md := range mds {
+		m := ms.Get(i)
+		for j, f := range md.Field {
+			if f.JsonName == nil {
+				f.JsonName = proto.String(m.Fields().Get(j).JSONName())
+			}
+		}
+		setJSONNames(md.NestedType, m.Messages())
+	}
+}
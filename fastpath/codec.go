@@ -0,0 +1,127 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastpath
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The generated marshaling methods encode a message backwards, from the end
+// of a buffer sized by the Size method: the Put functions write a value
+// ending at the index i of b, and return the index of its first byte.
+
+// PutVarint writes the varint encoding of v ending at the index i of b.
+func PutVarint(b []byte, i int, v uint64) int {
+	i -= protowire.SizeVarint(v)
+	protowire.AppendVarint(b[i:i], v)
+	return i
+}
+
+// PutFixed32 writes the fixed32 encoding of v ending at the index i of b.
+func PutFixed32(b []byte, i int, v uint32) int {
+	i -= 4
+	binary.LittleEndian.PutUint32(b[i:], v)
+	return i
+}
+
+// PutFixed64 writes the fixed64 encoding of v ending at the index i of b.
+func PutFixed64(b []byte, i int, v uint64) int {
+	i -= 8
+	binary.LittleEndian.PutUint64(b[i:], v)
+	return i
+}
+
+// PutBytes writes the length-prefixed encoding of v ending at the index i of b.
+func PutBytes(b []byte, i int, v []byte) int {
+	i -= len(v)
+	copy(b[i:], v)
+	return PutVarint(b, i, uint64(len(v)))
+}
+
+// PutString writes the length-prefixed encoding of v ending at the index i of b.
+func PutString(b []byte, i int, v string) int {
+	i -= len(v)
+	copy(b[i:], v)
+	return PutVarint(b, i, uint64(len(v)))
+}
+
+// sizedMarshaler and unmarshaler are implemented by the messages with
+// generated marshaling methods.
+type (
+	sizedMarshaler interface {
+		Size() int
+		MarshalToSizedBuffer([]byte) (int, error)
+	}
+	unmarshaler interface {
+		Unmarshal([]byte) error
+	}
+)
+
+// MarshalMessage writes the encoding of a nested message m, without its
+// length, at the end of b, and returns its length. Messages without generated
+// marshaling methods are encoded by protobuf reflection, with deterministic
+// ordering of map entries.
+func MarshalMessage(b []byte, m proto.Message) (int, error) {
+	if sm, ok := m.(sizedMarshaler); ok {
+		return sm.MarshalToSizedBuffer(b)
+	}
+	n := proto.Size(m)
+	if n == 0 {
+		return 0, nil
+	}
+	i := len(b) - n
+	out, err := protoV2.MarshalOptions{
+		AllowPartial:  true,
+		Deterministic: true,
+	}.MarshalAppend(b[i:i:len(b)], proto.MessageV2(m))
+	if err != nil {
+		return 0, err
+	}
+	if len(out) != n {
+		return 0, fmt.Errorf("proto: size of %v changed during marshaling", proto.MessageReflect(m).Descriptor().FullName())
+	}
+	return n, nil
+}
+
+// UnmarshalMessage merges the encoding in b into a nested message m.
+// Messages without generated marshaling methods are decoded by protobuf
+// reflection.
+func UnmarshalMessage(b []byte, m proto.Message) error {
+	if u, ok := m.(unmarshaler); ok {
+		return u.Unmarshal(b)
+	}
+	return protoV2.UnmarshalOptions{
+		AllowPartial: true,
+		Merge:        true,
+	}.Unmarshal(b, proto.MessageV2(m))
+}
+
+var errFieldNumber = errors.New("proto: invalid field number")
+
+// ConsumeTag parses b as a field tag, as protowire.ConsumeTag, and reports
+// an error if it is invalid.
+func ConsumeTag(b []byte) (protowire.Number, protowire.Type, int, error) {
+	num, typ, n := protowire.ConsumeTag(b)
+	if n < 0 {
+		return 0, 0, 0, protowire.ParseError(n)
+	}
+	if num > protowire.MaxValidNumber {
+		return 0, 0, 0, errFieldNumber
+	}
+	return num, typ, n, nil
+}
+
+// InvalidUTF8 returns the error of a value of the string field name not
+// being valid UTF-8, as required by proto3.
+func InvalidUTF8(name protoreflect.FullName) error {
+	return fmt.Errorf("proto: field %v contains invalid UTF-8", name)
+}
@@ -0,0 +1,369 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastpath_test
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/golang/protobuf/internal/testprotos/fastpath_proto"
+)
+
+// codec is implemented by the messages with generated marshaling methods.
+type codec interface {
+	proto.Message
+	Size() int
+	Marshal() ([]byte, error)
+	MarshalToSizedBuffer([]byte) (int, error)
+	Unmarshal([]byte) error
+}
+
+var (
+	randomInts    = []int64{0, 1, -1, 127, 128, 300, math.MaxInt32, math.MinInt32, math.MaxUint32, math.MaxInt64, math.MinInt64}
+	randomFloats  = []float64{0, math.Copysign(0, -1), 1.5, -1e300, math.Inf(1), math.Inf(-1), math.NaN()}
+	randomStrings = []string{"", "a", "héllo", "☺", strings.Repeat("x", 200), "\xff"}
+)
+
+// randomUnknown are encodings of unknown fields.
+var randomUnknown = [][]byte{
+	protowire.AppendVarint(protowire.AppendTag(nil, 1000, protowire.VarintType), 1),
+	protowire.AppendBytes(protowire.AppendTag(nil, 2000, protowire.BytesType), []byte("u")),
+	protowire.AppendTag(protowire.AppendTag(nil, 3000, protowire.StartGroupType), 3000, protowire.EndGroupType),
+}
+
+// randomValue returns a random value of a scalar field, favoring edge cases.
+func randomValue(r *rand.Rand, fd protoreflect.FieldDescriptor) protoreflect.Value {
+	i := randomInts[r.Intn(len(randomInts))]
+	if r.Intn(2) == 0 {
+		i = r.Int63() >> uint(r.Intn(64))
+	}
+	f := randomFloats[r.Intn(len(randomFloats))]
+	if r.Intn(2) == 0 {
+		f = r.NormFloat64()
+	}
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(r.Intn(2) == 0)
+	case protoreflect.EnumKind:
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i))
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(i))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(i))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(i)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(i))
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(f))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(f)
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(randomStrings[r.Intn(len(randomStrings))])
+	}
+	b := make([]byte, r.Intn(4))
+	if r.Intn(8) == 0 {
+		b = make([]byte, 300)
+	}
+	r.Read(b)
+	return protoreflect.ValueOfBytes(b)
+}
+
+// populate sets random values to about half of the fields of m, and adds
+// unknown fields, with messages nested up to the given depth.
+func populate(r *rand.Rand, m protoreflect.Message, depth int) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if r.Intn(2) == 0 || fd.Message() != nil && !fd.IsMap() && depth == 0 {
+			continue
+		}
+		switch {
+		case fd.IsList():
+			l := m.Mutable(fd).List()
+			for n := r.Intn(4); n > 0; n-- {
+				v := l.NewElement()
+				if fd.Message() != nil {
+					populate(r, v.Message(), depth-1)
+				} else {
+					v = randomValue(r, fd)
+				}
+				l.Append(v)
+			}
+		case fd.IsMap():
+			mv := m.Mutable(fd).Map()
+			for n := r.Intn(4); n > 0; n-- {
+				v := mv.NewValue()
+				if fd.MapValue().Message() != nil {
+					if depth == 0 {
+						break
+					}
+					populate(r, v.Message(), depth-1)
+				} else {
+					v = randomValue(r, fd.MapValue())
+				}
+				mv.Set(randomValue(r, fd.MapKey()).MapKey(), v)
+			}
+		case fd.Message() != nil:
+			populate(r, m.Mutable(fd).Message(), depth-1)
+		default:
+			m.Set(fd, randomValue(r, fd))
+		}
+	}
+	if r.Intn(4) == 0 {
+		m.SetUnknown(append(m.GetUnknown(), randomUnknown[r.Intn(len(randomUnknown))]...))
+	}
+}
+
+// randomMessages returns the messages of the Equal tests, followed by random
+// messages of the types with generated marshaling methods.
+func randomMessages() []codec {
+	var ms []codec
+	for _, m := range messages() {
+		ms = append(ms, m)
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, m := range []codec{&pb.Message{}, &pb.Scalars{}, &pb.Lists{}, &pb.Legacy{}} {
+		for i := 0; i < 200; i++ {
+			m := proto.MessageReflect(m).New()
+			populate(r, m, 3)
+			ms = append(ms, m.Interface().(codec))
+		}
+	}
+	return ms
+}
+
+func TestMarshal(t *testing.T) {
+	for i, m := range randomMessages() {
+		want, wantErr := protoV2.MarshalOptions{Deterministic: true, AllowPartial: true}.Marshal(proto.MessageV2(m))
+		got, err := m.Marshal()
+		if (err != nil) != (wantErr != nil) || err == nil && !bytes.Equal(got, want) {
+			t.Errorf("message %d: Marshal = %x, %v, want %x, %v\nmessage: %v", i, got, err, want, wantErr, m)
+			continue
+		}
+		wantSize := protoV2.Size(proto.MessageV2(m))
+		if n := m.Size(); n != wantSize {
+			t.Errorf("message %d: Size = %d, want %d\nmessage: %v", i, n, wantSize, m)
+		}
+		if n := proto.Size(m); n != wantSize {
+			t.Errorf("message %d: proto.Size = %d, want %d\nmessage: %v", i, n, wantSize, m)
+		}
+		if wantErr != nil {
+			continue
+		}
+		got, err = proto.Marshal(m)
+		if err != nil && !isRequiredNotSet(err) || !bytes.Equal(got, want) {
+			t.Errorf("message %d: proto.Marshal = %x, %v, want %x\nmessage: %v", i, got, err, want, m)
+		}
+		prefix := []byte("prefix")
+		b := proto.NewBuffer(prefix)
+		if err := b.Marshal(m); err != nil && !isRequiredNotSet(err) {
+			t.Errorf("message %d: Buffer.Marshal error: %v", i, err)
+		} else if got := b.Bytes(); !bytes.Equal(got, append(prefix, want...)) {
+			t.Errorf("message %d: Buffer.Marshal = %x, want %x%x", i, got, prefix, want)
+		}
+	}
+}
+
+func isRequiredNotSet(err error) bool {
+	_, ok := err.(*proto.RequiredNotSetError)
+	return ok
+}
+
+// valid reports whether a message has a valid encoding, with valid UTF-8
+// strings.
+func valid(m codec) bool {
+	_, err := protoV2.MarshalOptions{AllowPartial: true}.Marshal(proto.MessageV2(m))
+	return err == nil
+}
+
+// mutations returns variants of an encoding: truncated, with a random byte
+// modified, and repeated.
+func mutations(r *rand.Rand, b []byte) [][]byte {
+	bs := [][]byte{b, append(append([]byte{}, b...), b...)}
+	if len(b) > 0 {
+		bs = append(bs, b[:r.Intn(len(b))])
+		for i := 0; i < 4; i++ {
+			c := append([]byte{}, b...)
+			c[r.Intn(len(c))] = byte(r.Intn(256))
+			bs = append(bs, c)
+		}
+	}
+	return bs
+}
+
+func TestUnmarshal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ms := randomMessages()
+	for i, m := range ms {
+		b, err := protoV2.MarshalOptions{Deterministic: true, AllowPartial: true}.Marshal(proto.MessageV2(m))
+		if err != nil {
+			continue
+		}
+		// Unmarshal into an empty message and into the previous message of
+		// the same type, for the merge of the input.
+		bases := []codec{proto.MessageReflect(m).New().Interface().(codec)}
+		if i > 0 && proto.MessageReflect(ms[i-1]).Type() == proto.MessageReflect(m).Type() && valid(ms[i-1]) {
+			bases = append(bases, ms[i-1])
+		}
+		for j, in := range mutations(r, b) {
+			for k, base := range bases {
+				want := protoV2.Clone(proto.MessageV2(base))
+				wantErr := protoV2.UnmarshalOptions{AllowPartial: true, Merge: true}.Unmarshal(in, want)
+				got := proto.Clone(base).(codec)
+				err := got.Unmarshal(in)
+				if (err != nil) != (wantErr != nil) {
+					t.Errorf("message %d, input %d, base %d: Unmarshal(%x) error = %v, want %v", i, j, k, in, err, wantErr)
+					continue
+				}
+				if err == nil && (!reflectEqual(got, proto.MessageV1(want)) || !bytes.Equal(marshal(t, proto.MessageV2(got)), marshal(t, want))) {
+					t.Errorf("message %d, input %d, base %d: Unmarshal(%x):\ngot:  %v\nwant: %v", i, j, k, in, got, want)
+				}
+			}
+		}
+
+		got := proto.MessageReflect(m).New().Interface().(codec)
+		if err := proto.Unmarshal(b, got); err != nil && !isRequiredNotSet(err) {
+			t.Errorf("message %d: proto.Unmarshal error: %v", i, err)
+		}
+		if !reflectEqual(got, m) {
+			t.Errorf("message %d: proto.Unmarshal:\ngot:  %v\nwant: %v", i, got, m)
+		}
+	}
+}
+
+// field returns the encoding of a field with a tag and a value.
+func field(num protowire.Number, typ protowire.Type, v ...byte) []byte {
+	return append(protowire.AppendTag(nil, num, typ), v...)
+}
+
+// fieldBytes returns the encoding of a field of wire type bytes.
+func fieldBytes(num protowire.Number, v ...[]byte) []byte {
+	return protowire.AppendBytes(protowire.AppendTag(nil, num, protowire.BytesType), bytes.Join(v, nil))
+}
+
+func TestUnmarshalWireForms(t *testing.T) {
+	tests := []struct {
+		desc string
+		m    codec
+		in   []byte
+	}{
+		{"unpacked elements of a packed field", &pb.Message{}, bytes.Join([][]byte{field(6, protowire.VarintType, 1), field(6, protowire.VarintType, 2)}, nil)},
+		{"packed elements of an unpacked field", &pb.Lists{}, fieldBytes(15, []byte{1, 2})},
+		{"packed and unpacked elements", &pb.Legacy{}, bytes.Join([][]byte{fieldBytes(16, []byte{0, 1}), field(16, protowire.VarintType, 2)}, nil)},
+		{"known field of another wire type", &pb.Scalars{}, field(2, protowire.Fixed32Type, 1, 0, 0, 0)},
+		{"group of a message field", &pb.Message{}, field(1, protowire.StartGroupType, 0xc)},
+		{"field number 0", &pb.Scalars{}, field(0, protowire.VarintType, 1)},
+		{"field number above the maximum", &pb.Scalars{}, field(protowire.MaxValidNumber+1, protowire.VarintType, 1)},
+		{"maximum field number", &pb.Lists{}, field(protowire.MaxValidNumber, protowire.VarintType, 1)},
+		{"invalid wire type", &pb.Scalars{}, []byte{0x0e}},
+		{"end group", &pb.Scalars{}, field(1, protowire.EndGroupType)},
+		{"mismatched end group", &pb.Legacy{}, bytes.Join([][]byte{field(7, protowire.StartGroupType), field(8, protowire.EndGroupType)}, nil)},
+		{"overflowing varint", &pb.Scalars{}, field(2, protowire.VarintType, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02)},
+		{"truncated packed field", &pb.Lists{}, fieldBytes(4, []byte{1, 2, 3})},
+		{"invalid UTF-8 in proto3", &pb.Scalars{}, fieldBytes(14, []byte("\xff"))},
+		{"invalid UTF-8 in a proto3 map key", &pb.Lists{}, fieldBytes(18, fieldBytes(1, []byte("\xff")))},
+		{"invalid UTF-8 in proto2", &pb.Legacy{}, fieldBytes(4, []byte("\xff"))},
+		{"map entry without key and value", &pb.Message{}, fieldBytes(13)},
+		{"map entry with unknown fields", &pb.Message{}, fieldBytes(14, field(3, protowire.VarintType, 1), fieldBytes(2, []byte("b")), field(1, protowire.VarintType, 5))},
+		{"map entry with a value of another wire type", &pb.Message{}, fieldBytes(16, field(2, protowire.Fixed32Type, 1, 0, 0, 0))},
+		{"map entry with repeated values", &pb.Message{}, fieldBytes(13, fieldBytes(2, field(6, protowire.VarintType, 1)), fieldBytes(2, field(6, protowire.VarintType, 2)))},
+		{"repeated message", &pb.Message{}, bytes.Join([][]byte{fieldBytes(1, field(2, protowire.VarintType, 1)), fieldBytes(1, field(3, protowire.VarintType, 1))}, nil)},
+		{"repeated oneof message", &pb.Message{}, bytes.Join([][]byte{fieldBytes(22, field(6, protowire.VarintType, 1)), fieldBytes(22, field(6, protowire.VarintType, 2))}, nil)},
+		{"oneof of another field", &pb.Message{}, bytes.Join([][]byte{fieldBytes(22, field(6, protowire.VarintType, 1)), field(18, protowire.VarintType, 2)}, nil)},
+		{"empty implicit bytes", &pb.Scalars{By: []byte("b")}, fieldBytes(15)},
+		{"empty optional bytes", &pb.Scalars{}, fieldBytes(20)},
+		{"zero sint32 above 32 bits", &pb.Legacy{}, field(17, protowire.VarintType, 0x80, 0x80, 0x80, 0x80, 0x10)},
+	}
+	for _, tt := range tests {
+		want := protoV2.Clone(proto.MessageV2(tt.m))
+		wantErr := protoV2.UnmarshalOptions{AllowPartial: true, Merge: true}.Unmarshal(tt.in, want)
+		got := proto.Clone(tt.m).(codec)
+		err := got.Unmarshal(tt.in)
+		if (err != nil) != (wantErr != nil) {
+			t.Errorf("%s: Unmarshal error = %v, want %v", tt.desc, err, wantErr)
+			continue
+		}
+		if err == nil && (!reflectEqual(got, proto.MessageV1(want)) || !bytes.Equal(marshal(t, proto.MessageV2(got)), marshal(t, want))) {
+			t.Errorf("%s: Unmarshal:\ngot:  %v\nwant: %v", tt.desc, got, want)
+		}
+	}
+}
+
+func TestMarshalDeterministic(t *testing.T) {
+	// Unlike protobuf reflection, proto.Marshal always orders map entries.
+	m := &pb.Message{BytesById: make(map[int64][]byte)}
+	for i := int64(0); i < 100; i++ {
+		m.BytesById[i] = []byte{byte(i)}
+	}
+	want := marshal(t, m)
+	for i := 0; i < 10; i++ {
+		if got, err := proto.Marshal(m); err != nil || !bytes.Equal(got, want) {
+			t.Fatalf("proto.Marshal = %x, %v, want %x", got, err, want)
+		}
+	}
+}
+
+func TestRequiredFields(t *testing.T) {
+	b, err := proto.Marshal(&pb.Legacy{I32: proto.Int32(1)})
+	if !isRequiredNotSet(err) {
+		t.Errorf("proto.Marshal error = %v, want a RequiredNotSetError", err)
+	}
+	if want := []byte{0x08, 0x01}; !bytes.Equal(b, want) {
+		t.Errorf("proto.Marshal = %x, want %x", b, want)
+	}
+	m := new(pb.Legacy)
+	if err := proto.Unmarshal(b, m); !isRequiredNotSet(err) {
+		t.Errorf("proto.Unmarshal error = nil, want a RequiredNotSetError")
+	}
+	if err := proto.Unmarshal(append(b, 0x30, 0x02), m); err != nil {
+		t.Errorf("proto.Unmarshal error = %v", err)
+	}
+	if m.GetI32() != 1 || m.GetId() != 2 {
+		t.Errorf("proto.Unmarshal = %v, want i32:1 id:2", m)
+	}
+}
+
+func TestMarshalInvalidUTF8(t *testing.T) {
+	for _, m := range []codec{
+		&pb.Scalars{S: "\xff"},
+		&pb.Message{Ss: []string{"a", "\xff"}},
+		&pb.Message{ByName: map[string]*pb.Message{"\xff": nil}},
+		&pb.Message{Kind: &pb.Message_OS{OS: "\xff"}},
+		&pb.Message{Child: &pb.Message{Scalars: &pb.Scalars{OptS: proto.String("\xff")}}},
+	} {
+		if _, err := m.Marshal(); err == nil || !strings.Contains(err.Error(), "invalid UTF-8") {
+			t.Errorf("Marshal of %v: error = %v, want invalid UTF-8", m, err)
+		}
+		if _, err := proto.Marshal(m); err == nil {
+			t.Errorf("proto.Marshal of %v: error = nil, want invalid UTF-8", m)
+		}
+	}
+	m := &pb.Legacy{S: proto.String("\xff"), Id: proto.Int64(1)}
+	if _, err := proto.Marshal(m); err != nil {
+		t.Errorf("proto.Marshal of %v: error = %v, want nil for proto2", m, err)
+	}
+}
+
+func TestNoCodec(t *testing.T) {
+	// Messages without Equal methods, with fields named like the marshaling
+	// methods, or opted out have no marshaling methods.
+	for _, m := range []interface{}{&pb.Extendable{}, &pb.Conflict{}, &pb.Sized{}, &pb.Message_Nested{}, &pb.Legacy_Group{}} {
+		if _, ok := m.(codec); ok {
+			t.Errorf("%T has generated marshaling methods", m)
+		}
+	}
+	if _, ok := interface{}(&pb.Sized{}).(interface{ XXX_Equal(proto.Message) bool }); !ok {
+		t.Errorf("%T has no generated Equal method", &pb.Sized{})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fastpath is the runtime of the methods generated for messages by
+// protoc-gen-go with the plugins=fastpath parameter.
+//
+// For each message, the generated Equal, Clone and MergeFrom methods behave
+// like proto.Equal, proto.Clone and proto.Merge, but access the fields of the
+// message directly instead of through protobuf reflection. Those functions
+// use the generated methods when available. Messages with extension ranges
+// or weak fields, and messages with fields named like the generated methods,
+// have no generated methods.
+//
+// The messages selected by the marshal_messages file option and the marshal
+// message option of fastpath.proto also have generated Size,
+// MarshalToSizedBuffer, Marshal and Unmarshal methods, used by proto.Size,
+// proto.Marshal and proto.Unmarshal. Their encoding is that of protobuf
+// reflection with deterministic ordering of map entries, whether or not
+// deterministic marshaling is requested.
+package fastpath
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+	protoV2 "google.golang.org/protobuf/proto"
+)
+
+// EqualFloat64 reports whether two float or double values are equal, as
+// reported by proto.Equal: NaN values are equal to each other.
+func EqualFloat64(x, y float64) bool {
+	return x == y || math.IsNaN(x) && math.IsNaN(y)
+}
+
+// EqualImplicitFloat64 reports whether two values of a float or double field
+// without presence are equal, as reported by proto.Equal. Such a field is
+// unset if it is zero, but set if it is negative zero.
+func EqualImplicitFloat64(x, y float64) bool {
+	if x == 0 || y == 0 {
+		return x == y && math.Signbit(x) == math.Signbit(y)
+	}
+	return EqualFloat64(x, y)
+}
+
+// EqualMessage reports whether two elements of a repeated field, values of a
+// map field or values of a oneof field are equal, as reported by proto.Equal.
+// As in protobuf reflection, a nil message is equal to an empty message.
+func EqualMessage(x, y proto.Message) bool {
+	mx, my := proto.MessageReflect(x), proto.MessageReflect(y)
+	switch {
+	case !mx.IsValid() && !my.IsValid():
+		return true
+	case !mx.IsValid():
+		x = proto.MessageV1(mx.New().Interface())
+	case !my.IsValid():
+		y = proto.MessageV1(my.New().Interface())
+	}
+	return proto.Equal(x, y)
+}
+
+// EqualUnknown reports whether two sets of unknown fields are equal, as
+// reported by proto.Equal: the raw bytes of each field number are compared.
+func EqualUnknown(x, y []byte) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	if bytes.Equal(x, y) {
+		return true
+	}
+
+	mx := make(map[protowire.Number][]byte)
+	my := make(map[protowire.Number][]byte)
+	for len(x) > 0 {
+		num, _, n := protowire.ConsumeField(x)
+		mx[num] = append(mx[num], x[:n]...)
+		x = x[n:]
+	}
+	for len(y) > 0 {
+		num, _, n := protowire.ConsumeField(y)
+		my[num] = append(my[num], y[:n]...)
+		y = y[n:]
+	}
+	return reflect.DeepEqual(mx, my)
+}
+
+// MergeReflect merges src into dst by protobuf reflection, as proto.Merge
+// does for messages without generated methods. It is used by the generated
+// XXX_MergeFrom methods for sources of another type than dst, and panics as
+// proto.Merge does if src is not a message of the type of dst.
+func MergeReflect(dst, src proto.Message) {
+	protoV2.Merge(proto.MessageV2(dst), proto.MessageV2(src))
+}
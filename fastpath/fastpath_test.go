@@ -0,0 +1,274 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fastpath_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/golang/protobuf/fastpath"
+	"github.com/golang/protobuf/proto"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	pb "github.com/golang/protobuf/internal/testprotos/fastpath_proto"
+)
+
+// reflectEqual compares messages like proto.Equal, using protobuf reflection.
+var reflectEqual = proto.EqualOptions{EquateNaNs: true}.Equal
+
+// messages returns messages differing in a single way from each other,
+// covering the field kinds and their edge cases.
+func messages() []*pb.Message {
+	negZero := math.Copysign(0, -1)
+	nan := math.NaN()
+	withUnknown := func(m *pb.Message, b []byte) *pb.Message {
+		m.ProtoReflect().SetUnknown(b)
+		return m
+	}
+	extended := &pb.Extendable{Name: proto.String("x")}
+	if err := proto.SetExtension(extended, pb.E_Count, proto.Int32(3)); err != nil {
+		panic(err)
+	}
+	return []*pb.Message{
+		{},
+		{Scalars: &pb.Scalars{}},
+		{Scalars: &pb.Scalars{B: true, I32: -1, S32: -2, Sf32: -3, U32: 4, F32: 5, I64: -6, S64: -7, Sf64: -8, U64: 9, F64: 10, S: "s", By: []byte("b"), Color: pb.Color_RED}},
+		{Scalars: &pb.Scalars{Fl: 1.5, Db: 2.5}},
+		{Scalars: &pb.Scalars{Fl: float32(negZero), Db: negZero}},
+		{Scalars: &pb.Scalars{Db: nan}},
+		{Scalars: &pb.Scalars{Db: -nan}},
+		{Scalars: &pb.Scalars{By: []byte{}}},
+		{Scalars: &pb.Scalars{OptI32: proto.Int32(0), OptDb: proto.Float64(0), OptS: proto.String(""), OptColor: pb.Color_COLOR_UNSPECIFIED.Enum()}},
+		{Scalars: &pb.Scalars{OptI32: proto.Int32(1), OptDb: proto.Float64(nan), OptS: proto.String("s"), OptColor: pb.Color_GREEN.Enum()}},
+		{Scalars: &pb.Scalars{OptDb: proto.Float64(negZero)}},
+		{Scalars: &pb.Scalars{OptBy: []byte{}}},
+		{Scalars: &pb.Scalars{OptBy: []byte("b")}},
+		{Child: &pb.Message{}},
+		{Child: &pb.Message{Child: &pb.Message{Ss: []string{"a"}}}},
+		{Nested: &pb.Message_Nested{Name: "n", Next: &pb.Message_Nested{}}},
+		{Duration: &durationpb.Duration{}},
+		{Duration: &durationpb.Duration{Seconds: 1}},
+		{Legacy: &pb.Legacy{}},
+		{Legacy: &pb.Legacy{I32: proto.Int32(7), Fl: proto.Float32(float32(nan)), Db: proto.Float64(negZero), S: proto.String(""), By: []byte{}, Id: proto.Int64(1)}},
+		{Legacy: &pb.Legacy{Group: &pb.Legacy_Group{Name: proto.String("g"), Values: []int32{1, 2}}, Item: []*pb.Legacy_Item{{Value: proto.Int32(1)}, {}}}},
+		{Legacy: &pb.Legacy{Extendable: extended, Extendables: []*pb.Extendable{{}, extended}}},
+		{Extendable: &pb.Extendable{}},
+		{Extendable: extended},
+		{I32S: []int32{1, 2}},
+		{I32S: []int32{2, 1}},
+		{I32S: []int32{}},
+		{Dbs: []float64{nan, negZero}},
+		{Dbs: []float64{nan, 0}},
+		{Ss: []string{"a", ""}},
+		{Bys: [][]byte{nil, []byte("b")}},
+		{Bys: [][]byte{{}, []byte("b")}},
+		{Colors: []pb.Color{pb.Color_RED, pb.Color_COLOR_UNSPECIFIED}},
+		{Children: []*pb.Message{{}, {I32S: []int32{1}}}},
+		{Children: []*pb.Message{nil, {I32S: []int32{1}}}},
+		{Durations: []*durationpb.Duration{{Nanos: 1}, nil}},
+		{ByName: map[string]*pb.Message{"a": {}, "b": {Ss: []string{"b"}}}},
+		{ByName: map[string]*pb.Message{"a": nil, "b": {Ss: []string{"b"}}}},
+		{ByName: map[string]*pb.Message{"a": {}, "c": {Ss: []string{"b"}}}},
+		{BytesById: map[int64][]byte{1: nil, 2: []byte("b")}},
+		{BytesById: map[int64][]byte{1: []byte("a"), 2: []byte("b")}},
+		{DbsByFlag: map[bool]float64{true: nan, false: negZero}},
+		{DbsByFlag: map[bool]float64{true: nan, false: 1}},
+		{ColorsById: map[uint32]pb.Color{0: pb.Color_RED}},
+		{DurationsByName: map[string]*durationpb.Duration{"a": {Seconds: 1}, "b": nil}},
+		{Kind: &pb.Message_OI32{}},
+		{Kind: &pb.Message_OI32{OI32: 1}},
+		{Kind: &pb.Message_OFl{OFl: float32(nan)}},
+		{Kind: &pb.Message_OFl{OFl: float32(negZero)}},
+		{Kind: &pb.Message_OS{}},
+		{Kind: &pb.Message_OBy{}},
+		{Kind: &pb.Message_OBy{OBy: []byte("b")}},
+		{Kind: &pb.Message_OChild{}},
+		{Kind: &pb.Message_OChild{OChild: &pb.Message{}}},
+		{Kind: &pb.Message_OChild{OChild: &pb.Message{Kind: &pb.Message_OS{OS: "s"}}}},
+		{Kind: &pb.Message_ODuration{ODuration: &durationpb.Duration{Seconds: 2}}},
+		withUnknown(&pb.Message{}, []byte{0xf8, 0x0f, 0x01, 0x80, 0x10, 0x02}),
+		withUnknown(&pb.Message{}, []byte{0x80, 0x10, 0x02, 0xf8, 0x0f, 0x01}),
+		withUnknown(&pb.Message{}, []byte{0xf8, 0x0f, 0x01, 0xf8, 0x0f, 0x02}),
+		withUnknown(&pb.Message{}, []byte{0xf8, 0x0f, 0x02, 0xf8, 0x0f, 0x01}),
+	}
+}
+
+// marshal returns the deterministic encoding of a message, telling apart
+// messages equal by proto.Equal such as those with zero and negative zero
+// values of float fields with presence.
+func marshal(t *testing.T, m protoV2.Message) []byte {
+	b, err := protoV2.MarshalOptions{Deterministic: true, AllowPartial: true}.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEqual(t *testing.T) {
+	ms := messages()
+	for i, x := range ms {
+		for j, y := range ms {
+			want := reflectEqual(x, y)
+			if got := x.Equal(y); got != want {
+				t.Errorf("messages %d and %d: Equal = %v, want %v\nx: %v\ny: %v", i, j, got, want, x, y)
+			}
+			if got := proto.Equal(x, y); got != want {
+				t.Errorf("messages %d and %d: proto.Equal = %v, want %v\nx: %v\ny: %v", i, j, got, want, x, y)
+			}
+		}
+	}
+}
+
+func TestEqualNil(t *testing.T) {
+	var null *pb.Message
+	tests := []struct {
+		x, y proto.Message
+	}{
+		{null, null},
+		{null, &pb.Message{}},
+		{&pb.Message{}, null},
+		{&pb.Message{}, &pb.Scalars{}},
+		{&pb.Message{}, nil},
+	}
+	for _, tt := range tests {
+		if got, want := proto.Equal(tt.x, tt.y), reflectEqual(tt.x, tt.y); got != want {
+			t.Errorf("proto.Equal(%T(%v), %T(%v)) = %v, want %v", tt.x, tt.x, tt.y, tt.y, got, want)
+		}
+	}
+	if !null.Equal(nil) || null.Equal(&pb.Message{}) {
+		t.Errorf("Equal of a nil message is not only true for nil")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	ms := messages()
+	for i, dst := range ms {
+		for j, src := range ms {
+			want := protoV2.Clone(dst).(*pb.Message)
+			protoV2.Merge(want, src)
+
+			got := protoV2.Clone(dst).(*pb.Message)
+			got.MergeFrom(src)
+			if !reflectEqual(got, want) || !bytes.Equal(marshal(t, got), marshal(t, want)) {
+				t.Errorf("merge of message %d into %d:\ngot:  %v\nwant: %v", j, i, got, want)
+			}
+
+			got = protoV2.Clone(dst).(*pb.Message)
+			proto.Merge(got, src)
+			if !reflectEqual(got, want) || !bytes.Equal(marshal(t, got), marshal(t, want)) {
+				t.Errorf("proto.Merge of message %d into %d:\ngot:  %v\nwant: %v", j, i, got, want)
+			}
+		}
+	}
+}
+
+func TestMergeOtherType(t *testing.T) {
+	panicOf := func(merge func(dst, src proto.Message)) (r interface{}) {
+		defer func() { r = recover() }()
+		merge(&pb.Message{}, &pb.Scalars{})
+		return nil
+	}
+	want := panicOf(func(dst, src proto.Message) { protoV2.Merge(proto.MessageV2(dst), proto.MessageV2(src)) })
+	got := panicOf(proto.Merge)
+	if want == nil || got != want {
+		t.Errorf("proto.Merge of another type panics with %v, want %v", got, want)
+	}
+
+	// A dynamic message of the same type is merged by protobuf reflection.
+	src := dynamicpb.NewMessage((&pb.Message{}).ProtoReflect().Descriptor())
+	src.Set(src.Descriptor().Fields().ByName("o_s"), protoreflect.ValueOfString("x"))
+	dst := &pb.Message{}
+	proto.Merge(dst, src)
+	if want := (&pb.Message{Kind: &pb.Message_OS{OS: "x"}}); !reflectEqual(dst, want) {
+		t.Errorf("proto.Merge of a dynamic message = %v, want %v", dst, want)
+	}
+}
+
+func TestClone(t *testing.T) {
+	for i, m := range messages() {
+		want := protoV2.Clone(m).(*pb.Message)
+		for _, got := range []*pb.Message{m.Clone(), proto.Clone(m).(*pb.Message)} {
+			if !reflectEqual(got, want) || !bytes.Equal(marshal(t, got), marshal(t, want)) {
+				t.Errorf("clone of message %d:\ngot:  %v\nwant: %v", i, got, want)
+			}
+		}
+	}
+	var null *pb.Message
+	if got := proto.Clone(null); got.(*pb.Message) != nil {
+		t.Errorf("proto.Clone of a nil message = %v, want nil", got)
+	}
+}
+
+func TestCloneAliasing(t *testing.T) {
+	m := &pb.Message{
+		Scalars:  &pb.Scalars{By: []byte("b"), OptI32: proto.Int32(1)},
+		Child:    &pb.Message{Ss: []string{"a"}},
+		Bys:      [][]byte{[]byte("b")},
+		Children: []*pb.Message{{}},
+		ByName:   map[string]*pb.Message{"a": {}},
+		Kind:     &pb.Message_OBy{OBy: []byte("b")},
+		Legacy:   &pb.Legacy{Group: &pb.Legacy_Group{Name: proto.String("g")}},
+	}
+	c := m.Clone()
+	c.Scalars.By[0] = 'x'
+	*c.Scalars.OptI32 = 2
+	c.Child.Ss[0] = "x"
+	c.Bys[0][0] = 'x'
+	c.Children[0].I32S = []int32{1}
+	c.ByName["a"].I32S = []int32{1}
+	c.Kind.(*pb.Message_OBy).OBy[0] = 'x'
+	*c.Legacy.Group.Name = "x"
+	want := &pb.Message{
+		Scalars:  &pb.Scalars{By: []byte("b"), OptI32: proto.Int32(1)},
+		Child:    &pb.Message{Ss: []string{"a"}},
+		Bys:      [][]byte{[]byte("b")},
+		Children: []*pb.Message{{}},
+		ByName:   map[string]*pb.Message{"a": {}},
+		Kind:     &pb.Message_OBy{OBy: []byte("b")},
+		Legacy:   &pb.Legacy{Group: &pb.Legacy_Group{Name: proto.String("g")}},
+	}
+	if !reflectEqual(m, want) {
+		t.Errorf("message modified through its clone:\ngot:  %v\nwant: %v", m, want)
+	}
+}
+
+func TestNoMethods(t *testing.T) {
+	// Messages with extensions or with fields named like the methods are
+	// handled by protobuf reflection.
+	for _, m := range []interface{}{&pb.Extendable{}, &pb.Conflict{}} {
+		if _, ok := m.(interface{ XXX_Equal(proto.Message) bool }); ok {
+			t.Errorf("%T has generated methods", m)
+		}
+	}
+	x := &pb.Conflict{Clone: 1, Message: &pb.Message{Dbs: []float64{math.NaN()}}}
+	if !proto.Equal(x, proto.Clone(x)) {
+		t.Errorf("proto.Equal of a message without methods and its clone = false, want true")
+	}
+}
+
+func TestEqualImplicitFloat64(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	tests := []struct {
+		x, y float64
+		want bool
+	}{
+		{0, 0, true},
+		{0, negZero, false},
+		{negZero, negZero, true},
+		{math.NaN(), -math.NaN(), true},
+		{math.NaN(), 0, false},
+		{1, 1, true},
+		{1, 2, false},
+	}
+	for _, tt := range tests {
+		if got := fastpath.EqualImplicitFloat64(tt.x, tt.y); got != tt.want {
+			t.Errorf("EqualImplicitFloat64(%v, %v) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
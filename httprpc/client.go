@@ -0,0 +1,256 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httprpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// A Client calls the methods of services served by a ServeMux.
+// Generated clients call their methods with a Client.
+type Client struct {
+	// BaseURL is the URL the routes are relative to,
+	// such as "https://example.com/api".
+	BaseURL string
+
+	// HTTPClient sends the requests. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// ContentType is the encoding of the requests and responses:
+	// JSONContentType, the default, or ProtoContentType.
+	// Streamed responses are always encoded as JSON.
+	ContentType string
+}
+
+// ClientStream is the client end of a call to a server-streaming method.
+type ClientStream interface {
+	// Context returns the context of the call.
+	Context() context.Context
+
+	// RecvMsg receives the next response into m. It returns io.EOF at
+	// the end of the stream, and the error of the method if it failed.
+	RecvMsg(m proto.Message) error
+
+	// Close ends the call, discarding the responses not received.
+	Close() error
+}
+
+// Invoke calls a unary method at a route, storing its response in resp.
+func (c *Client) Invoke(ctx context.Context, route Route, req, resp proto.Message) error {
+	t := c.contentType()
+	hresp, err := c.do(ctx, route, req, t)
+	if err != nil {
+		return err
+	}
+	defer hresp.Body.Close()
+	b, err := readAll(ctx, hresp.Body)
+	if err != nil {
+		return err
+	}
+	rt, err := mediaType(hresp.Header.Get("Content-Type"))
+	if err != nil {
+		return &Error{Code: Internal, Message: err.(*Error).Message}
+	}
+	if route.ResponseBody != "" {
+		m := proto.MessageReflect(resp)
+		fd, err := messageField(m.Descriptor(), route.ResponseBody)
+		if err != nil {
+			return err
+		}
+		resp = proto.MessageV1(m.Mutable(fd).Message().Interface())
+	}
+	if err := decode(rt, b, resp); err != nil {
+		return Errorf(Internal, "invalid response body: %v", err)
+	}
+	return nil
+}
+
+// NewStream calls a server-streaming method at a route, returning the
+// stream of its responses.
+func (c *Client) NewStream(ctx context.Context, route Route, req proto.Message) (ClientStream, error) {
+	hresp, err := c.do(ctx, route, req, JSONContentType)
+	if err != nil {
+		return nil, err
+	}
+	return &clientStream{
+		ctx:          ctx,
+		body:         hresp.Body,
+		r:            bufio.NewReader(hresp.Body),
+		responseBody: route.ResponseBody,
+	}, nil
+}
+
+func (c *Client) contentType() string {
+	if c.ContentType == "" {
+		return JSONContentType
+	}
+	return c.ContentType
+}
+
+// do sends the request of a call to a route, returning the response
+// if it succeeded.
+func (c *Client) do(ctx context.Context, route Route, req proto.Message, accept string) (*http.Response, error) {
+	hreq, err := c.newRequest(route, req, accept)
+	if err != nil {
+		return nil, err
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	hresp, err := hc.Do(hreq.WithContext(ctx))
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx)
+		}
+		return nil, &Error{Code: Unavailable, Message: err.Error()}
+	}
+	if hresp.StatusCode != http.StatusOK {
+		defer hresp.Body.Close()
+		return nil, readError(ctx, hresp)
+	}
+	return hresp, nil
+}
+
+// newRequest returns the HTTP request of a call to a route.
+func (c *Client) newRequest(route Route, req proto.Message, accept string) (*http.Request, error) {
+	t, err := parseTemplate(route.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	m := proto.MessageReflect(req)
+	path, bound, err := t.expand(m)
+	if err != nil {
+		return nil, err
+	}
+	ct := c.contentType()
+	var body io.Reader
+	switch route.Body {
+	case "":
+	case "*":
+		b, err := encode(ct, req)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	default:
+		fd, err := messageField(m.Descriptor(), route.Body)
+		if err != nil {
+			return nil, err
+		}
+		bound[string(fd.Name())] = true
+		b, err := encode(ct, fieldMessage(m, fd))
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	}
+	u := strings.TrimSuffix(c.BaseURL, "/") + path
+	if route.Body != "*" {
+		q := make(url.Values)
+		if err := encodeQuery(m, "", bound, q); err != nil {
+			return nil, err
+		}
+		if len(q) > 0 {
+			u += "?" + q.Encode()
+		}
+	}
+	hreq, err := http.NewRequest(route.Method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		hreq.Header.Set("Content-Type", ct)
+	}
+	hreq.Header.Set("Accept", accept)
+	return hreq, nil
+}
+
+// readError returns the error reported by a response.
+func readError(ctx context.Context, hresp *http.Response) error {
+	b, err := readAll(ctx, hresp.Body)
+	if err != nil {
+		return err
+	}
+	var eb errorBody
+	if err := json.Unmarshal(b, &eb); err == nil && eb.Code != "" {
+		return eb.err()
+	}
+	msg := strings.TrimSpace(string(b))
+	if msg == "" {
+		msg = http.StatusText(hresp.StatusCode)
+	}
+	return &Error{Code: codeForHTTPStatus(hresp.StatusCode), Message: msg}
+}
+
+// clientStream is a ClientStream reading responses as lines of JSON.
+type clientStream struct {
+	ctx          context.Context
+	body         io.ReadCloser
+	r            *bufio.Reader
+	responseBody string
+	err          error // the error ending the stream, once received
+}
+
+func (s *clientStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *clientStream) RecvMsg(m proto.Message) error {
+	if s.err != nil {
+		return s.err
+	}
+	line, err := s.r.ReadBytes('\n')
+	if len(bytes.TrimSpace(line)) == 0 {
+		switch {
+		case err == io.EOF || err == nil:
+			s.err = io.EOF
+		case s.ctx.Err() != nil:
+			s.err = contextError(s.ctx)
+		default:
+			s.err = &Error{Code: Unavailable, Message: err.Error()}
+		}
+		s.body.Close()
+		return s.err
+	}
+	var l streamLine
+	if err := json.Unmarshal(line, &l); err != nil {
+		s.err = Errorf(Internal, "invalid response line: %v", err)
+		s.body.Close()
+		return s.err
+	}
+	if l.Error != nil {
+		s.err = l.Error.err()
+		s.body.Close()
+		return s.err
+	}
+	m.Reset()
+	if s.responseBody != "" {
+		r := proto.MessageReflect(m)
+		fd, err := messageField(r.Descriptor(), s.responseBody)
+		if err != nil {
+			return err
+		}
+		m = proto.MessageV1(r.Mutable(fd).Message().Interface())
+	}
+	if err := new(jsonpb.Unmarshaler).Unmarshal(bytes.NewReader(l.Result), m); err != nil {
+		return Errorf(Internal, "invalid response: %v", err)
+	}
+	return nil
+}
+
+func (s *clientStream) Close() error {
+	return s.body.Close()
+}
@@ -0,0 +1,296 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package httprpc is the runtime of the HTTP/JSON transport generated for
+// services by protoc-gen-go with the plugins=http parameter.
+//
+// A service is served by an http.Handler and called by a client exchanging
+// messages as JSON (application/json, encoded by jsonpb) or as protocol
+// buffers (application/x-protobuf). Requests are encoded as the client's
+// ContentType, and responses as requested by the Accept header of the
+// request, or else as the request.
+//
+// By default, a method is served at POST /package.Service/Method, with the
+// request in the body. A method annotated with the google.api.http option
+// is served at the routes of the annotation instead: fields of the request
+// are bound to the variables of the path template, the body holds the
+// request or one of its fields, and the remaining fields are given as query
+// parameters, such as ?page_size=10&filter.kind=BOOK.
+//
+// Server-streaming methods send their responses as newline-delimited JSON
+// (application/x-ndjson) in a chunked response. Each line is an object with
+// a "result" field holding a response, or an "error" field ending the stream.
+// Client-streaming and bidirectional streaming methods are not supported.
+//
+// Errors are reported with a Code, which determines the HTTP status of the
+// response, and a message, as a JSON object with "code" and "message" fields:
+//
+//	{"code":"NOT_FOUND","message":"no shelf 3"}
+package httprpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+// Content types of messages.
+const (
+	JSONContentType  = "application/json"
+	ProtoContentType = "application/x-protobuf"
+
+	// ndjsonContentType is the content type of streamed responses.
+	ndjsonContentType = "application/x-ndjson"
+)
+
+// A Code is the canonical code of an error, as the codes of gRPC.
+type Code int
+
+const (
+	OK                 Code = 0
+	Canceled           Code = 1
+	Unknown            Code = 2
+	InvalidArgument    Code = 3
+	DeadlineExceeded   Code = 4
+	NotFound           Code = 5
+	AlreadyExists      Code = 6
+	PermissionDenied   Code = 7
+	ResourceExhausted  Code = 8
+	FailedPrecondition Code = 9
+	Aborted            Code = 10
+	OutOfRange         Code = 11
+	Unimplemented      Code = 12
+	Internal           Code = 13
+	Unavailable        Code = 14
+	DataLoss           Code = 15
+	Unauthenticated    Code = 16
+)
+
+var codeNames = [...]string{
+	OK:                 "OK",
+	Canceled:           "CANCELLED",
+	Unknown:            "UNKNOWN",
+	InvalidArgument:    "INVALID_ARGUMENT",
+	DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	NotFound:           "NOT_FOUND",
+	AlreadyExists:      "ALREADY_EXISTS",
+	PermissionDenied:   "PERMISSION_DENIED",
+	ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	FailedPrecondition: "FAILED_PRECONDITION",
+	Aborted:            "ABORTED",
+	OutOfRange:         "OUT_OF_RANGE",
+	Unimplemented:      "UNIMPLEMENTED",
+	Internal:           "INTERNAL",
+	Unavailable:        "UNAVAILABLE",
+	DataLoss:           "DATA_LOSS",
+	Unauthenticated:    "UNAUTHENTICATED",
+}
+
+// String returns the name of the code in the google.rpc.Code enum,
+// such as "NOT_FOUND".
+func (c Code) String() string {
+	if c >= 0 && int(c) < len(codeNames) {
+		return codeNames[c]
+	}
+	return fmt.Sprintf("CODE(%d)", int(c))
+}
+
+func parseCode(s string) (Code, bool) {
+	for c, name := range codeNames {
+		if name == s {
+			return Code(c), true
+		}
+	}
+	return 0, false
+}
+
+// HTTPStatus returns the HTTP status of responses reporting an error
+// with the code.
+func (c Code) HTTPStatus() int {
+	switch c {
+	case OK:
+		return http.StatusOK
+	case Canceled:
+		return 499 // Client Closed Request
+	case InvalidArgument, FailedPrecondition, OutOfRange:
+		return http.StatusBadRequest
+	case DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case NotFound:
+		return http.StatusNotFound
+	case AlreadyExists, Aborted:
+		return http.StatusConflict
+	case PermissionDenied:
+		return http.StatusForbidden
+	case ResourceExhausted:
+		return http.StatusTooManyRequests
+	case Unimplemented:
+		return http.StatusNotImplemented
+	case Unavailable:
+		return http.StatusServiceUnavailable
+	case Unauthenticated:
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// codeForHTTPStatus returns the code of an error response whose body
+// does not hold an error.
+func codeForHTTPStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return InvalidArgument
+	case http.StatusUnauthorized:
+		return Unauthenticated
+	case http.StatusForbidden:
+		return PermissionDenied
+	case http.StatusNotFound:
+		return NotFound
+	case http.StatusConflict:
+		return Aborted
+	case 499:
+		return Canceled
+	case http.StatusNotImplemented:
+		return Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable:
+		return Unavailable
+	case http.StatusGatewayTimeout:
+		return DeadlineExceeded
+	}
+	return Unknown
+}
+
+// An Error is an error returned by a method.
+type Error struct {
+	Code    Code
+	Message string
+}
+
+// Errorf returns an Error with a code and a formatted message.
+func Errorf(code Code, format string, args ...interface{}) error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("httprpc: %v: %s", e.Code, e.Message)
+}
+
+// ErrorCode returns the code of an error: the code of an *Error, Canceled
+// or DeadlineExceeded for the errors of a context, OK for nil, and Unknown
+// for other errors.
+func ErrorCode(err error) Code {
+	switch err {
+	case nil:
+		return OK
+	case context.Canceled:
+		return Canceled
+	case context.DeadlineExceeded:
+		return DeadlineExceeded
+	}
+	if e, ok := err.(*Error); ok {
+		return e.Code
+	}
+	return Unknown
+}
+
+// errorBody is the JSON encoding of an error.
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func newErrorBody(err error) *errorBody {
+	msg := err.Error()
+	if e, ok := err.(*Error); ok {
+		msg = e.Message
+	}
+	return &errorBody{Code: ErrorCode(err).String(), Message: msg}
+}
+
+func (b *errorBody) err() error {
+	code, ok := parseCode(b.Code)
+	if !ok {
+		code = Unknown
+	}
+	return &Error{Code: code, Message: b.Message}
+}
+
+// contextError returns the error of a call whose context ctx is done.
+func contextError(ctx context.Context) error {
+	return &Error{Code: ErrorCode(ctx.Err()), Message: ctx.Err().Error()}
+}
+
+// mediaType returns the media type of a Content-Type header,
+// which defaults to JSON.
+func mediaType(contentType string) (string, error) {
+	if contentType == "" {
+		return JSONContentType, nil
+	}
+	t, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", Errorf(InvalidArgument, "invalid content type %q", contentType)
+	}
+	switch t {
+	case JSONContentType, ProtoContentType:
+		return t, nil
+	}
+	return "", Errorf(InvalidArgument, "unsupported content type %q", t)
+}
+
+// encode encodes a message as the media type t.
+func encode(t string, m proto.Message) ([]byte, error) {
+	if t == ProtoContentType {
+		return proto.Marshal(m)
+	}
+	var buf bytes.Buffer
+	if err := new(jsonpb.Marshaler).Marshal(&buf, m); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decode decodes a message encoded as the media type t.
+// An empty JSON body is an empty message.
+func decode(t string, b []byte, m proto.Message) error {
+	if t == ProtoContentType {
+		return proto.Unmarshal(b, m)
+	}
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	return new(jsonpb.Unmarshaler).Unmarshal(bytes.NewReader(b), m)
+}
+
+// readAll reads a body, returning the error of the context of a call if
+// it is done.
+func readAll(ctx context.Context, r io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx)
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// acceptedType returns the media type of the response to a request.
+func acceptedType(r *http.Request, requestType string) string {
+	for _, accept := range r.Header["Accept"] {
+		for _, part := range strings.Split(accept, ",") {
+			t, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err == nil && (t == JSONContentType || t == ProtoContentType) {
+				return t
+			}
+		}
+	}
+	return requestType
+}
@@ -0,0 +1,423 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httprpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/httprpc"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	pb "github.com/golang/protobuf/internal/testprotos/httprpc_proto"
+)
+
+// library is a LibraryHTTPServer serving a fixed set of books.
+type library struct {
+	books []*pb.Book
+
+	mu      sync.Mutex
+	updates []*pb.UpdateBookRequest
+}
+
+func newLibrary() *library {
+	return &library{books: []*pb.Book{
+		{Id: 1, Title: "The Go Programming Language", Authors: []string{"Alan Donovan", "Brian Kernighan"}},
+		{Id: 2, Title: "The C Programming Language", Authors: []string{"Brian Kernighan", "Dennis Ritchie"}},
+		{Id: 3, Title: "The AWK Programming Language", Authors: []string{"Alfred Aho", "Brian Kernighan", "Peter Weinberger"}},
+	}}
+}
+
+func (l *library) GetBook(ctx context.Context, req *pb.GetBookRequest) (*pb.Book, error) {
+	switch req.Id {
+	case -1:
+		return nil, errors.New("boom")
+	case -2:
+		return nil, httprpc.Errorf(httprpc.PermissionDenied, "not yours")
+	}
+	for _, b := range l.books {
+		if b.Id == req.Id {
+			return b, nil
+		}
+	}
+	return nil, httprpc.Errorf(httprpc.NotFound, "no book %d", req.Id)
+}
+
+func (l *library) ListBooks(req *pb.ListBooksRequest, stream pb.Library_ListBooksHTTPServer) error {
+	if req.Author == "" {
+		return httprpc.Errorf(httprpc.InvalidArgument, "missing author")
+	}
+	n := 0
+	for _, b := range l.books {
+		if req.PageSize > 0 && int32(n) == req.PageSize {
+			return httprpc.Errorf(httprpc.ResourceExhausted, "page size exceeded")
+		}
+		for _, a := range b.Authors {
+			if a == req.Author {
+				if err := stream.Send(b); err != nil {
+					return err
+				}
+				n++
+			}
+		}
+	}
+	return nil
+}
+
+func (l *library) UpdateBook(ctx context.Context, req *pb.UpdateBookRequest) (*pb.Book, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.updates = append(l.updates, req)
+	return req.Book, nil
+}
+
+func TestUnary(t *testing.T) {
+	srv := httptest.NewServer(pb.NewLibraryHTTPHandler(newLibrary()))
+	defer srv.Close()
+
+	for _, contentType := range []string{"", httprpc.JSONContentType, httprpc.ProtoContentType} {
+		c := pb.NewLibraryHTTPClient(&httprpc.Client{BaseURL: srv.URL, ContentType: contentType})
+		got, err := c.GetBook(context.Background(), &pb.GetBookRequest{Id: 2})
+		if err != nil {
+			t.Errorf("%q: GetBook: %v", contentType, err)
+			continue
+		}
+		if want := newLibrary().books[1]; !proto.Equal(got, want) {
+			t.Errorf("%q: GetBook = %v, want %v", contentType, got, want)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	srv := httptest.NewServer(pb.NewLibraryHTTPHandler(newLibrary()))
+	defer srv.Close()
+	c := pb.NewLibraryHTTPClient(&httprpc.Client{BaseURL: srv.URL})
+
+	tests := []struct {
+		id         int64
+		wantCode   httprpc.Code
+		wantStatus int
+		wantBody   string
+	}{
+		{7, httprpc.NotFound, http.StatusNotFound, `{"code":"NOT_FOUND","message":"no book 7"}`},
+		{-1, httprpc.Unknown, http.StatusInternalServerError, `{"code":"UNKNOWN","message":"boom"}`},
+		{-2, httprpc.PermissionDenied, http.StatusForbidden, `{"code":"PERMISSION_DENIED","message":"not yours"}`},
+	}
+	for _, tt := range tests {
+		_, err := c.GetBook(context.Background(), &pb.GetBookRequest{Id: tt.id})
+		if got := httprpc.ErrorCode(err); got != tt.wantCode {
+			t.Errorf("GetBook(%d) error = %v, want code %v", tt.id, err, tt.wantCode)
+		}
+
+		body := strings.NewReader(`{"id":"` + strconv.FormatInt(tt.id, 10) + `"}`)
+		resp, err := http.Post(srv.URL+"/httprpc_test.Library/GetBook", "application/json", body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tt.wantStatus || string(b) != tt.wantBody {
+			t.Errorf("POST GetBook(%d) = %d %s, want %d %s", tt.id, resp.StatusCode, b, tt.wantStatus, tt.wantBody)
+		}
+	}
+}
+
+func TestCurl(t *testing.T) {
+	srv := httptest.NewServer(pb.NewLibraryHTTPHandler(newLibrary()))
+	defer srv.Close()
+
+	tests := []struct {
+		method, path, contentType, body string
+		wantStatus                      int
+		wantBody                        string
+	}{{
+		method:      "POST",
+		path:        "/httprpc_test.Library/GetBook",
+		contentType: "application/json; charset=utf-8",
+		body:        `{"id": 1}`,
+		wantStatus:  http.StatusOK,
+		wantBody:    `{"id":"1","title":"The Go Programming Language","authors":["Alan Donovan","Brian Kernighan"]}`,
+	}, {
+		method:     "POST",
+		path:       "/httprpc_test.Library/ListBooks",
+		body:       `{"author": "Dennis Ritchie"}`,
+		wantStatus: http.StatusOK,
+		wantBody:   `{"result":{"id":"2","title":"The C Programming Language","authors":["Brian Kernighan","Dennis Ritchie"]}}` + "\n",
+	}, {
+		method:     "POST",
+		path:       "/httprpc_test.Library/GetBook",
+		body:       `{"id": "x"}`,
+		wantStatus: http.StatusBadRequest,
+	}, {
+		method:      "POST",
+		path:        "/httprpc_test.Library/GetBook",
+		contentType: "text/plain",
+		body:        `id: 1`,
+		wantStatus:  http.StatusBadRequest,
+		wantBody:    `{"code":"INVALID_ARGUMENT","message":"unsupported content type \"text/plain\""}`,
+	}, {
+		method:     "GET",
+		path:       "/httprpc_test.Library/GetBook",
+		wantStatus: http.StatusMethodNotAllowed,
+	}, {
+		method:     "POST",
+		path:       "/httprpc_test.Library/UploadBooks",
+		wantStatus: http.StatusNotFound,
+		wantBody:   `{"code":"NOT_FOUND","message":"no method at /httprpc_test.Library/UploadBooks"}`,
+	}}
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tt.wantStatus || (tt.wantBody != "" && string(b) != tt.wantBody) {
+			t.Errorf("%s %s %s = %d %s, want %d %s", tt.method, tt.path, tt.body, resp.StatusCode, b, tt.wantStatus, tt.wantBody)
+		}
+	}
+}
+
+func TestAccept(t *testing.T) {
+	srv := httptest.NewServer(pb.NewLibraryHTTPHandler(newLibrary()))
+	defer srv.Close()
+
+	req, err := http.NewRequest("POST", srv.URL+"/httprpc_test.Library/GetBook", strings.NewReader(`{"id":3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "text/html, application/x-protobuf")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != httprpc.ProtoContentType {
+		t.Fatalf("Content-Type = %q, want %q", got, httprpc.ProtoContentType)
+	}
+	b, _ := ioutil.ReadAll(resp.Body)
+	got := new(pb.Book)
+	if err := proto.Unmarshal(b, got); err != nil {
+		t.Fatal(err)
+	}
+	if want := newLibrary().books[2]; !proto.Equal(got, want) {
+		t.Errorf("GetBook = %v, want %v", got, want)
+	}
+}
+
+func TestServerStream(t *testing.T) {
+	srv := httptest.NewServer(pb.NewLibraryHTTPHandler(newLibrary()))
+	defer srv.Close()
+	c := pb.NewLibraryHTTPClient(&httprpc.Client{BaseURL: srv.URL})
+
+	tests := []struct {
+		req      *pb.ListBooksRequest
+		wantIDs  []int64
+		wantCode httprpc.Code
+	}{
+		{&pb.ListBooksRequest{Author: "Brian Kernighan"}, []int64{1, 2, 3}, httprpc.OK},
+		{&pb.ListBooksRequest{Author: "Alfred Aho"}, []int64{3}, httprpc.OK},
+		{&pb.ListBooksRequest{Author: "Nobody"}, nil, httprpc.OK},
+		{&pb.ListBooksRequest{Author: "Brian Kernighan", PageSize: 2}, []int64{1, 2}, httprpc.ResourceExhausted},
+		{&pb.ListBooksRequest{}, nil, httprpc.InvalidArgument},
+	}
+	for _, tt := range tests {
+		stream, err := c.ListBooks(context.Background(), tt.req)
+		var ids []int64
+		for err == nil {
+			var b *pb.Book
+			if b, err = stream.Recv(); err == nil {
+				ids = append(ids, b.Id)
+			}
+		}
+		if stream != nil {
+			if _, again := stream.Recv(); again != err {
+				t.Errorf("ListBooks(%v): Recv after %v = %v, want the same error", tt.req, err, again)
+			}
+		}
+		if err == io.EOF {
+			err = nil
+		}
+		if httprpc.ErrorCode(err) != tt.wantCode || cmp.Diff(ids, tt.wantIDs) != "" {
+			t.Errorf("ListBooks(%v) = %v, %v; want %v, code %v", tt.req, ids, err, tt.wantIDs, tt.wantCode)
+		}
+	}
+}
+
+func TestServerStreamCancel(t *testing.T) {
+	block := make(chan struct{})
+	mux := httprpc.NewServeMux()
+	mux.HandleServerStream([]httprpc.Route{{Method: "GET", Pattern: "/books"}}, func() proto.Message { return new(pb.ListBooksRequest) }, func(req proto.Message, stream httprpc.ServerStream) error {
+		if err := stream.SendMsg(&pb.Book{Id: 1}); err != nil {
+			return err
+		}
+		select {
+		case <-stream.Context().Done():
+		case <-block:
+		}
+		return nil
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	defer close(block)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &httprpc.Client{BaseURL: srv.URL}
+	stream, err := c.NewStream(ctx, httprpc.Route{Method: "GET", Pattern: "/books"}, new(pb.ListBooksRequest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.RecvMsg(new(pb.Book)); err != nil {
+		t.Fatalf("RecvMsg: %v", err)
+	}
+	cancel()
+	if err := stream.RecvMsg(new(pb.Book)); httprpc.ErrorCode(err) != httprpc.Canceled {
+		t.Errorf("RecvMsg after cancel = %v, want code %v", err, httprpc.Canceled)
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	l := newLibrary()
+	mux := httprpc.NewServeMux()
+	newGetBook := func() proto.Message { return new(pb.GetBookRequest) }
+	getBook := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return l.GetBook(ctx, req.(*pb.GetBookRequest))
+	}
+	newUpdateBook := func() proto.Message { return new(pb.UpdateBookRequest) }
+	updateBook := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return l.UpdateBook(ctx, req.(*pb.UpdateBookRequest))
+	}
+	echoUpdate := func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		if _, err := l.UpdateBook(ctx, req.(*pb.UpdateBookRequest)); err != nil {
+			return nil, err
+		}
+		return req, nil
+	}
+	routes := map[string]httprpc.Route{
+		"get":    {Method: "GET", Pattern: "/v1/books/{id}"},
+		"custom": {Method: "GET", Pattern: "/v1/books/{id}:fetch"},
+		"update": {Method: "PATCH", Pattern: "/v1/{shelf=shelves/*}/books/{book.id}", Body: "book"},
+		"put":    {Method: "PUT", Pattern: "/v1/{shelf=**}", Body: "*"},
+		"query":  {Method: "POST", Pattern: "/v1/books:update"},
+		"echo":   {Method: "POST", Pattern: "/v1/echo/{shelf}", Body: "book", ResponseBody: "book"},
+	}
+	mux.HandleUnary([]httprpc.Route{routes["get"], routes["custom"]}, newGetBook, getBook)
+	mux.HandleUnary([]httprpc.Route{routes["update"], routes["put"], routes["query"]}, newUpdateBook, updateBook)
+	mux.HandleUnary([]httprpc.Route{routes["echo"]}, newUpdateBook, echoUpdate)
+
+	var (
+		mu   sync.Mutex
+		uris []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		uris = append(uris, r.Method+" "+r.URL.RequestURI())
+		mu.Unlock()
+		mux.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	book := &pb.Book{Id: 4, Title: "Programming Pearls", Authors: []string{"Jon Bentley"}}
+	tests := []struct {
+		route   string
+		req     proto.Message
+		resp    proto.Message
+		wantURI string
+		want    proto.Message
+	}{{
+		route:   "get",
+		req:     &pb.GetBookRequest{Id: 1},
+		resp:    new(pb.Book),
+		wantURI: "GET /v1/books/1",
+		want:    l.books[0],
+	}, {
+		route:   "custom",
+		req:     &pb.GetBookRequest{Id: 3},
+		resp:    new(pb.Book),
+		wantURI: "GET /v1/books/3:fetch",
+		want:    l.books[2],
+	}, {
+		route:   "update",
+		req:     &pb.UpdateBookRequest{Shelf: "shelves/a b", Book: book},
+		resp:    new(pb.Book),
+		wantURI: "PATCH /v1/shelves/a%20b/books/4",
+		want:    book,
+	}, {
+		route:   "put",
+		req:     &pb.UpdateBookRequest{Shelf: "x/y/z", Book: book},
+		resp:    new(pb.Book),
+		wantURI: "PUT /v1/x/y/z",
+		want:    book,
+	}, {
+		route:   "query",
+		req:     &pb.UpdateBookRequest{Shelf: "s", Book: book},
+		resp:    new(pb.Book),
+		wantURI: "POST /v1/books:update?book.authors=Jon+Bentley&book.id=4&book.title=Programming+Pearls&shelf=s",
+		want:    book,
+	}, {
+		route:   "echo",
+		req:     &pb.UpdateBookRequest{Shelf: "s", Book: book},
+		resp:    new(pb.UpdateBookRequest),
+		wantURI: "POST /v1/echo/s",
+		want:    &pb.UpdateBookRequest{Book: book},
+	}}
+	for _, contentType := range []string{httprpc.JSONContentType, httprpc.ProtoContentType} {
+		c := &httprpc.Client{BaseURL: srv.URL + "/", ContentType: contentType}
+		for _, tt := range tests {
+			uris, l.updates = nil, nil
+			tt.resp.Reset()
+			if err := c.Invoke(context.Background(), routes[tt.route], tt.req, tt.resp); err != nil {
+				t.Errorf("%s %q: Invoke: %v", contentType, tt.route, err)
+				continue
+			}
+			if !proto.Equal(tt.resp, tt.want) {
+				t.Errorf("%s %q: response = %v, want %v", contentType, tt.route, tt.resp, tt.want)
+			}
+			if len(uris) != 1 || uris[0] != tt.wantURI {
+				t.Errorf("%s %q: requests = %q, want %q", contentType, tt.route, uris, tt.wantURI)
+			}
+			if req, ok := tt.req.(*pb.UpdateBookRequest); ok {
+				if len(l.updates) != 1 || !proto.Equal(l.updates[0], req) {
+					t.Errorf("%s %q: server got %v, want %v", contentType, tt.route, l.updates, req)
+				}
+			}
+		}
+	}
+}
+
+func TestInvalidRoute(t *testing.T) {
+	tests := []httprpc.Route{
+		{Method: "GET", Pattern: "v1/books"},
+		{Method: "GET", Pattern: "/v1/{title}"},
+		{Method: "GET", Pattern: "/v1/**/books"},
+		{Method: "POST", Pattern: "/v1/books", Body: "id"},
+	}
+	for _, route := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("HandleUnary(%v) did not panic", route)
+				}
+			}()
+			httprpc.NewServeMux().HandleUnary([]httprpc.Route{route}, func() proto.Message { return new(pb.GetBookRequest) }, nil)
+		}()
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httprpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// A Route is an HTTP route of a method, as given by a google.api.http
+// annotation.
+type Route struct {
+	// Method is the HTTP method, such as "GET".
+	Method string
+
+	// Pattern is the path template, such as "/v1/{name=shelves/*}".
+	Pattern string
+
+	// Body is the request field held by the body: "*" for the whole
+	// request, or "" for no body.
+	Body string
+
+	// ResponseBody is the response field held by the body,
+	// or "" for the whole response.
+	ResponseBody string
+}
+
+// A template is a parsed path template.
+//
+// Its grammar, as described in google/api/http.proto, is:
+//
+//	Template = "/" Segments [ Verb ] ;
+//	Segments = Segment { "/" Segment } ;
+//	Segment  = "*" | "**" | LITERAL | Variable ;
+//	Variable = "{" FieldPath [ "=" Segments ] "}" ;
+//	FieldPath = IDENT { "." IDENT } ;
+//	Verb     = ":" LITERAL ;
+type template struct {
+	segments []string // literals, "*" or "**"
+	vars     []variable
+	verb     string
+}
+
+// A variable binds the segments [start, end) of a template to a field.
+type variable struct {
+	fieldPath  []string
+	start, end int
+}
+
+func parseTemplate(s string) (*template, error) {
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("path template %q does not start with /", s)
+	}
+	t := new(template)
+	// The verb follows the last colon outside a variable and after
+	// the last slash.
+	depth, slash, colon := 0, 0, -1
+	for i, c := range s {
+		switch {
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		case c == '/' && depth == 0:
+			slash = i
+		case c == ':' && depth == 0:
+			colon = i
+		}
+	}
+	if colon > slash {
+		s, t.verb = s[:colon], s[colon+1:]
+	}
+	for rest := s[1:]; rest != ""; {
+		if rest[0] == '{' {
+			end := strings.IndexByte(rest, '}')
+			if end < 0 {
+				return nil, fmt.Errorf("path template %q has an unterminated variable", s)
+			}
+			v := rest[1:end]
+			rest = rest[end+1:]
+			name, pattern := v, "*"
+			if i := strings.IndexByte(v, '='); i >= 0 {
+				name, pattern = v[:i], v[i+1:]
+			}
+			start := len(t.segments)
+			t.segments = append(t.segments, strings.Split(pattern, "/")...)
+			t.vars = append(t.vars, variable{strings.Split(name, "."), start, len(t.segments)})
+		} else {
+			seg := rest
+			if i := strings.IndexByte(rest, '/'); i >= 0 {
+				seg = rest[:i]
+			}
+			t.segments = append(t.segments, seg)
+			rest = rest[len(seg):]
+		}
+		if rest != "" {
+			if rest[0] != '/' {
+				return nil, fmt.Errorf("path template %q has a variable within a segment", s)
+			}
+			rest = rest[1:]
+			if rest == "" {
+				return nil, fmt.Errorf("path template %q ends with /", s)
+			}
+		}
+	}
+	for i, seg := range t.segments {
+		if seg == "" || strings.ContainsAny(seg, "{}=") {
+			return nil, fmt.Errorf("path template %q has an invalid segment %q", s, seg)
+		}
+		if seg == "**" && i != len(t.segments)-1 {
+			return nil, fmt.Errorf("path template %q has ** before its last segment", s)
+		}
+	}
+	return t, nil
+}
+
+// match matches an escaped path with the template, returning the values of
+// its variables.
+func (t *template) match(path string) ([]string, bool) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+	path = path[1:]
+	if t.verb != "" {
+		if !strings.HasSuffix(path, ":"+t.verb) {
+			return nil, false
+		}
+		path = strings.TrimSuffix(path, ":"+t.verb)
+	}
+	var parts []string
+	if path != "" {
+		parts = strings.Split(path, "/")
+	}
+	n := len(t.segments)
+	deep := n > 0 && t.segments[n-1] == "**"
+	if deep && len(parts) < n-1 || !deep && len(parts) != n {
+		return nil, false
+	}
+	for i, seg := range t.segments {
+		if seg == "**" {
+			break
+		}
+		if seg == "*" {
+			if parts[i] == "" {
+				return nil, false
+			}
+			continue
+		}
+		if p, err := url.PathUnescape(parts[i]); err != nil || p != seg {
+			return nil, false
+		}
+	}
+	values := make([]string, len(t.vars))
+	for i, v := range t.vars {
+		end := v.end
+		if deep && end == n {
+			end = len(parts)
+		}
+		var vs []string
+		for _, p := range parts[v.start:end] {
+			p, err := url.PathUnescape(p)
+			if err != nil {
+				return nil, false
+			}
+			vs = append(vs, p)
+		}
+		values[i] = strings.Join(vs, "/")
+	}
+	return values, true
+}
+
+// expand returns the escaped path of the template with its variables bound
+// to the fields of m, and the paths of those fields.
+func (t *template) expand(m protoreflect.Message) (string, map[string]bool, error) {
+	var b bytes.Buffer
+	bound := make(map[string]bool)
+	for i := 0; i < len(t.segments); i++ {
+		b.WriteByte('/')
+		var v *variable
+		for j := range t.vars {
+			if t.vars[j].start == i {
+				v = &t.vars[j]
+			}
+		}
+		if v == nil {
+			if seg := t.segments[i]; seg != "*" && seg != "**" {
+				b.WriteString(seg)
+				continue
+			}
+			return "", nil, fmt.Errorf("httprpc: path template has a wildcard outside a variable")
+		}
+		path, value, err := fieldString(m, v.fieldPath)
+		if err != nil {
+			return "", nil, err
+		}
+		bound[path] = true
+		if v.end-v.start == 1 && t.segments[i] == "*" {
+			b.WriteString(url.PathEscape(value))
+		} else {
+			parts := strings.Split(value, "/")
+			for k, p := range parts {
+				parts[k] = url.PathEscape(p)
+			}
+			b.WriteString(strings.Join(parts, "/"))
+		}
+		i = v.end - 1
+	}
+	if t.verb != "" {
+		b.WriteString(":" + t.verb)
+	}
+	return b.String(), bound, nil
+}
+
+// fieldByName returns the field of a message with a name or a JSON name.
+func fieldByName(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if fd := md.Fields().ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return md.Fields().ByJSONName(name)
+}
+
+// messageField returns the singular message field of a message used as
+// a request or response body.
+func messageField(md protoreflect.MessageDescriptor, name string) (protoreflect.FieldDescriptor, error) {
+	fd := fieldByName(md, name)
+	if fd == nil || fd.Message() == nil || fd.IsList() || fd.IsMap() {
+		return nil, fmt.Errorf("%v has no message field %q", md.FullName(), name)
+	}
+	return fd, nil
+}
+
+// fieldMessage returns the message held by a message field of m, which is
+// empty if the field is unset.
+func fieldMessage(m protoreflect.Message, fd protoreflect.FieldDescriptor) proto.Message {
+	if !m.Has(fd) {
+		return proto.MessageV1(m.NewField(fd).Message().Interface())
+	}
+	return proto.MessageV1(m.Get(fd).Message().Interface())
+}
+
+// setField sets the field at a path of names in m from its string form.
+// A repeated field is appended to.
+func setField(m protoreflect.Message, path []string, s string) error {
+	for i, name := range path {
+		fd := fieldByName(m.Descriptor(), name)
+		if fd == nil {
+			return Errorf(InvalidArgument, "%v has no field %q", m.Descriptor().FullName(), name)
+		}
+		if i < len(path)-1 {
+			if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+				return Errorf(InvalidArgument, "field %v is not a singular message", fd.FullName())
+			}
+			m = m.Mutable(fd).Message()
+			continue
+		}
+		if fd.IsMap() {
+			return Errorf(InvalidArgument, "map field %v cannot be set from a string", fd.FullName())
+		}
+		var v protoreflect.Value
+		switch {
+		case fd.IsList():
+			v = m.Mutable(fd).List().NewElement()
+		case fd.Message() != nil:
+			v = m.NewField(fd)
+		}
+		v, err := parseValue(fd, v, s)
+		if err != nil {
+			return Errorf(InvalidArgument, "invalid value %q for field %v: %v", s, fd.FullName(), err)
+		}
+		if fd.IsList() {
+			m.Mutable(fd).List().Append(v)
+		} else {
+			m.Set(fd, v)
+		}
+	}
+	return nil
+}
+
+// parseValue parses the string form of a value of a field. The value of a
+// message field is parsed into v, from the JSON encoding of the message as
+// a string, which is that of well-known types such as Timestamp.
+func parseValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+			if b, err := enc.DecodeString(s); err == nil {
+				return protoreflect.ValueOfBytes(b), nil
+			}
+		}
+		return protoreflect.Value{}, fmt.Errorf("invalid base64")
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		n, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), err
+	}
+	m := proto.MessageV1(v.Message().Interface())
+	if err := jsonpb.UnmarshalString(strconv.Quote(s), m); err != nil {
+		return protoreflect.Value{}, err
+	}
+	return v, nil
+}
+
+// fieldString returns the canonical path and the string form of the
+// singular field at a path of names in m.
+func fieldString(m protoreflect.Message, path []string) (string, string, error) {
+	var names []string
+	for i, name := range path {
+		fd := fieldByName(m.Descriptor(), name)
+		if fd == nil {
+			return "", "", fmt.Errorf("httprpc: %v has no field %q", m.Descriptor().FullName(), name)
+		}
+		if fd.IsList() || fd.IsMap() {
+			return "", "", fmt.Errorf("httprpc: field %v bound to the path is not singular", fd.FullName())
+		}
+		names = append(names, string(fd.Name()))
+		if i < len(path)-1 {
+			if fd.Message() == nil {
+				return "", "", fmt.Errorf("httprpc: field %v is not a message", fd.FullName())
+			}
+			m = m.Get(fd).Message()
+			continue
+		}
+		s, err := formatValue(fd, m.Get(fd))
+		return strings.Join(names, "."), s, err
+	}
+	return "", "", fmt.Errorf("httprpc: empty field path")
+}
+
+// formatValue returns the string form of a value of a field,
+// as parsed by parseValue.
+func formatValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) (string, error) {
+	switch fd.Kind() {
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), nil
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name()), nil
+		}
+		return strconv.Itoa(int(v.Enum())), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		s, err := new(jsonpb.Marshaler).MarshalToString(proto.MessageV1(v.Message().Interface()))
+		if err != nil {
+			return "", err
+		}
+		if u, err := strconv.Unquote(s); err == nil {
+			return u, nil
+		}
+		return s, nil
+	}
+	return v.String(), nil
+}
+
+// scalarMessages are the well-known types encoded in JSON as a string
+// or another scalar, which are given as a single query parameter.
+var scalarMessages = map[protoreflect.FullName]bool{
+	"google.protobuf.Timestamp":   true,
+	"google.protobuf.Duration":    true,
+	"google.protobuf.FieldMask":   true,
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.UInt64Value": true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.BytesValue":  true,
+}
+
+// encodeQuery adds the fields of m to the query parameters q, except for
+// those whose paths are skipped.
+func encodeQuery(m protoreflect.Message, prefix string, skip map[string]bool, q url.Values) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		name := prefix + string(fd.Name())
+		if skip[name] {
+			return true
+		}
+		switch {
+		case fd.IsMap():
+			err = fmt.Errorf("httprpc: map field %v cannot be given as a query parameter", fd.FullName())
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len() && err == nil; i++ {
+				var s string
+				s, err = formatValue(fd, list.Get(i))
+				q.Add(name, s)
+			}
+		case fd.Message() != nil && !scalarMessages[fd.Message().FullName()]:
+			err = encodeQuery(v.Message(), name+".", skip, q)
+		default:
+			var s string
+			s, err = formatValue(fd, v)
+			q.Add(name, s)
+		}
+		return err == nil
+	})
+	return err
+}
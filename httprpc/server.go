@@ -0,0 +1,267 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httprpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ServerStream is the server end of a call to a server-streaming method.
+type ServerStream interface {
+	// Context returns the context of the call.
+	Context() context.Context
+
+	// SendMsg sends a response.
+	SendMsg(m proto.Message) error
+}
+
+// A ServeMux is an http.Handler serving the methods of services at their
+// routes. Generated code registers the methods of a service.
+type ServeMux struct {
+	handlers []*handler
+}
+
+// A handler serves a method at a route.
+type handler struct {
+	route      Route
+	template   *template
+	newRequest func() proto.Message
+	bodyField  protoreflect.FieldDescriptor
+	serve      func(w http.ResponseWriter, r *http.Request, h *handler, req proto.Message)
+}
+
+// NewServeMux returns an empty ServeMux.
+func NewServeMux() *ServeMux {
+	return new(ServeMux)
+}
+
+// HandleUnary registers a unary method at its routes. The method is called
+// with a request returned by newRequest.
+//
+// HandleUnary panics if a route is invalid for the request.
+func (mux *ServeMux) HandleUnary(routes []Route, newRequest func() proto.Message, call func(ctx context.Context, req proto.Message) (proto.Message, error)) {
+	mux.handle(routes, newRequest, func(w http.ResponseWriter, r *http.Request, h *handler, req proto.Message) {
+		resp, err := call(r.Context(), req)
+		if err == nil && (resp == nil || !proto.MessageReflect(resp).IsValid()) {
+			err = Errorf(Internal, "method returned a nil response")
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		reqType, _ := mediaType(r.Header.Get("Content-Type"))
+		t := acceptedType(r, reqType)
+		if h.route.ResponseBody != "" {
+			m := proto.MessageReflect(resp)
+			fd, err := messageField(m.Descriptor(), h.route.ResponseBody)
+			if err != nil {
+				writeError(w, &Error{Code: Internal, Message: err.Error()})
+				return
+			}
+			resp = fieldMessage(m, fd)
+		}
+		b, err := encode(t, resp)
+		if err != nil {
+			writeError(w, &Error{Code: Internal, Message: err.Error()})
+			return
+		}
+		w.Header().Set("Content-Type", t)
+		w.Write(b)
+	})
+}
+
+// HandleServerStream registers a server-streaming method at its routes.
+// The method is called with a request returned by newRequest.
+//
+// HandleServerStream panics if a route is invalid for the request.
+func (mux *ServeMux) HandleServerStream(routes []Route, newRequest func() proto.Message, call func(req proto.Message, stream ServerStream) error) {
+	mux.handle(routes, newRequest, func(w http.ResponseWriter, r *http.Request, h *handler, req proto.Message) {
+		s := &serverStream{ctx: r.Context(), w: w, responseBody: h.route.ResponseBody}
+		err := call(req, s)
+		switch {
+		case err != nil && !s.started:
+			writeError(w, err)
+		case err != nil:
+			s.writeLine(&streamLine{Error: newErrorBody(err)})
+		case !s.started:
+			s.start()
+		}
+	})
+}
+
+func (mux *ServeMux) handle(routes []Route, newRequest func() proto.Message, serve func(http.ResponseWriter, *http.Request, *handler, proto.Message)) {
+	md := proto.MessageReflect(newRequest()).Descriptor()
+	for _, route := range routes {
+		t, err := parseTemplate(route.Pattern)
+		if err != nil {
+			panic("httprpc: " + err.Error())
+		}
+		for _, v := range t.vars {
+			if _, _, err := fieldString(proto.MessageReflect(newRequest()), v.fieldPath); err != nil {
+				panic(fmt.Sprintf("httprpc: route %s %s: %v", route.Method, route.Pattern, err))
+			}
+		}
+		h := &handler{route: route, template: t, newRequest: newRequest, serve: serve}
+		if route.Body != "" && route.Body != "*" {
+			if h.bodyField, err = messageField(md, route.Body); err != nil {
+				panic(fmt.Sprintf("httprpc: route %s %s: %v", route.Method, route.Pattern, err))
+			}
+		}
+		mux.handlers = append(mux.handlers, h)
+	}
+	// Routes with a verb are matched first, since the variable of the
+	// last segment of a route without one also matches a verb.
+	sort.SliceStable(mux.handlers, func(i, j int) bool {
+		return mux.handlers[i].template.verb != "" && mux.handlers[j].template.verb == ""
+	})
+}
+
+// ServeHTTP serves a request with the first method whose route matches it,
+// in the order of registration except that routes with a verb come first.
+func (mux *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+	methodMismatch := false
+	for _, h := range mux.handlers {
+		values, ok := h.template.match(path)
+		if !ok {
+			continue
+		}
+		if h.route.Method != r.Method {
+			methodMismatch = true
+			continue
+		}
+		req := h.newRequest()
+		if err := h.readRequest(r, values, req); err != nil {
+			writeError(w, err)
+			return
+		}
+		h.serve(w, r, h, req)
+		return
+	}
+	if methodMismatch {
+		writeErrorStatus(w, http.StatusMethodNotAllowed, Errorf(Unimplemented, "method %s not allowed for %s", r.Method, path))
+		return
+	}
+	writeError(w, Errorf(NotFound, "no method at %s", path))
+}
+
+// readRequest reads the request of a method from its body, the values of
+// the variables of the route and the query parameters.
+func (h *handler) readRequest(r *http.Request, values []string, req proto.Message) error {
+	m := proto.MessageReflect(req)
+	if h.route.Body != "" {
+		t, err := mediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			return err
+		}
+		b, err := readAll(r.Context(), r.Body)
+		if err != nil {
+			return err
+		}
+		body := req
+		if h.bodyField != nil {
+			body = proto.MessageV1(m.Mutable(h.bodyField).Message().Interface())
+		}
+		if err := decode(t, b, body); err != nil {
+			return Errorf(InvalidArgument, "invalid request body: %v", err)
+		}
+	}
+	for i, v := range h.template.vars {
+		if err := setField(m, v.fieldPath, values[i]); err != nil {
+			return err
+		}
+	}
+	if h.route.Body != "*" {
+		for name, vs := range r.URL.Query() {
+			for _, v := range vs {
+				if err := setField(m, strings.Split(name, "."), v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeErrorStatus(w, ErrorCode(err).HTTPStatus(), err)
+}
+
+func writeErrorStatus(w http.ResponseWriter, status int, err error) {
+	b, _ := json.Marshal(newErrorBody(err))
+	w.Header().Set("Content-Type", JSONContentType)
+	w.WriteHeader(status)
+	w.Write(b)
+}
+
+// A streamLine is a line of a streamed response.
+type streamLine struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *errorBody      `json:"error,omitempty"`
+}
+
+// serverStream is a ServerStream writing its responses as lines of JSON.
+type serverStream struct {
+	ctx          context.Context
+	w            http.ResponseWriter
+	responseBody string
+	started      bool
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendMsg(m proto.Message) error {
+	if s.responseBody != "" {
+		r := proto.MessageReflect(m)
+		fd, err := messageField(r.Descriptor(), s.responseBody)
+		if err != nil {
+			return &Error{Code: Internal, Message: err.Error()}
+		}
+		m = fieldMessage(r, fd)
+	}
+	var buf bytes.Buffer
+	if err := new(jsonpb.Marshaler).Marshal(&buf, m); err != nil {
+		return &Error{Code: Internal, Message: err.Error()}
+	}
+	return s.writeLine(&streamLine{Result: buf.Bytes()})
+}
+
+func (s *serverStream) start() {
+	if !s.started {
+		s.w.Header().Set("Content-Type", ndjsonContentType)
+		s.w.WriteHeader(http.StatusOK)
+		s.started = true
+	}
+}
+
+func (s *serverStream) writeLine(line *streamLine) error {
+	s.start()
+	b, err := json.Marshal(line)
+	if err != nil {
+		return &Error{Code: Internal, Message: err.Error()}
+	}
+	if _, err := s.w.Write(append(b, '\n')); err != nil {
+		if s.ctx.Err() != nil {
+			return contextError(s.ctx)
+		}
+		return &Error{Code: Unavailable, Message: err.Error()}
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package decodecost holds the costs charged to the heap estimate bounded
+// by proto.DecodeLimits.MaxHeapEstimate, shared by the decoders of the proto
+// and jsonpb packages so that they agree on the estimate.
+package decodecost
+
+const (
+	Message = 64 // per message, including map entries
+	Scalar  = 8  // per scalar value, including list elements
+	Bytes   = 24 // per string or bytes value, excluding its contents
+)
@@ -0,0 +1,46 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dynamicext resolves the extensions declared in a set of files
+// with dynamic types, shared by the packages working on schemas known only
+// at run time.
+package dynamicext
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Types returns the dynamic types of the extensions declared in the files,
+// including those nested in messages. It reports an error if two of them
+// extend the same message with the same number, which building the files
+// does not check when they are declared in different files.
+func Types(files *protoregistry.Files) (*protoregistry.Types, error) {
+	types := new(protoregistry.Types)
+	var err error
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		err = register(types, fd.Extensions(), fd.Messages())
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+func register(types *protoregistry.Types, xds protoreflect.ExtensionDescriptors, mds protoreflect.MessageDescriptors) error {
+	for i := 0; i < xds.Len(); i++ {
+		if err := types.RegisterExtension(dynamicpb.NewExtensionType(xds.Get(i))); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		if err := register(types, md.Extensions(), md.Messages()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
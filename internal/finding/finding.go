@@ -0,0 +1,56 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package finding holds the parts of findings shared by the schema checkers
+// of the breaking and lint packages, so that they are reported and ordered
+// alike.
+package finding
+
+import "fmt"
+
+// A Location is a position in a .proto source file.
+// Line and Column are 1-based, and zero if unknown.
+type Location struct {
+	File   string `json:"file"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+func (l Location) String() string {
+	if l.Line == 0 {
+		return l.File
+	}
+	return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+}
+
+// A Finding is a problem found in a schema by a rule.
+type Finding struct {
+	// Rule is the ID of the rule.
+	Rule string `json:"rule"`
+	// Element is the full name of the element the problem is found in.
+	Element  string   `json:"element"`
+	Message  string   `json:"message"`
+	Location Location `json:"location"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%v: %s: %s", f.Location, f.Rule, f.Message)
+}
+
+// Less reports whether a is reported before b: findings are ordered by
+// location, then by element and rule.
+func Less(a, b Finding) bool {
+	la, lb := a.Location, b.Location
+	switch {
+	case la.File != lb.File:
+		return la.File < lb.File
+	case la.Line != lb.Line:
+		return la.Line < lb.Line
+	case la.Column != lb.Column:
+		return la.Column < lb.Column
+	case a.Element != b.Element:
+		return a.Element < b.Element
+	}
+	return a.Rule < b.Rule
+}
@@ -0,0 +1,531 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gendoc contains the schema documentation generator.
+//
+// It generates a page for each .proto file, in Markdown or HTML, describing
+// its messages, enums, extensions and services with their comments. Types
+// are linked to their declarations, in the same page or in the page of the
+// file declaring them, and the well-known types are linked to their
+// reference documentation.
+package gendoc
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// A Format is the format of the generated documentation.
+type Format string
+
+const (
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+)
+
+// Extension returns the file name extension of pages in the format.
+func (f Format) Extension() string {
+	if f == HTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+// wellKnownURL is the reference documentation of the well-known types.
+const wellKnownURL = "https://developers.google.com/protocol-buffers/docs/reference/google.protobuf"
+
+// wellKnownFiles are the files declaring the well-known types.
+var wellKnownFiles = map[string]bool{
+	"google/protobuf/any.proto":            true,
+	"google/protobuf/api.proto":            true,
+	"google/protobuf/duration.proto":       true,
+	"google/protobuf/empty.proto":          true,
+	"google/protobuf/field_mask.proto":     true,
+	"google/protobuf/source_context.proto": true,
+	"google/protobuf/struct.proto":         true,
+	"google/protobuf/timestamp.proto":      true,
+	"google/protobuf/type.proto":           true,
+	"google/protobuf/wrappers.proto":       true,
+}
+
+// GenerateFile generates the documentation page of a file.
+func GenerateFile(gen *protogen.Plugin, file *protogen.File, format Format) *protogen.GeneratedFile {
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+format.Extension(), file.GoImportPath)
+	page := newPage(gen, file, format)
+	var err error
+	if format == HTML {
+		err = htmlTemplate.Execute(g, page)
+	} else {
+		err = markdownTemplate.Execute(g, page)
+	}
+	if err != nil {
+		gen.Error(fmt.Errorf("%s: %v", file.Desc.Path(), err))
+	}
+	return g
+}
+
+// A page is the documentation of a file.
+type page struct {
+	Path       string
+	Package    string
+	Comments   string
+	Messages   []*message
+	Enums      []*enum
+	Extensions []*field
+	Services   []*service
+}
+
+type message struct {
+	Anchor     string
+	Name       string // full name
+	Comments   string
+	Deprecated bool
+	Fields     []*field
+	Extensions []*field
+}
+
+type field struct {
+	Name       string
+	Type       typeRef
+	Label      string
+	Number     int32
+	JSONName   string
+	Oneof      string
+	Extendee   typeRef
+	Comments   string
+	Deprecated bool
+}
+
+// typeRef is a reference to a type, with the URL of its documentation
+// if known.
+type typeRef struct {
+	Name string
+	URL  string
+}
+
+type enum struct {
+	Anchor     string
+	Name       string
+	Comments   string
+	Deprecated bool
+	Values     []*enumValue
+}
+
+type enumValue struct {
+	Name       string
+	Number     int32
+	Comments   string
+	Deprecated bool
+}
+
+type service struct {
+	Anchor     string
+	Name       string
+	Comments   string
+	Deprecated bool
+	Methods    []*method
+}
+
+type method struct {
+	Anchor          string
+	Name            string
+	Input, Output   typeRef
+	ClientStreaming bool
+	ServerStreaming bool
+	Comments        string
+	Deprecated      bool
+}
+
+// pageBuilder builds the documentation of a file.
+type pageBuilder struct {
+	gen    *protogen.Plugin
+	file   *protogen.File
+	format Format
+}
+
+func newPage(gen *protogen.Plugin, file *protogen.File, format Format) *page {
+	b := &pageBuilder{gen, file, format}
+	p := &page{
+		Path:     file.Desc.Path(),
+		Package:  string(file.Desc.Package()),
+		Comments: comments(file.Desc.SourceLocations().ByPath(protoreflect.SourcePath{2}).LeadingComments),
+	}
+	var addMessages func([]*protogen.Message)
+	addMessages = func(messages []*protogen.Message) {
+		for _, m := range messages {
+			if m.Desc.IsMapEntry() {
+				continue
+			}
+			p.Messages = append(p.Messages, b.message(m))
+			for _, e := range m.Enums {
+				p.Enums = append(p.Enums, b.enum(e))
+			}
+			addMessages(m.Messages)
+		}
+	}
+	addMessages(file.Messages)
+	for _, e := range file.Enums {
+		p.Enums = append(p.Enums, b.enum(e))
+	}
+	for _, x := range file.Extensions {
+		p.Extensions = append(p.Extensions, b.field(x))
+	}
+	for _, s := range file.Services {
+		p.Services = append(p.Services, b.service(s))
+	}
+	return p
+}
+
+func comments(s string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		lines = append(lines, strings.TrimPrefix(line, " "))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func (b *pageBuilder) message(m *protogen.Message) *message {
+	doc := &message{
+		Anchor:     string(m.Desc.FullName()),
+		Name:       string(m.Desc.FullName()),
+		Comments:   comments(string(m.Comments.Leading)),
+		Deprecated: m.Desc.Options().(*descriptorpb.MessageOptions).GetDeprecated(),
+	}
+	for _, f := range m.Fields {
+		doc.Fields = append(doc.Fields, b.field(f))
+	}
+	for _, x := range m.Extensions {
+		doc.Extensions = append(doc.Extensions, b.field(x))
+	}
+	return doc
+}
+
+func (b *pageBuilder) field(f *protogen.Field) *field {
+	doc := &field{
+		Name:       string(f.Desc.Name()),
+		Type:       b.fieldType(f),
+		Label:      label(f.Desc),
+		Number:     int32(f.Desc.Number()),
+		JSONName:   f.Desc.JSONName(),
+		Comments:   comments(string(f.Comments.Leading) + string(f.Comments.Trailing)),
+		Deprecated: f.Desc.Options().(*descriptorpb.FieldOptions).GetDeprecated(),
+	}
+	if f.Oneof != nil && !f.Oneof.Desc.IsSynthetic() {
+		doc.Oneof = string(f.Oneof.Desc.Name())
+	}
+	if f.Desc.IsExtension() {
+		doc.Name = string(f.Desc.FullName())
+		doc.Extendee = b.typeRef(f.Extendee.Desc)
+	}
+	return doc
+}
+
+func label(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return ""
+	case fd.Cardinality() == protoreflect.Repeated:
+		return "repeated"
+	case fd.Cardinality() == protoreflect.Required:
+		return "required"
+	case fd.Syntax() == protoreflect.Proto2 || fd.HasOptionalKeyword():
+		return "optional"
+	}
+	return ""
+}
+
+func (b *pageBuilder) fieldType(f *protogen.Field) typeRef {
+	if f.Desc.IsMap() {
+		key := b.fieldType(f.Message.Fields[0])
+		value := b.fieldType(f.Message.Fields[1])
+		// The key is a scalar, and needs no link.
+		return typeRef{Name: "map<" + key.Name + ", " + value.Name + ">", URL: value.URL}
+	}
+	switch {
+	case f.Message != nil:
+		return b.typeRef(f.Message.Desc)
+	case f.Enum != nil:
+		return b.typeRef(f.Enum.Desc)
+	}
+	return typeRef{Name: f.Desc.Kind().String()}
+}
+
+// typeRef returns a reference to a message or enum.
+func (b *pageBuilder) typeRef(d protoreflect.Descriptor) typeRef {
+	ref := typeRef{Name: string(d.FullName())}
+	path := d.ParentFile().Path()
+	switch {
+	case wellKnownFiles[path]:
+		ref.URL = wellKnownURL + "#" + string(d.FullName())
+	case path == b.file.Desc.Path():
+		ref.URL = "#" + string(d.FullName())
+	default:
+		// Link to the page of the file declaring the type,
+		// if it is generated as well.
+		if f, ok := b.gen.FilesByPath[path]; ok && f.Generate {
+			rel, err := filepath.Rel(filepath.Dir(b.file.GeneratedFilenamePrefix), f.GeneratedFilenamePrefix+b.format.Extension())
+			if err == nil {
+				ref.URL = filepath.ToSlash(rel) + "#" + string(d.FullName())
+			}
+		}
+	}
+	return ref
+}
+
+func (b *pageBuilder) enum(e *protogen.Enum) *enum {
+	doc := &enum{
+		Anchor:     string(e.Desc.FullName()),
+		Name:       string(e.Desc.FullName()),
+		Comments:   comments(string(e.Comments.Leading)),
+		Deprecated: e.Desc.Options().(*descriptorpb.EnumOptions).GetDeprecated(),
+	}
+	for _, v := range e.Values {
+		doc.Values = append(doc.Values, &enumValue{
+			Name:       string(v.Desc.Name()),
+			Number:     int32(v.Desc.Number()),
+			Comments:   comments(string(v.Comments.Leading) + string(v.Comments.Trailing)),
+			Deprecated: v.Desc.Options().(*descriptorpb.EnumValueOptions).GetDeprecated(),
+		})
+	}
+	return doc
+}
+
+func (b *pageBuilder) service(s *protogen.Service) *service {
+	doc := &service{
+		Anchor:     string(s.Desc.FullName()),
+		Name:       string(s.Desc.FullName()),
+		Comments:   comments(string(s.Comments.Leading)),
+		Deprecated: s.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated(),
+	}
+	for _, m := range s.Methods {
+		doc.Methods = append(doc.Methods, &method{
+			Anchor:          string(m.Desc.FullName()),
+			Name:            string(m.Desc.Name()),
+			Input:           b.typeRef(m.Input.Desc),
+			Output:          b.typeRef(m.Output.Desc),
+			ClientStreaming: m.Desc.IsStreamingClient(),
+			ServerStreaming: m.Desc.IsStreamingServer(),
+			Comments:        comments(string(m.Comments.Leading)),
+			Deprecated:      m.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated(),
+		})
+	}
+	return doc
+}
+
+// cell escapes text for a cell of a Markdown table.
+func cell(s string) string {
+	s = strings.Replace(s, "|", `\|`, -1)
+	return strings.Replace(s, "\n", "<br>", -1)
+}
+
+// mdLink formats a reference as a Markdown link.
+func mdLink(ref typeRef) string {
+	if ref.URL == "" {
+		return "`" + ref.Name + "`"
+	}
+	return "[`" + ref.Name + "`](" + ref.URL + ")"
+}
+
+// paragraphs splits comments into paragraphs.
+func paragraphs(s string) []string {
+	var ps []string
+	for _, p := range strings.Split(s, "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+var markdownTemplate = template.Must(template.New("markdown").Funcs(template.FuncMap{
+	"cell": cell,
+	"link": mdLink,
+}).Parse(`# {{.Path}}
+{{if .Package}}
+Package: ` + "`{{.Package}}`" + `
+{{end}}{{with .Comments}}
+{{.}}
+{{end}}
+## Contents
+{{range .Messages}}
+- [{{.Name}}](#{{.Anchor}})
+{{- end}}
+{{- range .Enums}}
+- [{{.Name}}](#{{.Anchor}})
+{{- end}}
+{{- if .Extensions}}
+- [Extensions](#extensions)
+{{- end}}
+{{- range .Services}}
+- [{{.Name}}](#{{.Anchor}})
+{{- range .Methods}}
+  - [{{.Name}}](#{{.Anchor}})
+{{- end}}
+{{- end}}
+{{range .Messages}}
+<a name="{{.Anchor}}"></a>
+
+## {{.Name}}
+{{if .Deprecated}}
+**Deprecated.**
+{{end}}{{with .Comments}}
+{{.}}
+{{end}}{{if .Fields}}
+| Field | Type | Label | Number | JSON name | Description |
+| ----- | ---- | ----- | -----: | --------- | ----------- |
+{{range .Fields}}| ` + "`{{.Name}}`" + ` | {{link .Type}} | {{.Label}} | {{.Number}} | ` + "`{{.JSONName}}`" + ` | {{template "fieldDescription" .}} |
+{{end}}{{end}}{{if .Extensions}}
+| Extension | Type | Extendee | Label | Number | Description |
+| --------- | ---- | -------- | ----- | -----: | ----------- |
+{{range .Extensions}}{{template "extensionRow" .}}
+{{end}}{{end}}{{end}}{{range .Enums}}
+<a name="{{.Anchor}}"></a>
+
+## {{.Name}}
+{{if .Deprecated}}
+**Deprecated.**
+{{end}}{{with .Comments}}
+{{.}}
+{{end}}
+| Name | Number | Description |
+| ---- | -----: | ----------- |
+{{range .Values}}| ` + "`{{.Name}}`" + ` | {{.Number}} | {{if .Deprecated}}**Deprecated.** {{end}}{{cell .Comments}} |
+{{end}}{{end}}{{if .Extensions}}
+<a name="extensions"></a>
+
+## Extensions
+
+| Extension | Type | Extendee | Label | Number | Description |
+| --------- | ---- | -------- | ----- | -----: | ----------- |
+{{range .Extensions}}{{template "extensionRow" .}}
+{{end}}{{end}}{{range .Services}}
+<a name="{{.Anchor}}"></a>
+
+## {{.Name}}
+{{if .Deprecated}}
+**Deprecated.**
+{{end}}{{with .Comments}}
+{{.}}
+{{end}}{{range .Methods}}
+<a name="{{.Anchor}}"></a>
+
+### {{.Name}}
+
+rpc {{.Name}}({{if .ClientStreaming}}stream {{end}}{{link .Input}}) returns ({{if .ServerStreaming}}stream {{end}}{{link .Output}})
+{{if .Deprecated}}
+**Deprecated.**
+{{end}}{{with .Comments}}
+{{.}}
+{{end}}{{end}}{{end}}
+{{- define "fieldDescription"}}{{if .Deprecated}}**Deprecated.** {{end}}{{if .Oneof}}Member of oneof ` + "`{{.Oneof}}`" + `.{{if .Comments}}<br>{{end}}{{end}}{{cell .Comments}}{{end}}
+{{- define "extensionRow"}}| ` + "`{{.Name}}`" + ` | {{link .Type}} | {{link .Extendee}} | {{.Label}} | {{.Number}} | {{if .Deprecated}}**Deprecated.** {{end}}{{cell .Comments}} |{{end}}
+`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(htmltemplate.FuncMap{
+	"paragraphs": paragraphs,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Path}}</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: auto; padding: 1em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+.deprecated { color: #b00; font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Path}}</h1>
+{{- if .Package}}
+<p>Package: <code>{{.Package}}</code></p>
+{{- end}}
+{{- template "comments" .Comments}}
+<h2>Contents</h2>
+<ul>
+{{- range .Messages}}
+<li><a href="#{{.Anchor}}">{{.Name}}</a></li>
+{{- end}}
+{{- range .Enums}}
+<li><a href="#{{.Anchor}}">{{.Name}}</a></li>
+{{- end}}
+{{- if .Extensions}}
+<li><a href="#extensions">Extensions</a></li>
+{{- end}}
+{{- range .Services}}
+<li><a href="#{{.Anchor}}">{{.Name}}</a>
+<ul>
+{{- range .Methods}}
+<li><a href="#{{.Anchor}}">{{.Name}}</a></li>
+{{- end}}
+</ul></li>
+{{- end}}
+</ul>
+{{- range .Messages}}
+<h2 id="{{.Anchor}}">{{.Name}}</h2>
+{{- template "deprecated" .Deprecated}}
+{{- template "comments" .Comments}}
+{{- if .Fields}}
+<table>
+<tr><th>Field</th><th>Type</th><th>Label</th><th>Number</th><th>JSON name</th><th>Description</th></tr>
+{{- range .Fields}}
+<tr><td><code>{{.Name}}</code></td><td>{{template "link" .Type}}</td><td>{{.Label}}</td><td>{{.Number}}</td><td><code>{{.JSONName}}</code></td><td>
+{{- template "deprecated" .Deprecated}}{{if .Oneof}}<p>Member of oneof <code>{{.Oneof}}</code>.</p>{{end}}{{template "comments" .Comments}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Extensions}}
+{{- template "extensions" .Extensions}}
+{{- end}}
+{{- end}}
+{{- range .Enums}}
+<h2 id="{{.Anchor}}">{{.Name}}</h2>
+{{- template "deprecated" .Deprecated}}
+{{- template "comments" .Comments}}
+<table>
+<tr><th>Name</th><th>Number</th><th>Description</th></tr>
+{{- range .Values}}
+<tr><td><code>{{.Name}}</code></td><td>{{.Number}}</td><td>{{template "deprecated" .Deprecated}}{{template "comments" .Comments}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Extensions}}
+<h2 id="extensions">Extensions</h2>
+{{- template "extensions" .Extensions}}
+{{- end}}
+{{- range .Services}}
+<h2 id="{{.Anchor}}">{{.Name}}</h2>
+{{- template "deprecated" .Deprecated}}
+{{- template "comments" .Comments}}
+{{- range .Methods}}
+<h3 id="{{.Anchor}}">{{.Name}}</h3>
+<p><code>rpc {{.Name}}({{if .ClientStreaming}}stream {{end}}{{template "link" .Input}}) returns ({{if .ServerStreaming}}stream {{end}}{{template "link" .Output}})</code></p>
+{{- template "deprecated" .Deprecated}}
+{{- template "comments" .Comments}}
+{{- end}}
+{{- end}}
+</body>
+</html>
+{{define "link"}}{{if .URL}}<a href="{{.URL}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}{{end}}
+{{- define "deprecated"}}{{if .}}
+<p class="deprecated">Deprecated.</p>{{end}}{{end}}
+{{- define "comments"}}{{range paragraphs .}}
+<p>{{.}}</p>{{end}}{{end}}
+{{- define "extensions"}}
+<table>
+<tr><th>Extension</th><th>Type</th><th>Extendee</th><th>Label</th><th>Number</th><th>Description</th></tr>
+{{- range .}}
+<tr><td><code>{{.Name}}</code></td><td>{{template "link" .Type}}</td><td>{{template "link" .Extendee}}</td><td>{{.Label}}</td><td>{{.Number}}</td><td>{{template "deprecated" .Deprecated}}{{template "comments" .Comments}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+`))
@@ -0,0 +1,168 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gendoc
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/protoparse"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+var sources = map[string]string{
+	"test/a.proto": `syntax = "proto3";
+
+// Package test tests the documentation.
+package test;
+
+import "google/protobuf/timestamp.proto";
+import "test/b/b.proto";
+
+option go_package = "example.com/test";
+
+// Event is something that happened.
+//
+// Events are never modified.
+message Event {
+  string name = 1; // The name | title.
+  google.protobuf.Timestamp time = 2;
+  repeated test.b.Tag tags = 3;
+  map<string, Event> children = 4;
+  optional int32 count = 5 [json_name = "n"];
+  oneof source {
+    // The host, if any.
+    string host = 6;
+    int64 pid = 7 [deprecated = true];
+  }
+  Kind kind = 8;
+
+  enum Kind {
+    KIND_UNSPECIFIED = 0;
+    // A user event.
+    KIND_USER = 1;
+  }
+}
+
+// Events records events.
+service Events {
+  option deprecated = true;
+
+  // Record records an event.
+  rpc Record(Event) returns (Event);
+  rpc Watch(stream Event) returns (stream Event);
+}
+`,
+	"test/b/b.proto": `syntax = "proto2";
+
+package test.b;
+
+option go_package = "example.com/test/b";
+
+message Tag {
+  required string key = 1;
+  extensions 100 to 200;
+}
+
+extend Tag {
+  optional string note = 100;
+}
+`,
+}
+
+func generate(t *testing.T, format Format) map[string]string {
+	p := &protoparse.Parser{
+		Accessor: func(name string) (io.ReadCloser, error) {
+			src, ok := sources[name]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return ioutil.NopCloser(strings.NewReader(src)), nil
+		},
+	}
+	req, err := p.CodeGeneratorRequest("", "test/a.proto", "test/b/b.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range gen.Files {
+		if f.Generate {
+			GenerateFile(gen, f, format)
+		}
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatal(resp.GetError())
+	}
+	files := make(map[string]string)
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+	return files
+}
+
+func TestGenerateFile(t *testing.T) {
+	tests := []struct {
+		format Format
+		name   string
+		want   []string
+	}{{
+		format: Markdown,
+		name:   "example.com/test/a.md",
+		want: []string{
+			"Package test tests the documentation.\n",
+			"  - [Watch](#test.Events.Watch)\n",
+			"<a name=\"test.Event\"></a>\n\n## test.Event\n\nEvent is something that happened.\n\nEvents are never modified.\n",
+			"| `name` | `string` |  | 1 | `name` | The name \\| title. |\n",
+			"| `time` | [`google.protobuf.Timestamp`](" + wellKnownURL + "#google.protobuf.Timestamp) |  | 2 | `time` |  |\n",
+			"| `tags` | [`test.b.Tag`](b/b.md#test.b.Tag) | repeated | 3 | `tags` |  |\n",
+			"| `children` | [`map<string, test.Event>`](#test.Event) |  | 4 | `children` |  |\n",
+			"| `count` | `int32` | optional | 5 | `n` |  |\n",
+			"| `host` | `string` |  | 6 | `host` | Member of oneof `source`.<br>The host, if any. |\n",
+			"| `pid` | `int64` |  | 7 | `pid` | **Deprecated.** Member of oneof `source`. |\n",
+			"| `KIND_USER` | 1 | A user event. |\n",
+			"## test.Events\n\n**Deprecated.**\n\nEvents records events.\n",
+			"rpc Watch(stream [`test.Event`](#test.Event)) returns (stream [`test.Event`](#test.Event))\n",
+		},
+	}, {
+		format: Markdown,
+		name:   "example.com/test/b/b.md",
+		want: []string{
+			"| `key` | `string` | required | 1 | `key` |  |\n",
+			"| `test.b.note` | `string` | [`test.b.Tag`](#test.b.Tag) | optional | 100 |  |\n",
+		},
+	}, {
+		format: HTML,
+		name:   "example.com/test/a.html",
+		want: []string{
+			"<h2 id=\"test.Event\">test.Event</h2>\n<p>Event is something that happened.</p>\n<p>Events are never modified.</p>\n",
+			"<tr><td><code>tags</code></td><td><a href=\"b/b.html#test.b.Tag\">test.b.Tag</a></td><td>repeated</td><td>3</td><td><code>tags</code></td><td></td></tr>\n",
+			"<td><a href=\"#test.Event\">map&lt;string, test.Event&gt;</a></td>",
+			"<td><code>n</code></td>",
+			"<p class=\"deprecated\">Deprecated.</p><p>Member of oneof <code>source</code>.</p>",
+			"<h3 id=\"test.Events.Record\">Record</h3>\n",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := generate(t, tt.format)
+			got, ok := files[tt.name]
+			if !ok {
+				t.Fatalf("%s not generated", tt.name)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("%s does not contain %q; got:\n%s", tt.name, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,719 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengofast
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/fastpath"
+	"github.com/golang/protobuf/internal/optionscope"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	mathPackage      = protogen.GoImportPath("math")
+	sortPackage      = protogen.GoImportPath("sort")
+	utf8Package      = protogen.GoImportPath("unicode/utf8")
+	protowirePackage = protogen.GoImportPath("google.golang.org/protobuf/encoding/protowire")
+)
+
+// codecMethodNames are the names of the generated marshaling methods, which
+// fields must not use. Fields named Marshal and Unmarshal are renamed by
+// protogen.
+var codecMethodNames = map[string]bool{
+	"Size":                 true,
+	"MarshalToSizedBuffer": true,
+}
+
+// codecOption returns the value of the marshal option of a message, or else
+// of the marshal_messages option of its file, and whether the message sets
+// the marshal option. The options are only read in files importing
+// fastpath.proto.
+func codecOption(file *protogen.File, message *protogen.Message) (on, set bool, err error) {
+	if optionscope.Visible(file.Desc, fastpath.E_Marshal) {
+		v, ok, err := descriptor.OptionReader{}.Get(message.Desc, fastpath.E_Marshal)
+		if err != nil || ok {
+			return ok && v.Bool(), ok, err
+		}
+	}
+	if !optionscope.Visible(file.Desc, fastpath.E_MarshalMessages) {
+		return false, false, nil
+	}
+	v, ok, err := descriptor.OptionReader{}.Get(file.Desc, fastpath.E_MarshalMessages)
+	return ok && v.Bool(), false, err
+}
+
+// codecMessages returns the messages of a file whose marshaling methods are
+// generated. A message selected by its own marshal option must be able to
+// have the methods: those without Equal, Clone and MergeFrom methods, and
+// those with fields named like the marshaling methods, cannot.
+func codecMessages(file *protogen.File) (map[*protogen.Message]bool, error) {
+	codecs := make(map[*protogen.Message]bool)
+	for _, message := range messages(file.Messages) {
+		on, set, err := codecOption(file, message)
+		if err != nil {
+			return nil, err
+		}
+		if !on {
+			continue
+		}
+		if !hasCodec(file, message) {
+			if set {
+				return nil, fmt.Errorf("%v: message %v cannot have marshaling methods", file.Desc.Path(), message.Desc.FullName())
+			}
+			continue
+		}
+		codecs[message] = true
+	}
+	return codecs, nil
+}
+
+// hasCodec reports whether a message can have marshaling methods.
+func hasCodec(file *protogen.File, message *protogen.Message) bool {
+	if !hasMethods(file, message) {
+		return false
+	}
+	for _, field := range message.Fields {
+		if codecMethodNames[field.GoName] {
+			return false
+		}
+	}
+	for _, oneof := range message.Oneofs {
+		if codecMethodNames[oneof.GoName] {
+			return false
+		}
+	}
+	return true
+}
+
+// A codecGen generates the marshaling methods of the messages of a file.
+type codecGen struct {
+	g      *protogen.GeneratedFile
+	codecs map[*protogen.Message]bool
+}
+
+func (c codecGen) genMessage(message *protogen.Message) {
+	c.genSize(message)
+	c.genMarshal(message)
+	c.genUnmarshal(message)
+}
+
+// tagBytes returns the encoding of the tag of a field.
+func tagBytes(num protoreflect.FieldNumber, typ protowire.Type) []byte {
+	return protowire.AppendVarint(nil, protowire.EncodeTag(num, typ))
+}
+
+// wireType returns the wire type of a value of a field, or of an element of
+// an unpacked repeated field.
+func wireType(field *protogen.Field) protowire.Type {
+	switch field.Desc.Kind() {
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind, protoreflect.FloatKind:
+		return protowire.Fixed32Type
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind, protoreflect.DoubleKind:
+		return protowire.Fixed64Type
+	case protoreflect.StringKind, protoreflect.BytesKind, protoreflect.MessageKind:
+		return protowire.BytesType
+	case protoreflect.GroupKind:
+		return protowire.StartGroupType
+	}
+	return protowire.VarintType
+}
+
+// fixedSize returns the size of the encoding of a value of a field of a kind
+// with a fixed size, or 0.
+func fixedSize(field *protogen.Field) int {
+	switch wireType(field) {
+	case protowire.Fixed32Type:
+		return 4
+	case protowire.Fixed64Type:
+		return 8
+	}
+	if field.Desc.Kind() == protoreflect.BoolKind {
+		return 1
+	}
+	return 0
+}
+
+// varint returns the expression of the varint encoding of a value of a field.
+func (c codecGen) varint(field *protogen.Field, v string) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return c.g.QualifiedGoIdent(protowirePackage.Ident("EncodeBool")) + "(" + v + ")"
+	case protoreflect.Sint32Kind:
+		return c.g.QualifiedGoIdent(protowirePackage.Ident("EncodeZigZag")) + "(int64(" + v + "))"
+	case protoreflect.Sint64Kind:
+		return c.g.QualifiedGoIdent(protowirePackage.Ident("EncodeZigZag")) + "(" + v + ")"
+	case protoreflect.Uint64Kind:
+		return v
+	}
+	return "uint64(" + v + ")"
+}
+
+// messageSize returns the expression of the size of a message value.
+func (c codecGen) messageSize(message *protogen.Message, v string) string {
+	if c.codecs[message] {
+		return v + ".Size()"
+	}
+	return c.g.QualifiedGoIdent(protoPackage.Ident("Size")) + "(" + v + ")"
+}
+
+// valueSize returns the expression of the size of the encoding of a value
+// of a field, or of an element of a repeated field, excluding its tag.
+func (c codecGen) valueSize(field *protogen.Field, v string) string {
+	if n := fixedSize(field); n > 0 {
+		return strconv.Itoa(n)
+	}
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return c.g.QualifiedGoIdent(protowirePackage.Ident("SizeBytes")) + "(len(" + v + "))"
+	case protoreflect.MessageKind:
+		return c.g.QualifiedGoIdent(protowirePackage.Ident("SizeBytes")) + "(" + c.messageSize(field.Message, v) + ")"
+	case protoreflect.GroupKind:
+		return strconv.Itoa(len(tagBytes(field.Desc.Number(), protowire.EndGroupType))) + " + " + c.messageSize(field.Message, v)
+	}
+	return c.g.QualifiedGoIdent(protowirePackage.Ident("SizeVarint")) + "(" + c.varint(field, v) + ")"
+}
+
+// fieldSize returns the expression of the size of the encoding of a value
+// of a field, or of an element of an unpacked repeated field.
+func (c codecGen) fieldSize(field *protogen.Field, v string) string {
+	tag := len(tagBytes(field.Desc.Number(), wireType(field)))
+	if n := fixedSize(field); n > 0 {
+		return strconv.Itoa(tag + n)
+	}
+	return strconv.Itoa(tag) + " + " + c.valueSize(field, v)
+}
+
+// present returns the condition of a field being encoded, for fields that
+// are neither repeated nor part of a oneof. Unlike proto.Merge, negative
+// zero float values are encoded.
+func (c codecGen) present(field *protogen.Field, v string) string {
+	switch {
+	case field.Message != nil || pointerField(field):
+		return v + " != nil"
+	case field.Desc.Kind() == protoreflect.BytesKind && field.Desc.HasPresence():
+		return v + " != nil"
+	case field.Desc.Kind() == protoreflect.BytesKind:
+		return "len(" + v + ") > 0"
+	case isFloat(field):
+		return v + " != 0 || " + c.g.QualifiedGoIdent(mathPackage.Ident("Signbit")) + "(" + float64Of(field, v) + ")"
+	}
+	return populated(field, v)
+}
+
+func (c codecGen) genSize(message *protogen.Message) {
+	g := c.g
+	g.P("// Size returns the size in bytes of the wire-format encoding of x, as by")
+	g.P("// proto.Size, without the use of protobuf reflection.")
+	g.P("func (x *", message.GoIdent.GoName, ") Size() (n int) {")
+	g.P("if x == nil {")
+	g.P("return 0")
+	g.P("}")
+	for _, field := range message.Fields {
+		x := "x." + field.GoName
+		switch {
+		case field.Desc.IsMap():
+			key, val := field.Message.Fields[0], field.Message.Fields[1]
+			tag := len(tagBytes(field.Desc.Number(), protowire.BytesType))
+			if fixedSize(key) > 0 && fixedSize(val) > 0 {
+				s := fixedSize(key) + fixedSize(val) + 2
+				g.P("n += ", tag+protowire.SizeBytes(s), " * len(", x, ")")
+				continue
+			}
+			k, v := "k", "v"
+			if fixedSize(key) > 0 {
+				k = "_"
+			}
+			if fixedSize(val) > 0 {
+				v = "_"
+			}
+			g.P("for ", k, ", ", v, " := range ", x, " {")
+			g.P("s := ", c.fieldSize(key, k), " + ", c.fieldSize(val, v))
+			g.P("n += ", tag, " + ", protowirePackage.Ident("SizeBytes"), "(s)")
+			g.P("}")
+		case field.Desc.IsPacked():
+			g.P("if len(", x, ") > 0 {")
+			if n := fixedSize(field); n > 0 {
+				g.P("s := ", n, " * len(", x, ")")
+			} else {
+				g.P("s := 0")
+				g.P("for _, v := range ", x, " {")
+				g.P("s += ", c.valueSize(field, "v"))
+				g.P("}")
+			}
+			g.P("n += ", len(tagBytes(field.Desc.Number(), protowire.BytesType)), " + ", protowirePackage.Ident("SizeBytes"), "(s)")
+			g.P("}")
+		case field.Desc.IsList() && fixedSize(field) > 0:
+			g.P("n += ", c.fieldSize(field, ""), " * len(", x, ")")
+		case field.Desc.IsList():
+			g.P("for _, v := range ", x, " {")
+			g.P("n += ", c.fieldSize(field, "v"))
+			g.P("}")
+		case oneofField(field) && fixedSize(field) > 0:
+			g.P("if _, ok := x.", field.Oneof.GoName, ".(*", field.GoIdent, "); ok {")
+			g.P("n += ", c.fieldSize(field, ""))
+			g.P("}")
+		case oneofField(field):
+			g.P("if v, ok := x.", field.Oneof.GoName, ".(*", field.GoIdent, "); ok {")
+			g.P("n += ", c.fieldSize(field, "v."+field.GoName))
+			g.P("}")
+		default:
+			v := x
+			if pointerField(field) {
+				v = "*" + x
+			}
+			g.P("if ", c.present(field, x), " {")
+			g.P("n += ", c.fieldSize(field, v))
+			g.P("}")
+		}
+	}
+	g.P("n += len(x.unknownFields)")
+	g.P("return n")
+	g.P("}")
+	g.P()
+}
+
+func (c codecGen) genMarshal(message *protogen.Message) {
+	g := c.g
+	name := message.GoIdent.GoName
+	g.P("// Marshal returns the wire-format encoding of x, as by proto.Marshal with")
+	g.P("// deterministic ordering of map entries, without the use of protobuf")
+	g.P("// reflection.")
+	g.P("func (x *", name, ") Marshal() ([]byte, error) {")
+	g.P("b := make([]byte, x.Size())")
+	g.P("n, err := x.MarshalToSizedBuffer(b)")
+	g.P("if err != nil {")
+	g.P("return nil, err")
+	g.P("}")
+	g.P("return b[len(b)-n:], nil")
+	g.P("}")
+	g.P()
+
+	// As in protobuf reflection, fields are encoded in order of field numbers,
+	// except oneofs which are encoded last in order of declaration, followed
+	// by the unknown fields. The encoding is written backwards.
+	fields := append([]*protogen.Field(nil), message.Fields...)
+	sort.Slice(fields, func(i, j int) bool {
+		x, y := fields[i], fields[j]
+		if oneofField(x) != oneofField(y) {
+			return oneofField(x)
+		}
+		if oneofField(x) && x.Oneof != y.Oneof {
+			return x.Oneof.Desc.Index() > y.Oneof.Desc.Index()
+		}
+		return x.Desc.Number() > y.Desc.Number()
+	})
+	g.P("// MarshalToSizedBuffer writes the wire-format encoding of x at the end of b,")
+	g.P("// which must be at least x.Size() bytes long, and returns its length.")
+	g.P("func (x *", name, ") MarshalToSizedBuffer(b []byte) (int, error) {")
+	g.P("if x == nil {")
+	g.P("return 0, nil")
+	g.P("}")
+	g.P("i := len(b)")
+	g.P("if len(x.unknownFields) > 0 {")
+	g.P("i -= len(x.unknownFields)")
+	g.P("copy(b[i:], x.unknownFields)")
+	g.P("}")
+	for _, field := range fields {
+		x := "x." + field.GoName
+		switch {
+		case field.Desc.IsMap():
+			c.genPutMap(field)
+		case field.Desc.IsPacked():
+			g.P("if len(", x, ") > 0 {")
+			g.P("j := i")
+			g.P("for k := len(", x, ") - 1; k >= 0; k-- {")
+			c.genPutValue(field, x+"[k]")
+			g.P("}")
+			g.P("i = ", fastpathPackage.Ident("PutVarint"), "(b, i, uint64(j-i))")
+			c.genPutTag(field.Desc.Number(), protowire.BytesType)
+			g.P("}")
+		case field.Desc.IsList():
+			g.P("for k := len(", x, ") - 1; k >= 0; k-- {")
+			c.genPutField(field, x+"[k]")
+			g.P("}")
+		case oneofField(field):
+			g.P("if v, ok := x.", field.Oneof.GoName, ".(*", field.GoIdent, "); ok {")
+			c.genPutField(field, "v."+field.GoName)
+			g.P("}")
+		default:
+			v := x
+			if pointerField(field) {
+				v = "*" + x
+			}
+			g.P("if ", c.present(field, x), " {")
+			c.genPutField(field, v)
+			g.P("}")
+		}
+	}
+	g.P("return len(b) - i, nil")
+	g.P("}")
+	g.P()
+}
+
+// genPutTag generates the backwards writing of a tag.
+func (c codecGen) genPutTag(num protoreflect.FieldNumber, typ protowire.Type) {
+	tag := tagBytes(num, typ)
+	if len(tag) == 1 {
+		c.g.P("i--")
+	} else {
+		c.g.P("i -= ", len(tag))
+	}
+	for j, t := range tag {
+		index := "i"
+		if j > 0 {
+			index = "i+" + strconv.Itoa(j)
+		}
+		c.g.P("b[", index, "] = ", fmt.Sprintf("%#x", t))
+	}
+}
+
+// genPutField generates the backwards writing of a value of a field, or of an
+// element of an unpacked repeated field, and of its tag.
+func (c codecGen) genPutField(field *protogen.Field, v string) {
+	if field.Desc.Kind() == protoreflect.GroupKind {
+		c.genPutTag(field.Desc.Number(), protowire.EndGroupType)
+		c.genPutMessage(field.Message, v)
+		c.genPutTag(field.Desc.Number(), protowire.StartGroupType)
+		return
+	}
+	c.genPutValue(field, v)
+	c.genPutTag(field.Desc.Number(), wireType(field))
+}
+
+// genPutValue generates the backwards writing of a value of a field, without
+// its tag.
+func (c codecGen) genPutValue(field *protogen.Field, v string) {
+	g := c.g
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		if field.Desc.Syntax() == protoreflect.Proto3 {
+			g.P("if !", utf8Package.Ident("ValidString"), "(", v, ") {")
+			g.P("return 0, ", fastpathPackage.Ident("InvalidUTF8"), "(", strconv.Quote(string(field.Desc.FullName())), ")")
+			g.P("}")
+		}
+		g.P("i = ", fastpathPackage.Ident("PutString"), "(b, i, ", v, ")")
+	case protoreflect.BytesKind:
+		g.P("i = ", fastpathPackage.Ident("PutBytes"), "(b, i, ", v, ")")
+	case protoreflect.MessageKind:
+		g.P("j := i")
+		c.genPutMessage(field.Message, v)
+		g.P("i = ", fastpathPackage.Ident("PutVarint"), "(b, i, uint64(j-i))")
+	case protoreflect.FloatKind:
+		g.P("i = ", fastpathPackage.Ident("PutFixed32"), "(b, i, ", mathPackage.Ident("Float32bits"), "(", v, "))")
+	case protoreflect.DoubleKind:
+		g.P("i = ", fastpathPackage.Ident("PutFixed64"), "(b, i, ", mathPackage.Ident("Float64bits"), "(", v, "))")
+	case protoreflect.Fixed32Kind:
+		g.P("i = ", fastpathPackage.Ident("PutFixed32"), "(b, i, ", v, ")")
+	case protoreflect.Sfixed32Kind:
+		g.P("i = ", fastpathPackage.Ident("PutFixed32"), "(b, i, uint32(", v, "))")
+	case protoreflect.Fixed64Kind:
+		g.P("i = ", fastpathPackage.Ident("PutFixed64"), "(b, i, ", v, ")")
+	case protoreflect.Sfixed64Kind:
+		g.P("i = ", fastpathPackage.Ident("PutFixed64"), "(b, i, uint64(", v, "))")
+	default:
+		g.P("i = ", fastpathPackage.Ident("PutVarint"), "(b, i, ", c.varint(field, v), ")")
+	}
+}
+
+// genPutMessage generates the backwards writing of the encoding of a message,
+// without its length.
+func (c codecGen) genPutMessage(message *protogen.Message, v string) {
+	g := c.g
+	if c.codecs[message] {
+		g.P("s, err := ", v, ".MarshalToSizedBuffer(b[:i])")
+	} else {
+		g.P("s, err := ", fastpathPackage.Ident("MarshalMessage"), "(b[:i], ", v, ")")
+	}
+	g.P("if err != nil {")
+	g.P("return 0, err")
+	g.P("}")
+	g.P("i -= s")
+}
+
+// genPutMap generates the backwards writing of the entries of a map field,
+// in decreasing order of keys.
+func (c codecGen) genPutMap(field *protogen.Field) {
+	g := c.g
+	x := "x." + field.GoName
+	key, val := field.Message.Fields[0], field.Message.Fields[1]
+	g.P("if len(", x, ") > 0 {")
+	g.P("keys := make([]", goType(g, key), ", 0, len(", x, "))")
+	g.P("for k := range ", x, " {")
+	g.P("keys = append(keys, k)")
+	g.P("}")
+	if key.Desc.Kind() == protoreflect.BoolKind {
+		g.P(sortPackage.Ident("Slice"), "(keys, func(k, l int) bool { return !keys[k] && keys[l] })")
+	} else {
+		g.P(sortPackage.Ident("Slice"), "(keys, func(k, l int) bool { return keys[k] < keys[l] })")
+	}
+	g.P("for k := len(keys) - 1; k >= 0; k-- {")
+	g.P("j := i")
+	g.P("v := ", x, "[keys[k]]")
+	if val.Message != nil {
+		g.P("{")
+		c.genPutField(val, "v")
+		g.P("}")
+	} else {
+		c.genPutField(val, "v")
+	}
+	c.genPutField(key, "keys[k]")
+	g.P("i = ", fastpathPackage.Ident("PutVarint"), "(b, i, uint64(j-i))")
+	c.genPutTag(field.Desc.Number(), protowire.BytesType)
+	g.P("}")
+	g.P("}")
+}
+
+func (c codecGen) genUnmarshal(message *protogen.Message) {
+	g := c.g
+	g.P("// Unmarshal merges the wire-format encoding in b into x, as by")
+	g.P("// proto.UnmarshalMerge, without the use of protobuf reflection. Required")
+	g.P("// fields are not checked.")
+	g.P("func (x *", message.GoIdent.GoName, ") Unmarshal(b []byte) error {")
+	g.P("for len(b) > 0 {")
+	g.P("num, typ, n, err := ", fastpathPackage.Ident("ConsumeTag"), "(b)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("switch {")
+	for _, field := range message.Fields {
+		c.genConsumeField(field)
+	}
+	g.P("default:")
+	g.P("m := ", protowirePackage.Ident("ConsumeFieldValue"), "(num, typ, b[n:])")
+	genCheckLength(g, "m")
+	g.P("// As in protobuf reflection, the tag is stored in its shortest form.")
+	g.P("x.unknownFields = ", protowirePackage.Ident("AppendTag"), "(x.unknownFields, num, typ)")
+	g.P("x.unknownFields = append(x.unknownFields, b[n:n+m]...)")
+	g.P("n += m")
+	g.P("}")
+	g.P("b = b[n:]")
+	g.P("}")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+}
+
+// genCase generates the case of the switch on the number and wire type of
+// a field.
+func genCase(g *protogen.GeneratedFile, num protoreflect.FieldNumber, typ protowire.Type) {
+	g.P("case num == ", num, " && typ == ", protowirePackage.Ident(wireTypeNames[typ]), ":")
+}
+
+var wireTypeNames = map[protowire.Type]string{
+	protowire.VarintType:     "VarintType",
+	protowire.Fixed32Type:    "Fixed32Type",
+	protowire.Fixed64Type:    "Fixed64Type",
+	protowire.BytesType:      "BytesType",
+	protowire.StartGroupType: "StartGroupType",
+}
+
+// genCheckLength generates the check of the length n returned by a protowire
+// function, which is negative for invalid input.
+func genCheckLength(g *protogen.GeneratedFile, n string) {
+	g.P("if ", n, " < 0 {")
+	g.P("return ", protowirePackage.Ident("ParseError"), "(", n, ")")
+	g.P("}")
+}
+
+// genConsumeField generates the cases of the switch parsing the field
+// following a tag of length n in b.
+func (c codecGen) genConsumeField(field *protogen.Field) {
+	g := c.g
+	num := field.Desc.Number()
+	x := "x." + field.GoName
+	switch {
+	case field.Desc.IsMap():
+		genCase(g, num, protowire.BytesType)
+		c.genConsumeMap(field)
+		return
+	case field.Message != nil && field.Desc.IsList():
+		genCase(g, num, wireType(field))
+		c.genConsumeMessage(field, "b[n:]", "v", "m", "e", true)
+		g.P(x, " = append(", x, ", e)")
+	case field.Message != nil && oneofField(field):
+		genCase(g, num, wireType(field))
+		g.P("w, ok := x.", field.Oneof.GoName, ".(*", field.GoIdent, ")")
+		g.P("if !ok || w == nil {")
+		g.P("w = new(", field.GoIdent, ")")
+		g.P("}")
+		c.genConsumeMessage(field, "b[n:]", "v", "m", "w."+field.GoName, false)
+		g.P("x.", field.Oneof.GoName, " = w")
+	case field.Message != nil:
+		genCase(g, num, wireType(field))
+		c.genConsumeMessage(field, "b[n:]", "v", "m", x, false)
+	case field.Desc.IsList():
+		if wireType(field) != protowire.BytesType {
+			// Accept the packed encoding, whether the field is packed or not.
+			genCase(g, num, protowire.BytesType)
+			g.P("v, m := ", protowirePackage.Ident("ConsumeBytes"), "(b[n:])")
+			genCheckLength(g, "m")
+			g.P("for len(v) > 0 {")
+			e := c.genConsume(field, "v", "e", "k")
+			g.P(x, " = append(", x, ", ", e, ")")
+			g.P("v = v[k:]")
+			g.P("}")
+			g.P("n += m")
+		}
+		genCase(g, num, wireType(field))
+		v := c.genConsume(field, "b[n:]", "v", "m")
+		g.P(x, " = append(", x, ", ", c.copied(field, v), ")")
+	case oneofField(field):
+		genCase(g, num, wireType(field))
+		v := c.genConsume(field, "b[n:]", "v", "m")
+		g.P("x.", field.Oneof.GoName, " = &", field.GoIdent, "{", field.GoName, ": ", c.copied(field, v), "}")
+	case pointerField(field):
+		genCase(g, num, wireType(field))
+		v := c.genConsume(field, "b[n:]", "v", "m")
+		g.P("s := ", v)
+		g.P(x, " = &s")
+	case field.Desc.Kind() == protoreflect.BytesKind && !field.Desc.HasPresence():
+		// As in protobuf reflection, an empty value leaves the field nil.
+		genCase(g, num, wireType(field))
+		v := c.genConsume(field, "b[n:]", "v", "m")
+		g.P(x, " = append([]byte(nil), ", v, "...)")
+	default:
+		genCase(g, num, wireType(field))
+		v := c.genConsume(field, "b[n:]", "v", "m")
+		g.P(x, " = ", c.copied(field, v))
+	}
+	g.P("n += m")
+}
+
+// copied returns the expression of a copy of a parsed value not aliasing the
+// input, for bytes values.
+func (c codecGen) copied(field *protogen.Field, v string) string {
+	if field.Desc.Kind() == protoreflect.BytesKind {
+		return "append([]byte{}, " + v + "...)"
+	}
+	return v
+}
+
+// genConsume generates the parsing of a scalar value of a field, or of an
+// element of a repeated field, at the start of the buffer b, declaring the
+// value v and its length n, and returns the expression of the value.
+func (c codecGen) genConsume(field *protogen.Field, b, v, n string) string {
+	g := c.g
+	switch wireType(field) {
+	case protowire.Fixed32Type:
+		g.P(v, ", ", n, " := ", protowirePackage.Ident("ConsumeFixed32"), "(", b, ")")
+	case protowire.Fixed64Type:
+		g.P(v, ", ", n, " := ", protowirePackage.Ident("ConsumeFixed64"), "(", b, ")")
+	case protowire.BytesType:
+		g.P(v, ", ", n, " := ", protowirePackage.Ident("ConsumeBytes"), "(", b, ")")
+	default:
+		g.P(v, ", ", n, " := ", protowirePackage.Ident("ConsumeVarint"), "(", b, ")")
+	}
+	genCheckLength(g, n)
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return g.QualifiedGoIdent(protowirePackage.Ident("DecodeBool")) + "(" + v + ")"
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(field.Enum.GoIdent) + "(" + v + ")"
+	case protoreflect.Int32Kind, protoreflect.Sfixed32Kind:
+		return "int32(" + v + ")"
+	case protoreflect.Uint32Kind:
+		return "uint32(" + v + ")"
+	case protoreflect.Int64Kind, protoreflect.Sfixed64Kind:
+		return "int64(" + v + ")"
+	case protoreflect.Sint32Kind:
+		return "int32(" + g.QualifiedGoIdent(protowirePackage.Ident("DecodeZigZag")) + "(" + v + " & " + g.QualifiedGoIdent(mathPackage.Ident("MaxUint32")) + "))"
+	case protoreflect.Sint64Kind:
+		return g.QualifiedGoIdent(protowirePackage.Ident("DecodeZigZag")) + "(" + v + ")"
+	case protoreflect.FloatKind:
+		return g.QualifiedGoIdent(mathPackage.Ident("Float32frombits")) + "(" + v + ")"
+	case protoreflect.DoubleKind:
+		return g.QualifiedGoIdent(mathPackage.Ident("Float64frombits")) + "(" + v + ")"
+	case protoreflect.StringKind:
+		if field.Desc.Syntax() == protoreflect.Proto3 {
+			g.P("if !", utf8Package.Ident("Valid"), "(", v, ") {")
+			g.P("return ", fastpathPackage.Ident("InvalidUTF8"), "(", strconv.Quote(string(field.Desc.FullName())), ")")
+			g.P("}")
+		}
+		return "string(" + v + ")"
+	}
+	return v
+}
+
+// genConsumeMessage generates the parsing of a message value of a field at
+// the start of the buffer b, declaring its encoding v and length n, merged
+// into the message dst. If declare is set, dst is declared as a new message,
+// and otherwise allocated if nil.
+func (c codecGen) genConsumeMessage(field *protogen.Field, b, v, n, dst string, declare bool) {
+	g := c.g
+	if field.Desc.Kind() == protoreflect.GroupKind {
+		g.P(v, ", ", n, " := ", protowirePackage.Ident("ConsumeGroup"), "(num, ", b, ")")
+	} else {
+		g.P(v, ", ", n, " := ", protowirePackage.Ident("ConsumeBytes"), "(", b, ")")
+	}
+	genCheckLength(g, n)
+	if declare {
+		g.P(dst, " := new(", field.Message.GoIdent, ")")
+	} else {
+		g.P("if ", dst, " == nil {")
+		g.P(dst, " = new(", field.Message.GoIdent, ")")
+		g.P("}")
+	}
+	if c.codecs[field.Message] {
+		g.P("if err := ", dst, ".Unmarshal(", v, "); err != nil {")
+	} else {
+		g.P("if err := ", fastpathPackage.Ident("UnmarshalMessage"), "(", v, ", ", dst, "); err != nil {")
+	}
+	g.P("return err")
+	g.P("}")
+}
+
+// genConsumeMap generates the parsing of an entry of a map field. As in
+// protobuf reflection, missing keys and values are zero, and message values
+// are empty.
+func (c codecGen) genConsumeMap(field *protogen.Field) {
+	g := c.g
+	x := "x." + field.GoName
+	key, val := field.Message.Fields[0], field.Message.Fields[1]
+	g.P("v, m := ", protowirePackage.Ident("ConsumeBytes"), "(b[n:])")
+	genCheckLength(g, "m")
+	g.P("var key ", goType(g, key))
+	g.P("var val ", goType(g, val))
+	g.P("for len(v) > 0 {")
+	g.P("num, typ, k, err := ", fastpathPackage.Ident("ConsumeTag"), "(v)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("switch {")
+	genCase(g, 1, wireType(key))
+	e := c.genConsume(key, "v[k:]", "e", "j")
+	g.P("key = ", e)
+	g.P("k += j")
+	genCase(g, 2, wireType(val))
+	if val.Message != nil {
+		c.genConsumeMessage(val, "v[k:]", "e", "j", "val", false)
+	} else {
+		e := c.genConsume(val, "v[k:]", "e", "j")
+		g.P("val = ", c.copied(val, e))
+	}
+	g.P("k += j")
+	g.P("default:")
+	g.P("j := ", protowirePackage.Ident("ConsumeFieldValue"), "(num, typ, v[k:])")
+	genCheckLength(g, "j")
+	g.P("k += j")
+	g.P("}")
+	g.P("v = v[k:]")
+	g.P("}")
+	if val.Message != nil {
+		g.P("if val == nil {")
+		g.P("val = new(", val.Message.GoIdent, ")")
+		g.P("}")
+	}
+	g.P("if ", x, " == nil {")
+	g.P(x, " = make(map[", goType(g, key), "]", goType(g, val), ")")
+	g.P("}")
+	g.P(x, "[key] = val")
+	g.P("n += m")
+}
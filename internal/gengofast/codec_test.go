@@ -0,0 +1,177 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengofast
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/protoparse"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const codecHeader = `syntax = "proto3";
+
+package test;
+
+import "fastpath/fastpath.proto";
+
+option go_package = "example.com/test";
+`
+
+// codecMessageNames returns the names of the messages of a file importing
+// fastpath.proto whose marshaling methods are generated.
+func codecMessageNames(t *testing.T, src string) ([]string, error) {
+	return codecMessageNamesOf(t, codecHeader+src)
+}
+
+// codecMessageNamesOf returns the names of the messages of a file with the
+// given contents whose marshaling methods are generated.
+func codecMessageNamesOf(t *testing.T, contents string) ([]string, error) {
+	p := &protoparse.Parser{
+		Accessor: func(name string) (io.ReadCloser, error) {
+			if name == "test/test.proto" {
+				return ioutil.NopCloser(strings.NewReader(contents)), nil
+			}
+			return os.Open(filepath.Join("..", "..", name))
+		},
+	}
+	req, err := p.CodeGeneratorRequest("", "test/test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codecs, err := codecMessages(gen.FilesByPath["test/test.proto"])
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for message := range codecs {
+		names = append(names, string(message.Desc.FullName()))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func TestCodecMessages(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []string
+	}{{
+		src: `
+message A {}
+`,
+	}, {
+		src: `
+option (golang.protobuf.fastpath.marshal_messages) = true;
+
+message A {
+  message B {
+    map<string, A> c = 1;
+  }
+}
+message D {
+  option (golang.protobuf.fastpath.marshal) = false;
+}
+message E {
+  int32 size = 1;
+}
+message F {
+  int32 clone = 1;
+}
+`,
+		want: []string{"test.A", "test.A.B"},
+	}, {
+		src: `
+message A {
+  option (golang.protobuf.fastpath.marshal) = true;
+
+  message B {}
+}
+message C {
+  option (golang.protobuf.fastpath.marshal) = false;
+}
+`,
+		want: []string{"test.A"},
+	}}
+	for _, tt := range tests {
+		got, err := codecMessageNames(t, tt.src)
+		if err != nil {
+			t.Errorf("%s: %v", tt.src, err)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s: messages mismatch (-want +got):\n%s", tt.src, diff)
+		}
+	}
+}
+
+func TestCodecMessagesForeignOptions(t *testing.T) {
+	// Options of another schema with the numbers of those of fastpath.proto
+	// are not read in a file not importing it.
+	got, err := codecMessageNamesOf(t, `syntax = "proto2";
+
+package test;
+
+import "google/protobuf/descriptor.proto";
+
+option go_package = "example.com/test";
+option (all) = true;
+
+extend google.protobuf.FileOptions {
+  optional bool all = 64171;
+}
+
+extend google.protobuf.MessageOptions {
+  optional bool one = 64171;
+}
+
+message A {
+  option (one) = true;
+}
+message B {}
+`)
+	if err != nil || got != nil {
+		t.Errorf("messages selected by foreign options = %v, %v; want none", got, err)
+	}
+}
+
+func TestInvalidCodecMessages(t *testing.T) {
+	for _, src := range []string{`
+message A {
+  option (golang.protobuf.fastpath.marshal) = true;
+
+  int32 size = 1;
+}
+`, `
+message A {
+  option (golang.protobuf.fastpath.marshal) = true;
+
+  oneof size {
+    int32 b = 1;
+  }
+}
+`, `
+message A {
+  option (golang.protobuf.fastpath.marshal) = true;
+
+  int32 marshal_to_sized_buffer = 1;
+}
+`} {
+		_, err := codecMessageNames(t, src)
+		if want := "test/test.proto: message test.A cannot have marshaling methods"; err == nil || err.Error() != want {
+			t.Errorf("%s: error = %v, want %q", src, err, want)
+		}
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gengofast contains the generator of the reflection-free methods
+// of messages.
+//
+// For each message, it generates Equal, Clone and MergeFrom methods behaving
+// like proto.Equal, proto.Clone and proto.Merge, which use them through the
+// XXX_Equal, XXX_Clone and XXX_MergeFrom methods.
+//
+// For the messages selected by the options of fastpath/fastpath.proto, it also
+// generates Size, MarshalToSizedBuffer, Marshal and Unmarshal methods, which
+// encode and decode the messages without protobuf reflection and are used by
+// proto.Size, proto.Marshal and proto.Unmarshal.
+package gengofast
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	bytesPackage      = protogen.GoImportPath("bytes")
+	protoPackage      = protogen.GoImportPath("github.com/golang/protobuf/proto")
+	fastpathPackage   = protogen.GoImportPath("github.com/golang/protobuf/fastpath")
+	protoifacePackage = protogen.GoImportPath("google.golang.org/protobuf/runtime/protoiface")
+)
+
+// methodNames are the names of the generated methods, which fields must not use.
+var methodNames = map[string]bool{
+	"Equal":         true,
+	"Clone":         true,
+	"MergeFrom":     true,
+	"XXX_Equal":     true,
+	"XXX_Clone":     true,
+	"XXX_MergeFrom": true,
+}
+
+// GenerateFileContent generates the reflection-free methods of the messages,
+// excluding the package statement.
+func GenerateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+	codecs, err := codecMessages(file)
+	if err != nil {
+		gen.Error(err)
+		return
+	}
+	c := codecGen{g: g, codecs: codecs}
+	for _, message := range messages(file.Messages) {
+		if hasMethods(file, message) {
+			genMessage(g, file, message)
+		}
+		if codecs[message] {
+			c.genMessage(message)
+		}
+	}
+}
+
+// messages returns the messages and the nested messages, in order.
+func messages(ms []*protogen.Message) []*protogen.Message {
+	var all []*protogen.Message
+	for _, m := range ms {
+		all = append(all, m)
+		all = append(all, messages(m.Messages)...)
+	}
+	return all
+}
+
+// hasMethods reports whether the methods of a message are generated with
+// those of the file. Messages with extensions or weak fields are handled by
+// protobuf reflection only, as are messages with fields named like the
+// methods.
+func hasMethods(file *protogen.File, message *protogen.Message) bool {
+	if message.Desc.ParentFile() != file.Desc || message.Desc.IsMapEntry() || message.Desc.ExtensionRanges().Len() > 0 {
+		return false
+	}
+	for _, field := range message.Fields {
+		if field.Desc.IsWeak() || methodNames[field.GoName] {
+			return false
+		}
+	}
+	for _, oneof := range message.Oneofs {
+		if methodNames[oneof.GoName] {
+			return false
+		}
+	}
+	return true
+}
+
+func genMessage(g *protogen.GeneratedFile, file *protogen.File, message *protogen.Message) {
+	name := message.GoIdent.GoName
+	genEqual(g, file, message)
+
+	g.P("// Clone returns a deep copy of x, as by proto.Clone, without the use of")
+	g.P("// protobuf reflection.")
+	g.P("func (x *", name, ") Clone() *", name, " {")
+	g.P("if x == nil {")
+	g.P("return nil")
+	g.P("}")
+	g.P("y := new(", name, ")")
+	g.P("y.MergeFrom(x)")
+	g.P("return y")
+	g.P("}")
+	g.P()
+
+	genMergeFrom(g, file, message)
+
+	g.P("// XXX_Equal is used by proto.Equal.")
+	g.P("func (x *", name, ") XXX_Equal(y ", protoifacePackage.Ident("MessageV1"), ") bool {")
+	g.P("m, ok := y.(*", name, ")")
+	g.P("return ok && x.Equal(m)")
+	g.P("}")
+	g.P()
+	g.P("// XXX_Clone is used by proto.Clone.")
+	g.P("func (x *", name, ") XXX_Clone() ", protoifacePackage.Ident("MessageV1"), " {")
+	g.P("return x.Clone()")
+	g.P("}")
+	g.P()
+	g.P("// XXX_MergeFrom is used by proto.Merge.")
+	g.P("func (x *", name, ") XXX_MergeFrom(src ", protoifacePackage.Ident("MessageV1"), ") {")
+	g.P("if m, ok := src.(*", name, "); ok {")
+	g.P("x.MergeFrom(m)")
+	g.P("return")
+	g.P("}")
+	g.P(fastpathPackage.Ident("MergeReflect"), "(x, src)")
+	g.P("}")
+	g.P()
+}
+
+// oneofField reports whether a field is part of a oneof, excluding the
+// synthetic oneofs of proto3 optional fields.
+func oneofField(field *protogen.Field) bool {
+	return field.Oneof != nil && !field.Oneof.Desc.IsSynthetic()
+}
+
+// pointerField reports whether a field is a pointer to a scalar value,
+// as are the scalar fields with presence, except bytes.
+func pointerField(field *protogen.Field) bool {
+	if !field.Desc.HasPresence() || oneofField(field) || field.Desc.IsList() {
+		return false
+	}
+	switch field.Desc.Kind() {
+	case protoreflect.BytesKind, protoreflect.MessageKind, protoreflect.GroupKind:
+		return false
+	}
+	return true
+}
+
+func isFloat(field *protogen.Field) bool {
+	k := field.Desc.Kind()
+	return k == protoreflect.FloatKind || k == protoreflect.DoubleKind
+}
+
+// float64Of returns the conversion of a value of a float or double field
+// to float64.
+func float64Of(field *protogen.Field, v string) string {
+	if field.Desc.Kind() == protoreflect.FloatKind {
+		return "float64(" + v + ")"
+	}
+	return v
+}
+
+// goType returns the Go type of a value of a field, or of an element of a
+// repeated field.
+func goType(g *protogen.GeneratedFile, field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(field.Enum.GoIdent)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "[]byte"
+	}
+	return "*" + g.QualifiedGoIdent(field.Message.GoIdent)
+}
+
+func genEqual(g *protogen.GeneratedFile, file *protogen.File, message *protogen.Message) {
+	name := message.GoIdent.GoName
+	g.P("// Equal reports whether x and y are equal, as by proto.Equal, without the")
+	g.P("// use of protobuf reflection. A nil message is only equal to nil.")
+	g.P("func (x *", name, ") Equal(y *", name, ") bool {")
+	g.P("if x == nil || y == nil {")
+	g.P("return x == nil && y == nil")
+	g.P("}")
+	for _, field := range message.Fields {
+		if oneofField(field) {
+			if field == field.Oneof.Fields[0] {
+				genEqualOneof(g, field.Oneof)
+			}
+			continue
+		}
+		genEqualField(g, file, field)
+	}
+	g.P("return ", fastpathPackage.Ident("EqualUnknown"), "(x.unknownFields, y.unknownFields)")
+	g.P("}")
+	g.P()
+}
+
+func genEqualField(g *protogen.GeneratedFile, file *protogen.File, field *protogen.Field) {
+	x, y := "x."+field.GoName, "y."+field.GoName
+	switch {
+	case field.Desc.IsMap():
+		g.P("if len(", x, ") != len(", y, ") {")
+		g.P("return false")
+		g.P("}")
+		g.P("for k, vx := range ", x, " {")
+		g.P("if vy, ok := ", y, "[k]; !ok || ", notEqualValue(g, field.Message.Fields[1], "vx", "vy"), " {")
+		g.P("return false")
+		g.P("}")
+		g.P("}")
+		return
+	case field.Desc.IsList():
+		g.P("if len(", x, ") != len(", y, ") {")
+		g.P("return false")
+		g.P("}")
+		g.P("for i, vx := range ", x, " {")
+		g.P("if ", notEqualValue(g, field, "vx", y+"[i]"), " {")
+		g.P("return false")
+		g.P("}")
+		g.P("}")
+		return
+	}
+	var cond string
+	switch {
+	case field.Message != nil:
+		cond = "(" + x + " == nil) != (" + y + " == nil) || " + x + " != nil && " + notEqualMessage(g, file, field.Message, x, y)
+	case field.Desc.Kind() == protoreflect.BytesKind && field.Desc.HasPresence():
+		cond = "(" + x + " == nil) != (" + y + " == nil) || " + notEqualValue(g, field, x, y)
+	case pointerField(field):
+		cond = "(" + x + " == nil) != (" + y + " == nil) || " + x + " != nil && " + notEqualValue(g, field, "*"+x, "*"+y)
+	case isFloat(field):
+		cond = "!" + g.QualifiedGoIdent(fastpathPackage.Ident("EqualImplicitFloat64")) + "(" + float64Of(field, x) + ", " + float64Of(field, y) + ")"
+	default:
+		cond = notEqualValue(g, field, x, y)
+	}
+	g.P("if ", cond, " {")
+	g.P("return false")
+	g.P("}")
+}
+
+// notEqualMessage returns the condition of two non-nil messages being unequal.
+func notEqualMessage(g *protogen.GeneratedFile, file *protogen.File, message *protogen.Message, x, y string) string {
+	if hasMethods(file, message) {
+		return "!" + x + ".Equal(" + y + ")"
+	}
+	return "!" + g.QualifiedGoIdent(protoPackage.Ident("Equal")) + "(" + x + ", " + y + ")"
+}
+
+// notEqualValue returns the condition of two values of a field being unequal,
+// for values without presence: the elements of repeated fields, the values
+// of maps and oneofs, and the scalar fields of proto3.
+func notEqualValue(g *protogen.GeneratedFile, field *protogen.Field, x, y string) string {
+	switch {
+	case field.Message != nil:
+		return "!" + g.QualifiedGoIdent(fastpathPackage.Ident("EqualMessage")) + "(" + x + ", " + y + ")"
+	case field.Desc.Kind() == protoreflect.BytesKind:
+		return "!" + g.QualifiedGoIdent(bytesPackage.Ident("Equal")) + "(" + x + ", " + y + ")"
+	case isFloat(field):
+		return "!" + g.QualifiedGoIdent(fastpathPackage.Ident("EqualFloat64")) + "(" + float64Of(field, x) + ", " + float64Of(field, y) + ")"
+	}
+	return x + " != " + y
+}
+
+func genEqualOneof(g *protogen.GeneratedFile, oneof *protogen.Oneof) {
+	x, y := "x."+oneof.GoName, "y."+oneof.GoName
+	g.P("switch vx := ", x, ".(type) {")
+	g.P("case nil:")
+	g.P("if ", y, " != nil {")
+	g.P("return false")
+	g.P("}")
+	for _, field := range oneof.Fields {
+		g.P("case *", field.GoIdent, ":")
+		g.P("if vy, ok := ", y, ".(*", field.GoIdent, "); !ok || ", notEqualValue(g, field, "vx."+field.GoName, "vy."+field.GoName), " {")
+		g.P("return false")
+		g.P("}")
+	}
+	g.P("}")
+}
+
+func genMergeFrom(g *protogen.GeneratedFile, file *protogen.File, message *protogen.Message) {
+	name := message.GoIdent.GoName
+	g.P("// MergeFrom merges src into x, as by proto.Merge, without the use of")
+	g.P("// protobuf reflection.")
+	g.P("func (x *", name, ") MergeFrom(src *", name, ") {")
+	g.P("if src == nil {")
+	g.P("return")
+	g.P("}")
+	for _, field := range message.Fields {
+		if oneofField(field) {
+			if field == field.Oneof.Fields[0] {
+				genMergeOneof(g, file, field.Oneof)
+			}
+			continue
+		}
+		genMergeField(g, file, field)
+	}
+	g.P("if len(src.unknownFields) > 0 {")
+	g.P("x.unknownFields = append(x.unknownFields, src.unknownFields...)")
+	g.P("}")
+	g.P("}")
+	g.P()
+}
+
+func genMergeField(g *protogen.GeneratedFile, file *protogen.File, field *protogen.Field) {
+	x, src := "x."+field.GoName, "src."+field.GoName
+	switch {
+	case field.Desc.IsMap():
+		key, val := field.Message.Fields[0], field.Message.Fields[1]
+		g.P("if len(", src, ") > 0 {")
+		g.P("if ", x, " == nil {")
+		g.P(x, " = make(map[", goType(g, key), "]", goType(g, val), ", len(", src, "))")
+		g.P("}")
+		g.P("for k, v := range ", src, " {")
+		g.P(x, "[k] = ", genCopy(g, file, val, "v"))
+		g.P("}")
+		g.P("}")
+	case field.Desc.IsList() && (field.Message != nil || field.Desc.Kind() == protoreflect.BytesKind):
+		g.P("for _, v := range ", src, " {")
+		g.P(x, " = append(", x, ", ", genCopy(g, file, field, "v"), ")")
+		g.P("}")
+	case field.Desc.IsList():
+		g.P(x, " = append(", x, ", ", src, "...)")
+	case field.Message != nil:
+		g.P("if ", src, " != nil {")
+		g.P("if ", x, " == nil {")
+		g.P(x, " = new(", field.Message.GoIdent, ")")
+		g.P("}")
+		genMergeMessage(g, file, field.Message, x, src)
+		g.P("}")
+	case field.Desc.Kind() == protoreflect.BytesKind && field.Desc.HasPresence():
+		g.P("if ", src, " != nil {")
+		g.P(x, " = append([]byte{}, ", src, "...)")
+		g.P("}")
+	case field.Desc.Kind() == protoreflect.BytesKind:
+		g.P("if len(", src, ") > 0 {")
+		g.P(x, " = append([]byte{}, ", src, "...)")
+		g.P("}")
+	case pointerField(field):
+		g.P("if ", src, " != nil {")
+		g.P("v := *", src)
+		g.P(x, " = &v")
+		g.P("}")
+	default:
+		g.P("if ", populated(field, src), " {")
+		g.P(x, " = ", src)
+		g.P("}")
+	}
+}
+
+// populated returns the condition of a scalar value without presence being
+// merged by proto.Merge, which unlike protobuf reflection does not merge
+// negative zero float values.
+func populated(field *protogen.Field, v string) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return v
+	case protoreflect.StringKind:
+		return v + ` != ""`
+	}
+	return v + " != 0"
+}
+
+// genCopy generates the copy of a value of a field, an element of a repeated
+// field or a value of a map or oneof, not aliasing any memory of the value,
+// and returns the expression of the copy. As in protobuf reflection, the copy
+// of a nil message is an empty message.
+func genCopy(g *protogen.GeneratedFile, file *protogen.File, field *protogen.Field, v string) string {
+	switch {
+	case field.Message != nil:
+		g.P("m := new(", field.Message.GoIdent, ")")
+		genMergeMessage(g, file, field.Message, "m", v)
+		return "m"
+	case field.Desc.Kind() == protoreflect.BytesKind:
+		return "append([]byte{}, " + v + "...)"
+	}
+	return v
+}
+
+// genMergeMessage generates the merge of the message src into dst.
+func genMergeMessage(g *protogen.GeneratedFile, file *protogen.File, message *protogen.Message, dst, src string) {
+	if hasMethods(file, message) {
+		g.P(dst, ".MergeFrom(", src, ")")
+		return
+	}
+	g.P(protoPackage.Ident("Merge"), "(", dst, ", ", src, ")")
+}
+
+func genMergeOneof(g *protogen.GeneratedFile, file *protogen.File, oneof *protogen.Oneof) {
+	x, src := "x."+oneof.GoName, "src."+oneof.GoName
+	g.P("switch v := ", src, ".(type) {")
+	for _, field := range oneof.Fields {
+		g.P("case *", field.GoIdent, ":")
+		if field.Message != nil {
+			// Merge into the message already set in x, if any.
+			g.P("if xv, ok := ", x, ".(*", field.GoIdent, "); ok && xv.", field.GoName, " != nil {")
+			genMergeMessage(g, file, field.Message, "xv."+field.GoName, "v."+field.GoName)
+			g.P("} else {")
+			g.P(x, " = &", field.GoIdent, "{", field.GoName, ": ", genCopy(g, file, field, "v."+field.GoName), "}")
+			g.P("}")
+			continue
+		}
+		g.P(x, " = &", field.GoIdent, "{", field.GoName, ": ", genCopy(g, file, field, "v."+field.GoName), "}")
+	}
+	g.P("}")
+}
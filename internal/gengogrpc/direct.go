@@ -0,0 +1,327 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengogrpc
+
+import (
+	"github.com/golang/protobuf/internal/gengoservice"
+	"google.golang.org/protobuf/compiler/protogen"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	ioPackage       = protogen.GoImportPath("io")
+	syncPackage     = protogen.GoImportPath("sync")
+	metadataPackage = protogen.GoImportPath("google.golang.org/grpc/metadata")
+	protoPackage    = protogen.GoImportPath("google.golang.org/protobuf/proto")
+)
+
+// directStreamBuffer is the number of messages buffered in each direction
+// of an in-process stream, standing in for the flow control window of a
+// network stream.
+const directStreamBuffer = 16
+
+// genDirectClient generates NewXxxClientFromServer, which returns a client
+// calling a server implementation in-process.
+//
+// The client uses a grpc.ClientConnInterface dispatching calls to the
+// handlers of the service descriptor, so calls go through the same
+// decoding and interceptors as calls received by a grpc.Server.
+func genDirectClient(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, service *protogen.Service) {
+	clientName := service.GoName + "Client"
+	serverType := service.GoName + "Server"
+	serviceDescVar := "_" + service.GoName + "_serviceDesc"
+	connType := gengoservice.Unexport(service.GoName) + "DirectConn"
+	streamType := gengoservice.Unexport(service.GoName) + "DirectStream"
+	errorFunc := contextError(service)
+
+	// Constructor.
+	g.P("// New", clientName, "FromServer returns a ", clientName, " calling srv in-process,")
+	g.P("// without a network connection.")
+	g.P("//")
+	g.P("// Requests and responses are copied, as they would be by a network call, and the")
+	g.P("// outgoing metadata of the client context is the incoming metadata of the server")
+	g.P("// context. The unary and stream interceptors are chained in order, as by a")
+	g.P("// server.")
+	if service.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated() {
+		g.P("//")
+		g.P(gengoservice.DeprecationComment)
+	}
+	g.P("func New", clientName, "FromServer(srv ", serverType, ", unary []", grpcPackage.Ident("UnaryServerInterceptor"), ", stream []", grpcPackage.Ident("StreamServerInterceptor"), ") ", clientName, " {")
+	g.P("return New", clientName, "(&", connType, "{srv: srv, unary: unary, stream: stream})")
+	g.P("}")
+	g.P()
+
+	// Connection.
+	g.P("// ", connType, " is a ", grpcPackage.Ident("ClientConnInterface"), " calling a ", serverType, " in-process.")
+	g.P("type ", connType, " struct {")
+	g.P("srv ", serverType)
+	g.P("unary []", grpcPackage.Ident("UnaryServerInterceptor"))
+	g.P("stream []", grpcPackage.Ident("StreamServerInterceptor"))
+	g.P("}")
+	g.P()
+
+	g.P("// serverContext returns the context of a handler called with the client context ctx.")
+	g.P("func (c *", connType, ") serverContext(ctx ", contextPackage.Ident("Context"), ") ", contextPackage.Ident("Context"), " {")
+	g.P("md, _ := ", metadataPackage.Ident("FromOutgoingContext"), "(ctx)")
+	g.P("ctx = ", metadataPackage.Ident("NewIncomingContext"), "(ctx, md.Copy())")
+	g.P("return ", metadataPackage.Ident("NewOutgoingContext"), "(ctx, nil)")
+	g.P("}")
+	g.P()
+
+	g.P("func (c *", connType, ") Invoke(ctx ", contextPackage.Ident("Context"), ", method string, args, reply interface{}, opts ...", grpcPackage.Ident("CallOption"), ") error {")
+	g.P("var handler func(interface{}, ", contextPackage.Ident("Context"), ", func(interface{}) error, ", grpcPackage.Ident("UnaryServerInterceptor"), ") (interface{}, error)")
+	g.P("for _, m := range ", serviceDescVar, ".Methods {")
+	g.P(`if method == "/"+`, serviceDescVar, `.ServiceName+"/"+m.MethodName {`)
+	g.P("handler = m.Handler")
+	g.P("}")
+	g.P("}")
+	g.P("if handler == nil {")
+	g.P("return ", statusPackage.Ident("Errorf"), "(", codesPackage.Ident("Unimplemented"), `, "unknown method %v", method)`)
+	g.P("}")
+	g.P("var interceptor ", grpcPackage.Ident("UnaryServerInterceptor"))
+	g.P("if len(c.unary) > 0 {")
+	g.P("interceptor = c.interceptUnary")
+	g.P("}")
+	g.P("sctx, cancel := ", contextPackage.Ident("WithCancel"), "(c.serverContext(ctx))")
+	g.P("defer cancel()")
+	g.P("type result struct {")
+	g.P("out interface{}")
+	g.P("err error")
+	g.P("}")
+	g.P("done := make(chan result, 1)")
+	g.P("go func() {")
+	g.P("dec := func(in interface{}) error {")
+	g.P(protoPackage.Ident("Merge"), "(in.(", protoPackage.Ident("Message"), "), args.(", protoPackage.Ident("Message"), "))")
+	g.P("return nil")
+	g.P("}")
+	g.P("out, err := handler(c.srv, sctx, dec, interceptor)")
+	g.P("done <- result{out, err}")
+	g.P("}()")
+	g.P("select {")
+	g.P("case r := <-done:")
+	g.P("if r.err != nil {")
+	g.P("return ", statusPackage.Ident("Convert"), "(r.err).Err()")
+	g.P("}")
+	g.P("out, ok := r.out.(", protoPackage.Ident("Message"), ")")
+	g.P("if !ok {")
+	g.P("return ", statusPackage.Ident("Errorf"), "(", codesPackage.Ident("Internal"), `, "no response returned for %v", method)`)
+	g.P("}")
+	g.P(protoPackage.Ident("Reset"), "(reply.(", protoPackage.Ident("Message"), "))")
+	g.P(protoPackage.Ident("Merge"), "(reply.(", protoPackage.Ident("Message"), "), out)")
+	g.P("return nil")
+	g.P("case <-ctx.Done():")
+	g.P("return ", errorFunc, "(ctx)")
+	g.P("}")
+	g.P("}")
+	g.P()
+
+	g.P("func (c *", connType, ") interceptUnary(ctx ", contextPackage.Ident("Context"), ", req interface{}, info *", grpcPackage.Ident("UnaryServerInfo"), ", handler ", grpcPackage.Ident("UnaryHandler"), ") (interface{}, error) {")
+	g.P("for i := len(c.unary) - 1; i >= 0; i-- {")
+	g.P("interceptor, next := c.unary[i], handler")
+	g.P("handler = func(ctx ", contextPackage.Ident("Context"), ", req interface{}) (interface{}, error) {")
+	g.P("return interceptor(ctx, req, info, next)")
+	g.P("}")
+	g.P("}")
+	g.P("return handler(ctx, req)")
+	g.P("}")
+	g.P()
+
+	g.P("func (c *", connType, ") NewStream(ctx ", contextPackage.Ident("Context"), ", desc *", grpcPackage.Ident("StreamDesc"), ", method string, opts ...", grpcPackage.Ident("CallOption"), ") (", grpcPackage.Ident("ClientStream"), ", error) {")
+	g.P("sctx, cancel := ", contextPackage.Ident("WithCancel"), "(c.serverContext(ctx))")
+	g.P("s := &", streamType, "{")
+	g.P("clientCtx: ctx,")
+	g.P("serverCtx: sctx,")
+	g.P("requests: make(chan ", protoPackage.Ident("Message"), ", ", directStreamBuffer, "),")
+	g.P("responses: make(chan ", protoPackage.Ident("Message"), ", ", directStreamBuffer, "),")
+	g.P("closeSend: make(chan struct{}),")
+	g.P("headerSent: make(chan struct{}),")
+	g.P("done: make(chan struct{}),")
+	g.P("}")
+	g.P("handler := desc.Handler")
+	g.P("info := &", grpcPackage.Ident("StreamServerInfo"), "{")
+	g.P("FullMethod: method,")
+	g.P("IsClientStream: desc.ClientStreams,")
+	g.P("IsServerStream: desc.ServerStreams,")
+	g.P("}")
+	g.P("for i := len(c.stream) - 1; i >= 0; i-- {")
+	g.P("interceptor, next := c.stream[i], handler")
+	g.P("handler = func(srv interface{}, stream ", grpcPackage.Ident("ServerStream"), ") error {")
+	g.P("return interceptor(srv, stream, info, next)")
+	g.P("}")
+	g.P("}")
+	g.P("go func() {")
+	g.P("defer cancel()")
+	g.P("s.err = ", statusPackage.Ident("Convert"), "(handler(c.srv, (*", streamType, "Server)(s))).Err()")
+	g.P("s.sendHeader()")
+	g.P("close(s.done)")
+	g.P("}()")
+	g.P("return (*", streamType, "Client)(s), nil")
+	g.P("}")
+	g.P()
+
+	// Stream.
+	g.P("// ", streamType, " is an in-process stream between a client and a handler")
+	g.P("// running in its own goroutine.")
+	g.P("type ", streamType, " struct {")
+	g.P("clientCtx, serverCtx ", contextPackage.Ident("Context"))
+	g.P("requests, responses chan ", protoPackage.Ident("Message"))
+	g.P("closeSend chan struct{} // closed by CloseSend")
+	g.P("closeSendOnce ", syncPackage.Ident("Once"))
+	g.P("headerSent chan struct{} // closed once the header is sent")
+	g.P("done chan struct{} // closed once the handler returned")
+	g.P("err error // the status returned by the handler, set before done is closed")
+	g.P()
+	g.P("mu ", syncPackage.Ident("Mutex"))
+	g.P("header, trailer ", metadataPackage.Ident("MD"))
+	g.P("}")
+	g.P()
+
+	g.P("func (s *", streamType, ") sendHeader() {")
+	g.P("s.mu.Lock()")
+	g.P("defer s.mu.Unlock()")
+	g.P("select {")
+	g.P("case <-s.headerSent:")
+	g.P("default:")
+	g.P("close(s.headerSent)")
+	g.P("}")
+	g.P("}")
+	g.P()
+
+	// Client end.
+	clientStream := streamType + "Client"
+	g.P("// ", clientStream, " is the client end of a ", streamType, ".")
+	g.P("type ", clientStream, " ", streamType)
+	g.P()
+	g.P("func (x *", clientStream, ") Header() (", metadataPackage.Ident("MD"), ", error) {")
+	g.P("select {")
+	g.P("case <-x.headerSent:")
+	g.P("case <-x.clientCtx.Done():")
+	g.P("return nil, ", errorFunc, "(x.clientCtx)")
+	g.P("}")
+	g.P("x.mu.Lock()")
+	g.P("defer x.mu.Unlock()")
+	g.P("return x.header.Copy(), nil")
+	g.P("}")
+	g.P()
+	g.P("func (x *", clientStream, ") Trailer() ", metadataPackage.Ident("MD"), " {")
+	g.P("x.mu.Lock()")
+	g.P("defer x.mu.Unlock()")
+	g.P("return x.trailer.Copy()")
+	g.P("}")
+	g.P()
+	g.P("func (x *", clientStream, ") CloseSend() error {")
+	g.P("x.closeSendOnce.Do(func() { close(x.closeSend) })")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+	g.P("func (x *", clientStream, ") Context() ", contextPackage.Ident("Context"), " {")
+	g.P("return x.clientCtx")
+	g.P("}")
+	g.P()
+	g.P("func (x *", clientStream, ") SendMsg(m interface{}) error {")
+	g.P("select {")
+	g.P("case <-x.closeSend:")
+	g.P("return ", statusPackage.Ident("Error"), "(", codesPackage.Ident("Internal"), `, "SendMsg called after CloseSend")`)
+	g.P("default:")
+	g.P("}")
+	g.P("select {")
+	g.P("case x.requests <- ", protoPackage.Ident("Clone"), "(m.(", protoPackage.Ident("Message"), ")):")
+	g.P("return nil")
+	g.P("case <-x.done:")
+	g.P("return ", ioPackage.Ident("EOF"))
+	g.P("case <-x.clientCtx.Done():")
+	g.P("return ", errorFunc, "(x.clientCtx)")
+	g.P("}")
+	g.P("}")
+	g.P()
+	g.P("func (x *", clientStream, ") RecvMsg(m interface{}) error {")
+	g.P("var resp ", protoPackage.Ident("Message"))
+	g.P("select {")
+	g.P("case resp = <-x.responses:")
+	g.P("case <-x.done:")
+	g.P("// Deliver the responses sent before the handler returned.")
+	g.P("select {")
+	g.P("case resp = <-x.responses:")
+	g.P("default:")
+	g.P("if x.err != nil {")
+	g.P("return x.err")
+	g.P("}")
+	g.P("return ", ioPackage.Ident("EOF"))
+	g.P("}")
+	g.P("case <-x.clientCtx.Done():")
+	g.P("return ", errorFunc, "(x.clientCtx)")
+	g.P("}")
+	g.P(protoPackage.Ident("Reset"), "(m.(", protoPackage.Ident("Message"), "))")
+	g.P(protoPackage.Ident("Merge"), "(m.(", protoPackage.Ident("Message"), "), resp)")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+
+	// Server end.
+	serverStream := streamType + "Server"
+	g.P("// ", serverStream, " is the server end of a ", streamType, ".")
+	g.P("type ", serverStream, " ", streamType)
+	g.P()
+	g.P("func (x *", serverStream, ") SetHeader(md ", metadataPackage.Ident("MD"), ") error {")
+	g.P("x.mu.Lock()")
+	g.P("defer x.mu.Unlock()")
+	g.P("select {")
+	g.P("case <-x.headerSent:")
+	g.P("return ", statusPackage.Ident("Error"), "(", codesPackage.Ident("Internal"), `, "SetHeader called after the header was sent")`)
+	g.P("default:")
+	g.P("}")
+	g.P("x.header = ", metadataPackage.Ident("Join"), "(x.header, md)")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+	g.P("func (x *", serverStream, ") SendHeader(md ", metadataPackage.Ident("MD"), ") error {")
+	g.P("if err := x.SetHeader(md); err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("(*", streamType, ")(x).sendHeader()")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+	g.P("func (x *", serverStream, ") SetTrailer(md ", metadataPackage.Ident("MD"), ") {")
+	g.P("x.mu.Lock()")
+	g.P("defer x.mu.Unlock()")
+	g.P("x.trailer = ", metadataPackage.Ident("Join"), "(x.trailer, md)")
+	g.P("}")
+	g.P()
+	g.P("func (x *", serverStream, ") Context() ", contextPackage.Ident("Context"), " {")
+	g.P("return x.serverCtx")
+	g.P("}")
+	g.P()
+	g.P("func (x *", serverStream, ") SendMsg(m interface{}) error {")
+	g.P("(*", streamType, ")(x).sendHeader()")
+	g.P("select {")
+	g.P("case x.responses <- ", protoPackage.Ident("Clone"), "(m.(", protoPackage.Ident("Message"), ")):")
+	g.P("return nil")
+	g.P("case <-x.serverCtx.Done():")
+	g.P("return ", errorFunc, "(x.serverCtx)")
+	g.P("}")
+	g.P("}")
+	g.P()
+	g.P("func (x *", serverStream, ") RecvMsg(m interface{}) error {")
+	g.P("var req ", protoPackage.Ident("Message"))
+	g.P("select {")
+	g.P("case req = <-x.requests:")
+	g.P("case <-x.closeSend:")
+	g.P("// Deliver the requests sent before CloseSend.")
+	g.P("select {")
+	g.P("case req = <-x.requests:")
+	g.P("default:")
+	g.P("return ", ioPackage.Ident("EOF"))
+	g.P("}")
+	g.P("case <-x.serverCtx.Done():")
+	g.P("return ", errorFunc, "(x.serverCtx)")
+	g.P("}")
+	g.P(protoPackage.Ident("Reset"), "(m.(", protoPackage.Ident("Message"), "))")
+	g.P(protoPackage.Ident("Merge"), "(m.(", protoPackage.Ident("Message"), "), req)")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengogrpc
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/protoparse"
+	"google.golang.org/protobuf/compiler/protogen"
+
+	gengo "google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+)
+
+// endToEndOptions are the options of the code generated for the end-to-end
+// test, enabling everything it tests.
+var endToEndOptions = Options{
+	DirectClients: true,
+	StreamHelpers: true,
+	MethodTables:  true,
+}
+
+// endToEndGoMod is the go.mod of the module of the end-to-end test, given
+// the directories of this module and of the stand-in grpc module.
+const endToEndGoMod = `module example.com/echo
+
+require (
+	github.com/golang/protobuf v1.5.0
+	google.golang.org/grpc v1.0.0
+	google.golang.org/protobuf v1.26.0
+)
+
+replace github.com/golang/protobuf => %s
+
+replace google.golang.org/grpc => %s
+`
+
+// generateEcho returns the Go files generated for testdata/echo/echo.proto,
+// by base name.
+func generateEcho(t *testing.T) (map[string]string, error) {
+	p := &protoparse.Parser{ImportPaths: []string{filepath.Join("testdata", "echo")}}
+	req, err := p.CodeGeneratorRequest("", "echo.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		g := gengo.GenerateFile(gen, f)
+		endToEndOptions.GenerateFileContent(gen, f, g)
+		GenerateFakeFile(gen, f)
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		return nil, errors.New(resp.GetError())
+	}
+	files := make(map[string]string)
+	for _, f := range resp.File {
+		files[path.Base(f.GetName())] = f.GetContent()
+	}
+	return files, nil
+}
+
+// TestEndToEnd runs the tests of testdata/echo on the code generated for
+// echo.proto, in a module of their own using the stand-in of the grpc
+// module in testdata/grpc.
+func TestEndToEnd(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping end-to-end test in short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	files, err := generateEcho(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files["go.mod"] = fmt.Sprintf(endToEndGoMod, root, filepath.Join(root, "internal", "gengogrpc", "testdata", "grpc"))
+	sum, err := ioutil.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files["go.sum"] = string(sum)
+	tests, err := filepath.Glob(filepath.Join("testdata", "echo", "*_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range tests {
+		b, err := ioutil.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[filepath.Base(name)] = string(b)
+	}
+
+	dir, err := ioutil.TempDir("", "gengogrpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command(goTool, "test", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test: %v\n%s", err, strings.TrimSpace(string(out)))
+	}
+}
@@ -0,0 +1,503 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengogrpc
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/internal/gengoservice"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// protoV1Package is the proto package used by the fakes, whose functions
+// also accept the messages generated by protoc-gen-go/grpc.
+const protoV1Package = protogen.GoImportPath("github.com/golang/protobuf/proto")
+
+// GenerateFakeFile generates a _grpc_fake.pb.go file containing recording
+// fakes of the client and server interfaces of each service, for tests.
+//
+// The fakes use the stream types of the gRPC service definitions,
+// which must be generated in the same package.
+func GenerateFakeFile(gen *protogen.Plugin, file *protogen.File) *protogen.GeneratedFile {
+	if len(file.Services) == 0 {
+		return nil
+	}
+	filename := file.GeneratedFilenamePrefix + "_grpc_fake.pb.go"
+	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	g.P("// Code generated by protoc-gen-go. DO NOT EDIT.")
+	g.P()
+	g.P("package ", file.GoPackageName)
+	g.P()
+	for _, service := range file.Services {
+		genFake(gen, file, g, service)
+	}
+	return g
+}
+
+func genFake(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, service *protogen.Service) {
+	recorderType := "Fake" + service.GoName + "Recorder"
+	callType := "Fake" + service.GoName + "Call"
+	resultType := "fake" + service.GoName + "Result"
+	clientStreamType := "fake" + service.GoName + "ClientStream"
+	serverStreamType := "Fake" + service.GoName + "ServerStream"
+	protoMessage := g.QualifiedGoIdent(protoV1Package.Ident("Message"))
+
+	// Recorder.
+	g.P("// ", callType, " is a call recorded by a Fake", service.GoName, "Client or a Fake", service.GoName, "Server.")
+	g.P("type ", callType, " struct {")
+	g.P("Method string // the name of the method in the .proto file")
+	g.P("Requests []", protoMessage, " // copies of the requests of the call, in order")
+	g.P("}")
+	g.P()
+	g.P("type ", resultType, " struct {")
+	g.P("responses []", protoMessage)
+	g.P("err error")
+	g.P("}")
+	g.P()
+	g.P("// ", recorderType, " records the calls to a fake ", service.GoName, " service and holds")
+	g.P("// the results scripted for its methods.")
+	g.P("type ", recorderType, " struct {")
+	g.P("mu ", syncPackage.Ident("Mutex"))
+	g.P("calls []*", callType)
+	g.P("results map[string][]", resultType)
+	g.P("}")
+	g.P()
+	g.P("// Calls returns the calls recorded so far, in order.")
+	g.P("func (r *", recorderType, ") Calls() []", callType, " {")
+	g.P("r.mu.Lock()")
+	g.P("defer r.mu.Unlock()")
+	g.P("calls := make([]", callType, ", len(r.calls))")
+	g.P("for i, c := range r.calls {")
+	g.P("calls[i] = ", callType, "{c.Method, append([]", protoMessage, "(nil), c.Requests...)}")
+	g.P("}")
+	g.P("return calls")
+	g.P("}")
+	g.P()
+	g.P("// Requests returns the requests of the calls to a method recorded so far, in order.")
+	g.P("func (r *", recorderType, ") Requests(method string) []", protoMessage, " {")
+	g.P("r.mu.Lock()")
+	g.P("defer r.mu.Unlock()")
+	g.P("var reqs []", protoMessage)
+	g.P("for _, c := range r.calls {")
+	g.P("if c.Method == method {")
+	g.P("reqs = append(reqs, c.Requests...)")
+	g.P("}")
+	g.P("}")
+	g.P("return reqs")
+	g.P("}")
+	g.P()
+	g.P("// AssertRequests reports an error to t unless the requests of the calls to a")
+	g.P("// method recorded so far are equal to want, as compared by proto.Equal.")
+	g.P("func (r *", recorderType, ") AssertRequests(t interface {")
+	g.P("Helper()")
+	g.P("Errorf(format string, args ...interface{})")
+	g.P("}, method string, want ...", protoMessage, ") bool {")
+	g.P("t.Helper()")
+	g.P("got := r.Requests(method)")
+	g.P("if len(got) != len(want) {")
+	g.P(`t.Errorf("`, service.GoName, `.%s: got %d requests, want %d", method, len(got), len(want))`)
+	g.P("return false")
+	g.P("}")
+	g.P("ok := true")
+	g.P("for i := range got {")
+	g.P("if !", protoV1Package.Ident("Equal"), "(got[i], want[i]) {")
+	g.P(`t.Errorf("`, service.GoName, `.%s: request %d is {%v}, want {%v}", method, i, got[i], want[i])`)
+	g.P("ok = false")
+	g.P("}")
+	g.P("}")
+	g.P("return ok")
+	g.P("}")
+	g.P()
+	g.P("func (r *", recorderType, ") record(method string, reqs ...", protoMessage, ") *", callType, " {")
+	g.P("c := &", callType, "{Method: method}")
+	g.P("r.mu.Lock()")
+	g.P("r.calls = append(r.calls, c)")
+	g.P("r.mu.Unlock()")
+	g.P("for _, req := range reqs {")
+	g.P("r.addRequest(c, req)")
+	g.P("}")
+	g.P("return c")
+	g.P("}")
+	g.P()
+	g.P("func (r *", recorderType, ") addRequest(c *", callType, ", req ", protoMessage, ") {")
+	g.P("req = ", protoV1Package.Ident("Clone"), "(req)")
+	g.P("r.mu.Lock()")
+	g.P("c.Requests = append(c.Requests, req)")
+	g.P("r.mu.Unlock()")
+	g.P("}")
+	g.P()
+	g.P("func (r *", recorderType, ") script(method string, responses []", protoMessage, ", err error) {")
+	g.P("r.mu.Lock()")
+	g.P("defer r.mu.Unlock()")
+	g.P("if r.results == nil {")
+	g.P("r.results = make(map[string][]", resultType, ")")
+	g.P("}")
+	g.P("res := ", resultType, "{err: err}")
+	g.P("for _, m := range responses {")
+	g.P("res.responses = append(res.responses, ", protoV1Package.Ident("Clone"), "(m))")
+	g.P("}")
+	g.P("r.results[method] = append(r.results[method], res)")
+	g.P("}")
+	g.P()
+	g.P("// result returns the next result scripted for a method.")
+	g.P("// The last result is repeated once the others are used.")
+	g.P("func (r *", recorderType, ") result(method string) ", resultType, " {")
+	g.P("r.mu.Lock()")
+	g.P("defer r.mu.Unlock()")
+	g.P("results := r.results[method]")
+	g.P("if len(results) == 0 {")
+	g.P("return ", resultType, "{err: ", statusPackage.Ident("Errorf"), "(", codesPackage.Ident("Unimplemented"), `, "no result scripted for method `, service.GoName, `.%s", method)}`)
+	g.P("}")
+	g.P("if len(results) > 1 {")
+	g.P("r.results[method] = results[1:]")
+	g.P("}")
+	g.P("res := ", resultType, "{err: results[0].err}")
+	g.P("for _, m := range results[0].responses {")
+	g.P("res.responses = append(res.responses, ", protoV1Package.Ident("Clone"), "(m))")
+	g.P("}")
+	g.P("return res")
+	g.P("}")
+	g.P()
+
+	// Client.
+	clientName := "Fake" + service.GoName + "Client"
+	g.P("// ", clientName, " is a fake ", service.GoName, "Client for tests, recording the calls made.")
+	g.P("//")
+	g.P("// A call to a method is handled by the Func field of the method if set,")
+	g.P("// and otherwise by the results scripted with the Return method of the method,")
+	g.P("// in order. The error of a streaming call is returned once its responses are")
+	g.P("// received. The calls handled by a Func are recorded as well, with their")
+	g.P("// request if the method takes one, but not the requests sent on the streams")
+	g.P("// the Func returns.")
+	g.P("type ", clientName, " struct {")
+	g.P(recorderType)
+	g.P()
+	for _, method := range service.Methods {
+		g.P(method.GoName, "Func func", transport.ClientSignature(g, method)[len(method.GoName):])
+	}
+	g.P("}")
+	g.P()
+	g.P("var _ ", service.GoName, "Client = (*", clientName, ")(nil)")
+	g.P()
+	for _, method := range service.Methods {
+		genFakeReturn(g, method, clientName)
+		name := strconv.Quote(string(method.Desc.Name()))
+		g.P("func (f *", clientName, ") ", transport.ClientSignature(g, method), " {")
+		streamType := gengoservice.Unexport(service.GoName) + method.GoName + "Client"
+		switch {
+		case !method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer():
+			g.P("f.record(", name, ", in)")
+			g.P("if f.", method.GoName, "Func != nil {")
+			g.P("return f.", method.GoName, "Func(ctx, in, opts...)")
+			g.P("}")
+			g.P("r := f.result(", name, ")")
+			g.P("if r.err != nil {")
+			g.P("return nil, r.err")
+			g.P("}")
+			g.P("out := new(", method.Output.GoIdent, ")")
+			g.P("if len(r.responses) > 0 {")
+			g.P(protoV1Package.Ident("Merge"), "(out, r.responses[0])")
+			g.P("}")
+			g.P("return out, nil")
+		case !method.Desc.IsStreamingClient():
+			g.P("c := f.record(", name, ", in)")
+			g.P("if f.", method.GoName, "Func != nil {")
+			g.P("return f.", method.GoName, "Func(ctx, in, opts...)")
+			g.P("}")
+			g.P("r := f.result(", name, ")")
+			g.P("return &", streamType, "{&", clientStreamType, "{ctx, &f.", recorderType, ", c, r}}, nil")
+		default:
+			g.P("c := f.record(", name, ")")
+			g.P("if f.", method.GoName, "Func != nil {")
+			g.P("return f.", method.GoName, "Func(ctx, opts...)")
+			g.P("}")
+			g.P("r := f.result(", name, ")")
+			g.P("return &", streamType, "{&", clientStreamType, "{ctx, &f.", recorderType, ", c, r}}, nil")
+		}
+		g.P("}")
+		g.P()
+	}
+	if hasStreams(service) {
+		g.P("// ", clientStreamType, " is the client end of a streaming call to a ", clientName, ".")
+		g.P("type ", clientStreamType, " struct {")
+		g.P("ctx ", contextPackage.Ident("Context"))
+		g.P("recorder *", recorderType)
+		g.P("call *", callType)
+		g.P("result ", resultType)
+		g.P("}")
+		g.P()
+		g.P("func (x *", clientStreamType, ") Header() (", metadataPackage.Ident("MD"), ", error) { return nil, nil }")
+		g.P("func (x *", clientStreamType, ") Trailer() ", metadataPackage.Ident("MD"), " { return nil }")
+		g.P("func (x *", clientStreamType, ") CloseSend() error { return nil }")
+		g.P("func (x *", clientStreamType, ") Context() ", contextPackage.Ident("Context"), " { return x.ctx }")
+		g.P()
+		g.P("func (x *", clientStreamType, ") SendMsg(m interface{}) error {")
+		g.P("x.recorder.addRequest(x.call, m.(", protoMessage, "))")
+		g.P("return nil")
+		g.P("}")
+		g.P()
+		g.P("func (x *", clientStreamType, ") RecvMsg(m interface{}) error {")
+		g.P("if len(x.result.responses) == 0 {")
+		g.P("if x.result.err != nil {")
+		g.P("return x.result.err")
+		g.P("}")
+		g.P("return ", ioPackage.Ident("EOF"))
+		g.P("}")
+		g.P("m.(", protoMessage, ").Reset()")
+		g.P(protoV1Package.Ident("Merge"), "(m.(", protoMessage, "), x.result.responses[0])")
+		g.P("x.result.responses = x.result.responses[1:]")
+		g.P("return nil")
+		g.P("}")
+		g.P()
+	}
+
+	// Server.
+	serverName := "Fake" + service.GoName + "Server"
+	g.P("// ", serverName, " is a fake ", service.GoName, "Server for tests, recording the calls received.")
+	g.P("//")
+	g.P("// A call to a method is handled by the Func field of the method if set,")
+	g.P("// and otherwise by the results scripted with the Return method of the method,")
+	g.P("// in order. A streaming call sends its responses before receiving the")
+	g.P("// requests, and returns its error once the requests are received.")
+	g.P("// The calls handled by a Func are recorded as well, with their request if")
+	g.P("// the method takes one, but not the requests the Func receives on the stream.")
+	g.P("type ", serverName, " struct {")
+	g.P(recorderType)
+	g.P()
+	for _, method := range service.Methods {
+		g.P(method.GoName, "Func func", transport.ServerSignature(g, method)[len(method.GoName):])
+	}
+	g.P("}")
+	g.P()
+	g.P("var _ ", service.GoName, "Server = (*", serverName, ")(nil)")
+	g.P()
+	for _, method := range service.Methods {
+		genFakeReturn(g, method, serverName)
+		name := strconv.Quote(string(method.Desc.Name()))
+		g.P("func (f *", serverName, ") ", namedServerSignature(g, method), " {")
+		switch {
+		case !method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer():
+			g.P("f.record(", name, ", in)")
+			g.P("if f.", method.GoName, "Func != nil {")
+			g.P("return f.", method.GoName, "Func(ctx, in)")
+			g.P("}")
+			g.P("r := f.result(", name, ")")
+			g.P("if r.err != nil {")
+			g.P("return nil, r.err")
+			g.P("}")
+			g.P("out := new(", method.Output.GoIdent, ")")
+			g.P("if len(r.responses) > 0 {")
+			g.P(protoV1Package.Ident("Merge"), "(out, r.responses[0])")
+			g.P("}")
+			g.P("return out, nil")
+		case !method.Desc.IsStreamingClient():
+			g.P("f.record(", name, ", in)")
+			g.P("if f.", method.GoName, "Func != nil {")
+			g.P("return f.", method.GoName, "Func(in, stream)")
+			g.P("}")
+			g.P("r := f.result(", name, ")")
+			g.P("for _, m := range r.responses {")
+			g.P("if err := stream.Send(m.(*", method.Output.GoIdent, ")); err != nil {")
+			g.P("return err")
+			g.P("}")
+			g.P("}")
+			g.P("return r.err")
+		default:
+			g.P("c := f.record(", name, ")")
+			g.P("if f.", method.GoName, "Func != nil {")
+			g.P("return f.", method.GoName, "Func(stream)")
+			g.P("}")
+			g.P("r := f.result(", name, ")")
+			if method.Desc.IsStreamingServer() {
+				g.P("for _, m := range r.responses {")
+				g.P("if err := stream.Send(m.(*", method.Output.GoIdent, ")); err != nil {")
+				g.P("return err")
+				g.P("}")
+				g.P("}")
+			}
+			g.P("for {")
+			g.P("m, err := stream.Recv()")
+			g.P("if err == ", ioPackage.Ident("EOF"), " {")
+			g.P("break")
+			g.P("}")
+			g.P("if err != nil {")
+			g.P("return err")
+			g.P("}")
+			g.P("f.addRequest(c, m)")
+			g.P("}")
+			if !method.Desc.IsStreamingServer() {
+				g.P("if r.err != nil {")
+				g.P("return r.err")
+				g.P("}")
+				g.P("out := new(", method.Output.GoIdent, ")")
+				g.P("if len(r.responses) > 0 {")
+				g.P(protoV1Package.Ident("Merge"), "(out, r.responses[0])")
+				g.P("}")
+				g.P("return stream.SendAndClose(out)")
+			} else {
+				g.P("return r.err")
+			}
+		}
+		g.P("}")
+		g.P()
+	}
+
+	if !hasStreams(service) {
+		return
+	}
+
+	// Server streams.
+	g.P("// ", serverStreamType, " is a fake server stream for testing the streaming methods")
+	g.P("// of ", service.GoName, "Server implementations. The stream of a method is returned by")
+	g.P("// the NewFake", service.GoName, "_<Method>Server function of the method.")
+	g.P("type ", serverStreamType, " struct {")
+	g.P("// Ctx is the context of the stream. If nil, it is context.Background.")
+	g.P("Ctx ", contextPackage.Ident("Context"))
+	g.P()
+	g.P("// Requests are the requests received from the stream, in order,")
+	g.P("// after which receiving returns io.EOF.")
+	g.P("Requests []", protoMessage)
+	g.P()
+	g.P("mu ", syncPackage.Ident("Mutex"))
+	g.P("responses []", protoMessage)
+	g.P("header, trailer ", metadataPackage.Ident("MD"))
+	g.P("}")
+	g.P()
+	g.P("// Responses returns copies of the responses sent to the stream so far, in order.")
+	g.P("func (s *", serverStreamType, ") Responses() []", protoMessage, " {")
+	g.P("s.mu.Lock()")
+	g.P("defer s.mu.Unlock()")
+	g.P("return append([]", protoMessage, "(nil), s.responses...)")
+	g.P("}")
+	g.P()
+	g.P("// Header returns the header metadata set on the stream.")
+	g.P("func (s *", serverStreamType, ") Header() ", metadataPackage.Ident("MD"), " {")
+	g.P("s.mu.Lock()")
+	g.P("defer s.mu.Unlock()")
+	g.P("return s.header.Copy()")
+	g.P("}")
+	g.P()
+	g.P("// Trailer returns the trailer metadata set on the stream.")
+	g.P("func (s *", serverStreamType, ") Trailer() ", metadataPackage.Ident("MD"), " {")
+	g.P("s.mu.Lock()")
+	g.P("defer s.mu.Unlock()")
+	g.P("return s.trailer.Copy()")
+	g.P("}")
+	g.P()
+	g.P("func (s *", serverStreamType, ") SetHeader(md ", metadataPackage.Ident("MD"), ") error {")
+	g.P("s.mu.Lock()")
+	g.P("defer s.mu.Unlock()")
+	g.P("s.header = ", metadataPackage.Ident("Join"), "(s.header, md)")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+	g.P("func (s *", serverStreamType, ") SendHeader(md ", metadataPackage.Ident("MD"), ") error {")
+	g.P("return s.SetHeader(md)")
+	g.P("}")
+	g.P()
+	g.P("func (s *", serverStreamType, ") SetTrailer(md ", metadataPackage.Ident("MD"), ") {")
+	g.P("s.mu.Lock()")
+	g.P("defer s.mu.Unlock()")
+	g.P("s.trailer = ", metadataPackage.Ident("Join"), "(s.trailer, md)")
+	g.P("}")
+	g.P()
+	g.P("func (s *", serverStreamType, ") Context() ", contextPackage.Ident("Context"), " {")
+	g.P("if s.Ctx == nil {")
+	g.P("return ", contextPackage.Ident("Background"), "()")
+	g.P("}")
+	g.P("return s.Ctx")
+	g.P("}")
+	g.P()
+	g.P("func (s *", serverStreamType, ") SendMsg(m interface{}) error {")
+	g.P("s.mu.Lock()")
+	g.P("defer s.mu.Unlock()")
+	g.P("s.responses = append(s.responses, ", protoV1Package.Ident("Clone"), "(m.(", protoMessage, ")))")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+	g.P("func (s *", serverStreamType, ") RecvMsg(m interface{}) error {")
+	g.P("s.mu.Lock()")
+	g.P("defer s.mu.Unlock()")
+	g.P("if len(s.Requests) == 0 {")
+	g.P("return ", ioPackage.Ident("EOF"))
+	g.P("}")
+	g.P("m.(", protoMessage, ").Reset()")
+	g.P(protoV1Package.Ident("Merge"), "(m.(", protoMessage, "), s.Requests[0])")
+	g.P("s.Requests = s.Requests[1:]")
+	g.P("return nil")
+	g.P("}")
+	g.P()
+	for _, method := range service.Methods {
+		if !method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer() {
+			continue
+		}
+		streamName := service.GoName + "_" + method.GoName + "Server"
+		g.P("// NewFake", streamName, " returns a ", streamName, " using s.")
+		g.P("func NewFake", streamName, "(s *", serverStreamType, ") ", streamName, " {")
+		g.P("return &", gengoservice.Unexport(service.GoName)+method.GoName+"Server", "{s}")
+		g.P("}")
+		g.P()
+	}
+}
+
+// genFakeReturn generates the Return method scripting the results of a
+// method of a fake client or server.
+func genFakeReturn(g *protogen.GeneratedFile, method *protogen.Method, fakeName string) {
+	name := strconv.Quote(string(method.Desc.Name()))
+	if method.Desc.IsStreamingServer() {
+		g.P("// Return", method.GoName, " scripts the result of a call to ", method.GoName, ":")
+		g.P("// the call receives the responses, then err.")
+		g.P("func (f *", fakeName, ") Return", method.GoName, "(responses []*", method.Output.GoIdent, ", err error) {")
+		g.P("var ms []", protoV1Package.Ident("Message"))
+		g.P("for _, m := range responses {")
+		g.P("ms = append(ms, m)")
+		g.P("}")
+		g.P("f.script(", name, ", ms, err)")
+		g.P("}")
+		g.P()
+		return
+	}
+	g.P("// Return", method.GoName, " scripts the result of a call to ", method.GoName, ":")
+	g.P("// the call returns out, or an empty message if out is nil, when err is nil,")
+	g.P("// and err otherwise.")
+	g.P("func (f *", fakeName, ") Return", method.GoName, "(out *", method.Output.GoIdent, ", err error) {")
+	g.P("var ms []", protoV1Package.Ident("Message"))
+	g.P("if err == nil {")
+	g.P("if out == nil {")
+	g.P("out = new(", method.Output.GoIdent, ")")
+	g.P("}")
+	g.P("ms = append(ms, out)")
+	g.P("}")
+	g.P("f.script(", name, ", ms, err)")
+	g.P("}")
+	g.P()
+}
+
+// namedServerSignature is serverSignature with named parameters.
+func namedServerSignature(g *protogen.GeneratedFile, method *protogen.Method) string {
+	var reqArgs []string
+	ret := "error"
+	if !method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer() {
+		reqArgs = append(reqArgs, "ctx "+g.QualifiedGoIdent(contextPackage.Ident("Context")))
+		ret = "(*" + g.QualifiedGoIdent(method.Output.GoIdent) + ", error)"
+	}
+	if !method.Desc.IsStreamingClient() {
+		reqArgs = append(reqArgs, "in *"+g.QualifiedGoIdent(method.Input.GoIdent))
+	}
+	if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+		reqArgs = append(reqArgs, "stream "+method.Parent.GoName+"_"+method.GoName+"Server")
+	}
+	return method.GoName + "(" + strings.Join(reqArgs, ", ") + ") " + ret
+}
+
+func hasStreams(service *protogen.Service) bool {
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+			return true
+		}
+	}
+	return false
+}
@@ -8,8 +8,8 @@ package gengogrpc
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
+	"github.com/golang/protobuf/internal/gengoservice"
 	"google.golang.org/protobuf/compiler/protogen"
 
 	"google.golang.org/protobuf/types/descriptorpb"
@@ -22,8 +22,46 @@ const (
 	statusPackage  = protogen.GoImportPath("google.golang.org/grpc/status")
 )
 
+// transport describes the signatures of the methods of the gRPC clients and
+// servers.
+var transport = gengoservice.Transport{
+	ClientStream: "Client",
+	ServerStream: "Server",
+	CallOption:   grpcPackage.Ident("CallOption"),
+}
+
+// Options selects the code generated for the services in addition to their
+// clients and servers, which are all that the zero value generates.
+type Options struct {
+	// DirectClients generates NewXxxClientFromServer, returning a client
+	// calling a server in-process.
+	DirectClients bool
+
+	// StreamHelpers generates functions adapting the streams of the
+	// streaming methods to channels, slices and callbacks.
+	StreamHelpers bool
+
+	// MethodTables generates XxxMethods, the descriptor.MethodTable
+	// describing the methods of the service for interceptors.
+	MethodTables bool
+
+	// ServiceConfig generates XxxServiceConfig, the gRPC service config
+	// given by the options of serviceconfig/serviceconfig.proto.
+	ServiceConfig bool
+}
+
 // GenerateFile generates a _grpc.pb.go file containing gRPC service definitions.
 func GenerateFile(gen *protogen.Plugin, file *protogen.File) *protogen.GeneratedFile {
+	return Options{}.GenerateFile(gen, file)
+}
+
+// GenerateFileContent generates the gRPC service definitions, excluding the package statement.
+func GenerateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+	Options{}.GenerateFileContent(gen, file, g)
+}
+
+// GenerateFile is like the GenerateFile function, with the code selected by o.
+func (o Options) GenerateFile(gen *protogen.Plugin, file *protogen.File) *protogen.GeneratedFile {
 	if len(file.Services) == 0 {
 		return nil
 	}
@@ -33,12 +71,13 @@ func GenerateFile(gen *protogen.Plugin, file *protogen.File) *protogen.Generated
 	g.P()
 	g.P("package ", file.GoPackageName)
 	g.P()
-	GenerateFileContent(gen, file, g)
+	o.GenerateFileContent(gen, file, g)
 	return g
 }
 
-// GenerateFileContent generates the gRPC service definitions, excluding the package statement.
-func GenerateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+// GenerateFileContent is like the GenerateFileContent function, with the code
+// selected by o.
+func (o Options) GenerateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
 	if len(file.Services) == 0 {
 		return
 	}
@@ -54,11 +93,11 @@ func GenerateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.
 	g.P("const _ = ", grpcPackage.Ident("SupportPackageIsVersion6"))
 	g.P()
 	for _, service := range file.Services {
-		genService(gen, file, g, service)
+		o.genService(gen, file, g, service)
 	}
 }
 
-func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, service *protogen.Service) {
+func (o Options) genService(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, service *protogen.Service) {
 	clientName := service.GoName + "Client"
 
 	g.P("// ", clientName, " is the client API for ", service.GoName, " service.")
@@ -68,33 +107,33 @@ func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.Generated
 	// Client interface.
 	if service.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated() {
 		g.P("//")
-		g.P(deprecationComment)
+		g.P(gengoservice.DeprecationComment)
 	}
 	g.Annotate(clientName, service.Location)
 	g.P("type ", clientName, " interface {")
 	for _, method := range service.Methods {
 		g.Annotate(clientName+"."+method.GoName, method.Location)
 		if method.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated() {
-			g.P(deprecationComment)
+			g.P(gengoservice.DeprecationComment)
 		}
 		g.P(method.Comments.Leading,
-			clientSignature(g, method))
+			transport.ClientSignature(g, method))
 	}
 	g.P("}")
 	g.P()
 
 	// Client structure.
-	g.P("type ", unexport(clientName), " struct {")
+	g.P("type ", gengoservice.Unexport(clientName), " struct {")
 	g.P("cc ", grpcPackage.Ident("ClientConnInterface"))
 	g.P("}")
 	g.P()
 
 	// NewClient factory.
 	if service.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated() {
-		g.P(deprecationComment)
+		g.P(gengoservice.DeprecationComment)
 	}
 	g.P("func New", clientName, " (cc ", grpcPackage.Ident("ClientConnInterface"), ") ", clientName, " {")
-	g.P("return &", unexport(clientName), "{cc}")
+	g.P("return &", gengoservice.Unexport(clientName), "{cc}")
 	g.P("}")
 	g.P()
 
@@ -117,17 +156,17 @@ func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.Generated
 	g.P("// ", serverType, " is the server API for ", service.GoName, " service.")
 	if service.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated() {
 		g.P("//")
-		g.P(deprecationComment)
+		g.P(gengoservice.DeprecationComment)
 	}
 	g.Annotate(serverType, service.Location)
 	g.P("type ", serverType, " interface {")
 	for _, method := range service.Methods {
 		g.Annotate(serverType+"."+method.GoName, method.Location)
 		if method.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated() {
-			g.P(deprecationComment)
+			g.P(gengoservice.DeprecationComment)
 		}
 		g.P(method.Comments.Leading,
-			serverSignature(g, method))
+			transport.ServerSignature(g, method))
 	}
 	g.P("}")
 	g.P()
@@ -142,7 +181,7 @@ func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.Generated
 		if !method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer() {
 			nilArg = "nil,"
 		}
-		g.P("func (*Unimplemented", serverType, ") ", serverSignature(g, method), "{")
+		g.P("func (*Unimplemented", serverType, ") ", transport.ServerSignature(g, method), "{")
 		g.P("return ", nilArg, statusPackage.Ident("Errorf"), "(", codesPackage.Ident("Unimplemented"), `, "method `, method.GoName, ` not implemented")`)
 		g.P("}")
 	}
@@ -150,7 +189,7 @@ func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.Generated
 
 	// Server registration.
 	if service.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated() {
-		g.P(deprecationComment)
+		g.P(gengoservice.DeprecationComment)
 	}
 	serviceDescVar := "_" + service.GoName + "_serviceDesc"
 	g.P("func Register", service.GoName, "Server(s *", grpcPackage.Ident("Server"), ", srv ", serverType, ") {")
@@ -200,21 +239,47 @@ func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.Generated
 	g.P("Metadata: \"", file.Desc.Path(), "\",")
 	g.P("}")
 	g.P()
-}
 
-func clientSignature(g *protogen.GeneratedFile, method *protogen.Method) string {
-	s := method.GoName + "(ctx " + g.QualifiedGoIdent(contextPackage.Ident("Context"))
-	if !method.Desc.IsStreamingClient() {
-		s += ", in *" + g.QualifiedGoIdent(method.Input.GoIdent)
+	// Method metadata.
+	if o.MethodTables {
+		genMethodTable(gen, file, g, service)
 	}
-	s += ", opts ..." + g.QualifiedGoIdent(grpcPackage.Ident("CallOption")) + ") ("
-	if !method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer() {
-		s += "*" + g.QualifiedGoIdent(method.Output.GoIdent)
-	} else {
-		s += method.Parent.GoName + "_" + method.GoName + "Client"
+	if o.ServiceConfig {
+		genServiceConfig(gen, file, g, service)
+	}
+
+	// In-process client.
+	if o.DirectClients {
+		genDirectClient(gen, file, g, service)
 	}
-	s += ", error)"
-	return s
+
+	// Stream adapters.
+	if o.StreamHelpers {
+		genStreamHelpers(gen, file, g, service)
+	}
+
+	if o.DirectClients || o.StreamHelpers && hasStreams(service) {
+		genContextError(g, service)
+	}
+}
+
+// contextError is the name of the function generated by genContextError.
+func contextError(service *protogen.Service) string {
+	return gengoservice.Unexport(service.GoName) + "ContextError"
+}
+
+// genContextError generates the function returning the status of a call
+// whose context is done, used by the in-process client and the stream
+// adapters of a service.
+func genContextError(g *protogen.GeneratedFile, service *protogen.Service) {
+	g.P("// ", contextError(service), " returns the status of a call whose context ctx is done.")
+	g.P("func ", contextError(service), "(ctx ", contextPackage.Ident("Context"), ") error {")
+	g.P("if ctx.Err() == ", contextPackage.Ident("DeadlineExceeded"), " {")
+	g.P("return ", statusPackage.Ident("Error"), "(", codesPackage.Ident("DeadlineExceeded"), ", ctx.Err().Error())")
+	g.P("}")
+	g.P("return ", statusPackage.Ident("Error"), "(", codesPackage.Ident("Canceled"), ", ctx.Err().Error())")
+	g.P("}")
+	g.P()
 }
 
 func genClientMethod(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, method *protogen.Method, index int) {
@@ -222,9 +287,9 @@ func genClientMethod(gen *protogen.Plugin, file *protogen.File, g *protogen.Gene
 	sname := fmt.Sprintf("/%s/%s", service.Desc.FullName(), method.Desc.Name())
 
 	if method.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated() {
-		g.P(deprecationComment)
+		g.P(gengoservice.DeprecationComment)
 	}
-	g.P("func (c *", unexport(service.GoName), "Client) ", clientSignature(g, method), "{")
+	g.P("func (c *", gengoservice.Unexport(service.GoName), "Client) ", transport.ClientSignature(g, method), "{")
 	if !method.Desc.IsStreamingServer() && !method.Desc.IsStreamingClient() {
 		g.P("out := new(", method.Output.GoIdent, ")")
 		g.P(`err := c.cc.Invoke(ctx, "`, sname, `", in, out, opts...)`)
@@ -234,7 +299,7 @@ func genClientMethod(gen *protogen.Plugin, file *protogen.File, g *protogen.Gene
 		g.P()
 		return
 	}
-	streamType := unexport(service.GoName) + method.GoName + "Client"
+	streamType := gengoservice.Unexport(service.GoName) + method.GoName + "Client"
 	serviceDescVar := "_" + service.GoName + "_serviceDesc"
 	g.P("stream, err := c.cc.NewStream(ctx, &", serviceDescVar, ".Streams[", index, `], "`, sname, `", opts...)`)
 	g.P("if err != nil { return nil, err }")
@@ -296,22 +361,6 @@ func genClientMethod(gen *protogen.Plugin, file *protogen.File, g *protogen.Gene
 	}
 }
 
-func serverSignature(g *protogen.GeneratedFile, method *protogen.Method) string {
-	var reqArgs []string
-	ret := "error"
-	if !method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer() {
-		reqArgs = append(reqArgs, g.QualifiedGoIdent(contextPackage.Ident("Context")))
-		ret = "(*" + g.QualifiedGoIdent(method.Output.GoIdent) + ", error)"
-	}
-	if !method.Desc.IsStreamingClient() {
-		reqArgs = append(reqArgs, "*"+g.QualifiedGoIdent(method.Input.GoIdent))
-	}
-	if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
-		reqArgs = append(reqArgs, method.Parent.GoName+"_"+method.GoName+"Server")
-	}
-	return method.GoName + "(" + strings.Join(reqArgs, ", ") + ") " + ret
-}
-
 func genServerMethod(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, method *protogen.Method) string {
 	service := method.Parent
 	hname := fmt.Sprintf("_%s_%s_Handler", service.GoName, method.GoName)
@@ -333,7 +382,7 @@ func genServerMethod(gen *protogen.Plugin, file *protogen.File, g *protogen.Gene
 		g.P()
 		return hname
 	}
-	streamType := unexport(service.GoName) + method.GoName + "Server"
+	streamType := gengoservice.Unexport(service.GoName) + method.GoName + "Server"
 	g.P("func ", hname, "(srv interface{}, stream ", grpcPackage.Ident("ServerStream"), ") error {")
 	if !method.Desc.IsStreamingClient() {
 		g.P("m := new(", method.Input.GoIdent, ")")
@@ -392,7 +441,3 @@ func genServerMethod(gen *protogen.Plugin, file *protogen.File, g *protogen.Gene
 
 	return hname
 }
-
-const deprecationComment = "// Deprecated: Do not use."
-
-func unexport(s string) string { return strings.ToLower(s[:1]) + s[1:] }
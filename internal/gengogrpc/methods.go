@@ -0,0 +1,32 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengogrpc
+
+import (
+	"strconv"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const (
+	descriptorPackage   = protogen.GoImportPath("github.com/golang/protobuf/descriptor")
+	protoreflectPackage = protogen.GoImportPath("google.golang.org/protobuf/reflect/protoreflect")
+)
+
+// genMethodTable generates XxxMethods, the descriptor.MethodTable describing
+// the methods of a service for interceptors.
+func genMethodTable(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, service *protogen.Service) {
+	tableVar := service.GoName + "Methods"
+	example := "Method"
+	if len(service.Methods) > 0 {
+		example = service.Methods[0].GoName
+	}
+	g.P("// ", tableVar, " describes the methods of the ", service.GoName, " service by full method")
+	g.P("// name, such as ", strconv.Quote("/"+string(service.Desc.FullName())+"/"+example), ", as given to interceptors.")
+	g.P("var ", tableVar, " = ", descriptorPackage.Ident("NewMethodTable"), "(func() ", protoreflectPackage.Ident("ServiceDescriptor"), " {")
+	g.P("return ", file.GoDescriptorIdent, ".Services().ByName(", strconv.Quote(string(service.Desc.Name())), ")")
+	g.P("})")
+	g.P()
+}
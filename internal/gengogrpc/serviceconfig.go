@@ -0,0 +1,258 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengogrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/internal/optionscope"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/serviceconfig"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Field numbers of the options fields of services and methods.
+const (
+	serviceOptionsField = 3
+	methodOptionsField  = 4
+)
+
+// genServiceConfig generates XxxServiceConfig, the gRPC service config of a
+// service given by its serviceconfig options, if it has any.
+func genServiceConfig(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, service *protogen.Service) {
+	configs, err := methodConfigs(file, service)
+	if err != nil {
+		gen.Error(err)
+		return
+	}
+	if len(configs) == 0 {
+		return
+	}
+	b, err := marshalServiceConfig(configs)
+	if err != nil {
+		gen.Error(err)
+		return
+	}
+	constName := service.GoName + "ServiceConfig"
+	g.P("// ", constName, " is the gRPC service config of the ", service.GoName, " service, as")
+	g.P("// given by the serviceconfig options of the service and its methods. It is")
+	g.P("// used as the default service config of a client connection with")
+	g.P("// ", grpcPackage.Ident("WithDefaultServiceConfig"), ".")
+	g.P("const ", constName, " = `", string(b), "`")
+	g.P()
+}
+
+// GenerateServiceConfigFile generates a _service_config.json file holding
+// the gRPC service config of the services of a file, given by their
+// serviceconfig options. No file is generated if none of the services
+// have options.
+func GenerateServiceConfigFile(gen *protogen.Plugin, file *protogen.File) *protogen.GeneratedFile {
+	var configs []json.RawMessage
+	for _, service := range file.Services {
+		cs, err := methodConfigs(file, service)
+		if err != nil {
+			gen.Error(err)
+			return nil
+		}
+		configs = append(configs, cs...)
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+	b, err := marshalServiceConfig(configs)
+	if err != nil {
+		gen.Error(err)
+		return nil
+	}
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+"_service_config.json", "")
+	g.P(string(b))
+	return g
+}
+
+// marshalServiceConfig returns the indented JSON of a service config
+// holding method configs.
+func marshalServiceConfig(configs []json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(map[string][]json.RawMessage{"methodConfig": configs})
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, "", "  "); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// A methodName is a name of the MethodConfig of the service config,
+// selecting the methods it applies to.
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method,omitempty"`
+}
+
+// methodConfigs returns the JSON of the method configs of a service: that
+// given by the default_method_config option of the service, followed by
+// those of the method_config options of its methods. It reports the first
+// invalid option.
+// The options are only read in files importing serviceconfig.proto.
+func methodConfigs(file *protogen.File, service *protogen.Service) ([]json.RawMessage, error) {
+	var configs []json.RawMessage
+	add := func(d protoreflect.Descriptor, xt protoreflect.ExtensionType, path protoreflect.SourcePath, name methodName) error {
+		if !optionscope.Visible(file.Desc, xt) {
+			return nil
+		}
+		v, ok, err := descriptor.OptionReader{}.Get(d, xt)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		mc := v.Message().Interface().(*serviceconfig.MethodConfig)
+		if err := checkMethodConfig(mc); err != nil {
+			return fmt.Errorf("%s: %v: invalid %v option: %v", optionLocation(file, path), d.FullName(), xt.TypeDescriptor().FullName(), err)
+		}
+		b, err := methodConfigJSON(mc, name)
+		if err != nil {
+			return err
+		}
+		configs = append(configs, b)
+		return nil
+	}
+	serviceName := string(service.Desc.FullName())
+	path := append(service.Location.Path[:len(service.Location.Path):len(service.Location.Path)], serviceOptionsField, int32(serviceconfig.E_DefaultMethodConfig.Field))
+	if err := add(service.Desc, serviceconfig.E_DefaultMethodConfig, path, methodName{Service: serviceName}); err != nil {
+		return nil, err
+	}
+	for _, method := range service.Methods {
+		path := append(method.Location.Path[:len(method.Location.Path):len(method.Location.Path)], methodOptionsField, int32(serviceconfig.E_MethodConfig.Field))
+		if err := add(method.Desc, serviceconfig.E_MethodConfig, path, methodName{Service: serviceName, Method: string(method.Desc.Name())}); err != nil {
+			return nil, err
+		}
+	}
+	return configs, nil
+}
+
+// methodConfigJSON returns the JSON of the MethodConfig of the service
+// config for a method config option: its name followed by the fields of
+// the option.
+func methodConfigJSON(mc *serviceconfig.MethodConfig, name methodName) (json.RawMessage, error) {
+	var fields bytes.Buffer
+	if err := new(jsonpb.Marshaler).Marshal(&fields, mc); err != nil {
+		return nil, err
+	}
+	names, err := json.Marshal([]methodName{name})
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString(`{"name":`)
+	buf.Write(names)
+	if f := bytes.TrimPrefix(fields.Bytes(), []byte("{")); len(f) > 1 {
+		buf.WriteByte(',')
+		buf.Write(f)
+	} else {
+		buf.WriteByte('}')
+	}
+	return buf.Bytes(), nil
+}
+
+// optionLocation returns the position of the option at a path in the
+// source of a file, as "file.proto:line:column", or the path of the file
+// if the source locations are not known.
+func optionLocation(file *protogen.File, path protoreflect.SourcePath) string {
+	locs := file.Desc.SourceLocations()
+	for len(path) > 0 {
+		if loc := locs.ByPath(path); len(loc.Path) > 0 {
+			return fmt.Sprintf("%s:%d:%d", file.Desc.Path(), loc.StartLine+1, loc.StartColumn+1)
+		}
+		// Fall back to the position of the service or method.
+		path = path[:len(path)-2]
+	}
+	return file.Desc.Path()
+}
+
+// checkMethodConfig reports whether a method config is valid, as required
+// by gRPC.
+func checkMethodConfig(mc *serviceconfig.MethodConfig) error {
+	if mc.Timeout != nil {
+		if err := checkDuration(mc.Timeout, "timeout", false); err != nil {
+			return err
+		}
+	}
+	if mc.MaxRequestMessageBytes != nil && mc.GetMaxRequestMessageBytes() == 0 {
+		return fmt.Errorf("max_request_message_bytes must be positive")
+	}
+	if mc.MaxResponseMessageBytes != nil && mc.GetMaxResponseMessageBytes() == 0 {
+		return fmt.Errorf("max_response_message_bytes must be positive")
+	}
+	if p := mc.GetRetryPolicy(); p != nil {
+		if p.GetMaxAttempts() < 2 {
+			return fmt.Errorf("retry_policy.max_attempts must be at least 2")
+		}
+		if p.InitialBackoff == nil {
+			return fmt.Errorf("retry_policy.initial_backoff must be set")
+		}
+		if err := checkDuration(p.InitialBackoff, "retry_policy.initial_backoff", false); err != nil {
+			return err
+		}
+		if p.MaxBackoff == nil {
+			return fmt.Errorf("retry_policy.max_backoff must be set")
+		}
+		if err := checkDuration(p.MaxBackoff, "retry_policy.max_backoff", false); err != nil {
+			return err
+		}
+		if !(p.GetBackoffMultiplier() > 0) {
+			return fmt.Errorf("retry_policy.backoff_multiplier must be positive")
+		}
+		if len(p.RetryableStatusCodes) == 0 {
+			return fmt.Errorf("retry_policy.retryable_status_codes must not be empty")
+		}
+		if err := checkStatusCodes(p.RetryableStatusCodes, "retry_policy.retryable_status_codes"); err != nil {
+			return err
+		}
+	}
+	if p := mc.GetHedgingPolicy(); p != nil {
+		if p.GetMaxAttempts() < 2 {
+			return fmt.Errorf("hedging_policy.max_attempts must be at least 2")
+		}
+		if p.HedgingDelay != nil {
+			if err := checkDuration(p.HedgingDelay, "hedging_policy.hedging_delay", true); err != nil {
+				return err
+			}
+		}
+		if err := checkStatusCodes(p.NonFatalStatusCodes, "hedging_policy.non_fatal_status_codes"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkDuration(d *durationpb.Duration, name string, zeroOK bool) error {
+	if err := d.CheckValid(); err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	if dur := d.AsDuration(); dur < 0 || dur == 0 && !zeroOK {
+		if zeroOK {
+			return fmt.Errorf("%s must not be negative, not %v", name, dur)
+		}
+		return fmt.Errorf("%s must be positive, not %v", name, dur)
+	}
+	return nil
+}
+
+func checkStatusCodes(codes []serviceconfig.StatusCode, name string) error {
+	for _, c := range codes {
+		if c == serviceconfig.StatusCode_OK {
+			return fmt.Errorf("%s must not hold OK", name)
+		}
+	}
+	return nil
+}
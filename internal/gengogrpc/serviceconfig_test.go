@@ -0,0 +1,294 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengogrpc
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/protoparse"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const serviceConfigHeader = `syntax = "proto3";
+
+package test;
+
+import "serviceconfig/serviceconfig.proto";
+
+option go_package = "example.com/test";
+
+message Request {}
+`
+
+// newServiceConfigPlugin returns the plugin generating test/test.proto with
+// the given contents.
+func newServiceConfigPlugin(t *testing.T, contents string) *protogen.Plugin {
+	p := &protoparse.Parser{
+		Accessor: func(name string) (io.ReadCloser, error) {
+			if name == "test/test.proto" {
+				return ioutil.NopCloser(strings.NewReader(contents)), nil
+			}
+			return os.Open(filepath.Join("..", "..", name))
+		},
+	}
+	req, err := p.CodeGeneratorRequest("", "test/test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gen
+}
+
+// generateServiceConfig returns the service config generated for the
+// services of a file importing serviceconfig.proto.
+func generateServiceConfig(t *testing.T, src string) (string, error) {
+	return generateServiceConfigFile(t, serviceConfigHeader+src)
+}
+
+// generateServiceConfigFile returns the service config generated for the
+// services of a file with the given contents.
+func generateServiceConfigFile(t *testing.T, contents string) (string, error) {
+	gen := newServiceConfigPlugin(t, contents)
+	for _, f := range gen.Files {
+		if f.Generate {
+			GenerateServiceConfigFile(gen, f)
+		}
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		return "", errors.New(resp.GetError())
+	}
+	for _, f := range resp.File {
+		if f.GetName() == "example.com/test/test_service_config.json" {
+			return f.GetContent(), nil
+		}
+	}
+	return "", nil
+}
+
+func TestServiceConfig(t *testing.T) {
+	got, err := generateServiceConfig(t, `
+service Library {
+  option (golang.protobuf.serviceconfig.default_method_config) = {
+    timeout { seconds: 5 }
+    wait_for_ready: true
+  };
+
+  rpc Get(Request) returns (Request) {
+    option (golang.protobuf.serviceconfig.method_config) = {
+      timeout { nanos: 500000000 }
+      retry_policy {
+        max_attempts: 3
+        initial_backoff { nanos: 100000000 }
+        max_backoff { seconds: 1 }
+        backoff_multiplier: 2
+        retryable_status_codes: [UNAVAILABLE, RESOURCE_EXHAUSTED]
+      }
+    };
+  }
+  rpc List(Request) returns (Request);
+}
+
+service Search {
+  rpc Find(Request) returns (Request) {
+    option (golang.protobuf.serviceconfig.method_config) = {
+      max_response_message_bytes: 1048576
+      hedging_policy { max_attempts: 2 hedging_delay { nanos: 50000000 } non_fatal_status_codes: UNAVAILABLE }
+    };
+  }
+}
+
+service Plain {
+  rpc Get(Request) returns (Request);
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "methodConfig": [
+    {
+      "name": [
+        {
+          "service": "test.Library"
+        }
+      ],
+      "waitForReady": true,
+      "timeout": "5s"
+    },
+    {
+      "name": [
+        {
+          "service": "test.Library",
+          "method": "Get"
+        }
+      ],
+      "timeout": "0.500s",
+      "retryPolicy": {
+        "maxAttempts": 3,
+        "initialBackoff": "0.100s",
+        "maxBackoff": "1s",
+        "backoffMultiplier": 2,
+        "retryableStatusCodes": [
+          "UNAVAILABLE",
+          "RESOURCE_EXHAUSTED"
+        ]
+      }
+    },
+    {
+      "name": [
+        {
+          "service": "test.Search",
+          "method": "Find"
+        }
+      ],
+      "maxResponseMessageBytes": 1048576,
+      "hedgingPolicy": {
+        "maxAttempts": 2,
+        "hedgingDelay": "0.050s",
+        "nonFatalStatusCodes": [
+          "UNAVAILABLE"
+        ]
+      }
+    }
+  ]
+}
+`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("service config mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = generateServiceConfig(t, `
+service Plain {
+  rpc Get(Request) returns (Request);
+}
+`)
+	if err != nil || got != "" {
+		t.Errorf("service config of a file without options = %q, %v; want none", got, err)
+	}
+}
+
+func TestServiceConfigOption(t *testing.T) {
+	const src = `
+service Library {
+  option (golang.protobuf.serviceconfig.default_method_config) = { timeout { seconds: 5 } };
+  rpc Get(Request) returns (Request);
+}
+`
+	for _, o := range []Options{{}, {ServiceConfig: true}} {
+		gen := newServiceConfigPlugin(t, serviceConfigHeader+src)
+		for _, f := range gen.Files {
+			if f.Generate {
+				o.GenerateFile(gen, f)
+			}
+		}
+		resp := gen.Response()
+		if resp.Error != nil {
+			t.Fatal(resp.GetError())
+		}
+		var got bool
+		for _, f := range resp.File {
+			got = got || strings.Contains(f.GetContent(), "const LibraryServiceConfig = ")
+		}
+		if got != o.ServiceConfig {
+			t.Errorf("%+v: LibraryServiceConfig generated = %v, want %v", o, got, o.ServiceConfig)
+		}
+	}
+}
+
+func TestServiceConfigForeignOption(t *testing.T) {
+	// An option of another schema with the number of method_config is
+	// not read in a file not importing serviceconfig.proto.
+	got, err := generateServiceConfigFile(t, `syntax = "proto2";
+
+package test;
+
+import "google/protobuf/descriptor.proto";
+
+option go_package = "example.com/test";
+
+extend google.protobuf.MethodOptions {
+  optional string note = 64170;
+}
+
+message Request {}
+
+service S {
+  rpc M(Request) returns (Request) {
+    option (note) = "unrelated";
+  }
+}
+`)
+	if err != nil || got != "" {
+		t.Errorf("service config of a file with a foreign option = %q, %v; want none", got, err)
+	}
+}
+
+func TestInvalidServiceConfig(t *testing.T) {
+	tests := []struct {
+		option  string
+		wantErr string
+	}{{
+		option:  `timeout {}`,
+		wantErr: "test/test.proto:13:5: test.S.M: invalid golang.protobuf.serviceconfig.method_config option: timeout must be positive, not 0s",
+	}, {
+		option:  `max_request_message_bytes: 0`,
+		wantErr: "max_request_message_bytes must be positive",
+	}, {
+		option:  `retry_policy { max_attempts: 1 }`,
+		wantErr: "retry_policy.max_attempts must be at least 2",
+	}, {
+		option:  `retry_policy { max_attempts: 2 initial_backoff { seconds: 1 } backoff_multiplier: 2 retryable_status_codes: UNAVAILABLE }`,
+		wantErr: "retry_policy.max_backoff must be set",
+	}, {
+		option:  `retry_policy { max_attempts: 2 initial_backoff { seconds: 1 } max_backoff { seconds: 1 } retryable_status_codes: UNAVAILABLE }`,
+		wantErr: "retry_policy.backoff_multiplier must be positive",
+	}, {
+		option:  `retry_policy { max_attempts: 2 initial_backoff { seconds: 1 } max_backoff { seconds: 1 } backoff_multiplier: 2 }`,
+		wantErr: "retry_policy.retryable_status_codes must not be empty",
+	}, {
+		option:  `retry_policy { max_attempts: 2 initial_backoff { nanos: 1000000000 } max_backoff { seconds: 1 } backoff_multiplier: 2 retryable_status_codes: OK }`,
+		wantErr: "retry_policy.initial_backoff: ",
+	}, {
+		option:  `hedging_policy { max_attempts: 3 hedging_delay { nanos: 1000000000 } }`,
+		wantErr: "hedging_policy.hedging_delay: ",
+	}, {
+		option:  `hedging_policy { max_attempts: 3 non_fatal_status_codes: OK }`,
+		wantErr: "hedging_policy.non_fatal_status_codes must not hold OK",
+	}}
+	for _, tt := range tests {
+		_, err := generateServiceConfig(t, `
+service S {
+  rpc M(Request) returns (Request) {
+    option (golang.protobuf.serviceconfig.method_config) = { `+tt.option+` };
+  }
+}
+`)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("option %s: error = %v, want %q", tt.option, err, tt.wantErr)
+		}
+	}
+
+	_, err := generateServiceConfig(t, `
+service S {
+  option (golang.protobuf.serviceconfig.default_method_config) = { timeout { seconds: 0 } };
+  rpc M(Request) returns (Request);
+}
+`)
+	want := "test/test.proto:12:3: test.S: invalid golang.protobuf.serviceconfig.default_method_config option: timeout must be positive, not 0s"
+	if err == nil || err.Error() != want {
+		t.Errorf("service option error = %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengogrpc
+
+import (
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// A streamEnd is the client or server end of a streaming method,
+// with the generated interface it is used through.
+type streamEnd struct {
+	method *protogen.Method
+	iface  string // such as "Foo_BarClient"
+	client bool
+
+	recv, send *protogen.Message // nil if the end does not receive or send
+}
+
+func streamEnds(method *protogen.Method) []streamEnd {
+	if !method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer() {
+		return nil
+	}
+	prefix := method.Parent.GoName + "_" + method.GoName
+	client := streamEnd{method: method, iface: prefix + "Client", client: true}
+	server := streamEnd{method: method, iface: prefix + "Server"}
+	if method.Desc.IsStreamingClient() {
+		client.send, server.recv = method.Input, method.Input
+	}
+	if method.Desc.IsStreamingServer() {
+		client.recv, server.send = method.Output, method.Output
+	}
+	return []streamEnd{client, server}
+}
+
+// genStreamHelpers generates functions adapting the streams of the streaming
+// methods of a service to channels, slices and callbacks, sparing callers
+// the loops around Send and Recv.
+func genStreamHelpers(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, service *protogen.Service) {
+	errorFunc := contextError(service)
+	for _, method := range service.Methods {
+		for _, end := range streamEnds(method) {
+			if end.recv != nil {
+				genRecvChan(g, end, errorFunc)
+				genForEach(g, end)
+			}
+			if end.send != nil {
+				genSendAll(g, end)
+				genSendChan(g, end, errorFunc)
+			}
+		}
+		if method.Desc.IsStreamingClient() && method.Desc.IsStreamingServer() {
+			genClientRun(g, method)
+		}
+	}
+}
+
+// sendResult returns the results of the functions sending all the messages
+// of a stream end: the response of a client-streaming call, and an error.
+func (end streamEnd) sendResult(g *protogen.GeneratedFile) (results, nilResult string) {
+	if end.client && end.recv == nil {
+		return "(*" + g.QualifiedGoIdent(end.method.Output.GoIdent) + ", error)", "nil, "
+	}
+	return "error", ""
+}
+
+func genRecvChan(g *protogen.GeneratedFile, end streamEnd, errorFunc string) {
+	name := end.iface + "RecvChan"
+	g.P("// ", name, " receives the messages of stream in a new goroutine.")
+	g.P("// They are sent on the returned channel, which is closed at the end of the")
+	g.P("// stream; the error ending the stream, or nil if it ended normally, is then")
+	g.P("// sent on the error channel.")
+	g.P("//")
+	g.P("// The goroutine also ends when the context of the stream is done, so a caller")
+	if end.client {
+		g.P("// not draining the channel must cancel the context of the call.")
+	} else {
+		g.P("// not draining the channel need only return from the method.")
+	}
+	g.P("func ", name, "(stream ", end.iface, ") (<-chan *", end.recv.GoIdent, ", <-chan error) {")
+	g.P("ch := make(chan *", end.recv.GoIdent, ")")
+	g.P("errc := make(chan error, 1)")
+	g.P("go func() {")
+	g.P("defer close(errc)")
+	g.P("defer close(ch)")
+	g.P("for {")
+	g.P("m, err := stream.Recv()")
+	g.P("if err == ", ioPackage.Ident("EOF"), " {")
+	g.P("errc <- nil")
+	g.P("return")
+	g.P("}")
+	g.P("if err != nil {")
+	g.P("errc <- err")
+	g.P("return")
+	g.P("}")
+	g.P("select {")
+	g.P("case ch <- m:")
+	g.P("case <-stream.Context().Done():")
+	g.P("errc <- ", errorFunc, "(stream.Context())")
+	g.P("return")
+	g.P("}")
+	g.P("}")
+	g.P("}()")
+	g.P("return ch, errc")
+	g.P("}")
+	g.P()
+}
+
+func genForEach(g *protogen.GeneratedFile, end streamEnd) {
+	name := end.iface + "ForEach"
+	g.P("// ", name, " calls f with each message received on stream.")
+	g.P("// It returns nil at the end of the stream, or the first error of the stream")
+	g.P("// or of f.")
+	g.P("func ", name, "(stream ", end.iface, ", f func(*", end.recv.GoIdent, ") error) error {")
+	g.P("for {")
+	g.P("m, err := stream.Recv()")
+	g.P("if err == ", ioPackage.Ident("EOF"), " {")
+	g.P("return nil")
+	g.P("}")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("if err := f(m); err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("}")
+	g.P("}")
+	g.P()
+}
+
+// genSendDoc generates the part of the comment of the sending functions
+// describing the end of the stream.
+func genSendDoc(g *protogen.GeneratedFile, end streamEnd) {
+	switch {
+	case end.client && end.recv == nil:
+		g.P("// It then closes the sending side of the stream and returns the response.")
+		g.P("// If the server ends the call early, the sending stops and the status of")
+		g.P("// the call is returned.")
+	case end.client:
+		g.P("// It then closes the sending side of the stream. If the server ends the call")
+		g.P("// early, the sending stops and nil is returned; the status of the call is")
+		g.P("// returned by Recv.")
+	}
+}
+
+// genSendEnd generates the end of the sending functions, once all the
+// messages are sent.
+func genSendEnd(g *protogen.GeneratedFile, end streamEnd) {
+	switch {
+	case end.client && end.recv == nil:
+		g.P("return stream.CloseAndRecv()")
+	case end.client:
+		g.P("return stream.CloseSend()")
+	default:
+		g.P("return nil")
+	}
+}
+
+// genSendError generates the handling of the error err of a call to Send.
+func genSendError(g *protogen.GeneratedFile, end streamEnd) {
+	_, nilResult := end.sendResult(g)
+	if end.client {
+		// The server ended the call; the status is given by RecvMsg.
+		g.P("if err == ", ioPackage.Ident("EOF"), " {")
+		if end.recv == nil {
+			g.P("return stream.CloseAndRecv()")
+		} else {
+			g.P("return nil")
+		}
+		g.P("}")
+	}
+	g.P("if err != nil {")
+	g.P("return ", nilResult, "err")
+	g.P("}")
+}
+
+func genSendAll(g *protogen.GeneratedFile, end streamEnd) {
+	name := end.iface + "SendAll"
+	results, _ := end.sendResult(g)
+	g.P("// ", name, " sends the messages ms on stream, in order.")
+	genSendDoc(g, end)
+	g.P("func ", name, "(stream ", end.iface, ", ms []*", end.send.GoIdent, ") ", results, " {")
+	g.P("for _, m := range ms {")
+	g.P("err := stream.Send(m)")
+	genSendError(g, end)
+	g.P("}")
+	genSendEnd(g, end)
+	g.P("}")
+	g.P()
+}
+
+func genSendChan(g *protogen.GeneratedFile, end streamEnd, errorFunc string) {
+	name := end.iface + "SendChan"
+	results, nilResult := end.sendResult(g)
+	g.P("// ", name, " sends the messages received from ch on stream until ch is closed.")
+	genSendDoc(g, end)
+	g.P("// If the context of the stream is done first, its status is returned.")
+	g.P("func ", name, "(stream ", end.iface, ", ch <-chan *", end.send.GoIdent, ") ", results, " {")
+	g.P("for {")
+	g.P("select {")
+	g.P("case m, ok := <-ch:")
+	g.P("if !ok {")
+	genSendEnd(g, end)
+	g.P("}")
+	g.P("err := stream.Send(m)")
+	genSendError(g, end)
+	g.P("case <-stream.Context().Done():")
+	g.P("return ", nilResult, errorFunc, "(stream.Context())")
+	g.P("}")
+	g.P("}")
+	g.P("}")
+	g.P()
+}
+
+// genClientRun generates Xxx_MethodClientRun, which calls a bidirectional
+// streaming method, sending requests from a channel while passing the
+// responses to a callback.
+func genClientRun(g *protogen.GeneratedFile, method *protogen.Method) {
+	service := method.Parent
+	iface := service.GoName + "_" + method.GoName + "Client"
+	name := iface + "Run"
+	g.P("// ", name, " calls the ", method.GoName, " method with c.")
+	g.P("// It sends the requests received from send until it is closed, and calls recv")
+	g.P("// with each response until the end of the call.")
+	g.P("//")
+	g.P("// It returns the status of the call, or the first error returned by recv,")
+	g.P("// which cancels the call. The call is over once ", name, " returns.")
+	g.P("func ", name, "(ctx ", contextPackage.Ident("Context"), ", c ", service.GoName, "Client, send <-chan *", method.Input.GoIdent,
+		", recv func(*", method.Output.GoIdent, ") error, opts ...", grpcPackage.Ident("CallOption"), ") error {")
+	g.P("ctx, cancel := ", contextPackage.Ident("WithCancel"), "(ctx)")
+	g.P("defer cancel()")
+	g.P("stream, err := c.", method.GoName, "(ctx, opts...)")
+	g.P("if err != nil {")
+	g.P("return err")
+	g.P("}")
+	g.P("sent := make(chan error, 1)")
+	g.P("go func() { sent <- ", iface, "SendChan(stream, send) }()")
+	g.P("err = ", iface, "ForEach(stream, recv)")
+	g.P("select {")
+	g.P("case serr := <-sent:")
+	g.P("// The sending stopped by itself; its error is that of the call.")
+	g.P("if err == nil {")
+	g.P("err = serr")
+	g.P("}")
+	g.P("default:")
+	g.P("// Stop the sending, which fails with the canceled context.")
+	g.P("cancel()")
+	g.P("<-sent")
+	g.P("}")
+	g.P("return err")
+	g.P("}")
+	g.P()
+}
@@ -0,0 +1,270 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package echo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestDirectUnary(t *testing.T) {
+	c := NewEchoClientFromServer(&server{}, nil, nil)
+	in := &Msg{Text: "hello", Nums: []int32{7}}
+	out, err := c.Unary(context.Background(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Text != "hello" || !reflect.DeepEqual(out.Nums, []int32{7, 1}) {
+		t.Errorf("Unary() = %v, want hello [7 1]", out)
+	}
+	if !reflect.DeepEqual(in.Nums, []int32{7}) {
+		t.Errorf("request modified by the server: %v", in)
+	}
+
+	if _, err := c.Unary(context.Background(), &Msg{Text: "fail"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Unary(fail) error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestDirectMetadata(t *testing.T) {
+	c := NewEchoClientFromServer(&server{}, nil, nil)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("key", "a", "key", "b"))
+	out, err := c.Unary(ctx, &Msg{Text: "metadata"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Text != "a,b" {
+		t.Errorf("incoming metadata = %q, want a,b", out.Text)
+	}
+
+	stream, err := c.ServerStream(context.Background(), &Msg{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, err := stream.Header()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := header.Get("header"); !reflect.DeepEqual(got, []string{"h"}) {
+		t.Errorf("header = %v, want [h]", got)
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := stream.Trailer().Get("trailer"); !reflect.DeepEqual(got, []string{"t"}) {
+		t.Errorf("trailer = %v, want [t]", got)
+	}
+}
+
+func TestDirectCancel(t *testing.T) {
+	s := newBlockingServer()
+	c := NewEchoClientFromServer(s, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-s.blocked
+		cancel()
+	}()
+	if _, err := c.Unary(ctx, &Msg{Text: "block"}); status.Code(err) != codes.Canceled {
+		t.Errorf("Unary() error = %v, want Canceled", err)
+	}
+	if err := <-s.done; err != context.Canceled {
+		t.Errorf("server context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDirectDeadline(t *testing.T) {
+	s := newBlockingServer()
+	c := NewEchoClientFromServer(s, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.Unary(ctx, &Msg{Text: "block"}); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Unary() error = %v, want DeadlineExceeded", err)
+	}
+	if err := <-s.done; err != context.DeadlineExceeded {
+		t.Errorf("server context error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDirectStreams(t *testing.T) {
+	c := NewEchoClientFromServer(&server{}, nil, nil)
+	ctx := context.Background()
+
+	ss, err := c.ServerStream(ctx, &Msg{Text: "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := int32(0); ; i++ {
+		m, err := ss.Recv()
+		if err == io.EOF {
+			if i != 40 {
+				t.Errorf("received %d messages, want 40", i)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Text != "s" || !reflect.DeepEqual(m.Nums, []int32{i}) {
+			t.Fatalf("message %d = %v", i, m)
+		}
+	}
+
+	cs, err := c.ClientStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := int32(1); i <= 30; i++ {
+		m := &Msg{Nums: []int32{i}}
+		if err := cs.Send(m); err != nil {
+			t.Fatal(err)
+		}
+		// The server receives a copy of the message.
+		m.Nums[0] = 1000
+	}
+	out, err := cs.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out.Nums, []int32{465}) {
+		t.Errorf("CloseAndRecv() = %v, want [465]", out)
+	}
+
+	bs, err := c.Bidi(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := int32(0); i < 10; i++ {
+		if err := bs.Send(&Msg{Nums: []int32{i}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bs.CloseSend()
+	for i := int32(0); ; i++ {
+		m, err := bs.Recv()
+		if err == io.EOF {
+			if i != 10 {
+				t.Errorf("received %d messages, want 10", i)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(m.Nums, []int32{i}) {
+			t.Fatalf("message %d = %v", i, m)
+		}
+	}
+
+	bs, err = c.Bidi(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs.Send(&Msg{Text: "fail"})
+	if _, err := bs.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Recv() error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestDirectStreamCancel(t *testing.T) {
+	s := newBlockingServer()
+	c := NewEchoClientFromServer(s, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.Bidi(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&Msg{Text: "block"}); err != nil {
+		t.Fatal(err)
+	}
+	<-s.blocked
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("Recv() error = %v, want Canceled", err)
+	}
+	if err := <-s.done; err != context.Canceled {
+		t.Errorf("server context error = %v, want %v", err, context.Canceled)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ss, err := c.ServerStream(ctx, &Msg{Text: "block"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err = ss.Recv(); err != nil {
+			break
+		}
+	}
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("Recv() error = %v, want DeadlineExceeded", err)
+	}
+	<-s.blocked
+	<-s.done
+}
+
+func TestDirectInterceptors(t *testing.T) {
+	var calls []string
+	unary := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name+" "+info.FullMethod)
+			return handler(ctx, req)
+		}
+	}
+	stream := func(name string) grpc.StreamServerInterceptor {
+		return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			calls = append(calls, fmt.Sprintf("%s %s %v %v", name, info.FullMethod, info.IsClientStream, info.IsServerStream))
+			return handler(srv, ss)
+		}
+	}
+	c := NewEchoClientFromServer(&server{},
+		[]grpc.UnaryServerInterceptor{unary("u1"), unary("u2")},
+		[]grpc.StreamServerInterceptor{stream("s1"), stream("s2")})
+	ctx := context.Background()
+	if _, err := c.Unary(ctx, &Msg{}); err != nil {
+		t.Fatal(err)
+	}
+	ss, err := c.ServerStream(ctx, &Msg{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := ss.Recv(); err != nil {
+			break
+		}
+	}
+	want := []string{
+		"u1 /echo.Echo/Unary",
+		"u2 /echo.Echo/Unary",
+		"s1 /echo.Echo/ServerStream false true",
+		"s2 /echo.Echo/ServerStream false true",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("interceptor calls = %q, want %q", calls, want)
+	}
+}
+
+func TestDirectNilResponse(t *testing.T) {
+	intercept := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return nil, nil
+	}
+	c := NewEchoClientFromServer(&server{}, []grpc.UnaryServerInterceptor{intercept}, nil)
+	if out, err := c.Unary(context.Background(), &Msg{}); status.Code(err) != codes.Internal {
+		t.Errorf("Unary() = %v, %v, want an Internal error", out, err)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package echo holds the tests run by the end-to-end test of gengogrpc on the
+// code generated for echo.proto.
+package echo
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// server is the EchoServer used by the tests. Its methods return the
+// messages they receive, with the text "block" waiting for the cancellation
+// of the call, and the text "fail" failing it.
+type server struct {
+	UnimplementedEchoServer
+
+	// blocked receives a value when a method starts waiting for the
+	// cancellation of its call, and done receives the error it then returns,
+	// if not nil.
+	blocked chan struct{}
+	done    chan error
+}
+
+// newBlockingServer returns a server reporting its blocked calls.
+func newBlockingServer() *server {
+	return &server{blocked: make(chan struct{}, 1), done: make(chan error, 1)}
+}
+
+// block waits for the end of ctx and returns its error, also sent to done.
+func (s *server) block(ctx context.Context) error {
+	if s.blocked != nil {
+		s.blocked <- struct{}{}
+	}
+	<-ctx.Done()
+	if s.done != nil {
+		s.done <- ctx.Err()
+	}
+	return ctx.Err()
+}
+
+func (s *server) Unary(ctx context.Context, in *Msg) (*Msg, error) {
+	switch in.Text {
+	case "block":
+		return nil, s.block(ctx)
+	case "fail":
+		return nil, status.Error(codes.InvalidArgument, "fail")
+	case "metadata":
+		md, _ := metadata.FromIncomingContext(ctx)
+		return &Msg{Text: strings.Join(md.Get("key"), ",")}, nil
+	}
+	// The request is the server's own copy.
+	in.Nums = append(in.Nums, 1)
+	return in, nil
+}
+
+func (s *server) ServerStream(in *Msg, stream Echo_ServerStreamServer) error {
+	stream.SetHeader(metadata.Pairs("header", "h"))
+	stream.SetTrailer(metadata.Pairs("trailer", "t"))
+	if in.Text == "block" {
+		return s.block(stream.Context())
+	}
+	for i := int32(0); i < 40; i++ {
+		if err := stream.Send(&Msg{Text: in.Text, Nums: []int32{i}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *server) ClientStream(stream Echo_ClientStreamServer) error {
+	var sum int32
+	for {
+		m, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&Msg{Nums: []int32{sum}})
+		}
+		if err != nil {
+			return err
+		}
+		if m.Text == "fail" {
+			return status.Error(codes.InvalidArgument, "fail")
+		}
+		for _, n := range m.Nums {
+			sum += n
+		}
+	}
+}
+
+func (s *server) Bidi(stream Echo_BidiServer) error {
+	for {
+		m, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch m.Text {
+		case "block":
+			return s.block(stream.Context())
+		case "fail":
+			return status.Error(codes.InvalidArgument, "fail")
+		}
+		if err := stream.Send(m); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package echo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// recordingT records the errors reported by AssertRequests.
+type recordingT struct {
+	errors []string
+}
+
+func (*recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestFakeClientUnary(t *testing.T) {
+	f := new(FakeEchoClient)
+	ctx := context.Background()
+	if _, err := f.Unary(ctx, &Msg{}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("Unary() without results error = %v, want Unimplemented", err)
+	}
+
+	f.ReturnUnary(&Msg{Text: "first"}, nil)
+	f.ReturnUnary(nil, status.Error(codes.NotFound, "second"))
+	in := &Msg{Text: "a"}
+	out, err := f.Unary(ctx, in)
+	if err != nil || out.Text != "first" {
+		t.Errorf("Unary() = %v, %v, want first", out, err)
+	}
+	// The recorded request is a copy.
+	in.Text = "b"
+	for i := 0; i < 2; i++ {
+		// The last result is repeated.
+		if _, err := f.Unary(ctx, in); status.Code(err) != codes.NotFound {
+			t.Errorf("Unary() error = %v, want NotFound", err)
+		}
+	}
+	f.AssertRequests(t, "Unary", &Msg{}, &Msg{Text: "a"}, &Msg{Text: "b"}, &Msg{Text: "b"})
+
+	f.UnaryFunc = func(ctx context.Context, in *Msg, opts ...grpc.CallOption) (*Msg, error) {
+		return &Msg{Text: "func " + in.Text}, nil
+	}
+	if out, err := f.Unary(ctx, &Msg{Text: "c"}); err != nil || out.Text != "func c" {
+		t.Errorf("Unary() with UnaryFunc = %v, %v, want func c", out, err)
+	}
+	if got, want := len(f.Calls()), 5; got != want {
+		t.Errorf("%d calls recorded, want %d", got, want)
+	}
+
+	// The scripted response is copied.
+	f = new(FakeEchoClient)
+	script := &Msg{Text: "scripted"}
+	f.ReturnUnary(script, nil)
+	script.Text = "changed"
+	out, _ = f.Unary(ctx, in)
+	out.Text = "changed too"
+	if out, _ := f.Unary(ctx, in); out.Text != "scripted" {
+		t.Errorf("Unary() = %v, want scripted", out)
+	}
+}
+
+func TestFakeClientStreams(t *testing.T) {
+	f := new(FakeEchoClient)
+	ctx := context.Background()
+
+	f.ReturnServerStream([]*Msg{{Text: "1"}, {Text: "2"}}, status.Error(codes.Aborted, "end"))
+	ss, err := f.ServerStream(ctx, &Msg{Text: "s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var texts []string
+	for {
+		m, err := ss.Recv()
+		if err != nil {
+			if status.Code(err) != codes.Aborted {
+				t.Errorf("Recv() error = %v, want Aborted", err)
+			}
+			break
+		}
+		texts = append(texts, m.Text)
+	}
+	if want := []string{"1", "2"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("received %q, want %q", texts, want)
+	}
+
+	f.ReturnClientStream(&Msg{Text: "sum"}, nil)
+	cs, err := f.ClientStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs.Send(&Msg{Text: "c1"})
+	cs.Send(&Msg{Text: "c2"})
+	if out, err := cs.CloseAndRecv(); err != nil || out.Text != "sum" {
+		t.Errorf("CloseAndRecv() = %v, %v, want sum", out, err)
+	}
+
+	f.ReturnBidi([]*Msg{{Text: "b"}}, nil)
+	bs, err := f.Bidi(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs.Send(&Msg{Text: "b1"})
+	if m, err := bs.Recv(); err != nil || m.Text != "b" {
+		t.Errorf("Recv() = %v, %v, want b", m, err)
+	}
+	if _, err := bs.Recv(); err != io.EOF {
+		t.Errorf("Recv() error = %v, want EOF", err)
+	}
+
+	f.AssertRequests(t, "ServerStream", &Msg{Text: "s"})
+	f.AssertRequests(t, "ClientStream", &Msg{Text: "c1"}, &Msg{Text: "c2"})
+	f.AssertRequests(t, "Bidi", &Msg{Text: "b1"})
+	var methods []string
+	for _, c := range f.Calls() {
+		methods = append(methods, c.Method)
+	}
+	if want := []string{"ServerStream", "ClientStream", "Bidi"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("calls = %q, want %q", methods, want)
+	}
+
+	// As for a unary call, a nil response is returned as an empty message,
+	// and a response scripted with an error is not returned.
+	f = new(FakeEchoClient)
+	f.ReturnClientStream(nil, nil)
+	f.ReturnClientStream(&Msg{Text: "ignored"}, status.Error(codes.Aborted, "end"))
+	cs, err = f.ClientStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := cs.CloseAndRecv(); err != nil || !proto.Equal(out, &Msg{}) {
+		t.Errorf("CloseAndRecv() = %v, %v, want an empty message", out, err)
+	}
+	cs, err = f.ClientStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := cs.CloseAndRecv(); status.Code(err) != codes.Aborted {
+		t.Errorf("CloseAndRecv() = %v, %v, want Aborted", out, err)
+	}
+}
+
+func TestFakeAssertRequests(t *testing.T) {
+	f := new(FakeEchoClient)
+	f.ReturnUnary(&Msg{}, nil)
+	f.Unary(context.Background(), &Msg{Text: "a"})
+	f.Unary(context.Background(), &Msg{Text: "b"})
+
+	rt := new(recordingT)
+	if !f.AssertRequests(rt, "Unary", &Msg{Text: "a"}, &Msg{Text: "b"}) || rt.errors != nil {
+		t.Errorf("AssertRequests() of the requests reported %q", rt.errors)
+	}
+	rt = new(recordingT)
+	if f.AssertRequests(rt, "Unary", &Msg{Text: "a"}) {
+		t.Error("AssertRequests() of a missing request succeeded")
+	}
+	if want := []string{"Echo.Unary: got 2 requests, want 1"}; !reflect.DeepEqual(rt.errors, want) {
+		t.Errorf("AssertRequests() reported %q, want %q", rt.errors, want)
+	}
+	rt = new(recordingT)
+	if f.AssertRequests(rt, "Unary", &Msg{Text: "a"}, &Msg{Text: "c"}) {
+		t.Error("AssertRequests() of a different request succeeded")
+	}
+	if len(rt.errors) != 1 {
+		t.Errorf("AssertRequests() reported %q, want an error about request 1", rt.errors)
+	}
+}
+
+func TestFakeServer(t *testing.T) {
+	f := new(FakeEchoServer)
+	f.ReturnUnary(&Msg{Text: "u"}, nil)
+	if out, err := f.Unary(context.Background(), &Msg{Text: "in"}); err != nil || out.Text != "u" {
+		t.Errorf("Unary() = %v, %v, want u", out, err)
+	}
+
+	f.ReturnServerStream([]*Msg{{Text: "1"}, {Text: "2"}}, nil)
+	s := new(FakeEchoServerStream)
+	if err := f.ServerStream(&Msg{Text: "s"}, NewFakeEcho_ServerStreamServer(s)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Responses(), []proto.Message{&Msg{Text: "1"}, &Msg{Text: "2"}}; !equalMessages(got, want) {
+		t.Errorf("responses = %v, want %v", got, want)
+	}
+
+	f.ReturnBidi([]*Msg{{Text: "r"}}, status.Error(codes.Aborted, "end"))
+	s = &FakeEchoServerStream{Requests: []proto.Message{&Msg{Text: "b1"}, &Msg{Text: "b2"}}}
+	if err := f.Bidi(NewFakeEcho_BidiServer(s)); status.Code(err) != codes.Aborted {
+		t.Errorf("Bidi() error = %v, want Aborted", err)
+	}
+	if got, want := s.Responses(), []proto.Message{&Msg{Text: "r"}}; !equalMessages(got, want) {
+		t.Errorf("responses = %v, want %v", got, want)
+	}
+	f.AssertRequests(t, "Unary", &Msg{Text: "in"})
+	f.AssertRequests(t, "ServerStream", &Msg{Text: "s"})
+	f.AssertRequests(t, "Bidi", &Msg{Text: "b1"}, &Msg{Text: "b2"})
+}
+
+func TestFakeServerStream(t *testing.T) {
+	// The fake streams test server implementations.
+	s := &FakeEchoServerStream{Requests: []proto.Message{&Msg{Nums: []int32{2}}, &Msg{Nums: []int32{3}}}}
+	if err := new(server).ClientStream(NewFakeEcho_ClientStreamServer(s)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Responses(), []proto.Message{&Msg{Nums: []int32{5}}}; !equalMessages(got, want) {
+		t.Errorf("responses = %v, want %v", got, want)
+	}
+
+	s = new(FakeEchoServerStream)
+	if err := new(server).ServerStream(&Msg{}, NewFakeEcho_ServerStreamServer(s)); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(s.Responses()); got != 40 {
+		t.Errorf("%d responses, want 40", got)
+	}
+	if got, want := s.Header(), metadata.Pairs("header", "h"); !reflect.DeepEqual(got, want) {
+		t.Errorf("header = %v, want %v", got, want)
+	}
+	if got, want := s.Trailer(), metadata.Pairs("trailer", "t"); !reflect.DeepEqual(got, want) {
+		t.Errorf("trailer = %v, want %v", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s = &FakeEchoServerStream{Ctx: ctx, Requests: []proto.Message{&Msg{Text: "block"}}}
+	if err := new(server).Bidi(NewFakeEcho_BidiServer(s)); err != context.Canceled {
+		t.Errorf("Bidi() with a canceled context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func equalMessages(x, y []proto.Message) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if !proto.Equal(x[i], y[i]) {
+			return false
+		}
+	}
+	return true
+}
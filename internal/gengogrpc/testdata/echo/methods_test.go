@@ -0,0 +1,85 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package echo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/descriptor"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestMethodTable(t *testing.T) {
+	if got, want := EchoMethods.Service().FullName(), "echo.Echo"; string(got) != want {
+		t.Errorf("Service() = %v, want %v", got, want)
+	}
+	var got []string
+	for _, m := range EchoMethods.Methods() {
+		got = append(got, fmt.Sprintf("%s %v %v %v", m.FullMethod, m.StreamKind, m.IdempotencyLevel, m.Deprecated))
+		if m.Input.Descriptor().FullName() != "echo.Msg" || m.Output.Descriptor().FullName() != "echo.Msg" {
+			t.Errorf("%s: types %v and %v, want echo.Msg", m.FullMethod, m.Input.Descriptor().FullName(), m.Output.Descriptor().FullName())
+		}
+		if m.Descriptor.Parent() != EchoMethods.Service() {
+			t.Errorf("%s: descriptor of another service", m.FullMethod)
+		}
+		if l := EchoMethods.Lookup(m.FullMethod); l != m {
+			t.Errorf("Lookup(%q) = %v, want %v", m.FullMethod, l, m)
+		}
+	}
+	want := []string{
+		"/echo.Echo/Unary unary NO_SIDE_EFFECTS false",
+		"/echo.Echo/ServerStream server streaming IDEMPOTENCY_UNKNOWN false",
+		"/echo.Echo/ClientStream client streaming IDEMPOTENCY_UNKNOWN true",
+		"/echo.Echo/Bidi bidirectional streaming IDEMPOTENCY_UNKNOWN false",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Methods() = %q, want %q", got, want)
+	}
+	for _, name := range []string{"/echo.Echo/Missing", "echo.Echo/Unary", "/echo.Other/Unary", ""} {
+		if m := EchoMethods.Lookup(name); m != nil {
+			t.Errorf("Lookup(%q) = %v, want nil", name, m)
+		}
+	}
+}
+
+func TestMethodTableInterceptor(t *testing.T) {
+	// The table describes the methods given to interceptors.
+	var infos []*descriptor.MethodInfo
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		m := EchoMethods.Lookup(info.FullMethod)
+		if m == nil {
+			t.Fatalf("no method %q", info.FullMethod)
+		}
+		if got := req.(*Msg).ProtoReflect().Type(); got != m.Input {
+			t.Errorf("request of type %v, want %v", got, m.Input)
+		}
+		infos = append(infos, m)
+		return handler(ctx, req)
+	}
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		infos = append(infos, EchoMethods.Lookup(info.FullMethod))
+		return handler(srv, ss)
+	}
+	c := NewEchoClientFromServer(&server{}, []grpc.UnaryServerInterceptor{unary}, []grpc.StreamServerInterceptor{stream})
+	if _, err := c.Unary(context.Background(), &Msg{}); err != nil {
+		t.Fatal(err)
+	}
+	bs, err := c.Bidi(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs.CloseSend()
+	if _, err := bs.Recv(); err != io.EOF {
+		t.Fatalf("Recv() error = %v, want EOF", err)
+	}
+	if len(infos) != 2 || infos[0].IdempotencyLevel != descriptorpb.MethodOptions_NO_SIDE_EFFECTS || infos[1].StreamKind != descriptor.BidiStreaming {
+		t.Errorf("interceptors got %v", infos)
+	}
+}
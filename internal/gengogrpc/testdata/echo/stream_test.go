@@ -0,0 +1,255 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package echo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecvChan(t *testing.T) {
+	c := NewEchoClientFromServer(&server{}, nil, nil)
+	stream, err := c.ServerStream(context.Background(), &Msg{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, errc := Echo_ServerStreamClientRecvChan(stream)
+	var nums []int32
+	for m := range ch {
+		nums = append(nums, m.Nums...)
+	}
+	if err := <-errc; err != nil {
+		t.Errorf("RecvChan() error = %v", err)
+	}
+	if len(nums) != 40 || nums[39] != 39 {
+		t.Errorf("received %v, want 0 to 39", nums)
+	}
+
+	bs, err := c.Bidi(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs.Send(&Msg{Text: "fail"})
+	ch, errc = Echo_BidiClientRecvChan(bs)
+	for range ch {
+	}
+	if err := <-errc; status.Code(err) != codes.InvalidArgument {
+		t.Errorf("RecvChan() error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestRecvChanShutdown(t *testing.T) {
+	// A caller not draining the channel cancels the call, which ends the
+	// goroutine receiving the messages.
+	c := NewEchoClientFromServer(&server{}, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.ServerStream(ctx, &Msg{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, errc := Echo_ServerStreamClientRecvChan(stream)
+	<-ch
+	cancel()
+	for range ch {
+	}
+	if err := <-errc; status.Code(err) != codes.Canceled {
+		t.Errorf("RecvChan() error = %v, want Canceled", err)
+	}
+	if _, ok := <-errc; ok {
+		t.Error("error channel not closed")
+	}
+
+	// On the server end, the end of the call does.
+	ctx, cancel = context.WithCancel(context.Background())
+	s := &FakeEchoServerStream{Ctx: ctx, Requests: []proto.Message{&Msg{Text: "1"}, &Msg{Text: "2"}}}
+	ch, errc = Echo_BidiServerRecvChan(NewFakeEcho_BidiServer(s))
+	if m := <-ch; m.Text != "1" {
+		t.Errorf("received %v, want 1", m)
+	}
+	cancel()
+	for range ch {
+	}
+	if err := <-errc; err != nil && status.Code(err) != codes.Canceled {
+		t.Errorf("RecvChan() error = %v, want nil or Canceled", err)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	c := NewEchoClientFromServer(&server{}, nil, nil)
+	stream, err := c.ServerStream(context.Background(), &Msg{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	if err := Echo_ServerStreamClientForEach(stream, func(*Msg) error { n++; return nil }); err != nil || n != 40 {
+		t.Errorf("ForEach() = %v after %d messages, want nil after 40", err, n)
+	}
+
+	stream, err = c.ServerStream(context.Background(), &Msg{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := errors.New("stop")
+	n = 0
+	err = Echo_ServerStreamClientForEach(stream, func(*Msg) error {
+		n++
+		if n == 3 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || n != 3 {
+		t.Errorf("ForEach() = %v after %d messages, want %v after 3", err, n, stop)
+	}
+}
+
+func TestSendAll(t *testing.T) {
+	c := NewEchoClientFromServer(&server{}, nil, nil)
+	ctx := context.Background()
+	stream, err := c.ClientStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := Echo_ClientStreamClientSendAll(stream, []*Msg{{Nums: []int32{1}}, {Nums: []int32{2}}})
+	if err != nil || !reflect.DeepEqual(out.Nums, []int32{3}) {
+		t.Errorf("SendAll() = %v, %v, want [3]", out, err)
+	}
+
+	// The server ends the call early.
+	stream, err = c.ClientStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms := []*Msg{{Text: "fail"}}
+	for i := 0; i < 100; i++ {
+		ms = append(ms, &Msg{})
+	}
+	if _, err := Echo_ClientStreamClientSendAll(stream, ms); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SendAll() error = %v, want InvalidArgument", err)
+	}
+
+	bs, err := c.Bidi(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Echo_BidiClientSendAll(bs, []*Msg{{Text: "1"}, {Text: "2"}}); err != nil {
+		t.Fatal(err)
+	}
+	var texts []string
+	if err := Echo_BidiClientForEach(bs, func(m *Msg) error { texts = append(texts, m.Text); return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1", "2"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("received %q, want %q", texts, want)
+	}
+
+	s := new(FakeEchoServerStream)
+	if err := Echo_ServerStreamServerSendAll(NewFakeEcho_ServerStreamServer(s), []*Msg{{Text: "a"}, {Text: "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(s.Responses()); got != 2 {
+		t.Errorf("%d responses sent, want 2", got)
+	}
+}
+
+func TestSendChan(t *testing.T) {
+	c := NewEchoClientFromServer(&server{}, nil, nil)
+	ctx := context.Background()
+	stream, err := c.ClientStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := make(chan *Msg)
+	go func() {
+		for i := int32(1); i <= 4; i++ {
+			ch <- &Msg{Nums: []int32{i}}
+		}
+		close(ch)
+	}()
+	out, err := Echo_ClientStreamClientSendChan(stream, ch)
+	if err != nil || !reflect.DeepEqual(out.Nums, []int32{10}) {
+		t.Errorf("SendChan() = %v, %v, want [10]", out, err)
+	}
+
+	// The sending stops when the call is canceled, without ch being closed.
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err = c.ClientStream(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if _, err := Echo_ClientStreamClientSendChan(stream, make(chan *Msg)); status.Code(err) != codes.Canceled {
+		t.Errorf("SendChan() error = %v, want Canceled", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	s := &FakeEchoServerStream{Ctx: ctx}
+	if err := Echo_BidiServerSendChan(NewFakeEcho_BidiServer(s), make(chan *Msg)); status.Code(err) != codes.Canceled {
+		t.Errorf("server SendChan() error = %v, want Canceled", err)
+	}
+}
+
+func TestClientRun(t *testing.T) {
+	c := NewEchoClientFromServer(&server{}, nil, nil)
+	ctx := context.Background()
+	send := make(chan *Msg)
+	go func(send chan<- *Msg) {
+		for i := int32(0); i < 50; i++ {
+			send <- &Msg{Nums: []int32{i}}
+		}
+		close(send)
+	}(send)
+	n := 0
+	if err := Echo_BidiClientRun(ctx, c, send, func(*Msg) error { n++; return nil }); err != nil || n != 50 {
+		t.Errorf("Run() = %v after %d responses, want nil after 50", err, n)
+	}
+
+	// An error of recv cancels the call, stopping the sending although
+	// send is never closed.
+	send = make(chan *Msg)
+	done := make(chan struct{})
+	defer close(done)
+	go func(send chan<- *Msg) {
+		for {
+			select {
+			case send <- &Msg{}:
+			case <-done:
+				return
+			}
+		}
+	}(send)
+	stop := errors.New("stop")
+	n = 0
+	err := Echo_BidiClientRun(ctx, c, send, func(*Msg) error {
+		n++
+		if n == 5 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Errorf("Run() error = %v, want %v", err, stop)
+	}
+
+	// The status of the call is returned.
+	send = make(chan *Msg, 1)
+	send <- &Msg{Text: "fail"}
+	if err := Echo_BidiClientRun(ctx, c, send, func(*Msg) error { return nil }); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Run() error = %v, want InvalidArgument", err)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := Echo_BidiClientRun(cctx, c, make(chan *Msg), func(*Msg) error { return nil }); status.Code(err) != codes.Canceled {
+		t.Errorf("Run() with a canceled context error = %v, want Canceled", err)
+	}
+}
@@ -0,0 +1 @@
+module google.golang.org/grpc
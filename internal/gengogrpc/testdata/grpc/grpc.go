@@ -0,0 +1,95 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package grpc is a stand-in for the google.golang.org/grpc package, with
+// the declarations used by the generated gRPC code, for testing the code
+// without the grpc module.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const SupportPackageIsVersion6 = true
+
+type CallOption interface {
+	before() error
+}
+
+type ClientConnInterface interface {
+	Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...CallOption) error
+	NewStream(ctx context.Context, desc *StreamDesc, method string, opts ...CallOption) (ClientStream, error)
+}
+
+type ClientStream interface {
+	Header() (metadata.MD, error)
+	Trailer() metadata.MD
+	CloseSend() error
+	Context() context.Context
+	SendMsg(m interface{}) error
+	RecvMsg(m interface{}) error
+}
+
+type ServerStream interface {
+	SetHeader(metadata.MD) error
+	SendHeader(metadata.MD) error
+	SetTrailer(metadata.MD)
+	Context() context.Context
+	SendMsg(m interface{}) error
+	RecvMsg(m interface{}) error
+}
+
+type Server struct {
+	services map[string]interface{}
+}
+
+func (s *Server) RegisterService(sd *ServiceDesc, ss interface{}) {
+	if s.services == nil {
+		s.services = make(map[string]interface{})
+	}
+	s.services[sd.ServiceName] = ss
+}
+
+type methodHandler func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor UnaryServerInterceptor) (interface{}, error)
+
+type MethodDesc struct {
+	MethodName string
+	Handler    methodHandler
+}
+
+type StreamHandler func(srv interface{}, stream ServerStream) error
+
+type StreamDesc struct {
+	StreamName    string
+	Handler       StreamHandler
+	ServerStreams bool
+	ClientStreams bool
+}
+
+type ServiceDesc struct {
+	ServiceName string
+	HandlerType interface{}
+	Methods     []MethodDesc
+	Streams     []StreamDesc
+	Metadata    interface{}
+}
+
+type UnaryServerInfo struct {
+	Server     interface{}
+	FullMethod string
+}
+
+type UnaryHandler func(ctx context.Context, req interface{}) (interface{}, error)
+
+type UnaryServerInterceptor func(ctx context.Context, req interface{}, info *UnaryServerInfo, handler UnaryHandler) (resp interface{}, err error)
+
+type StreamServerInfo struct {
+	FullMethod     string
+	IsClientStream bool
+	IsServerStream bool
+}
+
+type StreamServerInterceptor func(srv interface{}, ss ServerStream, info *StreamServerInfo, handler StreamHandler) error
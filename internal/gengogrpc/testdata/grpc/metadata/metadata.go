@@ -0,0 +1,62 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package metadata is a stand-in for the google.golang.org/grpc/metadata
+// package.
+package metadata
+
+import (
+	"context"
+	"strings"
+)
+
+type MD map[string][]string
+
+func Pairs(kv ...string) MD {
+	md := MD{}
+	for i := 0; i+1 < len(kv); i += 2 {
+		k := strings.ToLower(kv[i])
+		md[k] = append(md[k], kv[i+1])
+	}
+	return md
+}
+
+func (md MD) Get(k string) []string {
+	return md[strings.ToLower(k)]
+}
+
+func (md MD) Copy() MD {
+	return Join(md)
+}
+
+func Join(mds ...MD) MD {
+	out := MD{}
+	for _, md := range mds {
+		for k, v := range md {
+			out[k] = append(out[k], v...)
+		}
+	}
+	return out
+}
+
+type incomingKey struct{}
+type outgoingKey struct{}
+
+func NewIncomingContext(ctx context.Context, md MD) context.Context {
+	return context.WithValue(ctx, incomingKey{}, md)
+}
+
+func FromIncomingContext(ctx context.Context) (MD, bool) {
+	md, ok := ctx.Value(incomingKey{}).(MD)
+	return md, ok
+}
+
+func NewOutgoingContext(ctx context.Context, md MD) context.Context {
+	return context.WithValue(ctx, outgoingKey{}, md)
+}
+
+func FromOutgoingContext(ctx context.Context) (MD, bool) {
+	md, ok := ctx.Value(outgoingKey{}).(MD)
+	return md, ok
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package status is a stand-in for the google.golang.org/grpc/status package.
+package status
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+)
+
+type Status struct {
+	code    codes.Code
+	message string
+}
+
+func New(c codes.Code, msg string) *Status {
+	return &Status{code: c, message: msg}
+}
+
+func (s *Status) Code() codes.Code { return s.code }
+func (s *Status) Message() string  { return s.message }
+
+func (s *Status) Err() error {
+	if s.code == codes.OK {
+		return nil
+	}
+	return &statusError{s}
+}
+
+type statusError struct {
+	s *Status
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("rpc error: code = %v desc = %s", e.s.code, e.s.message)
+}
+
+func Error(c codes.Code, msg string) error {
+	return New(c, msg).Err()
+}
+
+func Errorf(c codes.Code, format string, a ...interface{}) error {
+	return Error(c, fmt.Sprintf(format, a...))
+}
+
+func FromError(err error) (s *Status, ok bool) {
+	if err == nil {
+		return New(codes.OK, ""), true
+	}
+	if se, ok := err.(*statusError); ok {
+		return se.s, true
+	}
+	return New(codes.Unknown, err.Error()), false
+}
+
+func Convert(err error) *Status {
+	s, _ := FromError(err)
+	return s
+}
+
+func Code(err error) codes.Code {
+	return Convert(err).Code()
+}
@@ -0,0 +1,444 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gengohttp contains the HTTP/JSON transport code generator.
+//
+// For each service, it generates an http.Handler serving an implementation
+// of the service and a client calling it, using the httprpc package.
+// Methods are routed as given by their google.api.http annotations,
+// or else at POST /package.Service/Method.
+package gengohttp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/internal/gengoservice"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	contextPackage = protogen.GoImportPath("context")
+	httpPackage    = protogen.GoImportPath("net/http")
+	protoPackage   = protogen.GoImportPath("github.com/golang/protobuf/proto")
+	httprpcPackage = protogen.GoImportPath("github.com/golang/protobuf/httprpc")
+)
+
+// transport describes the signatures of the methods of the HTTP clients and
+// servers.
+var transport = gengoservice.Transport{
+	ClientStream: "HTTPClient",
+	ServerStream: "HTTPServer",
+}
+
+// httpRuleField is the field number of the google.api.http extension
+// of google.protobuf.MethodOptions.
+const httpRuleField = 72295728
+
+// GenerateFile generates a _http.pb.go file containing the HTTP/JSON
+// transport of the services.
+func GenerateFile(gen *protogen.Plugin, file *protogen.File) *protogen.GeneratedFile {
+	if len(file.Services) == 0 {
+		return nil
+	}
+	filename := file.GeneratedFilenamePrefix + "_http.pb.go"
+	g := gen.NewGeneratedFile(filename, file.GoImportPath)
+	g.P("// Code generated by protoc-gen-go. DO NOT EDIT.")
+	g.P()
+	g.P("package ", file.GoPackageName)
+	g.P()
+	GenerateFileContent(gen, file, g)
+	return g
+}
+
+// GenerateFileContent generates the HTTP/JSON transport of the services,
+// excluding the package statement.
+func GenerateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+	for _, service := range file.Services {
+		genService(gen, file, g, service)
+	}
+}
+
+// httpMethods returns the methods of a service served over HTTP:
+// the unary and server-streaming methods.
+func httpMethods(service *protogen.Service) (methods, skipped []*protogen.Method) {
+	for _, method := range service.Methods {
+		if method.Desc.IsStreamingClient() {
+			skipped = append(skipped, method)
+		} else {
+			methods = append(methods, method)
+		}
+	}
+	return methods, skipped
+}
+
+func genService(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, service *protogen.Service) {
+	methods, skipped := httpMethods(service)
+	deprecated := service.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated()
+
+	// Routes.
+	for _, method := range methods {
+		routes, err := methodRoutes(method)
+		if err != nil {
+			gen.Error(err)
+			return
+		}
+		g.P("var ", routesVar(method), " = []", httprpcPackage.Ident("Route"), "{")
+		for _, r := range routes {
+			lit := "{Method: " + strconv.Quote(r.method) + ", Pattern: " + strconv.Quote(r.pattern)
+			if r.body != "" {
+				lit += ", Body: " + strconv.Quote(r.body)
+			}
+			if r.responseBody != "" {
+				lit += ", ResponseBody: " + strconv.Quote(r.responseBody)
+			}
+			g.P(lit, "},")
+		}
+		g.P("}")
+		g.P()
+	}
+
+	// Server interface.
+	serverType := service.GoName + "HTTPServer"
+	g.P("// ", serverType, " is the server API for ", service.GoName, " service served over HTTP")
+	g.P("// by New", service.GoName, "HTTPHandler.")
+	genSkippedComment(g, skipped)
+	if deprecated {
+		g.P("//")
+		g.P(gengoservice.DeprecationComment)
+	}
+	g.Annotate(serverType, service.Location)
+	g.P("type ", serverType, " interface {")
+	for _, method := range methods {
+		g.Annotate(serverType+"."+method.GoName, method.Location)
+		if method.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated() {
+			g.P(gengoservice.DeprecationComment)
+		}
+		g.P(method.Comments.Leading, transport.ServerSignature(g, method))
+	}
+	g.P("}")
+	g.P()
+
+	// Handler.
+	g.P("// New", service.GoName, "HTTPHandler returns an ", httpPackage.Ident("Handler"), " serving srv at the routes of its methods.")
+	if deprecated {
+		g.P("//")
+		g.P(gengoservice.DeprecationComment)
+	}
+	g.P("func New", service.GoName, "HTTPHandler(srv ", serverType, ") ", httpPackage.Ident("Handler"), " {")
+	g.P("mux := ", httprpcPackage.Ident("NewServeMux"), "()")
+	for _, method := range methods {
+		newRequest := "func() " + g.QualifiedGoIdent(protoPackage.Ident("Message")) + " { return new(" + g.QualifiedGoIdent(method.Input.GoIdent) + ") }"
+		if !method.Desc.IsStreamingServer() {
+			g.P("mux.HandleUnary(", routesVar(method), ", ", newRequest, ", func(ctx ", contextPackage.Ident("Context"), ", req ", protoPackage.Ident("Message"), ") (", protoPackage.Ident("Message"), ", error) {")
+			g.P("return srv.", method.GoName, "(ctx, req.(*", method.Input.GoIdent, "))")
+			g.P("})")
+		} else {
+			g.P("mux.HandleServerStream(", routesVar(method), ", ", newRequest, ", func(req ", protoPackage.Ident("Message"), ", stream ", httprpcPackage.Ident("ServerStream"), ") error {")
+			g.P("return srv.", method.GoName, "(req.(*", method.Input.GoIdent, "), &", gengoservice.Unexport(service.GoName), method.GoName, "HTTPServer{stream})")
+			g.P("})")
+		}
+	}
+	g.P("return mux")
+	g.P("}")
+	g.P()
+
+	for _, method := range methods {
+		if !method.Desc.IsStreamingServer() {
+			continue
+		}
+		streamIface := service.GoName + "_" + method.GoName + "HTTPServer"
+		streamType := gengoservice.Unexport(service.GoName) + method.GoName + "HTTPServer"
+		g.P("type ", streamIface, " interface {")
+		g.P("Send(*", method.Output.GoIdent, ") error")
+		g.P(httprpcPackage.Ident("ServerStream"))
+		g.P("}")
+		g.P()
+		g.P("type ", streamType, " struct {")
+		g.P(httprpcPackage.Ident("ServerStream"))
+		g.P("}")
+		g.P()
+		g.P("func (x *", streamType, ") Send(m *", method.Output.GoIdent, ") error {")
+		g.P("return x.ServerStream.SendMsg(m)")
+		g.P("}")
+		g.P()
+	}
+
+	// Client interface.
+	clientName := service.GoName + "HTTPClient"
+	g.P("// ", clientName, " is the client API for ", service.GoName, " service called over HTTP.")
+	genSkippedComment(g, skipped)
+	if deprecated {
+		g.P("//")
+		g.P(gengoservice.DeprecationComment)
+	}
+	g.Annotate(clientName, service.Location)
+	g.P("type ", clientName, " interface {")
+	for _, method := range methods {
+		g.Annotate(clientName+"."+method.GoName, method.Location)
+		if method.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated() {
+			g.P(gengoservice.DeprecationComment)
+		}
+		g.P(method.Comments.Leading, transport.ClientSignature(g, method))
+	}
+	g.P("}")
+	g.P()
+
+	clientType := gengoservice.Unexport(clientName)
+	g.P("type ", clientType, " struct {")
+	g.P("c *", httprpcPackage.Ident("Client"))
+	g.P("}")
+	g.P()
+	g.P("// New", clientName, " returns a ", clientName, " calling the methods with c,")
+	g.P("// at the first route of each method.")
+	if deprecated {
+		g.P("//")
+		g.P(gengoservice.DeprecationComment)
+	}
+	g.P("func New", clientName, "(c *", httprpcPackage.Ident("Client"), ") ", clientName, " {")
+	g.P("return &", clientType, "{c}")
+	g.P("}")
+	g.P()
+	for _, method := range methods {
+		if method.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated() {
+			g.P(gengoservice.DeprecationComment)
+		}
+		g.P("func (c *", clientType, ") ", transport.ClientSignature(g, method), " {")
+		if !method.Desc.IsStreamingServer() {
+			g.P("out := new(", method.Output.GoIdent, ")")
+			g.P("if err := c.c.Invoke(ctx, ", routesVar(method), "[0], in, out); err != nil {")
+			g.P("return nil, err")
+			g.P("}")
+			g.P("return out, nil")
+			g.P("}")
+			g.P()
+			continue
+		}
+		streamIface := service.GoName + "_" + method.GoName + "HTTPClient"
+		streamType := gengoservice.Unexport(service.GoName) + method.GoName + "HTTPClient"
+		g.P("stream, err := c.c.NewStream(ctx, ", routesVar(method), "[0], in)")
+		g.P("if err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("return &", streamType, "{stream}, nil")
+		g.P("}")
+		g.P()
+		g.P("type ", streamIface, " interface {")
+		g.P("Recv() (*", method.Output.GoIdent, ", error)")
+		g.P(httprpcPackage.Ident("ClientStream"))
+		g.P("}")
+		g.P()
+		g.P("type ", streamType, " struct {")
+		g.P(httprpcPackage.Ident("ClientStream"))
+		g.P("}")
+		g.P()
+		g.P("func (x *", streamType, ") Recv() (*", method.Output.GoIdent, ", error) {")
+		g.P("m := new(", method.Output.GoIdent, ")")
+		g.P("if err := x.ClientStream.RecvMsg(m); err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("return m, nil")
+		g.P("}")
+		g.P()
+	}
+}
+
+func genSkippedComment(g *protogen.GeneratedFile, skipped []*protogen.Method) {
+	if len(skipped) == 0 {
+		return
+	}
+	var names []string
+	for _, method := range skipped {
+		names = append(names, method.GoName)
+	}
+	g.P("//")
+	if len(names) == 1 {
+		g.P("// The method ", names[0], " streams requests and is not supported over HTTP.")
+	} else {
+		g.P("// The methods ", strings.Join(names, ", "), " stream requests and are not supported over HTTP.")
+	}
+}
+
+func routesVar(method *protogen.Method) string {
+	return "_" + method.Parent.GoName + "_" + method.GoName + "_httpRoutes"
+}
+
+// A route is an HTTP route of a method.
+type route struct {
+	method, pattern    string
+	body, responseBody string
+}
+
+// methodRoutes returns the routes of a method, given by its google.api.http
+// annotation if any.
+func methodRoutes(method *protogen.Method) ([]route, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(method.Desc.Options())
+	if err != nil {
+		return nil, err
+	}
+	var rule []byte
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		if num == httpRuleField && typ == protowire.BytesType {
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			rule = append(rule, v...) // repeated occurrences are merged
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	if rule == nil {
+		return []route{{
+			method:  "POST",
+			pattern: fmt.Sprintf("/%s/%s", method.Parent.Desc.FullName(), method.Desc.Name()),
+			body:    "*",
+		}}, nil
+	}
+	routes, err := parseHTTPRule(rule, true)
+	if err != nil {
+		return nil, fmt.Errorf("%v: invalid google.api.http option: %v", method.Desc.FullName(), err)
+	}
+	for _, r := range routes {
+		if err := checkRoute(method, r); err != nil {
+			return nil, fmt.Errorf("%v: invalid google.api.http option: %v", method.Desc.FullName(), err)
+		}
+	}
+	return routes, nil
+}
+
+// parseHTTPRule parses an encoded google.api.HttpRule, returning its route
+// followed by those of its additional bindings.
+func parseHTTPRule(b []byte, top bool) ([]route, error) {
+	var r route
+	var additional []route
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch num {
+		case 2:
+			r.method, r.pattern = "GET", string(v)
+		case 3:
+			r.method, r.pattern = "PUT", string(v)
+		case 4:
+			r.method, r.pattern = "POST", string(v)
+		case 5:
+			r.method, r.pattern = "DELETE", string(v)
+		case 6:
+			r.method, r.pattern = "PATCH", string(v)
+		case 7:
+			r.body = string(v)
+		case 8:
+			kind, path, err := parseCustomPattern(v)
+			if err != nil {
+				return nil, err
+			}
+			r.method, r.pattern = kind, path
+		case 11:
+			if !top {
+				return nil, fmt.Errorf("nested additional_bindings")
+			}
+			rs, err := parseHTTPRule(v, false)
+			if err != nil {
+				return nil, err
+			}
+			additional = append(additional, rs...)
+		case 12:
+			r.responseBody = string(v)
+		}
+	}
+	if r.pattern == "" {
+		return nil, fmt.Errorf("no pattern")
+	}
+	return append([]route{r}, additional...), nil
+}
+
+// parseCustomPattern parses an encoded google.api.CustomHttpPattern.
+func parseCustomPattern(b []byte) (kind, path string, err error) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return "", "", protowire.ParseError(n)
+		}
+		b = b[n:]
+		if typ == protowire.BytesType && (num == 1 || num == 2) {
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return "", "", protowire.ParseError(n)
+			}
+			b = b[n:]
+			if num == 1 {
+				kind = string(v)
+			} else {
+				path = string(v)
+			}
+			continue
+		}
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		if n < 0 {
+			return "", "", protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return kind, path, nil
+}
+
+// checkRoute reports whether the body fields of a route are singular
+// message fields of the request and response, as httprpc requires.
+func checkRoute(method *protogen.Method, r route) error {
+	if !strings.HasPrefix(r.pattern, "/") {
+		return fmt.Errorf("path %q does not start with /", r.pattern)
+	}
+	check := func(m *protogen.Message, name string) error {
+		for _, f := range m.Fields {
+			if string(f.Desc.Name()) == name {
+				if f.Message == nil || f.Desc.IsList() || f.Desc.IsMap() {
+					return fmt.Errorf("body field %q of %v is not a singular message", name, m.Desc.FullName())
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("%v has no field %q", m.Desc.FullName(), name)
+	}
+	if r.body != "" && r.body != "*" {
+		if err := check(method.Input, r.body); err != nil {
+			return err
+		}
+	}
+	if r.responseBody != "" {
+		if err := check(method.Output, r.responseBody); err != nil {
+			return err
+		}
+	}
+	return nil
+}
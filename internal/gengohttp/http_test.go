@@ -0,0 +1,247 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengohttp
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/protoparse"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// sources holds the parts of the google.api.http annotation used by the
+// tests, and test files annotated with it.
+var sources = map[string]string{
+	"google/api/annotations.proto": `syntax = "proto3";
+
+package google.api;
+
+option go_package = "google.golang.org/genproto/googleapis/api/annotations";
+
+import "google/api/http.proto";
+import "google/protobuf/descriptor.proto";
+
+extend google.protobuf.MethodOptions {
+  HttpRule http = 72295728;
+}
+`,
+	"google/api/http.proto": `syntax = "proto3";
+
+package google.api;
+
+option go_package = "google.golang.org/genproto/googleapis/api/annotations";
+
+message HttpRule {
+  string selector = 1;
+  oneof pattern {
+    string get = 2;
+    string put = 3;
+    string post = 4;
+    string delete = 5;
+    string patch = 6;
+    CustomHttpPattern custom = 8;
+  }
+  string body = 7;
+  string response_body = 12;
+  repeated HttpRule additional_bindings = 11;
+}
+
+message CustomHttpPattern {
+  string kind = 1;
+  string path = 2;
+}
+`,
+	"test/library.proto": `syntax = "proto3";
+
+package test;
+
+import "google/api/annotations.proto";
+
+option go_package = "example.com/test";
+
+message Book {
+  string name = 1;
+}
+
+message GetBookRequest {
+  string name = 1;
+}
+
+message UpdateBookRequest {
+  Book book = 1;
+}
+
+message ListBooksResponse {
+  repeated Book books = 1;
+  Book first = 2;
+}
+
+service Library {
+  rpc GetBook(GetBookRequest) returns (Book) {
+    option (google.api.http) = {
+      get: "/v1/{name=shelves/*/books/*}"
+      additional_bindings { get: "/v1/books/{name}" }
+      additional_bindings { custom { kind: "HEAD" path: "/v1/{name=shelves/*/books/*}" } }
+    };
+  }
+  rpc UpdateBook(UpdateBookRequest) returns (Book) {
+    option (google.api.http) = { patch: "/v1/{book.name=shelves/*/books/*}" body: "book" };
+  }
+  rpc ListBooks(GetBookRequest) returns (stream ListBooksResponse) {
+    option (google.api.http) = { post: "/v1/books:list" body: "*" response_body: "first" };
+  }
+  rpc Upload(stream Book) returns (Book);
+  rpc Chat(stream Book) returns (stream Book);
+}
+`,
+	"test/deprecated.proto": `syntax = "proto3";
+
+package test;
+
+option go_package = "example.com/test";
+
+message Empty {}
+
+service Old {
+  option deprecated = true;
+  rpc Get(Empty) returns (Empty);
+}
+`,
+	"test/invalid.proto": `syntax = "proto3";
+
+package test;
+
+import "google/api/annotations.proto";
+
+option go_package = "example.com/test";
+
+message Empty {}
+
+service Invalid {
+  rpc Get(Empty) returns (Empty) {
+    option (google.api.http) = { post: "/v1/get" body: "missing" };
+  }
+}
+`,
+}
+
+func generate(t *testing.T, name string) (string, error) {
+	p := &protoparse.Parser{
+		Accessor: func(name string) (io.ReadCloser, error) {
+			src, ok := sources[name]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return ioutil.NopCloser(strings.NewReader(src)), nil
+		},
+	}
+	req, err := p.CodeGeneratorRequest("", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range gen.Files {
+		if f.Generate {
+			GenerateFile(gen, f)
+		}
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		return "", errors.New(resp.GetError())
+	}
+	if len(resp.File) != 1 {
+		t.Fatalf("generated %d files, want 1", len(resp.File))
+	}
+	return resp.File[0].GetContent(), nil
+}
+
+func TestRoutes(t *testing.T) {
+	got, err := generate(t, "test/library.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`var _Library_GetBook_httpRoutes = []httprpc.Route{
+	{Method: "GET", Pattern: "/v1/{name=shelves/*/books/*}"},
+	{Method: "GET", Pattern: "/v1/books/{name}"},
+	{Method: "HEAD", Pattern: "/v1/{name=shelves/*/books/*}"},
+}`,
+		`var _Library_UpdateBook_httpRoutes = []httprpc.Route{
+	{Method: "PATCH", Pattern: "/v1/{book.name=shelves/*/books/*}", Body: "book"},
+}`,
+		`var _Library_ListBooks_httpRoutes = []httprpc.Route{
+	{Method: "POST", Pattern: "/v1/books:list", Body: "*", ResponseBody: "first"},
+}`,
+		"// The methods Upload, Chat stream requests and are not supported over HTTP.\n",
+		"ListBooks(*GetBookRequest, Library_ListBooksHTTPServer) error\n",
+		"ListBooks(ctx context.Context, in *GetBookRequest) (Library_ListBooksHTTPClient, error)\n",
+	}
+	for _, w := range want {
+		if !strings.Contains(got, w) {
+			t.Errorf("generated code does not contain:\n%s\ngot:\n%s", w, got)
+		}
+	}
+	for _, method := range []string{"Upload", "Chat"} {
+		if strings.Contains(got, "_Library_"+method+"_httpRoutes") {
+			t.Errorf("generated code has routes for client-streaming method %s", method)
+		}
+	}
+}
+
+func TestDeprecated(t *testing.T) {
+	got, err := generate(t, "test/deprecated.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The deprecation is a paragraph of its own, after the doc comment.
+	want := []string{
+		"// NewOldHTTPHandler returns an http.Handler serving srv at the routes of its methods.\n//\n// Deprecated: Do not use.\nfunc NewOldHTTPHandler(",
+		"// at the first route of each method.\n//\n// Deprecated: Do not use.\nfunc NewOldHTTPClient(",
+	}
+	for _, w := range want {
+		if !strings.Contains(got, w) {
+			t.Errorf("generated code does not contain:\n%s\ngot:\n%s", w, got)
+		}
+	}
+}
+
+func TestInvalidRoute(t *testing.T) {
+	_, err := generate(t, "test/invalid.proto")
+	want := `test.Invalid.Get: invalid google.api.http option: test.Empty has no field "missing"`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("generate error = %v, want %q", err, want)
+	}
+}
+
+func TestParseHTTPRule(t *testing.T) {
+	// A rule without a pattern, and one whose additional binding
+	// has bindings of its own.
+	tests := []struct {
+		rule    []byte
+		wantErr string
+	}{
+		{[]byte{0x3a, 0x01, '*'}, "no pattern"},
+		{[]byte{0x12, 0x02, '/', 'a', 0x5a, 0x08, 0x12, 0x02, '/', 'b', 0x5a, 0x02, 0x12, 0x00}, "nested additional_bindings"},
+	}
+	for _, tt := range tests {
+		_, err := parseHTTPRule(tt.rule, true)
+		var got string
+		if err != nil {
+			got = err.Error()
+		}
+		if diff := cmp.Diff(tt.wantErr, got); diff != "" {
+			t.Errorf("parseHTTPRule(%x) error mismatch (-want +got):\n%s", tt.rule, diff)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gengoservice holds the helpers shared by the code generators of
+// the transports of services, so that the gRPC and HTTP/JSON transports
+// declare the methods of a service alike.
+package gengoservice
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// DeprecationComment is the comment of the declarations generated for
+// deprecated services and methods.
+const DeprecationComment = "// Deprecated: Do not use."
+
+const contextPackage = protogen.GoImportPath("context")
+
+// Unexport returns s with its first letter lowercased.
+func Unexport(s string) string { return strings.ToLower(s[:1]) + s[1:] }
+
+// A Transport describes the types that the client and server interfaces of
+// a transport use in the signatures of their methods.
+type Transport struct {
+	// ClientStream and ServerStream are the suffixes of the names of the
+	// client and server stream types of a streaming method, which are
+	// Service_Method followed by the suffix.
+	ClientStream, ServerStream string
+
+	// CallOption is the type of the options of the client methods,
+	// which take none if it is zero.
+	CallOption protogen.GoIdent
+}
+
+// ClientSignature returns the signature of a method in the client
+// interface of its service.
+func (t Transport) ClientSignature(g *protogen.GeneratedFile, method *protogen.Method) string {
+	s := method.GoName + "(ctx " + g.QualifiedGoIdent(contextPackage.Ident("Context"))
+	if !method.Desc.IsStreamingClient() {
+		s += ", in *" + g.QualifiedGoIdent(method.Input.GoIdent)
+	}
+	if t.CallOption.GoName != "" {
+		s += ", opts ..." + g.QualifiedGoIdent(t.CallOption)
+	}
+	s += ") ("
+	if !method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer() {
+		s += "*" + g.QualifiedGoIdent(method.Output.GoIdent)
+	} else {
+		s += method.Parent.GoName + "_" + method.GoName + t.ClientStream
+	}
+	s += ", error)"
+	return s
+}
+
+// ServerSignature returns the signature of a method in the server
+// interface of its service.
+func (t Transport) ServerSignature(g *protogen.GeneratedFile, method *protogen.Method) string {
+	var reqArgs []string
+	ret := "error"
+	if !method.Desc.IsStreamingClient() && !method.Desc.IsStreamingServer() {
+		reqArgs = append(reqArgs, g.QualifiedGoIdent(contextPackage.Ident("Context")))
+		ret = "(*" + g.QualifiedGoIdent(method.Output.GoIdent) + ", error)"
+	}
+	if !method.Desc.IsStreamingClient() {
+		reqArgs = append(reqArgs, "*"+g.QualifiedGoIdent(method.Input.GoIdent))
+	}
+	if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
+		reqArgs = append(reqArgs, method.Parent.GoName+"_"+method.GoName+t.ServerStream)
+	}
+	return method.GoName + "(" + strings.Join(reqArgs, ", ") + ") " + ret
+}
@@ -0,0 +1,421 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gengovalidate contains the generator of the Validate methods of
+// messages.
+//
+// For each message, it generates a Validate method checking the rules given
+// to its fields by the options of validate/validate.proto without protobuf
+// reflection, and validating the messages it holds with the validate
+// package. The violations are those reported by validate.Reflect.
+package gengovalidate
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/validate"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	mathPackage     = protogen.GoImportPath("math")
+	regexpPackage   = protogen.GoImportPath("regexp")
+	sortPackage     = protogen.GoImportPath("sort")
+	utf8Package     = protogen.GoImportPath("unicode/utf8")
+	validatePackage = protogen.GoImportPath("github.com/golang/protobuf/validate")
+)
+
+// GenerateFileContent generates the Validate methods of the messages,
+// excluding the package statement.
+func GenerateFileContent(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+	for _, message := range messages(file.Messages) {
+		if !hasValidate(message) {
+			continue
+		}
+		rules := make(map[*protogen.Field]*validate.FieldRules)
+		for _, field := range message.Fields {
+			r, err := validate.Rules(field.Desc)
+			if err != nil {
+				gen.Error(err)
+				return
+			}
+			if r == nil {
+				r = new(validate.FieldRules)
+			}
+			rules[field] = r
+		}
+		c := validateGen{g: g, message: message}
+		c.genValidate(rules)
+	}
+}
+
+// messages returns the messages and the nested messages, in order.
+func messages(ms []*protogen.Message) []*protogen.Message {
+	var all []*protogen.Message
+	for _, m := range ms {
+		all = append(all, m)
+		all = append(all, messages(m.Messages)...)
+	}
+	return all
+}
+
+// hasValidate reports whether the Validate method of a message is generated.
+// Map entries have none, nor have messages with fields named Validate, which
+// are validated by protobuf reflection.
+func hasValidate(message *protogen.Message) bool {
+	if message.Desc.IsMapEntry() {
+		return false
+	}
+	for _, field := range message.Fields {
+		if field.GoName == "Validate" {
+			return false
+		}
+	}
+	for _, oneof := range message.Oneofs {
+		if oneof.GoName == "Validate" {
+			return false
+		}
+	}
+	return true
+}
+
+type validateGen struct {
+	g       *protogen.GeneratedFile
+	message *protogen.Message
+	field   *protogen.Field // the field whose checks are generated
+
+	// patterns are the names and expressions of the compiled patterns
+	// used by the method.
+	patterns [][2]string
+}
+
+func (c *validateGen) genValidate(rules map[*protogen.Field]*validate.FieldRules) {
+	g := c.g
+	g.P("// Validate reports the violations of the rules given to the fields of x by")
+	g.P("// the options of validate/validate.proto, and of the messages held by x,")
+	g.P("// as a validate.Error.")
+	g.P("func (x *", c.message.GoIdent.GoName, ") Validate() error {")
+	g.P("if x == nil {")
+	g.P("return nil")
+	g.P("}")
+	g.P("var v ", validatePackage.Ident("Error"))
+	for _, field := range c.message.Fields {
+		if field.Desc.IsWeak() {
+			continue
+		}
+		c.genField(field, rules[field])
+	}
+	g.P("if len(v) == 0 {")
+	g.P("return nil")
+	g.P("}")
+	g.P("return v")
+	g.P("}")
+	g.P()
+	for _, p := range c.patterns {
+		g.P("var ", p[0], " = ", regexpPackage.Ident("MustCompile"), "(", strconv.Quote(p[1]), ")")
+		g.P()
+	}
+}
+
+func (c *validateGen) genField(field *protogen.Field, r *validate.FieldRules) {
+	g := c.g
+	c.field = field
+	x := "x." + field.GoName
+	path := strconv.Quote(string(field.Desc.Name()))
+	if oneof := field.Oneof; oneof != nil && !oneof.Desc.IsSynthetic() {
+		if !hasValueChecks(field, r) {
+			return
+		}
+		g.P("if w, ok := x.", oneof.GoName, ".(*", field.GoIdent, "); ok {")
+		c.genValue(field, r, "w."+field.GoName, path)
+		g.P("}")
+		return
+	}
+	if r.GetRequired() {
+		g.P("if ", c.missing(field, x), " {")
+		c.genViolation(path, "is required")
+		g.P("}")
+	}
+	switch {
+	case field.Desc.IsList():
+		c.genItems(x, path, r)
+		if r.GetUnique() {
+			g.P("if len(", x, ") > 1 {")
+			key := goType(g, field)
+			elem := "e"
+			if field.Desc.Kind() == protoreflect.BytesKind {
+				key, elem = "string", "string(e)"
+			}
+			g.P("seen := make(map[", key, "]bool, len(", x, "))")
+			g.P("for _, e := range ", x, " {")
+			g.P("if seen[", elem, "] {")
+			c.genViolation(path, "must have unique items")
+			g.P("break")
+			g.P("}")
+			g.P("seen[", elem, "] = true")
+			g.P("}")
+			g.P("}")
+		}
+		if hasValueChecks(field, r) {
+			g.P("for i, e := range ", x, " {")
+			c.genValue(field, r, "e", g.QualifiedGoIdent(validatePackage.Ident("Index"))+"("+path+", i)")
+			g.P("}")
+		}
+	case field.Desc.IsMap():
+		c.genItems(x, path, r)
+		key, val := field.Message.Fields[0], field.Message.Fields[1]
+		if hasValueChecks(val, r) {
+			g.P("if len(", x, ") > 0 {")
+			g.P("keys := make([]", goType(g, key), ", 0, len(", x, "))")
+			g.P("for k := range ", x, " {")
+			g.P("keys = append(keys, k)")
+			g.P("}")
+			if key.Desc.Kind() == protoreflect.BoolKind {
+				g.P(sortPackage.Ident("Slice"), "(keys, func(i, j int) bool { return !keys[i] && keys[j] })")
+			} else {
+				g.P(sortPackage.Ident("Slice"), "(keys, func(i, j int) bool { return keys[i] < keys[j] })")
+			}
+			g.P("for _, k := range keys {")
+			g.P("e := ", x, "[k]")
+			c.genValue(val, r, "e", g.QualifiedGoIdent(validatePackage.Ident("Key"))+"("+path+", k)")
+			g.P("}")
+			g.P("}")
+		}
+	case field.Message != nil:
+		c.genValue(field, r, x, path)
+	case field.Desc.HasPresence():
+		if !hasValueChecks(field, r) {
+			return
+		}
+		g.P("if ", x, " != nil {")
+		if field.Desc.Kind() == protoreflect.BytesKind {
+			c.genValue(field, r, x, path)
+		} else {
+			c.genValue(field, r, "*"+x, path)
+		}
+		g.P("}")
+	default:
+		c.genValue(field, r, x, path)
+	}
+}
+
+// missing returns the condition of a field without its required value,
+// which is that of protobuf reflection reporting it as unset.
+func (c *validateGen) missing(field *protogen.Field, x string) string {
+	switch {
+	case field.Desc.IsList() || field.Desc.IsMap():
+		return "len(" + x + ") == 0"
+	case field.Message != nil || field.Desc.HasPresence():
+		return x + " == nil"
+	}
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "!" + x
+	case protoreflect.StringKind:
+		return x + ` == ""`
+	case protoreflect.BytesKind:
+		return "len(" + x + ") == 0"
+	case protoreflect.FloatKind:
+		return x + " == 0 && !" + c.g.QualifiedGoIdent(mathPackage.Ident("Signbit")) + "(float64(" + x + "))"
+	case protoreflect.DoubleKind:
+		return x + " == 0 && !" + c.g.QualifiedGoIdent(mathPackage.Ident("Signbit")) + "(" + x + ")"
+	}
+	return x + " == 0"
+}
+
+// genItems generates the checks of the number of elements of a repeated or
+// map field.
+func (c *validateGen) genItems(x, path string, r *validate.FieldRules) {
+	if r.MinItems != nil {
+		c.g.P("if uint64(len(", x, ")) < ", *r.MinItems, " {")
+		c.genViolation(path, "must have at least "+strconv.FormatUint(*r.MinItems, 10)+" items")
+		c.g.P("}")
+	}
+	if r.MaxItems != nil {
+		c.g.P("if uint64(len(", x, ")) > ", *r.MaxItems, " {")
+		c.genViolation(path, "must have at most "+strconv.FormatUint(*r.MaxItems, 10)+" items")
+		c.g.P("}")
+	}
+}
+
+// hasValueChecks reports whether the values of a field, or the elements or
+// map values described by field, are checked.
+func hasValueChecks(field *protogen.Field, r *validate.FieldRules) bool {
+	if field.Message != nil {
+		return true
+	}
+	return r.MinInt != nil || r.MaxInt != nil || r.MinFloat != nil || r.MaxFloat != nil ||
+		r.MinLen != nil || r.MaxLen != nil || r.Pattern != nil || r.GetDefinedOnly()
+}
+
+// genValue generates the checks of a value v of the current field, or of
+// its elements or map values described by field, at the path given by the
+// expression path.
+func (c *validateGen) genValue(field *protogen.Field, r *validate.FieldRules, v, path string) {
+	g := c.g
+	switch field.Desc.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if r.MinDuration != nil || r.MaxDuration != nil || r.MinTimestamp != nil || r.MaxTimestamp != nil {
+			g.P("if ", v, " != nil {")
+			c.genTimeBounds(r, v, path)
+			g.P("}")
+		}
+		g.P("if err := ", validatePackage.Ident("AppendMessage"), "(&v, ", path, ", ", v, "); err != nil {")
+		g.P("return err")
+		g.P("}")
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if r.MinInt != nil {
+			g.P("if ", v, " < ", *r.MinInt, " {")
+			c.genViolation(path, "must be at least "+strconv.FormatInt(*r.MinInt, 10))
+			g.P("}")
+		}
+		if r.MaxInt != nil {
+			g.P("if ", v, " > ", *r.MaxInt, " {")
+			c.genViolation(path, "must be at most "+strconv.FormatInt(*r.MaxInt, 10))
+			g.P("}")
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if field.Desc.Kind() == protoreflect.FloatKind {
+			v = "float64(" + v + ")"
+		}
+		if r.MinFloat != nil {
+			g.P("if !(", v, " >= ", formatFloat(*r.MinFloat), ") {")
+			c.genViolation(path, "must be at least "+formatFloat(*r.MinFloat))
+			g.P("}")
+		}
+		if r.MaxFloat != nil {
+			g.P("if !(", v, " <= ", formatFloat(*r.MaxFloat), ") {")
+			c.genViolation(path, "must be at most "+formatFloat(*r.MaxFloat))
+			g.P("}")
+		}
+	case protoreflect.StringKind:
+		c.genLen(r, func() string {
+			return "uint64(" + g.QualifiedGoIdent(utf8Package.Ident("RuneCountInString")) + "(" + v + "))"
+		}, path, "characters")
+		if r.Pattern != nil {
+			name := "pattern_" + c.message.GoIdent.GoName + "_" + c.field.GoName
+			c.patterns = append(c.patterns, [2]string{name, *r.Pattern})
+			g.P("if !", name, ".MatchString(", v, ") {")
+			c.genViolation(path, "must match the pattern "+strconv.Quote(*r.Pattern))
+			g.P("}")
+		}
+	case protoreflect.BytesKind:
+		c.genLen(r, func() string { return "uint64(len(" + v + "))" }, path, "bytes")
+	case protoreflect.EnumKind:
+		if r.GetDefinedOnly() {
+			var numbers []interface{}
+			seen := make(map[protoreflect.EnumNumber]bool)
+			for _, value := range field.Enum.Values {
+				if n := value.Desc.Number(); !seen[n] {
+					seen[n] = true
+					if len(numbers) > 0 {
+						numbers = append(numbers, ", ")
+					}
+					numbers = append(numbers, int32(n))
+				}
+			}
+			g.P("switch ", v, " {")
+			g.P(append([]interface{}{"case "}, append(numbers, ":")...)...)
+			g.P("default:")
+			c.genViolation(path, "must be a defined enum value")
+			g.P("}")
+		}
+	}
+}
+
+// genLen generates the checks of the length of a string or bytes value,
+// counted in unit. The expression of the length is given by length, called
+// only if there are checks so that it imports nothing otherwise.
+func (c *validateGen) genLen(r *validate.FieldRules, length func() string, path, unit string) {
+	if r.MinLen == nil && r.MaxLen == nil {
+		return
+	}
+	g := c.g
+	n := length()
+	switch {
+	case r.MinLen != nil && r.MaxLen != nil:
+		g.P("if n := ", n, "; n < ", *r.MinLen, " {")
+		c.genViolation(path, "must be at least "+strconv.FormatUint(*r.MinLen, 10)+" "+unit+" long")
+		g.P("} else if n > ", *r.MaxLen, " {")
+		c.genViolation(path, "must be at most "+strconv.FormatUint(*r.MaxLen, 10)+" "+unit+" long")
+		g.P("}")
+	case r.MinLen != nil:
+		g.P("if ", n, " < ", *r.MinLen, " {")
+		c.genViolation(path, "must be at least "+strconv.FormatUint(*r.MinLen, 10)+" "+unit+" long")
+		g.P("}")
+	case r.MaxLen != nil:
+		g.P("if ", n, " > ", *r.MaxLen, " {")
+		c.genViolation(path, "must be at most "+strconv.FormatUint(*r.MaxLen, 10)+" "+unit+" long")
+		g.P("}")
+	}
+}
+
+// genTimeBounds generates the checks of the bounds of a Duration or
+// Timestamp value v.
+func (c *validateGen) genTimeBounds(r *validate.FieldRules, v, path string) {
+	type bound struct {
+		seconds     int64
+		nanos       int32
+		op          string
+		description string
+	}
+	var bounds []bound
+	if b := r.MinDuration; b != nil {
+		bounds = append(bounds, bound{b.GetSeconds(), b.GetNanos(), "<", "must be at least " + b.AsDuration().String()})
+	}
+	if b := r.MaxDuration; b != nil {
+		bounds = append(bounds, bound{b.GetSeconds(), b.GetNanos(), ">", "must be at most " + b.AsDuration().String()})
+	}
+	if b := r.MinTimestamp; b != nil {
+		bounds = append(bounds, bound{b.GetSeconds(), b.GetNanos(), "<", "must be at or after " + b.AsTime().Format(time.RFC3339Nano)})
+	}
+	if b := r.MaxTimestamp; b != nil {
+		bounds = append(bounds, bound{b.GetSeconds(), b.GetNanos(), ">", "must be at or before " + b.AsTime().Format(time.RFC3339Nano)})
+	}
+	for _, b := range bounds {
+		c.g.P("if ", v, ".Seconds ", b.op, " ", b.seconds, " || ", v, ".Seconds == ", b.seconds, " && ", v, ".Nanos ", b.op, " ", b.nanos, " {")
+		c.genViolation(path, b.description)
+		c.g.P("}")
+	}
+}
+
+// genViolation generates the addition of a violation of the value at the
+// path given by the expression path.
+func (c *validateGen) genViolation(path, description string) {
+	c.g.P("v = append(v, ", validatePackage.Ident("Violation"), "{Field: ", path, ", Description: ", strconv.Quote(description), "})")
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func goType(g *protogen.GeneratedFile, field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(field.Enum.GoIdent)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "[]byte"
+	}
+	return "*" + g.QualifiedGoIdent(field.Message.GoIdent)
+}
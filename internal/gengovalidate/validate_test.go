@@ -0,0 +1,100 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gengovalidate
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/protoparse"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const validateHeader = `syntax = "proto3";
+
+package test;
+
+import "validate/validate.proto";
+
+option go_package = "example.com/test";
+`
+
+// generate generates the Validate methods of a file, and returns the
+// generated code or the error of the generation.
+func generate(t *testing.T, src string) (string, string) {
+	p := &protoparse.Parser{
+		Accessor: func(name string) (io.ReadCloser, error) {
+			if name == "test/test.proto" {
+				return ioutil.NopCloser(strings.NewReader(validateHeader + src)), nil
+			}
+			return os.Open(filepath.Join("..", "..", name))
+		},
+	}
+	req, err := p.CodeGeneratorRequest("", "test/test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := gen.FilesByPath["test/test.proto"]
+	g := gen.NewGeneratedFile("test.pb.go", file.GoImportPath)
+	g.P("package ", file.GoPackageName)
+	GenerateFileContent(gen, file, g)
+	resp := gen.Response()
+	if resp.Error != nil {
+		return "", resp.GetError()
+	}
+	return resp.File[0].GetContent(), ""
+}
+
+func TestValidateMethods(t *testing.T) {
+	content, errMsg := generate(t, `
+message A {
+  message B {
+    map<string, A> c = 1;
+  }
+  string validate_name = 1;
+}
+message D {
+  string validate = 1 [(golang.protobuf.validate.rules).required = true];
+}
+message E {
+  oneof validate {
+    int32 f = 1;
+  }
+}
+`)
+	if errMsg != "" {
+		t.Fatal(errMsg)
+	}
+	for _, want := range []string{"func (x *A) Validate() error", "func (x *A_B) Validate() error"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated code does not contain %q", want)
+		}
+	}
+	// No string field has length rules, which would count its characters.
+	for _, notWant := range []string{"func (x *A_B_CEntry) Validate", "func (x *D) Validate", "func (x *E) Validate", `"unicode/utf8"`} {
+		if strings.Contains(content, notWant) {
+			t.Errorf("generated code contains %q", notWant)
+		}
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	_, errMsg := generate(t, `
+message A {
+  int32 b = 1 [(golang.protobuf.validate.rules).pattern = "x"];
+}
+`)
+	if want := "validate: invalid rules of field test.A.b: pattern applies to string fields"; errMsg != want {
+		t.Errorf("error = %q, want %q", errMsg, want)
+	}
+}
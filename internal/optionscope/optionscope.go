@@ -0,0 +1,68 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package optionscope tells whether the custom options declared by this
+// module apply to a file.
+//
+// The extensions of serviceconfig, fastpath and validate use field numbers
+// that are not allocated to them by the global extension registry, so an
+// unrelated extension of another schema may have the same number. protoc
+// only accepts an option if its extension is declared in the file using it
+// or in a file it imports, so an option of a file that does not see the
+// extension of this module is that other extension, and is ignored.
+package optionscope
+
+import "google.golang.org/protobuf/reflect/protoreflect"
+
+// Visible reports whether the extension xt can be used as an option in the
+// file fd: whether it is declared in fd or in a file fd imports, directly
+// or through public imports. xt must be declared at the top level of its
+// file.
+func Visible(fd protoreflect.FileDescriptor, xt protoreflect.ExtensionType) bool {
+	if fd == nil {
+		return false
+	}
+	xd := xt.TypeDescriptor()
+	if declares(fd, xd) {
+		return true
+	}
+	seen := make(map[string]bool)
+	imports := fd.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if exports(imports.Get(i).FileDescriptor, xd, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// exports reports whether the extension xd is declared in the file fd
+// or in a file it publicly imports.
+func exports(fd protoreflect.FileDescriptor, xd protoreflect.ExtensionDescriptor, seen map[string]bool) bool {
+	if seen[fd.Path()] {
+		return false
+	}
+	seen[fd.Path()] = true
+	if declares(fd, xd) {
+		return true
+	}
+	imports := fd.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		if imp := imports.Get(i); imp.IsPublic && exports(imp.FileDescriptor, xd, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// declares reports whether the file fd declares the top-level extension xd.
+// The extension is found by name rather than by the path of its file, which
+// depends on the import paths the files were compiled with.
+func declares(fd protoreflect.FileDescriptor, xd protoreflect.ExtensionDescriptor) bool {
+	if fd.Package() != xd.ParentFile().Package() {
+		return false
+	}
+	d := fd.Extensions().ByName(xd.Name())
+	return d != nil && d.Number() == xd.Number() && d.ContainingMessage().FullName() == xd.ContainingMessage().FullName()
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package optionscope
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestVisible(t *testing.T) {
+	fds := new(descpb.FileDescriptorSet)
+	for _, s := range []string{
+		`name: "google/protobuf/descriptor.proto" package: "google.protobuf"
+		 message_type { name: "FieldOptions" extension_range { start: 1000 end: 536870912 } }`,
+		`name: "ext.proto" package: "ext" dependency: "google/protobuf/descriptor.proto"
+		 extension { name: "opt" number: 64172 label: LABEL_OPTIONAL type: TYPE_BOOL extendee: ".google.protobuf.FieldOptions" }`,
+		`name: "public.proto" dependency: "ext.proto" public_dependency: 0`,
+		`name: "private.proto" dependency: "ext.proto"`,
+		`name: "direct.proto" dependency: "ext.proto"`,
+		`name: "through_public.proto" dependency: "public.proto"`,
+		`name: "through_private.proto" dependency: "private.proto"`,
+		`name: "other.proto" package: "other" dependency: "google/protobuf/descriptor.proto"
+		 extension { name: "opt" number: 64172 label: LABEL_OPTIONAL type: TYPE_BOOL extendee: ".google.protobuf.FieldOptions" }`,
+	} {
+		fd := new(descpb.FileDescriptorProto)
+		if err := proto.UnmarshalText(s, fd); err != nil {
+			t.Fatal(err)
+		}
+		fds.File = append(fds.File, fd)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ext, err := files.FindFileByPath("ext.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	xt := dynamicpb.NewExtensionType(ext.Extensions().Get(0))
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"ext.proto", true},
+		{"direct.proto", true},
+		{"public.proto", true},
+		{"through_public.proto", true},
+		{"through_private.proto", false},
+		{"other.proto", false},
+	}
+	for _, tt := range tests {
+		fd, err := files.FindFileByPath(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Visible(fd, xt); got != tt.want {
+			t.Errorf("Visible(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
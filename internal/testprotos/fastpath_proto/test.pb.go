@@ -0,0 +1,4376 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        (unknown)
+// source: fastpath_proto/test.proto
+
+package fastpath_proto
+
+import (
+	bytes "bytes"
+	fastpath "github.com/golang/protobuf/fastpath"
+	proto "github.com/golang/protobuf/proto"
+	protowire "google.golang.org/protobuf/encoding/protowire"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoiface "google.golang.org/protobuf/runtime/protoiface"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	math "math"
+	reflect "reflect"
+	sort "sort"
+	sync "sync"
+	utf8 "unicode/utf8"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Color int32
+
+const (
+	Color_COLOR_UNSPECIFIED Color = 0
+	Color_RED               Color = 1
+	Color_GREEN             Color = 2
+)
+
+// Enum value maps for Color.
+var (
+	Color_name = map[int32]string{
+		0: "COLOR_UNSPECIFIED",
+		1: "RED",
+		2: "GREEN",
+	}
+	Color_value = map[string]int32{
+		"COLOR_UNSPECIFIED": 0,
+		"RED":               1,
+		"GREEN":             2,
+	}
+)
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
+}
+
+func (x Color) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Color) Descriptor() protoreflect.EnumDescriptor {
+	return file_fastpath_proto_test_proto_enumTypes[0].Descriptor()
+}
+
+func (Color) Type() protoreflect.EnumType {
+	return &file_fastpath_proto_test_proto_enumTypes[0]
+}
+
+func (x Color) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Color.Descriptor instead.
+func (Color) EnumDescriptor() ([]byte, []int) {
+	return file_fastpath_proto_test_proto_rawDescGZIP(), []int{0}
+}
+
+type Scalars struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	B        bool     `protobuf:"varint,1,opt,name=b,proto3" json:"b,omitempty"`
+	I32      int32    `protobuf:"varint,2,opt,name=i32,proto3" json:"i32,omitempty"`
+	S32      int32    `protobuf:"zigzag32,3,opt,name=s32,proto3" json:"s32,omitempty"`
+	Sf32     int32    `protobuf:"fixed32,4,opt,name=sf32,proto3" json:"sf32,omitempty"`
+	U32      uint32   `protobuf:"varint,5,opt,name=u32,proto3" json:"u32,omitempty"`
+	F32      uint32   `protobuf:"fixed32,6,opt,name=f32,proto3" json:"f32,omitempty"`
+	I64      int64    `protobuf:"varint,7,opt,name=i64,proto3" json:"i64,omitempty"`
+	S64      int64    `protobuf:"zigzag64,8,opt,name=s64,proto3" json:"s64,omitempty"`
+	Sf64     int64    `protobuf:"fixed64,9,opt,name=sf64,proto3" json:"sf64,omitempty"`
+	U64      uint64   `protobuf:"varint,10,opt,name=u64,proto3" json:"u64,omitempty"`
+	F64      uint64   `protobuf:"fixed64,11,opt,name=f64,proto3" json:"f64,omitempty"`
+	Fl       float32  `protobuf:"fixed32,12,opt,name=fl,proto3" json:"fl,omitempty"`
+	Db       float64  `protobuf:"fixed64,13,opt,name=db,proto3" json:"db,omitempty"`
+	S        string   `protobuf:"bytes,14,opt,name=s,proto3" json:"s,omitempty"`
+	By       []byte   `protobuf:"bytes,15,opt,name=by,proto3" json:"by,omitempty"`
+	Color    Color    `protobuf:"varint,16,opt,name=color,proto3,enum=fastpath_test.Color" json:"color,omitempty"`
+	OptI32   *int32   `protobuf:"varint,17,opt,name=opt_i32,json=optI32,proto3,oneof" json:"opt_i32,omitempty"`
+	OptDb    *float64 `protobuf:"fixed64,18,opt,name=opt_db,json=optDb,proto3,oneof" json:"opt_db,omitempty"`
+	OptS     *string  `protobuf:"bytes,19,opt,name=opt_s,json=optS,proto3,oneof" json:"opt_s,omitempty"`
+	OptBy    []byte   `protobuf:"bytes,20,opt,name=opt_by,json=optBy,proto3,oneof" json:"opt_by,omitempty"`
+	OptColor *Color   `protobuf:"varint,21,opt,name=opt_color,json=optColor,proto3,enum=fastpath_test.Color,oneof" json:"opt_color,omitempty"`
+}
+
+func (x *Scalars) Reset() {
+	*x = Scalars{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fastpath_proto_test_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Scalars) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scalars) ProtoMessage() {}
+
+func (x *Scalars) ProtoReflect() protoreflect.Message {
+	mi := &file_fastpath_proto_test_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scalars.ProtoReflect.Descriptor instead.
+func (*Scalars) Descriptor() ([]byte, []int) {
+	return file_fastpath_proto_test_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Scalars) GetB() bool {
+	if x != nil {
+		return x.B
+	}
+	return false
+}
+
+func (x *Scalars) GetI32() int32 {
+	if x != nil {
+		return x.I32
+	}
+	return 0
+}
+
+func (x *Scalars) GetS32() int32 {
+	if x != nil {
+		return x.S32
+	}
+	return 0
+}
+
+func (x *Scalars) GetSf32() int32 {
+	if x != nil {
+		return x.Sf32
+	}
+	return 0
+}
+
+func (x *Scalars) GetU32() uint32 {
+	if x != nil {
+		return x.U32
+	}
+	return 0
+}
+
+func (x *Scalars) GetF32() uint32 {
+	if x != nil {
+		return x.F32
+	}
+	return 0
+}
+
+func (x *Scalars) GetI64() int64 {
+	if x != nil {
+		return x.I64
+	}
+	return 0
+}
+
+func (x *Scalars) GetS64() int64 {
+	if x != nil {
+		return x.S64
+	}
+	return 0
+}
+
+func (x *Scalars) GetSf64() int64 {
+	if x != nil {
+		return x.Sf64
+	}
+	return 0
+}
+
+func (x *Scalars) GetU64() uint64 {
+	if x != nil {
+		return x.U64
+	}
+	return 0
+}
+
+func (x *Scalars) GetF64() uint64 {
+	if x != nil {
+		return x.F64
+	}
+	return 0
+}
+
+func (x *Scalars) GetFl() float32 {
+	if x != nil {
+		return x.Fl
+	}
+	return 0
+}
+
+func (x *Scalars) GetDb() float64 {
+	if x != nil {
+		return x.Db
+	}
+	return 0
+}
+
+func (x *Scalars) GetS() string {
+	if x != nil {
+		return x.S
+	}
+	return ""
+}
+
+func (x *Scalars) GetBy() []byte {
+	if x != nil {
+		return x.By
+	}
+	return nil
+}
+
+func (x *Scalars) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+func (x *Scalars) GetOptI32() int32 {
+	if x != nil && x.OptI32 != nil {
+		return *x.OptI32
+	}
+	return 0
+}
+
+func (x *Scalars) GetOptDb() float64 {
+	if x != nil && x.OptDb != nil {
+		return *x.OptDb
+	}
+	return 0
+}
+
+func (x *Scalars) GetOptS() string {
+	if x != nil && x.OptS != nil {
+		return *x.OptS
+	}
+	return ""
+}
+
+func (x *Scalars) GetOptBy() []byte {
+	if x != nil {
+		return x.OptBy
+	}
+	return nil
+}
+
+func (x *Scalars) GetOptColor() Color {
+	if x != nil && x.OptColor != nil {
+		return *x.OptColor
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+type Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scalars         *Scalars                        `protobuf:"bytes,1,opt,name=scalars,proto3" json:"scalars,omitempty"`
+	Child           *Message                        `protobuf:"bytes,2,opt,name=child,proto3" json:"child,omitempty"`
+	Duration        *durationpb.Duration            `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Legacy          *Legacy                         `protobuf:"bytes,4,opt,name=legacy,proto3" json:"legacy,omitempty"`
+	Extendable      *Extendable                     `protobuf:"bytes,5,opt,name=extendable,proto3" json:"extendable,omitempty"`
+	I32S            []int32                         `protobuf:"varint,6,rep,packed,name=i32s,proto3" json:"i32s,omitempty"`
+	Dbs             []float64                       `protobuf:"fixed64,7,rep,packed,name=dbs,proto3" json:"dbs,omitempty"`
+	Ss              []string                        `protobuf:"bytes,8,rep,name=ss,proto3" json:"ss,omitempty"`
+	Bys             [][]byte                        `protobuf:"bytes,9,rep,name=bys,proto3" json:"bys,omitempty"`
+	Colors          []Color                         `protobuf:"varint,10,rep,packed,name=colors,proto3,enum=fastpath_test.Color" json:"colors,omitempty"`
+	Children        []*Message                      `protobuf:"bytes,11,rep,name=children,proto3" json:"children,omitempty"`
+	Durations       []*durationpb.Duration          `protobuf:"bytes,12,rep,name=durations,proto3" json:"durations,omitempty"`
+	ByName          map[string]*Message             `protobuf:"bytes,13,rep,name=by_name,json=byName,proto3" json:"by_name,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BytesById       map[int64][]byte                `protobuf:"bytes,14,rep,name=bytes_by_id,json=bytesById,proto3" json:"bytes_by_id,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	DbsByFlag       map[bool]float64                `protobuf:"bytes,15,rep,name=dbs_by_flag,json=dbsByFlag,proto3" json:"dbs_by_flag,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	ColorsById      map[uint32]Color                `protobuf:"bytes,16,rep,name=colors_by_id,json=colorsById,proto3" json:"colors_by_id,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=fastpath_test.Color"`
+	DurationsByName map[string]*durationpb.Duration `protobuf:"bytes,17,rep,name=durations_by_name,json=durationsByName,proto3" json:"durations_by_name,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Types that are assignable to Kind:
+	//	*Message_OI32
+	//	*Message_OFl
+	//	*Message_OS
+	//	*Message_OBy
+	//	*Message_OChild
+	//	*Message_ODuration
+	Kind   isMessage_Kind  `protobuf_oneof:"kind"`
+	Nested *Message_Nested `protobuf:"bytes,24,opt,name=nested,proto3" json:"nested,omitempty"`
+	Lists  *Lists          `protobuf:"bytes,25,opt,name=lists,proto3" json:"lists,omitempty"`
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fastpath_proto_test_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_fastpath_proto_test_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_fastpath_proto_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Message) GetScalars() *Scalars {
+	if x != nil {
+		return x.Scalars
+	}
+	return nil
+}
+
+func (x *Message) GetChild() *Message {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+func (x *Message) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Message) GetLegacy() *Legacy {
+	if x != nil {
+		return x.Legacy
+	}
+	return nil
+}
+
+func (x *Message) GetExtendable() *Extendable {
+	if x != nil {
+		return x.Extendable
+	}
+	return nil
+}
+
+func (x *Message) GetI32S() []int32 {
+	if x != nil {
+		return x.I32S
+	}
+	return nil
+}
+
+func (x *Message) GetDbs() []float64 {
+	if x != nil {
+		return x.Dbs
+	}
+	return nil
+}
+
+func (x *Message) GetSs() []string {
+	if x != nil {
+		return x.Ss
+	}
+	return nil
+}
+
+func (x *Message) GetBys() [][]byte {
+	if x != nil {
+		return x.Bys
+	}
+	return nil
+}
+
+func (x *Message) GetColors() []Color {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+func (x *Message) GetChildren() []*Message {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *Message) GetDurations() []*durationpb.Duration {
+	if x != nil {
+		return x.Durations
+	}
+	return nil
+}
+
+func (x *Message) GetByName() map[string]*Message {
+	if x != nil {
+		return x.ByName
+	}
+	return nil
+}
+
+func (x *Message) GetBytesById() map[int64][]byte {
+	if x != nil {
+		return x.BytesById
+	}
+	return nil
+}
+
+func (x *Message) GetDbsByFlag() map[bool]float64 {
+	if x != nil {
+		return x.DbsByFlag
+	}
+	return nil
+}
+
+func (x *Message) GetColorsById() map[uint32]Color {
+	if x != nil {
+		return x.ColorsById
+	}
+	return nil
+}
+
+func (x *Message) GetDurationsByName() map[string]*durationpb.Duration {
+	if x != nil {
+		return x.DurationsByName
+	}
+	return nil
+}
+
+func (m *Message) GetKind() isMessage_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+func (x *Message) GetOI32() int32 {
+	if x, ok := x.GetKind().(*Message_OI32); ok {
+		return x.OI32
+	}
+	return 0
+}
+
+func (x *Message) GetOFl() float32 {
+	if x, ok := x.GetKind().(*Message_OFl); ok {
+		return x.OFl
+	}
+	return 0
+}
+
+func (x *Message) GetOS() string {
+	if x, ok := x.GetKind().(*Message_OS); ok {
+		return x.OS
+	}
+	return ""
+}
+
+func (x *Message) GetOBy() []byte {
+	if x, ok := x.GetKind().(*Message_OBy); ok {
+		return x.OBy
+	}
+	return nil
+}
+
+func (x *Message) GetOChild() *Message {
+	if x, ok := x.GetKind().(*Message_OChild); ok {
+		return x.OChild
+	}
+	return nil
+}
+
+func (x *Message) GetODuration() *durationpb.Duration {
+	if x, ok := x.GetKind().(*Message_ODuration); ok {
+		return x.ODuration
+	}
+	return nil
+}
+
+func (x *Message) GetNested() *Message_Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *Message) GetLists() *Lists {
+	if x != nil {
+		return x.Lists
+	}
+	return nil
+}
+
+type isMessage_Kind interface {
+	isMessage_Kind()
+}
+
+type Message_OI32 struct {
+	OI32 int32 `protobuf:"varint,18,opt,name=o_i32,json=oI32,proto3,oneof"`
+}
+
+type Message_OFl struct {
+	OFl float32 `protobuf:"fixed32,19,opt,name=o_fl,json=oFl,proto3,oneof"`
+}
+
+type Message_OS struct {
+	OS string `protobuf:"bytes,20,opt,name=o_s,json=oS,proto3,oneof"`
+}
+
+type Message_OBy struct {
+	OBy []byte `protobuf:"bytes,21,opt,name=o_by,json=oBy,proto3,oneof"`
+}
+
+type Message_OChild struct {
+	OChild *Message `protobuf:"bytes,22,opt,name=o_child,json=oChild,proto3,oneof"`
+}
+
+type Message_ODuration struct {
+	ODuration *durationpb.Duration `protobuf:"bytes,23,opt,name=o_duration,json=oDuration,proto3,oneof"`
+}
+
+func (*Message_OI32) isMessage_Kind() {}
+
+func (*Message_OFl) isMessage_Kind() {}
+
+func (*Message_OS) isMessage_Kind() {}
+
+func (*Message_OBy) isMessage_Kind() {}
+
+func (*Message_OChild) isMessage_Kind() {}
+
+func (*Message_ODuration) isMessage_Kind() {}
+
+type Lists struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bs           []bool              `protobuf:"varint,1,rep,packed,name=bs,proto3" json:"bs,omitempty"`
+	I32S         []int32             `protobuf:"varint,2,rep,packed,name=i32s,proto3" json:"i32s,omitempty"`
+	S32S         []int32             `protobuf:"zigzag32,3,rep,packed,name=s32s,proto3" json:"s32s,omitempty"`
+	Sf32S        []int32             `protobuf:"fixed32,4,rep,packed,name=sf32s,proto3" json:"sf32s,omitempty"`
+	U32S         []uint32            `protobuf:"varint,5,rep,packed,name=u32s,proto3" json:"u32s,omitempty"`
+	F32S         []uint32            `protobuf:"fixed32,6,rep,packed,name=f32s,proto3" json:"f32s,omitempty"`
+	I64S         []int64             `protobuf:"varint,7,rep,packed,name=i64s,proto3" json:"i64s,omitempty"`
+	S64S         []int64             `protobuf:"zigzag64,8,rep,packed,name=s64s,proto3" json:"s64s,omitempty"`
+	Sf64S        []int64             `protobuf:"fixed64,9,rep,packed,name=sf64s,proto3" json:"sf64s,omitempty"`
+	U64S         []uint64            `protobuf:"varint,10,rep,packed,name=u64s,proto3" json:"u64s,omitempty"`
+	F64S         []uint64            `protobuf:"fixed64,11,rep,packed,name=f64s,proto3" json:"f64s,omitempty"`
+	Fls          []float32           `protobuf:"fixed32,12,rep,packed,name=fls,proto3" json:"fls,omitempty"`
+	Dbs          []float64           `protobuf:"fixed64,13,rep,packed,name=dbs,proto3" json:"dbs,omitempty"`
+	Colors       []Color             `protobuf:"varint,14,rep,packed,name=colors,proto3,enum=fastpath_test.Color" json:"colors,omitempty"`
+	UnpackedI32S []int32             `protobuf:"varint,15,rep,name=unpacked_i32s,json=unpackedI32s,proto3" json:"unpacked_i32s,omitempty"`
+	StringsByS32 map[int32]string    `protobuf:"bytes,16,rep,name=strings_by_s32,json=stringsByS32,proto3" json:"strings_by_s32,omitempty" protobuf_key:"zigzag32,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ScalarsByF64 map[uint64]*Scalars `protobuf:"bytes,17,rep,name=scalars_by_f64,json=scalarsByF64,proto3" json:"scalars_by_f64,omitempty" protobuf_key:"fixed64,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Strings      map[string]string   `protobuf:"bytes,18,rep,name=strings,proto3" json:"strings,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Far          int32               `protobuf:"varint,536870911,opt,name=far,proto3" json:"far,omitempty"`
+}
+
+func (x *Lists) Reset() {
+	*x = Lists{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fastpath_proto_test_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lists) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lists) ProtoMessage() {}
+
+func (x *Lists) ProtoReflect() protoreflect.Message {
+	mi := &file_fastpath_proto_test_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lists.ProtoReflect.Descriptor instead.
+func (*Lists) Descriptor() ([]byte, []int) {
+	return file_fastpath_proto_test_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Lists) GetBs() []bool {
+	if x != nil {
+		return x.Bs
+	}
+	return nil
+}
+
+func (x *Lists) GetI32S() []int32 {
+	if x != nil {
+		return x.I32S
+	}
+	return nil
+}
+
+func (x *Lists) GetS32S() []int32 {
+	if x != nil {
+		return x.S32S
+	}
+	return nil
+}
+
+func (x *Lists) GetSf32S() []int32 {
+	if x != nil {
+		return x.Sf32S
+	}
+	return nil
+}
+
+func (x *Lists) GetU32S() []uint32 {
+	if x != nil {
+		return x.U32S
+	}
+	return nil
+}
+
+func (x *Lists) GetF32S() []uint32 {
+	if x != nil {
+		return x.F32S
+	}
+	return nil
+}
+
+func (x *Lists) GetI64S() []int64 {
+	if x != nil {
+		return x.I64S
+	}
+	return nil
+}
+
+func (x *Lists) GetS64S() []int64 {
+	if x != nil {
+		return x.S64S
+	}
+	return nil
+}
+
+func (x *Lists) GetSf64S() []int64 {
+	if x != nil {
+		return x.Sf64S
+	}
+	return nil
+}
+
+func (x *Lists) GetU64S() []uint64 {
+	if x != nil {
+		return x.U64S
+	}
+	return nil
+}
+
+func (x *Lists) GetF64S() []uint64 {
+	if x != nil {
+		return x.F64S
+	}
+	return nil
+}
+
+func (x *Lists) GetFls() []float32 {
+	if x != nil {
+		return x.Fls
+	}
+	return nil
+}
+
+func (x *Lists) GetDbs() []float64 {
+	if x != nil {
+		return x.Dbs
+	}
+	return nil
+}
+
+func (x *Lists) GetColors() []Color {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+func (x *Lists) GetUnpackedI32S() []int32 {
+	if x != nil {
+		return x.UnpackedI32S
+	}
+	return nil
+}
+
+func (x *Lists) GetStringsByS32() map[int32]string {
+	if x != nil {
+		return x.StringsByS32
+	}
+	return nil
+}
+
+func (x *Lists) GetScalarsByF64() map[uint64]*Scalars {
+	if x != nil {
+		return x.ScalarsByF64
+	}
+	return nil
+}
+
+func (x *Lists) GetStrings() map[string]string {
+	if x != nil {
+		return x.Strings
+	}
+	return nil
+}
+
+func (x *Lists) GetFar() int32 {
+	if x != nil {
+		return x.Far
+	}
+	return 0
+}
+
+// Conflict has a field named like a generated method, and so no methods.
+type Conflict struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Clone   int32    `protobuf:"varint,1,opt,name=clone,proto3" json:"clone,omitempty"`
+	Message *Message `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Conflict) Reset() {
+	*x = Conflict{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fastpath_proto_test_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Conflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conflict) ProtoMessage() {}
+
+func (x *Conflict) ProtoReflect() protoreflect.Message {
+	mi := &file_fastpath_proto_test_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conflict.ProtoReflect.Descriptor instead.
+func (*Conflict) Descriptor() ([]byte, []int) {
+	return file_fastpath_proto_test_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Conflict) GetClone() int32 {
+	if x != nil {
+		return x.Clone
+	}
+	return 0
+}
+
+func (x *Conflict) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// Sized has a field named like a generated marshaling method, and so no
+// marshaling methods.
+type Sized struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size int32 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *Sized) Reset() {
+	*x = Sized{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fastpath_proto_test_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sized) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sized) ProtoMessage() {}
+
+func (x *Sized) ProtoReflect() protoreflect.Message {
+	mi := &file_fastpath_proto_test_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sized.ProtoReflect.Descriptor instead.
+func (*Sized) Descriptor() ([]byte, []int) {
+	return file_fastpath_proto_test_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Sized) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Nested has no marshaling methods.
+type Message_Nested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Next *Message_Nested `protobuf:"bytes,2,opt,name=next,proto3" json:"next,omitempty"`
+}
+
+func (x *Message_Nested) Reset() {
+	*x = Message_Nested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fastpath_proto_test_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Message_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message_Nested) ProtoMessage() {}
+
+func (x *Message_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_fastpath_proto_test_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message_Nested.ProtoReflect.Descriptor instead.
+func (*Message_Nested) Descriptor() ([]byte, []int) {
+	return file_fastpath_proto_test_proto_rawDescGZIP(), []int{1, 5}
+}
+
+func (x *Message_Nested) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Message_Nested) GetNext() *Message_Nested {
+	if x != nil {
+		return x.Next
+	}
+	return nil
+}
+
+var File_fastpath_proto_test_proto protoreflect.FileDescriptor
+
+var file_fastpath_proto_test_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x66, 0x61, 0x73,
+	0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x66, 0x61, 0x73, 0x74,
+	0x70, 0x61, 0x74, 0x68, 0x2f, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x9b, 0x04, 0x0a, 0x07, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x62,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x01, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x33, 0x32,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x69, 0x33, 0x32, 0x12, 0x10, 0x0a, 0x03, 0x73,
+	0x33, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28, 0x11, 0x52, 0x03, 0x73, 0x33, 0x32, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x66, 0x33, 0x32, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0f, 0x52, 0x04, 0x73, 0x66, 0x33,
+	0x32, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x33, 0x32, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
+	0x75, 0x33, 0x32, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x33, 0x32, 0x18, 0x06, 0x20, 0x01, 0x28, 0x07,
+	0x52, 0x03, 0x66, 0x33, 0x32, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x36, 0x34, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x69, 0x36, 0x34, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x36, 0x34, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x12, 0x52, 0x03, 0x73, 0x36, 0x34, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x66, 0x36,
+	0x34, 0x18, 0x09, 0x20, 0x01, 0x28, 0x10, 0x52, 0x04, 0x73, 0x66, 0x36, 0x34, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x36, 0x34, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x75, 0x36, 0x34, 0x12,
+	0x10, 0x0a, 0x03, 0x66, 0x36, 0x34, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x06, 0x52, 0x03, 0x66, 0x36,
+	0x34, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x02, 0x52, 0x02, 0x66,
+	0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x62, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x02, 0x64,
+	0x62, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x73, 0x12,
+	0x0e, 0x0a, 0x02, 0x62, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x62, 0x79, 0x12,
+	0x2a, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1c, 0x0a, 0x07, 0x6f,
+	0x70, 0x74, 0x5f, 0x69, 0x33, 0x32, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x06,
+	0x6f, 0x70, 0x74, 0x49, 0x33, 0x32, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x06, 0x6f, 0x70, 0x74,
+	0x5f, 0x64, 0x62, 0x18, 0x12, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x05, 0x6f, 0x70, 0x74,
+	0x44, 0x62, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x05, 0x6f, 0x70, 0x74, 0x5f, 0x73, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x04, 0x6f, 0x70, 0x74, 0x53, 0x88, 0x01, 0x01, 0x12,
+	0x1a, 0x0a, 0x06, 0x6f, 0x70, 0x74, 0x5f, 0x62, 0x79, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0c, 0x48,
+	0x03, 0x52, 0x05, 0x6f, 0x70, 0x74, 0x42, 0x79, 0x88, 0x01, 0x01, 0x12, 0x36, 0x0a, 0x09, 0x6f,
+	0x70, 0x74, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14,
+	0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6f, 0x72, 0x48, 0x04, 0x52, 0x08, 0x6f, 0x70, 0x74, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6f, 0x70, 0x74, 0x5f, 0x69, 0x33, 0x32, 0x42,
+	0x09, 0x0a, 0x07, 0x5f, 0x6f, 0x70, 0x74, 0x5f, 0x64, 0x62, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6f,
+	0x70, 0x74, 0x5f, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6f, 0x70, 0x74, 0x5f, 0x62, 0x79, 0x42,
+	0x0c, 0x0a, 0x0a, 0x5f, 0x6f, 0x70, 0x74, 0x5f, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0xe3, 0x0c,
+	0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x73, 0x63, 0x61,
+	0x6c, 0x61, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x61, 0x73,
+	0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61,
+	0x72, 0x73, 0x52, 0x07, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x61, 0x73,
+	0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x2d, 0x0a, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x52, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x12,
+	0x39, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74,
+	0x65, 0x73, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0a,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x33,
+	0x32, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x69, 0x33, 0x32, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x64, 0x62, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x01, 0x52, 0x03, 0x64, 0x62, 0x73,
+	0x12, 0x0e, 0x0a, 0x02, 0x73, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x73, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x62, 0x79, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03, 0x62,
+	0x79, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x14, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x73,
+	0x12, 0x32, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c,
+	0x64, 0x72, 0x65, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3b, 0x0a,
+	0x07, 0x62, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x62, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x0b, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x25, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x42, 0x79, 0x49,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x62, 0x79, 0x74, 0x65, 0x73, 0x42, 0x79, 0x49,
+	0x64, 0x12, 0x45, 0x0a, 0x0b, 0x64, 0x62, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x66, 0x6c, 0x61, 0x67,
+	0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74,
+	0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x44,
+	0x62, 0x73, 0x42, 0x79, 0x46, 0x6c, 0x61, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x64,
+	0x62, 0x73, 0x42, 0x79, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x48, 0x0a, 0x0c, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26,
+	0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x49,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x42, 0x79,
+	0x49, 0x64, 0x12, 0x57, 0x0a, 0x11, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f,
+	0x62, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
+	0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42,
+	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x05, 0x6f,
+	0x5f, 0x69, 0x33, 0x32, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x04, 0x6f, 0x49,
+	0x33, 0x32, 0x12, 0x13, 0x0a, 0x04, 0x6f, 0x5f, 0x66, 0x6c, 0x18, 0x13, 0x20, 0x01, 0x28, 0x02,
+	0x48, 0x00, 0x52, 0x03, 0x6f, 0x46, 0x6c, 0x12, 0x11, 0x0a, 0x03, 0x6f, 0x5f, 0x73, 0x18, 0x14,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x53, 0x12, 0x13, 0x0a, 0x04, 0x6f, 0x5f,
+	0x62, 0x79, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x03, 0x6f, 0x42, 0x79, 0x12,
+	0x31, 0x0a, 0x07, 0x6f, 0x5f, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06, 0x6f, 0x43, 0x68, 0x69,
+	0x6c, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x6f, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x09, 0x6f, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x35,
+	0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x06, 0x6e,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x05, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x18, 0x19,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x52, 0x05, 0x6c, 0x69, 0x73, 0x74,
+	0x73, 0x1a, 0x51, 0x0a, 0x0b, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x42, 0x79, 0x49,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x44, 0x62, 0x73, 0x42, 0x79, 0x46, 0x6c, 0x61, 0x67, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x53, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x49, 0x64, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5d, 0x0a, 0x14, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x2f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x55, 0x0a, 0x06, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52,
+	0x04, 0x6e, 0x65, 0x78, 0x74, 0x3a, 0x04, 0xd8, 0xaa, 0x1f, 0x00, 0x42, 0x06, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x22, 0xa3, 0x06, 0x0a, 0x05, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x0e, 0x0a,
+	0x02, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x08, 0x52, 0x02, 0x62, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x69, 0x33, 0x32, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x69, 0x33, 0x32,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x33, 0x32, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x11, 0x52,
+	0x04, 0x73, 0x33, 0x32, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x66, 0x33, 0x32, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0f, 0x52, 0x05, 0x73, 0x66, 0x33, 0x32, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75,
+	0x33, 0x32, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x04, 0x75, 0x33, 0x32, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x66, 0x33, 0x32, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x07, 0x52, 0x04, 0x66,
+	0x33, 0x32, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x36, 0x34, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x04, 0x69, 0x36, 0x34, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x36, 0x34, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x12, 0x52, 0x04, 0x73, 0x36, 0x34, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x66, 0x36, 0x34, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x10, 0x52, 0x05, 0x73, 0x66, 0x36, 0x34,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x36, 0x34, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x04, 0x52,
+	0x04, 0x75, 0x36, 0x34, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x36, 0x34, 0x73, 0x18, 0x0b, 0x20,
+	0x03, 0x28, 0x06, 0x52, 0x04, 0x66, 0x36, 0x34, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x6c, 0x73,
+	0x18, 0x0c, 0x20, 0x03, 0x28, 0x02, 0x52, 0x03, 0x66, 0x6c, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x64,
+	0x62, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x01, 0x52, 0x03, 0x64, 0x62, 0x73, 0x12, 0x2c, 0x0a,
+	0x06, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x14, 0x2e,
+	0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x0d, 0x75,
+	0x6e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x69, 0x33, 0x32, 0x73, 0x18, 0x0f, 0x20, 0x03,
+	0x28, 0x05, 0x42, 0x02, 0x10, 0x00, 0x52, 0x0c, 0x75, 0x6e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x64,
+	0x49, 0x33, 0x32, 0x73, 0x12, 0x4c, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x5f,
+	0x62, 0x79, 0x5f, 0x73, 0x33, 0x32, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x66,
+	0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x53, 0x33, 0x32, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x42, 0x79, 0x53,
+	0x33, 0x32, 0x12, 0x4c, 0x0a, 0x0e, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x73, 0x5f, 0x62, 0x79,
+	0x5f, 0x66, 0x36, 0x34, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x66, 0x61, 0x73,
+	0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x73,
+	0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x73, 0x42, 0x79, 0x46, 0x36, 0x34, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0c, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x73, 0x42, 0x79, 0x46, 0x36, 0x34,
+	0x12, 0x3b, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x14, 0x0a,
+	0x03, 0x66, 0x61, 0x72, 0x18, 0xff, 0xff, 0xff, 0xff, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x66, 0x61, 0x72, 0x1a, 0x3f, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x42, 0x79,
+	0x53, 0x33, 0x32, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x11, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x57, 0x0a, 0x11, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x73, 0x42,
+	0x79, 0x46, 0x36, 0x34, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x06, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66, 0x61, 0x73,
+	0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61,
+	0x72, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3a, 0x0a,
+	0x0c, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x52, 0x0a, 0x08, 0x43, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6c, 0x6f, 0x6e, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x66,
+	0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x1b, 0x0a,
+	0x05, 0x53, 0x69, 0x7a, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x2a, 0x32, 0x0a, 0x05, 0x43, 0x6f,
+	0x6c, 0x6f, 0x72, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f, 0x4c, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x52, 0x45, 0x45, 0x4e, 0x10, 0x02, 0x42, 0x43,
+	0xd8, 0xaa, 0x1f, 0x01, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_fastpath_proto_test_proto_rawDescOnce sync.Once
+	file_fastpath_proto_test_proto_rawDescData = file_fastpath_proto_test_proto_rawDesc
+)
+
+func file_fastpath_proto_test_proto_rawDescGZIP() []byte {
+	file_fastpath_proto_test_proto_rawDescOnce.Do(func() {
+		file_fastpath_proto_test_proto_rawDescData = protoimpl.X.CompressGZIP(file_fastpath_proto_test_proto_rawDescData)
+	})
+	return file_fastpath_proto_test_proto_rawDescData
+}
+
+var file_fastpath_proto_test_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_fastpath_proto_test_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_fastpath_proto_test_proto_goTypes = []interface{}{
+	(Color)(0),                  // 0: fastpath_test.Color
+	(*Scalars)(nil),             // 1: fastpath_test.Scalars
+	(*Message)(nil),             // 2: fastpath_test.Message
+	(*Lists)(nil),               // 3: fastpath_test.Lists
+	(*Conflict)(nil),            // 4: fastpath_test.Conflict
+	(*Sized)(nil),               // 5: fastpath_test.Sized
+	nil,                         // 6: fastpath_test.Message.ByNameEntry
+	nil,                         // 7: fastpath_test.Message.BytesByIdEntry
+	nil,                         // 8: fastpath_test.Message.DbsByFlagEntry
+	nil,                         // 9: fastpath_test.Message.ColorsByIdEntry
+	nil,                         // 10: fastpath_test.Message.DurationsByNameEntry
+	(*Message_Nested)(nil),      // 11: fastpath_test.Message.Nested
+	nil,                         // 12: fastpath_test.Lists.StringsByS32Entry
+	nil,                         // 13: fastpath_test.Lists.ScalarsByF64Entry
+	nil,                         // 14: fastpath_test.Lists.StringsEntry
+	(*durationpb.Duration)(nil), // 15: google.protobuf.Duration
+	(*Legacy)(nil),              // 16: fastpath_test.Legacy
+	(*Extendable)(nil),          // 17: fastpath_test.Extendable
+}
+var file_fastpath_proto_test_proto_depIdxs = []int32{
+	0,  // 0: fastpath_test.Scalars.color:type_name -> fastpath_test.Color
+	0,  // 1: fastpath_test.Scalars.opt_color:type_name -> fastpath_test.Color
+	1,  // 2: fastpath_test.Message.scalars:type_name -> fastpath_test.Scalars
+	2,  // 3: fastpath_test.Message.child:type_name -> fastpath_test.Message
+	15, // 4: fastpath_test.Message.duration:type_name -> google.protobuf.Duration
+	16, // 5: fastpath_test.Message.legacy:type_name -> fastpath_test.Legacy
+	17, // 6: fastpath_test.Message.extendable:type_name -> fastpath_test.Extendable
+	0,  // 7: fastpath_test.Message.colors:type_name -> fastpath_test.Color
+	2,  // 8: fastpath_test.Message.children:type_name -> fastpath_test.Message
+	15, // 9: fastpath_test.Message.durations:type_name -> google.protobuf.Duration
+	6,  // 10: fastpath_test.Message.by_name:type_name -> fastpath_test.Message.ByNameEntry
+	7,  // 11: fastpath_test.Message.bytes_by_id:type_name -> fastpath_test.Message.BytesByIdEntry
+	8,  // 12: fastpath_test.Message.dbs_by_flag:type_name -> fastpath_test.Message.DbsByFlagEntry
+	9,  // 13: fastpath_test.Message.colors_by_id:type_name -> fastpath_test.Message.ColorsByIdEntry
+	10, // 14: fastpath_test.Message.durations_by_name:type_name -> fastpath_test.Message.DurationsByNameEntry
+	2,  // 15: fastpath_test.Message.o_child:type_name -> fastpath_test.Message
+	15, // 16: fastpath_test.Message.o_duration:type_name -> google.protobuf.Duration
+	11, // 17: fastpath_test.Message.nested:type_name -> fastpath_test.Message.Nested
+	3,  // 18: fastpath_test.Message.lists:type_name -> fastpath_test.Lists
+	0,  // 19: fastpath_test.Lists.colors:type_name -> fastpath_test.Color
+	12, // 20: fastpath_test.Lists.strings_by_s32:type_name -> fastpath_test.Lists.StringsByS32Entry
+	13, // 21: fastpath_test.Lists.scalars_by_f64:type_name -> fastpath_test.Lists.ScalarsByF64Entry
+	14, // 22: fastpath_test.Lists.strings:type_name -> fastpath_test.Lists.StringsEntry
+	2,  // 23: fastpath_test.Conflict.message:type_name -> fastpath_test.Message
+	2,  // 24: fastpath_test.Message.ByNameEntry.value:type_name -> fastpath_test.Message
+	0,  // 25: fastpath_test.Message.ColorsByIdEntry.value:type_name -> fastpath_test.Color
+	15, // 26: fastpath_test.Message.DurationsByNameEntry.value:type_name -> google.protobuf.Duration
+	11, // 27: fastpath_test.Message.Nested.next:type_name -> fastpath_test.Message.Nested
+	1,  // 28: fastpath_test.Lists.ScalarsByF64Entry.value:type_name -> fastpath_test.Scalars
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_fastpath_proto_test_proto_init() }
+func file_fastpath_proto_test_proto_init() {
+	if File_fastpath_proto_test_proto != nil {
+		return
+	}
+	file_fastpath_proto_test2_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_fastpath_proto_test_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Scalars); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fastpath_proto_test_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fastpath_proto_test_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Lists); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fastpath_proto_test_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Conflict); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fastpath_proto_test_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sized); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fastpath_proto_test_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message_Nested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_fastpath_proto_test_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_fastpath_proto_test_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Message_OI32)(nil),
+		(*Message_OFl)(nil),
+		(*Message_OS)(nil),
+		(*Message_OBy)(nil),
+		(*Message_OChild)(nil),
+		(*Message_ODuration)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_fastpath_proto_test_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_fastpath_proto_test_proto_goTypes,
+		DependencyIndexes: file_fastpath_proto_test_proto_depIdxs,
+		EnumInfos:         file_fastpath_proto_test_proto_enumTypes,
+		MessageInfos:      file_fastpath_proto_test_proto_msgTypes,
+	}.Build()
+	File_fastpath_proto_test_proto = out.File
+	file_fastpath_proto_test_proto_rawDesc = nil
+	file_fastpath_proto_test_proto_goTypes = nil
+	file_fastpath_proto_test_proto_depIdxs = nil
+}
+
+// Equal reports whether x and y are equal, as by proto.Equal, without the
+// use of protobuf reflection. A nil message is only equal to nil.
+func (x *Scalars) Equal(y *Scalars) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	if x.B != y.B {
+		return false
+	}
+	if x.I32 != y.I32 {
+		return false
+	}
+	if x.S32 != y.S32 {
+		return false
+	}
+	if x.Sf32 != y.Sf32 {
+		return false
+	}
+	if x.U32 != y.U32 {
+		return false
+	}
+	if x.F32 != y.F32 {
+		return false
+	}
+	if x.I64 != y.I64 {
+		return false
+	}
+	if x.S64 != y.S64 {
+		return false
+	}
+	if x.Sf64 != y.Sf64 {
+		return false
+	}
+	if x.U64 != y.U64 {
+		return false
+	}
+	if x.F64 != y.F64 {
+		return false
+	}
+	if !fastpath.EqualImplicitFloat64(float64(x.Fl), float64(y.Fl)) {
+		return false
+	}
+	if !fastpath.EqualImplicitFloat64(x.Db, y.Db) {
+		return false
+	}
+	if x.S != y.S {
+		return false
+	}
+	if !bytes.Equal(x.By, y.By) {
+		return false
+	}
+	if x.Color != y.Color {
+		return false
+	}
+	if (x.OptI32 == nil) != (y.OptI32 == nil) || x.OptI32 != nil && *x.OptI32 != *y.OptI32 {
+		return false
+	}
+	if (x.OptDb == nil) != (y.OptDb == nil) || x.OptDb != nil && !fastpath.EqualFloat64(*x.OptDb, *y.OptDb) {
+		return false
+	}
+	if (x.OptS == nil) != (y.OptS == nil) || x.OptS != nil && *x.OptS != *y.OptS {
+		return false
+	}
+	if (x.OptBy == nil) != (y.OptBy == nil) || !bytes.Equal(x.OptBy, y.OptBy) {
+		return false
+	}
+	if (x.OptColor == nil) != (y.OptColor == nil) || x.OptColor != nil && *x.OptColor != *y.OptColor {
+		return false
+	}
+	return fastpath.EqualUnknown(x.unknownFields, y.unknownFields)
+}
+
+// Clone returns a deep copy of x, as by proto.Clone, without the use of
+// protobuf reflection.
+func (x *Scalars) Clone() *Scalars {
+	if x == nil {
+		return nil
+	}
+	y := new(Scalars)
+	y.MergeFrom(x)
+	return y
+}
+
+// MergeFrom merges src into x, as by proto.Merge, without the use of
+// protobuf reflection.
+func (x *Scalars) MergeFrom(src *Scalars) {
+	if src == nil {
+		return
+	}
+	if src.B {
+		x.B = src.B
+	}
+	if src.I32 != 0 {
+		x.I32 = src.I32
+	}
+	if src.S32 != 0 {
+		x.S32 = src.S32
+	}
+	if src.Sf32 != 0 {
+		x.Sf32 = src.Sf32
+	}
+	if src.U32 != 0 {
+		x.U32 = src.U32
+	}
+	if src.F32 != 0 {
+		x.F32 = src.F32
+	}
+	if src.I64 != 0 {
+		x.I64 = src.I64
+	}
+	if src.S64 != 0 {
+		x.S64 = src.S64
+	}
+	if src.Sf64 != 0 {
+		x.Sf64 = src.Sf64
+	}
+	if src.U64 != 0 {
+		x.U64 = src.U64
+	}
+	if src.F64 != 0 {
+		x.F64 = src.F64
+	}
+	if src.Fl != 0 {
+		x.Fl = src.Fl
+	}
+	if src.Db != 0 {
+		x.Db = src.Db
+	}
+	if src.S != "" {
+		x.S = src.S
+	}
+	if len(src.By) > 0 {
+		x.By = append([]byte{}, src.By...)
+	}
+	if src.Color != 0 {
+		x.Color = src.Color
+	}
+	if src.OptI32 != nil {
+		v := *src.OptI32
+		x.OptI32 = &v
+	}
+	if src.OptDb != nil {
+		v := *src.OptDb
+		x.OptDb = &v
+	}
+	if src.OptS != nil {
+		v := *src.OptS
+		x.OptS = &v
+	}
+	if src.OptBy != nil {
+		x.OptBy = append([]byte{}, src.OptBy...)
+	}
+	if src.OptColor != nil {
+		v := *src.OptColor
+		x.OptColor = &v
+	}
+	if len(src.unknownFields) > 0 {
+		x.unknownFields = append(x.unknownFields, src.unknownFields...)
+	}
+}
+
+// XXX_Equal is used by proto.Equal.
+func (x *Scalars) XXX_Equal(y protoiface.MessageV1) bool {
+	m, ok := y.(*Scalars)
+	return ok && x.Equal(m)
+}
+
+// XXX_Clone is used by proto.Clone.
+func (x *Scalars) XXX_Clone() protoiface.MessageV1 {
+	return x.Clone()
+}
+
+// XXX_MergeFrom is used by proto.Merge.
+func (x *Scalars) XXX_MergeFrom(src protoiface.MessageV1) {
+	if m, ok := src.(*Scalars); ok {
+		x.MergeFrom(m)
+		return
+	}
+	fastpath.MergeReflect(x, src)
+}
+
+// Size returns the size in bytes of the wire-format encoding of x, as by
+// proto.Size, without the use of protobuf reflection.
+func (x *Scalars) Size() (n int) {
+	if x == nil {
+		return 0
+	}
+	if x.B {
+		n += 2
+	}
+	if x.I32 != 0 {
+		n += 1 + protowire.SizeVarint(uint64(x.I32))
+	}
+	if x.S32 != 0 {
+		n += 1 + protowire.SizeVarint(protowire.EncodeZigZag(int64(x.S32)))
+	}
+	if x.Sf32 != 0 {
+		n += 5
+	}
+	if x.U32 != 0 {
+		n += 1 + protowire.SizeVarint(uint64(x.U32))
+	}
+	if x.F32 != 0 {
+		n += 5
+	}
+	if x.I64 != 0 {
+		n += 1 + protowire.SizeVarint(uint64(x.I64))
+	}
+	if x.S64 != 0 {
+		n += 1 + protowire.SizeVarint(protowire.EncodeZigZag(x.S64))
+	}
+	if x.Sf64 != 0 {
+		n += 9
+	}
+	if x.U64 != 0 {
+		n += 1 + protowire.SizeVarint(x.U64)
+	}
+	if x.F64 != 0 {
+		n += 9
+	}
+	if x.Fl != 0 || math.Signbit(float64(x.Fl)) {
+		n += 5
+	}
+	if x.Db != 0 || math.Signbit(x.Db) {
+		n += 9
+	}
+	if x.S != "" {
+		n += 1 + protowire.SizeBytes(len(x.S))
+	}
+	if len(x.By) > 0 {
+		n += 1 + protowire.SizeBytes(len(x.By))
+	}
+	if x.Color != 0 {
+		n += 2 + protowire.SizeVarint(uint64(x.Color))
+	}
+	if x.OptI32 != nil {
+		n += 2 + protowire.SizeVarint(uint64(*x.OptI32))
+	}
+	if x.OptDb != nil {
+		n += 10
+	}
+	if x.OptS != nil {
+		n += 2 + protowire.SizeBytes(len(*x.OptS))
+	}
+	if x.OptBy != nil {
+		n += 2 + protowire.SizeBytes(len(x.OptBy))
+	}
+	if x.OptColor != nil {
+		n += 2 + protowire.SizeVarint(uint64(*x.OptColor))
+	}
+	n += len(x.unknownFields)
+	return n
+}
+
+// Marshal returns the wire-format encoding of x, as by proto.Marshal with
+// deterministic ordering of map entries, without the use of protobuf
+// reflection.
+func (x *Scalars) Marshal() ([]byte, error) {
+	b := make([]byte, x.Size())
+	n, err := x.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[len(b)-n:], nil
+}
+
+// MarshalToSizedBuffer writes the wire-format encoding of x at the end of b,
+// which must be at least x.Size() bytes long, and returns its length.
+func (x *Scalars) MarshalToSizedBuffer(b []byte) (int, error) {
+	if x == nil {
+		return 0, nil
+	}
+	i := len(b)
+	if len(x.unknownFields) > 0 {
+		i -= len(x.unknownFields)
+		copy(b[i:], x.unknownFields)
+	}
+	if x.OptColor != nil {
+		i = fastpath.PutVarint(b, i, uint64(*x.OptColor))
+		i -= 2
+		b[i] = 0xa8
+		b[i+1] = 0x1
+	}
+	if x.OptBy != nil {
+		i = fastpath.PutBytes(b, i, x.OptBy)
+		i -= 2
+		b[i] = 0xa2
+		b[i+1] = 0x1
+	}
+	if x.OptS != nil {
+		if !utf8.ValidString(*x.OptS) {
+			return 0, fastpath.InvalidUTF8("fastpath_test.Scalars.opt_s")
+		}
+		i = fastpath.PutString(b, i, *x.OptS)
+		i -= 2
+		b[i] = 0x9a
+		b[i+1] = 0x1
+	}
+	if x.OptDb != nil {
+		i = fastpath.PutFixed64(b, i, math.Float64bits(*x.OptDb))
+		i -= 2
+		b[i] = 0x91
+		b[i+1] = 0x1
+	}
+	if x.OptI32 != nil {
+		i = fastpath.PutVarint(b, i, uint64(*x.OptI32))
+		i -= 2
+		b[i] = 0x88
+		b[i+1] = 0x1
+	}
+	if x.Color != 0 {
+		i = fastpath.PutVarint(b, i, uint64(x.Color))
+		i -= 2
+		b[i] = 0x80
+		b[i+1] = 0x1
+	}
+	if len(x.By) > 0 {
+		i = fastpath.PutBytes(b, i, x.By)
+		i--
+		b[i] = 0x7a
+	}
+	if x.S != "" {
+		if !utf8.ValidString(x.S) {
+			return 0, fastpath.InvalidUTF8("fastpath_test.Scalars.s")
+		}
+		i = fastpath.PutString(b, i, x.S)
+		i--
+		b[i] = 0x72
+	}
+	if x.Db != 0 || math.Signbit(x.Db) {
+		i = fastpath.PutFixed64(b, i, math.Float64bits(x.Db))
+		i--
+		b[i] = 0x69
+	}
+	if x.Fl != 0 || math.Signbit(float64(x.Fl)) {
+		i = fastpath.PutFixed32(b, i, math.Float32bits(x.Fl))
+		i--
+		b[i] = 0x65
+	}
+	if x.F64 != 0 {
+		i = fastpath.PutFixed64(b, i, x.F64)
+		i--
+		b[i] = 0x59
+	}
+	if x.U64 != 0 {
+		i = fastpath.PutVarint(b, i, x.U64)
+		i--
+		b[i] = 0x50
+	}
+	if x.Sf64 != 0 {
+		i = fastpath.PutFixed64(b, i, uint64(x.Sf64))
+		i--
+		b[i] = 0x49
+	}
+	if x.S64 != 0 {
+		i = fastpath.PutVarint(b, i, protowire.EncodeZigZag(x.S64))
+		i--
+		b[i] = 0x40
+	}
+	if x.I64 != 0 {
+		i = fastpath.PutVarint(b, i, uint64(x.I64))
+		i--
+		b[i] = 0x38
+	}
+	if x.F32 != 0 {
+		i = fastpath.PutFixed32(b, i, x.F32)
+		i--
+		b[i] = 0x35
+	}
+	if x.U32 != 0 {
+		i = fastpath.PutVarint(b, i, uint64(x.U32))
+		i--
+		b[i] = 0x28
+	}
+	if x.Sf32 != 0 {
+		i = fastpath.PutFixed32(b, i, uint32(x.Sf32))
+		i--
+		b[i] = 0x25
+	}
+	if x.S32 != 0 {
+		i = fastpath.PutVarint(b, i, protowire.EncodeZigZag(int64(x.S32)))
+		i--
+		b[i] = 0x18
+	}
+	if x.I32 != 0 {
+		i = fastpath.PutVarint(b, i, uint64(x.I32))
+		i--
+		b[i] = 0x10
+	}
+	if x.B {
+		i = fastpath.PutVarint(b, i, protowire.EncodeBool(x.B))
+		i--
+		b[i] = 0x8
+	}
+	return len(b) - i, nil
+}
+
+// Unmarshal merges the wire-format encoding in b into x, as by
+// proto.UnmarshalMerge, without the use of protobuf reflection. Required
+// fields are not checked.
+func (x *Scalars) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n, err := fastpath.ConsumeTag(b)
+		if err != nil {
+			return err
+		}
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.B = protowire.DecodeBool(v)
+			n += m
+		case num == 2 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.I32 = int32(v)
+			n += m
+		case num == 3 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.S32 = int32(protowire.DecodeZigZag(v & math.MaxUint32))
+			n += m
+		case num == 4 && typ == protowire.Fixed32Type:
+			v, m := protowire.ConsumeFixed32(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Sf32 = int32(v)
+			n += m
+		case num == 5 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.U32 = uint32(v)
+			n += m
+		case num == 6 && typ == protowire.Fixed32Type:
+			v, m := protowire.ConsumeFixed32(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.F32 = v
+			n += m
+		case num == 7 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.I64 = int64(v)
+			n += m
+		case num == 8 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.S64 = protowire.DecodeZigZag(v)
+			n += m
+		case num == 9 && typ == protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Sf64 = int64(v)
+			n += m
+		case num == 10 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.U64 = v
+			n += m
+		case num == 11 && typ == protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.F64 = v
+			n += m
+		case num == 12 && typ == protowire.Fixed32Type:
+			v, m := protowire.ConsumeFixed32(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Fl = math.Float32frombits(v)
+			n += m
+		case num == 13 && typ == protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Db = math.Float64frombits(v)
+			n += m
+		case num == 14 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if !utf8.Valid(v) {
+				return fastpath.InvalidUTF8("fastpath_test.Scalars.s")
+			}
+			x.S = string(v)
+			n += m
+		case num == 15 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.By = append([]byte(nil), v...)
+			n += m
+		case num == 16 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Color = Color(v)
+			n += m
+		case num == 17 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := int32(v)
+			x.OptI32 = &s
+			n += m
+		case num == 18 && typ == protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := math.Float64frombits(v)
+			x.OptDb = &s
+			n += m
+		case num == 19 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if !utf8.Valid(v) {
+				return fastpath.InvalidUTF8("fastpath_test.Scalars.opt_s")
+			}
+			s := string(v)
+			x.OptS = &s
+			n += m
+		case num == 20 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.OptBy = append([]byte{}, v...)
+			n += m
+		case num == 21 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := Color(v)
+			x.OptColor = &s
+			n += m
+		default:
+			m := protowire.ConsumeFieldValue(num, typ, b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			// As in protobuf reflection, the tag is stored in its shortest form.
+			x.unknownFields = protowire.AppendTag(x.unknownFields, num, typ)
+			x.unknownFields = append(x.unknownFields, b[n:n+m]...)
+			n += m
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// Equal reports whether x and y are equal, as by proto.Equal, without the
+// use of protobuf reflection. A nil message is only equal to nil.
+func (x *Message) Equal(y *Message) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	if (x.Scalars == nil) != (y.Scalars == nil) || x.Scalars != nil && !x.Scalars.Equal(y.Scalars) {
+		return false
+	}
+	if (x.Child == nil) != (y.Child == nil) || x.Child != nil && !x.Child.Equal(y.Child) {
+		return false
+	}
+	if (x.Duration == nil) != (y.Duration == nil) || x.Duration != nil && !proto.Equal(x.Duration, y.Duration) {
+		return false
+	}
+	if (x.Legacy == nil) != (y.Legacy == nil) || x.Legacy != nil && !proto.Equal(x.Legacy, y.Legacy) {
+		return false
+	}
+	if (x.Extendable == nil) != (y.Extendable == nil) || x.Extendable != nil && !proto.Equal(x.Extendable, y.Extendable) {
+		return false
+	}
+	if len(x.I32S) != len(y.I32S) {
+		return false
+	}
+	for i, vx := range x.I32S {
+		if vx != y.I32S[i] {
+			return false
+		}
+	}
+	if len(x.Dbs) != len(y.Dbs) {
+		return false
+	}
+	for i, vx := range x.Dbs {
+		if !fastpath.EqualFloat64(vx, y.Dbs[i]) {
+			return false
+		}
+	}
+	if len(x.Ss) != len(y.Ss) {
+		return false
+	}
+	for i, vx := range x.Ss {
+		if vx != y.Ss[i] {
+			return false
+		}
+	}
+	if len(x.Bys) != len(y.Bys) {
+		return false
+	}
+	for i, vx := range x.Bys {
+		if !bytes.Equal(vx, y.Bys[i]) {
+			return false
+		}
+	}
+	if len(x.Colors) != len(y.Colors) {
+		return false
+	}
+	for i, vx := range x.Colors {
+		if vx != y.Colors[i] {
+			return false
+		}
+	}
+	if len(x.Children) != len(y.Children) {
+		return false
+	}
+	for i, vx := range x.Children {
+		if !fastpath.EqualMessage(vx, y.Children[i]) {
+			return false
+		}
+	}
+	if len(x.Durations) != len(y.Durations) {
+		return false
+	}
+	for i, vx := range x.Durations {
+		if !fastpath.EqualMessage(vx, y.Durations[i]) {
+			return false
+		}
+	}
+	if len(x.ByName) != len(y.ByName) {
+		return false
+	}
+	for k, vx := range x.ByName {
+		if vy, ok := y.ByName[k]; !ok || !fastpath.EqualMessage(vx, vy) {
+			return false
+		}
+	}
+	if len(x.BytesById) != len(y.BytesById) {
+		return false
+	}
+	for k, vx := range x.BytesById {
+		if vy, ok := y.BytesById[k]; !ok || !bytes.Equal(vx, vy) {
+			return false
+		}
+	}
+	if len(x.DbsByFlag) != len(y.DbsByFlag) {
+		return false
+	}
+	for k, vx := range x.DbsByFlag {
+		if vy, ok := y.DbsByFlag[k]; !ok || !fastpath.EqualFloat64(vx, vy) {
+			return false
+		}
+	}
+	if len(x.ColorsById) != len(y.ColorsById) {
+		return false
+	}
+	for k, vx := range x.ColorsById {
+		if vy, ok := y.ColorsById[k]; !ok || vx != vy {
+			return false
+		}
+	}
+	if len(x.DurationsByName) != len(y.DurationsByName) {
+		return false
+	}
+	for k, vx := range x.DurationsByName {
+		if vy, ok := y.DurationsByName[k]; !ok || !fastpath.EqualMessage(vx, vy) {
+			return false
+		}
+	}
+	switch vx := x.Kind.(type) {
+	case nil:
+		if y.Kind != nil {
+			return false
+		}
+	case *Message_OI32:
+		if vy, ok := y.Kind.(*Message_OI32); !ok || vx.OI32 != vy.OI32 {
+			return false
+		}
+	case *Message_OFl:
+		if vy, ok := y.Kind.(*Message_OFl); !ok || !fastpath.EqualFloat64(float64(vx.OFl), float64(vy.OFl)) {
+			return false
+		}
+	case *Message_OS:
+		if vy, ok := y.Kind.(*Message_OS); !ok || vx.OS != vy.OS {
+			return false
+		}
+	case *Message_OBy:
+		if vy, ok := y.Kind.(*Message_OBy); !ok || !bytes.Equal(vx.OBy, vy.OBy) {
+			return false
+		}
+	case *Message_OChild:
+		if vy, ok := y.Kind.(*Message_OChild); !ok || !fastpath.EqualMessage(vx.OChild, vy.OChild) {
+			return false
+		}
+	case *Message_ODuration:
+		if vy, ok := y.Kind.(*Message_ODuration); !ok || !fastpath.EqualMessage(vx.ODuration, vy.ODuration) {
+			return false
+		}
+	}
+	if (x.Nested == nil) != (y.Nested == nil) || x.Nested != nil && !x.Nested.Equal(y.Nested) {
+		return false
+	}
+	if (x.Lists == nil) != (y.Lists == nil) || x.Lists != nil && !x.Lists.Equal(y.Lists) {
+		return false
+	}
+	return fastpath.EqualUnknown(x.unknownFields, y.unknownFields)
+}
+
+// Clone returns a deep copy of x, as by proto.Clone, without the use of
+// protobuf reflection.
+func (x *Message) Clone() *Message {
+	if x == nil {
+		return nil
+	}
+	y := new(Message)
+	y.MergeFrom(x)
+	return y
+}
+
+// MergeFrom merges src into x, as by proto.Merge, without the use of
+// protobuf reflection.
+func (x *Message) MergeFrom(src *Message) {
+	if src == nil {
+		return
+	}
+	if src.Scalars != nil {
+		if x.Scalars == nil {
+			x.Scalars = new(Scalars)
+		}
+		x.Scalars.MergeFrom(src.Scalars)
+	}
+	if src.Child != nil {
+		if x.Child == nil {
+			x.Child = new(Message)
+		}
+		x.Child.MergeFrom(src.Child)
+	}
+	if src.Duration != nil {
+		if x.Duration == nil {
+			x.Duration = new(durationpb.Duration)
+		}
+		proto.Merge(x.Duration, src.Duration)
+	}
+	if src.Legacy != nil {
+		if x.Legacy == nil {
+			x.Legacy = new(Legacy)
+		}
+		proto.Merge(x.Legacy, src.Legacy)
+	}
+	if src.Extendable != nil {
+		if x.Extendable == nil {
+			x.Extendable = new(Extendable)
+		}
+		proto.Merge(x.Extendable, src.Extendable)
+	}
+	x.I32S = append(x.I32S, src.I32S...)
+	x.Dbs = append(x.Dbs, src.Dbs...)
+	x.Ss = append(x.Ss, src.Ss...)
+	for _, v := range src.Bys {
+		x.Bys = append(x.Bys, append([]byte{}, v...))
+	}
+	x.Colors = append(x.Colors, src.Colors...)
+	for _, v := range src.Children {
+		m := new(Message)
+		m.MergeFrom(v)
+		x.Children = append(x.Children, m)
+	}
+	for _, v := range src.Durations {
+		m := new(durationpb.Duration)
+		proto.Merge(m, v)
+		x.Durations = append(x.Durations, m)
+	}
+	if len(src.ByName) > 0 {
+		if x.ByName == nil {
+			x.ByName = make(map[string]*Message, len(src.ByName))
+		}
+		for k, v := range src.ByName {
+			m := new(Message)
+			m.MergeFrom(v)
+			x.ByName[k] = m
+		}
+	}
+	if len(src.BytesById) > 0 {
+		if x.BytesById == nil {
+			x.BytesById = make(map[int64][]byte, len(src.BytesById))
+		}
+		for k, v := range src.BytesById {
+			x.BytesById[k] = append([]byte{}, v...)
+		}
+	}
+	if len(src.DbsByFlag) > 0 {
+		if x.DbsByFlag == nil {
+			x.DbsByFlag = make(map[bool]float64, len(src.DbsByFlag))
+		}
+		for k, v := range src.DbsByFlag {
+			x.DbsByFlag[k] = v
+		}
+	}
+	if len(src.ColorsById) > 0 {
+		if x.ColorsById == nil {
+			x.ColorsById = make(map[uint32]Color, len(src.ColorsById))
+		}
+		for k, v := range src.ColorsById {
+			x.ColorsById[k] = v
+		}
+	}
+	if len(src.DurationsByName) > 0 {
+		if x.DurationsByName == nil {
+			x.DurationsByName = make(map[string]*durationpb.Duration, len(src.DurationsByName))
+		}
+		for k, v := range src.DurationsByName {
+			m := new(durationpb.Duration)
+			proto.Merge(m, v)
+			x.DurationsByName[k] = m
+		}
+	}
+	switch v := src.Kind.(type) {
+	case *Message_OI32:
+		x.Kind = &Message_OI32{OI32: v.OI32}
+	case *Message_OFl:
+		x.Kind = &Message_OFl{OFl: v.OFl}
+	case *Message_OS:
+		x.Kind = &Message_OS{OS: v.OS}
+	case *Message_OBy:
+		x.Kind = &Message_OBy{OBy: append([]byte{}, v.OBy...)}
+	case *Message_OChild:
+		if xv, ok := x.Kind.(*Message_OChild); ok && xv.OChild != nil {
+			xv.OChild.MergeFrom(v.OChild)
+		} else {
+			m := new(Message)
+			m.MergeFrom(v.OChild)
+			x.Kind = &Message_OChild{OChild: m}
+		}
+	case *Message_ODuration:
+		if xv, ok := x.Kind.(*Message_ODuration); ok && xv.ODuration != nil {
+			proto.Merge(xv.ODuration, v.ODuration)
+		} else {
+			m := new(durationpb.Duration)
+			proto.Merge(m, v.ODuration)
+			x.Kind = &Message_ODuration{ODuration: m}
+		}
+	}
+	if src.Nested != nil {
+		if x.Nested == nil {
+			x.Nested = new(Message_Nested)
+		}
+		x.Nested.MergeFrom(src.Nested)
+	}
+	if src.Lists != nil {
+		if x.Lists == nil {
+			x.Lists = new(Lists)
+		}
+		x.Lists.MergeFrom(src.Lists)
+	}
+	if len(src.unknownFields) > 0 {
+		x.unknownFields = append(x.unknownFields, src.unknownFields...)
+	}
+}
+
+// XXX_Equal is used by proto.Equal.
+func (x *Message) XXX_Equal(y protoiface.MessageV1) bool {
+	m, ok := y.(*Message)
+	return ok && x.Equal(m)
+}
+
+// XXX_Clone is used by proto.Clone.
+func (x *Message) XXX_Clone() protoiface.MessageV1 {
+	return x.Clone()
+}
+
+// XXX_MergeFrom is used by proto.Merge.
+func (x *Message) XXX_MergeFrom(src protoiface.MessageV1) {
+	if m, ok := src.(*Message); ok {
+		x.MergeFrom(m)
+		return
+	}
+	fastpath.MergeReflect(x, src)
+}
+
+// Size returns the size in bytes of the wire-format encoding of x, as by
+// proto.Size, without the use of protobuf reflection.
+func (x *Message) Size() (n int) {
+	if x == nil {
+		return 0
+	}
+	if x.Scalars != nil {
+		n += 1 + protowire.SizeBytes(x.Scalars.Size())
+	}
+	if x.Child != nil {
+		n += 1 + protowire.SizeBytes(x.Child.Size())
+	}
+	if x.Duration != nil {
+		n += 1 + protowire.SizeBytes(proto.Size(x.Duration))
+	}
+	if x.Legacy != nil {
+		n += 1 + protowire.SizeBytes(proto.Size(x.Legacy))
+	}
+	if x.Extendable != nil {
+		n += 1 + protowire.SizeBytes(proto.Size(x.Extendable))
+	}
+	if len(x.I32S) > 0 {
+		s := 0
+		for _, v := range x.I32S {
+			s += protowire.SizeVarint(uint64(v))
+		}
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.Dbs) > 0 {
+		s := 8 * len(x.Dbs)
+		n += 1 + protowire.SizeBytes(s)
+	}
+	for _, v := range x.Ss {
+		n += 1 + protowire.SizeBytes(len(v))
+	}
+	for _, v := range x.Bys {
+		n += 1 + protowire.SizeBytes(len(v))
+	}
+	if len(x.Colors) > 0 {
+		s := 0
+		for _, v := range x.Colors {
+			s += protowire.SizeVarint(uint64(v))
+		}
+		n += 1 + protowire.SizeBytes(s)
+	}
+	for _, v := range x.Children {
+		n += 1 + protowire.SizeBytes(v.Size())
+	}
+	for _, v := range x.Durations {
+		n += 1 + protowire.SizeBytes(proto.Size(v))
+	}
+	for k, v := range x.ByName {
+		s := 1 + protowire.SizeBytes(len(k)) + 1 + protowire.SizeBytes(v.Size())
+		n += 1 + protowire.SizeBytes(s)
+	}
+	for k, v := range x.BytesById {
+		s := 1 + protowire.SizeVarint(uint64(k)) + 1 + protowire.SizeBytes(len(v))
+		n += 1 + protowire.SizeBytes(s)
+	}
+	n += 13 * len(x.DbsByFlag)
+	for k, v := range x.ColorsById {
+		s := 1 + protowire.SizeVarint(uint64(k)) + 1 + protowire.SizeVarint(uint64(v))
+		n += 2 + protowire.SizeBytes(s)
+	}
+	for k, v := range x.DurationsByName {
+		s := 1 + protowire.SizeBytes(len(k)) + 1 + protowire.SizeBytes(proto.Size(v))
+		n += 2 + protowire.SizeBytes(s)
+	}
+	if v, ok := x.Kind.(*Message_OI32); ok {
+		n += 2 + protowire.SizeVarint(uint64(v.OI32))
+	}
+	if _, ok := x.Kind.(*Message_OFl); ok {
+		n += 6
+	}
+	if v, ok := x.Kind.(*Message_OS); ok {
+		n += 2 + protowire.SizeBytes(len(v.OS))
+	}
+	if v, ok := x.Kind.(*Message_OBy); ok {
+		n += 2 + protowire.SizeBytes(len(v.OBy))
+	}
+	if v, ok := x.Kind.(*Message_OChild); ok {
+		n += 2 + protowire.SizeBytes(v.OChild.Size())
+	}
+	if v, ok := x.Kind.(*Message_ODuration); ok {
+		n += 2 + protowire.SizeBytes(proto.Size(v.ODuration))
+	}
+	if x.Nested != nil {
+		n += 2 + protowire.SizeBytes(proto.Size(x.Nested))
+	}
+	if x.Lists != nil {
+		n += 2 + protowire.SizeBytes(x.Lists.Size())
+	}
+	n += len(x.unknownFields)
+	return n
+}
+
+// Marshal returns the wire-format encoding of x, as by proto.Marshal with
+// deterministic ordering of map entries, without the use of protobuf
+// reflection.
+func (x *Message) Marshal() ([]byte, error) {
+	b := make([]byte, x.Size())
+	n, err := x.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[len(b)-n:], nil
+}
+
+// MarshalToSizedBuffer writes the wire-format encoding of x at the end of b,
+// which must be at least x.Size() bytes long, and returns its length.
+func (x *Message) MarshalToSizedBuffer(b []byte) (int, error) {
+	if x == nil {
+		return 0, nil
+	}
+	i := len(b)
+	if len(x.unknownFields) > 0 {
+		i -= len(x.unknownFields)
+		copy(b[i:], x.unknownFields)
+	}
+	if v, ok := x.Kind.(*Message_ODuration); ok {
+		j := i
+		s, err := fastpath.MarshalMessage(b[:i], v.ODuration)
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i -= 2
+		b[i] = 0xba
+		b[i+1] = 0x1
+	}
+	if v, ok := x.Kind.(*Message_OChild); ok {
+		j := i
+		s, err := v.OChild.MarshalToSizedBuffer(b[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i -= 2
+		b[i] = 0xb2
+		b[i+1] = 0x1
+	}
+	if v, ok := x.Kind.(*Message_OBy); ok {
+		i = fastpath.PutBytes(b, i, v.OBy)
+		i -= 2
+		b[i] = 0xaa
+		b[i+1] = 0x1
+	}
+	if v, ok := x.Kind.(*Message_OS); ok {
+		if !utf8.ValidString(v.OS) {
+			return 0, fastpath.InvalidUTF8("fastpath_test.Message.o_s")
+		}
+		i = fastpath.PutString(b, i, v.OS)
+		i -= 2
+		b[i] = 0xa2
+		b[i+1] = 0x1
+	}
+	if v, ok := x.Kind.(*Message_OFl); ok {
+		i = fastpath.PutFixed32(b, i, math.Float32bits(v.OFl))
+		i -= 2
+		b[i] = 0x9d
+		b[i+1] = 0x1
+	}
+	if v, ok := x.Kind.(*Message_OI32); ok {
+		i = fastpath.PutVarint(b, i, uint64(v.OI32))
+		i -= 2
+		b[i] = 0x90
+		b[i+1] = 0x1
+	}
+	if x.Lists != nil {
+		j := i
+		s, err := x.Lists.MarshalToSizedBuffer(b[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i -= 2
+		b[i] = 0xca
+		b[i+1] = 0x1
+	}
+	if x.Nested != nil {
+		j := i
+		s, err := fastpath.MarshalMessage(b[:i], x.Nested)
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i -= 2
+		b[i] = 0xc2
+		b[i+1] = 0x1
+	}
+	if len(x.DurationsByName) > 0 {
+		keys := make([]string, 0, len(x.DurationsByName))
+		for k := range x.DurationsByName {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(k, l int) bool { return keys[k] < keys[l] })
+		for k := len(keys) - 1; k >= 0; k-- {
+			j := i
+			v := x.DurationsByName[keys[k]]
+			{
+				j := i
+				s, err := fastpath.MarshalMessage(b[:i], v)
+				if err != nil {
+					return 0, err
+				}
+				i -= s
+				i = fastpath.PutVarint(b, i, uint64(j-i))
+				i--
+				b[i] = 0x12
+			}
+			if !utf8.ValidString(keys[k]) {
+				return 0, fastpath.InvalidUTF8("fastpath_test.Message.DurationsByNameEntry.key")
+			}
+			i = fastpath.PutString(b, i, keys[k])
+			i--
+			b[i] = 0xa
+			i = fastpath.PutVarint(b, i, uint64(j-i))
+			i -= 2
+			b[i] = 0x8a
+			b[i+1] = 0x1
+		}
+	}
+	if len(x.ColorsById) > 0 {
+		keys := make([]uint32, 0, len(x.ColorsById))
+		for k := range x.ColorsById {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(k, l int) bool { return keys[k] < keys[l] })
+		for k := len(keys) - 1; k >= 0; k-- {
+			j := i
+			v := x.ColorsById[keys[k]]
+			i = fastpath.PutVarint(b, i, uint64(v))
+			i--
+			b[i] = 0x10
+			i = fastpath.PutVarint(b, i, uint64(keys[k]))
+			i--
+			b[i] = 0x8
+			i = fastpath.PutVarint(b, i, uint64(j-i))
+			i -= 2
+			b[i] = 0x82
+			b[i+1] = 0x1
+		}
+	}
+	if len(x.DbsByFlag) > 0 {
+		keys := make([]bool, 0, len(x.DbsByFlag))
+		for k := range x.DbsByFlag {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(k, l int) bool { return !keys[k] && keys[l] })
+		for k := len(keys) - 1; k >= 0; k-- {
+			j := i
+			v := x.DbsByFlag[keys[k]]
+			i = fastpath.PutFixed64(b, i, math.Float64bits(v))
+			i--
+			b[i] = 0x11
+			i = fastpath.PutVarint(b, i, protowire.EncodeBool(keys[k]))
+			i--
+			b[i] = 0x8
+			i = fastpath.PutVarint(b, i, uint64(j-i))
+			i--
+			b[i] = 0x7a
+		}
+	}
+	if len(x.BytesById) > 0 {
+		keys := make([]int64, 0, len(x.BytesById))
+		for k := range x.BytesById {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(k, l int) bool { return keys[k] < keys[l] })
+		for k := len(keys) - 1; k >= 0; k-- {
+			j := i
+			v := x.BytesById[keys[k]]
+			i = fastpath.PutBytes(b, i, v)
+			i--
+			b[i] = 0x12
+			i = fastpath.PutVarint(b, i, uint64(keys[k]))
+			i--
+			b[i] = 0x8
+			i = fastpath.PutVarint(b, i, uint64(j-i))
+			i--
+			b[i] = 0x72
+		}
+	}
+	if len(x.ByName) > 0 {
+		keys := make([]string, 0, len(x.ByName))
+		for k := range x.ByName {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(k, l int) bool { return keys[k] < keys[l] })
+		for k := len(keys) - 1; k >= 0; k-- {
+			j := i
+			v := x.ByName[keys[k]]
+			{
+				j := i
+				s, err := v.MarshalToSizedBuffer(b[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= s
+				i = fastpath.PutVarint(b, i, uint64(j-i))
+				i--
+				b[i] = 0x12
+			}
+			if !utf8.ValidString(keys[k]) {
+				return 0, fastpath.InvalidUTF8("fastpath_test.Message.ByNameEntry.key")
+			}
+			i = fastpath.PutString(b, i, keys[k])
+			i--
+			b[i] = 0xa
+			i = fastpath.PutVarint(b, i, uint64(j-i))
+			i--
+			b[i] = 0x6a
+		}
+	}
+	for k := len(x.Durations) - 1; k >= 0; k-- {
+		j := i
+		s, err := fastpath.MarshalMessage(b[:i], x.Durations[k])
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x62
+	}
+	for k := len(x.Children) - 1; k >= 0; k-- {
+		j := i
+		s, err := x.Children[k].MarshalToSizedBuffer(b[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x5a
+	}
+	if len(x.Colors) > 0 {
+		j := i
+		for k := len(x.Colors) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, uint64(x.Colors[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x52
+	}
+	for k := len(x.Bys) - 1; k >= 0; k-- {
+		i = fastpath.PutBytes(b, i, x.Bys[k])
+		i--
+		b[i] = 0x4a
+	}
+	for k := len(x.Ss) - 1; k >= 0; k-- {
+		if !utf8.ValidString(x.Ss[k]) {
+			return 0, fastpath.InvalidUTF8("fastpath_test.Message.ss")
+		}
+		i = fastpath.PutString(b, i, x.Ss[k])
+		i--
+		b[i] = 0x42
+	}
+	if len(x.Dbs) > 0 {
+		j := i
+		for k := len(x.Dbs) - 1; k >= 0; k-- {
+			i = fastpath.PutFixed64(b, i, math.Float64bits(x.Dbs[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x3a
+	}
+	if len(x.I32S) > 0 {
+		j := i
+		for k := len(x.I32S) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, uint64(x.I32S[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x32
+	}
+	if x.Extendable != nil {
+		j := i
+		s, err := fastpath.MarshalMessage(b[:i], x.Extendable)
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x2a
+	}
+	if x.Legacy != nil {
+		j := i
+		s, err := fastpath.MarshalMessage(b[:i], x.Legacy)
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x22
+	}
+	if x.Duration != nil {
+		j := i
+		s, err := fastpath.MarshalMessage(b[:i], x.Duration)
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x1a
+	}
+	if x.Child != nil {
+		j := i
+		s, err := x.Child.MarshalToSizedBuffer(b[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x12
+	}
+	if x.Scalars != nil {
+		j := i
+		s, err := x.Scalars.MarshalToSizedBuffer(b[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0xa
+	}
+	return len(b) - i, nil
+}
+
+// Unmarshal merges the wire-format encoding in b into x, as by
+// proto.UnmarshalMerge, without the use of protobuf reflection. Required
+// fields are not checked.
+func (x *Message) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n, err := fastpath.ConsumeTag(b)
+		if err != nil {
+			return err
+		}
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if x.Scalars == nil {
+				x.Scalars = new(Scalars)
+			}
+			if err := x.Scalars.Unmarshal(v); err != nil {
+				return err
+			}
+			n += m
+		case num == 2 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if x.Child == nil {
+				x.Child = new(Message)
+			}
+			if err := x.Child.Unmarshal(v); err != nil {
+				return err
+			}
+			n += m
+		case num == 3 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if x.Duration == nil {
+				x.Duration = new(durationpb.Duration)
+			}
+			if err := fastpath.UnmarshalMessage(v, x.Duration); err != nil {
+				return err
+			}
+			n += m
+		case num == 4 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if x.Legacy == nil {
+				x.Legacy = new(Legacy)
+			}
+			if err := fastpath.UnmarshalMessage(v, x.Legacy); err != nil {
+				return err
+			}
+			n += m
+		case num == 5 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if x.Extendable == nil {
+				x.Extendable = new(Extendable)
+			}
+			if err := fastpath.UnmarshalMessage(v, x.Extendable); err != nil {
+				return err
+			}
+			n += m
+		case num == 6 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.I32S = append(x.I32S, int32(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 6 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.I32S = append(x.I32S, int32(v))
+			n += m
+		case num == 7 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeFixed64(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.Dbs = append(x.Dbs, math.Float64frombits(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 7 && typ == protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Dbs = append(x.Dbs, math.Float64frombits(v))
+			n += m
+		case num == 8 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if !utf8.Valid(v) {
+				return fastpath.InvalidUTF8("fastpath_test.Message.ss")
+			}
+			x.Ss = append(x.Ss, string(v))
+			n += m
+		case num == 9 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Bys = append(x.Bys, append([]byte{}, v...))
+			n += m
+		case num == 10 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.Colors = append(x.Colors, Color(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 10 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Colors = append(x.Colors, Color(v))
+			n += m
+		case num == 11 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			e := new(Message)
+			if err := e.Unmarshal(v); err != nil {
+				return err
+			}
+			x.Children = append(x.Children, e)
+			n += m
+		case num == 12 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			e := new(durationpb.Duration)
+			if err := fastpath.UnmarshalMessage(v, e); err != nil {
+				return err
+			}
+			x.Durations = append(x.Durations, e)
+			n += m
+		case num == 13 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			var key string
+			var val *Message
+			for len(v) > 0 {
+				num, typ, k, err := fastpath.ConsumeTag(v)
+				if err != nil {
+					return err
+				}
+				switch {
+				case num == 1 && typ == protowire.BytesType:
+					e, j := protowire.ConsumeBytes(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					if !utf8.Valid(e) {
+						return fastpath.InvalidUTF8("fastpath_test.Message.ByNameEntry.key")
+					}
+					key = string(e)
+					k += j
+				case num == 2 && typ == protowire.BytesType:
+					e, j := protowire.ConsumeBytes(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					if val == nil {
+						val = new(Message)
+					}
+					if err := val.Unmarshal(e); err != nil {
+						return err
+					}
+					k += j
+				default:
+					j := protowire.ConsumeFieldValue(num, typ, v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					k += j
+				}
+				v = v[k:]
+			}
+			if val == nil {
+				val = new(Message)
+			}
+			if x.ByName == nil {
+				x.ByName = make(map[string]*Message)
+			}
+			x.ByName[key] = val
+			n += m
+		case num == 14 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			var key int64
+			var val []byte
+			for len(v) > 0 {
+				num, typ, k, err := fastpath.ConsumeTag(v)
+				if err != nil {
+					return err
+				}
+				switch {
+				case num == 1 && typ == protowire.VarintType:
+					e, j := protowire.ConsumeVarint(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					key = int64(e)
+					k += j
+				case num == 2 && typ == protowire.BytesType:
+					e, j := protowire.ConsumeBytes(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					val = append([]byte{}, e...)
+					k += j
+				default:
+					j := protowire.ConsumeFieldValue(num, typ, v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					k += j
+				}
+				v = v[k:]
+			}
+			if x.BytesById == nil {
+				x.BytesById = make(map[int64][]byte)
+			}
+			x.BytesById[key] = val
+			n += m
+		case num == 15 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			var key bool
+			var val float64
+			for len(v) > 0 {
+				num, typ, k, err := fastpath.ConsumeTag(v)
+				if err != nil {
+					return err
+				}
+				switch {
+				case num == 1 && typ == protowire.VarintType:
+					e, j := protowire.ConsumeVarint(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					key = protowire.DecodeBool(e)
+					k += j
+				case num == 2 && typ == protowire.Fixed64Type:
+					e, j := protowire.ConsumeFixed64(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					val = math.Float64frombits(e)
+					k += j
+				default:
+					j := protowire.ConsumeFieldValue(num, typ, v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					k += j
+				}
+				v = v[k:]
+			}
+			if x.DbsByFlag == nil {
+				x.DbsByFlag = make(map[bool]float64)
+			}
+			x.DbsByFlag[key] = val
+			n += m
+		case num == 16 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			var key uint32
+			var val Color
+			for len(v) > 0 {
+				num, typ, k, err := fastpath.ConsumeTag(v)
+				if err != nil {
+					return err
+				}
+				switch {
+				case num == 1 && typ == protowire.VarintType:
+					e, j := protowire.ConsumeVarint(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					key = uint32(e)
+					k += j
+				case num == 2 && typ == protowire.VarintType:
+					e, j := protowire.ConsumeVarint(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					val = Color(e)
+					k += j
+				default:
+					j := protowire.ConsumeFieldValue(num, typ, v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					k += j
+				}
+				v = v[k:]
+			}
+			if x.ColorsById == nil {
+				x.ColorsById = make(map[uint32]Color)
+			}
+			x.ColorsById[key] = val
+			n += m
+		case num == 17 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			var key string
+			var val *durationpb.Duration
+			for len(v) > 0 {
+				num, typ, k, err := fastpath.ConsumeTag(v)
+				if err != nil {
+					return err
+				}
+				switch {
+				case num == 1 && typ == protowire.BytesType:
+					e, j := protowire.ConsumeBytes(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					if !utf8.Valid(e) {
+						return fastpath.InvalidUTF8("fastpath_test.Message.DurationsByNameEntry.key")
+					}
+					key = string(e)
+					k += j
+				case num == 2 && typ == protowire.BytesType:
+					e, j := protowire.ConsumeBytes(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					if val == nil {
+						val = new(durationpb.Duration)
+					}
+					if err := fastpath.UnmarshalMessage(e, val); err != nil {
+						return err
+					}
+					k += j
+				default:
+					j := protowire.ConsumeFieldValue(num, typ, v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					k += j
+				}
+				v = v[k:]
+			}
+			if val == nil {
+				val = new(durationpb.Duration)
+			}
+			if x.DurationsByName == nil {
+				x.DurationsByName = make(map[string]*durationpb.Duration)
+			}
+			x.DurationsByName[key] = val
+			n += m
+		case num == 18 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Kind = &Message_OI32{OI32: int32(v)}
+			n += m
+		case num == 19 && typ == protowire.Fixed32Type:
+			v, m := protowire.ConsumeFixed32(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Kind = &Message_OFl{OFl: math.Float32frombits(v)}
+			n += m
+		case num == 20 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if !utf8.Valid(v) {
+				return fastpath.InvalidUTF8("fastpath_test.Message.o_s")
+			}
+			x.Kind = &Message_OS{OS: string(v)}
+			n += m
+		case num == 21 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Kind = &Message_OBy{OBy: append([]byte{}, v...)}
+			n += m
+		case num == 22 && typ == protowire.BytesType:
+			w, ok := x.Kind.(*Message_OChild)
+			if !ok || w == nil {
+				w = new(Message_OChild)
+			}
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if w.OChild == nil {
+				w.OChild = new(Message)
+			}
+			if err := w.OChild.Unmarshal(v); err != nil {
+				return err
+			}
+			x.Kind = w
+			n += m
+		case num == 23 && typ == protowire.BytesType:
+			w, ok := x.Kind.(*Message_ODuration)
+			if !ok || w == nil {
+				w = new(Message_ODuration)
+			}
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if w.ODuration == nil {
+				w.ODuration = new(durationpb.Duration)
+			}
+			if err := fastpath.UnmarshalMessage(v, w.ODuration); err != nil {
+				return err
+			}
+			x.Kind = w
+			n += m
+		case num == 24 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if x.Nested == nil {
+				x.Nested = new(Message_Nested)
+			}
+			if err := fastpath.UnmarshalMessage(v, x.Nested); err != nil {
+				return err
+			}
+			n += m
+		case num == 25 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if x.Lists == nil {
+				x.Lists = new(Lists)
+			}
+			if err := x.Lists.Unmarshal(v); err != nil {
+				return err
+			}
+			n += m
+		default:
+			m := protowire.ConsumeFieldValue(num, typ, b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			// As in protobuf reflection, the tag is stored in its shortest form.
+			x.unknownFields = protowire.AppendTag(x.unknownFields, num, typ)
+			x.unknownFields = append(x.unknownFields, b[n:n+m]...)
+			n += m
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// Equal reports whether x and y are equal, as by proto.Equal, without the
+// use of protobuf reflection. A nil message is only equal to nil.
+func (x *Message_Nested) Equal(y *Message_Nested) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	if x.Name != y.Name {
+		return false
+	}
+	if (x.Next == nil) != (y.Next == nil) || x.Next != nil && !x.Next.Equal(y.Next) {
+		return false
+	}
+	return fastpath.EqualUnknown(x.unknownFields, y.unknownFields)
+}
+
+// Clone returns a deep copy of x, as by proto.Clone, without the use of
+// protobuf reflection.
+func (x *Message_Nested) Clone() *Message_Nested {
+	if x == nil {
+		return nil
+	}
+	y := new(Message_Nested)
+	y.MergeFrom(x)
+	return y
+}
+
+// MergeFrom merges src into x, as by proto.Merge, without the use of
+// protobuf reflection.
+func (x *Message_Nested) MergeFrom(src *Message_Nested) {
+	if src == nil {
+		return
+	}
+	if src.Name != "" {
+		x.Name = src.Name
+	}
+	if src.Next != nil {
+		if x.Next == nil {
+			x.Next = new(Message_Nested)
+		}
+		x.Next.MergeFrom(src.Next)
+	}
+	if len(src.unknownFields) > 0 {
+		x.unknownFields = append(x.unknownFields, src.unknownFields...)
+	}
+}
+
+// XXX_Equal is used by proto.Equal.
+func (x *Message_Nested) XXX_Equal(y protoiface.MessageV1) bool {
+	m, ok := y.(*Message_Nested)
+	return ok && x.Equal(m)
+}
+
+// XXX_Clone is used by proto.Clone.
+func (x *Message_Nested) XXX_Clone() protoiface.MessageV1 {
+	return x.Clone()
+}
+
+// XXX_MergeFrom is used by proto.Merge.
+func (x *Message_Nested) XXX_MergeFrom(src protoiface.MessageV1) {
+	if m, ok := src.(*Message_Nested); ok {
+		x.MergeFrom(m)
+		return
+	}
+	fastpath.MergeReflect(x, src)
+}
+
+// Equal reports whether x and y are equal, as by proto.Equal, without the
+// use of protobuf reflection. A nil message is only equal to nil.
+func (x *Lists) Equal(y *Lists) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	if len(x.Bs) != len(y.Bs) {
+		return false
+	}
+	for i, vx := range x.Bs {
+		if vx != y.Bs[i] {
+			return false
+		}
+	}
+	if len(x.I32S) != len(y.I32S) {
+		return false
+	}
+	for i, vx := range x.I32S {
+		if vx != y.I32S[i] {
+			return false
+		}
+	}
+	if len(x.S32S) != len(y.S32S) {
+		return false
+	}
+	for i, vx := range x.S32S {
+		if vx != y.S32S[i] {
+			return false
+		}
+	}
+	if len(x.Sf32S) != len(y.Sf32S) {
+		return false
+	}
+	for i, vx := range x.Sf32S {
+		if vx != y.Sf32S[i] {
+			return false
+		}
+	}
+	if len(x.U32S) != len(y.U32S) {
+		return false
+	}
+	for i, vx := range x.U32S {
+		if vx != y.U32S[i] {
+			return false
+		}
+	}
+	if len(x.F32S) != len(y.F32S) {
+		return false
+	}
+	for i, vx := range x.F32S {
+		if vx != y.F32S[i] {
+			return false
+		}
+	}
+	if len(x.I64S) != len(y.I64S) {
+		return false
+	}
+	for i, vx := range x.I64S {
+		if vx != y.I64S[i] {
+			return false
+		}
+	}
+	if len(x.S64S) != len(y.S64S) {
+		return false
+	}
+	for i, vx := range x.S64S {
+		if vx != y.S64S[i] {
+			return false
+		}
+	}
+	if len(x.Sf64S) != len(y.Sf64S) {
+		return false
+	}
+	for i, vx := range x.Sf64S {
+		if vx != y.Sf64S[i] {
+			return false
+		}
+	}
+	if len(x.U64S) != len(y.U64S) {
+		return false
+	}
+	for i, vx := range x.U64S {
+		if vx != y.U64S[i] {
+			return false
+		}
+	}
+	if len(x.F64S) != len(y.F64S) {
+		return false
+	}
+	for i, vx := range x.F64S {
+		if vx != y.F64S[i] {
+			return false
+		}
+	}
+	if len(x.Fls) != len(y.Fls) {
+		return false
+	}
+	for i, vx := range x.Fls {
+		if !fastpath.EqualFloat64(float64(vx), float64(y.Fls[i])) {
+			return false
+		}
+	}
+	if len(x.Dbs) != len(y.Dbs) {
+		return false
+	}
+	for i, vx := range x.Dbs {
+		if !fastpath.EqualFloat64(vx, y.Dbs[i]) {
+			return false
+		}
+	}
+	if len(x.Colors) != len(y.Colors) {
+		return false
+	}
+	for i, vx := range x.Colors {
+		if vx != y.Colors[i] {
+			return false
+		}
+	}
+	if len(x.UnpackedI32S) != len(y.UnpackedI32S) {
+		return false
+	}
+	for i, vx := range x.UnpackedI32S {
+		if vx != y.UnpackedI32S[i] {
+			return false
+		}
+	}
+	if len(x.StringsByS32) != len(y.StringsByS32) {
+		return false
+	}
+	for k, vx := range x.StringsByS32 {
+		if vy, ok := y.StringsByS32[k]; !ok || vx != vy {
+			return false
+		}
+	}
+	if len(x.ScalarsByF64) != len(y.ScalarsByF64) {
+		return false
+	}
+	for k, vx := range x.ScalarsByF64 {
+		if vy, ok := y.ScalarsByF64[k]; !ok || !fastpath.EqualMessage(vx, vy) {
+			return false
+		}
+	}
+	if len(x.Strings) != len(y.Strings) {
+		return false
+	}
+	for k, vx := range x.Strings {
+		if vy, ok := y.Strings[k]; !ok || vx != vy {
+			return false
+		}
+	}
+	if x.Far != y.Far {
+		return false
+	}
+	return fastpath.EqualUnknown(x.unknownFields, y.unknownFields)
+}
+
+// Clone returns a deep copy of x, as by proto.Clone, without the use of
+// protobuf reflection.
+func (x *Lists) Clone() *Lists {
+	if x == nil {
+		return nil
+	}
+	y := new(Lists)
+	y.MergeFrom(x)
+	return y
+}
+
+// MergeFrom merges src into x, as by proto.Merge, without the use of
+// protobuf reflection.
+func (x *Lists) MergeFrom(src *Lists) {
+	if src == nil {
+		return
+	}
+	x.Bs = append(x.Bs, src.Bs...)
+	x.I32S = append(x.I32S, src.I32S...)
+	x.S32S = append(x.S32S, src.S32S...)
+	x.Sf32S = append(x.Sf32S, src.Sf32S...)
+	x.U32S = append(x.U32S, src.U32S...)
+	x.F32S = append(x.F32S, src.F32S...)
+	x.I64S = append(x.I64S, src.I64S...)
+	x.S64S = append(x.S64S, src.S64S...)
+	x.Sf64S = append(x.Sf64S, src.Sf64S...)
+	x.U64S = append(x.U64S, src.U64S...)
+	x.F64S = append(x.F64S, src.F64S...)
+	x.Fls = append(x.Fls, src.Fls...)
+	x.Dbs = append(x.Dbs, src.Dbs...)
+	x.Colors = append(x.Colors, src.Colors...)
+	x.UnpackedI32S = append(x.UnpackedI32S, src.UnpackedI32S...)
+	if len(src.StringsByS32) > 0 {
+		if x.StringsByS32 == nil {
+			x.StringsByS32 = make(map[int32]string, len(src.StringsByS32))
+		}
+		for k, v := range src.StringsByS32 {
+			x.StringsByS32[k] = v
+		}
+	}
+	if len(src.ScalarsByF64) > 0 {
+		if x.ScalarsByF64 == nil {
+			x.ScalarsByF64 = make(map[uint64]*Scalars, len(src.ScalarsByF64))
+		}
+		for k, v := range src.ScalarsByF64 {
+			m := new(Scalars)
+			m.MergeFrom(v)
+			x.ScalarsByF64[k] = m
+		}
+	}
+	if len(src.Strings) > 0 {
+		if x.Strings == nil {
+			x.Strings = make(map[string]string, len(src.Strings))
+		}
+		for k, v := range src.Strings {
+			x.Strings[k] = v
+		}
+	}
+	if src.Far != 0 {
+		x.Far = src.Far
+	}
+	if len(src.unknownFields) > 0 {
+		x.unknownFields = append(x.unknownFields, src.unknownFields...)
+	}
+}
+
+// XXX_Equal is used by proto.Equal.
+func (x *Lists) XXX_Equal(y protoiface.MessageV1) bool {
+	m, ok := y.(*Lists)
+	return ok && x.Equal(m)
+}
+
+// XXX_Clone is used by proto.Clone.
+func (x *Lists) XXX_Clone() protoiface.MessageV1 {
+	return x.Clone()
+}
+
+// XXX_MergeFrom is used by proto.Merge.
+func (x *Lists) XXX_MergeFrom(src protoiface.MessageV1) {
+	if m, ok := src.(*Lists); ok {
+		x.MergeFrom(m)
+		return
+	}
+	fastpath.MergeReflect(x, src)
+}
+
+// Size returns the size in bytes of the wire-format encoding of x, as by
+// proto.Size, without the use of protobuf reflection.
+func (x *Lists) Size() (n int) {
+	if x == nil {
+		return 0
+	}
+	if len(x.Bs) > 0 {
+		s := 1 * len(x.Bs)
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.I32S) > 0 {
+		s := 0
+		for _, v := range x.I32S {
+			s += protowire.SizeVarint(uint64(v))
+		}
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.S32S) > 0 {
+		s := 0
+		for _, v := range x.S32S {
+			s += protowire.SizeVarint(protowire.EncodeZigZag(int64(v)))
+		}
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.Sf32S) > 0 {
+		s := 4 * len(x.Sf32S)
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.U32S) > 0 {
+		s := 0
+		for _, v := range x.U32S {
+			s += protowire.SizeVarint(uint64(v))
+		}
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.F32S) > 0 {
+		s := 4 * len(x.F32S)
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.I64S) > 0 {
+		s := 0
+		for _, v := range x.I64S {
+			s += protowire.SizeVarint(uint64(v))
+		}
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.S64S) > 0 {
+		s := 0
+		for _, v := range x.S64S {
+			s += protowire.SizeVarint(protowire.EncodeZigZag(v))
+		}
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.Sf64S) > 0 {
+		s := 8 * len(x.Sf64S)
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.U64S) > 0 {
+		s := 0
+		for _, v := range x.U64S {
+			s += protowire.SizeVarint(v)
+		}
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.F64S) > 0 {
+		s := 8 * len(x.F64S)
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.Fls) > 0 {
+		s := 4 * len(x.Fls)
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.Dbs) > 0 {
+		s := 8 * len(x.Dbs)
+		n += 1 + protowire.SizeBytes(s)
+	}
+	if len(x.Colors) > 0 {
+		s := 0
+		for _, v := range x.Colors {
+			s += protowire.SizeVarint(uint64(v))
+		}
+		n += 1 + protowire.SizeBytes(s)
+	}
+	for _, v := range x.UnpackedI32S {
+		n += 1 + protowire.SizeVarint(uint64(v))
+	}
+	for k, v := range x.StringsByS32 {
+		s := 1 + protowire.SizeVarint(protowire.EncodeZigZag(int64(k))) + 1 + protowire.SizeBytes(len(v))
+		n += 2 + protowire.SizeBytes(s)
+	}
+	for _, v := range x.ScalarsByF64 {
+		s := 9 + 1 + protowire.SizeBytes(v.Size())
+		n += 2 + protowire.SizeBytes(s)
+	}
+	for k, v := range x.Strings {
+		s := 1 + protowire.SizeBytes(len(k)) + 1 + protowire.SizeBytes(len(v))
+		n += 2 + protowire.SizeBytes(s)
+	}
+	if x.Far != 0 {
+		n += 5 + protowire.SizeVarint(uint64(x.Far))
+	}
+	n += len(x.unknownFields)
+	return n
+}
+
+// Marshal returns the wire-format encoding of x, as by proto.Marshal with
+// deterministic ordering of map entries, without the use of protobuf
+// reflection.
+func (x *Lists) Marshal() ([]byte, error) {
+	b := make([]byte, x.Size())
+	n, err := x.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[len(b)-n:], nil
+}
+
+// MarshalToSizedBuffer writes the wire-format encoding of x at the end of b,
+// which must be at least x.Size() bytes long, and returns its length.
+func (x *Lists) MarshalToSizedBuffer(b []byte) (int, error) {
+	if x == nil {
+		return 0, nil
+	}
+	i := len(b)
+	if len(x.unknownFields) > 0 {
+		i -= len(x.unknownFields)
+		copy(b[i:], x.unknownFields)
+	}
+	if x.Far != 0 {
+		i = fastpath.PutVarint(b, i, uint64(x.Far))
+		i -= 5
+		b[i] = 0xf8
+		b[i+1] = 0xff
+		b[i+2] = 0xff
+		b[i+3] = 0xff
+		b[i+4] = 0xf
+	}
+	if len(x.Strings) > 0 {
+		keys := make([]string, 0, len(x.Strings))
+		for k := range x.Strings {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(k, l int) bool { return keys[k] < keys[l] })
+		for k := len(keys) - 1; k >= 0; k-- {
+			j := i
+			v := x.Strings[keys[k]]
+			if !utf8.ValidString(v) {
+				return 0, fastpath.InvalidUTF8("fastpath_test.Lists.StringsEntry.value")
+			}
+			i = fastpath.PutString(b, i, v)
+			i--
+			b[i] = 0x12
+			if !utf8.ValidString(keys[k]) {
+				return 0, fastpath.InvalidUTF8("fastpath_test.Lists.StringsEntry.key")
+			}
+			i = fastpath.PutString(b, i, keys[k])
+			i--
+			b[i] = 0xa
+			i = fastpath.PutVarint(b, i, uint64(j-i))
+			i -= 2
+			b[i] = 0x92
+			b[i+1] = 0x1
+		}
+	}
+	if len(x.ScalarsByF64) > 0 {
+		keys := make([]uint64, 0, len(x.ScalarsByF64))
+		for k := range x.ScalarsByF64 {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(k, l int) bool { return keys[k] < keys[l] })
+		for k := len(keys) - 1; k >= 0; k-- {
+			j := i
+			v := x.ScalarsByF64[keys[k]]
+			{
+				j := i
+				s, err := v.MarshalToSizedBuffer(b[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= s
+				i = fastpath.PutVarint(b, i, uint64(j-i))
+				i--
+				b[i] = 0x12
+			}
+			i = fastpath.PutFixed64(b, i, keys[k])
+			i--
+			b[i] = 0x9
+			i = fastpath.PutVarint(b, i, uint64(j-i))
+			i -= 2
+			b[i] = 0x8a
+			b[i+1] = 0x1
+		}
+	}
+	if len(x.StringsByS32) > 0 {
+		keys := make([]int32, 0, len(x.StringsByS32))
+		for k := range x.StringsByS32 {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(k, l int) bool { return keys[k] < keys[l] })
+		for k := len(keys) - 1; k >= 0; k-- {
+			j := i
+			v := x.StringsByS32[keys[k]]
+			if !utf8.ValidString(v) {
+				return 0, fastpath.InvalidUTF8("fastpath_test.Lists.StringsByS32Entry.value")
+			}
+			i = fastpath.PutString(b, i, v)
+			i--
+			b[i] = 0x12
+			i = fastpath.PutVarint(b, i, protowire.EncodeZigZag(int64(keys[k])))
+			i--
+			b[i] = 0x8
+			i = fastpath.PutVarint(b, i, uint64(j-i))
+			i -= 2
+			b[i] = 0x82
+			b[i+1] = 0x1
+		}
+	}
+	for k := len(x.UnpackedI32S) - 1; k >= 0; k-- {
+		i = fastpath.PutVarint(b, i, uint64(x.UnpackedI32S[k]))
+		i--
+		b[i] = 0x78
+	}
+	if len(x.Colors) > 0 {
+		j := i
+		for k := len(x.Colors) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, uint64(x.Colors[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x72
+	}
+	if len(x.Dbs) > 0 {
+		j := i
+		for k := len(x.Dbs) - 1; k >= 0; k-- {
+			i = fastpath.PutFixed64(b, i, math.Float64bits(x.Dbs[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x6a
+	}
+	if len(x.Fls) > 0 {
+		j := i
+		for k := len(x.Fls) - 1; k >= 0; k-- {
+			i = fastpath.PutFixed32(b, i, math.Float32bits(x.Fls[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x62
+	}
+	if len(x.F64S) > 0 {
+		j := i
+		for k := len(x.F64S) - 1; k >= 0; k-- {
+			i = fastpath.PutFixed64(b, i, x.F64S[k])
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x5a
+	}
+	if len(x.U64S) > 0 {
+		j := i
+		for k := len(x.U64S) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, x.U64S[k])
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x52
+	}
+	if len(x.Sf64S) > 0 {
+		j := i
+		for k := len(x.Sf64S) - 1; k >= 0; k-- {
+			i = fastpath.PutFixed64(b, i, uint64(x.Sf64S[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x4a
+	}
+	if len(x.S64S) > 0 {
+		j := i
+		for k := len(x.S64S) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, protowire.EncodeZigZag(x.S64S[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x42
+	}
+	if len(x.I64S) > 0 {
+		j := i
+		for k := len(x.I64S) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, uint64(x.I64S[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x3a
+	}
+	if len(x.F32S) > 0 {
+		j := i
+		for k := len(x.F32S) - 1; k >= 0; k-- {
+			i = fastpath.PutFixed32(b, i, x.F32S[k])
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x32
+	}
+	if len(x.U32S) > 0 {
+		j := i
+		for k := len(x.U32S) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, uint64(x.U32S[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x2a
+	}
+	if len(x.Sf32S) > 0 {
+		j := i
+		for k := len(x.Sf32S) - 1; k >= 0; k-- {
+			i = fastpath.PutFixed32(b, i, uint32(x.Sf32S[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x22
+	}
+	if len(x.S32S) > 0 {
+		j := i
+		for k := len(x.S32S) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, protowire.EncodeZigZag(int64(x.S32S[k])))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x1a
+	}
+	if len(x.I32S) > 0 {
+		j := i
+		for k := len(x.I32S) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, uint64(x.I32S[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x12
+	}
+	if len(x.Bs) > 0 {
+		j := i
+		for k := len(x.Bs) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, protowire.EncodeBool(x.Bs[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0xa
+	}
+	return len(b) - i, nil
+}
+
+// Unmarshal merges the wire-format encoding in b into x, as by
+// proto.UnmarshalMerge, without the use of protobuf reflection. Required
+// fields are not checked.
+func (x *Lists) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n, err := fastpath.ConsumeTag(b)
+		if err != nil {
+			return err
+		}
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.Bs = append(x.Bs, protowire.DecodeBool(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 1 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Bs = append(x.Bs, protowire.DecodeBool(v))
+			n += m
+		case num == 2 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.I32S = append(x.I32S, int32(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 2 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.I32S = append(x.I32S, int32(v))
+			n += m
+		case num == 3 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.S32S = append(x.S32S, int32(protowire.DecodeZigZag(e&math.MaxUint32)))
+				v = v[k:]
+			}
+			n += m
+		case num == 3 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.S32S = append(x.S32S, int32(protowire.DecodeZigZag(v&math.MaxUint32)))
+			n += m
+		case num == 4 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeFixed32(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.Sf32S = append(x.Sf32S, int32(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 4 && typ == protowire.Fixed32Type:
+			v, m := protowire.ConsumeFixed32(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Sf32S = append(x.Sf32S, int32(v))
+			n += m
+		case num == 5 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.U32S = append(x.U32S, uint32(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 5 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.U32S = append(x.U32S, uint32(v))
+			n += m
+		case num == 6 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeFixed32(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.F32S = append(x.F32S, e)
+				v = v[k:]
+			}
+			n += m
+		case num == 6 && typ == protowire.Fixed32Type:
+			v, m := protowire.ConsumeFixed32(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.F32S = append(x.F32S, v)
+			n += m
+		case num == 7 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.I64S = append(x.I64S, int64(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 7 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.I64S = append(x.I64S, int64(v))
+			n += m
+		case num == 8 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.S64S = append(x.S64S, protowire.DecodeZigZag(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 8 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.S64S = append(x.S64S, protowire.DecodeZigZag(v))
+			n += m
+		case num == 9 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeFixed64(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.Sf64S = append(x.Sf64S, int64(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 9 && typ == protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Sf64S = append(x.Sf64S, int64(v))
+			n += m
+		case num == 10 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.U64S = append(x.U64S, e)
+				v = v[k:]
+			}
+			n += m
+		case num == 10 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.U64S = append(x.U64S, v)
+			n += m
+		case num == 11 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeFixed64(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.F64S = append(x.F64S, e)
+				v = v[k:]
+			}
+			n += m
+		case num == 11 && typ == protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.F64S = append(x.F64S, v)
+			n += m
+		case num == 12 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeFixed32(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.Fls = append(x.Fls, math.Float32frombits(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 12 && typ == protowire.Fixed32Type:
+			v, m := protowire.ConsumeFixed32(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Fls = append(x.Fls, math.Float32frombits(v))
+			n += m
+		case num == 13 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeFixed64(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.Dbs = append(x.Dbs, math.Float64frombits(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 13 && typ == protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Dbs = append(x.Dbs, math.Float64frombits(v))
+			n += m
+		case num == 14 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.Colors = append(x.Colors, Color(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 14 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Colors = append(x.Colors, Color(v))
+			n += m
+		case num == 15 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.UnpackedI32S = append(x.UnpackedI32S, int32(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 15 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.UnpackedI32S = append(x.UnpackedI32S, int32(v))
+			n += m
+		case num == 16 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			var key int32
+			var val string
+			for len(v) > 0 {
+				num, typ, k, err := fastpath.ConsumeTag(v)
+				if err != nil {
+					return err
+				}
+				switch {
+				case num == 1 && typ == protowire.VarintType:
+					e, j := protowire.ConsumeVarint(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					key = int32(protowire.DecodeZigZag(e & math.MaxUint32))
+					k += j
+				case num == 2 && typ == protowire.BytesType:
+					e, j := protowire.ConsumeBytes(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					if !utf8.Valid(e) {
+						return fastpath.InvalidUTF8("fastpath_test.Lists.StringsByS32Entry.value")
+					}
+					val = string(e)
+					k += j
+				default:
+					j := protowire.ConsumeFieldValue(num, typ, v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					k += j
+				}
+				v = v[k:]
+			}
+			if x.StringsByS32 == nil {
+				x.StringsByS32 = make(map[int32]string)
+			}
+			x.StringsByS32[key] = val
+			n += m
+		case num == 17 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			var key uint64
+			var val *Scalars
+			for len(v) > 0 {
+				num, typ, k, err := fastpath.ConsumeTag(v)
+				if err != nil {
+					return err
+				}
+				switch {
+				case num == 1 && typ == protowire.Fixed64Type:
+					e, j := protowire.ConsumeFixed64(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					key = e
+					k += j
+				case num == 2 && typ == protowire.BytesType:
+					e, j := protowire.ConsumeBytes(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					if val == nil {
+						val = new(Scalars)
+					}
+					if err := val.Unmarshal(e); err != nil {
+						return err
+					}
+					k += j
+				default:
+					j := protowire.ConsumeFieldValue(num, typ, v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					k += j
+				}
+				v = v[k:]
+			}
+			if val == nil {
+				val = new(Scalars)
+			}
+			if x.ScalarsByF64 == nil {
+				x.ScalarsByF64 = make(map[uint64]*Scalars)
+			}
+			x.ScalarsByF64[key] = val
+			n += m
+		case num == 18 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			var key string
+			var val string
+			for len(v) > 0 {
+				num, typ, k, err := fastpath.ConsumeTag(v)
+				if err != nil {
+					return err
+				}
+				switch {
+				case num == 1 && typ == protowire.BytesType:
+					e, j := protowire.ConsumeBytes(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					if !utf8.Valid(e) {
+						return fastpath.InvalidUTF8("fastpath_test.Lists.StringsEntry.key")
+					}
+					key = string(e)
+					k += j
+				case num == 2 && typ == protowire.BytesType:
+					e, j := protowire.ConsumeBytes(v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					if !utf8.Valid(e) {
+						return fastpath.InvalidUTF8("fastpath_test.Lists.StringsEntry.value")
+					}
+					val = string(e)
+					k += j
+				default:
+					j := protowire.ConsumeFieldValue(num, typ, v[k:])
+					if j < 0 {
+						return protowire.ParseError(j)
+					}
+					k += j
+				}
+				v = v[k:]
+			}
+			if x.Strings == nil {
+				x.Strings = make(map[string]string)
+			}
+			x.Strings[key] = val
+			n += m
+		case num == 536870911 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Far = int32(v)
+			n += m
+		default:
+			m := protowire.ConsumeFieldValue(num, typ, b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			// As in protobuf reflection, the tag is stored in its shortest form.
+			x.unknownFields = protowire.AppendTag(x.unknownFields, num, typ)
+			x.unknownFields = append(x.unknownFields, b[n:n+m]...)
+			n += m
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// Equal reports whether x and y are equal, as by proto.Equal, without the
+// use of protobuf reflection. A nil message is only equal to nil.
+func (x *Sized) Equal(y *Sized) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	if x.Size != y.Size {
+		return false
+	}
+	return fastpath.EqualUnknown(x.unknownFields, y.unknownFields)
+}
+
+// Clone returns a deep copy of x, as by proto.Clone, without the use of
+// protobuf reflection.
+func (x *Sized) Clone() *Sized {
+	if x == nil {
+		return nil
+	}
+	y := new(Sized)
+	y.MergeFrom(x)
+	return y
+}
+
+// MergeFrom merges src into x, as by proto.Merge, without the use of
+// protobuf reflection.
+func (x *Sized) MergeFrom(src *Sized) {
+	if src == nil {
+		return
+	}
+	if src.Size != 0 {
+		x.Size = src.Size
+	}
+	if len(src.unknownFields) > 0 {
+		x.unknownFields = append(x.unknownFields, src.unknownFields...)
+	}
+}
+
+// XXX_Equal is used by proto.Equal.
+func (x *Sized) XXX_Equal(y protoiface.MessageV1) bool {
+	m, ok := y.(*Sized)
+	return ok && x.Equal(m)
+}
+
+// XXX_Clone is used by proto.Clone.
+func (x *Sized) XXX_Clone() protoiface.MessageV1 {
+	return x.Clone()
+}
+
+// XXX_MergeFrom is used by proto.Merge.
+func (x *Sized) XXX_MergeFrom(src protoiface.MessageV1) {
+	if m, ok := src.(*Sized); ok {
+		x.MergeFrom(m)
+		return
+	}
+	fastpath.MergeReflect(x, src)
+}
@@ -0,0 +1,1253 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        (unknown)
+// source: fastpath_proto/test2.proto
+
+package fastpath_proto
+
+import (
+	bytes "bytes"
+	fastpath "github.com/golang/protobuf/fastpath"
+	proto "github.com/golang/protobuf/proto"
+	protowire "google.golang.org/protobuf/encoding/protowire"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoiface "google.golang.org/protobuf/runtime/protoiface"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	math "math"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Legacy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	I32         *int32         `protobuf:"varint,1,opt,name=i32,def=7" json:"i32,omitempty"`
+	Fl          *float32       `protobuf:"fixed32,2,opt,name=fl" json:"fl,omitempty"`
+	Db          *float64       `protobuf:"fixed64,3,opt,name=db" json:"db,omitempty"`
+	S           *string        `protobuf:"bytes,4,opt,name=s,def=hello" json:"s,omitempty"`
+	By          []byte         `protobuf:"bytes,5,opt,name=by" json:"by,omitempty"`
+	Id          *int64         `protobuf:"varint,6,req,name=id" json:"id,omitempty"`
+	Group       *Legacy_Group  `protobuf:"group,7,opt,name=Group,json=group" json:"group,omitempty"`
+	Item        []*Legacy_Item `protobuf:"group,10,rep,name=Item,json=item" json:"item,omitempty"`
+	Extendable  *Extendable    `protobuf:"bytes,12,opt,name=extendable" json:"extendable,omitempty"`
+	Extendables []*Extendable  `protobuf:"bytes,13,rep,name=extendables" json:"extendables,omitempty"`
+	S64S        []int64        `protobuf:"zigzag64,14,rep,name=s64s" json:"s64s,omitempty"`
+	Fls         []float32      `protobuf:"fixed32,15,rep,name=fls" json:"fls,omitempty"`
+	Bs          []bool         `protobuf:"varint,16,rep,packed,name=bs" json:"bs,omitempty"`
+	S32         *int32         `protobuf:"zigzag32,17,opt,name=s32" json:"s32,omitempty"`
+	F64         *uint64        `protobuf:"fixed64,18,opt,name=f64" json:"f64,omitempty"`
+	B           *bool          `protobuf:"varint,19,opt,name=b" json:"b,omitempty"`
+}
+
+// Default values for Legacy fields.
+const (
+	Default_Legacy_I32 = int32(7)
+	Default_Legacy_S   = string("hello")
+)
+
+func (x *Legacy) Reset() {
+	*x = Legacy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fastpath_proto_test2_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Legacy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Legacy) ProtoMessage() {}
+
+func (x *Legacy) ProtoReflect() protoreflect.Message {
+	mi := &file_fastpath_proto_test2_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Legacy.ProtoReflect.Descriptor instead.
+func (*Legacy) Descriptor() ([]byte, []int) {
+	return file_fastpath_proto_test2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Legacy) GetI32() int32 {
+	if x != nil && x.I32 != nil {
+		return *x.I32
+	}
+	return Default_Legacy_I32
+}
+
+func (x *Legacy) GetFl() float32 {
+	if x != nil && x.Fl != nil {
+		return *x.Fl
+	}
+	return 0
+}
+
+func (x *Legacy) GetDb() float64 {
+	if x != nil && x.Db != nil {
+		return *x.Db
+	}
+	return 0
+}
+
+func (x *Legacy) GetS() string {
+	if x != nil && x.S != nil {
+		return *x.S
+	}
+	return Default_Legacy_S
+}
+
+func (x *Legacy) GetBy() []byte {
+	if x != nil {
+		return x.By
+	}
+	return nil
+}
+
+func (x *Legacy) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+func (x *Legacy) GetGroup() *Legacy_Group {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *Legacy) GetItem() []*Legacy_Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *Legacy) GetExtendable() *Extendable {
+	if x != nil {
+		return x.Extendable
+	}
+	return nil
+}
+
+func (x *Legacy) GetExtendables() []*Extendable {
+	if x != nil {
+		return x.Extendables
+	}
+	return nil
+}
+
+func (x *Legacy) GetS64S() []int64 {
+	if x != nil {
+		return x.S64S
+	}
+	return nil
+}
+
+func (x *Legacy) GetFls() []float32 {
+	if x != nil {
+		return x.Fls
+	}
+	return nil
+}
+
+func (x *Legacy) GetBs() []bool {
+	if x != nil {
+		return x.Bs
+	}
+	return nil
+}
+
+func (x *Legacy) GetS32() int32 {
+	if x != nil && x.S32 != nil {
+		return *x.S32
+	}
+	return 0
+}
+
+func (x *Legacy) GetF64() uint64 {
+	if x != nil && x.F64 != nil {
+		return *x.F64
+	}
+	return 0
+}
+
+func (x *Legacy) GetB() bool {
+	if x != nil && x.B != nil {
+		return *x.B
+	}
+	return false
+}
+
+// Extendable has extensions, and so no methods.
+type Extendable struct {
+	state           protoimpl.MessageState
+	sizeCache       protoimpl.SizeCache
+	unknownFields   protoimpl.UnknownFields
+	extensionFields protoimpl.ExtensionFields
+
+	Name *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (x *Extendable) Reset() {
+	*x = Extendable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fastpath_proto_test2_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Extendable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Extendable) ProtoMessage() {}
+
+func (x *Extendable) ProtoReflect() protoreflect.Message {
+	mi := &file_fastpath_proto_test2_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Extendable.ProtoReflect.Descriptor instead.
+func (*Extendable) Descriptor() ([]byte, []int) {
+	return file_fastpath_proto_test2_proto_rawDescGZIP(), []int{1}
+}
+
+var extRange_Extendable = []protoiface.ExtensionRangeV1{
+	{Start: 100, End: 536870911},
+}
+
+// Deprecated: Use Extendable.ProtoReflect.Descriptor.ExtensionRanges instead.
+func (*Extendable) ExtensionRangeArray() []protoiface.ExtensionRangeV1 {
+	return extRange_Extendable
+}
+
+func (x *Extendable) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+type Legacy_Group struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   *string `protobuf:"bytes,8,opt,name=name" json:"name,omitempty"`
+	Values []int32 `protobuf:"varint,9,rep,packed,name=values" json:"values,omitempty"`
+}
+
+func (x *Legacy_Group) Reset() {
+	*x = Legacy_Group{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fastpath_proto_test2_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Legacy_Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Legacy_Group) ProtoMessage() {}
+
+func (x *Legacy_Group) ProtoReflect() protoreflect.Message {
+	mi := &file_fastpath_proto_test2_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Legacy_Group.ProtoReflect.Descriptor instead.
+func (*Legacy_Group) Descriptor() ([]byte, []int) {
+	return file_fastpath_proto_test2_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Legacy_Group) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Legacy_Group) GetValues() []int32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Legacy_Item struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *int32 `protobuf:"varint,11,opt,name=value" json:"value,omitempty"`
+}
+
+func (x *Legacy_Item) Reset() {
+	*x = Legacy_Item{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fastpath_proto_test2_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Legacy_Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Legacy_Item) ProtoMessage() {}
+
+func (x *Legacy_Item) ProtoReflect() protoreflect.Message {
+	mi := &file_fastpath_proto_test2_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Legacy_Item.ProtoReflect.Descriptor instead.
+func (*Legacy_Item) Descriptor() ([]byte, []int) {
+	return file_fastpath_proto_test2_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *Legacy_Item) GetValue() int32 {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return 0
+}
+
+var file_fastpath_proto_test2_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*Extendable)(nil),
+		ExtensionType: (*int32)(nil),
+		Field:         100,
+		Name:          "fastpath_test.count",
+		Tag:           "varint,100,opt,name=count",
+		Filename:      "fastpath_proto/test2.proto",
+	},
+}
+
+// Extension fields to Extendable.
+var (
+	// optional int32 count = 100;
+	E_Count = &file_fastpath_proto_test2_proto_extTypes[0]
+)
+
+var File_fastpath_proto_test2_proto protoreflect.FileDescriptor
+
+var file_fastpath_proto_test2_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x74, 0x65, 0x73, 0x74, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x66, 0x61,
+	0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x66, 0x61, 0x73,
+	0x74, 0x70, 0x61, 0x74, 0x68, 0x2f, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x96, 0x04, 0x0a, 0x06, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x12,
+	0x13, 0x0a, 0x03, 0x69, 0x33, 0x32, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x37, 0x52,
+	0x03, 0x69, 0x33, 0x32, 0x12, 0x0e, 0x0a, 0x02, 0x66, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x02, 0x66, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x62, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x02, 0x64, 0x62, 0x12, 0x13, 0x0a, 0x01, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x3a,
+	0x05, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x01, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x62, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x62, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x06, 0x20, 0x02, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x31, 0x0a, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0a, 0x32, 0x1b, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70,
+	0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x2e,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2e, 0x0a, 0x04,
+	0x69, 0x74, 0x65, 0x6d, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0a, 0x32, 0x1a, 0x2e, 0x66, 0x61, 0x73,
+	0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63,
+	0x79, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x12, 0x39, 0x0a, 0x0a,
+	0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0a, 0x65, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x64, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x66,
+	0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x78, 0x74,
+	0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0b, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x36, 0x34, 0x73, 0x18, 0x0e, 0x20, 0x03,
+	0x28, 0x12, 0x52, 0x04, 0x73, 0x36, 0x34, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x6c, 0x73, 0x18,
+	0x0f, 0x20, 0x03, 0x28, 0x02, 0x52, 0x03, 0x66, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x02, 0x62, 0x73,
+	0x18, 0x10, 0x20, 0x03, 0x28, 0x08, 0x42, 0x02, 0x10, 0x01, 0x52, 0x02, 0x62, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x33, 0x32, 0x18, 0x11, 0x20, 0x01, 0x28, 0x11, 0x52, 0x03, 0x73, 0x33, 0x32,
+	0x12, 0x10, 0x0a, 0x03, 0x66, 0x36, 0x34, 0x18, 0x12, 0x20, 0x01, 0x28, 0x06, 0x52, 0x03, 0x66,
+	0x36, 0x34, 0x12, 0x0c, 0x0a, 0x01, 0x62, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x01, 0x62,
+	0x1a, 0x37, 0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x05, 0x42, 0x02, 0x10,
+	0x01, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x1c, 0x0a, 0x04, 0x49, 0x74, 0x65,
+	0x6d, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x04, 0xd8, 0xaa, 0x1f, 0x01, 0x22, 0x2a, 0x0a,
+	0x0a, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x2a,
+	0x08, 0x08, 0x64, 0x10, 0x80, 0x80, 0x80, 0x80, 0x02, 0x3a, 0x2f, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x19, 0x2e, 0x66, 0x61, 0x73, 0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x74, 0x65,
+	0x73, 0x74, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x64, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x66, 0x61, 0x73,
+	0x74, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_fastpath_proto_test2_proto_rawDescOnce sync.Once
+	file_fastpath_proto_test2_proto_rawDescData = file_fastpath_proto_test2_proto_rawDesc
+)
+
+func file_fastpath_proto_test2_proto_rawDescGZIP() []byte {
+	file_fastpath_proto_test2_proto_rawDescOnce.Do(func() {
+		file_fastpath_proto_test2_proto_rawDescData = protoimpl.X.CompressGZIP(file_fastpath_proto_test2_proto_rawDescData)
+	})
+	return file_fastpath_proto_test2_proto_rawDescData
+}
+
+var file_fastpath_proto_test2_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_fastpath_proto_test2_proto_goTypes = []interface{}{
+	(*Legacy)(nil),       // 0: fastpath_test.Legacy
+	(*Extendable)(nil),   // 1: fastpath_test.Extendable
+	(*Legacy_Group)(nil), // 2: fastpath_test.Legacy.Group
+	(*Legacy_Item)(nil),  // 3: fastpath_test.Legacy.Item
+}
+var file_fastpath_proto_test2_proto_depIdxs = []int32{
+	2, // 0: fastpath_test.Legacy.group:type_name -> fastpath_test.Legacy.Group
+	3, // 1: fastpath_test.Legacy.item:type_name -> fastpath_test.Legacy.Item
+	1, // 2: fastpath_test.Legacy.extendable:type_name -> fastpath_test.Extendable
+	1, // 3: fastpath_test.Legacy.extendables:type_name -> fastpath_test.Extendable
+	1, // 4: fastpath_test.count:extendee -> fastpath_test.Extendable
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	4, // [4:5] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_fastpath_proto_test2_proto_init() }
+func file_fastpath_proto_test2_proto_init() {
+	if File_fastpath_proto_test2_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_fastpath_proto_test2_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Legacy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fastpath_proto_test2_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Extendable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			case 3:
+				return &v.extensionFields
+			default:
+				return nil
+			}
+		}
+		file_fastpath_proto_test2_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Legacy_Group); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fastpath_proto_test2_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Legacy_Item); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_fastpath_proto_test2_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_fastpath_proto_test2_proto_goTypes,
+		DependencyIndexes: file_fastpath_proto_test2_proto_depIdxs,
+		MessageInfos:      file_fastpath_proto_test2_proto_msgTypes,
+		ExtensionInfos:    file_fastpath_proto_test2_proto_extTypes,
+	}.Build()
+	File_fastpath_proto_test2_proto = out.File
+	file_fastpath_proto_test2_proto_rawDesc = nil
+	file_fastpath_proto_test2_proto_goTypes = nil
+	file_fastpath_proto_test2_proto_depIdxs = nil
+}
+
+// Equal reports whether x and y are equal, as by proto.Equal, without the
+// use of protobuf reflection. A nil message is only equal to nil.
+func (x *Legacy) Equal(y *Legacy) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	if (x.I32 == nil) != (y.I32 == nil) || x.I32 != nil && *x.I32 != *y.I32 {
+		return false
+	}
+	if (x.Fl == nil) != (y.Fl == nil) || x.Fl != nil && !fastpath.EqualFloat64(float64(*x.Fl), float64(*y.Fl)) {
+		return false
+	}
+	if (x.Db == nil) != (y.Db == nil) || x.Db != nil && !fastpath.EqualFloat64(*x.Db, *y.Db) {
+		return false
+	}
+	if (x.S == nil) != (y.S == nil) || x.S != nil && *x.S != *y.S {
+		return false
+	}
+	if (x.By == nil) != (y.By == nil) || !bytes.Equal(x.By, y.By) {
+		return false
+	}
+	if (x.Id == nil) != (y.Id == nil) || x.Id != nil && *x.Id != *y.Id {
+		return false
+	}
+	if (x.Group == nil) != (y.Group == nil) || x.Group != nil && !x.Group.Equal(y.Group) {
+		return false
+	}
+	if len(x.Item) != len(y.Item) {
+		return false
+	}
+	for i, vx := range x.Item {
+		if !fastpath.EqualMessage(vx, y.Item[i]) {
+			return false
+		}
+	}
+	if (x.Extendable == nil) != (y.Extendable == nil) || x.Extendable != nil && !proto.Equal(x.Extendable, y.Extendable) {
+		return false
+	}
+	if len(x.Extendables) != len(y.Extendables) {
+		return false
+	}
+	for i, vx := range x.Extendables {
+		if !fastpath.EqualMessage(vx, y.Extendables[i]) {
+			return false
+		}
+	}
+	if len(x.S64S) != len(y.S64S) {
+		return false
+	}
+	for i, vx := range x.S64S {
+		if vx != y.S64S[i] {
+			return false
+		}
+	}
+	if len(x.Fls) != len(y.Fls) {
+		return false
+	}
+	for i, vx := range x.Fls {
+		if !fastpath.EqualFloat64(float64(vx), float64(y.Fls[i])) {
+			return false
+		}
+	}
+	if len(x.Bs) != len(y.Bs) {
+		return false
+	}
+	for i, vx := range x.Bs {
+		if vx != y.Bs[i] {
+			return false
+		}
+	}
+	if (x.S32 == nil) != (y.S32 == nil) || x.S32 != nil && *x.S32 != *y.S32 {
+		return false
+	}
+	if (x.F64 == nil) != (y.F64 == nil) || x.F64 != nil && *x.F64 != *y.F64 {
+		return false
+	}
+	if (x.B == nil) != (y.B == nil) || x.B != nil && *x.B != *y.B {
+		return false
+	}
+	return fastpath.EqualUnknown(x.unknownFields, y.unknownFields)
+}
+
+// Clone returns a deep copy of x, as by proto.Clone, without the use of
+// protobuf reflection.
+func (x *Legacy) Clone() *Legacy {
+	if x == nil {
+		return nil
+	}
+	y := new(Legacy)
+	y.MergeFrom(x)
+	return y
+}
+
+// MergeFrom merges src into x, as by proto.Merge, without the use of
+// protobuf reflection.
+func (x *Legacy) MergeFrom(src *Legacy) {
+	if src == nil {
+		return
+	}
+	if src.I32 != nil {
+		v := *src.I32
+		x.I32 = &v
+	}
+	if src.Fl != nil {
+		v := *src.Fl
+		x.Fl = &v
+	}
+	if src.Db != nil {
+		v := *src.Db
+		x.Db = &v
+	}
+	if src.S != nil {
+		v := *src.S
+		x.S = &v
+	}
+	if src.By != nil {
+		x.By = append([]byte{}, src.By...)
+	}
+	if src.Id != nil {
+		v := *src.Id
+		x.Id = &v
+	}
+	if src.Group != nil {
+		if x.Group == nil {
+			x.Group = new(Legacy_Group)
+		}
+		x.Group.MergeFrom(src.Group)
+	}
+	for _, v := range src.Item {
+		m := new(Legacy_Item)
+		m.MergeFrom(v)
+		x.Item = append(x.Item, m)
+	}
+	if src.Extendable != nil {
+		if x.Extendable == nil {
+			x.Extendable = new(Extendable)
+		}
+		proto.Merge(x.Extendable, src.Extendable)
+	}
+	for _, v := range src.Extendables {
+		m := new(Extendable)
+		proto.Merge(m, v)
+		x.Extendables = append(x.Extendables, m)
+	}
+	x.S64S = append(x.S64S, src.S64S...)
+	x.Fls = append(x.Fls, src.Fls...)
+	x.Bs = append(x.Bs, src.Bs...)
+	if src.S32 != nil {
+		v := *src.S32
+		x.S32 = &v
+	}
+	if src.F64 != nil {
+		v := *src.F64
+		x.F64 = &v
+	}
+	if src.B != nil {
+		v := *src.B
+		x.B = &v
+	}
+	if len(src.unknownFields) > 0 {
+		x.unknownFields = append(x.unknownFields, src.unknownFields...)
+	}
+}
+
+// XXX_Equal is used by proto.Equal.
+func (x *Legacy) XXX_Equal(y protoiface.MessageV1) bool {
+	m, ok := y.(*Legacy)
+	return ok && x.Equal(m)
+}
+
+// XXX_Clone is used by proto.Clone.
+func (x *Legacy) XXX_Clone() protoiface.MessageV1 {
+	return x.Clone()
+}
+
+// XXX_MergeFrom is used by proto.Merge.
+func (x *Legacy) XXX_MergeFrom(src protoiface.MessageV1) {
+	if m, ok := src.(*Legacy); ok {
+		x.MergeFrom(m)
+		return
+	}
+	fastpath.MergeReflect(x, src)
+}
+
+// Size returns the size in bytes of the wire-format encoding of x, as by
+// proto.Size, without the use of protobuf reflection.
+func (x *Legacy) Size() (n int) {
+	if x == nil {
+		return 0
+	}
+	if x.I32 != nil {
+		n += 1 + protowire.SizeVarint(uint64(*x.I32))
+	}
+	if x.Fl != nil {
+		n += 5
+	}
+	if x.Db != nil {
+		n += 9
+	}
+	if x.S != nil {
+		n += 1 + protowire.SizeBytes(len(*x.S))
+	}
+	if x.By != nil {
+		n += 1 + protowire.SizeBytes(len(x.By))
+	}
+	if x.Id != nil {
+		n += 1 + protowire.SizeVarint(uint64(*x.Id))
+	}
+	if x.Group != nil {
+		n += 1 + 1 + proto.Size(x.Group)
+	}
+	for _, v := range x.Item {
+		n += 1 + 1 + proto.Size(v)
+	}
+	if x.Extendable != nil {
+		n += 1 + protowire.SizeBytes(proto.Size(x.Extendable))
+	}
+	for _, v := range x.Extendables {
+		n += 1 + protowire.SizeBytes(proto.Size(v))
+	}
+	for _, v := range x.S64S {
+		n += 1 + protowire.SizeVarint(protowire.EncodeZigZag(v))
+	}
+	n += 5 * len(x.Fls)
+	if len(x.Bs) > 0 {
+		s := 1 * len(x.Bs)
+		n += 2 + protowire.SizeBytes(s)
+	}
+	if x.S32 != nil {
+		n += 2 + protowire.SizeVarint(protowire.EncodeZigZag(int64(*x.S32)))
+	}
+	if x.F64 != nil {
+		n += 10
+	}
+	if x.B != nil {
+		n += 3
+	}
+	n += len(x.unknownFields)
+	return n
+}
+
+// Marshal returns the wire-format encoding of x, as by proto.Marshal with
+// deterministic ordering of map entries, without the use of protobuf
+// reflection.
+func (x *Legacy) Marshal() ([]byte, error) {
+	b := make([]byte, x.Size())
+	n, err := x.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[len(b)-n:], nil
+}
+
+// MarshalToSizedBuffer writes the wire-format encoding of x at the end of b,
+// which must be at least x.Size() bytes long, and returns its length.
+func (x *Legacy) MarshalToSizedBuffer(b []byte) (int, error) {
+	if x == nil {
+		return 0, nil
+	}
+	i := len(b)
+	if len(x.unknownFields) > 0 {
+		i -= len(x.unknownFields)
+		copy(b[i:], x.unknownFields)
+	}
+	if x.B != nil {
+		i = fastpath.PutVarint(b, i, protowire.EncodeBool(*x.B))
+		i -= 2
+		b[i] = 0x98
+		b[i+1] = 0x1
+	}
+	if x.F64 != nil {
+		i = fastpath.PutFixed64(b, i, *x.F64)
+		i -= 2
+		b[i] = 0x91
+		b[i+1] = 0x1
+	}
+	if x.S32 != nil {
+		i = fastpath.PutVarint(b, i, protowire.EncodeZigZag(int64(*x.S32)))
+		i -= 2
+		b[i] = 0x88
+		b[i+1] = 0x1
+	}
+	if len(x.Bs) > 0 {
+		j := i
+		for k := len(x.Bs) - 1; k >= 0; k-- {
+			i = fastpath.PutVarint(b, i, protowire.EncodeBool(x.Bs[k]))
+		}
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i -= 2
+		b[i] = 0x82
+		b[i+1] = 0x1
+	}
+	for k := len(x.Fls) - 1; k >= 0; k-- {
+		i = fastpath.PutFixed32(b, i, math.Float32bits(x.Fls[k]))
+		i--
+		b[i] = 0x7d
+	}
+	for k := len(x.S64S) - 1; k >= 0; k-- {
+		i = fastpath.PutVarint(b, i, protowire.EncodeZigZag(x.S64S[k]))
+		i--
+		b[i] = 0x70
+	}
+	for k := len(x.Extendables) - 1; k >= 0; k-- {
+		j := i
+		s, err := fastpath.MarshalMessage(b[:i], x.Extendables[k])
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x6a
+	}
+	if x.Extendable != nil {
+		j := i
+		s, err := fastpath.MarshalMessage(b[:i], x.Extendable)
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i = fastpath.PutVarint(b, i, uint64(j-i))
+		i--
+		b[i] = 0x62
+	}
+	for k := len(x.Item) - 1; k >= 0; k-- {
+		i--
+		b[i] = 0x54
+		s, err := fastpath.MarshalMessage(b[:i], x.Item[k])
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i--
+		b[i] = 0x53
+	}
+	if x.Group != nil {
+		i--
+		b[i] = 0x3c
+		s, err := fastpath.MarshalMessage(b[:i], x.Group)
+		if err != nil {
+			return 0, err
+		}
+		i -= s
+		i--
+		b[i] = 0x3b
+	}
+	if x.Id != nil {
+		i = fastpath.PutVarint(b, i, uint64(*x.Id))
+		i--
+		b[i] = 0x30
+	}
+	if x.By != nil {
+		i = fastpath.PutBytes(b, i, x.By)
+		i--
+		b[i] = 0x2a
+	}
+	if x.S != nil {
+		i = fastpath.PutString(b, i, *x.S)
+		i--
+		b[i] = 0x22
+	}
+	if x.Db != nil {
+		i = fastpath.PutFixed64(b, i, math.Float64bits(*x.Db))
+		i--
+		b[i] = 0x19
+	}
+	if x.Fl != nil {
+		i = fastpath.PutFixed32(b, i, math.Float32bits(*x.Fl))
+		i--
+		b[i] = 0x15
+	}
+	if x.I32 != nil {
+		i = fastpath.PutVarint(b, i, uint64(*x.I32))
+		i--
+		b[i] = 0x8
+	}
+	return len(b) - i, nil
+}
+
+// Unmarshal merges the wire-format encoding in b into x, as by
+// proto.UnmarshalMerge, without the use of protobuf reflection. Required
+// fields are not checked.
+func (x *Legacy) Unmarshal(b []byte) error {
+	for len(b) > 0 {
+		num, typ, n, err := fastpath.ConsumeTag(b)
+		if err != nil {
+			return err
+		}
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := int32(v)
+			x.I32 = &s
+			n += m
+		case num == 2 && typ == protowire.Fixed32Type:
+			v, m := protowire.ConsumeFixed32(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := math.Float32frombits(v)
+			x.Fl = &s
+			n += m
+		case num == 3 && typ == protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := math.Float64frombits(v)
+			x.Db = &s
+			n += m
+		case num == 4 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := string(v)
+			x.S = &s
+			n += m
+		case num == 5 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.By = append([]byte{}, v...)
+			n += m
+		case num == 6 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := int64(v)
+			x.Id = &s
+			n += m
+		case num == 7 && typ == protowire.StartGroupType:
+			v, m := protowire.ConsumeGroup(num, b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if x.Group == nil {
+				x.Group = new(Legacy_Group)
+			}
+			if err := fastpath.UnmarshalMessage(v, x.Group); err != nil {
+				return err
+			}
+			n += m
+		case num == 10 && typ == protowire.StartGroupType:
+			v, m := protowire.ConsumeGroup(num, b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			e := new(Legacy_Item)
+			if err := fastpath.UnmarshalMessage(v, e); err != nil {
+				return err
+			}
+			x.Item = append(x.Item, e)
+			n += m
+		case num == 12 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			if x.Extendable == nil {
+				x.Extendable = new(Extendable)
+			}
+			if err := fastpath.UnmarshalMessage(v, x.Extendable); err != nil {
+				return err
+			}
+			n += m
+		case num == 13 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			e := new(Extendable)
+			if err := fastpath.UnmarshalMessage(v, e); err != nil {
+				return err
+			}
+			x.Extendables = append(x.Extendables, e)
+			n += m
+		case num == 14 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.S64S = append(x.S64S, protowire.DecodeZigZag(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 14 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.S64S = append(x.S64S, protowire.DecodeZigZag(v))
+			n += m
+		case num == 15 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeFixed32(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.Fls = append(x.Fls, math.Float32frombits(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 15 && typ == protowire.Fixed32Type:
+			v, m := protowire.ConsumeFixed32(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Fls = append(x.Fls, math.Float32frombits(v))
+			n += m
+		case num == 16 && typ == protowire.BytesType:
+			v, m := protowire.ConsumeBytes(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			for len(v) > 0 {
+				e, k := protowire.ConsumeVarint(v)
+				if k < 0 {
+					return protowire.ParseError(k)
+				}
+				x.Bs = append(x.Bs, protowire.DecodeBool(e))
+				v = v[k:]
+			}
+			n += m
+		case num == 16 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			x.Bs = append(x.Bs, protowire.DecodeBool(v))
+			n += m
+		case num == 17 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := int32(protowire.DecodeZigZag(v & math.MaxUint32))
+			x.S32 = &s
+			n += m
+		case num == 18 && typ == protowire.Fixed64Type:
+			v, m := protowire.ConsumeFixed64(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := v
+			x.F64 = &s
+			n += m
+		case num == 19 && typ == protowire.VarintType:
+			v, m := protowire.ConsumeVarint(b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			s := protowire.DecodeBool(v)
+			x.B = &s
+			n += m
+		default:
+			m := protowire.ConsumeFieldValue(num, typ, b[n:])
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			// As in protobuf reflection, the tag is stored in its shortest form.
+			x.unknownFields = protowire.AppendTag(x.unknownFields, num, typ)
+			x.unknownFields = append(x.unknownFields, b[n:n+m]...)
+			n += m
+		}
+		b = b[n:]
+	}
+	return nil
+}
+
+// Equal reports whether x and y are equal, as by proto.Equal, without the
+// use of protobuf reflection. A nil message is only equal to nil.
+func (x *Legacy_Group) Equal(y *Legacy_Group) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	if (x.Name == nil) != (y.Name == nil) || x.Name != nil && *x.Name != *y.Name {
+		return false
+	}
+	if len(x.Values) != len(y.Values) {
+		return false
+	}
+	for i, vx := range x.Values {
+		if vx != y.Values[i] {
+			return false
+		}
+	}
+	return fastpath.EqualUnknown(x.unknownFields, y.unknownFields)
+}
+
+// Clone returns a deep copy of x, as by proto.Clone, without the use of
+// protobuf reflection.
+func (x *Legacy_Group) Clone() *Legacy_Group {
+	if x == nil {
+		return nil
+	}
+	y := new(Legacy_Group)
+	y.MergeFrom(x)
+	return y
+}
+
+// MergeFrom merges src into x, as by proto.Merge, without the use of
+// protobuf reflection.
+func (x *Legacy_Group) MergeFrom(src *Legacy_Group) {
+	if src == nil {
+		return
+	}
+	if src.Name != nil {
+		v := *src.Name
+		x.Name = &v
+	}
+	x.Values = append(x.Values, src.Values...)
+	if len(src.unknownFields) > 0 {
+		x.unknownFields = append(x.unknownFields, src.unknownFields...)
+	}
+}
+
+// XXX_Equal is used by proto.Equal.
+func (x *Legacy_Group) XXX_Equal(y protoiface.MessageV1) bool {
+	m, ok := y.(*Legacy_Group)
+	return ok && x.Equal(m)
+}
+
+// XXX_Clone is used by proto.Clone.
+func (x *Legacy_Group) XXX_Clone() protoiface.MessageV1 {
+	return x.Clone()
+}
+
+// XXX_MergeFrom is used by proto.Merge.
+func (x *Legacy_Group) XXX_MergeFrom(src protoiface.MessageV1) {
+	if m, ok := src.(*Legacy_Group); ok {
+		x.MergeFrom(m)
+		return
+	}
+	fastpath.MergeReflect(x, src)
+}
+
+// Equal reports whether x and y are equal, as by proto.Equal, without the
+// use of protobuf reflection. A nil message is only equal to nil.
+func (x *Legacy_Item) Equal(y *Legacy_Item) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	if (x.Value == nil) != (y.Value == nil) || x.Value != nil && *x.Value != *y.Value {
+		return false
+	}
+	return fastpath.EqualUnknown(x.unknownFields, y.unknownFields)
+}
+
+// Clone returns a deep copy of x, as by proto.Clone, without the use of
+// protobuf reflection.
+func (x *Legacy_Item) Clone() *Legacy_Item {
+	if x == nil {
+		return nil
+	}
+	y := new(Legacy_Item)
+	y.MergeFrom(x)
+	return y
+}
+
+// MergeFrom merges src into x, as by proto.Merge, without the use of
+// protobuf reflection.
+func (x *Legacy_Item) MergeFrom(src *Legacy_Item) {
+	if src == nil {
+		return
+	}
+	if src.Value != nil {
+		v := *src.Value
+		x.Value = &v
+	}
+	if len(src.unknownFields) > 0 {
+		x.unknownFields = append(x.unknownFields, src.unknownFields...)
+	}
+}
+
+// XXX_Equal is used by proto.Equal.
+func (x *Legacy_Item) XXX_Equal(y protoiface.MessageV1) bool {
+	m, ok := y.(*Legacy_Item)
+	return ok && x.Equal(m)
+}
+
+// XXX_Clone is used by proto.Clone.
+func (x *Legacy_Item) XXX_Clone() protoiface.MessageV1 {
+	return x.Clone()
+}
+
+// XXX_MergeFrom is used by proto.Merge.
+func (x *Legacy_Item) XXX_MergeFrom(src protoiface.MessageV1) {
+	if m, ok := src.(*Legacy_Item); ok {
+		x.MergeFrom(m)
+		return
+	}
+	fastpath.MergeReflect(x, src)
+}
@@ -0,0 +1,583 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        (unknown)
+// source: httprpc_proto/test.proto
+
+package httprpc_proto
+
+import (
+	context "context"
+	httprpc "github.com/golang/protobuf/httprpc"
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	http "net/http"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Book struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title   string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Authors []string `protobuf:"bytes,3,rep,name=authors,proto3" json:"authors,omitempty"`
+}
+
+func (x *Book) Reset() {
+	*x = Book{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_httprpc_proto_test_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Book) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Book) ProtoMessage() {}
+
+func (x *Book) ProtoReflect() protoreflect.Message {
+	mi := &file_httprpc_proto_test_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Book.ProtoReflect.Descriptor instead.
+func (*Book) Descriptor() ([]byte, []int) {
+	return file_httprpc_proto_test_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Book) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Book) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Book) GetAuthors() []string {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+type GetBookRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetBookRequest) Reset() {
+	*x = GetBookRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_httprpc_proto_test_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBookRequest) ProtoMessage() {}
+
+func (x *GetBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_httprpc_proto_test_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBookRequest.ProtoReflect.Descriptor instead.
+func (*GetBookRequest) Descriptor() ([]byte, []int) {
+	return file_httprpc_proto_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBookRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListBooksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Author   string `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
+	PageSize int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (x *ListBooksRequest) Reset() {
+	*x = ListBooksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_httprpc_proto_test_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBooksRequest) ProtoMessage() {}
+
+func (x *ListBooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_httprpc_proto_test_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBooksRequest.ProtoReflect.Descriptor instead.
+func (*ListBooksRequest) Descriptor() ([]byte, []int) {
+	return file_httprpc_proto_test_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListBooksRequest) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ListBooksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type UpdateBookRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Book  *Book  `protobuf:"bytes,1,opt,name=book,proto3" json:"book,omitempty"`
+	Shelf string `protobuf:"bytes,2,opt,name=shelf,proto3" json:"shelf,omitempty"`
+}
+
+func (x *UpdateBookRequest) Reset() {
+	*x = UpdateBookRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_httprpc_proto_test_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookRequest) ProtoMessage() {}
+
+func (x *UpdateBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_httprpc_proto_test_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBookRequest) Descriptor() ([]byte, []int) {
+	return file_httprpc_proto_test_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateBookRequest) GetBook() *Book {
+	if x != nil {
+		return x.Book
+	}
+	return nil
+}
+
+func (x *UpdateBookRequest) GetShelf() string {
+	if x != nil {
+		return x.Shelf
+	}
+	return ""
+}
+
+type UploadBooksResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count int32 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *UploadBooksResponse) Reset() {
+	*x = UploadBooksResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_httprpc_proto_test_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadBooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadBooksResponse) ProtoMessage() {}
+
+func (x *UploadBooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_httprpc_proto_test_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadBooksResponse.ProtoReflect.Descriptor instead.
+func (*UploadBooksResponse) Descriptor() ([]byte, []int) {
+	return file_httprpc_proto_test_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UploadBooksResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_httprpc_proto_test_proto protoreflect.FileDescriptor
+
+var file_httprpc_proto_test_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x68, 0x74, 0x74, 0x70, 0x72, 0x70, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x68, 0x74, 0x74, 0x70,
+	0x72, 0x70, 0x63, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x22, 0x46, 0x0a, 0x04, 0x42, 0x6f, 0x6f, 0x6b,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x73,
+	0x22, 0x20, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x47, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6f, 0x6f, 0x6b, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x51, 0x0a, 0x11, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x26, 0x0a, 0x04, 0x62, 0x6f, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x68, 0x74, 0x74, 0x70, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x6f,
+	0x6f, 0x6b, 0x52, 0x04, 0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x65, 0x6c,
+	0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x68, 0x65, 0x6c, 0x66, 0x22, 0x2b,
+	0x0a, 0x13, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x32, 0x94, 0x02, 0x0a, 0x07,
+	0x4c, 0x69, 0x62, 0x72, 0x61, 0x72, 0x79, 0x12, 0x3b, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x42, 0x6f,
+	0x6f, 0x6b, 0x12, 0x1c, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e,
+	0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x41, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6f, 0x6f, 0x6b,
+	0x73, 0x12, 0x1e, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x12, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x1f, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x72, 0x70, 0x63, 0x5f,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x72, 0x70, 0x63,
+	0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x12, 0x46, 0x0a, 0x0b, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x42, 0x6f, 0x6f, 0x6b, 0x73, 0x12, 0x12, 0x2e, 0x68, 0x74, 0x74, 0x70,
+	0x72, 0x70, 0x63, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x1a, 0x21, 0x2e,
+	0x68, 0x74, 0x74, 0x70, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x42, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x28, 0x01, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x68, 0x74, 0x74, 0x70, 0x72, 0x70, 0x63, 0x5f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_httprpc_proto_test_proto_rawDescOnce sync.Once
+	file_httprpc_proto_test_proto_rawDescData = file_httprpc_proto_test_proto_rawDesc
+)
+
+func file_httprpc_proto_test_proto_rawDescGZIP() []byte {
+	file_httprpc_proto_test_proto_rawDescOnce.Do(func() {
+		file_httprpc_proto_test_proto_rawDescData = protoimpl.X.CompressGZIP(file_httprpc_proto_test_proto_rawDescData)
+	})
+	return file_httprpc_proto_test_proto_rawDescData
+}
+
+var file_httprpc_proto_test_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_httprpc_proto_test_proto_goTypes = []interface{}{
+	(*Book)(nil),                // 0: httprpc_test.Book
+	(*GetBookRequest)(nil),      // 1: httprpc_test.GetBookRequest
+	(*ListBooksRequest)(nil),    // 2: httprpc_test.ListBooksRequest
+	(*UpdateBookRequest)(nil),   // 3: httprpc_test.UpdateBookRequest
+	(*UploadBooksResponse)(nil), // 4: httprpc_test.UploadBooksResponse
+}
+var file_httprpc_proto_test_proto_depIdxs = []int32{
+	0, // 0: httprpc_test.UpdateBookRequest.book:type_name -> httprpc_test.Book
+	1, // 1: httprpc_test.Library.GetBook:input_type -> httprpc_test.GetBookRequest
+	2, // 2: httprpc_test.Library.ListBooks:input_type -> httprpc_test.ListBooksRequest
+	3, // 3: httprpc_test.Library.UpdateBook:input_type -> httprpc_test.UpdateBookRequest
+	0, // 4: httprpc_test.Library.UploadBooks:input_type -> httprpc_test.Book
+	0, // 5: httprpc_test.Library.GetBook:output_type -> httprpc_test.Book
+	0, // 6: httprpc_test.Library.ListBooks:output_type -> httprpc_test.Book
+	0, // 7: httprpc_test.Library.UpdateBook:output_type -> httprpc_test.Book
+	4, // 8: httprpc_test.Library.UploadBooks:output_type -> httprpc_test.UploadBooksResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_httprpc_proto_test_proto_init() }
+func file_httprpc_proto_test_proto_init() {
+	if File_httprpc_proto_test_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_httprpc_proto_test_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Book); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_httprpc_proto_test_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBookRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_httprpc_proto_test_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBooksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_httprpc_proto_test_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateBookRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_httprpc_proto_test_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadBooksResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_httprpc_proto_test_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_httprpc_proto_test_proto_goTypes,
+		DependencyIndexes: file_httprpc_proto_test_proto_depIdxs,
+		MessageInfos:      file_httprpc_proto_test_proto_msgTypes,
+	}.Build()
+	File_httprpc_proto_test_proto = out.File
+	file_httprpc_proto_test_proto_rawDesc = nil
+	file_httprpc_proto_test_proto_goTypes = nil
+	file_httprpc_proto_test_proto_depIdxs = nil
+}
+
+var _Library_GetBook_httpRoutes = []httprpc.Route{
+	{Method: "POST", Pattern: "/httprpc_test.Library/GetBook", Body: "*"},
+}
+
+var _Library_ListBooks_httpRoutes = []httprpc.Route{
+	{Method: "POST", Pattern: "/httprpc_test.Library/ListBooks", Body: "*"},
+}
+
+var _Library_UpdateBook_httpRoutes = []httprpc.Route{
+	{Method: "POST", Pattern: "/httprpc_test.Library/UpdateBook", Body: "*"},
+}
+
+// LibraryHTTPServer is the server API for Library service served over HTTP
+// by NewLibraryHTTPHandler.
+//
+// The method UploadBooks streams requests and is not supported over HTTP.
+type LibraryHTTPServer interface {
+	// GetBook returns a book.
+	GetBook(context.Context, *GetBookRequest) (*Book, error)
+	// ListBooks streams the books of an author.
+	ListBooks(*ListBooksRequest, Library_ListBooksHTTPServer) error
+	UpdateBook(context.Context, *UpdateBookRequest) (*Book, error)
+}
+
+// NewLibraryHTTPHandler returns an http.Handler serving srv at the routes of its methods.
+func NewLibraryHTTPHandler(srv LibraryHTTPServer) http.Handler {
+	mux := httprpc.NewServeMux()
+	mux.HandleUnary(_Library_GetBook_httpRoutes, func() proto.Message { return new(GetBookRequest) }, func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return srv.GetBook(ctx, req.(*GetBookRequest))
+	})
+	mux.HandleServerStream(_Library_ListBooks_httpRoutes, func() proto.Message { return new(ListBooksRequest) }, func(req proto.Message, stream httprpc.ServerStream) error {
+		return srv.ListBooks(req.(*ListBooksRequest), &libraryListBooksHTTPServer{stream})
+	})
+	mux.HandleUnary(_Library_UpdateBook_httpRoutes, func() proto.Message { return new(UpdateBookRequest) }, func(ctx context.Context, req proto.Message) (proto.Message, error) {
+		return srv.UpdateBook(ctx, req.(*UpdateBookRequest))
+	})
+	return mux
+}
+
+type Library_ListBooksHTTPServer interface {
+	Send(*Book) error
+	httprpc.ServerStream
+}
+
+type libraryListBooksHTTPServer struct {
+	httprpc.ServerStream
+}
+
+func (x *libraryListBooksHTTPServer) Send(m *Book) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// LibraryHTTPClient is the client API for Library service called over HTTP.
+//
+// The method UploadBooks streams requests and is not supported over HTTP.
+type LibraryHTTPClient interface {
+	// GetBook returns a book.
+	GetBook(ctx context.Context, in *GetBookRequest) (*Book, error)
+	// ListBooks streams the books of an author.
+	ListBooks(ctx context.Context, in *ListBooksRequest) (Library_ListBooksHTTPClient, error)
+	UpdateBook(ctx context.Context, in *UpdateBookRequest) (*Book, error)
+}
+
+type libraryHTTPClient struct {
+	c *httprpc.Client
+}
+
+// NewLibraryHTTPClient returns a LibraryHTTPClient calling the methods with c,
+// at the first route of each method.
+func NewLibraryHTTPClient(c *httprpc.Client) LibraryHTTPClient {
+	return &libraryHTTPClient{c}
+}
+
+func (c *libraryHTTPClient) GetBook(ctx context.Context, in *GetBookRequest) (*Book, error) {
+	out := new(Book)
+	if err := c.c.Invoke(ctx, _Library_GetBook_httpRoutes[0], in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *libraryHTTPClient) ListBooks(ctx context.Context, in *ListBooksRequest) (Library_ListBooksHTTPClient, error) {
+	stream, err := c.c.NewStream(ctx, _Library_ListBooks_httpRoutes[0], in)
+	if err != nil {
+		return nil, err
+	}
+	return &libraryListBooksHTTPClient{stream}, nil
+}
+
+type Library_ListBooksHTTPClient interface {
+	Recv() (*Book, error)
+	httprpc.ClientStream
+}
+
+type libraryListBooksHTTPClient struct {
+	httprpc.ClientStream
+}
+
+func (x *libraryListBooksHTTPClient) Recv() (*Book, error) {
+	m := new(Book)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *libraryHTTPClient) UpdateBook(ctx context.Context, in *UpdateBookRequest) (*Book, error) {
+	out := new(Book)
+	if err := c.c.Invoke(ctx, _Library_UpdateBook_httpRoutes[0], in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
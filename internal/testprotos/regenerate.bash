@@ -11,7 +11,27 @@
 # Versions used:
 #	protoc:        v3.9.1
 #	protoc-gen-go: v1.3.2
+#
+# The files generated by this module's plugins are compiled instead by
+# this module's proto-compile and protoc-gen-go. proto-compile reports no
+# compiler version, so those files name protoc as "(unknown)".
 
-for X in $(find . -name "*.proto" | sed "s|^\./||"); do
+for X in $(find . -name "*.proto" ! -path "./httprpc_proto/*" ! -path "./fastpath_proto/*" ! -path "./validate_proto/*" | sed "s|^\./||"); do
 	protoc -I$(pwd) --go_out=paths=source_relative:. $X
 done
+
+tmpdir=$(mktemp -d)
+trap 'rm -rf "$tmpdir"' EXIT
+go build -o "$tmpdir/protoc-gen-go" ../../protoc-gen-go
+compile() {
+	go run ../../cmd/proto-compile -I=. -I=../.. -plugin="$tmpdir/protoc-gen-go" "$@"
+}
+
+# The HTTP transport of the services is generated by this module's plugin.
+compile -param=plugins=http,paths=source_relative httprpc_proto/test.proto
+
+# The reflection-free methods of the messages are generated by this module's plugin.
+compile -param=plugins=fastpath,paths=source_relative fastpath_proto/test.proto fastpath_proto/test2.proto
+
+# The Validate methods of the messages are generated by this module's plugin.
+compile -param=plugins=validate,paths=source_relative validate_proto/test.proto validate_proto/test2.proto validate_proto/test3.proto
@@ -0,0 +1,991 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        (unknown)
+// source: validate_proto/test.proto
+
+package validate_proto
+
+import (
+	validate "github.com/golang/protobuf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	math "math"
+	reflect "reflect"
+	regexp "regexp"
+	sort "sort"
+	sync "sync"
+	utf8 "unicode/utf8"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Color int32
+
+const (
+	Color_COLOR_UNSPECIFIED Color = 0
+	Color_RED               Color = 1
+	Color_GREEN             Color = 2
+)
+
+// Enum value maps for Color.
+var (
+	Color_name = map[int32]string{
+		0: "COLOR_UNSPECIFIED",
+		1: "RED",
+		2: "GREEN",
+	}
+	Color_value = map[string]int32{
+		"COLOR_UNSPECIFIED": 0,
+		"RED":               1,
+		"GREEN":             2,
+	}
+)
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
+}
+
+func (x Color) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Color) Descriptor() protoreflect.EnumDescriptor {
+	return file_validate_proto_test_proto_enumTypes[0].Descriptor()
+}
+
+func (Color) Type() protoreflect.EnumType {
+	return &file_validate_proto_test_proto_enumTypes[0]
+}
+
+func (x Color) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Color.Descriptor instead.
+func (Color) EnumDescriptor() ([]byte, []int) {
+	return file_validate_proto_test_proto_rawDescGZIP(), []int{0}
+}
+
+type Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string                            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	I32      int32                             `protobuf:"varint,2,opt,name=i32,proto3" json:"i32,omitempty"`
+	S64      int64                             `protobuf:"zigzag64,3,opt,name=s64,proto3" json:"s64,omitempty"`
+	U32      uint32                            `protobuf:"varint,4,opt,name=u32,proto3" json:"u32,omitempty"`
+	F64      uint64                            `protobuf:"fixed64,5,opt,name=f64,proto3" json:"f64,omitempty"`
+	Fl       float32                           `protobuf:"fixed32,6,opt,name=fl,proto3" json:"fl,omitempty"`
+	Db       float64                           `protobuf:"fixed64,7,opt,name=db,proto3" json:"db,omitempty"`
+	Bs       []byte                            `protobuf:"bytes,8,opt,name=bs,proto3" json:"bs,omitempty"`
+	Color    Color                             `protobuf:"varint,9,opt,name=color,proto3,enum=validate_test.Color" json:"color,omitempty"`
+	B        bool                              `protobuf:"varint,10,opt,name=b,proto3" json:"b,omitempty"`
+	Opt      *int64                            `protobuf:"varint,11,opt,name=opt,proto3,oneof" json:"opt,omitempty"`
+	OptName  *string                           `protobuf:"bytes,12,opt,name=opt_name,json=optName,proto3,oneof" json:"opt_name,omitempty"`
+	Tags     []string                          `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	Blobs    [][]byte                          `protobuf:"bytes,14,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	Floats   []float32                         `protobuf:"fixed32,15,rep,packed,name=floats,proto3" json:"floats,omitempty"`
+	Colors   []Color                           `protobuf:"varint,16,rep,packed,name=colors,proto3,enum=validate_test.Color" json:"colors,omitempty"`
+	Children []*Message                        `protobuf:"bytes,17,rep,name=children,proto3" json:"children,omitempty"`
+	Counts   map[string]int32                  `protobuf:"bytes,18,rep,name=counts,proto3" json:"counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Flags    map[bool]*Message                 `protobuf:"bytes,19,rep,name=flags,proto3" json:"flags,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Names    map[int32]string                  `protobuf:"bytes,20,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"zigzag32,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Timeout  *durationpb.Duration              `protobuf:"bytes,21,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Time     *timestamppb.Timestamp            `protobuf:"bytes,22,opt,name=time,proto3" json:"time,omitempty"`
+	Timeouts []*durationpb.Duration            `protobuf:"bytes,23,rep,name=timeouts,proto3" json:"timeouts,omitempty"`
+	Times    map[uint64]*timestamppb.Timestamp `protobuf:"bytes,24,rep,name=times,proto3" json:"times,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Child    *Message                          `protobuf:"bytes,25,opt,name=child,proto3" json:"child,omitempty"`
+	Legacy   *Legacy                           `protobuf:"bytes,26,opt,name=legacy,proto3" json:"legacy,omitempty"`
+	// Types that are assignable to Kind:
+	//	*Message_Text
+	//	*Message_Number
+	//	*Message_Nested
+	//	*Message_Shade
+	Kind isMessage_Kind `protobuf_oneof:"kind"`
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validate_proto_test_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_test_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_validate_proto_test_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Message) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Message) GetI32() int32 {
+	if x != nil {
+		return x.I32
+	}
+	return 0
+}
+
+func (x *Message) GetS64() int64 {
+	if x != nil {
+		return x.S64
+	}
+	return 0
+}
+
+func (x *Message) GetU32() uint32 {
+	if x != nil {
+		return x.U32
+	}
+	return 0
+}
+
+func (x *Message) GetF64() uint64 {
+	if x != nil {
+		return x.F64
+	}
+	return 0
+}
+
+func (x *Message) GetFl() float32 {
+	if x != nil {
+		return x.Fl
+	}
+	return 0
+}
+
+func (x *Message) GetDb() float64 {
+	if x != nil {
+		return x.Db
+	}
+	return 0
+}
+
+func (x *Message) GetBs() []byte {
+	if x != nil {
+		return x.Bs
+	}
+	return nil
+}
+
+func (x *Message) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+func (x *Message) GetB() bool {
+	if x != nil {
+		return x.B
+	}
+	return false
+}
+
+func (x *Message) GetOpt() int64 {
+	if x != nil && x.Opt != nil {
+		return *x.Opt
+	}
+	return 0
+}
+
+func (x *Message) GetOptName() string {
+	if x != nil && x.OptName != nil {
+		return *x.OptName
+	}
+	return ""
+}
+
+func (x *Message) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Message) GetBlobs() [][]byte {
+	if x != nil {
+		return x.Blobs
+	}
+	return nil
+}
+
+func (x *Message) GetFloats() []float32 {
+	if x != nil {
+		return x.Floats
+	}
+	return nil
+}
+
+func (x *Message) GetColors() []Color {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+func (x *Message) GetChildren() []*Message {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *Message) GetCounts() map[string]int32 {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+func (x *Message) GetFlags() map[bool]*Message {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *Message) GetNames() map[int32]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Message) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Message) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Message) GetTimeouts() []*durationpb.Duration {
+	if x != nil {
+		return x.Timeouts
+	}
+	return nil
+}
+
+func (x *Message) GetTimes() map[uint64]*timestamppb.Timestamp {
+	if x != nil {
+		return x.Times
+	}
+	return nil
+}
+
+func (x *Message) GetChild() *Message {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+func (x *Message) GetLegacy() *Legacy {
+	if x != nil {
+		return x.Legacy
+	}
+	return nil
+}
+
+func (m *Message) GetKind() isMessage_Kind {
+	if m != nil {
+		return m.Kind
+	}
+	return nil
+}
+
+func (x *Message) GetText() string {
+	if x, ok := x.GetKind().(*Message_Text); ok {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Message) GetNumber() uint64 {
+	if x, ok := x.GetKind().(*Message_Number); ok {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Message) GetNested() *Message {
+	if x, ok := x.GetKind().(*Message_Nested); ok {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *Message) GetShade() Color {
+	if x, ok := x.GetKind().(*Message_Shade); ok {
+		return x.Shade
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+type isMessage_Kind interface {
+	isMessage_Kind()
+}
+
+type Message_Text struct {
+	Text string `protobuf:"bytes,27,opt,name=text,proto3,oneof"`
+}
+
+type Message_Number struct {
+	Number uint64 `protobuf:"varint,28,opt,name=number,proto3,oneof"`
+}
+
+type Message_Nested struct {
+	Nested *Message `protobuf:"bytes,29,opt,name=nested,proto3,oneof"`
+}
+
+type Message_Shade struct {
+	Shade Color `protobuf:"varint,30,opt,name=shade,proto3,enum=validate_test.Color,oneof"`
+}
+
+func (*Message_Text) isMessage_Kind() {}
+
+func (*Message_Number) isMessage_Kind() {}
+
+func (*Message_Nested) isMessage_Kind() {}
+
+func (*Message_Shade) isMessage_Kind() {}
+
+// Free has no rules of its own.
+type Free struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValidateCount int32    `protobuf:"varint,1,opt,name=validate_count,json=validateCount,proto3" json:"validate_count,omitempty"`
+	Message       *Message `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Free) Reset() {
+	*x = Free{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validate_proto_test_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Free) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Free) ProtoMessage() {}
+
+func (x *Free) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_test_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Free.ProtoReflect.Descriptor instead.
+func (*Free) Descriptor() ([]byte, []int) {
+	return file_validate_proto_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Free) GetValidateCount() int32 {
+	if x != nil {
+		return x.ValidateCount
+	}
+	return 0
+}
+
+func (x *Free) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+var File_validate_proto_test_proto protoreflect.FileDescriptor
+
+var file_validate_proto_test_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x8d, 0x0d, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x16, 0xe2, 0xaa, 0x1f, 0x12,
+	0x28, 0x02, 0x30, 0x08, 0x3a, 0x0a, 0x5e, 0x5b, 0x61, 0x2d, 0x7a, 0xc3, 0xa9, 0x5d, 0x2b, 0x24,
+	0x40, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x03, 0x69, 0x33, 0x32, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0x08, 0xe2, 0xaa, 0x1f, 0x04, 0x08, 0x09, 0x10, 0x0a, 0x52,
+	0x03, 0x69, 0x33, 0x32, 0x12, 0x1d, 0x0a, 0x03, 0x73, 0x36, 0x34, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x12, 0x42, 0x0b, 0xe2, 0xaa, 0x1f, 0x07, 0x08, 0xff, 0xbf, 0xa8, 0xca, 0x9a, 0x3a, 0x52, 0x03,
+	0x73, 0x36, 0x34, 0x12, 0x19, 0x0a, 0x03, 0x75, 0x33, 0x32, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x42, 0x07, 0xe2, 0xaa, 0x1f, 0x03, 0x10, 0xd0, 0x0f, 0x52, 0x03, 0x75, 0x33, 0x32, 0x12, 0x1a,
+	0x0a, 0x03, 0x66, 0x36, 0x34, 0x18, 0x05, 0x20, 0x01, 0x28, 0x06, 0x42, 0x08, 0xe2, 0xaa, 0x1f,
+	0x04, 0x08, 0x14, 0x10, 0x28, 0x52, 0x03, 0x66, 0x36, 0x34, 0x12, 0x26, 0x0a, 0x02, 0x66, 0x6c,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x42, 0x16, 0xe2, 0xaa, 0x1f, 0x12, 0x19, 0x9a, 0x99, 0x99,
+	0x99, 0x99, 0x99, 0xb9, 0x3f, 0x21, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf8, 0x3f, 0x52, 0x02,
+	0x66, 0x6c, 0x12, 0x1f, 0x0a, 0x02, 0x64, 0x62, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x42, 0x0f,
+	0xe2, 0xaa, 0x1f, 0x0b, 0x19, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x04, 0xc0, 0x40, 0x01, 0x52,
+	0x02, 0x64, 0x62, 0x12, 0x16, 0x0a, 0x02, 0x62, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x42,
+	0x06, 0xe2, 0xaa, 0x1f, 0x02, 0x30, 0x03, 0x52, 0x02, 0x62, 0x73, 0x12, 0x32, 0x0a, 0x05, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72,
+	0x42, 0x06, 0xe2, 0xaa, 0x1f, 0x02, 0x48, 0x01, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12,
+	0x14, 0x0a, 0x01, 0x62, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x42, 0x06, 0xe2, 0xaa, 0x1f, 0x02,
+	0x40, 0x01, 0x52, 0x01, 0x62, 0x12, 0x1f, 0x0a, 0x03, 0x6f, 0x70, 0x74, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x03, 0x42, 0x08, 0xe2, 0xaa, 0x1f, 0x04, 0x08, 0x02, 0x40, 0x01, 0x48, 0x01, 0x52, 0x03,
+	0x6f, 0x70, 0x74, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x08, 0x6f, 0x70, 0x74, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06, 0xe2, 0xaa, 0x1f, 0x02, 0x28, 0x01,
+	0x48, 0x02, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x20,
+	0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0c, 0xe2, 0xaa,
+	0x1f, 0x08, 0x30, 0x04, 0x50, 0x01, 0x58, 0x03, 0x60, 0x01, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x12, 0x1c, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0c, 0x42,
+	0x06, 0xe2, 0xaa, 0x1f, 0x02, 0x60, 0x01, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x27,
+	0x0a, 0x06, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x02, 0x42, 0x0f,
+	0xe2, 0xaa, 0x1f, 0x0b, 0x21, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x24, 0x40, 0x60, 0x01, 0x52,
+	0x06, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x42, 0x08, 0xe2,
+	0xaa, 0x1f, 0x04, 0x48, 0x01, 0x60, 0x01, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x73, 0x12,
+	0x3a, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x18, 0x11, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73,
+	0x74, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x06, 0xe2, 0xaa, 0x1f, 0x02, 0x58,
+	0x02, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x44, 0x0a, 0x06, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42,
+	0x08, 0xe2, 0xaa, 0x1f, 0x04, 0x08, 0x00, 0x58, 0x02, 0x52, 0x06, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x73, 0x12, 0x37, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x41, 0x0a, 0x05, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x08, 0xe2, 0xaa,
+	0x1f, 0x04, 0x3a, 0x02, 0x5e, 0x78, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x45, 0x0a,
+	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x10, 0xe2, 0xaa, 0x1f, 0x0c, 0x40,
+	0x01, 0x6a, 0x04, 0x10, 0xc0, 0x84, 0x3d, 0x72, 0x02, 0x08, 0x3c, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x12, 0x48, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x18,
+	0xe2, 0xaa, 0x1f, 0x14, 0x7a, 0x06, 0x08, 0x80, 0x87, 0xb5, 0xc3, 0x03, 0x82, 0x01, 0x09, 0x08,
+	0x80, 0xae, 0x99, 0xa4, 0x0f, 0x10, 0xf4, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x3f,
+	0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x17, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xe2, 0xaa, 0x1f,
+	0x04, 0x72, 0x02, 0x08, 0x01, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x12,
+	0x3f, 0x0a, 0x05, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x18, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x42, 0x06, 0xe2, 0xaa, 0x1f, 0x02, 0x7a, 0x00, 0x52, 0x05, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x12, 0x2c, 0x0a, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x12, 0x2d,
+	0x0a, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c,
+	0x65, 0x67, 0x61, 0x63, 0x79, 0x52, 0x06, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x12, 0x1c, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06, 0xe2, 0xaa, 0x1f,
+	0x02, 0x28, 0x01, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x21, 0x0a, 0x06, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x04, 0x42, 0x07, 0xe2, 0xaa, 0x1f,
+	0x03, 0x10, 0xc8, 0x01, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x30,
+	0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x12, 0x34, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x64, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x14, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e,
+	0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x42, 0x06, 0xe2, 0xaa, 0x1f, 0x02, 0x48, 0x01, 0x48, 0x00, 0x52,
+	0x05, 0x73, 0x68, 0x61, 0x64, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x50, 0x0a, 0x0a, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x38, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x11, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x54, 0x0a,
+	0x0a, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x42, 0x06, 0x0a, 0x04, 0x5f,
+	0x6f, 0x70, 0x74, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6f, 0x70, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x5f, 0x0a, 0x04, 0x46, 0x72, 0x65, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x30, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x2a, 0x32, 0x0a, 0x05, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x15, 0x0a, 0x11, 0x43, 0x4f,
+	0x4c, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x52,
+	0x45, 0x45, 0x4e, 0x10, 0x02, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73,
+	0x74, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_validate_proto_test_proto_rawDescOnce sync.Once
+	file_validate_proto_test_proto_rawDescData = file_validate_proto_test_proto_rawDesc
+)
+
+func file_validate_proto_test_proto_rawDescGZIP() []byte {
+	file_validate_proto_test_proto_rawDescOnce.Do(func() {
+		file_validate_proto_test_proto_rawDescData = protoimpl.X.CompressGZIP(file_validate_proto_test_proto_rawDescData)
+	})
+	return file_validate_proto_test_proto_rawDescData
+}
+
+var file_validate_proto_test_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_validate_proto_test_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_validate_proto_test_proto_goTypes = []interface{}{
+	(Color)(0),                    // 0: validate_test.Color
+	(*Message)(nil),               // 1: validate_test.Message
+	(*Free)(nil),                  // 2: validate_test.Free
+	nil,                           // 3: validate_test.Message.CountsEntry
+	nil,                           // 4: validate_test.Message.FlagsEntry
+	nil,                           // 5: validate_test.Message.NamesEntry
+	nil,                           // 6: validate_test.Message.TimesEntry
+	(*durationpb.Duration)(nil),   // 7: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*Legacy)(nil),                // 9: validate_test.Legacy
+}
+var file_validate_proto_test_proto_depIdxs = []int32{
+	0,  // 0: validate_test.Message.color:type_name -> validate_test.Color
+	0,  // 1: validate_test.Message.colors:type_name -> validate_test.Color
+	1,  // 2: validate_test.Message.children:type_name -> validate_test.Message
+	3,  // 3: validate_test.Message.counts:type_name -> validate_test.Message.CountsEntry
+	4,  // 4: validate_test.Message.flags:type_name -> validate_test.Message.FlagsEntry
+	5,  // 5: validate_test.Message.names:type_name -> validate_test.Message.NamesEntry
+	7,  // 6: validate_test.Message.timeout:type_name -> google.protobuf.Duration
+	8,  // 7: validate_test.Message.time:type_name -> google.protobuf.Timestamp
+	7,  // 8: validate_test.Message.timeouts:type_name -> google.protobuf.Duration
+	6,  // 9: validate_test.Message.times:type_name -> validate_test.Message.TimesEntry
+	1,  // 10: validate_test.Message.child:type_name -> validate_test.Message
+	9,  // 11: validate_test.Message.legacy:type_name -> validate_test.Legacy
+	1,  // 12: validate_test.Message.nested:type_name -> validate_test.Message
+	0,  // 13: validate_test.Message.shade:type_name -> validate_test.Color
+	1,  // 14: validate_test.Free.message:type_name -> validate_test.Message
+	1,  // 15: validate_test.Message.FlagsEntry.value:type_name -> validate_test.Message
+	8,  // 16: validate_test.Message.TimesEntry.value:type_name -> google.protobuf.Timestamp
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_validate_proto_test_proto_init() }
+func file_validate_proto_test_proto_init() {
+	if File_validate_proto_test_proto != nil {
+		return
+	}
+	file_validate_proto_test2_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_validate_proto_test_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validate_proto_test_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Free); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_validate_proto_test_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Message_Text)(nil),
+		(*Message_Number)(nil),
+		(*Message_Nested)(nil),
+		(*Message_Shade)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_validate_proto_test_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_validate_proto_test_proto_goTypes,
+		DependencyIndexes: file_validate_proto_test_proto_depIdxs,
+		EnumInfos:         file_validate_proto_test_proto_enumTypes,
+		MessageInfos:      file_validate_proto_test_proto_msgTypes,
+	}.Build()
+	File_validate_proto_test_proto = out.File
+	file_validate_proto_test_proto_rawDesc = nil
+	file_validate_proto_test_proto_goTypes = nil
+	file_validate_proto_test_proto_depIdxs = nil
+}
+
+// Validate reports the violations of the rules given to the fields of x by
+// the options of validate/validate.proto, and of the messages held by x,
+// as a validate.Error.
+func (x *Message) Validate() error {
+	if x == nil {
+		return nil
+	}
+	var v validate.Error
+	if x.Name == "" {
+		v = append(v, validate.Violation{Field: "name", Description: "is required"})
+	}
+	if n := uint64(utf8.RuneCountInString(x.Name)); n < 2 {
+		v = append(v, validate.Violation{Field: "name", Description: "must be at least 2 characters long"})
+	} else if n > 8 {
+		v = append(v, validate.Violation{Field: "name", Description: "must be at most 8 characters long"})
+	}
+	if !pattern_Message_Name.MatchString(x.Name) {
+		v = append(v, validate.Violation{Field: "name", Description: "must match the pattern \"^[a-zé]+$\""})
+	}
+	if x.I32 < -5 {
+		v = append(v, validate.Violation{Field: "i32", Description: "must be at least -5"})
+	}
+	if x.I32 > 5 {
+		v = append(v, validate.Violation{Field: "i32", Description: "must be at most 5"})
+	}
+	if x.S64 < -1000000000000 {
+		v = append(v, validate.Violation{Field: "s64", Description: "must be at least -1000000000000"})
+	}
+	if x.U32 > 1000 {
+		v = append(v, validate.Violation{Field: "u32", Description: "must be at most 1000"})
+	}
+	if x.F64 < 10 {
+		v = append(v, validate.Violation{Field: "f64", Description: "must be at least 10"})
+	}
+	if x.F64 > 20 {
+		v = append(v, validate.Violation{Field: "f64", Description: "must be at most 20"})
+	}
+	if !(float64(x.Fl) >= 0.1) {
+		v = append(v, validate.Violation{Field: "fl", Description: "must be at least 0.1"})
+	}
+	if !(float64(x.Fl) <= 1.5) {
+		v = append(v, validate.Violation{Field: "fl", Description: "must be at most 1.5"})
+	}
+	if x.Db == 0 && !math.Signbit(x.Db) {
+		v = append(v, validate.Violation{Field: "db", Description: "is required"})
+	}
+	if !(x.Db >= -2.5) {
+		v = append(v, validate.Violation{Field: "db", Description: "must be at least -2.5"})
+	}
+	if uint64(len(x.Bs)) > 3 {
+		v = append(v, validate.Violation{Field: "bs", Description: "must be at most 3 bytes long"})
+	}
+	switch x.Color {
+	case 0, 1, 2:
+	default:
+		v = append(v, validate.Violation{Field: "color", Description: "must be a defined enum value"})
+	}
+	if !x.B {
+		v = append(v, validate.Violation{Field: "b", Description: "is required"})
+	}
+	if x.Opt == nil {
+		v = append(v, validate.Violation{Field: "opt", Description: "is required"})
+	}
+	if x.Opt != nil {
+		if *x.Opt < 1 {
+			v = append(v, validate.Violation{Field: "opt", Description: "must be at least 1"})
+		}
+	}
+	if x.OptName != nil {
+		if uint64(utf8.RuneCountInString(*x.OptName)) < 1 {
+			v = append(v, validate.Violation{Field: "opt_name", Description: "must be at least 1 characters long"})
+		}
+	}
+	if uint64(len(x.Tags)) < 1 {
+		v = append(v, validate.Violation{Field: "tags", Description: "must have at least 1 items"})
+	}
+	if uint64(len(x.Tags)) > 3 {
+		v = append(v, validate.Violation{Field: "tags", Description: "must have at most 3 items"})
+	}
+	if len(x.Tags) > 1 {
+		seen := make(map[string]bool, len(x.Tags))
+		for _, e := range x.Tags {
+			if seen[e] {
+				v = append(v, validate.Violation{Field: "tags", Description: "must have unique items"})
+				break
+			}
+			seen[e] = true
+		}
+	}
+	for i, e := range x.Tags {
+		if uint64(utf8.RuneCountInString(e)) > 4 {
+			v = append(v, validate.Violation{Field: validate.Index("tags", i), Description: "must be at most 4 characters long"})
+		}
+	}
+	if len(x.Blobs) > 1 {
+		seen := make(map[string]bool, len(x.Blobs))
+		for _, e := range x.Blobs {
+			if seen[string(e)] {
+				v = append(v, validate.Violation{Field: "blobs", Description: "must have unique items"})
+				break
+			}
+			seen[string(e)] = true
+		}
+	}
+	if len(x.Floats) > 1 {
+		seen := make(map[float32]bool, len(x.Floats))
+		for _, e := range x.Floats {
+			if seen[e] {
+				v = append(v, validate.Violation{Field: "floats", Description: "must have unique items"})
+				break
+			}
+			seen[e] = true
+		}
+	}
+	for i, e := range x.Floats {
+		if !(float64(e) <= 10) {
+			v = append(v, validate.Violation{Field: validate.Index("floats", i), Description: "must be at most 10"})
+		}
+	}
+	if len(x.Colors) > 1 {
+		seen := make(map[Color]bool, len(x.Colors))
+		for _, e := range x.Colors {
+			if seen[e] {
+				v = append(v, validate.Violation{Field: "colors", Description: "must have unique items"})
+				break
+			}
+			seen[e] = true
+		}
+	}
+	for i, e := range x.Colors {
+		switch e {
+		case 0, 1, 2:
+		default:
+			v = append(v, validate.Violation{Field: validate.Index("colors", i), Description: "must be a defined enum value"})
+		}
+	}
+	if uint64(len(x.Children)) > 2 {
+		v = append(v, validate.Violation{Field: "children", Description: "must have at most 2 items"})
+	}
+	for i, e := range x.Children {
+		if err := validate.AppendMessage(&v, validate.Index("children", i), e); err != nil {
+			return err
+		}
+	}
+	if uint64(len(x.Counts)) > 2 {
+		v = append(v, validate.Violation{Field: "counts", Description: "must have at most 2 items"})
+	}
+	if len(x.Counts) > 0 {
+		keys := make([]string, 0, len(x.Counts))
+		for k := range x.Counts {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, k := range keys {
+			e := x.Counts[k]
+			if e < 0 {
+				v = append(v, validate.Violation{Field: validate.Key("counts", k), Description: "must be at least 0"})
+			}
+		}
+	}
+	if len(x.Flags) > 0 {
+		keys := make([]bool, 0, len(x.Flags))
+		for k := range x.Flags {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return !keys[i] && keys[j] })
+		for _, k := range keys {
+			e := x.Flags[k]
+			if err := validate.AppendMessage(&v, validate.Key("flags", k), e); err != nil {
+				return err
+			}
+		}
+	}
+	if len(x.Names) > 0 {
+		keys := make([]int32, 0, len(x.Names))
+		for k := range x.Names {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, k := range keys {
+			e := x.Names[k]
+			if !pattern_Message_Names.MatchString(e) {
+				v = append(v, validate.Violation{Field: validate.Key("names", k), Description: "must match the pattern \"^x\""})
+			}
+		}
+	}
+	if x.Timeout == nil {
+		v = append(v, validate.Violation{Field: "timeout", Description: "is required"})
+	}
+	if x.Timeout != nil {
+		if x.Timeout.Seconds < 0 || x.Timeout.Seconds == 0 && x.Timeout.Nanos < 1000000 {
+			v = append(v, validate.Violation{Field: "timeout", Description: "must be at least 1ms"})
+		}
+		if x.Timeout.Seconds > 60 || x.Timeout.Seconds == 60 && x.Timeout.Nanos > 0 {
+			v = append(v, validate.Violation{Field: "timeout", Description: "must be at most 1m0s"})
+		}
+	}
+	if err := validate.AppendMessage(&v, "timeout", x.Timeout); err != nil {
+		return err
+	}
+	if x.Time != nil {
+		if x.Time.Seconds < 946684800 || x.Time.Seconds == 946684800 && x.Time.Nanos < 0 {
+			v = append(v, validate.Violation{Field: "time", Description: "must be at or after 2000-01-01T00:00:00Z"})
+		}
+		if x.Time.Seconds > 4102444800 || x.Time.Seconds == 4102444800 && x.Time.Nanos > 500 {
+			v = append(v, validate.Violation{Field: "time", Description: "must be at or before 2100-01-01T00:00:00.0000005Z"})
+		}
+	}
+	if err := validate.AppendMessage(&v, "time", x.Time); err != nil {
+		return err
+	}
+	for i, e := range x.Timeouts {
+		if e != nil {
+			if e.Seconds > 1 || e.Seconds == 1 && e.Nanos > 0 {
+				v = append(v, validate.Violation{Field: validate.Index("timeouts", i), Description: "must be at most 1s"})
+			}
+		}
+		if err := validate.AppendMessage(&v, validate.Index("timeouts", i), e); err != nil {
+			return err
+		}
+	}
+	if len(x.Times) > 0 {
+		keys := make([]uint64, 0, len(x.Times))
+		for k := range x.Times {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		for _, k := range keys {
+			e := x.Times[k]
+			if e != nil {
+				if e.Seconds < 0 || e.Seconds == 0 && e.Nanos < 0 {
+					v = append(v, validate.Violation{Field: validate.Key("times", k), Description: "must be at or after 1970-01-01T00:00:00Z"})
+				}
+			}
+			if err := validate.AppendMessage(&v, validate.Key("times", k), e); err != nil {
+				return err
+			}
+		}
+	}
+	if err := validate.AppendMessage(&v, "child", x.Child); err != nil {
+		return err
+	}
+	if err := validate.AppendMessage(&v, "legacy", x.Legacy); err != nil {
+		return err
+	}
+	if w, ok := x.Kind.(*Message_Text); ok {
+		if uint64(utf8.RuneCountInString(w.Text)) < 1 {
+			v = append(v, validate.Violation{Field: "text", Description: "must be at least 1 characters long"})
+		}
+	}
+	if w, ok := x.Kind.(*Message_Number); ok {
+		if w.Number > 100 {
+			v = append(v, validate.Violation{Field: "number", Description: "must be at most 100"})
+		}
+	}
+	if w, ok := x.Kind.(*Message_Nested); ok {
+		if err := validate.AppendMessage(&v, "nested", w.Nested); err != nil {
+			return err
+		}
+	}
+	if w, ok := x.Kind.(*Message_Shade); ok {
+		switch w.Shade {
+		case 0, 1, 2:
+		default:
+			v = append(v, validate.Violation{Field: "shade", Description: "must be a defined enum value"})
+		}
+	}
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+var pattern_Message_Name = regexp.MustCompile("^[a-zé]+$")
+
+var pattern_Message_Names = regexp.MustCompile("^x")
+
+// Validate reports the violations of the rules given to the fields of x by
+// the options of validate/validate.proto, and of the messages held by x,
+// as a validate.Error.
+func (x *Free) Validate() error {
+	if x == nil {
+		return nil
+	}
+	var v validate.Error
+	if err := validate.AppendMessage(&v, "message", x.Message); err != nil {
+		return err
+	}
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
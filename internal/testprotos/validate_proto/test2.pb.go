@@ -0,0 +1,475 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        (unknown)
+// source: validate_proto/test2.proto
+
+package validate_proto
+
+import (
+	validate "github.com/golang/protobuf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	utf8 "unicode/utf8"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Legacy_Size int32
+
+const (
+	Legacy_SMALL Legacy_Size = 1
+	Legacy_LARGE Legacy_Size = 2
+	Legacy_BIG   Legacy_Size = 2
+)
+
+// Enum value maps for Legacy_Size.
+var (
+	Legacy_Size_name = map[int32]string{
+		1: "SMALL",
+		2: "LARGE",
+		// Duplicate value: 2: "BIG",
+	}
+	Legacy_Size_value = map[string]int32{
+		"SMALL": 1,
+		"LARGE": 2,
+		"BIG":   2,
+	}
+)
+
+func (x Legacy_Size) Enum() *Legacy_Size {
+	p := new(Legacy_Size)
+	*p = x
+	return p
+}
+
+func (x Legacy_Size) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Legacy_Size) Descriptor() protoreflect.EnumDescriptor {
+	return file_validate_proto_test2_proto_enumTypes[0].Descriptor()
+}
+
+func (Legacy_Size) Type() protoreflect.EnumType {
+	return &file_validate_proto_test2_proto_enumTypes[0]
+}
+
+func (x Legacy_Size) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *Legacy_Size) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = Legacy_Size(num)
+	return nil
+}
+
+// Deprecated: Use Legacy_Size.Descriptor instead.
+func (Legacy_Size) EnumDescriptor() ([]byte, []int) {
+	return file_validate_proto_test2_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type Legacy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    *int32        `protobuf:"varint,1,req,name=id" json:"id,omitempty"`
+	Name  *string       `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Data  []byte        `protobuf:"bytes,3,opt,name=data" json:"data,omitempty"`
+	Size  *Legacy_Size  `protobuf:"varint,4,opt,name=size,enum=validate_test.Legacy_Size" json:"size,omitempty"`
+	Ids   []int32       `protobuf:"fixed32,5,rep,name=ids" json:"ids,omitempty"`
+	Group *Legacy_Group `protobuf:"group,6,opt,name=Group,json=group" json:"group,omitempty"`
+}
+
+func (x *Legacy) Reset() {
+	*x = Legacy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validate_proto_test2_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Legacy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Legacy) ProtoMessage() {}
+
+func (x *Legacy) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_test2_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Legacy.ProtoReflect.Descriptor instead.
+func (*Legacy) Descriptor() ([]byte, []int) {
+	return file_validate_proto_test2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Legacy) GetId() int32 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+func (x *Legacy) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *Legacy) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Legacy) GetSize() Legacy_Size {
+	if x != nil && x.Size != nil {
+		return *x.Size
+	}
+	return Legacy_SMALL
+}
+
+func (x *Legacy) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *Legacy) GetGroup() *Legacy_Group {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// Conflict is validated by protobuf reflection, since its field is named
+// like the generated method.
+type Conflict struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Validate *string `protobuf:"bytes,1,opt,name=validate" json:"validate,omitempty"`
+}
+
+func (x *Conflict) Reset() {
+	*x = Conflict{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validate_proto_test2_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Conflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conflict) ProtoMessage() {}
+
+func (x *Conflict) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_test2_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conflict.ProtoReflect.Descriptor instead.
+func (*Conflict) Descriptor() ([]byte, []int) {
+	return file_validate_proto_test2_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Conflict) GetValidate() string {
+	if x != nil && x.Validate != nil {
+		return *x.Validate
+	}
+	return ""
+}
+
+type Legacy_Group struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	N *uint64 `protobuf:"varint,7,opt,name=n" json:"n,omitempty"`
+}
+
+func (x *Legacy_Group) Reset() {
+	*x = Legacy_Group{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validate_proto_test2_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Legacy_Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Legacy_Group) ProtoMessage() {}
+
+func (x *Legacy_Group) ProtoReflect() protoreflect.Message {
+	mi := &file_validate_proto_test2_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Legacy_Group.ProtoReflect.Descriptor instead.
+func (*Legacy_Group) Descriptor() ([]byte, []int) {
+	return file_validate_proto_test2_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Legacy_Group) GetN() uint64 {
+	if x != nil && x.N != nil {
+		return *x.N
+	}
+	return 0
+}
+
+var File_validate_proto_test2_proto protoreflect.FileDescriptor
+
+var file_validate_proto_test2_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x74, 0x65, 0x73, 0x74, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xab, 0x02, 0x0a, 0x06, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x12,
+	0x16, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x02, 0x28, 0x05, 0x42, 0x06, 0xe2, 0xaa, 0x1f,
+	0x02, 0x08, 0x02, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xe2, 0xaa, 0x1f, 0x04, 0x30, 0x03, 0x40, 0x01, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x42, 0x06, 0xe2, 0xaa, 0x1f, 0x02, 0x28, 0x01, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x36, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1a, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x65, 0x73, 0x74, 0x2e,
+	0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x2e, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x06, 0xe2, 0xaa, 0x1f,
+	0x02, 0x48, 0x01, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x03, 0x69, 0x64, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0f, 0x42, 0x08, 0xe2, 0xaa, 0x1f, 0x04, 0x10, 0x12, 0x60, 0x01,
+	0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x31, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0a, 0x32, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f,
+	0x74, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x2e, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x1a, 0x1d, 0x0a, 0x05, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x12, 0x14, 0x0a, 0x01, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x42, 0x06, 0xe2, 0xaa,
+	0x1f, 0x02, 0x08, 0x04, 0x52, 0x01, 0x6e, 0x22, 0x29, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x09, 0x0a, 0x05, 0x53, 0x4d, 0x41, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x41,
+	0x52, 0x47, 0x45, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x49, 0x47, 0x10, 0x02, 0x1a, 0x02,
+	0x10, 0x01, 0x22, 0x2e, 0x0a, 0x08, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x22,
+	0x0a, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x06, 0xe2, 0xaa, 0x1f, 0x02, 0x40, 0x01, 0x52, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_validate_proto_test2_proto_rawDescOnce sync.Once
+	file_validate_proto_test2_proto_rawDescData = file_validate_proto_test2_proto_rawDesc
+)
+
+func file_validate_proto_test2_proto_rawDescGZIP() []byte {
+	file_validate_proto_test2_proto_rawDescOnce.Do(func() {
+		file_validate_proto_test2_proto_rawDescData = protoimpl.X.CompressGZIP(file_validate_proto_test2_proto_rawDescData)
+	})
+	return file_validate_proto_test2_proto_rawDescData
+}
+
+var file_validate_proto_test2_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_validate_proto_test2_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_validate_proto_test2_proto_goTypes = []interface{}{
+	(Legacy_Size)(0),     // 0: validate_test.Legacy.Size
+	(*Legacy)(nil),       // 1: validate_test.Legacy
+	(*Conflict)(nil),     // 2: validate_test.Conflict
+	(*Legacy_Group)(nil), // 3: validate_test.Legacy.Group
+}
+var file_validate_proto_test2_proto_depIdxs = []int32{
+	0, // 0: validate_test.Legacy.size:type_name -> validate_test.Legacy.Size
+	3, // 1: validate_test.Legacy.group:type_name -> validate_test.Legacy.Group
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_validate_proto_test2_proto_init() }
+func file_validate_proto_test2_proto_init() {
+	if File_validate_proto_test2_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_validate_proto_test2_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Legacy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validate_proto_test2_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Conflict); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validate_proto_test2_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Legacy_Group); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_validate_proto_test2_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_validate_proto_test2_proto_goTypes,
+		DependencyIndexes: file_validate_proto_test2_proto_depIdxs,
+		EnumInfos:         file_validate_proto_test2_proto_enumTypes,
+		MessageInfos:      file_validate_proto_test2_proto_msgTypes,
+	}.Build()
+	File_validate_proto_test2_proto = out.File
+	file_validate_proto_test2_proto_rawDesc = nil
+	file_validate_proto_test2_proto_goTypes = nil
+	file_validate_proto_test2_proto_depIdxs = nil
+}
+
+// Validate reports the violations of the rules given to the fields of x by
+// the options of validate/validate.proto, and of the messages held by x,
+// as a validate.Error.
+func (x *Legacy) Validate() error {
+	if x == nil {
+		return nil
+	}
+	var v validate.Error
+	if x.Id != nil {
+		if *x.Id < 1 {
+			v = append(v, validate.Violation{Field: "id", Description: "must be at least 1"})
+		}
+	}
+	if x.Name == nil {
+		v = append(v, validate.Violation{Field: "name", Description: "is required"})
+	}
+	if x.Name != nil {
+		if uint64(utf8.RuneCountInString(*x.Name)) > 3 {
+			v = append(v, validate.Violation{Field: "name", Description: "must be at most 3 characters long"})
+		}
+	}
+	if x.Data != nil {
+		if uint64(len(x.Data)) < 1 {
+			v = append(v, validate.Violation{Field: "data", Description: "must be at least 1 bytes long"})
+		}
+	}
+	if x.Size != nil {
+		switch *x.Size {
+		case 1, 2:
+		default:
+			v = append(v, validate.Violation{Field: "size", Description: "must be a defined enum value"})
+		}
+	}
+	if len(x.Ids) > 1 {
+		seen := make(map[int32]bool, len(x.Ids))
+		for _, e := range x.Ids {
+			if seen[e] {
+				v = append(v, validate.Violation{Field: "ids", Description: "must have unique items"})
+				break
+			}
+			seen[e] = true
+		}
+	}
+	for i, e := range x.Ids {
+		if e > 9 {
+			v = append(v, validate.Violation{Field: validate.Index("ids", i), Description: "must be at most 9"})
+		}
+	}
+	if err := validate.AppendMessage(&v, "group", x.Group); err != nil {
+		return err
+	}
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
+
+// Validate reports the violations of the rules given to the fields of x by
+// the options of validate/validate.proto, and of the messages held by x,
+// as a validate.Error.
+func (x *Legacy_Group) Validate() error {
+	if x == nil {
+		return nil
+	}
+	var v validate.Error
+	if x.N != nil {
+		if *x.N < 2 {
+			v = append(v, validate.Violation{Field: "n", Description: "must be at least 2"})
+		}
+	}
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
@@ -15,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/internal/decodecost"
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/protobuf/encoding/protojson"
 	protoV2 "google.golang.org/protobuf/proto"
@@ -49,6 +50,10 @@ type Unmarshaler struct {
 	// AnyResolver is used to resolve the google.protobuf.Any well-known type.
 	// If unset, the global registry is used by default.
 	AnyResolver AnyResolver
+
+	// Limits bounds the resources consumed while unmarshaling.
+	// If any limit is exceeded, a *proto.LimitError is returned.
+	Limits proto.DecodeLimits
 }
 
 // JSONPBUnmarshaler is implemented by protobuf messages that customize the way
@@ -65,9 +70,36 @@ type JSONPBUnmarshaler interface {
 
 // Unmarshal unmarshals a JSON object from r into m.
 func (u *Unmarshaler) Unmarshal(r io.Reader, m proto.Message) error {
+	if max := u.Limits.MaxInputSize; max > 0 {
+		lr := &limitedReader{r: r, n: max + 1}
+		err := u.UnmarshalNext(json.NewDecoder(lr), m)
+		if err != nil && lr.n <= 0 {
+			return &proto.LimitError{Kind: proto.InputSizeLimit, Limit: max}
+		}
+		return err
+	}
 	return u.UnmarshalNext(json.NewDecoder(r), m)
 }
 
+// limitedReader is like io.LimitedReader, but reports io.ErrUnexpectedEOF
+// when the limit is reached so that a truncated object is never decoded.
+type limitedReader struct {
+	r io.Reader
+	n int
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if len(p) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= n
+	return n, err
+}
+
 // UnmarshalNext unmarshals the next JSON object from d into m.
 func (u *Unmarshaler) UnmarshalNext(d *json.Decoder, m proto.Message) error {
 	if m == nil {
@@ -79,6 +111,9 @@ func (u *Unmarshaler) UnmarshalNext(d *json.Decoder, m proto.Message) error {
 	if err := d.Decode(&raw); err != nil {
 		return err
 	}
+	if max := u.Limits.MaxInputSize; max > 0 && len(raw) > max {
+		return &proto.LimitError{Kind: proto.InputSizeLimit, Limit: max}
+	}
 
 	// Check for custom unmarshalers first since they may not properly
 	// implement protobuf reflection that the logic below relies on.
@@ -124,25 +159,74 @@ func (u *Unmarshaler) UnmarshalNext(d *json.Decoder, m proto.Message) error {
 		}
 		return opts.Unmarshal(raw, mr.Interface())
 	} else {
-		if err := u.unmarshalMessage(mr, raw); err != nil {
+		r := jsonReader{Unmarshaler: u}
+		if err := r.unmarshalMessage(mr, raw); err != nil {
 			return err
 		}
 		return protoV2.CheckInitialized(mr.Interface())
 	}
 }
 
-func (u *Unmarshaler) unmarshalMessage(m protoreflect.Message, in []byte) error {
+// jsonReader tracks the state of a single call to Unmarshaler.UnmarshalNext.
+type jsonReader struct {
+	*Unmarshaler
+	depth int // current message nesting depth
+	heap  int // estimated heap usage of the values unmarshaled so far
+}
+
+// charge adds n bytes to the heap estimate, reporting an error if the
+// estimate exceeds the limit.
+func (u *jsonReader) charge(n int, fd protoreflect.FieldDescriptor) error {
+	u.heap += n
+	if max := u.Limits.MaxHeapEstimate; max > 0 && u.heap > max {
+		e := &proto.LimitError{Kind: proto.HeapEstimateLimit, Limit: max}
+		if fd != nil {
+			e.Field = fd.FullName()
+		}
+		return e
+	}
+	return nil
+}
+
+// checkElements reports an error if n elements in the repeated or map
+// field fd exceeds the limit on repeated elements.
+func (u *jsonReader) checkElements(n int, fd protoreflect.FieldDescriptor) error {
+	if max := u.Limits.MaxRepeatedElements; max > 0 && n > max {
+		return &proto.LimitError{Kind: proto.RepeatedElementsLimit, Limit: max, Field: fd.FullName()}
+	}
+	return nil
+}
+
+// annotateError prefixes err with a formatted description,
+// unless it is a *proto.LimitError, which is passed through unchanged.
+func annotateError(err error, format string, a ...interface{}) error {
+	if _, ok := err.(*proto.LimitError); ok {
+		return err
+	}
+	return fmt.Errorf(format+": %v", append(a, err)...)
+}
+
+func (u *jsonReader) unmarshalMessage(m protoreflect.Message, in []byte) error {
 	md := m.Descriptor()
 	fds := md.Fields()
 
 	if jsu, ok := proto.MessageV1(m.Interface()).(JSONPBUnmarshaler); ok {
-		return jsu.UnmarshalJSONPB(u, in)
+		return jsu.UnmarshalJSONPB(u.Unmarshaler, in)
 	}
 
 	if string(in) == "null" && md.FullName() != "google.protobuf.Value" {
 		return nil
 	}
 
+	u.depth++
+	defer func() { u.depth-- }()
+	if max := u.Limits.MaxDepth; max > 0 && u.depth > max {
+		return &proto.LimitError{Kind: proto.DepthLimit, Limit: max, Field: md.FullName()}
+	}
+	if err := u.charge(decodecost.Message, nil); err != nil {
+		return err
+	}
+
 	switch wellKnownType(md.FullName()) {
 	case "Any":
 		var jsonObject map[string]json.RawMessage
@@ -184,7 +268,7 @@ func (u *Unmarshaler) unmarshalMessage(m protoreflect.Message, in []byte) error
 				return errors.New("Any JSON doesn't have 'value'")
 			}
 			if err := u.unmarshalMessage(m2, rawValue); err != nil {
-				return fmt.Errorf("can't unmarshal Any nested proto %v: %v", typeURL, err)
+				return annotateError(err, "can't unmarshal Any nested proto %v", typeURL)
 			}
 		} else {
 			delete(jsonObject, "@type")
@@ -193,7 +277,7 @@ func (u *Unmarshaler) unmarshalMessage(m protoreflect.Message, in []byte) error
 				return fmt.Errorf("can't generate JSON for Any's nested proto to be unmarshaled: %v", err)
 			}
 			if err = u.unmarshalMessage(m2, rawJSON); err != nil {
-				return fmt.Errorf("can't unmarshal Any nested proto %v: %v", typeURL, err)
+				return annotateError(err, "can't unmarshal Any nested proto %v", typeURL)
 			}
 		}
 
@@ -276,6 +360,9 @@ func (u *Unmarshaler) unmarshalMessage(m protoreflect.Message, in []byte) error
 		if err := json.Unmarshal(in, &jsonArray); err != nil {
 			return fmt.Errorf("bad ListValue: %v", err)
 		}
+		if err := u.checkElements(len(jsonArray), fds.ByNumber(1)); err != nil {
+			return err
+		}
 
 		lv := m.Mutable(fds.ByNumber(1)).List()
 		for _, raw := range jsonArray {
@@ -291,13 +378,19 @@ func (u *Unmarshaler) unmarshalMessage(m protoreflect.Message, in []byte) error
 		if err := json.Unmarshal(in, &jsonObject); err != nil {
 			return fmt.Errorf("bad StructValue: %v", err)
 		}
+		if err := u.checkElements(len(jsonObject), fds.ByNumber(1)); err != nil {
+			return err
+		}
 
 		mv := m.Mutable(fds.ByNumber(1)).Map()
 		for key, raw := range jsonObject {
+			if err := u.charge(decodecost.Message+decodecost.Bytes+len(key), fds.ByNumber(1)); err != nil {
+				return err
+			}
 			kv := protoreflect.ValueOf(key).MapKey()
 			vv := mv.NewValue()
 			if err := u.unmarshalMessage(vv.Message(), raw); err != nil {
-				return fmt.Errorf("bad value in StructValue for key %q: %v", key, err)
+				return annotateError(err, "bad value in StructValue for key %q", key)
 			}
 			mv.Set(kv, vv)
 		}
@@ -406,13 +499,16 @@ func isSingularJSONPBUnmarshaler(v protoreflect.Value, fd protoreflect.FieldDesc
 	return false
 }
 
-func (u *Unmarshaler) unmarshalValue(v protoreflect.Value, in []byte, fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+func (u *jsonReader) unmarshalValue(v protoreflect.Value, in []byte, fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
 	switch {
 	case fd.IsList():
 		var jsonArray []json.RawMessage
 		if err := json.Unmarshal(in, &jsonArray); err != nil {
 			return v, err
 		}
+		if err := u.checkElements(len(jsonArray), fd); err != nil {
+			return v, err
+		}
 		lv := v.List()
 		for _, raw := range jsonArray {
 			ve, err := u.unmarshalSingularValue(lv.NewElement(), raw, fd)
@@ -427,10 +523,16 @@ func (u *Unmarshaler) unmarshalValue(v protoreflect.Value, in []byte, fd protore
 		if err := json.Unmarshal(in, &jsonObject); err != nil {
 			return v, err
 		}
+		if err := u.checkElements(len(jsonObject), fd); err != nil {
+			return v, err
+		}
 		kfd := fd.MapKey()
 		vfd := fd.MapValue()
 		mv := v.Map()
 		for key, raw := range jsonObject {
+			if err := u.charge(decodecost.Message, fd); err != nil {
+				return v, err
+			}
 			var kv protoreflect.MapKey
 			if kfd.Kind() == protoreflect.StringKind {
 				kv = protoreflect.ValueOf(key).MapKey()
@@ -460,7 +562,19 @@ var nonFinite = map[string]float64{
 	`"-Infinity"`: math.Inf(-1),
 }
 
-func (u *Unmarshaler) unmarshalSingularValue(v protoreflect.Value, in []byte, fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+func (u *jsonReader) unmarshalSingularValue(v protoreflect.Value, in []byte, fd protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		if err := u.charge(decodecost.Bytes+len(in), fd); err != nil {
+			return v, err
+		}
+	default:
+		if err := u.charge(decodecost.Scalar, fd); err != nil {
+			return v, err
+		}
+	}
+
 	switch fd.Kind() {
 	case protoreflect.BoolKind:
 		return unmarshalValue(in, new(bool))
@@ -48,6 +48,11 @@ type Marshaler struct {
 	// AnyResolver is used to resolve the google.protobuf.Any well-known type.
 	// If unset, the global registry is used by default.
 	AnyResolver AnyResolver
+
+	// Redact reports which fields have their values replaced by the string
+	// proto.RedactedPlaceholder. It applies to fields at any depth,
+	// including within Any messages. If nil, no fields are redacted.
+	Redact proto.RedactFunc
 }
 
 // JSONPBMarshaler is implemented by protobuf messages that customize the
@@ -414,6 +419,10 @@ func (w *jsonWriter) marshalField(fd protoreflect.FieldDescriptor, v protoreflec
 	if w.Indent != "" {
 		w.write(" ")
 	}
+	if w.Redact != nil && w.Redact(fd) {
+		w.write(`"` + proto.RedactedPlaceholder + `"`)
+		return nil
+	}
 	return w.marshalValue(fd, v, indent)
 }
 
@@ -16,6 +16,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	pb2 "github.com/golang/protobuf/internal/testprotos/jsonpb_proto"
 	pb3 "github.com/golang/protobuf/internal/testprotos/proto3_proto"
@@ -598,6 +599,57 @@ func TestMarshalWithCustomValidation(t *testing.T) {
 	}
 }
 
+func TestMarshalRedact(t *testing.T) {
+	redact := func(fd protoreflect.FieldDescriptor) bool {
+		switch fd.Name() {
+		case "o_string", "r_color", "m_int64_str":
+			return true
+		}
+		return false
+	}
+	an, err := ptypes.MarshalAny(&pb2.Simple{OString: proto.String("z"), OBool: proto.Bool(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc string
+		pb   proto.Message
+		want string
+	}{{
+		desc: "nested messages",
+		pb: &pb2.Widget{
+			Color:   pb2.Widget_BLUE.Enum(),
+			RColor:  []pb2.Widget_Color{pb2.Widget_RED},
+			Simple:  &pb2.Simple{OString: proto.String("s"), OBool: proto.Bool(true)},
+			RSimple: []*pb2.Simple{{OString: proto.String("x")}},
+		},
+		want: `{"color":"BLUE","rColor":"[REDACTED]","simple":{"oBool":true,"oString":"[REDACTED]"},"rSimple":[{"oString":"[REDACTED]"}]}`,
+	}, {
+		desc: "maps",
+		pb: &pb2.Maps{
+			MInt64Str:   map[int64]string{1: "a"},
+			MBoolSimple: map[bool]*pb2.Simple{true: {OString: proto.String("y"), OInt32: proto.Int32(3)}},
+		},
+		want: `{"mInt64Str":"[REDACTED]","mBoolSimple":{"true":{"oInt32":3,"oString":"[REDACTED]"}}}`,
+	}, {
+		desc: "Any",
+		pb:   &pb2.KnownTypes{An: an},
+		want: `{"an":{"@type":"type.googleapis.com/jsonpb_test.Simple","oBool":true,"oString":"[REDACTED]"}}`,
+	}}
+	for _, tt := range tests {
+		m := Marshaler{Redact: redact}
+		got, err := m.MarshalToString(tt.pb)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got:\n%s\nwant:\n%s", tt.desc, got, tt.want)
+		}
+	}
+}
+
 // Test marshaling message containing unset required fields should produce error.
 func TestMarshalUnsetRequiredFields(t *testing.T) {
 	msgExt := &pb2.Real{}
@@ -1262,3 +1314,102 @@ func TestUnmarshalUnsetRequiredFields(t *testing.T) {
 		}
 	}
 }
+
+func TestUnmarshalLimits(t *testing.T) {
+	tests := []struct {
+		desc   string
+		json   string
+		pb     proto.Message
+		limits proto.DecodeLimits
+		want   proto.LimitKind // zero if no error is expected
+	}{{
+		desc:   "depth within limit",
+		json:   `{"submessage": {"submessage": {"name": "leaf"}}}`,
+		pb:     &pb3.Message{},
+		limits: proto.DecodeLimits{MaxDepth: 3},
+	}, {
+		desc:   "depth exceeded",
+		json:   `{"submessage": {"submessage": {"submessage": {}}}}`,
+		pb:     &pb3.Message{},
+		limits: proto.DecodeLimits{MaxDepth: 3},
+		want:   proto.DepthLimit,
+	}, {
+		desc:   "nested Any depth exceeded",
+		json:   `{"anything": {"@type": "type.googleapis.com/proto3_test.Message", "submessage": {}}}`,
+		pb:     &pb3.Message{},
+		limits: proto.DecodeLimits{MaxDepth: 3},
+		want:   proto.DepthLimit,
+	}, {
+		desc:   "nested Struct depth exceeded",
+		json:   `{"a": {"b": {"c": 1}}}`,
+		pb:     &stpb.Struct{},
+		limits: proto.DecodeLimits{MaxDepth: 4},
+		want:   proto.DepthLimit,
+	}, {
+		desc:   "input size exceeded",
+		json:   `{"name": "0123456789"}`,
+		pb:     &pb3.Message{},
+		limits: proto.DecodeLimits{MaxInputSize: 10},
+		want:   proto.InputSizeLimit,
+	}, {
+		desc:   "repeated elements within limit",
+		json:   `{"key": ["1", "2", "3"]}`,
+		pb:     &pb3.Message{},
+		limits: proto.DecodeLimits{MaxRepeatedElements: 3},
+	}, {
+		desc:   "repeated elements exceeded",
+		json:   `{"key": ["1", "2", "3", "4"]}`,
+		pb:     &pb3.Message{},
+		limits: proto.DecodeLimits{MaxRepeatedElements: 3},
+		want:   proto.RepeatedElementsLimit,
+	}, {
+		desc:   "map entries exceeded",
+		json:   `{"stringMap": {"a": "", "b": "", "c": "", "d": ""}}`,
+		pb:     &pb3.Message{},
+		limits: proto.DecodeLimits{MaxRepeatedElements: 3},
+		want:   proto.RepeatedElementsLimit,
+	}, {
+		desc:   "ListValue elements exceeded",
+		json:   `[1, 2, 3, 4]`,
+		pb:     &stpb.ListValue{},
+		limits: proto.DecodeLimits{MaxRepeatedElements: 3},
+		want:   proto.RepeatedElementsLimit,
+	}, {
+		desc:   "heap estimate exceeded",
+		json:   `{"name": "` + strings.Repeat("x", 1000) + `"}`,
+		pb:     &pb3.Message{},
+		limits: proto.DecodeLimits{MaxHeapEstimate: 500},
+		want:   proto.HeapEstimateLimit,
+	}}
+
+	for _, tt := range tests {
+		u := Unmarshaler{Limits: tt.limits}
+		err := u.Unmarshal(strings.NewReader(tt.json), tt.pb)
+		if tt.want == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.desc, err)
+			}
+			continue
+		}
+		le, ok := err.(*proto.LimitError)
+		if !ok {
+			t.Errorf("%s: got error %v, want *proto.LimitError", tt.desc, err)
+			continue
+		}
+		if le.Kind != tt.want {
+			t.Errorf("%s: got %v limit error, want %v", tt.desc, le.Kind, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalNextInputSizeLimit(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"name": "a"} {"name": "0123456789"}`))
+	u := Unmarshaler{Limits: proto.DecodeLimits{MaxInputSize: 15}}
+	if err := u.UnmarshalNext(dec, &pb3.Message{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := u.UnmarshalNext(dec, &pb3.Message{})
+	if le, ok := err.(*proto.LimitError); !ok || le.Kind != proto.InputSizeLimit {
+		t.Errorf("got error %v, want input size *proto.LimitError", err)
+	}
+}
@@ -0,0 +1,714 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lint checks protocol buffer schemas for style problems and for
+// declarations that are unsafe to generate Go code for.
+//
+// Each problem is detected by a rule, identified by an ID such as
+// GO_NAME_COLLISION. Rules of Error severity detect declarations that
+// break the generated code or its JSON encoding; rules of Warning severity
+// detect departures from the protocol buffers style guide.
+//
+// A finding is suppressed by a comment attached to the element it is
+// reported for, or to any element enclosing it, naming the rule:
+//
+//	// lint:ignore FIELD_LOWER_SNAKE_CASE kept for compatibility
+//	string userID = 1;
+//
+// Several rules may be named, separated by commas. A comment anywhere in
+// a file containing lint:file-ignore suppresses the rules it names in
+// the whole file. Suppressions are read from the source code info of the
+// files, so the descriptors must be produced with protoc --include_source_info.
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/internal/finding"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// A Severity is the importance of a finding.
+type Severity string
+
+const (
+	// Error findings break the generated code or the encoding of data.
+	Error Severity = "error"
+	// Warning findings depart from the style guide.
+	Warning Severity = "warning"
+)
+
+// A Rule detects a kind of problem.
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+}
+
+// IDs of the rules.
+const (
+	GoNameCollision         = "GO_NAME_COLLISION"
+	GoFieldNameCollision    = "GO_FIELD_NAME_COLLISION"
+	JSONNameCollision       = "JSON_NAME_COLLISION"
+	GoPackageMissing        = "GO_PACKAGE_MISSING"
+	PackageMissing          = "PACKAGE_MISSING"
+	ReservedNumberReused    = "RESERVED_NUMBER_REUSED"
+	ReservedNameReused      = "RESERVED_NAME_REUSED"
+	MessagePascalCase       = "MESSAGE_PASCAL_CASE"
+	FieldLowerSnakeCase     = "FIELD_LOWER_SNAKE_CASE"
+	OneofLowerSnakeCase     = "ONEOF_LOWER_SNAKE_CASE"
+	EnumPascalCase          = "ENUM_PASCAL_CASE"
+	EnumValueUpperSnakeCase = "ENUM_VALUE_UPPER_SNAKE_CASE"
+	EnumValuePrefix         = "ENUM_VALUE_PREFIX"
+	EnumZeroValueSuffix     = "ENUM_ZERO_VALUE_SUFFIX"
+	ServicePascalCase       = "SERVICE_PASCAL_CASE"
+	MethodPascalCase        = "METHOD_PASCAL_CASE"
+)
+
+// Rules lists all the rules.
+var Rules = []Rule{
+	{GoNameCollision, Error, "two declarations map to the same identifier in the generated Go package"},
+	{GoFieldNameCollision, Warning, "two fields map to the same Go struct field or method, so one is renamed with a trailing underscore"},
+	{JSONNameCollision, Error, "two fields of a message have the same JSON name"},
+	{GoPackageMissing, Error, "the file has no go_package option"},
+	{PackageMissing, Warning, "the file has no package declaration"},
+	{ReservedNumberReused, Error, "a field or enum value uses a reserved number"},
+	{ReservedNameReused, Error, "a field or enum value uses a reserved name"},
+	{MessagePascalCase, Warning, "a message name is not PascalCase"},
+	{FieldLowerSnakeCase, Warning, "a field name is not lower_snake_case"},
+	{OneofLowerSnakeCase, Warning, "a oneof name is not lower_snake_case"},
+	{EnumPascalCase, Warning, "an enum name is not PascalCase"},
+	{EnumValueUpperSnakeCase, Warning, "an enum value name is not UPPER_SNAKE_CASE"},
+	{EnumValuePrefix, Warning, "an enum value name is not prefixed with the UPPER_SNAKE_CASE name of its enum"},
+	{EnumZeroValueSuffix, Warning, "the zero value of an enum is not suffixed with _UNSPECIFIED"},
+	{ServicePascalCase, Warning, "a service name is not PascalCase"},
+	{MethodPascalCase, Warning, "a method name is not PascalCase"},
+}
+
+func ruleSeverity(id string) Severity {
+	for _, r := range Rules {
+		if r.ID == id {
+			return r.Severity
+		}
+	}
+	panic("lint: unknown rule " + id)
+}
+
+// A Location is a position in a .proto source file.
+// Line and Column are 1-based, and zero if unknown.
+type Location = finding.Location
+
+// A Finding is a problem found in a schema. Its Element is the full name
+// of the element the problem is found in, or the path of the file for
+// problems with the file itself.
+type Finding struct {
+	finding.Finding
+	Severity Severity `json:"severity"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%v: %s: %s: %s", f.Location, f.Severity, f.Rule, f.Message)
+}
+
+// A Linter checks schemas.
+// The zero value checks all the rules.
+type Linter struct {
+	// Disable lists the IDs of rules that are not checked.
+	Disable []string
+}
+
+// LintSet checks the files of a set with the given paths, or all the files
+// of the set if no path is given. The other files of the set are only used
+// to find the declarations generated in the same Go package.
+func (l *Linter) LintSet(set *descriptorpb.FileDescriptorSet, paths ...string) ([]Finding, error) {
+	lint := make(map[string]bool)
+	for _, p := range paths {
+		lint[p] = true
+	}
+	found := make(map[string]bool)
+	var files []*descriptorpb.FileDescriptorProto
+	for _, fd := range set.File {
+		if len(paths) == 0 || lint[fd.GetName()] {
+			files = append(files, fd)
+			found[fd.GetName()] = true
+		}
+	}
+	for _, p := range paths {
+		if !found[p] {
+			return nil, fmt.Errorf("lint: file %q not found in set", p)
+		}
+	}
+	return l.lint(files, set.File), nil
+}
+
+// Lint checks files, and returns the problems found sorted by location.
+func (l *Linter) Lint(files ...*descriptorpb.FileDescriptorProto) []Finding {
+	return l.lint(files, files)
+}
+
+func (l *Linter) lint(files, all []*descriptorpb.FileDescriptorProto) []Finding {
+	r := &run{Linter: l, goIdents: make(map[string]map[string]goIdent)}
+	linted := make(map[*descriptorpb.FileDescriptorProto]bool)
+	for _, fd := range files {
+		linted[fd] = true
+	}
+	for _, fd := range all {
+		r.file = newFileState(fd)
+		r.report = linted[fd]
+		r.collectGoIdents()
+	}
+	for _, fd := range files {
+		r.file = newFileState(fd)
+		r.report = true
+		r.lintFile()
+	}
+	sort.SliceStable(r.findings, func(i, j int) bool {
+		return finding.Less(r.findings[i].Finding, r.findings[j].Finding)
+	})
+	return r.findings
+}
+
+// fileState holds the source locations and suppressions of a file.
+type fileState struct {
+	fd         *descriptorpb.FileDescriptorProto
+	locs       map[string]*descriptorpb.SourceCodeInfo_Location
+	fileIgnore map[string]bool
+}
+
+var (
+	ignoreComment     = regexp.MustCompile(`lint:ignore\s+([A-Z0-9_,]+)`)
+	fileIgnoreComment = regexp.MustCompile(`lint:file-ignore\s+([A-Z0-9_,]+)`)
+)
+
+func newFileState(fd *descriptorpb.FileDescriptorProto) *fileState {
+	f := &fileState{
+		fd:         fd,
+		locs:       make(map[string]*descriptorpb.SourceCodeInfo_Location),
+		fileIgnore: make(map[string]bool),
+	}
+	for _, loc := range fd.GetSourceCodeInfo().GetLocation() {
+		key := pathKey(loc.Path)
+		if _, ok := f.locs[key]; !ok {
+			f.locs[key] = loc
+		}
+		for _, c := range comments(loc) {
+			for _, m := range fileIgnoreComment.FindAllStringSubmatch(c, -1) {
+				for _, id := range strings.Split(m[1], ",") {
+					f.fileIgnore[id] = true
+				}
+			}
+		}
+	}
+	return f
+}
+
+func comments(loc *descriptorpb.SourceCodeInfo_Location) []string {
+	return append([]string{loc.GetLeadingComments(), loc.GetTrailingComments()}, loc.LeadingDetachedComments...)
+}
+
+// ignored reports whether a rule is suppressed for the element at path.
+func (f *fileState) ignored(rule string, path []int32) bool {
+	if f.fileIgnore[rule] {
+		return true
+	}
+	for n := len(path); n >= 0; n-- {
+		loc := f.locs[pathKey(path[:n])]
+		if loc == nil {
+			continue
+		}
+		for _, c := range []string{loc.GetLeadingComments(), loc.GetTrailingComments()} {
+			for _, m := range ignoreComment.FindAllStringSubmatch(c, -1) {
+				for _, id := range strings.Split(m[1], ",") {
+					if id == rule {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (f *fileState) location(path []int32) Location {
+	l := Location{File: f.fd.GetName()}
+	if loc := f.locs[pathKey(path)]; loc != nil && len(loc.Span) >= 2 {
+		l.Line = int(loc.Span[0]) + 1
+		l.Column = int(loc.Span[1]) + 1
+	}
+	return l
+}
+
+func pathKey(path []int32) string {
+	return fmt.Sprint(path)
+}
+
+func subPath(path []int32, elems ...int32) []int32 {
+	return append(path[:len(path):len(path)], elems...)
+}
+
+func fullName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// Field numbers of the descriptor messages, used in source code info paths.
+const (
+	filePackageTag   = 2
+	fileMessageTag   = 4
+	fileEnumTag      = 5
+	fileServiceTag   = 6
+	fileExtensionTag = 7
+	fileSyntaxTag    = 12
+
+	messageFieldTag     = 2
+	messageNestedTag    = 3
+	messageEnumTag      = 4
+	messageExtensionTag = 6
+	messageOneofTag     = 8
+
+	enumValueTag = 2
+
+	serviceMethodTag = 2
+)
+
+type run struct {
+	*Linter
+	file     *fileState
+	report   bool // whether findings are reported for the file
+	findings []Finding
+
+	// goIdents holds the package-level identifiers declared
+	// in each Go package, by import path.
+	goIdents map[string]map[string]goIdent
+}
+
+// goIdent is a package-level identifier of the generated code.
+type goIdent struct {
+	element string
+	file    *fileState
+	path    []int32
+}
+
+func (r *run) enabled(rule string) bool {
+	for _, id := range r.Disable {
+		if id == rule {
+			return false
+		}
+	}
+	return true
+}
+
+// reportf records a finding for the element with the given full name,
+// located at path in the current file.
+func (r *run) reportf(rule, element string, path []int32, format string, args ...interface{}) {
+	if !r.enabled(rule) || r.file.ignored(rule, path) {
+		return
+	}
+	r.findings = append(r.findings, Finding{
+		Finding: finding.Finding{
+			Rule:     rule,
+			Element:  element,
+			Message:  fmt.Sprintf(format, args...),
+			Location: r.file.location(path),
+		},
+		Severity: ruleSeverity(rule),
+	})
+}
+
+func (r *run) lintFile() {
+	fd := r.file.fd
+	pkg := fd.GetPackage()
+	if pkg == "" {
+		r.reportf(PackageMissing, fd.GetName(), []int32{fileSyntaxTag}, "file %s has no package declaration", fd.GetName())
+	}
+	if fd.GetOptions().GetGoPackage() == "" {
+		r.reportf(GoPackageMissing, fd.GetName(), []int32{filePackageTag}, "file %s has no go_package option", fd.GetName())
+	}
+	for i, md := range fd.MessageType {
+		r.lintMessage(md, pkg, []int32{fileMessageTag, int32(i)})
+	}
+	for i, ed := range fd.EnumType {
+		r.lintEnum(ed, pkg, []int32{fileEnumTag, int32(i)})
+	}
+	for i, sd := range fd.Service {
+		path := []int32{fileServiceTag, int32(i)}
+		name := fullName(pkg, sd.GetName())
+		if !isPascalCase(sd.GetName()) {
+			r.reportf(ServicePascalCase, name, path, "service name %s should be PascalCase", sd.GetName())
+		}
+		for j, m := range sd.Method {
+			if !isPascalCase(m.GetName()) {
+				r.reportf(MethodPascalCase, fullName(name, m.GetName()), subPath(path, serviceMethodTag, int32(j)), "method name %s should be PascalCase", m.GetName())
+			}
+		}
+	}
+	for i, xd := range fd.Extension {
+		r.lintFieldName(xd, pkg, []int32{fileExtensionTag, int32(i)})
+	}
+}
+
+func (r *run) lintMessage(md *descriptorpb.DescriptorProto, scope string, path []int32) {
+	if md.GetOptions().GetMapEntry() {
+		return
+	}
+	name := fullName(scope, md.GetName())
+	if !isPascalCase(md.GetName()) {
+		r.reportf(MessagePascalCase, name, path, "message name %s should be PascalCase", md.GetName())
+	}
+	for i, fd := range md.Field {
+		r.lintFieldName(fd, name, subPath(path, messageFieldTag, int32(i)))
+	}
+	for i, od := range md.OneofDecl {
+		if !isLowerSnakeCase(od.GetName()) && !isSyntheticOneof(md, int32(i)) {
+			r.reportf(OneofLowerSnakeCase, fullName(name, od.GetName()), subPath(path, messageOneofTag, int32(i)), "oneof name %s should be lower_snake_case", od.GetName())
+		}
+	}
+	for i, xd := range md.Extension {
+		r.lintFieldName(xd, name, subPath(path, messageExtensionTag, int32(i)))
+	}
+	r.lintReserved(md, name, path)
+	r.lintGoFieldNames(md, name, path)
+	r.lintJSONNames(md, name, path)
+	for i, nested := range md.NestedType {
+		r.lintMessage(nested, name, subPath(path, messageNestedTag, int32(i)))
+	}
+	for i, ed := range md.EnumType {
+		r.lintEnum(ed, name, subPath(path, messageEnumTag, int32(i)))
+	}
+}
+
+// isSyntheticOneof reports whether a oneof is the one protoc declares
+// for a proto3 optional field, which is named after the field.
+func isSyntheticOneof(md *descriptorpb.DescriptorProto, index int32) bool {
+	for _, fd := range md.Field {
+		if fd.OneofIndex != nil && fd.GetOneofIndex() == index {
+			return fd.GetProto3Optional()
+		}
+	}
+	return false
+}
+
+func (r *run) lintFieldName(fd *descriptorpb.FieldDescriptorProto, scope string, path []int32) {
+	if fd.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+		return // the name of a group field is the lower-cased name of its message
+	}
+	if !isLowerSnakeCase(fd.GetName()) {
+		r.reportf(FieldLowerSnakeCase, fullName(scope, fd.GetName()), path, "field name %s should be lower_snake_case", fd.GetName())
+	}
+}
+
+func (r *run) lintReserved(md *descriptorpb.DescriptorProto, name string, path []int32) {
+	reserved := make(map[string]bool)
+	for _, s := range md.ReservedName {
+		reserved[s] = true
+	}
+	for i, fd := range md.Field {
+		fieldPath := subPath(path, messageFieldTag, int32(i))
+		for _, rr := range md.ReservedRange {
+			if rr.GetStart() <= fd.GetNumber() && fd.GetNumber() < rr.GetEnd() {
+				r.reportf(ReservedNumberReused, fullName(name, fd.GetName()), fieldPath, "field %s uses reserved number %d", fd.GetName(), fd.GetNumber())
+			}
+		}
+		if reserved[fd.GetName()] {
+			r.reportf(ReservedNameReused, fullName(name, fd.GetName()), fieldPath, "field name %s is reserved", fd.GetName())
+		}
+	}
+}
+
+// fieldMethods are the methods of generated messages,
+// which fields cannot be named after.
+var fieldMethods = []string{
+	"Reset",
+	"String",
+	"ProtoMessage",
+	"Marshal",
+	"Unmarshal",
+	"ExtensionRangeArray",
+	"ExtensionMap",
+	"Descriptor",
+}
+
+// lintGoFieldNames reports the fields whose Go names conflict with the
+// name of another field or method of the generated struct, which the
+// generator resolves by appending underscores.
+func (r *run) lintGoFieldNames(md *descriptorpb.DescriptorProto, name string, path []int32) {
+	type use struct {
+		what  string // a description of the declaration using the name
+		proto string
+	}
+	used := make(map[string]use)
+	for _, m := range fieldMethods {
+		used[m] = use{"method " + m, ""}
+	}
+	claim := func(goName, protoName, what string, getter bool, fieldPath []int32) {
+		conflict, ok := used[goName]
+		if !ok && getter {
+			conflict, ok = used["Get"+goName]
+		}
+		if ok {
+			r.reportf(GoFieldNameCollision, fullName(name, protoName), fieldPath, "%s %s maps to Go name %s, which conflicts with %s", what, protoName, goName, conflict.what)
+			return
+		}
+		used[goName] = use{fmt.Sprintf("%s %s", what, protoName), protoName}
+		if getter {
+			used["Get"+goName] = use{fmt.Sprintf("the getter of %s %s", what, protoName), protoName}
+		}
+	}
+	seenOneof := make(map[int32]bool)
+	for i, fd := range md.Field {
+		claim(goCamelCase(fd.GetName()), fd.GetName(), "field", true, subPath(path, messageFieldTag, int32(i)))
+		if fd.OneofIndex != nil && !seenOneof[fd.GetOneofIndex()] {
+			seenOneof[fd.GetOneofIndex()] = true
+			oi := fd.GetOneofIndex()
+			if int(oi) < len(md.OneofDecl) && !isSyntheticOneof(md, oi) {
+				od := md.OneofDecl[oi]
+				claim(goCamelCase(od.GetName()), od.GetName(), "oneof", false, subPath(path, messageOneofTag, oi))
+			}
+		}
+	}
+}
+
+// lintJSONNames reports fields of a message with the same JSON name,
+// or whose JSON name is the name of another field, as the JSON
+// format accepts both.
+func (r *run) lintJSONNames(md *descriptorpb.DescriptorProto, name string, path []int32) {
+	byName := make(map[string]*descriptorpb.FieldDescriptorProto)
+	for i, fd := range md.Field {
+		fieldPath := subPath(path, messageFieldTag, int32(i))
+		names := []string{jsonName(fd)}
+		if fd.GetName() != names[0] {
+			names = append(names, fd.GetName())
+		}
+		reported := false
+		for _, n := range names {
+			other, ok := byName[n]
+			if ok && !reported {
+				r.reportf(JSONNameCollision, fullName(name, fd.GetName()), fieldPath, "field %s has JSON name %s, which conflicts with field %s", fd.GetName(), jsonName(fd), other.GetName())
+				reported = true
+			}
+			if !ok {
+				byName[n] = fd
+			}
+		}
+	}
+}
+
+func jsonName(fd *descriptorpb.FieldDescriptorProto) string {
+	if fd.JsonName != nil {
+		return fd.GetJsonName()
+	}
+	var b []byte
+	upper := false
+	for i := 0; i < len(fd.GetName()); i++ {
+		switch c := fd.GetName()[i]; {
+		case c == '_':
+			upper = true
+		case upper && 'a' <= c && c <= 'z':
+			b = append(b, c-'a'+'A')
+			upper = false
+		default:
+			b = append(b, c)
+			upper = false
+		}
+	}
+	return string(b)
+}
+
+func (r *run) lintEnum(ed *descriptorpb.EnumDescriptorProto, scope string, path []int32) {
+	name := fullName(scope, ed.GetName())
+	if !isPascalCase(ed.GetName()) {
+		r.reportf(EnumPascalCase, name, path, "enum name %s should be PascalCase", ed.GetName())
+	}
+	prefix := upperSnakeCase(ed.GetName()) + "_"
+	reserved := make(map[string]bool)
+	for _, s := range ed.ReservedName {
+		reserved[s] = true
+	}
+	for i, vd := range ed.Value {
+		valuePath := subPath(path, enumValueTag, int32(i))
+		// Enum values are siblings of their enum.
+		valueName := fullName(scope, vd.GetName())
+		if !isUpperSnakeCase(vd.GetName()) {
+			r.reportf(EnumValueUpperSnakeCase, valueName, valuePath, "enum value name %s should be UPPER_SNAKE_CASE", vd.GetName())
+		}
+		if !strings.HasPrefix(vd.GetName(), prefix) {
+			r.reportf(EnumValuePrefix, valueName, valuePath, "enum value name %s should be prefixed with %s", vd.GetName(), prefix)
+		}
+		if i == 0 && vd.GetNumber() == 0 && !strings.HasSuffix(vd.GetName(), "_UNSPECIFIED") {
+			r.reportf(EnumZeroValueSuffix, valueName, valuePath, "enum zero value name %s should be suffixed with _UNSPECIFIED", vd.GetName())
+		}
+		for _, rr := range ed.ReservedRange {
+			// The end of enum reserved ranges is inclusive.
+			if rr.GetStart() <= vd.GetNumber() && vd.GetNumber() <= rr.GetEnd() {
+				r.reportf(ReservedNumberReused, valueName, valuePath, "enum value %s uses reserved number %d", vd.GetName(), vd.GetNumber())
+			}
+		}
+		if reserved[vd.GetName()] {
+			r.reportf(ReservedNameReused, valueName, valuePath, "enum value name %s is reserved", vd.GetName())
+		}
+	}
+}
+
+// collectGoIdents records the package-level identifiers generated for
+// the current file, reporting those already declared in its Go package.
+func (r *run) collectGoIdents() {
+	fd := r.file.fd
+	importPath := fd.GetOptions().GetGoPackage()
+	if i := strings.IndexByte(importPath, ';'); i >= 0 {
+		importPath = importPath[:i]
+	}
+	if importPath == "" {
+		// Files without a go_package are reported by GO_PACKAGE_MISSING,
+		// and are only grouped with the files of their proto package.
+		importPath = "proto:" + fd.GetPackage()
+	}
+	idents := r.goIdents[importPath]
+	if idents == nil {
+		idents = make(map[string]goIdent)
+		r.goIdents[importPath] = idents
+	}
+	declare := func(ident, element string, path []int32) {
+		if other, ok := idents[ident]; ok {
+			if r.report {
+				where := other.element
+				if other.file != r.file {
+					where += " in " + other.file.fd.GetName()
+				}
+				r.reportf(GoNameCollision, element, path, "%s maps to Go identifier %s, which is also generated for %s", element, ident, where)
+			}
+			return
+		}
+		idents[ident] = goIdent{element, r.file, path}
+	}
+
+	pkg := fd.GetPackage()
+	// Names are relative to the package, as the Go names derive from them.
+	addEnum := func(ed *descriptorpb.EnumDescriptorProto, parent string, path []int32) {
+		name := fullName(pkg, fullName(parent, ed.GetName()))
+		ident := goCamelCase(fullName(parent, ed.GetName()))
+		declare(ident, name, path)
+		declare(ident+"_name", name, path)
+		declare(ident+"_value", name, path)
+		valuePrefix := ident
+		if parent != "" {
+			valuePrefix = goCamelCase(parent)
+		}
+		for i, vd := range ed.Value {
+			declare(valuePrefix+"_"+vd.GetName(), fullName(pkg, fullName(parent, vd.GetName())), subPath(path, enumValueTag, int32(i)))
+		}
+	}
+	addExtensions := func(xds []*descriptorpb.FieldDescriptorProto, parent string, path []int32, tag int32) {
+		for i, xd := range xds {
+			ident := goCamelCase(xd.GetName())
+			if parent != "" {
+				ident = goCamelCase(parent) + "_" + ident
+			}
+			declare("E_"+ident, fullName(pkg, fullName(parent, xd.GetName())), subPath(path, tag, int32(i)))
+		}
+	}
+	var addMessage func(md *descriptorpb.DescriptorProto, parent string, path []int32)
+	addMessage = func(md *descriptorpb.DescriptorProto, parent string, path []int32) {
+		if md.GetOptions().GetMapEntry() {
+			return
+		}
+		name := fullName(parent, md.GetName())
+		ident := goCamelCase(name)
+		declare(ident, fullName(pkg, name), path)
+		for i, f := range md.Field {
+			if f.OneofIndex != nil && !f.GetProto3Optional() {
+				declare(ident+"_"+goCamelCase(f.GetName()), fullName(pkg, name+"."+f.GetName()), subPath(path, messageFieldTag, int32(i)))
+			}
+		}
+		for i, nested := range md.NestedType {
+			addMessage(nested, name, subPath(path, messageNestedTag, int32(i)))
+		}
+		for i, ed := range md.EnumType {
+			addEnum(ed, name, subPath(path, messageEnumTag, int32(i)))
+		}
+		addExtensions(md.Extension, name, path, messageExtensionTag)
+	}
+	for i, md := range fd.MessageType {
+		addMessage(md, "", []int32{fileMessageTag, int32(i)})
+	}
+	for i, ed := range fd.EnumType {
+		addEnum(ed, "", []int32{fileEnumTag, int32(i)})
+	}
+	addExtensions(fd.Extension, "", nil, fileExtensionTag)
+}
+
+var (
+	pascalCasePattern     = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	lowerSnakeCasePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+	upperSnakeCasePattern = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+func isPascalCase(s string) bool     { return pascalCasePattern.MatchString(s) }
+func isLowerSnakeCase(s string) bool { return lowerSnakeCasePattern.MatchString(s) }
+func isUpperSnakeCase(s string) bool { return upperSnakeCasePattern.MatchString(s) }
+
+// upperSnakeCase converts a PascalCase name to UPPER_SNAKE_CASE,
+// keeping acronyms together: HTTPStatus becomes HTTP_STATUS.
+func upperSnakeCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if isUpper(c) && i > 0 {
+			prev := s[i-1]
+			nextLower := i+1 < len(s) && isLower(s[i+1])
+			if isLower(prev) || isDigit(prev) || (isUpper(prev) && nextLower) {
+				b = append(b, '_')
+			}
+		}
+		if isLower(c) {
+			c -= 'a' - 'A'
+		}
+		b = append(b, c)
+	}
+	return string(b)
+}
+
+// goCamelCase returns the Go name generated for a proto name,
+// as protoc-gen-go computes it.
+func goCamelCase(s string) string {
+	// Invariant: if the next letter is lower case, it must be converted
+	// to upper case.
+	// That is, we process a word at a time, where words are marked by _ or
+	// upper case letter. Digits are treated as words.
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isLower(s[i+1]):
+			// Skip over '.' in ".{{lowercase}}".
+		case c == '.':
+			b = append(b, '_') // convert '.' to '_'
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			// Convert initial '_' to ensure we start with a capital letter.
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isLower(s[i+1]):
+			// Skip over '_' in "_{{lowercase}}".
+		case isDigit(c):
+			b = append(b, c)
+		default:
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isLower(c byte) bool { return 'a' <= c && c <= 'z' }
+func isUpper(c byte) bool { return 'A' <= c && c <= 'Z' }
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
@@ -0,0 +1,196 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lint_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/lint"
+	"github.com/golang/protobuf/protoparse"
+	"github.com/google/go-cmp/cmp"
+
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// parse parses source files, the first of which is linted.
+func parse(t *testing.T, files ...string) *descpb.FileDescriptorSet {
+	srcs := make(map[string]string)
+	var names []string
+	for _, src := range files {
+		name := string(rune('a'+len(names))) + ".proto"
+		srcs[name] = src
+		names = append(names, name)
+	}
+	p := &protoparse.Parser{
+		Accessor: func(name string) (io.ReadCloser, error) {
+			src, ok := srcs[name]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return ioutil.NopCloser(strings.NewReader(src)), nil
+		},
+		IncludeSourceCodeInfo: true,
+	}
+	set, err := p.ParseFileSet(names...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return set
+}
+
+const (
+	header       = `syntax = "proto3"; package test; option go_package = "example.com/test";` + "\n"
+	proto2Header = `syntax = "proto2"; package test; option go_package = "example.com/test";` + "\n"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		desc    string
+		files   []string
+		disable []string
+		want    []string
+	}{{
+		desc:  "clean",
+		files: []string{header + `message Foo { string foo_bar = 1; } enum Kind { KIND_UNSPECIFIED = 0; KIND_A = 1; }`},
+	}, {
+		desc:  "missing packages",
+		files: []string{`syntax = "proto3"; message Foo {}`},
+		want: []string{
+			"a.proto: error: GO_PACKAGE_MISSING: file a.proto has no go_package option",
+			"a.proto:1:1: warning: PACKAGE_MISSING: file a.proto has no package declaration",
+		},
+	}, {
+		desc: "Go field name collision",
+		files: []string{proto2Header + `message Foo {
+  optional string foo_bar = 1;
+  optional string fooBar = 2;
+}`},
+		want: []string{
+			"a.proto:4:3: warning: FIELD_LOWER_SNAKE_CASE: field name fooBar should be lower_snake_case",
+			"a.proto:4:3: warning: GO_FIELD_NAME_COLLISION: field fooBar maps to Go name FooBar, which conflicts with field foo_bar",
+			"a.proto:4:3: error: JSON_NAME_COLLISION: field fooBar has JSON name fooBar, which conflicts with field foo_bar",
+		},
+	}, {
+		desc: "Go getter collision",
+		files: []string{header + `message Foo {
+  string name = 1;
+  string get_name = 2;
+  string descriptor = 3;
+}`},
+		want: []string{
+			"a.proto:4:3: warning: GO_FIELD_NAME_COLLISION: field get_name maps to Go name GetName, which conflicts with the getter of field name",
+			"a.proto:5:3: warning: GO_FIELD_NAME_COLLISION: field descriptor maps to Go name Descriptor, which conflicts with method Descriptor",
+		},
+	}, {
+		desc: "Go name collision",
+		files: []string{header + `message Foo_Bar {}
+message Foo { message Bar {} }`},
+		want: []string{
+			"a.proto:2:1: warning: MESSAGE_PASCAL_CASE: message name Foo_Bar should be PascalCase",
+			"a.proto:3:15: error: GO_NAME_COLLISION: test.Foo.Bar maps to Go identifier Foo_Bar, which is also generated for test.Foo_Bar",
+		},
+	}, {
+		desc: "Go name collision across files",
+		files: []string{
+			header + `import "b.proto"; message Kind_KIND_A {}`,
+			header + `enum Kind { KIND_UNSPECIFIED = 0; KIND_A = 1; }`,
+		},
+		want: []string{
+			"a.proto:2:19: error: GO_NAME_COLLISION: test.Kind_KIND_A maps to Go identifier Kind_KIND_A, which is also generated for test.KIND_A in b.proto",
+			"a.proto:2:19: warning: MESSAGE_PASCAL_CASE: message name Kind_KIND_A should be PascalCase",
+		},
+	}, {
+		desc: "JSON name collision",
+		files: []string{proto2Header + `message Foo {
+  optional string a = 1 [json_name = "b"];
+  optional string b = 2;
+}`},
+		want: []string{
+			"a.proto:4:3: error: JSON_NAME_COLLISION: field b has JSON name b, which conflicts with field a",
+		},
+	}, {
+		desc: "enum values",
+		files: []string{header + `enum HTTPStatus {
+  UNKNOWN = 0;
+  HTTP_STATUS_OK = 1;
+  http_status_lower = 2;
+}`},
+		want: []string{
+			"a.proto:3:3: warning: ENUM_VALUE_PREFIX: enum value name UNKNOWN should be prefixed with HTTP_STATUS_",
+			"a.proto:3:3: warning: ENUM_ZERO_VALUE_SUFFIX: enum zero value name UNKNOWN should be suffixed with _UNSPECIFIED",
+			"a.proto:5:3: warning: ENUM_VALUE_PREFIX: enum value name http_status_lower should be prefixed with HTTP_STATUS_",
+			"a.proto:5:3: warning: ENUM_VALUE_UPPER_SNAKE_CASE: enum value name http_status_lower should be UPPER_SNAKE_CASE",
+		},
+	}, {
+		desc: "service names",
+		files: []string{header + `message M {}
+service foo_service { rpc get_thing(M) returns (M); }`},
+		want: []string{
+			"a.proto:3:1: warning: SERVICE_PASCAL_CASE: service name foo_service should be PascalCase",
+			"a.proto:3:23: warning: METHOD_PASCAL_CASE: method name get_thing should be PascalCase",
+		},
+	}, {
+		desc:    "disabled rules",
+		files:   []string{`syntax = "proto3"; message Foo {}`},
+		disable: []string{lint.PackageMissing, lint.GoPackageMissing},
+	}, {
+		desc: "suppressed on the element",
+		files: []string{header + `message Foo {
+  // lint:ignore FIELD_LOWER_SNAKE_CASE kept for compatibility
+  string fooBar = 1;
+  string bazQux = 2; // lint:ignore FIELD_LOWER_SNAKE_CASE
+  string quuxCorge = 3;
+}`},
+		want: []string{
+			"a.proto:6:3: warning: FIELD_LOWER_SNAKE_CASE: field name quuxCorge should be lower_snake_case",
+		},
+	}, {
+		desc: "suppressed on the parent",
+		files: []string{header + `// lint:ignore FIELD_LOWER_SNAKE_CASE,ONEOF_LOWER_SNAKE_CASE
+message Foo {
+  oneof Choice { string fooBar = 1; }
+}`},
+	}, {
+		desc: "suppressed in the file",
+		files: []string{header + `// lint:file-ignore MESSAGE_PASCAL_CASE
+
+message foo {}
+message bar {}`},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			set := parse(t, tt.files...)
+			l := &lint.Linter{Disable: tt.disable}
+			findings, err := l.LintSet(set, "a.proto")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, f := range findings {
+				got = append(got, f.String())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("findings mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRules(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, r := range lint.Rules {
+		if seen[r.ID] {
+			t.Errorf("rule %s listed twice", r.ID)
+		}
+		seen[r.ID] = true
+		if r.Severity != lint.Error && r.Severity != lint.Warning {
+			t.Errorf("rule %s has severity %q", r.ID, r.Severity)
+		}
+	}
+}
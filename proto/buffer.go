@@ -50,6 +50,7 @@ type Buffer struct {
 	buf           []byte
 	idx           int
 	deterministic bool
+	limits        DecodeLimits
 }
 
 // NewBuffer allocates a new Buffer initialized with buf,
@@ -82,6 +83,13 @@ func (b *Buffer) SetDeterministic(deterministic bool) {
 	b.deterministic = deterministic
 }
 
+// SetDecodeLimits specifies the limits to enforce when unmarshaling messages
+// with Unmarshal, DecodeMessage, and DecodeGroup.
+// If any limit is exceeded, a *LimitError is returned.
+func (b *Buffer) SetDecodeLimits(limits DecodeLimits) {
+	b.limits = limits
+}
+
 // SetBuf sets buf as the internal buffer,
 // where the contents of buf are considered the unread portion of the buffer.
 func (b *Buffer) SetBuf(buf []byte) {
@@ -116,7 +124,7 @@ func (b *Buffer) Marshal(m Message) error {
 // places the decoded results in m.
 // It does not reset m before unmarshaling.
 func (b *Buffer) Unmarshal(m Message) error {
-	err := UnmarshalMerge(b.Unread(), m)
+	err := unmarshalMergeWithLimits(b.Unread(), m, b.limits)
 	b.idx = len(b.buf)
 	return err
 }
@@ -266,7 +274,7 @@ func (b *Buffer) DecodeMessage(m Message) error {
 	if err != nil {
 		return err
 	}
-	return UnmarshalMerge(v, m)
+	return unmarshalMergeWithLimits(v, m, b.limits)
 }
 
 // DecodeGroup consumes a message group from the buffer.
@@ -279,7 +287,7 @@ func (b *Buffer) DecodeGroup(m Message) error {
 		return err
 	}
 	b.idx += n
-	return UnmarshalMerge(v, m)
+	return unmarshalMergeWithLimits(v, m, b.limits)
 }
 
 // consumeGroup parses b until it finds an end group marker, returning
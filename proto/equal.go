@@ -0,0 +1,292 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// EqualOptions configures a comparison of messages that is more lenient
+// than Equal. The zero value compares messages exactly like Equal,
+// except that NaN values are unequal.
+//
+// Fields are identified by paths in the syntax accepted by GetPath,
+// but without any list indexes or map keys. A path names the field in every
+// element of the lists and every value of the maps along the way.
+// For example, "children.name" names the name field of every child,
+// and "terrain.bunny" names the bunny field of every value of terrain.
+type EqualOptions struct {
+	// IgnorePaths lists the fields that are not compared.
+	IgnorePaths []string
+
+	// FloatEpsilon is the largest absolute difference between
+	// two float or double values that are considered equal.
+	FloatEpsilon float64
+
+	// EquateNaNs specifies whether NaN values are equal to each other.
+	EquateNaNs bool
+
+	// IgnoreUnknown specifies whether unknown fields are ignored.
+	IgnoreUnknown bool
+
+	// EquateEmpty specifies whether an unset message field is equal to
+	// a message field that is set to an empty message, or to one that is
+	// empty once the options are applied.
+	EquateEmpty bool
+
+	// UnorderedLists maps the paths of repeated fields to compare without
+	// regard to order to the name of a key field. The elements of a list of
+	// messages with a key field are matched by the value of their key field.
+	// The elements of other lists, or those mapped to an empty key,
+	// are compared as multisets. Equal panics if a key is not the name of
+	// a singular scalar field of the elements of the list.
+	UnorderedLists map[string]string
+}
+
+// Equal reports whether x and y are equal under the options.
+func (o EqualOptions) Equal(x, y Message) bool {
+	if x == nil || y == nil {
+		return x == nil && y == nil
+	}
+	mx := MessageReflect(x)
+	my := MessageReflect(y)
+	if mx.IsValid() != my.IsValid() {
+		return false
+	}
+	c := comparer{opts: &o}
+	if len(o.IgnorePaths) > 0 {
+		c.ignore = make(map[string]bool)
+		for _, p := range o.IgnorePaths {
+			c.ignore[p] = true
+		}
+	}
+	c.usePaths = len(o.IgnorePaths) > 0 || len(o.UnorderedLists) > 0
+	return c.equalMessage(mx, my, "")
+}
+
+var defaultEqualOptions = EqualOptions{EquateNaNs: true}
+
+// comparer compares messages under a set of options.
+type comparer struct {
+	opts     *EqualOptions
+	ignore   map[string]bool
+	usePaths bool // whether paths need to be tracked
+}
+
+func (c *comparer) fieldPath(path string, fd protoreflect.FieldDescriptor) string {
+	if !c.usePaths {
+		return ""
+	}
+	return appendPath(path, fieldPathName(fd))
+}
+
+// equalMessage compares two messages located at path.
+func (c *comparer) equalMessage(mx, my protoreflect.Message, path string) bool {
+	if mx.Descriptor() != my.Descriptor() {
+		return false
+	}
+
+	equal := true
+	mx.Range(func(fd protoreflect.FieldDescriptor, vx protoreflect.Value) bool {
+		fpath := c.fieldPath(path, fd)
+		switch {
+		case c.ignore[fpath]:
+		case my.Has(fd):
+			equal = c.equalField(fd, vx, my.Get(fd), fpath)
+		default:
+			equal = c.isEmptyMessageField(fd, vx, fpath)
+		}
+		return equal
+	})
+	if !equal {
+		return false
+	}
+	my.Range(func(fd protoreflect.FieldDescriptor, vy protoreflect.Value) bool {
+		if mx.Has(fd) {
+			return true
+		}
+		fpath := c.fieldPath(path, fd)
+		equal = c.ignore[fpath] || c.isEmptyMessageField(fd, vy, fpath)
+		return equal
+	})
+	if !equal {
+		return false
+	}
+
+	if c.opts.IgnoreUnknown {
+		return true
+	}
+	return equalUnknown(mx.GetUnknown(), my.GetUnknown())
+}
+
+// isEmptyMessageField reports whether the populated field fd with value v
+// is considered equal to the field being unset.
+func (c *comparer) isEmptyMessageField(fd protoreflect.FieldDescriptor, v protoreflect.Value, path string) bool {
+	if !c.opts.EquateEmpty || fd.IsList() || fd.IsMap() || fd.Message() == nil {
+		return false
+	}
+	m := v.Message()
+	return c.equalMessage(m, m.Type().Zero(), path)
+}
+
+// equalField compares two values of the field fd.
+func (c *comparer) equalField(fd protoreflect.FieldDescriptor, x, y protoreflect.Value, path string) bool {
+	switch {
+	case fd.IsList():
+		if key, ok := c.opts.UnorderedLists[path]; ok && c.usePaths {
+			return c.equalUnorderedList(fd, x.List(), y.List(), key, path)
+		}
+		return c.equalList(fd, x.List(), y.List(), path)
+	case fd.IsMap():
+		return c.equalMap(fd, x.Map(), y.Map(), path)
+	default:
+		return c.equalValue(fd, x, y, path)
+	}
+}
+
+// equalMap compares two maps.
+func (c *comparer) equalMap(fd protoreflect.FieldDescriptor, x, y protoreflect.Map, path string) bool {
+	if x.Len() != y.Len() {
+		return false
+	}
+	equal := true
+	x.Range(func(k protoreflect.MapKey, vx protoreflect.Value) bool {
+		vy := y.Get(k)
+		equal = y.Has(k) && c.equalValue(fd.MapValue(), vx, vy, path)
+		return equal
+	})
+	return equal
+}
+
+// equalList compares two lists.
+func (c *comparer) equalList(fd protoreflect.FieldDescriptor, x, y protoreflect.List, path string) bool {
+	if x.Len() != y.Len() {
+		return false
+	}
+	for i := x.Len() - 1; i >= 0; i-- {
+		if !c.equalValue(fd, x.Get(i), y.Get(i), path) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalUnorderedList compares two lists without regard to the order of their
+// elements. Each element of x must be equal to a distinct element of y with
+// the same value of the key field, if there is one.
+func (c *comparer) equalUnorderedList(fd protoreflect.FieldDescriptor, x, y protoreflect.List, key, path string) bool {
+	if x.Len() != y.Len() {
+		return false
+	}
+	var kfd protoreflect.FieldDescriptor
+	if key != "" {
+		md := fd.Message()
+		if md == nil {
+			panic(fmt.Sprintf("proto: EqualOptions.UnorderedLists: key %q given to %s, which is not a list of messages", key, path))
+		}
+		kfd = md.Fields().ByName(protoreflect.Name(key))
+		if kfd == nil || kfd.IsList() || kfd.IsMap() || kfd.Message() != nil {
+			panic(fmt.Sprintf("proto: EqualOptions.UnorderedLists: key %q of %s is not a singular scalar field of %v", key, path, md.FullName()))
+		}
+	}
+	keyOf := func(v protoreflect.Value) interface{} {
+		if kfd == nil {
+			return nil
+		}
+		k := v.Message().Get(kfd).Interface()
+		if b, ok := k.([]byte); ok {
+			return string(b)
+		}
+		return k
+	}
+
+	// Group the unmatched elements of y by key.
+	unmatched := make(map[interface{}][]protoreflect.Value)
+	for i := 0; i < y.Len(); i++ {
+		v := y.Get(i)
+		k := keyOf(v)
+		unmatched[k] = append(unmatched[k], v)
+	}
+	for i := 0; i < x.Len(); i++ {
+		vx := x.Get(i)
+		k := keyOf(vx)
+		candidates := unmatched[k]
+		found := false
+		for j, vy := range candidates {
+			if c.equalValue(fd, vx, vy, path) {
+				unmatched[k] = append(candidates[:j:j], candidates[j+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// equalValue compares two singular values.
+func (c *comparer) equalValue(fd protoreflect.FieldDescriptor, x, y protoreflect.Value, path string) bool {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return x.Bool() == y.Bool()
+	case protoreflect.EnumKind:
+		return x.Enum() == y.Enum()
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return x.Int() == y.Int()
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+		protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return x.Uint() == y.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		fx := x.Float()
+		fy := y.Float()
+		if math.IsNaN(fx) || math.IsNaN(fy) {
+			return c.opts.EquateNaNs && math.IsNaN(fx) && math.IsNaN(fy)
+		}
+		return fx == fy || math.Abs(fx-fy) <= c.opts.FloatEpsilon
+	case protoreflect.StringKind:
+		return x.String() == y.String()
+	case protoreflect.BytesKind:
+		return bytes.Equal(x.Bytes(), y.Bytes())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return c.equalMessage(x.Message(), y.Message(), path)
+	default:
+		return x.Interface() == y.Interface()
+	}
+}
+
+// equalUnknown compares unknown fields by direct comparison on the raw bytes
+// of each individual field number.
+func equalUnknown(x, y protoreflect.RawFields) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	if bytes.Equal([]byte(x), []byte(y)) {
+		return true
+	}
+
+	mx := make(map[protoreflect.FieldNumber]protoreflect.RawFields)
+	my := make(map[protoreflect.FieldNumber]protoreflect.RawFields)
+	for len(x) > 0 {
+		fnum, _, n := protowire.ConsumeField(x)
+		mx[fnum] = append(mx[fnum], x[:n]...)
+		x = x[n:]
+	}
+	for len(y) > 0 {
+		fnum, _, n := protowire.ConsumeField(y)
+		my[fnum] = append(my[fnum], y[:n]...)
+		y = y[n:]
+	}
+	return reflect.DeepEqual(mx, my)
+}
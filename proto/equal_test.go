@@ -0,0 +1,244 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	pb2 "github.com/golang/protobuf/internal/testprotos/proto2_proto"
+	pb3 "github.com/golang/protobuf/internal/testprotos/proto3_proto"
+)
+
+func TestEqualOptions(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		desc string
+		opts proto.EqualOptions
+		x, y proto.Message
+		want bool
+	}{{
+		desc: "zero options",
+		x:    &pb3.Message{Name: "Aaron", Children: []*pb3.Message{{Name: "Bob"}}},
+		y:    &pb3.Message{Name: "Aaron", Children: []*pb3.Message{{Name: "Bob"}}},
+		want: true,
+	}, {
+		desc: "zero options with NaN",
+		x:    &pb2.FloatingPoint{F: proto.Float64(nan)},
+		y:    &pb2.FloatingPoint{F: proto.Float64(nan)},
+		want: false,
+	}, {
+		desc: "NaNs equated",
+		opts: proto.EqualOptions{EquateNaNs: true},
+		x:    &pb2.FloatingPoint{F: proto.Float64(nan)},
+		y:    &pb2.FloatingPoint{F: proto.Float64(nan)},
+		want: true,
+	}, {
+		desc: "NaN and number",
+		opts: proto.EqualOptions{EquateNaNs: true, FloatEpsilon: math.Inf(1)},
+		x:    &pb2.FloatingPoint{F: proto.Float64(nan)},
+		y:    &pb2.FloatingPoint{F: proto.Float64(1)},
+		want: false,
+	}, {
+		desc: "float within epsilon",
+		opts: proto.EqualOptions{FloatEpsilon: 0.01},
+		x:    &pb3.Message{Score: 1.001},
+		y:    &pb3.Message{Score: 1.002},
+		want: true,
+	}, {
+		desc: "float beyond epsilon",
+		opts: proto.EqualOptions{FloatEpsilon: 0.01},
+		x:    &pb3.Message{Score: 1},
+		y:    &pb3.Message{Score: 1.1},
+		want: false,
+	}, {
+		desc: "float set and unset within epsilon",
+		opts: proto.EqualOptions{FloatEpsilon: 0.01},
+		x:    &pb2.FloatingPoint{F: proto.Float64(0)},
+		y:    &pb2.FloatingPoint{},
+		want: false,
+	}, {
+		desc: "ignored field",
+		opts: proto.EqualOptions{IgnorePaths: []string{"name"}},
+		x:    &pb3.Message{Name: "Aaron", HeightInCm: 170},
+		y:    &pb3.Message{Name: "Bob", HeightInCm: 170},
+		want: true,
+	}, {
+		desc: "ignored field set on one side",
+		opts: proto.EqualOptions{IgnorePaths: []string{"name"}},
+		x:    &pb3.Message{},
+		y:    &pb3.Message{Name: "Bob"},
+		want: true,
+	}, {
+		desc: "ignored field in list elements",
+		opts: proto.EqualOptions{IgnorePaths: []string{"children.name"}},
+		x:    &pb3.Message{Children: []*pb3.Message{{Name: "Aaron", HeightInCm: 1}, {Name: "Bob"}}},
+		y:    &pb3.Message{Children: []*pb3.Message{{Name: "Carol", HeightInCm: 1}, {}}},
+		want: true,
+	}, {
+		desc: "ignored field in map values",
+		opts: proto.EqualOptions{IgnorePaths: []string{"terrain.bunny"}},
+		x:    &pb3.Message{Terrain: map[string]*pb3.Nested{"a": {Bunny: "x", Cute: true}}},
+		y:    &pb3.Message{Terrain: map[string]*pb3.Nested{"a": {Bunny: "y", Cute: true}}},
+		want: true,
+	}, {
+		desc: "ignored field at a different depth",
+		opts: proto.EqualOptions{IgnorePaths: []string{"name"}},
+		x:    &pb3.Message{Submessage: &pb3.Message{Name: "Aaron"}},
+		y:    &pb3.Message{Submessage: &pb3.Message{Name: "Bob"}},
+		want: false,
+	}, {
+		desc: "ignored extension",
+		opts: proto.EqualOptions{IgnorePaths: []string{"[proto2_test.greeting]"}},
+		x:    withExtension(&pb2.MyMessage{Count: proto.Int32(1)}, pb2.E_Greeting, []string{"hello"}),
+		y:    &pb2.MyMessage{Count: proto.Int32(1)},
+		want: true,
+	}, {
+		desc: "unknown fields",
+		x:    &pb3.Message{XXX_unrecognized: []byte{0xa0, 0x1f, 0x01}},
+		y:    &pb3.Message{},
+		want: false,
+	}, {
+		desc: "unknown fields ignored",
+		opts: proto.EqualOptions{IgnoreUnknown: true},
+		x:    &pb3.Message{XXX_unrecognized: []byte{0xa0, 0x1f, 0x01}},
+		y:    &pb3.Message{},
+		want: true,
+	}, {
+		desc: "empty and unset message",
+		x:    &pb3.Message{Nested: &pb3.Nested{}},
+		y:    &pb3.Message{},
+		want: false,
+	}, {
+		desc: "empty and unset message equated",
+		opts: proto.EqualOptions{EquateEmpty: true},
+		x:    &pb3.Message{Nested: &pb3.Nested{}},
+		y:    &pb3.Message{},
+		want: true,
+	}, {
+		desc: "unset and empty message equated",
+		opts: proto.EqualOptions{EquateEmpty: true},
+		x:    &pb3.Message{},
+		y:    &pb3.Message{Submessage: &pb3.Message{Nested: &pb3.Nested{}}},
+		want: true,
+	}, {
+		desc: "unset and message empty once ignored fields are removed",
+		opts: proto.EqualOptions{EquateEmpty: true, IgnorePaths: []string{"nested.bunny"}},
+		x:    &pb3.Message{Nested: &pb3.Nested{Bunny: "x"}},
+		y:    &pb3.Message{},
+		want: true,
+	}, {
+		desc: "unset and non-empty message",
+		opts: proto.EqualOptions{EquateEmpty: true},
+		x:    &pb3.Message{Nested: &pb3.Nested{Cute: true}},
+		y:    &pb3.Message{},
+		want: false,
+	}, {
+		desc: "reordered list",
+		x:    &pb3.Message{Key: []uint64{1, 2, 3}},
+		y:    &pb3.Message{Key: []uint64{3, 2, 1}},
+		want: false,
+	}, {
+		desc: "reordered unordered list",
+		opts: proto.EqualOptions{UnorderedLists: map[string]string{"key": ""}},
+		x:    &pb3.Message{Key: []uint64{1, 2, 2, 3}},
+		y:    &pb3.Message{Key: []uint64{2, 3, 2, 1}},
+		want: true,
+	}, {
+		desc: "unordered list with different multiplicity",
+		opts: proto.EqualOptions{UnorderedLists: map[string]string{"key": ""}},
+		x:    &pb3.Message{Key: []uint64{1, 1, 2}},
+		y:    &pb3.Message{Key: []uint64{1, 2, 2}},
+		want: false,
+	}, {
+		desc: "reordered messages matched by key",
+		opts: proto.EqualOptions{UnorderedLists: map[string]string{"children": "name"}},
+		x: &pb3.Message{Children: []*pb3.Message{
+			{Name: "Aaron", HeightInCm: 170},
+			{Name: "Bob", HeightInCm: 180},
+		}},
+		y: &pb3.Message{Children: []*pb3.Message{
+			{Name: "Bob", HeightInCm: 180},
+			{Name: "Aaron", HeightInCm: 170},
+		}},
+		want: true,
+	}, {
+		desc: "messages matched by key with different values",
+		opts: proto.EqualOptions{UnorderedLists: map[string]string{"children": "name"}},
+		x: &pb3.Message{Children: []*pb3.Message{
+			{Name: "Aaron", HeightInCm: 170},
+			{Name: "Bob", HeightInCm: 180},
+		}},
+		y: &pb3.Message{Children: []*pb3.Message{
+			{Name: "Bob", HeightInCm: 170},
+			{Name: "Aaron", HeightInCm: 180},
+		}},
+		want: false,
+	}, {
+		desc: "nested unordered lists with ignored fields",
+		opts: proto.EqualOptions{
+			IgnorePaths:    []string{"children.children.height_in_cm"},
+			UnorderedLists: map[string]string{"children": "name", "children.children": ""},
+		},
+		x: &pb3.Message{Children: []*pb3.Message{
+			{Name: "Aaron", Children: []*pb3.Message{{Name: "x", HeightInCm: 1}, {Name: "y"}}},
+			{Name: "Bob"},
+		}},
+		y: &pb3.Message{Children: []*pb3.Message{
+			{Name: "Bob"},
+			{Name: "Aaron", Children: []*pb3.Message{{Name: "y", HeightInCm: 2}, {Name: "x"}}},
+		}},
+		want: true,
+	}, {
+		desc: "different message types",
+		opts: proto.EqualOptions{EquateEmpty: true, IgnoreUnknown: true},
+		x:    &pb3.Message{},
+		y:    &pb3.Nested{},
+		want: false,
+	}, {
+		desc: "nil and empty message",
+		x:    (*pb3.Message)(nil),
+		y:    &pb3.Message{},
+		want: false,
+	}}
+
+	for _, tt := range tests {
+		if got := tt.opts.Equal(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: Equal(%v, %v) = %v, want %v", tt.desc, tt.x, tt.y, got, tt.want)
+		}
+		if got := tt.opts.Equal(tt.y, tt.x); got != tt.want {
+			t.Errorf("%s: Equal(%v, %v) = %v, want %v", tt.desc, tt.y, tt.x, got, tt.want)
+		}
+	}
+}
+
+func TestEqualOptionsInvalidKey(t *testing.T) {
+	tests := []struct {
+		keys      map[string]string
+		wantPanic string
+	}{{
+		keys:      map[string]string{"children": "nmae"},
+		wantPanic: `proto: EqualOptions.UnorderedLists: key "nmae" of children is not a singular scalar field of proto3_test.Message`,
+	}, {
+		keys:      map[string]string{"children": "nested"},
+		wantPanic: `proto: EqualOptions.UnorderedLists: key "nested" of children is not a singular scalar field of proto3_test.Message`,
+	}, {
+		keys:      map[string]string{"key": "name"},
+		wantPanic: `proto: EqualOptions.UnorderedLists: key "name" given to key, which is not a list of messages`,
+	}}
+	m := &pb3.Message{Key: []uint64{1}, Children: []*pb3.Message{{Name: "Aaron"}}}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if got := recover(); got != tt.wantPanic {
+					t.Errorf("Equal with UnorderedLists %v panics with %v, want %q", tt.keys, got, tt.wantPanic)
+				}
+			}()
+			proto.EqualOptions{UnorderedLists: tt.keys}.Equal(m, m)
+		}()
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/internal/decodecost"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// DecodeLimits bounds the resources that may be consumed while decoding
+// untrusted input in the binary, text, and JSON formats.
+// A zero value for any limit means that the dimension is unbounded.
+type DecodeLimits struct {
+	// MaxDepth is the maximum nesting depth of messages and groups.
+	// A message without any populated message fields has a depth of 1.
+	MaxDepth int
+
+	// MaxInputSize is the maximum length in bytes of the encoded input.
+	MaxInputSize int
+
+	// MaxRepeatedElements is the maximum number of elements in any single
+	// repeated or map field of a single message.
+	MaxRepeatedElements int
+
+	// MaxHeapEstimate is the maximum estimated number of bytes of memory
+	// retained by the decoded message. The estimate charges a fixed cost for
+	// every message, scalar value, and map entry, plus the length of every
+	// string, bytes value, and unknown field.
+	MaxHeapEstimate int
+}
+
+// LimitKind identifies which of the DecodeLimits was exceeded.
+type LimitKind int
+
+const (
+	DepthLimit LimitKind = iota + 1
+	InputSizeLimit
+	RepeatedElementsLimit
+	HeapEstimateLimit
+)
+
+func (k LimitKind) String() string {
+	switch k {
+	case DepthLimit:
+		return "nesting depth"
+	case InputSizeLimit:
+		return "input size"
+	case RepeatedElementsLimit:
+		return "repeated field elements"
+	case HeapEstimateLimit:
+		return "heap estimate"
+	default:
+		return fmt.Sprintf("LimitKind(%d)", int(k))
+	}
+}
+
+// LimitError is returned when decoding stops because the input
+// exceeded one of the configured DecodeLimits.
+type LimitError struct {
+	Kind  LimitKind
+	Limit int // the configured limit
+
+	// Field is the full name of the field being decoded when the limit was
+	// exceeded. It is empty if the limit applies to the input as a whole.
+	Field protoreflect.FullName
+}
+
+func (e *LimitError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("proto: %v limit of %d exceeded in field %v", e.Kind, e.Limit, e.Field)
+	}
+	return fmt.Sprintf("proto: %v limit of %d exceeded", e.Kind, e.Limit)
+}
+
+func (l *DecodeLimits) isZero() bool {
+	return *l == DecodeLimits{}
+}
+
+func (l *DecodeLimits) checkInputSize(n int) error {
+	if l.MaxInputSize > 0 && n > l.MaxInputSize {
+		return &LimitError{Kind: InputSizeLimit, Limit: l.MaxInputSize}
+	}
+	return nil
+}
+
+// UnmarshalWithLimits is like Unmarshal, but returns a *LimitError
+// without modifying m if b exceeds any of the provided limits.
+func UnmarshalWithLimits(b []byte, m Message, limits DecodeLimits) error {
+	if err := limits.checkWire(b, m); err != nil {
+		return err
+	}
+	m.Reset()
+	return UnmarshalMerge(b, m)
+}
+
+func unmarshalMergeWithLimits(b []byte, m Message, limits DecodeLimits) error {
+	if err := limits.checkWire(b, m); err != nil {
+		return err
+	}
+	return UnmarshalMerge(b, m)
+}
+
+// checkWire reports whether decoding the wire-format input b into a message
+// of the type of m stays within the limits, without modifying m.
+func (l *DecodeLimits) checkWire(b []byte, m Message) error {
+	if l.isZero() {
+		return nil
+	}
+	if err := l.checkInputSize(len(b)); err != nil {
+		return err
+	}
+	c := wireLimitChecker{decodeBudget{limits: *l}}
+	return c.checkMessage(b, MessageReflect(m).Descriptor(), 1)
+}
+
+// decodeBudget tracks the resources consumed while decoding
+// and reports when they exceed the limits.
+type decodeBudget struct {
+	limits DecodeLimits
+	heap   int // estimated heap usage of the values decoded so far
+}
+
+// checkDepth reports an error if a message of type name at the given
+// nesting depth exceeds the depth limit.
+func (b *decodeBudget) checkDepth(depth int, name protoreflect.FullName) error {
+	if max := b.limits.MaxDepth; max > 0 && depth > max {
+		return &LimitError{Kind: DepthLimit, Limit: max, Field: name}
+	}
+	return nil
+}
+
+// checkElements reports an error if n elements in the repeated or map
+// field fd exceeds the limit on repeated elements.
+func (b *decodeBudget) checkElements(n int, fd protoreflect.FieldDescriptor) error {
+	if max := b.limits.MaxRepeatedElements; max > 0 && n > max {
+		return &LimitError{Kind: RepeatedElementsLimit, Limit: max, Field: fd.FullName()}
+	}
+	return nil
+}
+
+// charge adds n bytes to the heap estimate, reporting an error if the
+// estimate exceeds the limit. The field fd may be nil.
+func (b *decodeBudget) charge(n int, fd protoreflect.FieldDescriptor) error {
+	b.heap += n
+	if max := b.limits.MaxHeapEstimate; max > 0 && b.heap > max {
+		e := &LimitError{Kind: HeapEstimateLimit, Limit: max}
+		if fd != nil {
+			e.Field = fd.FullName()
+		}
+		return e
+	}
+	return nil
+}
+
+// wireLimitChecker scans wire-format input ahead of unmarshaling to verify
+// that decoding it stays within the limits. Malformed input is not reported
+// here, but left for the unmarshaler to reject.
+type wireLimitChecker struct {
+	decodeBudget
+}
+
+// checkMessage checks the encoded message b of type md at the given depth.
+// The descriptor md is nil for groups in the unknown fields.
+func (c *wireLimitChecker) checkMessage(b []byte, md protoreflect.MessageDescriptor, depth int) error {
+	var name protoreflect.FullName
+	if md != nil {
+		name = md.FullName()
+	}
+	if err := c.checkDepth(depth, name); err != nil {
+		return err
+	}
+	if err := c.charge(decodecost.Message, nil); err != nil {
+		return err
+	}
+
+	var counts map[protoreflect.FieldNumber]int
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil
+		}
+		b = b[n:]
+
+		fd := c.findField(md, num)
+		var v []byte
+		switch typ {
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		case protowire.StartGroupType:
+			v, n = protowire.ConsumeGroup(num, b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return nil
+		}
+		b = b[n:]
+
+		if fd == nil {
+			if typ == protowire.StartGroupType {
+				if err := c.checkMessage(v, nil, depth+1); err != nil {
+					return err
+				}
+			}
+			if err := c.charge(n+protowire.SizeTag(num), nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		elems := 1
+		switch {
+		case fd.IsMap():
+			if typ == protowire.BytesType {
+				if err := c.checkMessage(v, fd.Message(), depth); err != nil {
+					return err
+				}
+			}
+		case fd.Message() != nil:
+			if typ == protowire.BytesType || typ == protowire.StartGroupType {
+				if err := c.checkMessage(v, fd.Message(), depth+1); err != nil {
+					return err
+				}
+			}
+		case fd.Kind() == protoreflect.StringKind || fd.Kind() == protoreflect.BytesKind:
+			if err := c.charge(decodecost.Bytes+len(v), fd); err != nil {
+				return err
+			}
+		case typ == protowire.BytesType && fd.IsList():
+			elems = packedLen(v, fd.Kind())
+			if err := c.charge(elems*decodecost.Scalar, fd); err != nil {
+				return err
+			}
+		default:
+			if err := c.charge(decodecost.Scalar, fd); err != nil {
+				return err
+			}
+		}
+
+		if fd.IsList() || fd.IsMap() {
+			if counts == nil {
+				counts = make(map[protoreflect.FieldNumber]int)
+			}
+			counts[num] += elems
+			if err := c.checkElements(counts[num], fd); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *wireLimitChecker) findField(md protoreflect.MessageDescriptor, num protoreflect.FieldNumber) protoreflect.FieldDescriptor {
+	if md == nil {
+		return nil
+	}
+	if fd := md.Fields().ByNumber(num); fd != nil {
+		return fd
+	}
+	if md.ExtensionRanges().Has(num) {
+		if xt, _ := protoregistry.GlobalTypes.FindExtensionByNumber(md.FullName(), num); xt != nil {
+			return xt.TypeDescriptor()
+		}
+	}
+	return nil
+}
+
+// packedLen reports the number of elements in the packed encoding b
+// of a repeated scalar field of the given kind.
+func packedLen(b []byte, k protoreflect.Kind) int {
+	switch k {
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind, protoreflect.FloatKind:
+		return len(b) / 4
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind, protoreflect.DoubleKind:
+		return len(b) / 8
+	default:
+		var n int
+		for _, c := range b {
+			if c < 0x80 {
+				n++
+			}
+		}
+		return n
+	}
+}
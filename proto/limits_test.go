@@ -0,0 +1,176 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/testing/protopack"
+
+	pb3 "github.com/golang/protobuf/internal/testprotos/proto3_proto"
+)
+
+func nestedMessage(depth int) *pb3.Message {
+	m := &pb3.Message{Name: "leaf"}
+	for i := 1; i < depth; i++ {
+		m = &pb3.Message{Submessage: m}
+	}
+	return m
+}
+
+var limitTests = []struct {
+	desc   string
+	in     proto.Message
+	limits proto.DecodeLimits
+	want   proto.LimitKind // zero if no error is expected
+}{{
+	desc:   "depth within limit",
+	in:     nestedMessage(5),
+	limits: proto.DecodeLimits{MaxDepth: 5},
+}, {
+	desc:   "depth exceeded",
+	in:     nestedMessage(6),
+	limits: proto.DecodeLimits{MaxDepth: 5},
+	want:   proto.DepthLimit,
+}, {
+	desc:   "map value depth exceeded",
+	in:     &pb3.Message{Terrain: map[string]*pb3.Nested{"a": {Bunny: "b"}}},
+	limits: proto.DecodeLimits{MaxDepth: 1},
+	want:   proto.DepthLimit,
+}, {
+	desc:   "input size exceeded",
+	in:     &pb3.Message{Name: strings.Repeat("x", 100)},
+	limits: proto.DecodeLimits{MaxInputSize: 50},
+	want:   proto.InputSizeLimit,
+}, {
+	desc:   "repeated elements within limit",
+	in:     &pb3.Message{Key: []uint64{1, 2, 3}, ShortKey: []int32{1, 2, 3}},
+	limits: proto.DecodeLimits{MaxRepeatedElements: 3},
+}, {
+	desc:   "repeated elements exceeded",
+	in:     &pb3.Message{Key: []uint64{1, 2, 3, 4}},
+	limits: proto.DecodeLimits{MaxRepeatedElements: 3},
+	want:   proto.RepeatedElementsLimit,
+}, {
+	desc:   "repeated messages exceeded",
+	in:     &pb3.Message{Children: []*pb3.Message{{}, {}, {}, {}}},
+	limits: proto.DecodeLimits{MaxRepeatedElements: 3},
+	want:   proto.RepeatedElementsLimit,
+}, {
+	desc:   "map entries exceeded",
+	in:     &pb3.Message{StringMap: map[string]string{"a": "", "b": "", "c": "", "d": ""}},
+	limits: proto.DecodeLimits{MaxRepeatedElements: 3},
+	want:   proto.RepeatedElementsLimit,
+}, {
+	desc:   "heap estimate exceeded",
+	in:     &pb3.Message{Data: make([]byte, 1000)},
+	limits: proto.DecodeLimits{MaxHeapEstimate: 500},
+	want:   proto.HeapEstimateLimit,
+}, {
+	desc:   "heap estimate within limit",
+	in:     &pb3.Message{Data: make([]byte, 100)},
+	limits: proto.DecodeLimits{MaxHeapEstimate: 500},
+}}
+
+func checkLimitError(t *testing.T, desc string, err error, want proto.LimitKind) {
+	t.Helper()
+	if want == 0 {
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", desc, err)
+		}
+		return
+	}
+	le, ok := err.(*proto.LimitError)
+	if !ok {
+		t.Errorf("%s: got error %v, want *proto.LimitError", desc, err)
+		return
+	}
+	if le.Kind != want {
+		t.Errorf("%s: got %v limit error, want %v", desc, le.Kind, want)
+	}
+}
+
+func TestUnmarshalWithLimits(t *testing.T) {
+	for _, tt := range limitTests {
+		b, err := proto.Marshal(tt.in)
+		if err != nil {
+			t.Fatalf("%s: Marshal error: %v", tt.desc, err)
+		}
+		prev := &pb3.Message{Name: "previous"}
+		got := proto.Clone(prev).(*pb3.Message)
+		err = proto.UnmarshalWithLimits(b, got, tt.limits)
+		checkLimitError(t, tt.desc, err, tt.want)
+		if tt.want == 0 && !proto.Equal(got, tt.in) {
+			t.Errorf("%s: mismatching message:\ngot  %v\nwant %v", tt.desc, got, tt.in)
+		}
+		if tt.want != 0 && !proto.Equal(got, prev) {
+			t.Errorf("%s: message modified on limit error:\ngot  %v\nwant %v", tt.desc, got, prev)
+		}
+	}
+}
+
+func TestBufferDecodeLimits(t *testing.T) {
+	for _, tt := range limitTests {
+		buf := proto.NewBuffer(nil)
+		if err := buf.EncodeMessage(tt.in); err != nil {
+			t.Fatalf("%s: EncodeMessage error: %v", tt.desc, err)
+		}
+		buf.SetDecodeLimits(tt.limits)
+		err := buf.DecodeMessage(new(pb3.Message))
+		checkLimitError(t, tt.desc, err, tt.want)
+	}
+}
+
+func TestUnmarshalWithLimitsPacked(t *testing.T) {
+	// A single byte per varint element is amplified to eight bytes in memory.
+	b := protopack.Message{
+		protopack.Tag{Number: 5, Type: protopack.BytesType}, protopack.LengthPrefix{
+			protopack.Varint(1), protopack.Varint(2), protopack.Varint(3), protopack.Varint(4),
+		},
+	}.Marshal()
+	err := proto.UnmarshalWithLimits(b, new(pb3.Message), proto.DecodeLimits{MaxRepeatedElements: 3})
+	checkLimitError(t, "packed elements", err, proto.RepeatedElementsLimit)
+	// The limit leaves room for the message itself and three elements.
+	err = proto.UnmarshalWithLimits(b, new(pb3.Message), proto.DecodeLimits{MaxHeapEstimate: 64 + 3*8})
+	checkLimitError(t, "packed heap", err, proto.HeapEstimateLimit)
+}
+
+func TestUnmarshalWithLimitsUnknownGroups(t *testing.T) {
+	var m protopack.Message
+	for i := 0; i < 10; i++ {
+		m = protopack.Message{
+			protopack.Tag{Number: 100, Type: protopack.StartGroupType},
+			m,
+			protopack.Tag{Number: 100, Type: protopack.EndGroupType},
+		}
+	}
+	err := proto.UnmarshalWithLimits(m.Marshal(), new(pb3.Message), proto.DecodeLimits{MaxDepth: 5})
+	checkLimitError(t, "unknown groups", err, proto.DepthLimit)
+}
+
+func TestTextUnmarshalerLimits(t *testing.T) {
+	for _, tt := range limitTests {
+		s := proto.MarshalTextString(tt.in)
+		tu := proto.TextUnmarshaler{Limits: tt.limits}
+		if tt.limits.MaxInputSize > 0 {
+			// The text format is larger than the wire format.
+			tu.Limits.MaxInputSize = len(s) - 1
+		}
+		got := new(pb3.Message)
+		err := tu.Unmarshal(s, got)
+		checkLimitError(t, tt.desc, err, tt.want)
+		if tt.want == 0 && !proto.Equal(got, tt.in) {
+			t.Errorf("%s: mismatching message:\ngot  %v\nwant %v", tt.desc, got, tt.in)
+		}
+	}
+}
+
+func TestTextUnmarshalerLimitsListNotation(t *testing.T) {
+	tu := proto.TextUnmarshaler{Limits: proto.DecodeLimits{MaxRepeatedElements: 3}}
+	err := tu.Unmarshal("key: [1, 2, 3, 4]", new(pb3.Message))
+	checkLimitError(t, "list notation", err, proto.RepeatedElementsLimit)
+}
@@ -0,0 +1,683 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/runtime/protoimpl"
+)
+
+// PathError is returned by GetPath, SetPath, and ClearPath
+// when a path is malformed or cannot be resolved against a message.
+type PathError struct {
+	Path string
+
+	// Offset is the byte offset in Path of the element that is in error.
+	Offset int
+
+	Message string
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("proto: path %q at offset %d: %s", e.Path, e.Offset, e.Message)
+}
+
+// GetPath returns the value located at path within m.
+//
+// A path is a sequence of field names separated by dots, using the same
+// names as the text format. Extension fields are written as their full name
+// enclosed in brackets. A list element is selected with its index and a map
+// entry with its key in the syntax of the text format. For example:
+//
+//	spec.containers[2].env["FOO"]
+//	children[0].[pkg.extension_name].count
+//	int32_map[-5]
+//
+// The name of a oneof selects whichever of its fields is populated.
+//
+// Unpopulated messages along the path read as empty messages, so the value
+// of an unpopulated field is its default. It is an error for a list index to
+// be out of range or for a map key to be absent.
+func GetPath(m Message, path string) (protoreflect.Value, error) {
+	t, _, err := resolvePath(m, path, getPath)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	v := t.m.Get(t.fd)
+	switch {
+	case t.index >= 0:
+		return v.List().Get(t.index), nil
+	case t.hasKey:
+		return v.Map().Get(t.key), nil
+	default:
+		return v, nil
+	}
+}
+
+// SetPath stores v at the location named by path within m,
+// allocating any unpopulated messages along the path.
+// See GetPath for the syntax of a path.
+//
+// The value v may be a protoreflect.Value or a Go value of a type that is
+// convertible to the field: a bool, integer, floating-point,
+// string, or []byte for scalar fields; a generated enum, protoreflect.EnumNumber,
+// integer, or value name for enum fields; and a message of the field's type
+// for message fields. Integers that overflow the field are rejected.
+// A repeated field may be set as a whole from a slice,
+// and a map field from a Go map. A list index equal to the length of the list
+// appends a new element, and setting a member of a oneof clears the others.
+//
+// The message m is not modified if SetPath returns an error.
+func SetPath(m Message, path string, v interface{}) error {
+	t, _, err := resolvePath(m, path, setPath)
+	if err != nil {
+		return err
+	}
+	pv, err := t.value(v)
+	if err != nil {
+		return &PathError{Path: path, Offset: t.offset, Message: err.Error()}
+	}
+	t.allocate(MessageReflect(m))
+	t.store(pv)
+	return nil
+}
+
+// ClearPath clears the value located at path within m. A list element is
+// removed, shifting any later elements down, and a map entry is deleted.
+// It is not an error for the value to already be absent.
+// See GetPath for the syntax of a path.
+func ClearPath(m Message, path string) error {
+	t, ok, err := resolvePath(m, path, clearPath)
+	if err != nil || !ok {
+		return err
+	}
+	switch {
+	case t.index >= 0:
+		lv := t.m.Mutable(t.fd).List()
+		n := lv.Len()
+		for i := t.index; i < n-1; i++ {
+			lv.Set(i, lv.Get(i+1))
+		}
+		lv.Truncate(n - 1)
+	case t.hasKey:
+		t.m.Mutable(t.fd).Map().Clear(t.key)
+	default:
+		t.m.Clear(t.fd)
+	}
+	return nil
+}
+
+type pathOp int
+
+const (
+	getPath pathOp = iota
+	setPath
+	clearPath
+)
+
+type pathElemKind int
+
+const (
+	fieldElem     pathElemKind = iota // a field or oneof name
+	extensionElem                     // an extension name in brackets
+	keyElem                           // a list index or map key in brackets
+)
+
+type pathElem struct {
+	kind   pathElemKind
+	offset int
+	name   string // field or extension name, or the key in its text form
+	quoted bool   // the key is a quoted string
+}
+
+// parsePath splits path into its elements.
+func parsePath(path string) ([]pathElem, error) {
+	errorf := func(offset int, format string, args ...interface{}) error {
+		return &PathError{Path: path, Offset: offset, Message: fmt.Sprintf(format, args...)}
+	}
+
+	var elems []pathElem
+	i := 0
+	wantField := true
+	for {
+		start := i
+		if wantField {
+			switch {
+			case i == len(path):
+				return nil, errorf(i, "missing field name")
+			case path[i] == '[':
+				n := strings.IndexByte(path[i:], ']')
+				if n < 0 {
+					return nil, errorf(i, "unterminated extension name")
+				}
+				name := protoreflect.FullName(path[i+1 : i+n])
+				if !name.IsValid() {
+					return nil, errorf(i, "invalid extension name %q", name)
+				}
+				elems = append(elems, pathElem{kind: extensionElem, offset: start, name: string(name)})
+				i += n + 1
+			default:
+				for i < len(path) && isPathIdentChar(path[i]) {
+					i++
+				}
+				name := protoreflect.Name(path[start:i])
+				if !name.IsValid() {
+					if i == start {
+						return nil, errorf(i, "unexpected %q, want field name", path[i])
+					}
+					return nil, errorf(start, "invalid field name %q", name)
+				}
+				elems = append(elems, pathElem{kind: fieldElem, offset: start, name: string(name)})
+			}
+			wantField = false
+			continue
+		}
+
+		if i == len(path) {
+			return elems, nil
+		}
+		switch path[i] {
+		case '.':
+			i++
+			wantField = true
+		case '[':
+			i++
+			e := pathElem{kind: keyElem, offset: start}
+			if i < len(path) && path[i] == '"' {
+				j := i + 1
+				for j < len(path) && path[j] != '"' {
+					if path[j] == '\\' {
+						j++
+					}
+					j++
+				}
+				if j >= len(path) {
+					return nil, errorf(start, "unterminated string key")
+				}
+				s, err := strconv.Unquote(path[i : j+1])
+				if err != nil {
+					return nil, errorf(start, "invalid string key %s", path[i:j+1])
+				}
+				e.name, e.quoted = s, true
+				i = j + 1
+			} else {
+				n := strings.IndexByte(path[i:], ']')
+				if n < 0 {
+					return nil, errorf(start, "unterminated index")
+				}
+				e.name = path[i : i+n]
+				i += n
+			}
+			if i == len(path) || path[i] != ']' {
+				return nil, errorf(start, "unterminated index")
+			}
+			i++
+			elems = append(elems, e)
+		default:
+			return nil, errorf(i, "unexpected %q after %q", path[i], path[:i])
+		}
+	}
+}
+
+func isPathIdentChar(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// pathTarget is the location within a message that a path resolves to.
+type pathTarget struct {
+	m      protoreflect.Message // the message containing the field
+	fd     protoreflect.FieldDescriptor
+	offset int // offset of the field in the path
+
+	index  int // the list index, or -1 if there is none
+	key    protoreflect.MapKey
+	hasKey bool
+
+	// parents are the locations of the messages along the path, from the
+	// root message, when setting. The message m is only read, and may be an
+	// empty message standing for an unpopulated one until allocate is called.
+	parents []pathTarget
+}
+
+// resolvePath walks the path through m, returning the location it names.
+// When clearing, it reports false if the location is already absent.
+// It does not modify m: when setting, the unpopulated messages along the
+// path are allocated by the allocate method of the location.
+func resolvePath(m Message, path string, op pathOp) (pathTarget, bool, error) {
+	elems, err := parsePath(path)
+	if err != nil {
+		return pathTarget{}, false, err
+	}
+	errorf := func(offset int, format string, args ...interface{}) error {
+		return &PathError{Path: path, Offset: offset, Message: fmt.Sprintf(format, args...)}
+	}
+
+	cur := MessageReflect(m)
+	if cur == nil || (op != getPath && !cur.IsValid()) {
+		return pathTarget{}, false, errorf(0, "nil message")
+	}
+	// When clearing, absent is set once the path leaves the populated
+	// values. The rest of the path is still checked against the descriptors.
+	absent := false
+	var parents []pathTarget
+	for i := 0; ; {
+		e := elems[i]
+		i++
+		if e.kind == keyElem {
+			// A list element or map value is never itself a list or map.
+			return pathTarget{}, false, errorf(e.offset, "%s is not a list or map", path[:e.offset])
+		}
+
+		fd, od, err := findPathField(cur.Descriptor(), e)
+		if err != nil {
+			return pathTarget{}, false, errorf(e.offset, "%v", err)
+		}
+		if od != nil {
+			if op == setPath {
+				return pathTarget{}, false, errorf(e.offset, "cannot set oneof %q, set one of its fields instead", od.Name())
+			}
+			if fd = cur.WhichOneof(od); fd == nil {
+				if op == clearPath {
+					return pathTarget{}, false, nil
+				}
+				return pathTarget{}, false, errorf(e.offset, "oneof %q is not set", od.Name())
+			}
+		}
+
+		t := pathTarget{m: cur, fd: fd, offset: e.offset, index: -1}
+		if i < len(elems) && elems[i].kind == keyElem {
+			ke := elems[i]
+			i++
+			switch {
+			case fd.IsList():
+				idx, err := strconv.Atoi(ke.name)
+				if err != nil || ke.quoted || idx < 0 {
+					return pathTarget{}, false, errorf(ke.offset, "invalid index %q for list field %v", ke.name, fd.FullName())
+				}
+				n := cur.Get(fd).List().Len()
+				switch {
+				case idx < n:
+				case op == setPath && idx == n:
+					// Setting the element just past the end appends it.
+				case op == clearPath:
+					absent = true
+				default:
+					return pathTarget{}, false, errorf(ke.offset, "index %d out of range for list field %v of length %d", idx, fd.FullName(), n)
+				}
+				t.index = idx
+			case fd.IsMap():
+				key, err := parsePathMapKey(fd.MapKey(), ke)
+				if err != nil {
+					return pathTarget{}, false, errorf(ke.offset, "%v", err)
+				}
+				switch {
+				case op == setPath || cur.Get(fd).Map().Has(key):
+				case op == clearPath:
+					absent = true
+				default:
+					return pathTarget{}, false, errorf(ke.offset, "key %s not found in map field %v", formatMapKey(key), fd.FullName())
+				}
+				t.key, t.hasKey = key, true
+			default:
+				return pathTarget{}, false, errorf(ke.offset, "field %v is not a list or map", fd.FullName())
+			}
+		}
+		if i == len(elems) {
+			t.parents = parents
+			return t, !absent, nil
+		}
+
+		// Descend into the message located by t.
+		next := elems[i]
+		switch {
+		case (fd.IsList() || fd.IsMap()) && t.index < 0 && !t.hasKey:
+			return pathTarget{}, false, errorf(next.offset, "field %v must be indexed before accessing its fields", fd.FullName())
+		case fd.IsMap() && fd.MapValue().Message() == nil:
+			return pathTarget{}, false, errorf(next.offset, "values of map field %v are not messages", fd.FullName())
+		case !fd.IsMap() && fd.Message() == nil:
+			return pathTarget{}, false, errorf(next.offset, "field %v is not a message", fd.FullName())
+		}
+		if op == clearPath && t.index < 0 && !t.hasKey && !cur.Has(fd) {
+			absent = true
+		}
+		if op == setPath {
+			parents = append(parents, t)
+		}
+		cur = readPathMessage(cur, t)
+	}
+}
+
+// allocate populates the messages along the path of t within the root
+// message, and makes t locate its field in the populated message.
+func (t *pathTarget) allocate(root protoreflect.Message) {
+	cur := root
+	for _, p := range t.parents {
+		switch {
+		case p.index >= 0:
+			lv := cur.Mutable(p.fd).List()
+			if p.index == lv.Len() {
+				lv.Append(lv.NewElement())
+			}
+			cur = lv.Get(p.index).Message()
+		case p.hasKey:
+			cur = cur.Mutable(p.fd).Map().Mutable(p.key).Message()
+		default:
+			cur = cur.Mutable(p.fd).Message()
+		}
+	}
+	t.m = cur
+}
+
+// readPathMessage returns the message located by t without modifying
+// its parent. An absent list element or map value reads as an empty message.
+func readPathMessage(m protoreflect.Message, t pathTarget) protoreflect.Message {
+	v := m.Get(t.fd)
+	switch {
+	case t.index >= 0:
+		if lv := v.List(); t.index < lv.Len() {
+			return lv.Get(t.index).Message()
+		}
+		return m.NewField(t.fd).List().NewElement().Message()
+	case t.hasKey:
+		if mv := v.Map(); mv.Has(t.key) {
+			return mv.Get(t.key).Message()
+		}
+		return m.NewField(t.fd).Map().NewValue().Message()
+	default:
+		return v.Message()
+	}
+}
+
+// findPathField returns the field or oneof in md named by e,
+// which is either a field or an extension element.
+func findPathField(md protoreflect.MessageDescriptor, e pathElem) (protoreflect.FieldDescriptor, protoreflect.OneofDescriptor, error) {
+	if e.kind == extensionElem {
+		xname := protoreflect.FullName(e.name)
+		xt, _ := protoregistry.GlobalTypes.FindExtensionByName(xname)
+		if xt == nil && isMessageSet(md) {
+			xt, _ = protoregistry.GlobalTypes.FindExtensionByName(xname.Append("message_set_extension"))
+		}
+		if xt == nil {
+			return nil, nil, fmt.Errorf("unknown extension %v", xname)
+		}
+		xd := xt.TypeDescriptor()
+		if xd.ContainingMessage().FullName() != md.FullName() {
+			return nil, nil, fmt.Errorf("extension %v does not extend message %v", xname, md.FullName())
+		}
+		return xd, nil, nil
+	}
+
+	// Groups are named by their message name, as in the text format.
+	name := protoreflect.Name(e.name)
+	fds := md.Fields()
+	fd := fds.ByName(name)
+	switch {
+	case fd == nil:
+		gd := fds.ByName(protoreflect.Name(strings.ToLower(string(name))))
+		if gd != nil && gd.Kind() == protoreflect.GroupKind && gd.Message().Name() == name {
+			fd = gd
+		}
+	case fd.Kind() == protoreflect.GroupKind && fd.Message().Name() != name:
+		fd = nil
+	}
+	if fd != nil {
+		return fd, nil, nil
+	}
+	if od := md.Oneofs().ByName(name); od != nil && !od.IsSynthetic() {
+		return nil, od, nil
+	}
+	return nil, nil, fmt.Errorf("no field %q in message %v", name, md.FullName())
+}
+
+// parsePathMapKey parses the text of a map key for the key field kfd.
+func parsePathMapKey(kfd protoreflect.FieldDescriptor, e pathElem) (protoreflect.MapKey, error) {
+	if kfd.Kind() == protoreflect.StringKind {
+		if !e.quoted {
+			return protoreflect.MapKey{}, fmt.Errorf("key %s for map field %v must be a quoted string", e.name, kfd.Parent().FullName())
+		}
+		return protoreflect.ValueOfString(e.name).MapKey(), nil
+	}
+	if !e.quoted {
+		switch kfd.Kind() {
+		case protoreflect.BoolKind:
+			switch e.name {
+			case "true":
+				return protoreflect.ValueOfBool(true).MapKey(), nil
+			case "false":
+				return protoreflect.ValueOfBool(false).MapKey(), nil
+			}
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+			if n, err := strconv.ParseInt(e.name, 0, 32); err == nil {
+				return protoreflect.ValueOfInt32(int32(n)).MapKey(), nil
+			}
+		case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			if n, err := strconv.ParseInt(e.name, 0, 64); err == nil {
+				return protoreflect.ValueOfInt64(n).MapKey(), nil
+			}
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+			if n, err := strconv.ParseUint(e.name, 0, 32); err == nil {
+				return protoreflect.ValueOfUint32(uint32(n)).MapKey(), nil
+			}
+		case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			if n, err := strconv.ParseUint(e.name, 0, 64); err == nil {
+				return protoreflect.ValueOfUint64(n).MapKey(), nil
+			}
+		}
+	}
+	return protoreflect.MapKey{}, fmt.Errorf("invalid %v key %q for map field %v", kfd.Kind(), e.name, kfd.Parent().FullName())
+}
+
+// value converts v to the value stored at the location t.
+func (t pathTarget) value(v interface{}) (protoreflect.Value, error) {
+	fd := t.fd
+	switch {
+	case t.index >= 0:
+		return pathValueOf(fd, v, func() protoreflect.Message { return t.m.NewField(fd).List().NewElement().Message() })
+	case t.hasKey:
+		return pathValueOf(fd.MapValue(), v, func() protoreflect.Message { return t.m.NewField(fd).Map().NewValue().Message() })
+	case fd.IsList():
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			return protoreflect.Value{}, fmt.Errorf("cannot use %T as value for repeated field %v", v, fd.FullName())
+		}
+		lv := t.m.NewField(fd).List()
+		for i := 0; i < rv.Len(); i++ {
+			ev, err := pathValueOf(fd, rv.Index(i).Interface(), func() protoreflect.Message { return lv.NewElement().Message() })
+			if err != nil {
+				return protoreflect.Value{}, fmt.Errorf("element %d: %v", i, err)
+			}
+			lv.Append(ev)
+		}
+		return protoreflect.ValueOfList(lv), nil
+	case fd.IsMap():
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return protoreflect.Value{}, fmt.Errorf("cannot use %T as value for map field %v", v, fd.FullName())
+		}
+		mv := t.m.NewField(fd).Map()
+		for _, k := range rv.MapKeys() {
+			kv, err := pathValueOf(fd.MapKey(), k.Interface(), nil)
+			if err != nil {
+				return protoreflect.Value{}, fmt.Errorf("key %v: %v", k, err)
+			}
+			ev, err := pathValueOf(fd.MapValue(), rv.MapIndex(k).Interface(), func() protoreflect.Message { return mv.NewValue().Message() })
+			if err != nil {
+				return protoreflect.Value{}, fmt.Errorf("value for key %v: %v", k, err)
+			}
+			mv.Set(kv.MapKey(), ev)
+		}
+		return protoreflect.ValueOfMap(mv), nil
+	default:
+		return pathValueOf(fd, v, func() protoreflect.Message { return t.m.NewField(fd).Message() })
+	}
+}
+
+// store stores v at the location t, whose messages must be allocated.
+func (t pathTarget) store(v protoreflect.Value) {
+	switch {
+	case t.index >= 0:
+		lv := t.m.Mutable(t.fd).List()
+		if t.index == lv.Len() {
+			lv.Append(v)
+		} else {
+			lv.Set(t.index, v)
+		}
+	case t.hasKey:
+		t.m.Mutable(t.fd).Map().Set(t.key, v)
+	default:
+		t.m.Set(t.fd, v)
+	}
+}
+
+// pathValueOf converts v to a single value of the field fd.
+// The newMessage function allocates a message value of the field's Go type.
+func pathValueOf(fd protoreflect.FieldDescriptor, v interface{}, newMessage func() protoreflect.Message) (protoreflect.Value, error) {
+	if pv, ok := v.(protoreflect.Value); ok {
+		v = pv.Interface()
+	}
+	rv := reflect.ValueOf(v)
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if b, ok := v.(bool); ok {
+			return protoreflect.ValueOfBool(b), nil
+		}
+	case protoreflect.EnumKind:
+		switch v := v.(type) {
+		case protoreflect.EnumNumber:
+			return protoreflect.ValueOfEnum(v), nil
+		case string:
+			vd := fd.Enum().Values().ByName(protoreflect.Name(v))
+			if vd == nil {
+				return protoreflect.Value{}, fmt.Errorf("unknown value %q for enum %v", v, fd.Enum().FullName())
+			}
+			return protoreflect.ValueOfEnum(vd.Number()), nil
+		case protoreflect.Enum, interface{ EnumDescriptor() ([]byte, []int) }:
+			// A generated enum must be of the field's enum type.
+			if ed := protoimpl.X.EnumDescriptorOf(v); ed.FullName() != fd.Enum().FullName() {
+				return protoreflect.Value{}, fmt.Errorf("cannot use enum %v as value for field %v of type %v", ed.FullName(), fd.FullName(), fd.Enum().FullName())
+			}
+		}
+		if n, ok, err := pathInt(rv, 32); ok {
+			return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), err
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if n, ok, err := pathInt(rv, 32); ok {
+			return protoreflect.ValueOfInt32(int32(n)), err
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if n, ok, err := pathInt(rv, 64); ok {
+			return protoreflect.ValueOfInt64(n), err
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if n, ok, err := pathUint(rv, 32); ok {
+			return protoreflect.ValueOfUint32(uint32(n)), err
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if n, ok, err := pathUint(rv, 64); ok {
+			return protoreflect.ValueOfUint64(n), err
+		}
+	case protoreflect.FloatKind:
+		switch v := v.(type) {
+		case float32:
+			return protoreflect.ValueOfFloat32(v), nil
+		case float64:
+			return protoreflect.ValueOfFloat32(float32(v)), nil
+		}
+	case protoreflect.DoubleKind:
+		switch v := v.(type) {
+		case float32:
+			return protoreflect.ValueOfFloat64(float64(v)), nil
+		case float64:
+			return protoreflect.ValueOfFloat64(v), nil
+		}
+	case protoreflect.StringKind:
+		if s, ok := v.(string); ok {
+			return protoreflect.ValueOfString(s), nil
+		}
+	case protoreflect.BytesKind:
+		switch v := v.(type) {
+		case []byte:
+			return protoreflect.ValueOfBytes(v), nil
+		case string:
+			return protoreflect.ValueOfBytes([]byte(v)), nil
+		}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		var mr protoreflect.Message
+		switch v := v.(type) {
+		case protoreflect.Message:
+			mr = v
+		case protoreflect.ProtoMessage:
+			mr = v.ProtoReflect()
+		case Message:
+			mr = MessageReflect(v)
+		}
+		if mr == nil {
+			break
+		}
+		if mr.Descriptor().FullName() != fd.Message().FullName() {
+			return protoreflect.Value{}, fmt.Errorf("cannot use message %v as value for field %v of type %v", mr.Descriptor().FullName(), fd.FullName(), fd.Message().FullName())
+		}
+		if !mr.IsValid() {
+			return protoreflect.Value{}, fmt.Errorf("cannot use nil %T as value for field %v", v, fd.FullName())
+		}
+		if nm := newMessage(); nm.Type() != mr.Type() {
+			// The message is of a different Go type, such as a dynamic message.
+			protoV2.Merge(nm.Interface(), mr.Interface())
+			mr = nm
+		}
+		return protoreflect.ValueOfMessage(mr), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("cannot use %T as value for field %v of kind %v", v, fd.FullName(), fd.Kind())
+}
+
+// pathInt converts an integer to an int64 that fits in the given number of bits.
+// It reports false if rv is not an integer.
+func pathInt(rv reflect.Value, bits uint) (int64, bool, error) {
+	var n int64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, true, fmt.Errorf("value %v overflows int%d", rv.Uint(), bits)
+		}
+		n = int64(rv.Uint())
+	default:
+		return 0, false, nil
+	}
+	if bits < 64 && (n < -1<<(bits-1) || n >= 1<<(bits-1)) {
+		return 0, true, fmt.Errorf("value %v overflows int%d", n, bits)
+	}
+	return n, true, nil
+}
+
+// pathUint converts an integer to a uint64 that fits in the given number of bits.
+// It reports false if rv is not an integer.
+func pathUint(rv reflect.Value, bits uint) (uint64, bool, error) {
+	var n uint64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() < 0 {
+			return 0, true, fmt.Errorf("value %v overflows uint%d", rv.Int(), bits)
+		}
+		n = uint64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n = rv.Uint()
+	default:
+		return 0, false, nil
+	}
+	if bits < 64 && n >= 1<<bits {
+		return 0, true, fmt.Errorf("value %v overflows uint%d", n, bits)
+	}
+	return n, true, nil
+}
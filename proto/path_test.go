@@ -0,0 +1,376 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb2 "github.com/golang/protobuf/internal/testprotos/proto2_proto"
+	pb3 "github.com/golang/protobuf/internal/testprotos/proto3_proto"
+)
+
+func withExtension(m proto.Message, xt *proto.ExtensionDesc, v interface{}) proto.Message {
+	if err := proto.SetExtension(m, xt, v); err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func TestSetPath(t *testing.T) {
+	tests := []struct {
+		in   proto.Message
+		path string
+		v    interface{}
+		want proto.Message
+	}{{
+		in:   &pb3.Message{},
+		path: "name",
+		v:    "Aaron",
+		want: &pb3.Message{Name: "Aaron"},
+	}, {
+		in:   &pb3.Message{},
+		path: "height_in_cm",
+		v:    170,
+		want: &pb3.Message{HeightInCm: 170},
+	}, {
+		in:   &pb3.Message{},
+		path: "hilarity",
+		v:    "PUNS",
+		want: &pb3.Message{Hilarity: pb3.Message_PUNS},
+	}, {
+		in:   &pb3.Message{},
+		path: "submessage.submessage.hilarity",
+		v:    pb3.Message_SLAPSTICK,
+		want: &pb3.Message{Submessage: &pb3.Message{Submessage: &pb3.Message{Hilarity: pb3.Message_SLAPSTICK}}},
+	}, {
+		in:   &pb3.Message{},
+		path: "nested",
+		v:    &pb3.Nested{Bunny: "Bugs"},
+		want: &pb3.Message{Nested: &pb3.Nested{Bunny: "Bugs"}},
+	}, {
+		in:   &pb3.Message{Children: []*pb3.Message{{Name: "Sarah"}}},
+		path: "children[1].name",
+		v:    "Tom",
+		want: &pb3.Message{Children: []*pb3.Message{{Name: "Sarah"}, {Name: "Tom"}}},
+	}, {
+		in:   &pb3.Message{Children: []*pb3.Message{{Name: "Sarah"}, {Name: "Tom"}}},
+		path: "children[0].name",
+		v:    "Sally",
+		want: &pb3.Message{Children: []*pb3.Message{{Name: "Sally"}, {Name: "Tom"}}},
+	}, {
+		in:   &pb3.Message{Key: []uint64{1, 2}},
+		path: "key[1]",
+		v:    uint64(5),
+		want: &pb3.Message{Key: []uint64{1, 5}},
+	}, {
+		in:   &pb3.Message{Key: []uint64{1, 2}},
+		path: "key",
+		v:    []int{3, 4, 5},
+		want: &pb3.Message{Key: []uint64{3, 4, 5}},
+	}, {
+		in:   &pb3.Message{},
+		path: "r_funny[0]",
+		v:    pb3.Message_BILL_BAILEY,
+		want: &pb3.Message{RFunny: []pb3.Message_Humour{pb3.Message_BILL_BAILEY}},
+	}, {
+		in:   &pb3.Message{},
+		path: `terrain["a b"].bunny`,
+		v:    "Bugs",
+		want: &pb3.Message{Terrain: map[string]*pb3.Nested{"a b": {Bunny: "Bugs"}}},
+	}, {
+		in:   &pb3.Message{StringMap: map[string]string{"a": "b"}},
+		path: `string_map["\x00"]`,
+		v:    "nul",
+		want: &pb3.Message{StringMap: map[string]string{"a": "b", "\x00": "nul"}},
+	}, {
+		in:   &pb3.Message{StringMap: map[string]string{"a": "b"}},
+		path: "string_map",
+		v:    map[string]string{"c": "d"},
+		want: &pb3.Message{StringMap: map[string]string{"c": "d"}},
+	}, {
+		in:   &pb3.IntMaps{},
+		path: "maps[0].rtt[-5]",
+		v:    int32(5),
+		want: &pb3.IntMaps{Maps: []*pb3.IntMap{{Rtt: map[int32]int32{-5: 5}}}},
+	}, {
+		in:   &pb2.MessageWithMap{},
+		path: "msg_mapping[0x10].f",
+		v:    1.5,
+		want: &pb2.MessageWithMap{MsgMapping: map[int64]*pb2.FloatingPoint{16: {F: proto.Float64(1.5)}}},
+	}, {
+		in:   &pb2.MessageWithMap{},
+		path: "byte_mapping[true]",
+		v:    "yes",
+		want: &pb2.MessageWithMap{ByteMapping: map[bool][]byte{true: []byte("yes")}},
+	}, {
+		in:   &pb2.MyMessage{},
+		path: "SomeGroup.group_field",
+		v:    int64(7),
+		want: &pb2.MyMessage{Somegroup: &pb2.MyMessage_SomeGroup{GroupField: proto.Int32(7)}},
+	}, {
+		in:   &pb2.MyMessage{},
+		path: "bikeshed",
+		v:    protoreflect.EnumNumber(2),
+		want: &pb2.MyMessage{Bikeshed: pb2.MyMessage_BLUE.Enum()},
+	}, {
+		in:   &pb2.MyMessage{Count: proto.Int32(1)},
+		path: "[proto2_test.Ext.more].data",
+		v:    "Picard",
+		want: withExtension(&pb2.MyMessage{Count: proto.Int32(1)}, pb2.E_Ext_More, &pb2.Ext{Data: proto.String("Picard")}),
+	}, {
+		in:   &pb2.MyMessage{},
+		path: "[proto2_test.greeting][0]",
+		v:    "hello",
+		want: withExtension(&pb2.MyMessage{}, pb2.E_Greeting, []string{"hello"}),
+	}, {
+		in:   &pb2.Communique{Union: &pb2.Communique_Number{Number: 5}},
+		path: "name",
+		v:    "Ernest",
+		want: &pb2.Communique{Union: &pb2.Communique_Name{Name: "Ernest"}},
+	}, {
+		in:   &pb2.Communique{},
+		path: "msg.string_field",
+		v:    "text",
+		want: &pb2.Communique{Union: &pb2.Communique_Msg{Msg: &pb2.Strings{StringField: proto.String("text")}}},
+	}, {
+		in:   &pb3.Message{},
+		path: "name",
+		v:    protoreflect.ValueOfString("Aaron"),
+		want: &pb3.Message{Name: "Aaron"},
+	}}
+
+	for _, tt := range tests {
+		got := proto.Clone(tt.in)
+		if err := proto.SetPath(got, tt.path, tt.v); err != nil {
+			t.Errorf("SetPath(%v, %q, %v) error: %v", tt.in, tt.path, tt.v, err)
+			continue
+		}
+		if !proto.Equal(got, tt.want) {
+			t.Errorf("SetPath(%v, %q, %v):\ngot  %v\nwant %v", tt.in, tt.path, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	m3 := &pb3.Message{
+		Name:     "Aaron",
+		Hilarity: pb3.Message_PUNS,
+		Key:      []uint64{1, 2},
+		Terrain:  map[string]*pb3.Nested{"a": {Bunny: "Bugs"}},
+		Children: []*pb3.Message{{Name: "Sarah"}, {Name: "Tom"}},
+	}
+	m2 := withExtension(&pb2.MyMessage{
+		Count:     proto.Int32(4),
+		Somegroup: &pb2.MyMessage_SomeGroup{GroupField: proto.Int32(9)},
+	}, pb2.E_Ext_Number, proto.Int32(42))
+
+	tests := []struct {
+		in   proto.Message
+		path string
+		want interface{}
+	}{
+		{m3, "name", "Aaron"},
+		{m3, "hilarity", protoreflect.EnumNumber(1)},
+		{m3, "key[1]", uint64(2)},
+		{m3, `terrain["a"].bunny`, "Bugs"},
+		{m3, "children[1].name", "Tom"},
+		{m3, "submessage.submessage.name", ""},
+		{m3, "nested.cute", false},
+		{m2, "count", int32(4)},
+		{m2, "SomeGroup.group_field", int32(9)},
+		{m2, "[proto2_test.Ext.number]", int32(42)},
+		{m2, "bikeshed", protoreflect.EnumNumber(pb2.MyMessage_RED)}, // the default value
+		{&pb2.Communique{Union: &pb2.Communique_Name{Name: "Ernest"}}, "union", "Ernest"},
+		{&pb2.MessageWithMap{NameMapping: map[int32]string{-1: "minus one"}}, "name_mapping[-1]", "minus one"},
+	}
+
+	for _, tt := range tests {
+		v, err := proto.GetPath(tt.in, tt.path)
+		if err != nil {
+			t.Errorf("GetPath(%v, %q) error: %v", tt.in, tt.path, err)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, v.Interface()); diff != "" {
+			t.Errorf("GetPath(%v, %q) mismatch (-want +got):\n%s", tt.in, tt.path, diff)
+		}
+	}
+
+	v, err := proto.GetPath(m3, "children[0]")
+	if err != nil {
+		t.Fatalf("GetPath(%v, %q) error: %v", m3, "children[0]", err)
+	}
+	if got := proto.MessageV1(v.Message().Interface()); got != m3.Children[0] {
+		t.Errorf("GetPath(%v, %q) = %v, want %v", m3, "children[0]", got, m3.Children[0])
+	}
+}
+
+func TestClearPath(t *testing.T) {
+	tests := []struct {
+		in   proto.Message
+		path string
+		want proto.Message
+	}{{
+		in:   &pb3.Message{Name: "Aaron", Key: []uint64{1}},
+		path: "name",
+		want: &pb3.Message{Key: []uint64{1}},
+	}, {
+		in:   &pb3.Message{Children: []*pb3.Message{{Name: "Sarah"}, {Name: "Tom"}, {Name: "Ann"}}},
+		path: "children[0]",
+		want: &pb3.Message{Children: []*pb3.Message{{Name: "Tom"}, {Name: "Ann"}}},
+	}, {
+		in:   &pb3.Message{Children: []*pb3.Message{{Name: "Sarah"}}},
+		path: "children[0].name",
+		want: &pb3.Message{Children: []*pb3.Message{{}}},
+	}, {
+		in:   &pb3.Message{Terrain: map[string]*pb3.Nested{"a": {}, "b": {}}},
+		path: `terrain["a"]`,
+		want: &pb3.Message{Terrain: map[string]*pb3.Nested{"b": {}}},
+	}, {
+		in:   &pb3.Message{Name: "Aaron"},
+		path: "submessage.name",
+		want: &pb3.Message{Name: "Aaron"},
+	}, {
+		in:   &pb3.Message{Key: []uint64{1}},
+		path: "key[5]",
+		want: &pb3.Message{Key: []uint64{1}},
+	}, {
+		in:   &pb3.Message{},
+		path: `terrain["missing"].bunny`,
+		want: &pb3.Message{},
+	}, {
+		in:   &pb2.Communique{MakeMeCry: proto.Bool(true), Union: &pb2.Communique_Number{Number: 5}},
+		path: "union",
+		want: &pb2.Communique{MakeMeCry: proto.Bool(true)},
+	}, {
+		in:   withExtension(&pb2.MyMessage{Count: proto.Int32(1)}, pb2.E_Ext_Text, proto.String("hi")),
+		path: "[proto2_test.Ext.text]",
+		want: &pb2.MyMessage{Count: proto.Int32(1)},
+	}}
+
+	for _, tt := range tests {
+		got := proto.Clone(tt.in)
+		if err := proto.ClearPath(got, tt.path); err != nil {
+			t.Errorf("ClearPath(%v, %q) error: %v", tt.in, tt.path, err)
+			continue
+		}
+		if !proto.Equal(got, tt.want) {
+			t.Errorf("ClearPath(%v, %q):\ngot  %v\nwant %v", tt.in, tt.path, got, tt.want)
+		}
+	}
+
+	// Clearing below an unpopulated message must not allocate it.
+	m := &pb3.Message{}
+	if err := proto.ClearPath(m, "submessage.name"); err != nil {
+		t.Fatalf("ClearPath error: %v", err)
+	}
+	if m.Submessage != nil {
+		t.Errorf("ClearPath allocated submessage")
+	}
+}
+
+func TestPathErrors(t *testing.T) {
+	type op int
+	const (
+		get op = iota
+		set
+		clear
+	)
+	tests := []struct {
+		op         op
+		in         proto.Message
+		path       string
+		v          interface{}
+		wantOffset int
+	}{
+		{op: get, in: &pb3.Message{}, path: "", wantOffset: 0},
+		{op: get, in: &pb3.Message{}, path: "name.", wantOffset: 5},
+		{op: get, in: &pb3.Message{}, path: "name..x", wantOffset: 5},
+		{op: get, in: &pb3.Message{}, path: "9name", wantOffset: 0},
+		{op: get, in: &pb3.Message{}, path: "name x", wantOffset: 4},
+		{op: get, in: &pb3.Message{}, path: "nmae", wantOffset: 0},
+		{op: get, in: &pb3.Message{}, path: "nested.bunnny", wantOffset: 7},
+		{op: get, in: &pb3.Message{}, path: "children[x]", wantOffset: 8},
+		{op: get, in: &pb3.Message{}, path: "children[-1]", wantOffset: 8},
+		{op: get, in: &pb3.Message{}, path: "children[1", wantOffset: 8},
+		{op: get, in: &pb3.Message{Children: []*pb3.Message{{}}}, path: "children[1].name", wantOffset: 8},
+		{op: set, in: &pb3.Message{Children: []*pb3.Message{{}}}, path: "children[2].name", wantOffset: 8},
+		{op: get, in: &pb3.Message{}, path: "name[0]", wantOffset: 4},
+		{op: get, in: &pb3.Message{}, path: "children.name", wantOffset: 9},
+		{op: get, in: &pb3.Message{}, path: "name.bunny", wantOffset: 5},
+		{op: get, in: &pb3.Message{}, path: "string_map[a]", wantOffset: 10},
+		{op: get, in: &pb3.Message{}, path: `string_map["a"]`, wantOffset: 10},
+		{op: get, in: &pb3.Message{}, path: `string_map["a`, wantOffset: 10},
+		{op: get, in: &pb3.Message{StringMap: map[string]string{"a": "b"}}, path: `string_map["a"].x`, wantOffset: 16},
+		{op: set, in: &pb3.IntMaps{}, path: "maps[0].rtt[1][2]", v: int32(1), wantOffset: 14},
+		{op: get, in: &pb3.IntMaps{Maps: []*pb3.IntMap{{}}}, path: "maps[0].rtt[1 << 40]", wantOffset: 11},
+		{op: get, in: &pb3.Message{}, path: "[proto2_test.Ext.more]", wantOffset: 0},
+		{op: get, in: &pb2.MyMessage{}, path: "[proto2_test.no_such_ext]", wantOffset: 0},
+		{op: get, in: &pb2.MyMessage{}, path: "[proto2_test..bad]", wantOffset: 0},
+		{op: get, in: &pb2.MyMessage{}, path: "somegroup", wantOffset: 0},
+		{op: get, in: &pb2.Communique{}, path: "union", wantOffset: 0},
+		{op: set, in: &pb2.Communique{}, path: "union", v: int32(1), wantOffset: 0},
+		{op: set, in: &pb3.Message{}, path: "name", v: 5, wantOffset: 0},
+		{op: set, in: &pb3.Message{}, path: "height_in_cm", v: -1, wantOffset: 0},
+		{op: set, in: &pb3.Message{}, path: "short_key[0]", v: int64(1 << 40), wantOffset: 0},
+		{op: set, in: &pb3.Message{}, path: "hilarity", v: "NOT_FUNNY", wantOffset: 0},
+		{op: set, in: &pb3.Message{}, path: "hilarity", v: pb2.MyMessage_RED, wantOffset: 0},
+		{op: set, in: &pb3.Message{}, path: "nested", v: &pb3.Message{}, wantOffset: 0},
+		{op: set, in: &pb3.Message{}, path: "key", v: []string{"a"}, wantOffset: 0},
+		{op: set, in: &pb3.Message{}, path: "submessage.key", v: uint64(1), wantOffset: 11},
+		{op: clear, in: &pb3.Message{}, path: "nested.nope", wantOffset: 7},
+		{op: clear, in: &pb3.Message{}, path: "children[3].nope", wantOffset: 12},
+		{op: clear, in: &pb3.Message{}, path: `terrain["a"].nope`, wantOffset: 13},
+	}
+
+	for _, tt := range tests {
+		var err error
+		switch tt.op {
+		case get:
+			_, err = proto.GetPath(tt.in, tt.path)
+		case set:
+			err = proto.SetPath(tt.in, tt.path, tt.v)
+		case clear:
+			err = proto.ClearPath(tt.in, tt.path)
+		}
+		pe, ok := err.(*proto.PathError)
+		if !ok {
+			t.Errorf("op %d on %q: got error %v, want *proto.PathError", tt.op, tt.path, err)
+			continue
+		}
+		if pe.Offset != tt.wantOffset {
+			t.Errorf("op %d on %q: got error %v at offset %d, want offset %d", tt.op, tt.path, err, pe.Offset, tt.wantOffset)
+		}
+	}
+}
+
+func TestSetPathErrorLeavesMessageUnchanged(t *testing.T) {
+	tests := []struct {
+		in   proto.Message
+		path string
+		v    interface{}
+	}{
+		{in: &pb3.Message{}, path: "submessage.nosuch", v: 1},
+		{in: &pb3.Message{}, path: "submessage.submessage.name", v: 5},
+		{in: &pb3.Message{}, path: "children[0].name", v: 5},
+		{in: &pb3.Message{Children: []*pb3.Message{{Name: "a"}}}, path: "children[1].nested.bunny", v: true},
+		{in: &pb3.Message{}, path: `terrain["a"].name`, v: 5},
+		{in: &pb3.IntMaps{}, path: "maps[0].rtt[1]", v: "x"},
+	}
+
+	for _, tt := range tests {
+		want := proto.Clone(tt.in)
+		if err := proto.SetPath(tt.in, tt.path, tt.v); err == nil {
+			t.Errorf("SetPath(%q, %v): got nil error, want error", tt.path, tt.v)
+			continue
+		}
+		if !proto.Equal(tt.in, want) {
+			t.Errorf("SetPath(%q, %v) failed but modified the message:\ngot  %v\nwant %v", tt.path, tt.v, tt.in, want)
+		}
+	}
+}
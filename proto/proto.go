@@ -125,8 +125,31 @@ func checkRequiredNotSet(m protoV2.Message) error {
 	return nil
 }
 
+// fastEqualer, fastCloner and fastMerger are implemented by the messages
+// generated by protoc-gen-go with plugins=fastpath, whose methods are used
+// by Equal, Clone and Merge instead of protobuf reflection.
+type (
+	fastEqualer interface{ XXX_Equal(Message) bool }
+	fastCloner  interface{ XXX_Clone() Message }
+	fastMerger  interface{ XXX_MergeFrom(Message) }
+)
+
+// fastCodec is implemented by the messages for which protoc-gen-go with
+// plugins=fastpath generates marshaling methods, used by Size, Marshal and
+// Unmarshal instead of protobuf reflection. Their encoding is always
+// deterministic.
+type fastCodec interface {
+	Marshaler
+	Unmarshaler
+	Size() int
+	MarshalToSizedBuffer([]byte) (int, error)
+}
+
 // Clone returns a deep copy of src.
 func Clone(src Message) Message {
+	if c, ok := src.(fastCloner); ok {
+		return c.XXX_Clone()
+	}
 	return MessageV1(protoV2.Clone(MessageV2(src)))
 }
 
@@ -139,6 +162,10 @@ func Clone(src Message) Message {
 // the corresponding map field in dst, possibly replacing existing entries.
 // The unknown fields of src are appended to the unknown fields of dst.
 func Merge(dst, src Message) {
+	if m, ok := dst.(fastMerger); ok {
+		m.XXX_MergeFrom(src)
+		return
+	}
 	protoV2.Merge(MessageV2(dst), MessageV2(src))
 }
 
@@ -157,8 +184,13 @@ func Merge(dst, src Message) {
 // Lists are equal if each element value is also equal.
 // Maps are equal if they have the same set of keys, where the pair of values
 // for each key is also equal.
+//
+// Use EqualOptions for a more lenient comparison.
 func Equal(x, y Message) bool {
-	return protoV2.Equal(MessageV2(x), MessageV2(y))
+	if e, ok := x.(fastEqualer); ok && y != nil {
+		return e.XXX_Equal(y)
+	}
+	return defaultEqualOptions.Equal(x, y)
 }
 
 func isMessageSet(md protoreflect.MessageDescriptor) bool {
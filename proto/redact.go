@@ -0,0 +1,152 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// RedactedPlaceholder is printed in place of the value of a redacted field.
+const RedactedPlaceholder = "[REDACTED]"
+
+// A RedactFunc reports whether the value of the field fd holds sensitive
+// data that must be replaced by RedactedPlaceholder in human-readable output.
+//
+// A RedactFunc is used by TextMarshaler, the Marshaler in the jsonpb package,
+// and CompactTextString.
+type RedactFunc func(fd protoreflect.FieldDescriptor) bool
+
+// debugRedactNumber is the field number of FieldOptions.debug_redact.
+const debugRedactNumber = 16
+
+// IsDebugRedacted reports whether fd is declared with the debug_redact
+// field option. It is the default policy used by CompactTextString.
+func IsDebugRedacted(fd protoreflect.FieldDescriptor) bool {
+	opts := fd.Options()
+	if opts == nil {
+		return false
+	}
+	m := opts.ProtoReflect()
+	if xd := m.Descriptor().Fields().ByNumber(debugRedactNumber); xd != nil && m.Has(xd) {
+		return m.Get(xd).Bool()
+	}
+	return unknownBoolOption(m, debugRedactNumber)
+}
+
+// RedactByOption returns a RedactFunc that reports whether a field is declared
+// with the custom field option xt set to true. The extension xt must be
+// a bool extension of google.protobuf.FieldOptions.
+func RedactByOption(xt *ExtensionDesc) RedactFunc {
+	xd := xt.TypeDescriptor()
+	if xd.ContainingMessage().FullName() != "google.protobuf.FieldOptions" || xd.Kind() != protoreflect.BoolKind || xd.IsList() {
+		panic(fmt.Sprintf("proto: extension %v is not a bool field option", xd.FullName()))
+	}
+	return func(fd protoreflect.FieldDescriptor) bool {
+		opts := fd.Options()
+		if opts == nil {
+			return false
+		}
+		m := opts.ProtoReflect()
+		if m.Has(xd) {
+			return m.Get(xd).Bool()
+		}
+		return unknownBoolOption(m, xd.Number())
+	}
+}
+
+// unknownBoolOption reports the value of the bool option num stored
+// in the unknown fields of the options message m. Options are left unknown
+// when they are not known to the descriptors linked into the program.
+func unknownBoolOption(m protoreflect.Message, num protoreflect.FieldNumber) bool {
+	var v bool
+	b := m.GetUnknown()
+	for len(b) > 0 {
+		n, typ, tagLen := protowire.ConsumeTag(b)
+		if tagLen < 0 {
+			return v
+		}
+		b = b[tagLen:]
+		valLen := protowire.ConsumeFieldValue(n, typ, b)
+		if valLen < 0 {
+			return v
+		}
+		if n == num && typ == protowire.VarintType {
+			x, _ := protowire.ConsumeVarint(b)
+			v = x != 0
+		}
+		b = b[valLen:]
+	}
+	return v
+}
+
+var stringRedaction atomic.Value // RedactFunc
+
+func init() { stringRedaction.Store(RedactFunc(IsDebugRedacted)) }
+
+// SetStringRedaction sets the redaction policy used by CompactTextString,
+// and thus by the String methods of generated messages.
+// The default policy is IsDebugRedacted. A nil policy disables redaction.
+func SetStringRedaction(f RedactFunc) {
+	stringRedaction.Store(f)
+}
+
+func loadStringRedaction() RedactFunc {
+	return stringRedaction.Load().(RedactFunc)
+}
+
+// anyContainsRedacted reports whether the payload of the google.protobuf.Any
+// message m may contain a field redacted by f. A payload of an unknown type
+// or that cannot be decoded is assumed to contain one.
+func anyContainsRedacted(m protoreflect.Message, f RedactFunc) bool {
+	fds := m.Descriptor().Fields()
+	b := m.Get(fds.ByName("value")).Bytes()
+	if len(b) == 0 {
+		return false
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByURL(m.Get(fds.ByName("type_url")).String())
+	if err != nil {
+		return true
+	}
+	m2 := mt.New()
+	if err := protoV2.Unmarshal(b, m2.Interface()); err != nil {
+		return true
+	}
+	return containsRedacted(m2, f)
+}
+
+// containsRedacted reports whether any populated field in m,
+// or in a message nested within m, is redacted by f.
+func containsRedacted(m protoreflect.Message, f RedactFunc) bool {
+	if m.Descriptor().FullName() == "google.protobuf.Any" {
+		return anyContainsRedacted(m, f)
+	}
+	var found bool
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case f(fd):
+			found = true
+		case fd.IsList() && fd.Message() != nil:
+			lv := v.List()
+			for i := 0; i < lv.Len() && !found; i++ {
+				found = containsRedacted(lv.Get(i).Message(), f)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				found = containsRedacted(v.Message(), f)
+				return !found
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			found = containsRedacted(v.Message(), f)
+		}
+		return !found
+	})
+	return found
+}
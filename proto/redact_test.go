@@ -0,0 +1,276 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	pb2 "github.com/golang/protobuf/internal/testprotos/proto2_proto"
+)
+
+var sensitiveOption = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+	ExtensionType: (*bool)(nil),
+	Field:         50001,
+	Name:          "redact_test.sensitive",
+	Tag:           "varint,50001,opt,name=sensitive",
+}
+
+// loginType is the type of a dynamic message equivalent to:
+//
+//	message Login {
+//	  string user = 1;
+//	  string password = 2 [debug_redact = true];
+//	  string token = 3 [(sensitive) = true];
+//	  repeated string secrets = 4 [debug_redact = true];
+//	  map<string, Login> sessions = 5;
+//	  google.protobuf.Any extra = 6;
+//	  Login nested = 7;
+//	  string token2 = 8 [(sensitive) = true];
+//	}
+//
+// The debug_redact option and the (sensitive) option of token2 are only
+// present as unknown fields of the options.
+var loginType = func() protoreflect.MessageType {
+	rawOption := func(num protowire.Number) *descriptorpb.FieldOptions {
+		opts := new(descriptorpb.FieldOptions)
+		b := protowire.AppendTag(nil, num, protowire.VarintType)
+		opts.ProtoReflect().SetUnknown(protowire.AppendVarint(b, 1))
+		return opts
+	}
+	sensitive := new(descriptorpb.FieldOptions)
+	if err := proto.SetExtension(sensitive, sensitiveOption, proto.Bool(true)); err != nil {
+		panic(err)
+	}
+	field := func(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type, opts *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(num),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+			Options:  opts,
+		}
+	}
+	message := func(name string, num int32, typeName string) *descriptorpb.FieldDescriptorProto {
+		fd := field(name, num, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, nil)
+		fd.TypeName = proto.String(typeName)
+		return fd
+	}
+	secrets := field("secrets", 4, descriptorpb.FieldDescriptorProto_TYPE_STRING, rawOption(16))
+	secrets.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	sessions := message("sessions", 5, ".redact_test.Login.SessionsEntry")
+	sessions.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("redact_test/login.proto"),
+		Package:    proto.String("redact_test"),
+		Dependency: []string{"google/protobuf/any.proto"},
+		Syntax:     proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Login"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("user", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+				field("password", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, rawOption(16)),
+				field("token", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, sensitive),
+				secrets,
+				sessions,
+				message("extra", 6, ".google.protobuf.Any"),
+				message("nested", 7, ".redact_test.Login"),
+				field("token2", 8, descriptorpb.FieldDescriptorProto_TYPE_STRING, rawOption(50001)),
+			},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("SessionsEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, nil),
+					message("value", 2, ".redact_test.Login"),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		panic(err)
+	}
+	mt := dynamicpb.NewMessageType(fd.Messages().Get(0))
+	if err := protoregistry.GlobalTypes.RegisterMessage(mt); err != nil {
+		panic(err)
+	}
+	return mt
+}()
+
+// newLogin returns a new Login message with the given field values.
+func newLogin(fields map[string]interface{}) protoreflect.Message {
+	m := loginType.New()
+	fds := m.Descriptor().Fields()
+	for name, v := range fields {
+		fd := fds.ByName(protoreflect.Name(name))
+		switch v := v.(type) {
+		case []string:
+			lv := m.Mutable(fd).List()
+			for _, s := range v {
+				lv.Append(protoreflect.ValueOfString(s))
+			}
+		case map[string]protoreflect.Message:
+			mv := m.Mutable(fd).Map()
+			for k, s := range v {
+				mv.Set(protoreflect.ValueOfString(k).MapKey(), protoreflect.ValueOfMessage(s))
+			}
+		case protoreflect.Message:
+			m.Set(fd, protoreflect.ValueOfMessage(v))
+		default:
+			m.Set(fd, protoreflect.ValueOf(v))
+		}
+	}
+	return m
+}
+
+func marshalAnyLogin(m protoreflect.Message) protoreflect.Message {
+	a, err := anypb.New(m.Interface())
+	if err != nil {
+		panic(err)
+	}
+	return a.ProtoReflect()
+}
+
+func redactBoth(fd protoreflect.FieldDescriptor) bool {
+	return proto.IsDebugRedacted(fd) || proto.RedactByOption(sensitiveOption)(fd)
+}
+
+func TestTextMarshalerRedact(t *testing.T) {
+	secret := newLogin(map[string]interface{}{"user": "eve", "password": "hunter2"})
+	public := newLogin(map[string]interface{}{"user": "bob"})
+	m := newLogin(map[string]interface{}{
+		"user":     "alice",
+		"password": "hunter2",
+		"token":    "t0k3n",
+		"secrets":  []string{"a", "b"},
+		"sessions": map[string]protoreflect.Message{"s": secret},
+		"extra":    marshalAnyLogin(secret),
+		"nested":   newLogin(map[string]interface{}{"password": "hunter2", "nested": public}),
+		"token2":   "t0k3n",
+	})
+
+	tests := []struct {
+		tm   proto.TextMarshaler
+		want string
+	}{{
+		tm: proto.TextMarshaler{Compact: true, Redact: redactBoth},
+		want: `user:"alice" password:[REDACTED] token:[REDACTED] secrets:[REDACTED] ` +
+			`sessions:<key:"s" value:<user:"eve" password:[REDACTED] > > ` +
+			`extra:<type_url:"type.googleapis.com/redact_test.Login" value:[REDACTED] > ` +
+			`nested:<password:[REDACTED] nested:<user:"bob" > > token2:[REDACTED] `,
+	}, {
+		tm: proto.TextMarshaler{Compact: true, ExpandAny: true, Redact: redactBoth},
+		want: `user:"alice" password:[REDACTED] token:[REDACTED] secrets:[REDACTED] ` +
+			`sessions:<key:"s" value:<user:"eve" password:[REDACTED] > > ` +
+			`extra:<[type.googleapis.com/redact_test.Login]:<user:"eve" password:[REDACTED] > > ` +
+			`nested:<password:[REDACTED] nested:<user:"bob" > > token2:[REDACTED] `,
+	}, {
+		tm: proto.TextMarshaler{Compact: true, Redact: proto.IsDebugRedacted},
+		want: `user:"alice" password:[REDACTED] token:"t0k3n" secrets:[REDACTED] ` +
+			`sessions:<key:"s" value:<user:"eve" password:[REDACTED] > > ` +
+			`extra:<type_url:"type.googleapis.com/redact_test.Login" value:[REDACTED] > ` +
+			`nested:<password:[REDACTED] nested:<user:"bob" > > token2:"t0k3n" `,
+	}}
+	for _, tt := range tests {
+		got := tt.tm.Text(proto.MessageV1(m.Interface()))
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%+v: Text() mismatch (-want +got):\n%s", tt.tm, diff)
+		}
+	}
+
+	// Without redaction, every value is printed.
+	tm := proto.TextMarshaler{Compact: true}
+	if got := tm.Text(proto.MessageV1(secret.Interface())); got != `user:"eve" password:"hunter2" ` {
+		t.Errorf("Text() = %q, want no redaction", got)
+	}
+}
+
+func TestTextMarshalerRedactAny(t *testing.T) {
+	public := newLogin(map[string]interface{}{"user": "bob"})
+	tests := []struct {
+		desc string
+		in   *anypb.Any
+		want string
+	}{{
+		desc: "no redacted fields",
+		in:   marshalAnyLogin(public).Interface().(*anypb.Any),
+		want: `type_url:"type.googleapis.com/redact_test.Login" value:"\n\003bob" `,
+	}, {
+		desc: "redacted field in map value",
+		in: marshalAnyLogin(newLogin(map[string]interface{}{
+			"sessions": map[string]protoreflect.Message{"s": newLogin(map[string]interface{}{"token": "x"})},
+		})).Interface().(*anypb.Any),
+		want: `type_url:"type.googleapis.com/redact_test.Login" value:[REDACTED] `,
+	}, {
+		desc: "redacted field in nested Any",
+		in: marshalAnyLogin(newLogin(map[string]interface{}{
+			"extra": marshalAnyLogin(newLogin(map[string]interface{}{"password": "x"})),
+		})).Interface().(*anypb.Any),
+		want: `type_url:"type.googleapis.com/redact_test.Login" value:[REDACTED] `,
+	}, {
+		desc: "unknown type",
+		in:   &anypb.Any{TypeUrl: "type.googleapis.com/redact_test.Unknown", Value: []byte("\n\003bob")},
+		want: `type_url:"type.googleapis.com/redact_test.Unknown" value:[REDACTED] `,
+	}}
+	for _, tt := range tests {
+		tm := proto.TextMarshaler{Compact: true, Redact: redactBoth}
+		if got := tm.Text(tt.in); got != tt.want {
+			t.Errorf("%s: Text() = %q, want %q", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestCompactTextStringRedact(t *testing.T) {
+	m := proto.MessageV1(newLogin(map[string]interface{}{
+		"user":     "alice",
+		"password": "hunter2",
+		"token":    "t0k3n",
+	}).Interface())
+
+	// By default, only fields with the debug_redact option are redacted.
+	if got, want := proto.CompactTextString(m), `user:"alice" password:[REDACTED] token:"t0k3n" `; got != want {
+		t.Errorf("CompactTextString() = %q, want %q", got, want)
+	}
+
+	defer proto.SetStringRedaction(proto.IsDebugRedacted)
+	proto.SetStringRedaction(redactBoth)
+	if got, want := proto.CompactTextString(m), `user:"alice" password:[REDACTED] token:[REDACTED] `; got != want {
+		t.Errorf("CompactTextString() = %q, want %q", got, want)
+	}
+	proto.SetStringRedaction(nil)
+	if got, want := proto.CompactTextString(m), `user:"alice" password:"hunter2" token:"t0k3n" `; got != want {
+		t.Errorf("CompactTextString() = %q, want %q", got, want)
+	}
+}
+
+func TestTextMarshalerRedactExtensionsAndGroups(t *testing.T) {
+	m := &pb2.MyMessage{
+		Count:     proto.Int32(1),
+		Somegroup: &pb2.MyMessage_SomeGroup{GroupField: proto.Int32(2)},
+	}
+	if err := proto.SetExtension(m, pb2.E_Ext_Text, proto.String("secret")); err != nil {
+		t.Fatal(err)
+	}
+	tm := proto.TextMarshaler{Redact: func(fd protoreflect.FieldDescriptor) bool {
+		return fd.FullName() == "proto2_test.Ext.text" || fd.Name() == "somegroup"
+	}}
+	want := "count: 1\nSomeGroup: [REDACTED]\n[proto2_test.Ext.text]: [REDACTED]\n"
+	if diff := cmp.Diff(want, tm.Text(m)); diff != "" {
+		t.Errorf("Text() mismatch (-want +got):\n%s", diff)
+	}
+}
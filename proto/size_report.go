@@ -0,0 +1,261 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldSize is a node in the tree of encoded sizes returned by SizeReport.
+//
+// A FieldSize can be rendered for humans with the String method,
+// or as JSON using the "encoding/json" package.
+type FieldSize struct {
+	// Path is the location of the value relative to the root message,
+	// using the field names of the text format. For example:
+	//	children[2].name
+	//	terrain["key"].bunny
+	//	nested.[pkg.extension_name]
+	//	nested.<unknown>
+	// The path of the root message is empty.
+	Path string `json:"path"`
+
+	// Size is the number of bytes in the wire-format encoding of the value,
+	// including any field tags and length prefixes.
+	Size int `json:"size"`
+
+	// Fields is the breakdown of Size into its constituent parts.
+	// For a message, these are the populated fields ordered by field number,
+	// followed by the unknown fields. For a repeated field of messages,
+	// strings, or bytes, these are the list elements. For a map field,
+	// these are the map entries ordered by key.
+	Fields []*FieldSize `json:"fields,omitempty"`
+}
+
+// SizeReport returns a breakdown of the wire-format size of m by field.
+// The Size of the returned root node is equal to Size(m).
+func SizeReport(m Message) *FieldSize {
+	if m == nil {
+		return &FieldSize{}
+	}
+	return sizeOfMessage(MessageReflect(m), "")
+}
+
+// String formats the tree of sizes with one indented line per node.
+func (s *FieldSize) String() string {
+	var b bytes.Buffer
+	s.writeText(&b, 0)
+	return b.String()
+}
+
+func (s *FieldSize) writeText(b *bytes.Buffer, indent int) {
+	for i := 0; i < indent; i++ {
+		b.WriteString("  ")
+	}
+	path := s.Path
+	if path == "" {
+		path = "(root)"
+	}
+	fmt.Fprintf(b, "%s: %d bytes\n", path, s.Size)
+	for _, f := range s.Fields {
+		f.writeText(b, indent+1)
+	}
+}
+
+func sizeOfMessage(m protoreflect.Message, path string) *FieldSize {
+	s := &FieldSize{Path: path}
+	if m == nil || !m.IsValid() {
+		return s
+	}
+
+	type field struct {
+		fd protoreflect.FieldDescriptor
+		v  protoreflect.Value
+	}
+	var fields []field
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		fields = append(fields, field{fd, v})
+		return true
+	})
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].fd.Number() < fields[j].fd.Number()
+	})
+	for _, f := range fields {
+		fs := sizeOfField(f.fd, f.v, appendPath(path, fieldPathName(f.fd)))
+		s.Fields = append(s.Fields, fs)
+		s.Size += fs.Size
+	}
+	if b := m.GetUnknown(); len(b) > 0 {
+		s.Fields = append(s.Fields, &FieldSize{Path: appendPath(path, "<unknown>"), Size: len(b)})
+		s.Size += len(b)
+	}
+	return s
+}
+
+func sizeOfField(fd protoreflect.FieldDescriptor, v protoreflect.Value, path string) *FieldSize {
+	switch {
+	case fd.IsList():
+		s := &FieldSize{Path: path}
+		lv := v.List()
+		if fd.IsPacked() && lv.Len() > 0 {
+			var n int
+			for i := 0; i < lv.Len(); i++ {
+				n += sizeOfScalar(fd.Kind(), lv.Get(i))
+			}
+			s.Size = protowire.SizeTag(fd.Number()) + protowire.SizeBytes(n)
+			return s
+		}
+		for i := 0; i < lv.Len(); i++ {
+			es := sizeOfSingular(fd, lv.Get(i), path+"["+strconv.Itoa(i)+"]")
+			s.Size += es.Size
+			switch fd.Kind() {
+			case protoreflect.MessageKind, protoreflect.GroupKind, protoreflect.StringKind, protoreflect.BytesKind:
+				s.Fields = append(s.Fields, es)
+			}
+		}
+		return s
+	case fd.IsMap():
+		s := &FieldSize{Path: path}
+		kfd, vfd := fd.MapKey(), fd.MapValue()
+		for _, e := range sortedMapEntries(kfd, v.Map()) {
+			es := &FieldSize{Path: path + "[" + formatMapKey(e.key.MapKey()) + "]"}
+			n := protowire.SizeTag(1) + sizeOfScalar(kfd.Kind(), e.key)
+			if vfd.Message() != nil {
+				// Report the fields of the value as belonging to the entry.
+				vs := sizeOfMessage(e.val.Message(), es.Path)
+				es.Fields = vs.Fields
+				n += protowire.SizeTag(2) + protowire.SizeBytes(vs.Size)
+			} else {
+				n += protowire.SizeTag(2) + sizeOfScalar(vfd.Kind(), e.val)
+			}
+			es.Size = protowire.SizeTag(fd.Number()) + protowire.SizeBytes(n)
+			s.Fields = append(s.Fields, es)
+			s.Size += es.Size
+		}
+		return s
+	default:
+		if fd.IsExtension() && isMessageSet(fd.ContainingMessage()) {
+			return sizeOfMessageSetItem(fd, v, path)
+		}
+		return sizeOfSingular(fd, v, path)
+	}
+}
+
+// sizeOfSingular returns the size of a single value of the field fd,
+// including its tag.
+func sizeOfSingular(fd protoreflect.FieldDescriptor, v protoreflect.Value, path string) *FieldSize {
+	tagSize := protowire.SizeTag(fd.Number())
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		s := sizeOfMessage(v.Message(), path)
+		s.Size = tagSize + protowire.SizeBytes(s.Size)
+		return s
+	case protoreflect.GroupKind:
+		s := sizeOfMessage(v.Message(), path)
+		s.Size = 2*tagSize + s.Size
+		return s
+	default:
+		return &FieldSize{Path: path, Size: tagSize + sizeOfScalar(fd.Kind(), v)}
+	}
+}
+
+// sizeOfMessageSetItem returns the size of an extension field in a message set,
+// which is encoded as an item group containing the type ID and message.
+func sizeOfMessageSetItem(fd protoreflect.FieldDescriptor, v protoreflect.Value, path string) *FieldSize {
+	s := sizeOfMessage(v.Message(), path)
+	s.Size = 2*protowire.SizeTag(1) +
+		protowire.SizeTag(2) + protowire.SizeVarint(uint64(fd.Number())) +
+		protowire.SizeTag(3) + protowire.SizeBytes(s.Size)
+	return s
+}
+
+// sizeOfScalar returns the size of a scalar value of kind k, excluding its tag.
+func sizeOfScalar(k protoreflect.Kind, v protoreflect.Value) int {
+	switch k {
+	case protoreflect.BoolKind:
+		return 1
+	case protoreflect.EnumKind:
+		return protowire.SizeVarint(uint64(v.Enum()))
+	case protoreflect.Int32Kind, protoreflect.Int64Kind:
+		return protowire.SizeVarint(uint64(v.Int()))
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		return protowire.SizeVarint(protowire.EncodeZigZag(v.Int()))
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind:
+		return protowire.SizeVarint(v.Uint())
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind, protoreflect.FloatKind:
+		return protowire.SizeFixed32()
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind, protoreflect.DoubleKind:
+		return protowire.SizeFixed64()
+	case protoreflect.StringKind:
+		return protowire.SizeBytes(len(v.String()))
+	case protoreflect.BytesKind:
+		return protowire.SizeBytes(len(v.Bytes()))
+	default:
+		panic(fmt.Sprintf("invalid kind %v", k))
+	}
+}
+
+// appendPath appends the field name to the path of its parent message.
+func appendPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// fieldPathName returns the name of fd as it appears in a field path,
+// which matches the field name used by the text format.
+func fieldPathName(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsExtension():
+		return "[" + string(fd.FullName()) + "]"
+	case fd.Kind() == protoreflect.GroupKind:
+		return string(fd.Message().Name())
+	default:
+		return string(fd.Name())
+	}
+}
+
+// formatMapKey formats a map key as it appears in a field path.
+func formatMapKey(k protoreflect.MapKey) string {
+	switch v := k.Interface().(type) {
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type mapEntry struct{ key, val protoreflect.Value }
+
+// sortedMapEntries returns the entries of mv ordered by key.
+func sortedMapEntries(kfd protoreflect.FieldDescriptor, mv protoreflect.Map) []mapEntry {
+	var entries []mapEntry
+	mv.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+		entries = append(entries, mapEntry{k.Value(), v})
+		return true
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		switch kfd.Kind() {
+		case protoreflect.BoolKind:
+			return !entries[i].key.Bool() && entries[j].key.Bool()
+		case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind, protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+			return entries[i].key.Int() < entries[j].key.Int()
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			return entries[i].key.Uint() < entries[j].key.Uint()
+		case protoreflect.StringKind:
+			return entries[i].key.String() < entries[j].key.String()
+		default:
+			panic("invalid kind")
+		}
+	})
+	return entries
+}
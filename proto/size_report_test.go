@@ -0,0 +1,145 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package proto_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/go-cmp/cmp"
+
+	pb2 "github.com/golang/protobuf/internal/testprotos/proto2_proto"
+	pb3 "github.com/golang/protobuf/internal/testprotos/proto3_proto"
+)
+
+func TestSizeReportTotal(t *testing.T) {
+	goTest := initGoTest(true)
+	goTest.RepeatedField = []*pb2.GoTestField{initGoTestField(), initGoTestField()}
+	goTest.Repeatedgroup = []*pb2.GoTest_RepeatedGroup{{RequiredField: proto.String("r")}}
+	goTest.F_Int32Repeated = []int32{-1, 0, 1 << 20}
+	goTest.F_Sint64RepeatedPacked = []int64{-1, 0, 1 << 40}
+	goTest.XXX_unrecognized = []byte(rawFields)
+
+	tests := []proto.Message{
+		&pb3.Message{},
+		&pb3.Message{
+			Name:     "Aaron",
+			Hilarity: pb3.Message_SLAPSTICK,
+			Key:      []uint64{1, 1 << 40},
+			ShortKey: []int32{-1},
+			RFunny:   []pb3.Message_Humour{pb3.Message_PUNS},
+			Terrain: map[string]*pb3.Nested{
+				"a": {Bunny: "b"},
+				"c": nil,
+			},
+			StringMap:        map[string]string{"k": "v", "": ""},
+			Children:         []*pb3.Message{{Name: "Sarah"}, {}},
+			Submessage:       &pb3.Message{Data: []byte("data")},
+			XXX_unrecognized: []byte(rawFields),
+		},
+		&pb3.IntMaps{Maps: []*pb3.IntMap{{Rtt: map[int32]int32{-1: 1, 2: -2}}}},
+		&pb3.MessageWithMap{ByteMapping: map[bool][]byte{true: []byte("x"), false: nil}},
+		goTest,
+		messageWithExtension2,
+		messageWithInt32Extension1,
+	}
+	for _, m := range tests {
+		got := proto.SizeReport(m)
+		if want := proto.Size(m); got.Size != want {
+			t.Errorf("SizeReport(%v).Size = %d, want %d", m, got.Size, want)
+		}
+		checkSizeReportSums(t, got)
+	}
+}
+
+// checkSizeReportSums checks that the size of every message node is the sum
+// of the sizes of its fields, plus any tag and length prefix.
+func checkSizeReportSums(t *testing.T, s *proto.FieldSize) {
+	t.Helper()
+	if len(s.Fields) == 0 {
+		return
+	}
+	var sum int
+	for _, f := range s.Fields {
+		sum += f.Size
+		checkSizeReportSums(t, f)
+	}
+	if sum > s.Size {
+		t.Errorf("size of %q is %d, but the sum of its fields is %d", s.Path, s.Size, sum)
+	}
+}
+
+func TestSizeReportPaths(t *testing.T) {
+	m := &pb3.Message{
+		Name:             "Aaron",
+		Key:              []uint64{1, 2},
+		Terrain:          map[string]*pb3.Nested{"a": {Bunny: "b"}},
+		Children:         []*pb3.Message{{Name: "Sarah"}},
+		XXX_unrecognized: []byte(rawFields),
+	}
+
+	want := &proto.FieldSize{
+		Size: 36,
+		Fields: []*proto.FieldSize{
+			{Path: "name", Size: 7},
+			{Path: "key", Size: 4},
+			{Path: "terrain", Size: 10, Fields: []*proto.FieldSize{
+				{Path: `terrain["a"]`, Size: 10, Fields: []*proto.FieldSize{
+					{Path: `terrain["a"].bunny`, Size: 3},
+				}},
+			}},
+			{Path: "children", Size: 10, Fields: []*proto.FieldSize{
+				{Path: "children[0]", Size: 10, Fields: []*proto.FieldSize{
+					{Path: "children[0].name", Size: 7},
+				}},
+			}},
+			{Path: "<unknown>", Size: 5},
+		},
+	}
+	got := proto.SizeReport(m)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SizeReport mismatch (-want +got):\n%s", diff)
+	}
+
+	wantText := `(root): 36 bytes
+  name: 7 bytes
+  key: 4 bytes
+  terrain: 10 bytes
+    terrain["a"]: 10 bytes
+      terrain["a"].bunny: 3 bytes
+  children: 10 bytes
+    children[0]: 10 bytes
+      children[0].name: 7 bytes
+  <unknown>: 5 bytes
+`
+	if diff := cmp.Diff(wantText, got.String()); diff != "" {
+		t.Errorf("String mismatch (-want +got):\n%s", diff)
+	}
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal error: %v", err)
+	}
+	var roundTrip proto.FieldSize
+	if err := json.Unmarshal(b, &roundTrip); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+	if diff := cmp.Diff(got, &roundTrip); diff != "" {
+		t.Errorf("JSON round-trip mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSizeReportExtensions(t *testing.T) {
+	got := proto.SizeReport(messageWithExtension2)
+	var paths []string
+	for _, f := range got.Fields {
+		paths = append(paths, f.Path)
+	}
+	want := []string{"count", "[proto2_test.Ext.more]"}
+	if diff := cmp.Diff(want, paths); diff != "" {
+		t.Errorf("field paths mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"unicode/utf8"
 
+	"github.com/golang/protobuf/internal/decodecost"
 	"google.golang.org/protobuf/encoding/prototext"
 	protoV2 "google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -39,8 +40,25 @@ func (e *ParseError) Error() string {
 	return fmt.Sprintf("line %d: %v", e.Line, e.Message)
 }
 
+// TextUnmarshaler is a configurable text format unmarshaler.
+type TextUnmarshaler struct {
+	// Limits bounds the resources consumed while parsing.
+	// If any limit is exceeded, a *LimitError is returned.
+	Limits DecodeLimits
+}
+
+var defaultTextUnmarshaler = TextUnmarshaler{}
+
 // UnmarshalText parses a proto text formatted string into m.
 func UnmarshalText(s string, m Message) error {
+	return defaultTextUnmarshaler.Unmarshal(s, m)
+}
+
+// Unmarshal parses a proto text formatted string into m.
+func (tu *TextUnmarshaler) Unmarshal(s string, m Message) error {
+	if err := tu.Limits.checkInputSize(len(s)); err != nil {
+		return err
+	}
 	if u, ok := m.(encoding.TextUnmarshaler); ok {
 		return u.UnmarshalText([]byte(s))
 	}
@@ -57,7 +75,9 @@ func UnmarshalText(s string, m Message) error {
 		}
 		return checkRequiredNotSet(mi)
 	} else {
-		if err := newTextParser(s).unmarshalMessage(mi.ProtoReflect(), ""); err != nil {
+		p := newTextParser(s)
+		p.budget.limits = tu.Limits
+		if err := p.unmarshalMessage(mi.ProtoReflect(), ""); err != nil {
 			return err
 		}
 		return checkRequiredNotSet(mi)
@@ -70,6 +90,9 @@ type textParser struct {
 	backed       bool   // whether back() was called
 	offset, line int
 	cur          token
+
+	budget decodeBudget // enforces TextUnmarshaler.Limits
+	depth  int          // current message nesting depth
 }
 
 type token struct {
@@ -92,6 +115,15 @@ func (p *textParser) unmarshalMessage(m protoreflect.Message, terminator string)
 	md := m.Descriptor()
 	fds := md.Fields()
 
+	p.depth++
+	defer func() { p.depth-- }()
+	if err := p.budget.checkDepth(p.depth, md.FullName()); err != nil {
+		return err
+	}
+	if err := p.budget.charge(decodecost.Message, nil); err != nil {
+		return err
+	}
+
 	// A struct is a sequence of "name: value", terminated by one of
 	// '>' or '}', or the end of the input.  A name may also be
 	// "[extension]" or "[type/url]".
@@ -277,6 +309,9 @@ func (p *textParser) unmarshalValue(v protoreflect.Value, fd protoreflect.FieldD
 					return v, err
 				}
 				lv.Append(vv)
+				if err := p.budget.checkElements(lv.Len(), fd); err != nil {
+					return v, err
+				}
 
 				tok := p.next()
 				if tok.err != nil {
@@ -300,7 +335,7 @@ func (p *textParser) unmarshalValue(v protoreflect.Value, fd protoreflect.FieldD
 			return v, err
 		}
 		lv.Append(vv)
-		return v, nil
+		return v, p.budget.checkElements(lv.Len(), fd)
 	case fd.IsMap():
 		// The map entry should be this sequence of tokens:
 		//	< key : KEY value : VALUE >
@@ -358,7 +393,10 @@ func (p *textParser) unmarshalValue(v protoreflect.Value, fd protoreflect.FieldD
 			}
 		}
 		mv.Set(kv.MapKey(), vv)
-		return v, nil
+		if err := p.budget.charge(decodecost.Message, fd); err != nil {
+			return v, err
+		}
+		return v, p.budget.checkElements(mv.Len(), fd)
 	default:
 		p.back()
 		return p.unmarshalSingularValue(v, fd)
@@ -373,6 +411,17 @@ func (p *textParser) unmarshalSingularValue(v protoreflect.Value, fd protoreflec
 	if tok.value == "" {
 		return v, p.errorf("unexpected EOF")
 	}
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		if err := p.budget.charge(decodecost.Bytes+len(tok.unquoted), fd); err != nil {
+			return v, err
+		}
+	default:
+		if err := p.budget.charge(decodecost.Scalar, fd); err != nil {
+			return v, err
+		}
+	}
 
 	switch fd.Kind() {
 	case protoreflect.BoolKind:
@@ -26,6 +26,12 @@ const wrapTextMarshalV2 = false
 type TextMarshaler struct {
 	Compact   bool // use compact text format (one line)
 	ExpandAny bool // expand google.protobuf.Any messages of known types
+
+	// Redact reports which fields have their values replaced by
+	// RedactedPlaceholder. An Any message that is not expanded has its
+	// payload redacted if it is of an unknown type or contains a redacted
+	// field. If nil, no fields are redacted.
+	Redact RedactFunc
 }
 
 // Marshal writes the proto text format of m to w.
@@ -71,6 +77,7 @@ func (tm *TextMarshaler) marshal(m Message) ([]byte, error) {
 		w := &textWriter{
 			compact:   tm.Compact,
 			expandAny: tm.ExpandAny,
+			redact:    tm.Redact,
 			complete:  true,
 		}
 
@@ -103,7 +110,11 @@ func MarshalTextString(m Message) string { return defaultTextMarshaler.Text(m) }
 func CompactText(w io.Writer, m Message) error { return compactTextMarshaler.Marshal(w, m) }
 
 // CompactTextString returns a compact proto text formatted string of m.
-func CompactTextString(m Message) string { return compactTextMarshaler.Text(m) }
+// Fields are redacted according to the policy set by SetStringRedaction.
+func CompactTextString(m Message) string {
+	tm := TextMarshaler{Compact: true, Redact: loadStringRedaction()}
+	return tm.Text(m)
+}
 
 var (
 	newline         = []byte("\n")
@@ -115,10 +126,11 @@ var (
 
 // textWriter is an io.Writer that tracks its indentation level.
 type textWriter struct {
-	compact   bool // same as TextMarshaler.Compact
-	expandAny bool // same as TextMarshaler.ExpandAny
-	complete  bool // whether the current position is a complete line
-	indent    int  // indentation level; never negative
+	compact   bool       // same as TextMarshaler.Compact
+	expandAny bool       // same as TextMarshaler.ExpandAny
+	redact    RedactFunc // same as TextMarshaler.Redact
+	complete  bool       // whether the current position is a complete line
+	indent    int        // indentation level; never negative
 	buf       []byte
 }
 
@@ -278,6 +290,14 @@ func (w *textWriter) writeMessage(m protoreflect.Message) error {
 		if fd == nil || !m.Has(fd) {
 			continue
 		}
+		if w.isRedacted(m, fd) {
+			name := string(fd.Name())
+			if fd.Kind() == protoreflect.GroupKind {
+				name = string(fd.Message().Name())
+			}
+			w.writeRedacted(name)
+			continue
+		}
 
 		switch {
 		case fd.IsList():
@@ -295,26 +315,7 @@ func (w *textWriter) writeMessage(m protoreflect.Message) error {
 			vfd := fd.MapValue()
 			mv := m.Get(fd).Map()
 
-			type entry struct{ key, val protoreflect.Value }
-			var entries []entry
-			mv.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
-				entries = append(entries, entry{k.Value(), v})
-				return true
-			})
-			sort.Slice(entries, func(i, j int) bool {
-				switch kfd.Kind() {
-				case protoreflect.BoolKind:
-					return !entries[i].key.Bool() && entries[j].key.Bool()
-				case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind, protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
-					return entries[i].key.Int() < entries[j].key.Int()
-				case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
-					return entries[i].key.Uint() < entries[j].key.Uint()
-				case protoreflect.StringKind:
-					return entries[i].key.String() < entries[j].key.String()
-				default:
-					panic("invalid kind")
-				}
-			})
+			entries := sortedMapEntries(kfd, mv)
 			for _, entry := range entries {
 				w.writeName(fd)
 				w.WriteByte('<')
@@ -351,6 +352,31 @@ func (w *textWriter) writeMessage(m protoreflect.Message) error {
 	return w.writeExtensions(m)
 }
 
+// isRedacted reports whether the value of the field fd in m is redacted.
+func (w *textWriter) isRedacted(m protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	if w.redact == nil {
+		return false
+	}
+	if w.redact(fd) {
+		return true
+	}
+	// The payload of an unexpanded Any is written as bytes,
+	// so it is redacted whole if it may hold a redacted field.
+	return m.Descriptor().FullName() == "google.protobuf.Any" && fd.Name() == "value" && anyContainsRedacted(m, w.redact)
+}
+
+// writeRedacted writes a field with the placeholder in place of its value.
+// A repeated or map field is written once, regardless of its length.
+func (w *textWriter) writeRedacted(name string) {
+	w.Write([]byte(name))
+	w.WriteByte(':')
+	if !w.compact {
+		w.WriteByte(' ')
+	}
+	w.Write([]byte(RedactedPlaceholder))
+	w.WriteByte('\n')
+}
+
 func (w *textWriter) writeSingularValue(v protoreflect.Value, fd protoreflect.FieldDescriptor) error {
 	switch fd.Kind() {
 	case protoreflect.FloatKind, protoreflect.DoubleKind:
@@ -520,6 +546,10 @@ func (w *textWriter) writeExtensions(m protoreflect.Message) error {
 		if isMessageSet(ext.desc.ContainingMessage()) {
 			name = strings.TrimSuffix(name, ".message_set_extension")
 		}
+		if w.redact != nil && w.redact(ext.desc) {
+			w.writeRedacted("[" + name + "]")
+			continue
+		}
 
 		if !ext.desc.IsList() {
 			if err := w.writeSingularExtension(name, ext.val, ext.desc); err != nil {
@@ -14,6 +14,9 @@ func Size(m Message) int {
 	if m == nil {
 		return 0
 	}
+	if c, ok := m.(fastCodec); ok {
+		return c.Size()
+	}
 	mi := MessageV2(m)
 	return protoV2.Size(mi)
 }
@@ -34,6 +37,13 @@ func marshalAppend(buf []byte, m Message, deterministic bool) ([]byte, error) {
 		return nil, ErrNil
 	}
 	mi := MessageV2(m)
+	if c, ok := m.(fastCodec); ok && mi.ProtoReflect().IsValid() {
+		nbuf := append(buf, make([]byte, c.Size())...)
+		if _, err := c.MarshalToSizedBuffer(nbuf[len(buf):]); err != nil {
+			return buf, err
+		}
+		return nbuf, checkRequiredNotSet(mi)
+	}
 	nbuf, err := protoV2.MarshalOptions{
 		Deterministic: deterministic,
 		AllowPartial:  true,
@@ -61,6 +71,12 @@ func Unmarshal(b []byte, m Message) error {
 // UnmarshalMerge parses a wire-format message in b and places the decoded results in m.
 func UnmarshalMerge(b []byte, m Message) error {
 	mi := MessageV2(m)
+	if c, ok := m.(fastCodec); ok {
+		if err := c.Unmarshal(b); err != nil {
+			return err
+		}
+		return checkRequiredNotSet(mi)
+	}
 	out, err := protoV2.UnmarshalOptions{
 		AllowPartial: true,
 		Merge:        true,
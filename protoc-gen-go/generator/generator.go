@@ -410,6 +410,7 @@ type Generator struct {
 	writeOutput      bool
 	annotateCode     bool                                       // whether to store annotations
 	annotations      []*descriptor.GeneratedCodeInfo_Annotation // annotations to store
+	sourceCodeInfo   bool                                       // whether to keep source_code_info in the file descriptor
 }
 
 type pathType int
@@ -478,6 +479,10 @@ func (g *Generator) CommandLineParameters(parameter string) {
 			if v == "true" {
 				g.annotateCode = true
 			}
+		case "source_code_info":
+			if v == "true" {
+				g.sourceCodeInfo = true
+			}
 		default:
 			if len(k) > 0 && k[0] == 'M' {
 				g.ImportMap[k[1:]] = v
@@ -2574,10 +2579,12 @@ func (g *Generator) generateInitFunction() {
 }
 
 func (g *Generator) generateFileDescriptor(file *FileDescriptor) {
-	// Make a copy and trim source_code_info data.
+	// Make a copy and trim source_code_info data, unless it was requested.
 	// TODO: Trim this more when we know exactly what we need.
 	pb := proto.Clone(file.FileDescriptorProto).(*descriptor.FileDescriptorProto)
-	pb.SourceCodeInfo = nil
+	if !g.sourceCodeInfo {
+		pb.SourceCodeInfo = nil
+	}
 
 	b, err := proto.Marshal(pb)
 	if err != nil {
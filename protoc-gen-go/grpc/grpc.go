@@ -4,6 +4,11 @@
 
 // Package grpc is deprecated.
 //
+// As protoc-gen-go does with plugins=grpc, the plugin also generates
+// recording fakes of the clients and servers in _grpc_fake.pb.go files with
+// the grpc.fakes=true parameter, and the descriptor.MethodTable of each
+// service with grpc.method_tables=true.
+//
 // This package is excluded from the Go protocol buffer compatibility guarantee
 // and may be deleted at some point in the future.
 //
@@ -15,8 +20,10 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/golang/protobuf/internal/gengogrpc"
 	pb "github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/golang/protobuf/protoc-gen-go/generator"
+	"google.golang.org/protobuf/compiler/protogen"
 )
 
 // generatedCodeVersion indicates a version of the generated code.
@@ -28,10 +35,13 @@ const generatedCodeVersion = 6
 // Paths for packages used by code generated in this file,
 // relative to the import_prefix of the generator.Generator.
 const (
-	contextPkgPath = "context"
-	grpcPkgPath    = "google.golang.org/grpc"
-	codePkgPath    = "google.golang.org/grpc/codes"
-	statusPkgPath  = "google.golang.org/grpc/status"
+	contextPkgPath       = "context"
+	grpcPkgPath          = "google.golang.org/grpc"
+	codePkgPath          = "google.golang.org/grpc/codes"
+	statusPkgPath        = "google.golang.org/grpc/status"
+	descriptorPkgPath    = "github.com/golang/protobuf/descriptor"
+	protoreflectPkgPath  = "google.golang.org/protobuf/reflect/protoreflect"
+	protoregistryPkgPath = "google.golang.org/protobuf/reflect/protoregistry"
 )
 
 func init() {
@@ -41,7 +51,9 @@ func init() {
 // grpc is an implementation of the Go protocol buffer compiler's
 // plugin architecture.  It generates bindings for gRPC support.
 type grpc struct {
-	gen *generator.Generator
+	gen          *generator.Generator
+	fakes        bool
+	methodTables bool
 }
 
 // Name returns the name of this plugin, "grpc".
@@ -59,7 +71,22 @@ var (
 
 // Init initializes the plugin.
 func (g *grpc) Init(gen *generator.Generator) {
-	g.gen = gen
+	*g = grpc{gen: gen}
+	flags := map[string]*bool{
+		"grpc.fakes":         &g.fakes,
+		"grpc.method_tables": &g.methodTables,
+	}
+	for name, f := range flags {
+		v, ok := gen.Param[name]
+		if !ok {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			gen.Fail(fmt.Sprintf("invalid value %q for parameter %s", v, name))
+		}
+		*f = b
+	}
 }
 
 // Given a type name defined in a .proto, return its object.
@@ -100,6 +127,35 @@ func (g *grpc) Generate(file *generator.FileDescriptor) {
 	for i, service := range file.FileDescriptorProto.Service {
 		g.generateService(file, service, i)
 	}
+
+	if g.fakes {
+		g.generateFakes(file)
+	}
+}
+
+// generateFakes adds the _grpc_fake.pb.go file of a file being generated to
+// the response, as generated by protoc-gen-go with plugins=grpc.
+func (g *grpc) generateFakes(file *generator.FileDescriptor) {
+	generate := false
+	for _, name := range g.gen.Request.FileToGenerate {
+		generate = generate || name == file.GetName()
+	}
+	if !generate {
+		return
+	}
+	gen, err := protogen.Options{
+		// The parameters are those of the generator.
+		ParamFunc: func(name, value string) error { return nil },
+	}.New(g.gen.Request)
+	if err != nil {
+		g.gen.Error(err, "generating fakes")
+	}
+	gengogrpc.GenerateFakeFile(gen, gen.FilesByPath[file.GetName()])
+	resp := gen.Response()
+	if resp.Error != nil {
+		g.gen.Fail("generating fakes:", resp.GetError())
+	}
+	g.gen.Response.File = append(g.gen.Response.File, resp.File...)
 }
 
 // GenerateImports generates the import declaration for this file.
@@ -259,6 +315,32 @@ func (g *grpc) generateService(file *generator.FileDescriptor, service *pb.Servi
 	g.P("Metadata: \"", file.GetName(), "\",")
 	g.P("}")
 	g.P()
+
+	if g.methodTables {
+		g.generateMethodTable(fullServName, servName, service)
+	}
+}
+
+// generateMethodTable generates the descriptor.MethodTable of a service,
+// whose descriptor is found in the registry once the table is first used.
+func (g *grpc) generateMethodTable(fullServName, servName string, service *pb.ServiceDescriptorProto) {
+	descriptorPkg := string(g.gen.AddImport(descriptorPkgPath))
+	protoreflectPkg := string(g.gen.AddImport(protoreflectPkgPath))
+	protoregistryPkg := string(g.gen.AddImport(protoregistryPkgPath))
+	example := "Method"
+	if len(service.Method) > 0 {
+		example = service.Method[0].GetName()
+	}
+	g.P("// ", servName, "Methods describes the methods of the ", servName, " service by full method")
+	g.P("// name, such as ", strconv.Quote("/"+fullServName+"/"+example), ", as given to interceptors.")
+	g.P("var ", servName, "Methods = ", descriptorPkg, ".NewMethodTable(func() ", protoreflectPkg, ".ServiceDescriptor {")
+	g.P("d, err := ", protoregistryPkg, ".GlobalFiles.FindDescriptorByName(", strconv.Quote(fullServName), ")")
+	g.P("if err != nil {")
+	g.P("panic(err)")
+	g.P("}")
+	g.P("return d.(", protoreflectPkg, ".ServiceDescriptor)")
+	g.P("})")
+	g.P()
 }
 
 // generateUnimplementedServer creates the unimplemented server struct
@@ -0,0 +1,94 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grpc
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/protoc-gen-go/generator"
+	"github.com/golang/protobuf/protoparse"
+)
+
+const testProto = `syntax = "proto3";
+
+package test;
+
+option go_package = "example.com/test";
+
+message Msg {}
+
+service Echo {
+  rpc Unary(Msg) returns (Msg);
+  rpc Bidi(stream Msg) returns (stream Msg);
+}
+`
+
+// generate returns the files generated for testProto with a parameter,
+// by name.
+func generate(t *testing.T, parameter string) map[string]string {
+	p := &protoparse.Parser{
+		Accessor: func(name string) (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(testProto)), nil
+		},
+	}
+	req, err := p.CodeGeneratorRequest(parameter, "test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := generator.New()
+	g.Request = req
+	g.CommandLineParameters(g.Request.GetParameter())
+	g.WrapTypes()
+	g.SetPackageNames()
+	g.BuildTypeNameMap()
+	g.GenerateAllFiles()
+	files := make(map[string]string)
+	for _, f := range g.Response.File {
+		files[f.GetName()] = f.GetContent()
+	}
+	return files
+}
+
+func TestFakes(t *testing.T) {
+	files := generate(t, "plugins=grpc")
+	if _, ok := files["example.com/test/test_grpc_fake.pb.go"]; ok {
+		t.Error("fakes generated without grpc.fakes=true")
+	}
+
+	files = generate(t, "plugins=grpc,grpc.fakes=true")
+	if !strings.Contains(files["example.com/test/test.pb.go"], "type EchoClient interface") {
+		t.Error("test.pb.go does not declare EchoClient")
+	}
+	fake := files["example.com/test/test_grpc_fake.pb.go"]
+	for _, want := range []string{
+		"type FakeEchoClient struct",
+		"type FakeEchoServer struct",
+		"func NewFakeEcho_BidiServer(s *FakeEchoServerStream) Echo_BidiServer",
+	} {
+		if !strings.Contains(fake, want) {
+			t.Errorf("test_grpc_fake.pb.go does not contain %q", want)
+		}
+	}
+}
+
+func TestMethodTables(t *testing.T) {
+	const table = "var EchoMethods = descriptor.NewMethodTable("
+	if strings.Contains(generate(t, "plugins=grpc")["example.com/test/test.pb.go"], table) {
+		t.Error("method table generated without grpc.method_tables=true")
+	}
+	code := generate(t, "plugins=grpc,grpc.method_tables=true")["example.com/test/test.pb.go"]
+	for _, want := range []string{
+		`"github.com/golang/protobuf/descriptor"`,
+		table,
+		`protoregistry.GlobalFiles.FindDescriptorByName("test.Echo")`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("test.pb.go does not contain %q", want)
+		}
+	}
+}
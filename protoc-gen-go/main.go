@@ -15,60 +15,53 @@
 // With that input, the output will be written to:
 //	path/to/file.pb.go
 //
+// The source_code_info=true parameter retains the comments of the .proto file
+// in the generated code, where they are available to descriptor.SourceComments.
+//
+// Plugins adding to the generated code are enabled with the plugins parameter,
+// such as plugins=grpc+http, and given the parameters prefixed with their name,
+// such as grpc.fakes=true. Programs holding additional plugins register them
+// with the protoc-gen-go/plugins package, whose Main runs protoc-gen-go.
+//
+// With plugins=grpc, the grpc.fakes=true parameter also generates recording
+// fakes of the service clients and servers for tests, written to:
+//	path/to/file_grpc_fake.pb.go
+//
+// With plugins=grpc, the grpc.direct_clients=true parameter also generates
+// a NewXxxClientFromServer function for each service, returning a client
+// calling a server implementation in-process, and the grpc.stream_helpers=true
+// parameter functions adapting the streams of the streaming methods to
+// channels, slices and callbacks, such as Xxx_MethodClientRecvChan.
+// The grpc.method_tables=true parameter generates an XxxMethods variable
+// for each service, the descriptor.MethodTable describing its methods for
+// interceptors.
+//
+// With plugins=grpc, the grpc.service_config=true parameter generates the gRPC
+// service config given by the options declared in
+// serviceconfig/serviceconfig.proto as a constant for each service, and for
+// all the services of the file in:
+//	path/to/file_service_config.json
+//
+// With plugins=http, the generated code also holds an HTTP/JSON transport of
+// the services, served and called with the httprpc package.
+//
+// With plugins=fastpath, the generated code also holds Equal, Clone and
+// MergeFrom methods of the messages, which proto.Equal, proto.Clone and
+// proto.Merge use instead of protobuf reflection. For the messages selected
+// by the options declared in fastpath/fastpath.proto, it also holds Size,
+// Marshal and Unmarshal methods, which proto.Size, proto.Marshal and
+// proto.Unmarshal use instead.
+//
+// With plugins=validate, the generated code also holds a Validate method of
+// each message, which checks the rules given to its fields by the options
+// declared in validate/validate.proto and reports their violations.
+//
 // See the README and documentation for protocol buffers to learn more:
 //	https://developers.google.com/protocol-buffers/
 package main
 
-import (
-	"flag"
-	"fmt"
-	"strings"
-
-	"github.com/golang/protobuf/internal/gengogrpc"
-	gengo "google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
-	"google.golang.org/protobuf/compiler/protogen"
-)
+import "github.com/golang/protobuf/protoc-gen-go/plugins"
 
 func main() {
-	var (
-		flags        flag.FlagSet
-		plugins      = flags.String("plugins", "", "list of plugins to enable (supported values: grpc)")
-		importPrefix = flags.String("import_prefix", "", "prefix to prepend to import paths")
-	)
-	importRewriteFunc := func(importPath protogen.GoImportPath) protogen.GoImportPath {
-		switch importPath {
-		case "context", "fmt", "math":
-			return importPath
-		}
-		if *importPrefix != "" {
-			return protogen.GoImportPath(*importPrefix) + importPath
-		}
-		return importPath
-	}
-	protogen.Options{
-		ParamFunc:         flags.Set,
-		ImportRewriteFunc: importRewriteFunc,
-	}.Run(func(gen *protogen.Plugin) error {
-		grpc := false
-		for _, plugin := range strings.Split(*plugins, ",") {
-			switch plugin {
-			case "grpc":
-				grpc = true
-			case "":
-			default:
-				return fmt.Errorf("protoc-gen-go: unknown plugin %q", plugin)
-			}
-		}
-		for _, f := range gen.Files {
-			if !f.Generate {
-				continue
-			}
-			g := gengo.GenerateFile(gen, f)
-			if grpc {
-				gengogrpc.GenerateFileContent(gen, f, g)
-			}
-		}
-		gen.SupportedFeatures = gengo.SupportedFeatures
-		return nil
-	})
+	plugins.Main()
 }
@@ -0,0 +1,123 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plugins
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/internal/gengofast"
+	"github.com/golang/protobuf/internal/gengogrpc"
+	"github.com/golang/protobuf/internal/gengohttp"
+	"github.com/golang/protobuf/internal/gengovalidate"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func init() {
+	Register(new(grpcPlugin))
+	Register(httpPlugin{})
+	Register(fastpathPlugin{})
+	Register(validatePlugin{})
+}
+
+// grpcPlugin generates the gRPC clients and servers of the services. Its
+// boolean parameters select additional code: with fakes=true, recording
+// fakes of the clients and servers in _grpc_fake.pb.go files, with
+// direct_clients=true, clients calling servers in-process, with
+// stream_helpers=true, functions adapting the streams to channels, slices
+// and callbacks, with method_tables=true, the descriptor.MethodTable of
+// each service, and with service_config=true, the gRPC service config of
+// the services, also in _service_config.json files.
+type grpcPlugin struct {
+	fakes bool
+	opts  gengogrpc.Options
+}
+
+func (*grpcPlugin) Name() string { return "grpc" }
+
+func (p *grpcPlugin) Init(gen *protogen.Plugin, params map[string]string) error {
+	*p = grpcPlugin{}
+	flags := map[string]*bool{
+		"fakes":          &p.fakes,
+		"direct_clients": &p.opts.DirectClients,
+		"stream_helpers": &p.opts.StreamHelpers,
+		"method_tables":  &p.opts.MethodTables,
+		"service_config": &p.opts.ServiceConfig,
+	}
+	for name, value := range params {
+		f, ok := flags[name]
+		if !ok {
+			return fmt.Errorf("unknown parameter %q", name)
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for parameter %s", value, name)
+		}
+		*f = b
+	}
+	return nil
+}
+
+func (p *grpcPlugin) GenerateFile(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+	p.opts.GenerateFileContent(gen, file, g)
+	if p.opts.ServiceConfig {
+		gengogrpc.GenerateServiceConfigFile(gen, file)
+	}
+	if p.fakes {
+		gengogrpc.GenerateFakeFile(gen, file)
+	}
+}
+
+// httpPlugin generates the HTTP/JSON transport of the services.
+type httpPlugin struct{}
+
+func (httpPlugin) Name() string { return "http" }
+
+func (httpPlugin) Init(gen *protogen.Plugin, params map[string]string) error {
+	for name := range params {
+		return fmt.Errorf("unknown parameter %q", name)
+	}
+	return nil
+}
+
+func (httpPlugin) GenerateFile(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+	gengohttp.GenerateFileContent(gen, file, g)
+}
+
+// fastpathPlugin generates the Equal, Clone and MergeFrom methods of the
+// messages, used by proto.Equal, proto.Clone and proto.Merge instead of
+// protobuf reflection, and the marshaling methods of the messages selected
+// by the options of fastpath/fastpath.proto.
+type fastpathPlugin struct{}
+
+func (fastpathPlugin) Name() string { return "fastpath" }
+
+func (fastpathPlugin) Init(gen *protogen.Plugin, params map[string]string) error {
+	for name := range params {
+		return fmt.Errorf("unknown parameter %q", name)
+	}
+	return nil
+}
+
+func (fastpathPlugin) GenerateFile(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+	gengofast.GenerateFileContent(gen, file, g)
+}
+
+// validatePlugin generates the Validate methods of the messages, checking
+// the rules given to their fields by the options of validate/validate.proto.
+type validatePlugin struct{}
+
+func (validatePlugin) Name() string { return "validate" }
+
+func (validatePlugin) Init(gen *protogen.Plugin, params map[string]string) error {
+	for name := range params {
+		return fmt.Errorf("unknown parameter %q", name)
+	}
+	return nil
+}
+
+func (validatePlugin) GenerateFile(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+	gengovalidate.GenerateFileContent(gen, file, g)
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package plugins runs protoc-gen-go with sub-generators, or plugins,
+// adding to the generated code of the files, such as the gRPC plugin.
+//
+// Plugins are enabled with the plugins parameter, such as plugins=grpc+http,
+// and each receives the parameters prefixed with its name and a dot: the
+// grpc.fakes=true parameter is given to the grpc plugin as fakes=true.
+//
+// A program holding additional plugins registers them and runs protoc-gen-go:
+//
+//	func main() {
+//		plugins.Register(myPlugin{})
+//		plugins.Main()
+//	}
+package plugins
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	gengo "google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const descriptorPackage = protogen.GoImportPath("github.com/golang/protobuf/descriptor")
+
+// A Plugin adds to the code generated by protoc-gen-go for the files.
+type Plugin interface {
+	// Name identifies the plugin in the plugins parameter and prefixes
+	// its parameters.
+	Name() string
+
+	// Init is called once, before any file is generated, with the parameters
+	// given to the plugin by name, such as "fakes" for grpc.fakes=true.
+	// It reports an error for the parameters it does not know.
+	Init(gen *protogen.Plugin, params map[string]string) error
+
+	// GenerateFile generates the code of the plugin for a file into g,
+	// the generated .pb.go file. It may also generate other files with gen,
+	// and reports errors with gen.Error.
+	GenerateFile(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile)
+}
+
+var registry = make(map[string]Plugin)
+
+// Register installs a plugin to be run when enabled by the plugins parameter.
+// It is typically called during initialization, and panics if a plugin of
+// the same name is already registered.
+func Register(p Plugin) {
+	name := p.Name()
+	if name == "" || strings.ContainsAny(name, "+.,=") {
+		panic(fmt.Sprintf("plugins: invalid plugin name %q", name))
+	}
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("plugins: plugin %q registered twice", name))
+	}
+	registry[name] = p
+}
+
+// Names returns the names of the registered plugins, in sorted order.
+func Names() []string {
+	var names []string
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Main runs protoc-gen-go with the registered plugins, reading the
+// CodeGeneratorRequest from stdin and writing the response to stdout.
+func Main() {
+	c := newConfig()
+	protogen.Options{
+		ParamFunc:         c.set,
+		ImportRewriteFunc: c.rewriteImport,
+	}.Run(c.generate)
+}
+
+// A config holds the parameters of protoc-gen-go.
+type config struct {
+	flags        flag.FlagSet
+	plugins      *string
+	importPrefix *string
+	sourceInfo   *bool
+
+	// params holds the parameters of the plugins, by plugin name.
+	params map[string]map[string]string
+}
+
+func newConfig() *config {
+	c := &config{params: make(map[string]map[string]string)}
+	c.plugins = c.flags.String("plugins", "", "list of plugins to enable, separated by +")
+	c.importPrefix = c.flags.String("import_prefix", "", "prefix to prepend to import paths")
+	c.sourceInfo = c.flags.Bool("source_code_info", false, "retain the source information of the files for descriptor.SourceComments")
+	return c
+}
+
+// set sets a parameter, given to a plugin if prefixed with its name.
+func (c *config) set(name, value string) error {
+	if i := strings.Index(name, "."); i >= 0 {
+		plugin := name[:i]
+		if c.params[plugin] == nil {
+			c.params[plugin] = make(map[string]string)
+		}
+		c.params[plugin][name[i+1:]] = value
+		return nil
+	}
+	return c.flags.Set(name, value)
+}
+
+func (c *config) rewriteImport(importPath protogen.GoImportPath) protogen.GoImportPath {
+	switch importPath {
+	case "context", "fmt", "math":
+		return importPath
+	}
+	if *c.importPrefix != "" {
+		return protogen.GoImportPath(*c.importPrefix) + importPath
+	}
+	return importPath
+}
+
+// enabled returns the plugins enabled by the plugins parameter, in order.
+func (c *config) enabled() ([]Plugin, error) {
+	var enabled []Plugin
+	seen := make(map[string]bool)
+	if *c.plugins != "" {
+		for _, name := range strings.Split(*c.plugins, "+") {
+			p, ok := registry[name]
+			if !ok {
+				return nil, fmt.Errorf("protoc-gen-go: unknown plugin %q (registered plugins: %s)", name, strings.Join(Names(), ", "))
+			}
+			if !seen[name] {
+				seen[name] = true
+				enabled = append(enabled, p)
+			}
+		}
+	}
+	var names []string
+	for name := range c.params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !seen[name] {
+			return nil, fmt.Errorf("protoc-gen-go: parameters given to plugin %q, which is not enabled", name)
+		}
+	}
+	return enabled, nil
+}
+
+func (c *config) generate(gen *protogen.Plugin) error {
+	enabled, err := c.enabled()
+	if err != nil {
+		return err
+	}
+	for _, p := range enabled {
+		params := c.params[p.Name()]
+		if params == nil {
+			params = make(map[string]string)
+		}
+		if err := p.Init(gen, params); err != nil {
+			return fmt.Errorf("protoc-gen-go: plugin %s: %v", p.Name(), err)
+		}
+	}
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		g := gengo.GenerateFile(gen, f)
+		for _, p := range enabled {
+			p.GenerateFile(gen, f, g)
+		}
+		if *c.sourceInfo {
+			genSourceCodeInfo(gen, f, g)
+		}
+	}
+	gen.SupportedFeatures = gengo.SupportedFeatures
+	return nil
+}
+
+// genSourceCodeInfo generates the registration of the source information
+// of the file, which is dropped from its raw descriptor.
+func genSourceCodeInfo(gen *protogen.Plugin, f *protogen.File, g *protogen.GeneratedFile) {
+	if f.Proto.SourceCodeInfo == nil {
+		return
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(f.Proto.SourceCodeInfo)
+	if err != nil {
+		gen.Error(err)
+		return
+	}
+	b = protoimpl.X.CompressGZIP(b)
+
+	varName := "file_" + strings.TrimPrefix(f.GoDescriptorIdent.GoName, "File_") + "_sourceCodeInfo"
+	g.P("func init() { ", descriptorPackage.Ident("RegisterSourceCodeInfo"), "(", strconv.Quote(f.Desc.Path()), ", ", varName, ") }")
+	g.P()
+	g.P("var ", varName, " = []byte{")
+	g.P("// ", len(b), " bytes of a gzipped SourceCodeInfo")
+	for len(b) > 0 {
+		n := 16
+		if n > len(b) {
+			n = len(b)
+		}
+
+		s := ""
+		for _, c := range b[:n] {
+			s += fmt.Sprintf("0x%02x,", c)
+		}
+		g.P(s)
+
+		b = b[n:]
+	}
+	g.P("}")
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plugins
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/protoparse"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const testProto = `syntax = "proto3";
+
+package test;
+
+option go_package = "example.com/test";
+
+message Request {}
+
+service Echo {
+  rpc Echo(Request) returns (Request);
+  rpc Watch(Request) returns (stream Request);
+}
+`
+
+// recordPlugin records its parameters and comments the files it generates.
+type recordPlugin struct {
+	params map[string]string
+}
+
+func (*recordPlugin) Name() string { return "record" }
+
+func (p *recordPlugin) Init(gen *protogen.Plugin, params map[string]string) error {
+	if params["fail"] != "" {
+		return errors.New(params["fail"])
+	}
+	p.params = params
+	return nil
+}
+
+func (p *recordPlugin) GenerateFile(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
+	var keys []string
+	for k := range p.params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, k := range keys {
+		params = append(params, k+"="+p.params[k])
+	}
+	g.P("// record: ", file.Desc.Path(), " ", strings.Join(params, ","))
+}
+
+var record = new(recordPlugin)
+
+func init() {
+	Register(record)
+}
+
+// generate runs protoc-gen-go with a parameter, returning the content of the
+// generated files by name.
+func generate(t *testing.T, param string) (map[string]string, error) {
+	p := &protoparse.Parser{
+		Accessor: func(name string) (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(testProto)), nil
+		},
+	}
+	req, err := p.CodeGeneratorRequest(param, "test/test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newConfig()
+	gen, err := protogen.Options{ParamFunc: c.set}.New(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.generate(gen); err != nil {
+		return nil, err
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		return nil, errors.New(resp.GetError())
+	}
+	files := make(map[string]string)
+	for _, f := range resp.File {
+		files[f.GetName()] = f.GetContent()
+	}
+	return files, nil
+}
+
+func TestPlugins(t *testing.T) {
+	tests := []struct {
+		param     string
+		wantFiles []string
+		wantCode  []string
+		skipCode  []string
+	}{{
+		param:     "",
+		wantFiles: []string{"example.com/test/test.pb.go"},
+		skipCode:  []string{"EchoClient", "// record:", "RegisterSourceCodeInfo"},
+	}, {
+		param:     "source_code_info=true",
+		wantFiles: []string{"example.com/test/test.pb.go"},
+		wantCode:  []string{`descriptor.RegisterSourceCodeInfo("test/test.proto", file_test_test_proto_sourceCodeInfo)`},
+	}, {
+		param:     "plugins=grpc",
+		wantFiles: []string{"example.com/test/test.pb.go"},
+		wantCode:  []string{"type EchoClient interface"},
+		skipCode:  []string{"EchoHTTPClient", "// record:", "NewEchoClientFromServer", "RecvChan", "echoContextError", "EchoMethods", "github.com/golang/protobuf/descriptor"},
+	}, {
+		param:     "plugins=grpc,grpc.direct_clients=true",
+		wantFiles: []string{"example.com/test/test.pb.go"},
+		wantCode:  []string{"func NewEchoClientFromServer("},
+	}, {
+		param:     "plugins=grpc,grpc.stream_helpers=true",
+		wantFiles: []string{"example.com/test/test.pb.go"},
+		wantCode:  []string{"func Echo_WatchClientRecvChan(", "func echoContextError("},
+		skipCode:  []string{"NewEchoClientFromServer"},
+	}, {
+		param:     "plugins=grpc,grpc.method_tables=true",
+		wantFiles: []string{"example.com/test/test.pb.go"},
+		wantCode:  []string{"var EchoMethods = descriptor.NewMethodTable("},
+	}, {
+		param:     "plugins=grpc,grpc.service_config=true",
+		wantFiles: []string{"example.com/test/test.pb.go"},
+		wantCode:  []string{"type EchoClient interface"},
+	}, {
+		param:     "plugins=grpc+http,grpc.fakes=true",
+		wantFiles: []string{"example.com/test/test.pb.go", "example.com/test/test_grpc_fake.pb.go"},
+		wantCode:  []string{"type EchoClient interface", "type EchoHTTPClient interface"},
+	}, {
+		param:     "plugins=record+http+record,record.a=1,record.b.c=2",
+		wantFiles: []string{"example.com/test/test.pb.go"},
+		wantCode:  []string{"// record: test/test.proto a=1,b.c=2", "type EchoHTTPClient interface"},
+		skipCode:  []string{"type EchoClient interface"},
+	}, {
+		param:     "plugins=record",
+		wantFiles: []string{"example.com/test/test.pb.go"},
+		wantCode:  []string{"// record: test/test.proto\n"},
+	}}
+	for _, tt := range tests {
+		files, err := generate(t, tt.param)
+		if err != nil {
+			t.Errorf("%q: %v", tt.param, err)
+			continue
+		}
+		var names []string
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if diff := cmp.Diff(tt.wantFiles, names); diff != "" {
+			t.Errorf("%q: generated files mismatch (-want +got):\n%s", tt.param, diff)
+		}
+		code := files["example.com/test/test.pb.go"]
+		for _, s := range tt.wantCode {
+			if !strings.Contains(code, s) {
+				t.Errorf("%q: generated code does not hold %q", tt.param, s)
+			}
+		}
+		for _, s := range tt.skipCode {
+			if strings.Contains(code, s) {
+				t.Errorf("%q: generated code holds %q", tt.param, s)
+			}
+		}
+	}
+}
+
+func TestPluginErrors(t *testing.T) {
+	tests := []struct {
+		param   string
+		wantErr string
+	}{{
+		param:   "plugins=grpc+micro",
+		wantErr: `protoc-gen-go: unknown plugin "micro" (registered plugins: fastpath, grpc, http, record, validate)`,
+	}, {
+		param:   "plugins=http,grpc.fakes=true",
+		wantErr: `protoc-gen-go: parameters given to plugin "grpc", which is not enabled`,
+	}, {
+		param:   "plugins=grpc,grpc_fakes=true",
+		wantErr: "no such flag -grpc_fakes",
+	}, {
+		param:   "plugins=grpc,grpc.mocks=true",
+		wantErr: `protoc-gen-go: plugin grpc: unknown parameter "mocks"`,
+	}, {
+		param:   "plugins=grpc,grpc.fakes=maybe",
+		wantErr: `protoc-gen-go: plugin grpc: invalid value "maybe" for parameter fakes`,
+	}, {
+		param:   "plugins=http,http.x=1",
+		wantErr: `protoc-gen-go: plugin http: unknown parameter "x"`,
+	}, {
+		param:   "plugins=record,record.fail=broken",
+		wantErr: `protoc-gen-go: plugin record: broken`,
+	}, {
+		param:   "unknown=1",
+		wantErr: "no such flag -unknown",
+	}}
+	for _, tt := range tests {
+		_, err := generate(t, tt.param)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%q: error = %v, want %q", tt.param, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	for _, p := range []Plugin{record, namedPlugin(""), namedPlugin("a.b"), namedPlugin("a+b")} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register of plugin %q did not panic", p.Name())
+				}
+			}()
+			Register(p)
+		}()
+	}
+}
+
+type namedPlugin string
+
+func (p namedPlugin) Name() string { return string(p) }
+
+func (namedPlugin) Init(*protogen.Plugin, map[string]string) error { return nil }
+
+func (namedPlugin) GenerateFile(*protogen.Plugin, *protogen.File, *protogen.GeneratedFile) {}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/protoc-gen-go/generator"
+	"github.com/golang/protobuf/protoparse"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// sourceInfoGoMod is the go.mod of the module of the source information
+// test, given the directory of this module.
+const sourceInfoGoMod = `module example.com/sourceinfo
+
+require (
+	github.com/golang/protobuf v1.5.0
+	google.golang.org/protobuf v1.26.0
+)
+
+replace github.com/golang/protobuf => %s
+`
+
+// generateSourceInfo returns the Go files generated for
+// testdata/sourceinfo/comments.proto with source_code_info=true,
+// by this package and by the generator package, by base name.
+func generateSourceInfo(t *testing.T) (plugins, legacy map[string]string) {
+	p := &protoparse.Parser{ImportPaths: []string{filepath.Join("testdata", "sourceinfo")}}
+	req, err := p.CodeGeneratorRequest("source_code_info=true", "comments.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newConfig()
+	gen, err := protogen.Options{ParamFunc: c.set}.New(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.generate(gen); err != nil {
+		t.Fatal(err)
+	}
+	resp := gen.Response()
+	if resp.Error != nil {
+		t.Fatal(errors.New(resp.GetError()))
+	}
+	plugins = make(map[string]string)
+	for _, f := range resp.File {
+		plugins[path.Base(f.GetName())] = f.GetContent()
+	}
+
+	g := generator.New()
+	g.Request = req
+	g.CommandLineParameters(g.Request.GetParameter())
+	g.WrapTypes()
+	g.SetPackageNames()
+	g.BuildTypeNameMap()
+	g.GenerateAllFiles()
+	legacy = make(map[string]string)
+	for _, f := range g.Response.File {
+		legacy[path.Base(f.GetName())] = f.GetContent()
+	}
+	return plugins, legacy
+}
+
+// TestSourceCodeInfo runs the tests of testdata/sourceinfo on the code
+// generated for comments.proto with source_code_info=true, by this package
+// and by the generator package, in a module of their own.
+func TestSourceCodeInfo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping end-to-end test in short mode")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	plugins, legacy := generateSourceInfo(t)
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, err := ioutil.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	test, err := ioutil.ReadFile(filepath.Join("testdata", "sourceinfo", "comments_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"go.mod": fmt.Sprintf(sourceInfoGoMod, root),
+		"go.sum": string(sum),
+	}
+	// The two versions of the generated code register the same
+	// descriptors, so they are tested in packages of their own.
+	for dir, generated := range map[string]map[string]string{"plugins": plugins, "generator": legacy} {
+		for name, content := range generated {
+			files[path.Join(dir, name)] = content
+		}
+		files[path.Join(dir, "comments_test.go")] = string(test)
+	}
+
+	dir, err := ioutil.TempDir("", "sourceinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, content := range files {
+		name = filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd := exec.Command(goTool, "test", "./plugins", "./generator")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go test: %v\n%s", err, strings.TrimSpace(string(out)))
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package comments
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/descriptor"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestSourceComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		leading  string
+		trailing string
+	}{
+		{"test.sourceinfo.Request", " A Request asks for something.\n", ""},
+		{"test.sourceinfo.Request.text", "", " The text to echo.\n"},
+		{"test.sourceinfo.Color", " The colors.\n", ""},
+		{"test.sourceinfo.COLOR_UNSPECIFIED", " No color.\n", ""},
+		{"test.sourceinfo.Echo", " Echo echoes requests.\n", ""},
+		{"test.sourceinfo.Echo.Echo", " Echo echoes a request.\n", ""},
+	}
+	for _, tt := range tests {
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(tt.name))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		c, ok := descriptor.SourceComments(d)
+		if !ok {
+			t.Errorf("SourceComments(%s) found no comments", tt.name)
+			continue
+		}
+		if c.Leading != tt.leading || c.Trailing != tt.trailing {
+			t.Errorf("SourceComments(%s) = %q, %q; want %q, %q", tt.name, c.Leading, c.Trailing, tt.leading, tt.trailing)
+		}
+	}
+}
@@ -0,0 +1,546 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoparse
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenSymbol
+)
+
+// A position is a zero-based line and column in a source file,
+// where tabs advance the column to the next multiple of 8, as in protoc.
+type position struct {
+	line, col int
+}
+
+// A token is a lexical token of a .proto source file, together with the
+// comments between it and the previous token.
+type token struct {
+	kind  tokenKind
+	text  string // text of the token as it appears in the source
+	value string // value of a string literal
+	start position
+	end   position // position following the token
+
+	// The comments preceding the token, attributed as by protoc:
+	// the trailing comment of the previous token, the detached comments,
+	// and the leading comment of this token.
+	prevTrailing string
+	detached     []string
+	leading      string
+}
+
+// lexer splits a source file into tokens.
+type lexer struct {
+	src  string
+	i    int
+	pos  position
+	errs *errorList
+	file string
+}
+
+// tokenize returns the tokens of the source file, ending with an EOF token.
+// It stops at the first lexical error, which is recorded in errs.
+func tokenize(filename, src string, errs *errorList) ([]token, bool) {
+	l := &lexer{src: src, errs: errs, file: filename}
+	if strings.HasPrefix(l.src, "\ufeff") {
+		l.i = len("\ufeff") // byte order mark
+	}
+	var toks []token
+	first := true
+	for {
+		c := &commentCollector{canAttachToPrev: !first}
+		if !l.scanComments(c, first) {
+			return nil, false
+		}
+		first = false
+		tok, ok := l.next()
+		if !ok {
+			return nil, false
+		}
+		if tok.kind == tokenEOF || tok.text == "}" || tok.text == "]" || tok.text == ")" {
+			// At the end of a scope, no comment can lead the next token.
+			c.flush()
+		}
+		c.finish()
+		tok.prevTrailing, tok.detached, tok.leading = c.trailing, c.detached, c.leading
+		toks = append(toks, tok)
+		if tok.kind == tokenEOF {
+			return toks, true
+		}
+	}
+}
+
+// commentCollector attributes the comments between two tokens.
+type commentCollector struct {
+	trailing string
+	detached []string
+	leading  string
+
+	buf             []byte
+	hasComment      bool
+	isLineComment   bool
+	canAttachToPrev bool
+}
+
+func (c *commentCollector) startLineComment() {
+	if c.hasComment && !c.isLineComment {
+		c.flush()
+	}
+	c.hasComment = true
+	c.isLineComment = true
+}
+
+func (c *commentCollector) startBlockComment() {
+	if c.hasComment {
+		c.flush()
+	}
+	c.hasComment = true
+	c.isLineComment = false
+}
+
+func (c *commentCollector) flush() {
+	if !c.hasComment {
+		return
+	}
+	if c.canAttachToPrev {
+		c.trailing = string(c.buf)
+		c.canAttachToPrev = false
+	} else {
+		c.detached = append(c.detached, string(c.buf))
+	}
+	c.clear()
+}
+
+func (c *commentCollector) clear() {
+	c.buf = nil
+	c.hasComment = false
+}
+
+func (c *commentCollector) finish() {
+	if c.hasComment {
+		c.leading = string(c.buf)
+		c.clear()
+	}
+}
+
+func (l *lexer) errorf(pos position, format string, args ...interface{}) {
+	l.errs.add(l.file, pos, format, args...)
+}
+
+func (l *lexer) peek() byte {
+	if l.i < len(l.src) {
+		return l.src[l.i]
+	}
+	return 0
+}
+
+func (l *lexer) peekAt(n int) byte {
+	if l.i+n < len(l.src) {
+		return l.src[l.i+n]
+	}
+	return 0
+}
+
+// advance consumes one byte.
+func (l *lexer) advance() {
+	switch l.src[l.i] {
+	case '\n':
+		l.pos.line++
+		l.pos.col = 0
+	case '\t':
+		l.pos.col += 8 - l.pos.col%8
+	default:
+		l.pos.col++
+	}
+	l.i++
+}
+
+func (l *lexer) tryConsume(c byte) bool {
+	if l.i < len(l.src) && l.src[l.i] == c {
+		l.advance()
+		return true
+	}
+	return false
+}
+
+func isWhitespaceNoNewline(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\v' || c == '\f'
+}
+
+func (l *lexer) skipWhitespaceNoNewline() {
+	for l.i < len(l.src) && isWhitespaceNoNewline(l.src[l.i]) {
+		l.advance()
+	}
+}
+
+// commentStart reports whether a comment starts at the current position,
+// and whether it is a line comment.
+func (l *lexer) commentStart() (isComment, isLine bool) {
+	if l.peek() != '/' {
+		return false, false
+	}
+	switch l.peekAt(1) {
+	case '/':
+		l.advance()
+		l.advance()
+		return true, true
+	case '*':
+		l.advance()
+		l.advance()
+		return true, false
+	}
+	return false, false
+}
+
+func (l *lexer) lineComment(c *commentCollector) {
+	start := l.i
+	for l.i < len(l.src) && l.src[l.i] != '\n' {
+		l.advance()
+	}
+	l.tryConsume('\n')
+	c.buf = append(c.buf, l.src[start:l.i]...)
+}
+
+func (l *lexer) blockComment(c *commentCollector) bool {
+	start := l.pos
+	start.col -= 2
+	rec := l.i
+	for {
+		for l.i < len(l.src) && l.src[l.i] != '*' && l.src[l.i] != '/' && l.src[l.i] != '\n' {
+			l.advance()
+		}
+		switch {
+		case l.tryConsume('\n'):
+			c.buf = append(c.buf, l.src[rec:l.i]...)
+			l.skipWhitespaceNoNewline()
+			if l.tryConsume('*') && l.tryConsume('/') {
+				return true
+			}
+			rec = l.i
+		case l.peek() == '*' && l.peekAt(1) == '/':
+			c.buf = append(c.buf, l.src[rec:l.i]...)
+			l.advance()
+			l.advance()
+			return true
+		case l.peek() == '*':
+			l.advance()
+		case l.peek() == '/':
+			l.advance()
+			if l.peek() == '*' {
+				l.errorf(l.pos, "\"/*\" inside block comment.  Block comments cannot be nested.")
+				return false
+			}
+		default:
+			l.errorf(l.pos, "End-of-file inside block comment.")
+			l.errorf(start, "  Comment started here.")
+			return false
+		}
+	}
+}
+
+// scanComments consumes the whitespace and comments preceding the next token,
+// attributing the comments as protoc does.
+func (l *lexer) scanComments(c *commentCollector, first bool) bool {
+	if !first {
+		// A comment on the same line as the previous token trails it.
+		l.skipWhitespaceNoNewline()
+		isComment, isLine := l.commentStart()
+		switch {
+		case isComment && isLine:
+			c.startLineComment()
+			l.lineComment(c)
+			c.flush()
+		case isComment:
+			c.startBlockComment()
+			if !l.blockComment(c) {
+				return false
+			}
+			l.skipWhitespaceNoNewline()
+			if !l.tryConsume('\n') {
+				// The next token is on the same line: the comment
+				// cannot be attributed to either token.
+				c.clear()
+				return l.skipComments()
+			}
+			c.flush()
+		default:
+			if !l.tryConsume('\n') {
+				return true // the next token is on the same line
+			}
+		}
+	}
+
+	for {
+		l.skipWhitespaceNoNewline()
+		isComment, isLine := l.commentStart()
+		switch {
+		case isComment && isLine:
+			c.startLineComment()
+			l.lineComment(c)
+		case isComment:
+			c.startBlockComment()
+			if !l.blockComment(c) {
+				return false
+			}
+			l.skipWhitespaceNoNewline()
+			l.tryConsume('\n')
+		case l.tryConsume('\n'):
+			// A blank line detaches the comments from the tokens.
+			c.flush()
+			c.canAttachToPrev = false
+		default:
+			return true
+		}
+	}
+}
+
+// skipComments consumes whitespace and comments without recording them.
+func (l *lexer) skipComments() bool {
+	var discard commentCollector
+	for {
+		for l.i < len(l.src) && (isWhitespaceNoNewline(l.src[l.i]) || l.src[l.i] == '\n') {
+			l.advance()
+		}
+		isComment, isLine := l.commentStart()
+		switch {
+		case isComment && isLine:
+			l.lineComment(&discard)
+		case isComment:
+			if !l.blockComment(&discard) {
+				return false
+			}
+		default:
+			return true
+		}
+	}
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// next scans the token at the current position.
+func (l *lexer) next() (token, bool) {
+	tok := token{start: l.pos}
+	start := l.i
+	c := l.peek()
+	switch {
+	case l.i >= len(l.src):
+		tok.kind = tokenEOF
+	case isLetter(c):
+		tok.kind = tokenIdent
+		for l.i < len(l.src) && (isLetter(l.src[l.i]) || isDigit(l.src[l.i])) {
+			l.advance()
+		}
+	case isDigit(c) || c == '.' && isDigit(l.peekAt(1)):
+		var ok bool
+		if tok.kind, ok = l.number(); !ok {
+			return tok, false
+		}
+	case c == '"' || c == '\'':
+		tok.kind = tokenString
+		v, ok := l.stringLiteral()
+		if !ok {
+			return tok, false
+		}
+		tok.value = v
+	case c < ' ' || c == 0x7f:
+		l.errorf(l.pos, "Invalid control characters encountered in text.")
+		return tok, false
+	case c >= utf8.RuneSelf:
+		l.errorf(l.pos, "Interpreting non ascii codepoint %d.", c)
+		return tok, false
+	default:
+		tok.kind = tokenSymbol
+		l.advance()
+	}
+	tok.text = l.src[start:l.i]
+	tok.end = l.pos
+	return tok, true
+}
+
+// number scans an integer or floating-point literal.
+func (l *lexer) number() (tokenKind, bool) {
+	kind := tokenInt
+	switch {
+	case l.peek() == '0' && (l.peekAt(1) == 'x' || l.peekAt(1) == 'X'):
+		l.advance()
+		l.advance()
+		if !isHexDigit(l.peek()) {
+			l.errorf(l.pos, "\"0x\" must be followed by hex digits.")
+			return kind, false
+		}
+		for isHexDigit(l.peek()) {
+			l.advance()
+		}
+	case l.peek() == '0' && isDigit(l.peekAt(1)):
+		for '0' <= l.peek() && l.peek() <= '7' {
+			l.advance()
+		}
+		if isDigit(l.peek()) {
+			l.errorf(l.pos, "Numbers starting with leading zero must be in octal.")
+			return kind, false
+		}
+	default:
+		for isDigit(l.peek()) {
+			l.advance()
+		}
+		if l.tryConsume('.') {
+			kind = tokenFloat
+			for isDigit(l.peek()) {
+				l.advance()
+			}
+		}
+		if l.peek() == 'e' || l.peek() == 'E' {
+			kind = tokenFloat
+			l.advance()
+			if l.peek() == '-' || l.peek() == '+' {
+				l.advance()
+			}
+			if !isDigit(l.peek()) {
+				l.errorf(l.pos, "\"e\" must be followed by exponent.")
+				return kind, false
+			}
+			for isDigit(l.peek()) {
+				l.advance()
+			}
+		}
+	}
+	switch {
+	case isLetter(l.peek()):
+		l.errorf(l.pos, "Need space between number and identifier.")
+		return kind, false
+	case l.peek() == '.' && kind == tokenFloat:
+		l.errorf(l.pos, "Already saw decimal point or exponent; can't have another one.")
+		return kind, false
+	case l.peek() == '.':
+		l.errorf(l.pos, "Hex and octal numbers must be integers.")
+		return kind, false
+	}
+	return kind, true
+}
+
+// stringLiteral scans a quoted string and returns its value.
+func (l *lexer) stringLiteral() (string, bool) {
+	quote := l.peek()
+	l.advance()
+	var b []byte
+	for {
+		c := l.peek()
+		switch {
+		case l.i >= len(l.src):
+			l.errorf(l.pos, "Unexpected end of string.")
+			return "", false
+		case c == '\n':
+			l.errorf(l.pos, "String literals cannot cross line boundaries.")
+			return "", false
+		case c == quote:
+			l.advance()
+			return string(b), true
+		case c == '\\':
+			l.advance()
+			var ok bool
+			if b, ok = l.escape(b); !ok {
+				return "", false
+			}
+		default:
+			b = append(b, c)
+			l.advance()
+		}
+	}
+}
+
+func (l *lexer) escape(b []byte) ([]byte, bool) {
+	c := l.peek()
+	switch c {
+	case 'a':
+		b = append(b, '\a')
+	case 'b':
+		b = append(b, '\b')
+	case 'f':
+		b = append(b, '\f')
+	case 'n':
+		b = append(b, '\n')
+	case 'r':
+		b = append(b, '\r')
+	case 't':
+		b = append(b, '\t')
+	case 'v':
+		b = append(b, '\v')
+	case '\\', '?', '\'', '"':
+		b = append(b, c)
+	case 'x', 'X':
+		l.advance()
+		n := 0
+		for n < 2 && isHexDigit(l.peek()) {
+			n++
+			l.advance()
+		}
+		if n == 0 {
+			l.errorf(l.pos, "Expected hex digits for escape sequence.")
+			return nil, false
+		}
+		v, _ := strconv.ParseUint(l.src[l.i-n:l.i], 16, 8)
+		return append(b, byte(v)), true
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		l.advance()
+		msg := "Expected four hex digits for \\u escape sequence."
+		if c == 'U' {
+			msg = "Expected eight hex digits up to 10ffff for \\U escape sequence"
+		}
+		for i := 0; i < n; i++ {
+			if !isHexDigit(l.peek()) {
+				l.errorf(l.pos, msg)
+				return nil, false
+			}
+			l.advance()
+		}
+		v, _ := strconv.ParseUint(l.src[l.i-n:l.i], 16, 32)
+		if v > utf8.MaxRune {
+			l.errorf(l.pos, msg)
+			return nil, false
+		}
+		var buf [utf8.UTFMax]byte
+		return append(b, buf[:utf8.EncodeRune(buf[:], rune(v))]...), true
+	default:
+		if '0' <= c && c <= '7' {
+			start := l.i
+			for l.i-start < 3 && '0' <= l.peek() && l.peek() <= '7' {
+				l.advance()
+			}
+			v, _ := strconv.ParseUint(l.src[start:l.i], 8, 16)
+			return append(b, byte(v)), true
+		}
+		l.errorf(l.pos, "Invalid escape sequence in string literal.")
+		return nil, false
+	}
+	l.advance()
+	return b, true
+}
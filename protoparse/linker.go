@@ -0,0 +1,922 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoparse
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+type symbolKind int
+
+const (
+	symPackage symbolKind = iota
+	symMessage
+	symEnum
+	symEnumValue
+	symField
+	symOneof
+	symService
+	symMethod
+)
+
+// A symbol is a named declaration.
+type symbol struct {
+	kind     symbolKind
+	fullName string
+	file     *fileState
+	msg      *descriptorpb.DescriptorProto     // for messages
+	enum     *descriptorpb.EnumDescriptorProto // for enums, and the enum of enum values
+}
+
+func (s *symbol) isType() bool {
+	return s.kind == symMessage || s.kind == symEnum
+}
+
+// isAggregate reports whether the symbol may contain other symbols.
+func (s *symbol) isAggregate() bool {
+	return s.kind == symMessage || s.kind == symEnum || s.kind == symService || s.kind == symPackage
+}
+
+// builder links a file, as protoc's DescriptorBuilder.
+type builder struct {
+	l    *loader
+	f    *fileState
+	fd   *descriptorpb.FileDescriptorProto
+	desc protoreflect.FileDescriptor // the file, once linked
+
+	// visible holds the files whose symbols the file may use:
+	// its imports and the files they import publicly.
+	visible map[*fileState]bool
+	added   []string // symbols added by the file
+
+	// The reasons the last symbol looked up was not found.
+	undeclared     *fileState
+	undeclaredName string
+	unresolved     string
+
+	// The fields and extensions of each message, by number.
+	numbers map[string]map[int32]string
+
+	options     []*optionsTarget
+	interpreted map[string][]int32 // paths of interpreted options
+}
+
+func join(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+func (b *builder) errorf(elem proto.Message, loc errorLocation, format string, args ...interface{}) {
+	b.l.errs.add(b.f.name, b.f.parsed.position(elem, loc), format, args...)
+}
+
+// build links and validates a file whose imports are loaded,
+// and reports whether it has no errors.
+func (l *loader) build(f *fileState) bool {
+	b := &builder{
+		l:       l,
+		f:       f,
+		fd:      f.fd,
+		visible: make(map[*fileState]bool),
+		numbers: make(map[string]map[int32]string),
+	}
+	n := l.errs.len()
+	ok := func() bool { return l.errs.len() == n }
+
+	seen := make(map[string]bool)
+	for i, dep := range f.fd.Dependency {
+		pos := noPosition
+		if f.parsed != nil {
+			pos = f.parsed.imports[dep]
+		}
+		if seen[dep] {
+			l.errs.add(f.name, pos, "Import \"%s\" was listed twice.", dep)
+		}
+		seen[dep] = true
+		if f.deps[i] == nil {
+			l.errs.add(f.name, pos, "Import \"%s\" was not found or had errors.", dep)
+		}
+	}
+	for _, dep := range f.deps {
+		b.recordPublicDependencies(dep)
+	}
+
+	b.buildFile()
+	if f.parsed == nil {
+		// The file is linked into the program, and only its symbols are needed.
+		if ok() {
+			if err := l.reg.RegisterFile(f.desc); err != nil {
+				l.errs.add(f.name, noPosition, "%v", err)
+			}
+		}
+		return b.finish(ok())
+	}
+
+	b.crossLinkFile()
+	if !ok() {
+		return b.finish(false)
+	}
+	b.interpretOptions(false)
+	if !ok() {
+		return b.finish(false)
+	}
+	b.validateFile()
+	if !ok() {
+		return b.finish(false)
+	}
+	desc, err := protodesc.NewFile(f.fd, l.reg)
+	if err != nil {
+		l.errs.add(f.name, noPosition, "%v", err)
+		return b.finish(false)
+	}
+	b.desc = desc
+	b.interpretOptions(true)
+	if !ok() {
+		return b.finish(false)
+	}
+	b.updateSourceCodeInfo()
+
+	// Link the file again, for its descriptor to hold the custom options.
+	if desc, err = protodesc.NewFile(f.fd, l.reg); err == nil {
+		err = l.reg.RegisterFile(desc)
+	}
+	if err != nil {
+		l.errs.add(f.name, noPosition, "%v", err)
+		return b.finish(false)
+	}
+	f.desc = desc
+	return b.finish(true)
+}
+
+// finish removes the symbols of a file with errors.
+func (b *builder) finish(ok bool) bool {
+	if !ok {
+		for _, name := range b.added {
+			delete(b.l.symbols, name)
+		}
+	}
+	return ok
+}
+
+func (b *builder) recordPublicDependencies(f *fileState) {
+	if f == nil || b.visible[f] {
+		return
+	}
+	b.visible[f] = true
+	for _, i := range f.fd.PublicDependency {
+		if int(i) < len(f.deps) {
+			b.recordPublicDependencies(f.deps[i])
+		}
+	}
+}
+
+// addSymbol adds a symbol, reporting an error if its name is already defined.
+func (b *builder) addSymbol(sym *symbol, elem proto.Message) bool {
+	if other, ok := b.l.symbols[sym.fullName]; ok {
+		if other.file == b.f {
+			if i := strings.LastIndexByte(sym.fullName, '.'); i >= 0 {
+				b.errorf(elem, locName, "\"%s\" is already defined in \"%s\".", sym.fullName[i+1:], sym.fullName[:i])
+			} else {
+				b.errorf(elem, locName, "\"%s\" is already defined.", sym.fullName)
+			}
+		} else {
+			b.errorf(elem, locName, "\"%s\" is already defined in file \"%s\".", sym.fullName, other.file.name)
+		}
+		return false
+	}
+	sym.file = b.f
+	b.l.symbols[sym.fullName] = sym
+	b.added = append(b.added, sym.fullName)
+	return true
+}
+
+func (b *builder) addPackage(name string) {
+	if other, ok := b.l.symbols[name]; ok {
+		if other.kind != symPackage {
+			b.errorf(b.fd, locName, "\"%s\" is already defined (as something other than a package) in file \"%s\".", name, other.file.name)
+		}
+		return
+	}
+	b.addSymbol(&symbol{kind: symPackage, fullName: name}, b.fd)
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		b.addPackage(name[:i])
+	}
+}
+
+// buildFile adds the symbols of the file, and checks the declarations
+// which do not reference other declarations.
+func (b *builder) buildFile() {
+	pkg := b.fd.GetPackage()
+	if pkg != "" {
+		b.addPackage(pkg)
+	}
+	for _, md := range b.fd.MessageType {
+		b.buildMessage(md, pkg)
+	}
+	for _, ed := range b.fd.EnumType {
+		b.buildEnum(ed, pkg)
+	}
+	for _, sd := range b.fd.Service {
+		b.buildService(sd, pkg)
+	}
+	for _, xd := range b.fd.Extension {
+		b.buildField(xd, pkg)
+	}
+}
+
+func (b *builder) buildMessage(md *descriptorpb.DescriptorProto, scope string) {
+	name := join(scope, md.GetName())
+	b.addSymbol(&symbol{kind: symMessage, fullName: name, msg: md}, md)
+	for _, od := range md.OneofDecl {
+		b.addSymbol(&symbol{kind: symOneof, fullName: join(name, od.GetName())}, od)
+	}
+	for _, fd := range md.Field {
+		b.buildField(fd, name)
+	}
+	for _, nested := range md.NestedType {
+		b.buildMessage(nested, name)
+	}
+	for _, ed := range md.EnumType {
+		b.buildEnum(ed, name)
+	}
+	for _, r := range md.ExtensionRange {
+		if r.GetStart() <= 0 {
+			b.errorf(r, locNumber, "Extension numbers must be positive integers.")
+		}
+		if r.GetStart() >= r.GetEnd() {
+			b.errorf(r, locNumber, "Extension range end number must be greater than start number.")
+		}
+	}
+	for _, xd := range md.Extension {
+		b.buildField(xd, name)
+	}
+	for _, r := range md.ReservedRange {
+		if r.GetStart() <= 0 {
+			b.errorf(r, locNumber, "Reserved numbers must be positive integers.")
+		}
+		if r.GetStart() >= r.GetEnd() {
+			b.errorf(r, locNumber, "Reserved range end number must be greater than start number.")
+		}
+	}
+
+	reserved := make(map[string]bool)
+	for _, s := range md.ReservedName {
+		if reserved[s] {
+			b.errorf(md, locName, "Field name \"%s\" is reserved multiple times.", s)
+		}
+		reserved[s] = true
+	}
+	for _, fd := range md.Field {
+		for _, r := range md.ExtensionRange {
+			if r.GetStart() <= fd.GetNumber() && fd.GetNumber() < r.GetEnd() {
+				b.errorf(r, locNumber, "Extension range %d to %d includes field \"%s\" (%d).", r.GetStart(), r.GetEnd()-1, fd.GetName(), fd.GetNumber())
+			}
+		}
+		for _, r := range md.ReservedRange {
+			if r.GetStart() <= fd.GetNumber() && fd.GetNumber() < r.GetEnd() {
+				b.errorf(fd, locNumber, "Field \"%s\" uses reserved number %d.", fd.GetName(), fd.GetNumber())
+			}
+		}
+		if reserved[fd.GetName()] {
+			b.errorf(fd, locName, "Field name \"%s\" is reserved.", fd.GetName())
+		}
+	}
+	for i, r1 := range md.ExtensionRange {
+		for _, r2 := range md.ReservedRange {
+			if r1.GetEnd() > r2.GetStart() && r2.GetEnd() > r1.GetStart() {
+				b.errorf(r1, locNumber, "Extension range %d to %d overlaps with reserved range %d to %d.", r1.GetStart(), r1.GetEnd()-1, r2.GetStart(), r2.GetEnd()-1)
+			}
+		}
+		for _, r2 := range md.ExtensionRange[i+1:] {
+			if r1.GetEnd() > r2.GetStart() && r2.GetEnd() > r1.GetStart() {
+				b.errorf(r1, locNumber, "Extension range %d to %d overlaps with already-defined range %d to %d.", r2.GetStart(), r2.GetEnd()-1, r1.GetStart(), r1.GetEnd()-1)
+			}
+		}
+	}
+	for i, r1 := range md.ReservedRange {
+		for _, r2 := range md.ReservedRange[i+1:] {
+			if r1.GetEnd() > r2.GetStart() && r2.GetEnd() > r1.GetStart() {
+				b.errorf(r1, locNumber, "Reserved range %d to %d overlaps with already-defined range %d to %d.", r2.GetStart(), r2.GetEnd()-1, r1.GetStart(), r1.GetEnd()-1)
+			}
+		}
+	}
+}
+
+func (b *builder) buildField(fd *descriptorpb.FieldDescriptorProto, scope string) {
+	b.addSymbol(&symbol{kind: symField, fullName: join(scope, fd.GetName())}, fd)
+	switch n := fd.GetNumber(); {
+	case n <= 0:
+		b.errorf(fd, locNumber, "Field numbers must be positive integers.")
+	case fd.Extendee == nil && n > maxFieldNumber:
+		b.errorf(fd, locNumber, "Field numbers cannot be greater than %d.", maxFieldNumber)
+	case 19000 <= n && n <= 19999:
+		b.errorf(fd, locNumber, "Field numbers 19000 through 19999 are reserved for the protocol buffer library implementation.")
+	}
+}
+
+func (b *builder) buildEnum(ed *descriptorpb.EnumDescriptorProto, scope string) {
+	name := join(scope, ed.GetName())
+	if len(ed.Value) == 0 {
+		b.errorf(ed, locName, "Enums must contain at least one value.")
+	}
+	b.addSymbol(&symbol{kind: symEnum, fullName: name, enum: ed}, ed)
+
+	for i, r1 := range ed.ReservedRange {
+		if r1.GetStart() > r1.GetEnd() {
+			b.errorf(r1, locNumber, "Reserved range end number must be greater than start number.")
+		}
+		for _, r2 := range ed.ReservedRange[i+1:] {
+			if r1.GetEnd() >= r2.GetStart() && r2.GetEnd() >= r1.GetStart() {
+				b.errorf(r1, locNumber, "Reserved range %d to %d overlaps with already-defined range %d to %d.", r2.GetStart(), r2.GetEnd(), r1.GetStart(), r1.GetEnd())
+			}
+		}
+	}
+
+	// Enum values are siblings of their enum, not children of it.
+	inner := make(map[string]bool)
+	for _, vd := range ed.Value {
+		added := b.addSymbol(&symbol{kind: symEnumValue, fullName: join(scope, vd.GetName()), enum: ed}, vd)
+		if !inner[vd.GetName()] && !added {
+			outer := "the global scope"
+			if scope != "" {
+				outer = "\"" + scope + "\""
+			}
+			b.errorf(vd, locName, "Note that enum values use C++ scoping rules, meaning that enum values are siblings of their type, not children of it.  Therefore, \"%s\" must be unique within %s, not just within \"%s\".", vd.GetName(), outer, ed.GetName())
+		}
+		inner[vd.GetName()] = true
+	}
+
+	reserved := make(map[string]bool)
+	for _, s := range ed.ReservedName {
+		if reserved[s] {
+			b.errorf(ed, locName, "Enum value \"%s\" is reserved multiple times.", s)
+		}
+		reserved[s] = true
+	}
+	for _, vd := range ed.Value {
+		for _, r := range ed.ReservedRange {
+			if r.GetStart() <= vd.GetNumber() && vd.GetNumber() <= r.GetEnd() {
+				b.errorf(r, locNumber, "Enum value \"%s\" uses reserved number %d.", vd.GetName(), vd.GetNumber())
+			}
+		}
+		if reserved[vd.GetName()] {
+			b.errorf(vd, locName, "Enum value \"%s\" is reserved.", vd.GetName())
+		}
+	}
+}
+
+func (b *builder) buildService(sd *descriptorpb.ServiceDescriptorProto, scope string) {
+	name := join(scope, sd.GetName())
+	b.addSymbol(&symbol{kind: symService, fullName: name}, sd)
+	for _, md := range sd.Method {
+		b.addSymbol(&symbol{kind: symMethod, fullName: join(name, md.GetName())}, md)
+	}
+}
+
+// find returns the symbol with the given full name,
+// if it is declared in the file or a file it may use.
+func (b *builder) find(name string) *symbol {
+	sym := b.l.symbols[name]
+	if sym == nil {
+		return nil
+	}
+	if sym.file == b.f || b.visible[sym.file] {
+		return sym
+	}
+	if sym.kind == symPackage {
+		// The package may also be declared by a file which is visible.
+		if inPackage(b.f, name) {
+			return sym
+		}
+		for f := range b.visible {
+			if inPackage(f, name) {
+				return sym
+			}
+		}
+	}
+	b.undeclared, b.undeclaredName = sym.file, name
+	return nil
+}
+
+func inPackage(f *fileState, name string) bool {
+	pkg := f.fd.GetPackage()
+	return pkg == name || strings.HasPrefix(pkg, name+".")
+}
+
+type lookupMode int
+
+const (
+	lookupAll lookupMode = iota
+	lookupTypes
+)
+
+// lookup resolves a name relative to the full name of a declaration,
+// searching the innermost scope first.
+func (b *builder) lookup(name, relativeTo string, mode lookupMode) *symbol {
+	b.undeclared, b.undeclaredName, b.unresolved = nil, "", ""
+	if strings.HasPrefix(name, ".") {
+		return b.find(name[1:])
+	}
+
+	// If the name is compound, such as Foo.Bar, look for the scope
+	// declaring Foo, then for Bar within it.
+	first := name
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		first = name[:i]
+	}
+	scope := relativeTo
+	for {
+		i := strings.LastIndexByte(scope, '.')
+		if i < 0 {
+			return b.find(name)
+		}
+		scope = scope[:i]
+		sym := b.find(scope + "." + first)
+		if sym == nil {
+			continue
+		}
+		if len(first) < len(name) {
+			if sym.isAggregate() {
+				full := scope + "." + name
+				sym = b.find(full)
+				if sym == nil {
+					b.unresolved = full
+				}
+				return sym
+			}
+		} else if mode != lookupTypes || sym.isType() {
+			return sym
+		}
+	}
+}
+
+// notDefined reports an error for a name which could not be resolved.
+func (b *builder) notDefined(elem proto.Message, loc errorLocation, name string) {
+	if b.undeclared == nil && b.unresolved == "" {
+		b.errorf(elem, loc, "\"%s\" is not defined.", name)
+		return
+	}
+	if b.undeclared != nil {
+		b.errorf(elem, loc, "\"%s\" seems to be defined in \"%s\", which is not imported by \"%s\".  To use it here, please add the necessary import.", b.undeclaredName, b.undeclared.name, b.f.name)
+	}
+	if b.unresolved != "" {
+		b.errorf(elem, loc, "\"%s\" is resolved to \"%s\", which is not defined. The innermost scope is searched first in name resolution. Consider using a leading '.'(i.e., \".%s\") to start from the outermost scope.", name, b.unresolved, name)
+	}
+}
+
+// crossLinkFile resolves the names of the types referenced by the file.
+func (b *builder) crossLinkFile() {
+	pkg := b.fd.GetPackage()
+	for _, md := range b.fd.MessageType {
+		b.crossLinkMessage(md, join(pkg, md.GetName()))
+	}
+	for _, xd := range b.fd.Extension {
+		b.crossLinkField(xd, pkg, "")
+	}
+	for _, sd := range b.fd.Service {
+		name := join(pkg, sd.GetName())
+		for _, md := range sd.Method {
+			b.crossLinkMethod(md, join(name, md.GetName()))
+		}
+	}
+}
+
+func (b *builder) crossLinkMessage(md *descriptorpb.DescriptorProto, name string) {
+	for _, nested := range md.NestedType {
+		b.crossLinkMessage(nested, join(name, nested.GetName()))
+	}
+	for _, fd := range md.Field {
+		b.crossLinkField(fd, name, name)
+	}
+	for _, xd := range md.Extension {
+		b.crossLinkField(xd, name, "")
+	}
+}
+
+// crossLinkField resolves the type and extendee of a field declared in
+// the given scope. The message is that of the field, and empty for extensions.
+func (b *builder) crossLinkField(fd *descriptorpb.FieldDescriptorProto, scope, message string) {
+	name := join(scope, fd.GetName())
+	if fd.JsonName != nil && fd.Extendee != nil {
+		b.errorf(fd, locOptionName, "option json_name is not allowed on extension fields.")
+	}
+	if fd.JsonName == nil {
+		fd.JsonName = proto.String(jsonName(fd.GetName()))
+	}
+
+	if fd.Extendee != nil {
+		sym := b.lookup(fd.GetExtendee(), name, lookupAll)
+		if sym == nil {
+			b.notDefined(fd, locExtendee, fd.GetExtendee())
+			return
+		}
+		if sym.kind != symMessage {
+			b.errorf(fd, locExtendee, "\"%s\" is not a message type.", fd.GetExtendee())
+			return
+		}
+		fd.Extendee = proto.String("." + sym.fullName)
+		message = sym.fullName
+		declared := false
+		for _, r := range sym.msg.ExtensionRange {
+			if r.GetStart() <= fd.GetNumber() && fd.GetNumber() < r.GetEnd() {
+				declared = true
+			}
+		}
+		if !declared {
+			b.errorf(fd, locNumber, "\"%s\" does not declare %d as an extension number.", sym.fullName, fd.GetNumber())
+		}
+	}
+
+	if fd.TypeName != nil {
+		sym := b.lookup(fd.GetTypeName(), name, lookupTypes)
+		if sym == nil {
+			b.notDefined(fd, locType, fd.GetTypeName())
+			return
+		}
+		if fd.Type == nil {
+			switch sym.kind {
+			case symMessage:
+				fd.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+			case symEnum:
+				fd.Type = descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum()
+			default:
+				b.errorf(fd, locType, "\"%s\" is not a type.", fd.GetTypeName())
+				return
+			}
+		}
+		switch fd.GetType() {
+		case descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+			if sym.kind != symMessage {
+				b.errorf(fd, locType, "\"%s\" is not a message type.", fd.GetTypeName())
+				return
+			}
+			if fd.DefaultValue != nil {
+				b.errorf(fd, locDefaultValue, "Messages can't have default values.")
+			}
+		case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+			if sym.kind != symEnum {
+				b.errorf(fd, locType, "\"%s\" is not an enum type.", fd.GetTypeName())
+				return
+			}
+			if fd.DefaultValue != nil {
+				if !isIdentifier(fd.GetDefaultValue()) {
+					b.errorf(fd, locDefaultValue, "Default value for an enum field must be an identifier.")
+				} else if v := b.lookup(fd.GetDefaultValue(), sym.fullName, lookupAll); v == nil || v.kind != symEnumValue || v.enum != sym.enum {
+					b.errorf(fd, locDefaultValue, "Enum type \"%s\" has no value named \"%s\".", sym.fullName, fd.GetDefaultValue())
+				}
+			}
+		default:
+			b.errorf(fd, locType, "Field with primitive type has type_name.")
+		}
+		fd.TypeName = proto.String("." + sym.fullName)
+	} else if fd.DefaultValue != nil {
+		fd.DefaultValue = proto.String(normalizeDefault(fd.GetType(), fd.GetDefaultValue()))
+	}
+
+	numbers := b.numbers[message]
+	if numbers == nil {
+		numbers = make(map[int32]string)
+		b.numbers[message] = numbers
+	}
+	if other, ok := numbers[fd.GetNumber()]; ok {
+		if fd.Extendee != nil {
+			b.errorf(fd, locNumber, "Extension number %d has already been used in \"%s\" by extension \"%s\".", fd.GetNumber(), message, other)
+		} else {
+			b.errorf(fd, locNumber, "Field number %d has already been used in \"%s\" by field \"%s\".", fd.GetNumber(), message, other)
+		}
+	} else if fd.Extendee != nil {
+		numbers[fd.GetNumber()] = name
+	} else {
+		numbers[fd.GetNumber()] = fd.GetName()
+	}
+}
+
+func (b *builder) crossLinkMethod(md *descriptorpb.MethodDescriptorProto, name string) {
+	for _, typ := range []struct {
+		name *string
+		loc  errorLocation
+	}{
+		{md.InputType, locInputType},
+		{md.OutputType, locOutputType},
+	} {
+		sym := b.lookup(*typ.name, name, lookupAll)
+		switch {
+		case sym == nil:
+			b.notDefined(md, typ.loc, *typ.name)
+		case sym.kind != symMessage:
+			b.errorf(md, typ.loc, "\"%s\" is not a message type.", *typ.name)
+		default:
+			*typ.name = "." + sym.fullName
+		}
+	}
+}
+
+// jsonName returns the JSON name of a field, as protoc's ToJsonName.
+func jsonName(name string) string {
+	var b []byte
+	upper := false
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_':
+			upper = true
+		case upper:
+			if 'a' <= c && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			upper = false
+		default:
+			b = append(b, c)
+		}
+	}
+	return string(b)
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || i > 0 && '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeDefault formats the default value of a scalar field
+// as protoc does in the descriptors it passes to plugins.
+func normalizeDefault(typ descriptorpb.FieldDescriptorProto_Type, s string) string {
+	switch typ {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return strconv.FormatInt(v, 10)
+		}
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return strconv.FormatUint(v, 10)
+		}
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT:
+		return formatFloat(float32(parseDefaultFloat(s)))
+	case descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		return formatDouble(parseDefaultFloat(s))
+	}
+	return s
+}
+
+func parseDefaultFloat(s string) float64 {
+	if strings.HasSuffix(s, "nan") {
+		return math.NaN()
+	}
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// validateFile checks the file once its options are interpreted.
+func (b *builder) validateFile() {
+	pkg := b.fd.GetPackage()
+	for _, md := range b.fd.MessageType {
+		b.validateMessage(md, join(pkg, md.GetName()))
+	}
+	for _, ed := range b.fd.EnumType {
+		b.validateEnum(ed, pkg)
+	}
+	for _, xd := range b.fd.Extension {
+		b.validateField(xd, pkg, nil)
+	}
+
+	// Lite files can only be imported by other lite files.
+	if !isLite(b.fd) {
+		for i, dep := range b.f.deps {
+			if dep != nil && isLite(dep.fd) {
+				b.l.errs.add(b.f.name, b.f.parsed.imports[b.fd.Dependency[i]], "Files that do not use optimize_for = LITE_RUNTIME cannot import files which do use this option.  This file is not lite, but it imports \"%s\" which is.", dep.name)
+				break
+			}
+		}
+	}
+
+	if b.fd.GetSyntax() == "proto3" {
+		for _, xd := range b.fd.Extension {
+			b.validateProto3Field(xd, "")
+		}
+		for _, md := range b.fd.MessageType {
+			b.validateProto3Message(md, join(pkg, md.GetName()))
+		}
+		for _, ed := range b.fd.EnumType {
+			b.validateProto3Enum(ed)
+		}
+	}
+}
+
+func isLite(fd *descriptorpb.FileDescriptorProto) bool {
+	return fd.GetOptions().GetOptimizeFor() == descriptorpb.FileOptions_LITE_RUNTIME
+}
+
+func (b *builder) validateMessage(md *descriptorpb.DescriptorProto, name string) {
+	for _, fd := range md.Field {
+		b.validateField(fd, name, md)
+	}
+	for _, nested := range md.NestedType {
+		b.validateMessage(nested, join(name, nested.GetName()))
+	}
+	for _, ed := range md.EnumType {
+		b.validateEnum(ed, name)
+	}
+	for _, xd := range md.Extension {
+		b.validateField(xd, name, nil)
+	}
+	max := int32(maxFieldNumber)
+	if md.GetOptions().GetMessageSetWireFormat() {
+		max = math.MaxInt32
+	}
+	for _, r := range md.ExtensionRange {
+		if int64(r.GetEnd()) > int64(max)+1 {
+			b.errorf(r, locNumber, "Extension numbers cannot be greater than %d.", max)
+		}
+	}
+}
+
+// validateField checks a field of the given message, which is nil for
+// extensions.
+func (b *builder) validateField(fd *descriptorpb.FieldDescriptorProto, scope string, md *descriptorpb.DescriptorProto) {
+	opts := fd.GetOptions()
+	if opts.GetLazy() && fd.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		b.errorf(fd, locType, "[lazy = true] can only be specified for submessage fields.")
+	}
+	if opts.GetPacked() && !isPackable(fd) {
+		b.errorf(fd, locType, "[packed = true] can only be specified for repeated primitive fields.")
+	}
+
+	container := md
+	if fd.Extendee != nil {
+		container = b.l.symbols[fd.GetExtendee()[1:]].msg
+	}
+	if container.GetOptions().GetMessageSetWireFormat() {
+		if fd.Extendee == nil {
+			b.errorf(fd, locName, "MessageSets cannot have fields, only extensions.")
+		} else if fd.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL || fd.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+			b.errorf(fd, locType, "Extensions of MessageSets must be optional messages.")
+		}
+	}
+
+	if entry := b.mapEntry(fd); entry != nil {
+		switch entry.Field[0].GetType() {
+		case descriptorpb.FieldDescriptorProto_TYPE_ENUM:
+			b.errorf(fd, locType, "Key in map fields cannot be enum types.")
+		case descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+			descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+			descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+			descriptorpb.FieldDescriptorProto_TYPE_GROUP,
+			descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+			b.errorf(fd, locType, "Key in map fields cannot be float/double, bytes or message types.")
+		}
+		if value := entry.Field[1]; value.GetType() == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+			if ed := b.l.symbols[value.GetTypeName()[1:]].enum; len(ed.Value) > 0 && ed.Value[0].GetNumber() != 0 {
+				b.errorf(fd, locType, "Enum value in map must define 0 as the first value.")
+			}
+		}
+	}
+}
+
+// mapEntry returns the entry message of a map field,
+// or nil if the field is not a map.
+func (b *builder) mapEntry(fd *descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	if fd.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE || fd.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		return nil
+	}
+	msg := b.l.symbols[fd.GetTypeName()[1:]].msg
+	if !msg.GetOptions().GetMapEntry() || len(msg.Field) != 2 {
+		return nil
+	}
+	return msg
+}
+
+func isPackable(fd *descriptorpb.FieldDescriptorProto) bool {
+	if fd.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+		return false
+	}
+	switch fd.GetType() {
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING,
+		descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+		descriptorpb.FieldDescriptorProto_TYPE_MESSAGE,
+		descriptorpb.FieldDescriptorProto_TYPE_GROUP:
+		return false
+	}
+	return true
+}
+
+func (b *builder) validateEnum(ed *descriptorpb.EnumDescriptorProto, scope string) {
+	if ed.GetOptions().GetAllowAlias() {
+		return
+	}
+	used := make(map[int32]string)
+	for _, vd := range ed.Value {
+		name := join(scope, vd.GetName())
+		if other, ok := used[vd.GetNumber()]; ok {
+			b.errorf(vd, locNumber, "\"%s\" uses the same enum value as \"%s\". If this is intended, set 'option allow_alias = true;' to the enum definition.", name, other)
+		} else {
+			used[vd.GetNumber()] = name
+		}
+	}
+}
+
+// proto3Extendees are the messages proto3 files may extend.
+var proto3Extendees = map[string]bool{
+	"google.protobuf.FileOptions":           true,
+	"google.protobuf.MessageOptions":        true,
+	"google.protobuf.FieldOptions":          true,
+	"google.protobuf.EnumOptions":           true,
+	"google.protobuf.EnumValueOptions":      true,
+	"google.protobuf.ServiceOptions":        true,
+	"google.protobuf.MethodOptions":         true,
+	"google.protobuf.OneofOptions":          true,
+	"google.protobuf.ExtensionRangeOptions": true,
+	// Extending MessageSet is also allowed, for backward compatibility.
+	"google.protobuf.bridge.MessageSet": true,
+}
+
+func (b *builder) validateProto3Message(md *descriptorpb.DescriptorProto, name string) {
+	for _, nested := range md.NestedType {
+		b.validateProto3Message(nested, join(name, nested.GetName()))
+	}
+	for _, ed := range md.EnumType {
+		b.validateProto3Enum(ed)
+	}
+	for _, fd := range md.Field {
+		b.validateProto3Field(fd, name)
+	}
+	for _, xd := range md.Extension {
+		b.validateProto3Field(xd, "")
+	}
+	if len(md.ExtensionRange) > 0 {
+		b.errorf(md.ExtensionRange[0], locNumber, "Extension ranges are not allowed in proto3.")
+	}
+	if md.GetOptions().GetMessageSetWireFormat() {
+		b.errorf(md, locName, "MessageSet is not supported in proto3.")
+	}
+
+	// Field names must be unique once lower-cased without underscores,
+	// for their JSON names not to conflict.
+	names := make(map[string]string)
+	for _, fd := range md.Field {
+		lower := strings.ToLower(strings.Replace(fd.GetName(), "_", "", -1))
+		if other, ok := names[lower]; ok {
+			b.errorf(fd, locName, "The JSON camel-case name of field \"%s\" conflicts with field \"%s\". This is not allowed in proto3.", fd.GetName(), other)
+		} else {
+			names[lower] = fd.GetName()
+		}
+	}
+}
+
+// validateProto3Field checks a field of the given message,
+// which is empty for extensions.
+func (b *builder) validateProto3Field(fd *descriptorpb.FieldDescriptorProto, message string) {
+	if fd.Extendee != nil {
+		message = fd.GetExtendee()[1:]
+		if !proto3Extendees[message] {
+			b.errorf(fd, locExtendee, "Extensions in proto3 are only allowed for defining options.")
+		}
+	}
+	if fd.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REQUIRED {
+		b.errorf(fd, locType, "Required fields are not allowed in proto3.")
+	}
+	if fd.DefaultValue != nil {
+		b.errorf(fd, locDefaultValue, "Explicit default values are not allowed in proto3.")
+	}
+	if fd.GetType() == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+		if sym := b.l.symbols[fd.GetTypeName()[1:]]; sym.file.fd.GetSyntax() != "proto3" {
+			b.errorf(fd, locType, "Enum type \"%s\" is not a proto3 enum, but is used in \"%s\" which is a proto3 message type.", sym.fullName, message)
+		}
+	}
+	if fd.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+		b.errorf(fd, locType, "Groups are not supported in proto3 syntax.")
+	}
+}
+
+func (b *builder) validateProto3Enum(ed *descriptorpb.EnumDescriptorProto) {
+	if len(ed.Value) > 0 && ed.Value[0].GetNumber() != 0 {
+		b.errorf(ed.Value[0], locNumber, "The first enum value must be zero in proto3.")
+	}
+}
@@ -0,0 +1,582 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoparse
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// An optionsTarget is the options message of a declaration,
+// holding the options to interpret.
+type optionsTarget struct {
+	opts  protoV2.Message
+	path  []int32 // the path of the options in the file
+	scope string  // the name option names are resolved relative to
+
+	pending []*descriptorpb.UninterpretedOption // options not yet interpreted
+	index   map[*descriptorpb.UninterpretedOption]int
+	set     map[string]bool // the options set, keyed by their field numbers
+	counts  map[string]int  // the number of values of repeated options
+	failed  bool
+}
+
+func (b *builder) addOptions(opts protoV2.Message, path []int32, scope string) {
+	t := &optionsTarget{
+		opts:    opts,
+		path:    append([]int32(nil), path...),
+		scope:   scope,
+		pending: uninterpretedOptions(opts),
+		index:   make(map[*descriptorpb.UninterpretedOption]int),
+		set:     make(map[string]bool),
+		counts:  make(map[string]int),
+	}
+	for i, opt := range t.pending {
+		t.index[opt] = i
+	}
+	b.options = append(b.options, t)
+}
+
+func uninterpretedField(opts protoV2.Message) protoreflect.FieldDescriptor {
+	return opts.ProtoReflect().Descriptor().Fields().ByName("uninterpreted_option")
+}
+
+func uninterpretedOptions(opts protoV2.Message) []*descriptorpb.UninterpretedOption {
+	m := opts.ProtoReflect()
+	list := m.Get(uninterpretedField(opts)).List()
+	var s []*descriptorpb.UninterpretedOption
+	for i := 0; i < list.Len(); i++ {
+		s = append(s, list.Get(i).Message().Interface().(*descriptorpb.UninterpretedOption))
+	}
+	return s
+}
+
+// collectOptions records the options messages of the file.
+func (b *builder) collectOptions() {
+	fd := b.fd
+	pkg := fd.GetPackage()
+	if fd.Options != nil {
+		b.addOptions(fd.Options, []int32{fileOptionsTag}, join(pkg, "dummy"))
+	}
+	for i, md := range fd.MessageType {
+		b.collectMessageOptions(md, join(pkg, md.GetName()), []int32{fileMessageTag, int32(i)})
+	}
+	for i, ed := range fd.EnumType {
+		b.collectEnumOptions(ed, pkg, []int32{fileEnumTag, int32(i)})
+	}
+	for i, xd := range fd.Extension {
+		if xd.Options != nil {
+			b.addOptions(xd.Options, []int32{fileExtensionTag, int32(i), fieldOptionsTag}, join(pkg, xd.GetName()))
+		}
+	}
+	for i, sd := range fd.Service {
+		name := join(pkg, sd.GetName())
+		path := []int32{fileServiceTag, int32(i)}
+		if sd.Options != nil {
+			b.addOptions(sd.Options, append(path, serviceOptionsTag), name)
+		}
+		for j, md := range sd.Method {
+			if md.Options != nil {
+				b.addOptions(md.Options, append(path, serviceMethodTag, int32(j), methodOptionsTag), join(name, md.GetName()))
+			}
+		}
+	}
+}
+
+func (b *builder) collectMessageOptions(md *descriptorpb.DescriptorProto, name string, path []int32) {
+	path = path[:len(path):len(path)]
+	if md.Options != nil {
+		b.addOptions(md.Options, append(path, messageOptionsTag), name)
+	}
+	for i, fd := range md.Field {
+		if fd.Options != nil {
+			b.addOptions(fd.Options, append(path, messageFieldTag, int32(i), fieldOptionsTag), join(name, fd.GetName()))
+		}
+	}
+	for i, nested := range md.NestedType {
+		b.collectMessageOptions(nested, join(name, nested.GetName()), append(path, messageNestedTag, int32(i)))
+	}
+	for i, ed := range md.EnumType {
+		b.collectEnumOptions(ed, name, append(path, messageEnumTag, int32(i)))
+	}
+	for i, r := range md.ExtensionRange {
+		if r.Options != nil {
+			b.addOptions(r.Options, append(path, messageExtensionRangeTag, int32(i), extensionRangeOptionsTag), name)
+		}
+	}
+	for i, xd := range md.Extension {
+		if xd.Options != nil {
+			b.addOptions(xd.Options, append(path, messageExtensionTag, int32(i), fieldOptionsTag), join(name, xd.GetName()))
+		}
+	}
+	for i, od := range md.OneofDecl {
+		if od.Options != nil {
+			b.addOptions(od.Options, append(path, messageOneofTag, int32(i), oneofOptionsTag), join(name, od.GetName()))
+		}
+	}
+}
+
+func (b *builder) collectEnumOptions(ed *descriptorpb.EnumDescriptorProto, scope string, path []int32) {
+	path = path[:len(path):len(path)]
+	if ed.Options != nil {
+		b.addOptions(ed.Options, append(path, enumOptionsTag), join(scope, ed.GetName()))
+	}
+	for i, vd := range ed.Value {
+		if vd.Options != nil {
+			// Enum values are siblings of their enum.
+			b.addOptions(vd.Options, append(path, enumValueTag, int32(i), enumValueOptionsTag), join(scope, vd.GetName()))
+		}
+	}
+}
+
+// interpretOptions interprets the options of the file, as protoc's
+// OptionInterpreter. The options of descriptor.proto are interpreted
+// before the file is validated, and custom options once it is linked.
+func (b *builder) interpretOptions(custom bool) {
+	if !custom {
+		b.interpreted = make(map[string][]int32)
+		b.collectOptions()
+	}
+	for _, t := range b.options {
+		var pending []*descriptorpb.UninterpretedOption
+		for _, opt := range t.pending {
+			if t.failed || isCustomOption(opt) != custom {
+				pending = append(pending, opt)
+				continue
+			}
+			if !b.interpretOption(t, opt) {
+				t.failed = true
+			}
+		}
+		t.pending = pending
+		if custom {
+			t.opts.ProtoReflect().Clear(uninterpretedField(t.opts))
+		}
+	}
+}
+
+func isCustomOption(opt *descriptorpb.UninterpretedOption) bool {
+	for _, part := range opt.Name {
+		if part.GetIsExtension() {
+			return true
+		}
+	}
+	return false
+}
+
+func pathKey(path []int32) string {
+	return fmt.Sprint(path)
+}
+
+// interpretOption sets an option, and reports whether it is valid.
+func (b *builder) interpretOption(t *optionsTarget, opt *descriptorpb.UninterpretedOption) bool {
+	if len(opt.Name) == 0 {
+		return false
+	}
+	if !opt.Name[0].GetIsExtension() && opt.Name[0].GetNamePart() == "uninterpreted_option" {
+		b.errorf(opt, locOptionName, "Option must not use reserved name \"uninterpreted_option\".")
+		return false
+	}
+
+	var fields []protoreflect.FieldDescriptor
+	var dbg string
+	md := t.opts.ProtoReflect().Descriptor()
+	for i, part := range opt.Name {
+		if i > 0 {
+			dbg += "."
+		}
+		name := part.GetNamePart()
+		var fd protoreflect.FieldDescriptor
+		if part.GetIsExtension() {
+			dbg += "(" + name + ")"
+			if sym := b.lookup(name, t.scope, lookupAll); sym != nil && sym.kind == symField {
+				fd, _ = b.findDescriptor(sym.fullName).(protoreflect.FieldDescriptor)
+			}
+		} else {
+			dbg += name
+			b.unresolved = ""
+			fd = md.Fields().ByName(protoreflect.Name(name))
+		}
+		switch {
+		case fd == nil && b.unresolved != "":
+			b.errorf(opt, locOptionName, "Option \"%s\" is resolved to \"(%s)\", which is not defined. The innermost scope is searched first in name resolution. Consider using a leading '.'(i.e., \"(.%s\") to start from the outermost scope.", dbg, b.unresolved, dbg[1:])
+			return false
+		case fd == nil:
+			b.errorf(opt, locOptionName, "Option \"%s\" unknown. Ensure that your proto definition file imports the proto which defines the option.", dbg)
+			return false
+		case fd.ContainingMessage().FullName() != md.FullName():
+			b.errorf(opt, locOptionName, "Option field \"%s\" is not a field or extension of message \"%s\".", dbg, md.Name())
+			return false
+		}
+		fields = append(fields, fd)
+		if i < len(opt.Name)-1 {
+			if fd.Message() == nil {
+				b.errorf(opt, locOptionName, "Option \"%s\" is an atomic type, not a message.", dbg)
+				return false
+			}
+			if fd.Cardinality() == protoreflect.Repeated {
+				b.errorf(opt, locOptionName, "Option field \"%s\" is a repeated message. Repeated message options must be initialized using an aggregate value.", dbg)
+				return false
+			}
+			md = fd.Message()
+		}
+	}
+
+	dest := append([]int32(nil), t.path...)
+	for _, fd := range fields {
+		dest = append(dest, int32(fd.Number()))
+	}
+	key := pathKey(dest)
+	leaf := fields[len(fields)-1]
+	if leaf.Cardinality() == protoreflect.Repeated {
+		dest = append(dest, int32(t.counts[key]))
+		t.counts[key]++
+	} else if t.set[key] {
+		b.errorf(opt, locOptionName, "Option \"%s\" was already set.", dbg)
+		return false
+	}
+
+	value, ok := b.optionValue(t, opt, leaf)
+	if !ok {
+		return false
+	}
+	for i := len(fields) - 2; i >= 0; i-- {
+		value = appendField(nil, fields[i], value)
+	}
+	if err := (protoV2.UnmarshalOptions{
+		Merge:        true,
+		AllowPartial: true,
+		Resolver:     new(protoregistry.Types),
+	}).Unmarshal(value, t.opts); err != nil {
+		b.errorf(opt, locOptionValue, "%v", err)
+		return false
+	}
+
+	n := len(t.path)
+	for i := n + 1; i <= n+len(fields); i++ {
+		t.set[pathKey(dest[:i])] = true
+	}
+	src := append(append([]int32(nil), t.path...), uninterpretedOptionTag, int32(t.index[opt]))
+	b.interpreted[pathKey(src)] = dest
+	return true
+}
+
+// appendField appends a field holding the encoded value of a message.
+func appendField(b []byte, fd protoreflect.FieldDescriptor, value []byte) []byte {
+	if fd.Kind() == protoreflect.GroupKind {
+		b = protowire.AppendTag(b, fd.Number(), protowire.StartGroupType)
+		b = append(b, value...)
+		return protowire.AppendTag(b, fd.Number(), protowire.EndGroupType)
+	}
+	b = protowire.AppendTag(b, fd.Number(), protowire.BytesType)
+	return protowire.AppendBytes(b, value)
+}
+
+// optionValue returns the encoded field setting an option to its value.
+func (b *builder) optionValue(t *optionsTarget, opt *descriptorpb.UninterpretedOption, fd protoreflect.FieldDescriptor) ([]byte, bool) {
+	valueError := func(format string, args ...interface{}) ([]byte, bool) {
+		b.errorf(opt, locOptionValue, format, args...)
+		return nil, false
+	}
+	num := fd.Number()
+	full := fd.FullName()
+	var v []byte
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var x int64
+		switch {
+		case opt.PositiveIntValue != nil:
+			if opt.GetPositiveIntValue() > math.MaxInt32 {
+				return valueError("Value out of range for int32 option \"%s\".", full)
+			}
+			x = int64(opt.GetPositiveIntValue())
+		case opt.NegativeIntValue != nil:
+			if opt.GetNegativeIntValue() < math.MinInt32 {
+				return valueError("Value out of range for int32 option \"%s\".", full)
+			}
+			x = opt.GetNegativeIntValue()
+		default:
+			return valueError("Value must be integer for int32 option \"%s\".", full)
+		}
+		switch fd.Kind() {
+		case protoreflect.Int32Kind:
+			v = protowire.AppendTag(v, num, protowire.VarintType)
+			v = protowire.AppendVarint(v, uint64(x))
+		case protoreflect.Sint32Kind:
+			v = protowire.AppendTag(v, num, protowire.VarintType)
+			v = protowire.AppendVarint(v, protowire.EncodeZigZag(x))
+		default:
+			v = protowire.AppendTag(v, num, protowire.Fixed32Type)
+			v = protowire.AppendFixed32(v, uint32(x))
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var x int64
+		switch {
+		case opt.PositiveIntValue != nil:
+			if opt.GetPositiveIntValue() > math.MaxInt64 {
+				return valueError("Value out of range for int64 option \"%s\".", full)
+			}
+			x = int64(opt.GetPositiveIntValue())
+		case opt.NegativeIntValue != nil:
+			x = opt.GetNegativeIntValue()
+		default:
+			return valueError("Value must be integer for int64 option \"%s\".", full)
+		}
+		switch fd.Kind() {
+		case protoreflect.Int64Kind:
+			v = protowire.AppendTag(v, num, protowire.VarintType)
+			v = protowire.AppendVarint(v, uint64(x))
+		case protoreflect.Sint64Kind:
+			v = protowire.AppendTag(v, num, protowire.VarintType)
+			v = protowire.AppendVarint(v, protowire.EncodeZigZag(x))
+		default:
+			v = protowire.AppendTag(v, num, protowire.Fixed64Type)
+			v = protowire.AppendFixed64(v, uint64(x))
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if opt.PositiveIntValue == nil {
+			return valueError("Value must be non-negative integer for uint32 option \"%s\".", full)
+		}
+		x := opt.GetPositiveIntValue()
+		if x > math.MaxUint32 {
+			// protoc names the option by its short name here.
+			return valueError("Value out of range for uint32 option \"%s\".", fd.Name())
+		}
+		if fd.Kind() == protoreflect.Uint32Kind {
+			v = protowire.AppendTag(v, num, protowire.VarintType)
+			v = protowire.AppendVarint(v, x)
+		} else {
+			v = protowire.AppendTag(v, num, protowire.Fixed32Type)
+			v = protowire.AppendFixed32(v, uint32(x))
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if opt.PositiveIntValue == nil {
+			return valueError("Value must be non-negative integer for uint64 option \"%s\".", full)
+		}
+		x := opt.GetPositiveIntValue()
+		if fd.Kind() == protoreflect.Uint64Kind {
+			v = protowire.AppendTag(v, num, protowire.VarintType)
+			v = protowire.AppendVarint(v, x)
+		} else {
+			v = protowire.AppendTag(v, num, protowire.Fixed64Type)
+			v = protowire.AppendFixed64(v, x)
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		var x float64
+		switch {
+		case opt.PositiveIntValue != nil:
+			x = float64(opt.GetPositiveIntValue())
+		case opt.NegativeIntValue != nil:
+			x = float64(opt.GetNegativeIntValue())
+		case opt.DoubleValue != nil:
+			x = opt.GetDoubleValue()
+		case opt.GetIdentifierValue() == "inf":
+			x = math.Inf(1)
+		case opt.GetIdentifierValue() == "nan":
+			x = math.NaN()
+		default:
+			return valueError("Value must be number for %s option \"%s\".", fd.Kind(), full)
+		}
+		if fd.Kind() == protoreflect.FloatKind {
+			v = protowire.AppendTag(v, num, protowire.Fixed32Type)
+			v = protowire.AppendFixed32(v, math.Float32bits(float32(x)))
+		} else {
+			v = protowire.AppendTag(v, num, protowire.Fixed64Type)
+			v = protowire.AppendFixed64(v, math.Float64bits(x))
+		}
+	case protoreflect.BoolKind:
+		if opt.IdentifierValue == nil {
+			return valueError("Value must be identifier for boolean option \"%s\".", full)
+		}
+		var x uint64
+		switch opt.GetIdentifierValue() {
+		case "true":
+			x = 1
+		case "false":
+		default:
+			return valueError("Value must be \"true\" or \"false\" for boolean option \"%s\".", full)
+		}
+		v = protowire.AppendTag(v, num, protowire.VarintType)
+		v = protowire.AppendVarint(v, x)
+	case protoreflect.EnumKind:
+		if opt.IdentifierValue == nil {
+			return valueError("Value must be identifier for enum-valued option \"%s\".", full)
+		}
+		ed := fd.Enum()
+		name := opt.GetIdentifierValue()
+		ev := ed.Values().ByName(protoreflect.Name(name))
+		if ev == nil {
+			// The values of an enum are siblings of the enum.
+			sibling := join(string(ed.FullName().Parent()), name)
+			if sym := b.l.symbols[sibling]; sym != nil && sym.kind == symEnumValue {
+				return valueError("Enum type \"%s\" has no value named \"%s\" for option \"%s\". This appears to be a value from a sibling type.", ed.FullName(), name, full)
+			}
+			return valueError("Enum type \"%s\" has no value named \"%s\" for option \"%s\".", ed.FullName(), name, full)
+		}
+		v = protowire.AppendTag(v, num, protowire.VarintType)
+		v = protowire.AppendVarint(v, uint64(int64(ev.Number())))
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		if opt.StringValue == nil {
+			return valueError("Value must be quoted string for string option \"%s\".", full)
+		}
+		v = protowire.AppendTag(v, num, protowire.BytesType)
+		v = protowire.AppendBytes(v, opt.StringValue)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if opt.AggregateValue == nil {
+			return valueError("Option \"%s\" is a message. To set the entire message, use syntax like \"%s = { <proto text format> }\". To set fields within it, use syntax like \"%s.foo = value\".", full, fd.Name(), fd.Name())
+		}
+		m := dynamicpb.NewMessage(fd.Message())
+		err := prototext.UnmarshalOptions{
+			AllowPartial: true,
+			Resolver:     &aggregateResolver{b, t.scope},
+		}.Unmarshal([]byte(opt.GetAggregateValue()), m)
+		if err != nil {
+			return valueError("Error while parsing option value for \"%s\": %v", fd.Name(), err)
+		}
+		// Deterministic marshaling encodes the fields in order of field
+		// numbers, as protoc does, rather than in the random order of the
+		// fields of dynamic messages.
+		msg, err := protoV2.MarshalOptions{AllowPartial: true, Deterministic: true}.Marshal(m)
+		if err != nil {
+			return valueError("Error while parsing option value for \"%s\": %v", fd.Name(), err)
+		}
+		v = appendField(v, fd, msg)
+	}
+	return v, true
+}
+
+// findDescriptor returns the declaration with the given full name,
+// in the file or the files it imports.
+func (b *builder) findDescriptor(name string) protoreflect.Descriptor {
+	if b.desc != nil {
+		if d := findInFile(b.desc, protoreflect.FullName(name)); d != nil {
+			return d
+		}
+	}
+	d, _ := b.l.reg.FindDescriptorByName(protoreflect.FullName(name))
+	return d
+}
+
+// findInFile returns the message, enum or extension with the given full
+// name declared in a file.
+func findInFile(fd protoreflect.FileDescriptor, name protoreflect.FullName) protoreflect.Descriptor {
+	var find func(msgs protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors, exts protoreflect.ExtensionDescriptors) protoreflect.Descriptor
+	find = func(msgs protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors, exts protoreflect.ExtensionDescriptors) protoreflect.Descriptor {
+		for i := 0; i < exts.Len(); i++ {
+			if exts.Get(i).FullName() == name {
+				return exts.Get(i)
+			}
+		}
+		for i := 0; i < enums.Len(); i++ {
+			if enums.Get(i).FullName() == name {
+				return enums.Get(i)
+			}
+		}
+		for i := 0; i < msgs.Len(); i++ {
+			md := msgs.Get(i)
+			if md.FullName() == name {
+				return md
+			}
+			if strings.HasPrefix(string(name), string(md.FullName())+".") {
+				if d := find(md.Messages(), md.Enums(), md.Extensions()); d != nil {
+					return d
+				}
+				if f := md.Fields().ByName(name.Name()); f != nil && f.FullName() == name {
+					return f
+				}
+			}
+		}
+		return nil
+	}
+	return find(fd.Messages(), fd.Enums(), fd.Extensions())
+}
+
+// aggregateResolver resolves the types in the aggregate value of an option,
+// relative to the scope of the option.
+type aggregateResolver struct {
+	b     *builder
+	scope string
+}
+
+func (r *aggregateResolver) FindExtensionByName(name protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	sym := r.b.lookup(string(name), r.scope, lookupAll)
+	if sym != nil && sym.kind == symField {
+		if xd, ok := r.b.findDescriptor(sym.fullName).(protoreflect.ExtensionDescriptor); ok && xd.IsExtension() {
+			return dynamicpb.NewExtensionType(xd), nil
+		}
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (r *aggregateResolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	return nil, protoregistry.NotFound
+}
+
+func (r *aggregateResolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	if md, ok := r.b.findDescriptor(string(name)).(protoreflect.MessageDescriptor); ok {
+		return dynamicpb.NewMessageType(md), nil
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (r *aggregateResolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	if i := strings.LastIndexByte(url, '/'); i >= 0 {
+		url = url[i+1:]
+	}
+	return r.FindMessageByName(protoreflect.FullName(url))
+}
+
+// updateSourceCodeInfo moves the locations of the interpreted options
+// to the paths of the fields they set, as protoc does.
+func (b *builder) updateSourceCodeInfo() {
+	info := b.fd.SourceCodeInfo
+	if info == nil || len(b.interpreted) == 0 {
+		return
+	}
+	var locs []*descriptorpb.SourceCodeInfo_Location
+	var prefix []int32
+	for _, loc := range info.Location {
+		if prefix != nil {
+			if hasPrefix(loc.Path, prefix) {
+				// Drop the locations within the interpreted option.
+				continue
+			}
+			prefix = nil
+		}
+		dest, ok := b.interpreted[pathKey(loc.Path)]
+		if !ok {
+			locs = append(locs, loc)
+			continue
+		}
+		prefix = loc.Path
+		loc = proto.Clone(loc).(*descriptorpb.SourceCodeInfo_Location)
+		loc.Path = dest
+		locs = append(locs, loc)
+	}
+	info.Location = locs
+}
+
+func hasPrefix(path, prefix []int32) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,1990 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoparse
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// Paths of the elements of descriptor protos, as used by SourceCodeInfo.
+const (
+	filePackageTag          = 2
+	fileDependencyTag       = 3
+	fileMessageTag          = 4
+	fileEnumTag             = 5
+	fileServiceTag          = 6
+	fileExtensionTag        = 7
+	fileOptionsTag          = 8
+	filePublicDependencyTag = 10
+	fileWeakDependencyTag   = 11
+	fileSyntaxTag           = 12
+
+	messageNameTag           = 1
+	messageFieldTag          = 2
+	messageNestedTag         = 3
+	messageEnumTag           = 4
+	messageExtensionRangeTag = 5
+	messageExtensionTag      = 6
+	messageOptionsTag        = 7
+	messageOneofTag          = 8
+	messageReservedRangeTag  = 9
+	messageReservedNameTag   = 10
+
+	extensionRangeStartTag   = 1
+	extensionRangeEndTag     = 2
+	extensionRangeOptionsTag = 3
+
+	fieldNameTag     = 1
+	fieldExtendeeTag = 2
+	fieldNumberTag   = 3
+	fieldLabelTag    = 4
+	fieldTypeTag     = 5
+	fieldTypeNameTag = 6
+	fieldDefaultTag  = 7
+	fieldOptionsTag  = 8
+	fieldJSONNameTag = 10
+
+	oneofNameTag    = 1
+	oneofOptionsTag = 2
+
+	enumNameTag          = 1
+	enumValueTag         = 2
+	enumOptionsTag       = 3
+	enumReservedRangeTag = 4
+	enumReservedNameTag  = 5
+
+	enumValueNameTag    = 1
+	enumValueNumberTag  = 2
+	enumValueOptionsTag = 3
+
+	serviceNameTag    = 1
+	serviceMethodTag  = 2
+	serviceOptionsTag = 3
+
+	methodNameTag            = 1
+	methodInputTag           = 2
+	methodOutputTag          = 3
+	methodOptionsTag         = 4
+	methodClientStreamingTag = 5
+	methodServerStreamingTag = 6
+
+	uninterpretedOptionTag = 999
+
+	optionNameTag      = 2
+	optionIdentTag     = 3
+	optionPositiveTag  = 4
+	optionNegativeTag  = 5
+	optionDoubleTag    = 6
+	optionStringTag    = 7
+	optionAggregateTag = 8
+
+	namePartTag = 1
+)
+
+const (
+	maxFieldNumber = 1<<29 - 1
+
+	// rangeMaxSentinel marks the end of a range extending to max until
+	// the message is known not to use the message set wire format.
+	rangeMaxSentinel = -1
+)
+
+// An errorLocation identifies the part of a declaration an error refers to,
+// as the ErrorLocation of protoc's DescriptorPool::ErrorCollector.
+type errorLocation int
+
+const (
+	locName errorLocation = iota
+	locNumber
+	locType
+	locExtendee
+	locDefaultValue
+	locInputType
+	locOutputType
+	locOptionName
+	locOptionValue
+	locOther
+)
+
+type legacyKey struct {
+	elem proto.Message
+	loc  errorLocation
+}
+
+// A parsedFile is the result of parsing a single source file.
+type parsedFile struct {
+	fd *descriptorpb.FileDescriptorProto
+
+	// The positions of the parts of declarations, used to report the
+	// errors found when linking the file.
+	positions map[legacyKey]position
+	imports   map[string]position
+}
+
+// position returns the position of a part of a declaration,
+// or noPosition if it is unknown.
+func (f *parsedFile) position(elem proto.Message, loc errorLocation) position {
+	if f == nil {
+		return noPosition
+	}
+	if pos, ok := f.positions[legacyKey{elem, loc}]; ok {
+		return pos
+	}
+	return noPosition
+}
+
+var scalarTypes = map[string]descriptorpb.FieldDescriptorProto_Type{
+	"double":   descriptorpb.FieldDescriptorProto_TYPE_DOUBLE,
+	"float":    descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+	"int64":    descriptorpb.FieldDescriptorProto_TYPE_INT64,
+	"uint64":   descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+	"int32":    descriptorpb.FieldDescriptorProto_TYPE_INT32,
+	"fixed64":  descriptorpb.FieldDescriptorProto_TYPE_FIXED64,
+	"fixed32":  descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+	"bool":     descriptorpb.FieldDescriptorProto_TYPE_BOOL,
+	"string":   descriptorpb.FieldDescriptorProto_TYPE_STRING,
+	"group":    descriptorpb.FieldDescriptorProto_TYPE_GROUP,
+	"bytes":    descriptorpb.FieldDescriptorProto_TYPE_BYTES,
+	"uint32":   descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+	"sfixed32": descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+	"sfixed64": descriptorpb.FieldDescriptorProto_TYPE_SFIXED64,
+	"sint32":   descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+	"sint64":   descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+}
+
+// parser is a recursive descent parser for .proto files. It follows the
+// structure of protoc's Parser, so that the descriptors, source locations,
+// comments and errors it produces are those protoc produces.
+type parser struct {
+	file   string
+	toks   []token
+	i      int
+	errs   *errorList
+	result *parsedFile
+	info   *descriptorpb.SourceCodeInfo
+	syntax string
+
+	// The comments of the next declaration.
+	upcomingDoc      string
+	upcomingDetached []string
+}
+
+// parse parses the source of a file. It returns nil if the file
+// has errors, which are recorded in errs.
+func parse(filename, src string, errs *errorList) *parsedFile {
+	n := errs.len()
+	toks, ok := tokenize(filename, src, errs)
+	if !ok {
+		return nil
+	}
+	p := &parser{
+		file: filename,
+		toks: toks,
+		errs: errs,
+		result: &parsedFile{
+			fd:        &descriptorpb.FileDescriptorProto{Name: proto.String(filename)},
+			positions: make(map[legacyKey]position),
+			imports:   make(map[string]position),
+		},
+		info: new(descriptorpb.SourceCodeInfo),
+	}
+	p.upcomingDetached, p.upcomingDoc = toks[0].detached, toks[0].leading
+	if !p.parseFile() || errs.len() > n {
+		return nil
+	}
+	p.result.fd.SourceCodeInfo = p.info
+	return p.result
+}
+
+func (p *parser) cur() *token {
+	return &p.toks[p.i]
+}
+
+func (p *parser) atEnd() bool {
+	return p.cur().kind == tokenEOF
+}
+
+func (p *parser) lookingAt(text string) bool {
+	return p.cur().text == text
+}
+
+func (p *parser) next() {
+	if !p.atEnd() {
+		p.i++
+	}
+}
+
+func (p *parser) errorf(format string, args ...interface{}) {
+	p.errs.add(p.file, p.cur().start, format, args...)
+}
+
+func (p *parser) tryConsume(text string) bool {
+	if p.lookingAt(text) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+// consume consumes the given token, reporting an error if it is not next.
+// The error message defaults to "Expected <text>.".
+func (p *parser) consume(text string, msg ...string) bool {
+	if p.tryConsume(text) {
+		return true
+	}
+	if len(msg) > 0 {
+		p.errorf("%s", msg[0])
+	} else {
+		p.errorf("Expected \"%s\".", text)
+	}
+	return false
+}
+
+func (p *parser) consumeIdentifier(msg string) (string, bool) {
+	if p.cur().kind != tokenIdent {
+		p.errorf("%s", msg)
+		return "", false
+	}
+	s := p.cur().text
+	p.next()
+	return s, true
+}
+
+// consumeString consumes a string literal, concatenating adjacent literals.
+func (p *parser) consumeString(msg string) (string, bool) {
+	if p.cur().kind != tokenString {
+		p.errorf("%s", msg)
+		return "", false
+	}
+	var s string
+	for p.cur().kind == tokenString {
+		s += p.cur().value
+		p.next()
+	}
+	return s, true
+}
+
+// consumeInteger64 consumes an integer no greater than max.
+func (p *parser) consumeInteger64(max uint64, msg string) (uint64, bool) {
+	if p.cur().kind != tokenInt {
+		p.errorf("%s", msg)
+		return 0, false
+	}
+	v, ok := parseInteger(p.cur().text, max)
+	if !ok {
+		p.errorf("Integer out of range.")
+		v = 0
+	}
+	p.next()
+	return v, true
+}
+
+func (p *parser) consumeInteger(msg string) (int32, bool) {
+	v, ok := p.consumeInteger64(math.MaxInt32, msg)
+	return int32(v), ok
+}
+
+func (p *parser) consumeSignedInteger(msg string) (int32, bool) {
+	max := uint64(math.MaxInt32)
+	neg := p.tryConsume("-")
+	if neg {
+		max++
+	}
+	v, ok := p.consumeInteger64(max, msg)
+	if neg {
+		return int32(-int64(v)), ok
+	}
+	return int32(v), ok
+}
+
+// consumeNumber consumes a floating-point or integer literal, inf or nan.
+func (p *parser) consumeNumber(msg string) (float64, bool) {
+	switch {
+	case p.cur().kind == tokenFloat:
+		v := parseFloat(p.cur().text)
+		p.next()
+		return v, true
+	case p.cur().kind == tokenInt:
+		v, ok := parseInteger(p.cur().text, math.MaxUint64)
+		if !ok {
+			p.errorf("Integer out of range.")
+		}
+		p.next()
+		return float64(v), true
+	case p.lookingAt("inf"):
+		p.next()
+		return math.Inf(1), true
+	case p.lookingAt("nan"):
+		p.next()
+		return math.NaN(), true
+	}
+	p.errorf("%s", msg)
+	return 0, false
+}
+
+// parseInteger parses a decimal, hexadecimal or octal integer literal.
+func parseInteger(text string, max uint64) (uint64, bool) {
+	v, err := strconv.ParseUint(text, 0, 64)
+	return v, err == nil && v <= max
+}
+
+// parseFloat parses a floating-point literal, which is known to be well formed.
+func parseFloat(text string) float64 {
+	v, _ := strconv.ParseFloat(text, 64) // out of range values are ±Inf
+	return v
+}
+
+// tryConsumeEndOfDeclaration consumes the token ending a declaration,
+// attributing the comments around it as protoc does: the comments before
+// the declaration lead it, the comment following the token trails it,
+// and the comments following those lead the next declaration.
+func (p *parser) tryConsumeEndOfDeclaration(text string, loc *locationRecorder) bool {
+	if !p.lookingAt(text) {
+		return false
+	}
+	p.next()
+	next := p.cur()
+	leading := p.upcomingDoc
+	p.upcomingDoc = next.leading
+	switch {
+	case loc != nil:
+		detached := p.upcomingDetached
+		p.upcomingDetached = next.detached
+		loc.attachComments(leading, next.prevTrailing, detached)
+	case text == "}":
+		p.upcomingDetached = next.detached
+	default:
+		p.upcomingDetached = append(p.upcomingDetached, next.detached...)
+	}
+	return true
+}
+
+func (p *parser) consumeEndOfDeclaration(text string, loc *locationRecorder) bool {
+	if p.tryConsumeEndOfDeclaration(text, loc) {
+		return true
+	}
+	p.errorf("Expected \"%s\".", text)
+	return false
+}
+
+// skipStatement skips the rest of a statement after an error.
+func (p *parser) skipStatement() {
+	for !p.atEnd() {
+		if p.cur().kind == tokenSymbol {
+			if p.tryConsumeEndOfDeclaration(";", nil) {
+				return
+			}
+			if p.tryConsume("{") {
+				p.skipRestOfBlock()
+				return
+			}
+			if p.lookingAt("}") {
+				return
+			}
+		}
+		p.next()
+	}
+}
+
+func (p *parser) skipRestOfBlock() {
+	for !p.atEnd() {
+		if p.cur().kind == tokenSymbol {
+			if p.tryConsumeEndOfDeclaration("}", nil) {
+				return
+			}
+			if p.tryConsume("{") {
+				p.skipRestOfBlock()
+				continue
+			}
+		}
+		p.next()
+	}
+}
+
+// A locationRecorder records the source location of an element.
+// The location starts at the current token and, unless set explicitly,
+// ends at the last token consumed before end is called.
+type locationRecorder struct {
+	p   *parser
+	loc *descriptorpb.SourceCodeInfo_Location
+}
+
+// location starts recording the location of the element at the given path
+// relative to parent, which may be nil for the file itself.
+func (p *parser) location(parent *locationRecorder, path ...int32) *locationRecorder {
+	var full []int32
+	if parent != nil {
+		full = append(full, parent.loc.Path...)
+	}
+	start := p.cur().start
+	loc := &descriptorpb.SourceCodeInfo_Location{
+		Path: append(full, path...),
+		Span: []int32{int32(start.line), int32(start.col)},
+	}
+	p.info.Location = append(p.info.Location, loc)
+	return &locationRecorder{p, loc}
+}
+
+func (l *locationRecorder) addPath(n int32) {
+	l.loc.Path = append(l.loc.Path, n)
+}
+
+func (l *locationRecorder) startAt(t *token) {
+	l.loc.Span[0], l.loc.Span[1] = int32(t.start.line), int32(t.start.col)
+}
+
+func (l *locationRecorder) endAt(t *token) {
+	if int32(t.start.line) != l.loc.Span[0] {
+		l.loc.Span = append(l.loc.Span, int32(t.start.line))
+	}
+	l.loc.Span = append(l.loc.Span, int32(t.end.col))
+}
+
+func (l *locationRecorder) end() {
+	if len(l.loc.Span) > 2 {
+		return
+	}
+	if l.p.i == 0 {
+		l.endAt(&token{start: l.p.cur().start, end: l.p.cur().start})
+		return
+	}
+	l.endAt(&l.p.toks[l.p.i-1])
+}
+
+func (l *locationRecorder) attachComments(leading, trailing string, detached []string) {
+	if leading != "" {
+		l.loc.LeadingComments = proto.String(leading)
+	}
+	if trailing != "" {
+		l.loc.TrailingComments = proto.String(trailing)
+	}
+	l.loc.LeadingDetachedComments = append(l.loc.LeadingDetachedComments, detached...)
+}
+
+// recordLegacy records the start of the location as the position
+// of the given part of the element, to report errors found when linking.
+func (l *locationRecorder) recordLegacy(elem proto.Message, loc errorLocation) {
+	l.p.result.positions[legacyKey{elem, loc}] = position{int(l.loc.Span[0]), int(l.loc.Span[1])}
+}
+
+func (p *parser) parseFile() bool {
+	fd := p.result.fd
+	root := p.location(nil)
+	defer root.end()
+	root.recordLegacy(fd, locOther)
+
+	if p.lookingAt("syntax") {
+		if !p.parseSyntax(root) {
+			return false
+		}
+		if p.syntax == "proto3" {
+			fd.Syntax = proto.String(p.syntax)
+		}
+	} else {
+		p.syntax = "proto2"
+	}
+
+	for !p.atEnd() {
+		if !p.parseTopLevelStatement(root) {
+			p.skipStatement()
+			if p.lookingAt("}") {
+				p.errorf("Unmatched \"}\".")
+				p.next()
+				p.upcomingDetached, p.upcomingDoc = p.cur().detached, p.cur().leading
+			}
+		}
+	}
+	return true
+}
+
+func (p *parser) parseSyntax(root *locationRecorder) bool {
+	loc := p.location(root, fileSyntaxTag)
+	defer loc.end()
+	if !p.consume("syntax", "File must begin with a syntax statement, e.g. 'syntax = \"proto2\";'.") || !p.consume("=") {
+		return false
+	}
+	tok := p.cur()
+	syntax, ok := p.consumeString("Expected syntax identifier.")
+	if !ok || !p.consumeEndOfDeclaration(";", loc) {
+		return false
+	}
+	p.syntax = syntax
+	if syntax != "proto2" && syntax != "proto3" {
+		p.errs.add(p.file, tok.start, "Unrecognized syntax identifier \"%s\".  This parser only recognizes \"proto2\" and \"proto3\".", syntax)
+		return false
+	}
+	return true
+}
+
+func (p *parser) parseTopLevelStatement(root *locationRecorder) bool {
+	fd := p.result.fd
+	switch {
+	case p.tryConsumeEndOfDeclaration(";", nil):
+		return true
+	case p.lookingAt("message"):
+		loc := p.location(root, fileMessageTag, int32(len(fd.MessageType)))
+		defer loc.end()
+		md := new(descriptorpb.DescriptorProto)
+		fd.MessageType = append(fd.MessageType, md)
+		return p.parseMessageDefinition(md, loc)
+	case p.lookingAt("enum"):
+		loc := p.location(root, fileEnumTag, int32(len(fd.EnumType)))
+		defer loc.end()
+		ed := new(descriptorpb.EnumDescriptorProto)
+		fd.EnumType = append(fd.EnumType, ed)
+		return p.parseEnumDefinition(ed, loc)
+	case p.lookingAt("service"):
+		loc := p.location(root, fileServiceTag, int32(len(fd.Service)))
+		defer loc.end()
+		sd := new(descriptorpb.ServiceDescriptorProto)
+		fd.Service = append(fd.Service, sd)
+		return p.parseServiceDefinition(sd, loc)
+	case p.lookingAt("extend"):
+		loc := p.location(root, fileExtensionTag)
+		defer loc.end()
+		return p.parseExtend(&fd.Extension, &fd.MessageType, root, fileMessageTag, loc)
+	case p.lookingAt("import"):
+		return p.parseImport(root)
+	case p.lookingAt("package"):
+		return p.parsePackage(root)
+	case p.lookingAt("option"):
+		loc := p.location(root, fileOptionsTag)
+		defer loc.end()
+		if fd.Options == nil {
+			fd.Options = new(descriptorpb.FileOptions)
+		}
+		return p.parseOption(&fd.Options.UninterpretedOption, loc, optionStatement)
+	}
+	p.errorf("Expected top-level statement (e.g. \"message\").")
+	return false
+}
+
+func (p *parser) parseImport(root *locationRecorder) bool {
+	fd := p.result.fd
+	loc := p.location(root, fileDependencyTag, int32(len(fd.Dependency)))
+	defer loc.end()
+	if !p.consume("import") {
+		return false
+	}
+	switch {
+	case p.lookingAt("public"):
+		l := p.location(root, filePublicDependencyTag, int32(len(fd.PublicDependency)))
+		p.next()
+		l.end()
+		fd.PublicDependency = append(fd.PublicDependency, int32(len(fd.Dependency)))
+	case p.lookingAt("weak"):
+		l := p.location(root, fileWeakDependencyTag, int32(len(fd.WeakDependency)))
+		p.next()
+		l.end()
+		fd.WeakDependency = append(fd.WeakDependency, int32(len(fd.Dependency)))
+	}
+	name, ok := p.consumeString("Expected a string naming the file to import.")
+	if !ok {
+		return false
+	}
+	fd.Dependency = append(fd.Dependency, name)
+	p.result.imports[name] = position{int(loc.loc.Span[0]), int(loc.loc.Span[1])}
+	return p.consumeEndOfDeclaration(";", loc)
+}
+
+func (p *parser) parsePackage(root *locationRecorder) bool {
+	fd := p.result.fd
+	if fd.Package != nil {
+		p.errorf("Multiple package definitions.")
+		fd.Package = nil
+	}
+	loc := p.location(root, filePackageTag)
+	defer loc.end()
+	loc.recordLegacy(fd, locName)
+	if !p.consume("package") {
+		return false
+	}
+	var pkg string
+	for {
+		ident, ok := p.consumeIdentifier("Expected identifier.")
+		if !ok {
+			return false
+		}
+		pkg += ident
+		if !p.tryConsume(".") {
+			break
+		}
+		pkg += "."
+	}
+	fd.Package = proto.String(pkg)
+	return p.consumeEndOfDeclaration(";", loc)
+}
+
+type optionStyle int
+
+const (
+	optionAssignment optionStyle = iota // field [name = value]
+	optionStatement                     // option name = value;
+)
+
+// parseOption parses an option, appending it to the uninterpreted options
+// of the element whose options are at loc.
+func (p *parser) parseOption(opts *[]*descriptorpb.UninterpretedOption, optionsLoc *locationRecorder, style optionStyle) bool {
+	loc := p.location(optionsLoc, uninterpretedOptionTag, int32(len(*opts)))
+	defer loc.end()
+	if style == optionStatement && !p.consume("option") {
+		return false
+	}
+	opt := new(descriptorpb.UninterpretedOption)
+	*opts = append(*opts, opt)
+
+	nameLoc := p.location(loc, optionNameTag)
+	nameLoc.recordLegacy(opt, locOptionName)
+	for {
+		partLoc := p.location(nameLoc, optionNameTag, int32(len(opt.Name)))
+		ok := p.parseOptionNamePart(opt, partLoc)
+		partLoc.end()
+		if !ok {
+			nameLoc.end()
+			return false
+		}
+		if !p.tryConsume(".") {
+			break
+		}
+	}
+	nameLoc.end()
+
+	if !p.consume("=") || !p.parseOptionValue(opt, loc) {
+		return false
+	}
+	if style == optionStatement {
+		return p.consumeEndOfDeclaration(";", loc)
+	}
+	return true
+}
+
+func (p *parser) parseOptionNamePart(opt *descriptorpb.UninterpretedOption, partLoc *locationRecorder) bool {
+	part := new(descriptorpb.UninterpretedOption_NamePart)
+	opt.Name = append(opt.Name, part)
+	if !p.tryConsume("(") {
+		loc := p.location(partLoc, namePartTag)
+		defer loc.end()
+		ident, ok := p.consumeIdentifier("Expected identifier.")
+		part.NamePart, part.IsExtension = proto.String(ident), proto.Bool(false)
+		return ok
+	}
+
+	// An extension name consists of dot-separated identifiers,
+	// and may begin with a dot.
+	loc := p.location(partLoc, namePartTag)
+	var name string
+	if p.cur().kind == tokenIdent {
+		name = p.cur().text
+		p.next()
+	}
+	for p.lookingAt(".") {
+		p.next()
+		ident, ok := p.consumeIdentifier("Expected identifier.")
+		if !ok {
+			loc.end()
+			return false
+		}
+		name += "." + ident
+	}
+	loc.end()
+	part.NamePart, part.IsExtension = proto.String(name), proto.Bool(true)
+	return p.consume(")")
+}
+
+func (p *parser) parseOptionValue(opt *descriptorpb.UninterpretedOption, optLoc *locationRecorder) bool {
+	loc := p.location(optLoc)
+	defer loc.end()
+	loc.recordLegacy(opt, locOptionValue)
+
+	// All values are a single token, except for negative numbers,
+	// which consist of a '-' symbol followed by a positive number.
+	neg := p.tryConsume("-")
+	switch p.cur().kind {
+	case tokenEOF:
+		p.errorf("Unexpected end of stream while parsing option value.")
+		return false
+	case tokenIdent:
+		loc.addPath(optionIdentTag)
+		if neg {
+			switch p.cur().text {
+			case "inf":
+				opt.DoubleValue = proto.Float64(math.Inf(-1))
+			case "nan":
+				opt.DoubleValue = proto.Float64(math.NaN())
+			default:
+				p.errorf("Identifier after '-' symbol must be inf or nan.")
+				return false
+			}
+			p.next()
+			return true
+		}
+		opt.IdentifierValue = proto.String(p.cur().text)
+		p.next()
+	case tokenInt:
+		max := uint64(math.MaxUint64)
+		if neg {
+			max = math.MaxInt64 + 1
+		}
+		v, ok := p.consumeInteger64(max, "Expected integer.")
+		if !ok {
+			return false
+		}
+		if neg {
+			loc.addPath(optionNegativeTag)
+			opt.NegativeIntValue = proto.Int64(int64(-v))
+		} else {
+			loc.addPath(optionPositiveTag)
+			opt.PositiveIntValue = proto.Uint64(v)
+		}
+	case tokenFloat:
+		loc.addPath(optionDoubleTag)
+		v, _ := p.consumeNumber("Expected number.")
+		if neg {
+			v = -v
+		}
+		opt.DoubleValue = proto.Float64(v)
+	case tokenString:
+		loc.addPath(optionStringTag)
+		if neg {
+			p.errorf("Invalid '-' symbol before string.")
+			return false
+		}
+		s, _ := p.consumeString("Expected string.")
+		opt.StringValue = []byte(s)
+	default:
+		if !p.lookingAt("{") {
+			p.errorf("Expected option value.")
+			return false
+		}
+		loc.addPath(optionAggregateTag)
+		v, ok := p.parseUninterpretedBlock()
+		if !ok {
+			return false
+		}
+		opt.AggregateValue = proto.String(v)
+	}
+	return true
+}
+
+// parseUninterpretedBlock returns the text of an aggregate value,
+// without its enclosing braces.
+func (p *parser) parseUninterpretedBlock() (string, bool) {
+	if !p.consume("{") {
+		return "", false
+	}
+	var toks []string
+	depth := 1
+	for !p.atEnd() {
+		switch {
+		case p.lookingAt("{"):
+			depth++
+		case p.lookingAt("}"):
+			depth--
+			if depth == 0 {
+				p.next()
+				return strings.Join(toks, " "), true
+			}
+		}
+		if n := len(toks); n > 0 && toks[n-1] == "-" {
+			// The text format has no space between a sign and its number.
+			toks[n-1] += p.cur().text
+		} else {
+			toks = append(toks, p.cur().text)
+		}
+		p.next()
+	}
+	p.errorf("Unexpected end of stream while parsing aggregate value.")
+	return "", false
+}
+
+func (p *parser) parseMessageDefinition(md *descriptorpb.DescriptorProto, msgLoc *locationRecorder) bool {
+	if !p.consume("message") {
+		return false
+	}
+	loc := p.location(msgLoc, messageNameTag)
+	loc.recordLegacy(md, locName)
+	name, ok := p.consumeIdentifier("Expected message name.")
+	loc.end()
+	if !ok {
+		return false
+	}
+	md.Name = proto.String(name)
+	if !p.parseMessageBlock(md, msgLoc) {
+		return false
+	}
+	if p.syntax == "proto3" {
+		generateSyntheticOneofs(md)
+	}
+	return true
+}
+
+func (p *parser) parseMessageBlock(md *descriptorpb.DescriptorProto, msgLoc *locationRecorder) bool {
+	if !p.consumeEndOfDeclaration("{", msgLoc) {
+		return false
+	}
+	for !p.tryConsumeEndOfDeclaration("}", nil) {
+		if p.atEnd() {
+			p.errorf("Reached end of input in message definition (missing '}').")
+			return false
+		}
+		if !p.parseMessageStatement(md, msgLoc) {
+			p.skipStatement()
+		}
+	}
+
+	// Ranges extending to max end at the largest field number,
+	// or at the largest int32 for messages using the message set wire format.
+	max := int32(maxFieldNumber + 1)
+	if isMessageSet(md) {
+		max = math.MaxInt32
+	}
+	for _, r := range md.ExtensionRange {
+		if r.GetEnd() == rangeMaxSentinel {
+			r.End = proto.Int32(max)
+		}
+	}
+	for _, r := range md.ReservedRange {
+		if r.GetEnd() == rangeMaxSentinel {
+			r.End = proto.Int32(max)
+		}
+	}
+	return true
+}
+
+// isMessageSet reports whether the message declares
+// option message_set_wire_format = true.
+func isMessageSet(md *descriptorpb.DescriptorProto) bool {
+	for _, opt := range md.GetOptions().GetUninterpretedOption() {
+		if len(opt.Name) == 1 && opt.Name[0].GetNamePart() == "message_set_wire_format" && opt.GetIdentifierValue() == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseMessageStatement(md *descriptorpb.DescriptorProto, msgLoc *locationRecorder) bool {
+	switch {
+	case p.tryConsumeEndOfDeclaration(";", nil):
+		return true
+	case p.lookingAt("message"):
+		loc := p.location(msgLoc, messageNestedTag, int32(len(md.NestedType)))
+		defer loc.end()
+		nested := new(descriptorpb.DescriptorProto)
+		md.NestedType = append(md.NestedType, nested)
+		return p.parseMessageDefinition(nested, loc)
+	case p.lookingAt("enum"):
+		loc := p.location(msgLoc, messageEnumTag, int32(len(md.EnumType)))
+		defer loc.end()
+		ed := new(descriptorpb.EnumDescriptorProto)
+		md.EnumType = append(md.EnumType, ed)
+		return p.parseEnumDefinition(ed, loc)
+	case p.lookingAt("extensions"):
+		loc := p.location(msgLoc, messageExtensionRangeTag)
+		defer loc.end()
+		return p.parseExtensions(md, loc)
+	case p.lookingAt("reserved"):
+		return p.parseReserved(md, msgLoc)
+	case p.lookingAt("extend"):
+		loc := p.location(msgLoc, messageExtensionTag)
+		defer loc.end()
+		return p.parseExtend(&md.Extension, &md.NestedType, msgLoc, messageNestedTag, loc)
+	case p.lookingAt("option"):
+		loc := p.location(msgLoc, messageOptionsTag)
+		defer loc.end()
+		if md.Options == nil {
+			md.Options = new(descriptorpb.MessageOptions)
+		}
+		return p.parseOption(&md.Options.UninterpretedOption, loc, optionStatement)
+	case p.lookingAt("oneof"):
+		index := int32(len(md.OneofDecl))
+		loc := p.location(msgLoc, messageOneofTag, index)
+		defer loc.end()
+		od := new(descriptorpb.OneofDescriptorProto)
+		md.OneofDecl = append(md.OneofDecl, od)
+		return p.parseOneof(od, md, index, loc, msgLoc)
+	}
+	loc := p.location(msgLoc, messageFieldTag, int32(len(md.Field)))
+	defer loc.end()
+	field := new(descriptorpb.FieldDescriptorProto)
+	md.Field = append(md.Field, field)
+	return p.parseMessageField(field, &md.NestedType, msgLoc, messageNestedTag, loc)
+}
+
+func (p *parser) parseMessageField(field *descriptorpb.FieldDescriptorProto, messages *[]*descriptorpb.DescriptorProto, parentLoc *locationRecorder, nestedTag int32, fieldLoc *locationRecorder) bool {
+	if label, ok := p.parseLabel(fieldLoc); ok {
+		field.Label = label.Enum()
+		if label == descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL && p.syntax == "proto3" {
+			field.Proto3Optional = proto.Bool(true)
+		}
+	}
+	return p.parseMessageFieldNoLabel(field, messages, parentLoc, nestedTag, fieldLoc)
+}
+
+func (p *parser) parseLabel(fieldLoc *locationRecorder) (descriptorpb.FieldDescriptorProto_Label, bool) {
+	var label descriptorpb.FieldDescriptorProto_Label
+	switch {
+	case p.lookingAt("optional"):
+		label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	case p.lookingAt("repeated"):
+		label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	case p.lookingAt("required"):
+		label = descriptorpb.FieldDescriptorProto_LABEL_REQUIRED
+	default:
+		return 0, false
+	}
+	loc := p.location(fieldLoc, fieldLabelTag)
+	p.next()
+	loc.end()
+	return label, true
+}
+
+// A mapField holds the key and value types of a map field.
+type mapField struct {
+	keyType, valueType         descriptorpb.FieldDescriptorProto_Type
+	keyTypeName, valueTypeName string
+}
+
+func (p *parser) parseMessageFieldNoLabel(field *descriptorpb.FieldDescriptorProto, messages *[]*descriptorpb.DescriptorProto, parentLoc *locationRecorder, nestedTag int32, fieldLoc *locationRecorder) bool {
+	var mf *mapField
+	if !p.parseFieldType(field, fieldLoc, &mf) {
+		return false
+	}
+
+	// Parse name and '='.
+	nameTok := p.cur()
+	loc := p.location(fieldLoc, fieldNameTag)
+	loc.recordLegacy(field, locName)
+	name, ok := p.consumeIdentifier("Expected field name.")
+	loc.end()
+	if !ok {
+		return false
+	}
+	field.Name = proto.String(name)
+	if !p.consume("=", "Missing field number.") {
+		return false
+	}
+
+	// Parse field number.
+	loc = p.location(fieldLoc, fieldNumberTag)
+	loc.recordLegacy(field, locNumber)
+	number, ok := p.consumeInteger("Expected field number.")
+	loc.end()
+	if !ok {
+		return false
+	}
+	field.Number = proto.Int32(number)
+
+	if !p.parseFieldOptions(field, fieldLoc) {
+		return false
+	}
+
+	if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_GROUP {
+		// Since a group declares both a message type and a field,
+		// their locations overlap.
+		groupLoc := p.location(parentLoc, nestedTag, int32(len(*messages)))
+		groupLoc.loc.Span[0], groupLoc.loc.Span[1] = fieldLoc.loc.Span[0], fieldLoc.loc.Span[1]
+		defer groupLoc.end()
+		group := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+		*messages = append(*messages, group)
+
+		loc := p.location(groupLoc, messageNameTag)
+		loc.startAt(nameTok)
+		loc.endAt(nameTok)
+		loc.recordLegacy(group, locName)
+
+		loc = p.location(fieldLoc, fieldTypeNameTag)
+		loc.startAt(nameTok)
+		loc.endAt(nameTok)
+		loc.recordLegacy(field, locType)
+
+		// The group name must start with a capital letter,
+		// and the field name is its lower-case form.
+		if name[0] < 'A' || 'Z' < name[0] {
+			p.errs.add(p.file, nameTok.start, "Group names must start with a capital letter.")
+		}
+		field.Name = proto.String(strings.ToLower(name))
+		field.TypeName = proto.String(name)
+		if !p.lookingAt("{") {
+			p.errorf("Missing group body.")
+			return false
+		}
+		if !p.parseMessageBlock(group, groupLoc) {
+			return false
+		}
+	} else if !p.consumeEndOfDeclaration(";", fieldLoc) {
+		return false
+	}
+
+	if mf != nil {
+		generateMapEntry(mf, field, messages)
+	}
+	return true
+}
+
+// parseFieldType parses the type of a field, or the key and value types of
+// a map field, which it stores in *mf.
+func (p *parser) parseFieldType(field *descriptorpb.FieldDescriptorProto, fieldLoc *locationRecorder, mf **mapField) bool {
+	loc := p.location(fieldLoc) // the path is added below
+	defer loc.end()
+	loc.recordLegacy(field, locType)
+
+	typeParsed := false
+	var typ descriptorpb.FieldDescriptorProto_Type
+	var typeName string
+
+	// A type named "map" is a map field only if followed by '<'.
+	if p.tryConsume("map") {
+		if p.lookingAt("<") {
+			*mf = new(mapField)
+		} else {
+			typeParsed = true
+			typeName = "map"
+		}
+	}
+	if m := *mf; m != nil {
+		switch {
+		case field.OneofIndex != nil:
+			p.errorf("Map fields are not allowed in oneofs.")
+			return false
+		case field.Label != nil:
+			p.errorf("Field labels (required/optional/repeated) are not allowed on map fields.")
+			return false
+		case field.Extendee != nil:
+			p.errorf("Map fields are not allowed to be extensions.")
+			return false
+		}
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		if !p.consume("<") ||
+			!p.parseType(&m.keyType, &m.keyTypeName) ||
+			!p.consume(",") ||
+			!p.parseType(&m.valueType, &m.valueTypeName) ||
+			!p.consume(">") {
+			return false
+		}
+		loc.addPath(fieldTypeNameTag)
+		return true
+	}
+
+	if field.Label == nil && p.syntax == "proto3" {
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	}
+	if field.Label == nil {
+		p.errorf("Expected \"required\", \"optional\", or \"repeated\".")
+		// Recover by assuming the label was forgotten.
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	}
+	if !typeParsed && !p.parseType(&typ, &typeName) {
+		return false
+	}
+	if typeName == "" {
+		loc.addPath(fieldTypeTag)
+		field.Type = typ.Enum()
+	} else {
+		loc.addPath(fieldTypeNameTag)
+		field.TypeName = proto.String(typeName)
+	}
+	return true
+}
+
+func (p *parser) parseType(typ *descriptorpb.FieldDescriptorProto_Type, typeName *string) bool {
+	if t, ok := scalarTypes[p.cur().text]; ok && p.cur().kind == tokenIdent {
+		*typ = t
+		p.next()
+		return true
+	}
+	return p.parseUserDefinedType(typeName)
+}
+
+func (p *parser) parseUserDefinedType(typeName *string) bool {
+	if _, ok := scalarTypes[p.cur().text]; ok && p.cur().kind == tokenIdent {
+		p.errorf("Expected message type.")
+		// Pretend to accept the type to go on parsing.
+		*typeName = p.cur().text
+		p.next()
+		return true
+	}
+	var name string
+	if p.tryConsume(".") {
+		name = "."
+	}
+	ident, ok := p.consumeIdentifier("Expected type name.")
+	if !ok {
+		return false
+	}
+	name += ident
+	for p.tryConsume(".") {
+		ident, ok := p.consumeIdentifier("Expected identifier.")
+		if !ok {
+			return false
+		}
+		name += "." + ident
+	}
+	*typeName = name
+	return true
+}
+
+func (p *parser) parseFieldOptions(field *descriptorpb.FieldDescriptorProto, fieldLoc *locationRecorder) bool {
+	if !p.lookingAt("[") {
+		return true
+	}
+	loc := p.location(fieldLoc, fieldOptionsTag)
+	defer loc.end()
+	p.next()
+	for {
+		var ok bool
+		switch {
+		case p.lookingAt("default"):
+			// The default value and JSON name are not actually options,
+			// and are recorded relative to the field.
+			ok = p.parseDefaultAssignment(field, fieldLoc)
+		case p.lookingAt("json_name"):
+			ok = p.parseJSONName(field, fieldLoc)
+		default:
+			if field.Options == nil {
+				field.Options = new(descriptorpb.FieldOptions)
+			}
+			ok = p.parseOption(&field.Options.UninterpretedOption, loc, optionAssignment)
+		}
+		if !ok {
+			return false
+		}
+		if !p.tryConsume(",") {
+			break
+		}
+	}
+	return p.consume("]")
+}
+
+func (p *parser) parseDefaultAssignment(field *descriptorpb.FieldDescriptorProto, fieldLoc *locationRecorder) bool {
+	if field.DefaultValue != nil {
+		p.errorf("Already set option \"default\".")
+		field.DefaultValue = nil
+	}
+	if !p.consume("default") || !p.consume("=") {
+		return false
+	}
+	loc := p.location(fieldLoc, fieldDefaultTag)
+	defer loc.end()
+	loc.recordLegacy(field, locDefaultValue)
+
+	if field.Type == nil {
+		// The field is of a message or enum type, which is not known yet.
+		// Take the token as the default value, which is checked when linking.
+		field.DefaultValue = proto.String(p.cur().text)
+		p.next()
+		return true
+	}
+
+	var def string
+	switch t := field.GetType(); t {
+	case descriptorpb.FieldDescriptorProto_TYPE_INT32,
+		descriptorpb.FieldDescriptorProto_TYPE_INT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_SINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_SFIXED64:
+		max := uint64(math.MaxInt64)
+		if t == descriptorpb.FieldDescriptorProto_TYPE_INT32 ||
+			t == descriptorpb.FieldDescriptorProto_TYPE_SINT32 ||
+			t == descriptorpb.FieldDescriptorProto_TYPE_SFIXED32 {
+			max = math.MaxInt32
+		}
+		if p.tryConsume("-") {
+			def = "-"
+			max++
+		}
+		v, ok := p.consumeInteger64(max, "Expected integer for field default value.")
+		if !ok {
+			return false
+		}
+		def += strconv.FormatUint(v, 10)
+	case descriptorpb.FieldDescriptorProto_TYPE_UINT32,
+		descriptorpb.FieldDescriptorProto_TYPE_UINT64,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED32,
+		descriptorpb.FieldDescriptorProto_TYPE_FIXED64:
+		max := uint64(math.MaxUint64)
+		if t == descriptorpb.FieldDescriptorProto_TYPE_UINT32 ||
+			t == descriptorpb.FieldDescriptorProto_TYPE_FIXED32 {
+			max = math.MaxUint32
+		}
+		if p.tryConsume("-") {
+			p.errorf("Unsigned field can't have negative default value.")
+		}
+		v, ok := p.consumeInteger64(max, "Expected integer for field default value.")
+		if !ok {
+			return false
+		}
+		def = strconv.FormatUint(v, 10)
+	case descriptorpb.FieldDescriptorProto_TYPE_FLOAT,
+		descriptorpb.FieldDescriptorProto_TYPE_DOUBLE:
+		if p.tryConsume("-") {
+			def = "-"
+		}
+		v, ok := p.consumeNumber("Expected number.")
+		if !ok {
+			return false
+		}
+		def += formatDouble(v)
+	case descriptorpb.FieldDescriptorProto_TYPE_BOOL:
+		switch {
+		case p.tryConsume("true"):
+			def = "true"
+		case p.tryConsume("false"):
+			def = "false"
+		default:
+			p.errorf("Expected \"true\" or \"false\".")
+			return false
+		}
+	case descriptorpb.FieldDescriptorProto_TYPE_STRING:
+		s, ok := p.consumeString("Expected string for field default value.")
+		if !ok {
+			return false
+		}
+		def = s
+	case descriptorpb.FieldDescriptorProto_TYPE_BYTES:
+		s, ok := p.consumeString("Expected string.")
+		if !ok {
+			return false
+		}
+		def = cEscape(s)
+	default:
+		p.errorf("Messages can't have default values.")
+		return false
+	}
+	field.DefaultValue = proto.String(def)
+	return true
+}
+
+func (p *parser) parseJSONName(field *descriptorpb.FieldDescriptorProto, fieldLoc *locationRecorder) bool {
+	if field.JsonName != nil {
+		p.errorf("Already set option \"json_name\".")
+		field.JsonName = nil
+	}
+	loc := p.location(fieldLoc, fieldJSONNameTag)
+	defer loc.end()
+	loc.recordLegacy(field, locOptionName)
+	if !p.consume("json_name") || !p.consume("=") {
+		return false
+	}
+	valueLoc := p.location(loc)
+	defer valueLoc.end()
+	valueLoc.recordLegacy(field, locOptionValue)
+	s, ok := p.consumeString("Expected string for JSON name.")
+	if !ok {
+		return false
+	}
+	field.JsonName = proto.String(s)
+	return true
+}
+
+func (p *parser) parseOneof(od *descriptorpb.OneofDescriptorProto, md *descriptorpb.DescriptorProto, index int32, oneofLoc, msgLoc *locationRecorder) bool {
+	if !p.consume("oneof") {
+		return false
+	}
+	loc := p.location(oneofLoc, oneofNameTag)
+	name, ok := p.consumeIdentifier("Expected oneof name.")
+	loc.end()
+	if !ok {
+		return false
+	}
+	od.Name = proto.String(name)
+	if !p.consumeEndOfDeclaration("{", oneofLoc) {
+		return false
+	}
+	for {
+		if p.atEnd() {
+			p.errorf("Reached end of input in oneof definition (missing '}').")
+			return false
+		}
+		if p.lookingAt("option") {
+			loc := p.location(oneofLoc, oneofOptionsTag)
+			if od.Options == nil {
+				od.Options = new(descriptorpb.OneofOptions)
+			}
+			ok := p.parseOption(&od.Options.UninterpretedOption, loc, optionStatement)
+			loc.end()
+			if !ok {
+				return false
+			}
+		} else {
+			if p.lookingAt("required") || p.lookingAt("optional") || p.lookingAt("repeated") {
+				p.errorf("Fields in oneofs must not have labels (required / optional / repeated).")
+				// Go on parsing: the intent is clear.
+				p.next()
+			}
+			loc := p.location(msgLoc, messageFieldTag, int32(len(md.Field)))
+			field := &descriptorpb.FieldDescriptorProto{
+				Label:      descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				OneofIndex: proto.Int32(index),
+			}
+			md.Field = append(md.Field, field)
+			ok := p.parseMessageFieldNoLabel(field, &md.NestedType, msgLoc, messageNestedTag, loc)
+			loc.end()
+			if !ok {
+				p.skipStatement()
+			}
+		}
+		if p.tryConsumeEndOfDeclaration("}", nil) {
+			return true
+		}
+	}
+}
+
+func (p *parser) parseExtensions(md *descriptorpb.DescriptorProto, extLoc *locationRecorder) bool {
+	if !p.consume("extensions") {
+		return false
+	}
+	first := len(md.ExtensionRange)
+	for {
+		loc := p.location(extLoc, int32(len(md.ExtensionRange)))
+		r := new(descriptorpb.DescriptorProto_ExtensionRange)
+		md.ExtensionRange = append(md.ExtensionRange, r)
+		loc.recordLegacy(r, locNumber)
+		start, end, ok := p.parseRange(loc, extensionRangeStartTag, extensionRangeEndTag, "Expected field number range.")
+		loc.end()
+		if !ok {
+			return false
+		}
+		r.Start, r.End = proto.Int32(start), proto.Int32(end)
+		if !p.tryConsume(",") {
+			break
+		}
+	}
+
+	if p.lookingAt("[") {
+		// The options apply to all the ranges of the statement.
+		// They are parsed into the first, then copied to the others
+		// along with their source locations.
+		mark := len(p.info.Location)
+		opts := new(descriptorpb.ExtensionRangeOptions)
+		md.ExtensionRange[first].Options = opts
+		indexLoc := p.location(extLoc, 0)
+		loc := p.location(indexLoc, extensionRangeOptionsTag)
+		p.next()
+		for {
+			if !p.parseOption(&opts.UninterpretedOption, loc, optionAssignment) {
+				return false
+			}
+			if !p.tryConsume(",") {
+				break
+			}
+		}
+		if !p.consume("]") {
+			return false
+		}
+		loc.end()
+		indexLoc.end()
+
+		locs := p.info.Location[mark:]
+		p.info.Location = p.info.Location[:mark]
+		index := len(extLoc.loc.Path)
+		for i := first; i < len(md.ExtensionRange); i++ {
+			if i > first {
+				md.ExtensionRange[i].Options = proto.Clone(opts).(*descriptorpb.ExtensionRangeOptions)
+			}
+			for _, l := range locs {
+				if len(l.Path) == index+1 {
+					continue // the location of the range itself
+				}
+				l = proto.Clone(l).(*descriptorpb.SourceCodeInfo_Location)
+				l.Path[index] = int32(i)
+				p.info.Location = append(p.info.Location, l)
+			}
+		}
+	}
+	return p.consumeEndOfDeclaration(";", extLoc)
+}
+
+// parseRange parses a range of field numbers, whose end is exclusive.
+func (p *parser) parseRange(loc *locationRecorder, startTag, endTag int32, msg string) (start, end int32, ok bool) {
+	startTok := p.cur()
+	l := p.location(loc, startTag)
+	start, ok = p.consumeInteger(msg)
+	l.end()
+	if !ok {
+		return 0, 0, false
+	}
+	if !p.tryConsume("to") {
+		l = p.location(loc, endTag)
+		l.startAt(startTok)
+		l.endAt(startTok)
+		return start, start + 1, true
+	}
+	l = p.location(loc, endTag)
+	defer l.end()
+	if p.tryConsume("max") {
+		return start, rangeMaxSentinel, true
+	}
+	end, ok = p.consumeInteger("Expected integer.")
+	return start, end + 1, ok
+}
+
+func (p *parser) parseReserved(md *descriptorpb.DescriptorProto, msgLoc *locationRecorder) bool {
+	startTok := p.cur()
+	if !p.consume("reserved") {
+		return false
+	}
+	if p.cur().kind == tokenString {
+		loc := p.location(msgLoc, messageReservedNameTag)
+		loc.startAt(startTok)
+		defer loc.end()
+		return p.parseReservedNames(&md.ReservedName, loc)
+	}
+	loc := p.location(msgLoc, messageReservedRangeTag)
+	loc.startAt(startTok)
+	defer loc.end()
+	msg := "Expected field name or number range."
+	for {
+		l := p.location(loc, int32(len(md.ReservedRange)))
+		r := new(descriptorpb.DescriptorProto_ReservedRange)
+		md.ReservedRange = append(md.ReservedRange, r)
+		start, end, ok := p.parseRange(l, 1, 2, msg)
+		l.end()
+		if !ok {
+			return false
+		}
+		r.Start, r.End = proto.Int32(start), proto.Int32(end)
+		msg = "Expected field number range."
+		if !p.tryConsume(",") {
+			break
+		}
+	}
+	return p.consumeEndOfDeclaration(";", loc)
+}
+
+func (p *parser) parseReservedNames(names *[]string, loc *locationRecorder) bool {
+	for {
+		l := p.location(loc, int32(len(*names)))
+		name, ok := p.consumeString("Expected field name.")
+		l.end()
+		if !ok {
+			return false
+		}
+		*names = append(*names, name)
+		if !p.tryConsume(",") {
+			break
+		}
+	}
+	return p.consumeEndOfDeclaration(";", loc)
+}
+
+func (p *parser) parseExtend(extensions *[]*descriptorpb.FieldDescriptorProto, messages *[]*descriptorpb.DescriptorProto, parentLoc *locationRecorder, nestedTag int32, extendLoc *locationRecorder) bool {
+	if !p.consume("extend") {
+		return false
+	}
+	startTok := p.cur()
+	var extendee string
+	if !p.parseUserDefinedType(&extendee) {
+		return false
+	}
+	endTok := &p.toks[p.i-1]
+	if !p.consumeEndOfDeclaration("{", extendLoc) {
+		return false
+	}
+	first := true
+	for {
+		if p.atEnd() {
+			p.errorf("Reached end of input in extend definition (missing '}').")
+			return false
+		}
+		loc := p.location(extendLoc, int32(len(*extensions)))
+		field := new(descriptorpb.FieldDescriptorProto)
+		*extensions = append(*extensions, field)
+
+		l := p.location(loc, fieldExtendeeTag)
+		l.startAt(startTok)
+		l.endAt(endTok)
+		if first {
+			l.recordLegacy(field, locExtendee)
+			first = false
+		}
+		field.Extendee = proto.String(extendee)
+
+		ok := p.parseMessageField(field, messages, parentLoc, nestedTag, loc)
+		loc.end()
+		if !ok {
+			p.skipStatement()
+		}
+		if p.tryConsumeEndOfDeclaration("}", nil) {
+			return true
+		}
+	}
+}
+
+func (p *parser) parseEnumDefinition(ed *descriptorpb.EnumDescriptorProto, enumLoc *locationRecorder) bool {
+	if !p.consume("enum") {
+		return false
+	}
+	loc := p.location(enumLoc, enumNameTag)
+	loc.recordLegacy(ed, locName)
+	name, ok := p.consumeIdentifier("Expected enum name.")
+	loc.end()
+	if !ok {
+		return false
+	}
+	ed.Name = proto.String(name)
+
+	if !p.consumeEndOfDeclaration("{", enumLoc) {
+		return false
+	}
+	for !p.tryConsumeEndOfDeclaration("}", nil) {
+		if p.atEnd() {
+			p.errorf("Reached end of input in enum definition (missing '}').")
+			return false
+		}
+		if !p.parseEnumStatement(ed, enumLoc) {
+			p.skipStatement()
+		}
+	}
+	return p.validateEnum(ed)
+}
+
+// validateEnum checks the use of the allow_alias option,
+// which protoc does when parsing.
+func (p *parser) validateEnum(ed *descriptorpb.EnumDescriptorProto) bool {
+	hasAllowAlias, allowAlias := false, false
+	for _, opt := range ed.GetOptions().GetUninterpretedOption() {
+		if len(opt.Name) > 1 {
+			continue
+		}
+		if !opt.Name[0].GetIsExtension() && opt.Name[0].GetNamePart() == "allow_alias" {
+			hasAllowAlias = true
+			allowAlias = opt.GetIdentifierValue() == "true"
+			break
+		}
+	}
+	if hasAllowAlias && !allowAlias {
+		p.errorf("\"%s\" declares 'option allow_alias = false;' which has no effect. Please remove the declaration.", ed.GetName())
+		return false
+	}
+	if allowAlias {
+		seen := make(map[int32]bool)
+		for _, v := range ed.Value {
+			if seen[v.GetNumber()] {
+				return true
+			}
+			seen[v.GetNumber()] = true
+		}
+		p.errorf("\"%s\" declares support for enum aliases but no enum values share field numbers. Please remove the unnecessary 'option allow_alias = true;' declaration.", ed.GetName())
+		return false
+	}
+	return true
+}
+
+func (p *parser) parseEnumStatement(ed *descriptorpb.EnumDescriptorProto, enumLoc *locationRecorder) bool {
+	switch {
+	case p.tryConsumeEndOfDeclaration(";", nil):
+		return true
+	case p.lookingAt("option"):
+		loc := p.location(enumLoc, enumOptionsTag)
+		defer loc.end()
+		if ed.Options == nil {
+			ed.Options = new(descriptorpb.EnumOptions)
+		}
+		return p.parseOption(&ed.Options.UninterpretedOption, loc, optionStatement)
+	case p.lookingAt("reserved"):
+		return p.parseEnumReserved(ed, enumLoc)
+	}
+	loc := p.location(enumLoc, enumValueTag, int32(len(ed.Value)))
+	defer loc.end()
+	vd := new(descriptorpb.EnumValueDescriptorProto)
+	ed.Value = append(ed.Value, vd)
+	return p.parseEnumConstant(vd, loc)
+}
+
+func (p *parser) parseEnumConstant(vd *descriptorpb.EnumValueDescriptorProto, valueLoc *locationRecorder) bool {
+	loc := p.location(valueLoc, enumValueNameTag)
+	loc.recordLegacy(vd, locName)
+	name, ok := p.consumeIdentifier("Expected enum constant name.")
+	loc.end()
+	if !ok {
+		return false
+	}
+	vd.Name = proto.String(name)
+	if !p.consume("=", "Missing numeric value for enum constant.") {
+		return false
+	}
+
+	loc = p.location(valueLoc, enumValueNumberTag)
+	loc.recordLegacy(vd, locNumber)
+	number, ok := p.consumeSignedInteger("Expected integer.")
+	loc.end()
+	if !ok {
+		return false
+	}
+	vd.Number = proto.Int32(number)
+
+	if p.lookingAt("[") {
+		loc := p.location(valueLoc, enumValueOptionsTag)
+		p.next()
+		vd.Options = new(descriptorpb.EnumValueOptions)
+		for {
+			if !p.parseOption(&vd.Options.UninterpretedOption, loc, optionAssignment) {
+				loc.end()
+				return false
+			}
+			if !p.tryConsume(",") {
+				break
+			}
+		}
+		ok := p.consume("]")
+		loc.end()
+		if !ok {
+			return false
+		}
+	}
+	return p.consumeEndOfDeclaration(";", valueLoc)
+}
+
+func (p *parser) parseEnumReserved(ed *descriptorpb.EnumDescriptorProto, enumLoc *locationRecorder) bool {
+	startTok := p.cur()
+	if !p.consume("reserved") {
+		return false
+	}
+	if p.cur().kind == tokenString {
+		loc := p.location(enumLoc, enumReservedNameTag)
+		loc.startAt(startTok)
+		defer loc.end()
+		return p.parseReservedNames(&ed.ReservedName, loc)
+	}
+	loc := p.location(enumLoc, enumReservedRangeTag)
+	loc.startAt(startTok)
+	defer loc.end()
+	msg := "Expected enum value or number range."
+	for {
+		l := p.location(loc, int32(len(ed.ReservedRange)))
+		r := new(descriptorpb.EnumDescriptorProto_EnumReservedRange)
+		ed.ReservedRange = append(ed.ReservedRange, r)
+
+		// Enum reserved ranges are inclusive, and may be negative.
+		startTok := p.cur()
+		sl := p.location(l, 1)
+		start, ok := p.consumeSignedInteger(msg)
+		sl.end()
+		if !ok {
+			l.end()
+			return false
+		}
+		end := start
+		if p.tryConsume("to") {
+			el := p.location(l, 2)
+			if p.tryConsume("max") {
+				end = math.MaxInt32
+			} else {
+				end, ok = p.consumeSignedInteger("Expected integer.")
+			}
+			el.end()
+		} else {
+			el := p.location(l, 2)
+			el.startAt(startTok)
+			el.endAt(startTok)
+		}
+		l.end()
+		if !ok {
+			return false
+		}
+		r.Start, r.End = proto.Int32(start), proto.Int32(end)
+		msg = "Expected enum number range."
+		if !p.tryConsume(",") {
+			break
+		}
+	}
+	return p.consumeEndOfDeclaration(";", loc)
+}
+
+func (p *parser) parseServiceDefinition(sd *descriptorpb.ServiceDescriptorProto, serviceLoc *locationRecorder) bool {
+	if !p.consume("service") {
+		return false
+	}
+	loc := p.location(serviceLoc, serviceNameTag)
+	loc.recordLegacy(sd, locName)
+	name, ok := p.consumeIdentifier("Expected service name.")
+	loc.end()
+	if !ok {
+		return false
+	}
+	sd.Name = proto.String(name)
+
+	if !p.consumeEndOfDeclaration("{", serviceLoc) {
+		return false
+	}
+	for !p.tryConsumeEndOfDeclaration("}", nil) {
+		if p.atEnd() {
+			p.errorf("Reached end of input in service definition (missing '}').")
+			return false
+		}
+		if !p.parseServiceStatement(sd, serviceLoc) {
+			p.skipStatement()
+		}
+	}
+	return true
+}
+
+func (p *parser) parseServiceStatement(sd *descriptorpb.ServiceDescriptorProto, serviceLoc *locationRecorder) bool {
+	switch {
+	case p.tryConsumeEndOfDeclaration(";", nil):
+		return true
+	case p.lookingAt("option"):
+		loc := p.location(serviceLoc, serviceOptionsTag)
+		defer loc.end()
+		if sd.Options == nil {
+			sd.Options = new(descriptorpb.ServiceOptions)
+		}
+		return p.parseOption(&sd.Options.UninterpretedOption, loc, optionStatement)
+	}
+	loc := p.location(serviceLoc, serviceMethodTag, int32(len(sd.Method)))
+	defer loc.end()
+	md := new(descriptorpb.MethodDescriptorProto)
+	sd.Method = append(sd.Method, md)
+	return p.parseServiceMethod(md, loc)
+}
+
+func (p *parser) parseServiceMethod(md *descriptorpb.MethodDescriptorProto, methodLoc *locationRecorder) bool {
+	if !p.consume("rpc") {
+		return false
+	}
+	loc := p.location(methodLoc, methodNameTag)
+	loc.recordLegacy(md, locName)
+	name, ok := p.consumeIdentifier("Expected method name.")
+	loc.end()
+	if !ok {
+		return false
+	}
+	md.Name = proto.String(name)
+
+	if !p.consume("(") {
+		return false
+	}
+	if p.lookingAt("stream") {
+		loc := p.location(methodLoc, methodClientStreamingTag)
+		loc.recordLegacy(md, locOther)
+		md.ClientStreaming = proto.Bool(true)
+		p.next()
+		loc.end()
+	}
+	loc = p.location(methodLoc, methodInputTag)
+	loc.recordLegacy(md, locInputType)
+	var input string
+	ok = p.parseUserDefinedType(&input)
+	loc.end()
+	if !ok {
+		return false
+	}
+	md.InputType = proto.String(input)
+	if !p.consume(")") {
+		return false
+	}
+
+	if !p.consume("returns") || !p.consume("(") {
+		return false
+	}
+	if p.lookingAt("stream") {
+		loc := p.location(methodLoc, methodServerStreamingTag)
+		loc.recordLegacy(md, locOther)
+		p.next()
+		loc.end()
+		md.ServerStreaming = proto.Bool(true)
+	}
+	loc = p.location(methodLoc, methodOutputTag)
+	loc.recordLegacy(md, locOutputType)
+	var output string
+	ok = p.parseUserDefinedType(&output)
+	loc.end()
+	if !ok {
+		return false
+	}
+	md.OutputType = proto.String(output)
+	if !p.consume(")") {
+		return false
+	}
+
+	if !p.lookingAt("{") {
+		return p.consumeEndOfDeclaration(";", methodLoc)
+	}
+	md.Options = new(descriptorpb.MethodOptions)
+	p.consumeEndOfDeclaration("{", methodLoc)
+	for !p.tryConsumeEndOfDeclaration("}", nil) {
+		if p.atEnd() {
+			p.errorf("Reached end of input in method options (missing '}').")
+			return false
+		}
+		if p.tryConsumeEndOfDeclaration(";", nil) {
+			continue
+		}
+		loc := p.location(methodLoc, methodOptionsTag)
+		ok := p.parseOption(&md.Options.UninterpretedOption, loc, optionStatement)
+		loc.end()
+		if !ok {
+			p.skipStatement()
+		}
+	}
+	return true
+}
+
+// generateMapEntry adds the entry message of a map field.
+func generateMapEntry(mf *mapField, field *descriptorpb.FieldDescriptorProto, messages *[]*descriptorpb.DescriptorProto) {
+	name := mapEntryName(field.GetName())
+	field.TypeName = proto.String(name)
+	key := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String("key"),
+		Number: proto.Int32(1),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if mf.keyTypeName == "" {
+		key.Type = mf.keyType.Enum()
+	} else {
+		key.TypeName = proto.String(mf.keyTypeName)
+	}
+	value := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String("value"),
+		Number: proto.Int32(2),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+	}
+	if mf.valueTypeName == "" {
+		value.Type = mf.valueType.Enum()
+	} else {
+		value.TypeName = proto.String(mf.valueTypeName)
+	}
+	*messages = append(*messages, &descriptorpb.DescriptorProto{
+		Name:    proto.String(name),
+		Field:   []*descriptorpb.FieldDescriptorProto{key, value},
+		Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+	})
+}
+
+// mapEntryName returns the name of the entry message of a map field.
+func mapEntryName(field string) string {
+	var b []byte
+	capNext := true
+	for i := 0; i < len(field); i++ {
+		c := field[i]
+		switch {
+		case c == '_':
+			capNext = true
+		case capNext:
+			if 'a' <= c && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			capNext = false
+		default:
+			b = append(b, c)
+		}
+	}
+	return string(b) + "Entry"
+}
+
+// generateSyntheticOneofs adds a oneof for each proto3 optional field,
+// with a name that conflicts with no other field or oneof.
+func generateSyntheticOneofs(md *descriptorpb.DescriptorProto) {
+	names := make(map[string]bool)
+	for _, f := range md.Field {
+		names[f.GetName()] = true
+	}
+	for _, o := range md.OneofDecl {
+		names[o.GetName()] = true
+	}
+	for _, f := range md.Field {
+		if !f.GetProto3Optional() {
+			continue
+		}
+		name := f.GetName()
+		if !strings.HasPrefix(name, "_") {
+			name = "_" + name
+		}
+		for names[name] {
+			name = "X" + name
+		}
+		names[name] = true
+		f.OneofIndex = proto.Int32(int32(len(md.OneofDecl)))
+		md.OneofDecl = append(md.OneofDecl, &descriptorpb.OneofDescriptorProto{Name: proto.String(name)})
+	}
+}
+
+// formatDouble formats a double as protoc's SimpleDtoa does.
+func formatDouble(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	case math.IsNaN(v):
+		return "nan"
+	}
+	s := formatG(v, 15, 64)
+	if f, _ := strconv.ParseFloat(s, 64); f != v {
+		s = formatG(v, 17, 64)
+	}
+	return s
+}
+
+// formatFloat formats a float as protoc's SimpleFtoa does.
+func formatFloat(v float32) string {
+	switch {
+	case math.IsInf(float64(v), 1):
+		return "inf"
+	case math.IsInf(float64(v), -1):
+		return "-inf"
+	case math.IsNaN(float64(v)):
+		return "nan"
+	}
+	s := formatG(float64(v), 6, 32)
+	if f, _ := strconv.ParseFloat(s, 32); float32(f) != v {
+		s = formatG(float64(v), 9, 32)
+	}
+	return s
+}
+
+// formatG formats v as C's printf does for "%.*g".
+func formatG(v float64, prec, bitSize int) string {
+	s := strconv.FormatFloat(v, 'g', prec, bitSize)
+	if i := strings.IndexByte(s, 'e'); i >= 0 {
+		// C prints at least two exponent digits, as Go does, but
+		// removes trailing zeros from the mantissa.
+		m, exp := s[:i], s[i:]
+		if strings.Contains(m, ".") {
+			m = strings.TrimRight(strings.TrimRight(m, "0"), ".")
+		}
+		return m + exp
+	}
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// cEscape escapes a string as protoc's CEscape does.
+func cEscape(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\n':
+			b = append(b, `\n`...)
+		case '\r':
+			b = append(b, `\r`...)
+		case '\t':
+			b = append(b, `\t`...)
+		case '"':
+			b = append(b, `\"`...)
+		case '\'':
+			b = append(b, `\'`...)
+		case '\\':
+			b = append(b, `\\`...)
+		default:
+			if c < 0x20 || c >= 0x7f {
+				b = append(b, '\\', '0'+c>>6, '0'+(c>>3)&7, '0'+c&7)
+			} else {
+				b = append(b, c)
+			}
+		}
+	}
+	return string(b)
+}
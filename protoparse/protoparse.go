@@ -0,0 +1,327 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package protoparse parses .proto source files into descriptors,
+// without running protoc.
+//
+// A Parser reads source files from a set of import paths, as protoc's
+// --proto_path flag, links the types they reference, interprets their
+// options and validates them. The descriptors it produces are those
+// protoc passes to plugins: the names of referenced types are fully
+// qualified, every field has a JSON name, and the source code info
+// holds the locations and comments of the declarations.
+//
+// Errors are reported in protoc's format and, where possible,
+// with protoc's messages:
+//
+//	foo.proto:12:3: "Bar" is not defined.
+//
+// Imports not found in the import paths are taken from the files
+// linked into the program, as registered in protoregistry.GlobalFiles.
+// These include descriptor.proto, plugin.proto and the well-known types,
+// such as google/protobuf/any.proto.
+package protoparse
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+
+	// The well-known types, which may be imported by any file.
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/apipb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+	_ "google.golang.org/protobuf/types/known/sourcecontextpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/typepb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// A Parser parses .proto source files.
+type Parser struct {
+	// ImportPaths are the directories searched, in order, for the files
+	// to parse and the files they import. Files are named by their path
+	// relative to an import path. If ImportPaths is empty, files are
+	// searched for in the current directory.
+	ImportPaths []string
+
+	// Accessor, if not nil, opens the source files instead of os.Open.
+	// It is passed the path of a file joined to an import path,
+	// and returns an error satisfying os.IsNotExist if there is no such file.
+	Accessor func(filename string) (io.ReadCloser, error)
+
+	// IncludeSourceCodeInfo reports whether the descriptors hold
+	// the source code info of the files, as protoc --include_source_info.
+	IncludeSourceCodeInfo bool
+}
+
+// ParseFiles parses the named files and returns their descriptors.
+func (p *Parser) ParseFiles(filenames ...string) ([]*descriptorpb.FileDescriptorProto, error) {
+	l, err := p.load(filenames)
+	if err != nil {
+		return nil, err
+	}
+	var fds []*descriptorpb.FileDescriptorProto
+	for _, name := range filenames {
+		fds = append(fds, p.output(l.files[name].fd))
+	}
+	return fds, nil
+}
+
+// ParseFileSet parses the named files and returns the descriptors of
+// the files and all the files they import, with every file preceding
+// the files importing it, as protoc --include_imports.
+func (p *Parser) ParseFileSet(filenames ...string) (*descriptorpb.FileDescriptorSet, error) {
+	l, err := p.load(filenames)
+	if err != nil {
+		return nil, err
+	}
+	set := new(descriptorpb.FileDescriptorSet)
+	for _, f := range l.order {
+		set.File = append(set.File, p.output(f.fd))
+	}
+	return set, nil
+}
+
+// ParseFileDescriptors parses the named files and returns their descriptors,
+// which may be used to create messages with the dynamicpb package.
+func (p *Parser) ParseFileDescriptors(filenames ...string) ([]protoreflect.FileDescriptor, error) {
+	l, err := p.load(filenames)
+	if err != nil {
+		return nil, err
+	}
+	var fds []protoreflect.FileDescriptor
+	for _, name := range filenames {
+		fds = append(fds, l.files[name].desc)
+	}
+	return fds, nil
+}
+
+// CodeGeneratorRequest parses the named files and returns the request
+// protoc sends to a plugin to generate code for them. The request holds
+// the source code info of the files, whatever IncludeSourceCodeInfo is.
+func (p *Parser) CodeGeneratorRequest(parameter string, filenames ...string) (*plugin.CodeGeneratorRequest, error) {
+	q := *p
+	q.IncludeSourceCodeInfo = true
+	set, err := q.ParseFileSet(filenames...)
+	if err != nil {
+		return nil, err
+	}
+	req := &plugin.CodeGeneratorRequest{
+		FileToGenerate: filenames,
+		ProtoFile:      set.File,
+	}
+	if parameter != "" {
+		req.Parameter = proto.String(parameter)
+	}
+	return req, nil
+}
+
+func (p *Parser) output(fd *descriptorpb.FileDescriptorProto) *descriptorpb.FileDescriptorProto {
+	fd = proto.Clone(fd).(*descriptorpb.FileDescriptorProto)
+	if !p.IncludeSourceCodeInfo {
+		fd.SourceCodeInfo = nil
+	}
+	return fd
+}
+
+// open returns the source of a file, or ok == false if it is not found
+// in the import paths.
+func (p *Parser) open(name string) (src string, ok bool, err error) {
+	open := p.Accessor
+	if open == nil {
+		open = func(name string) (io.ReadCloser, error) { return os.Open(name) }
+	}
+	dirs := p.ImportPaths
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	for _, dir := range dirs {
+		r, err := open(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", false, err
+		}
+		b, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			return "", false, err
+		}
+		return string(b), true, nil
+	}
+	return "", false, nil
+}
+
+// An Error is an error in a source file.
+type Error struct {
+	Filename string
+	Line     int // one-based; zero if the position is unknown
+	Column   int // one-based
+	Message  string
+}
+
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.Filename, e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.Filename, e.Line, e.Column, e.Message)
+}
+
+// An ErrorList is the list of errors returned when parsing fails,
+// in the order protoc reports them.
+type ErrorList []*Error
+
+func (l ErrorList) Error() string {
+	var s []string
+	for _, e := range l {
+		s = append(s, e.Error())
+	}
+	return strings.Join(s, "\n")
+}
+
+// noPosition is the position of errors whose position is unknown.
+var noPosition = position{-1, -1}
+
+// errorList collects the errors found when parsing.
+type errorList struct {
+	errs ErrorList
+}
+
+func (l *errorList) add(file string, pos position, format string, args ...interface{}) {
+	l.errs = append(l.errs, &Error{
+		Filename: file,
+		Line:     pos.line + 1,
+		Column:   pos.col + 1,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *errorList) len() int {
+	return len(l.errs)
+}
+
+func (l *errorList) err() error {
+	if len(l.errs) == 0 {
+		return nil
+	}
+	return l.errs
+}
+
+// fileState is the state of a file being loaded.
+type fileState struct {
+	name   string
+	parsed *parsedFile // nil for files linked into the program
+	fd     *descriptorpb.FileDescriptorProto
+	desc   protoreflect.FileDescriptor
+	deps   []*fileState // nil for imports which had errors
+
+	building bool
+	failed   bool
+}
+
+// loader loads files and their imports, linking them in a single
+// namespace of symbols, as protoc's DescriptorPool.
+type loader struct {
+	p       *Parser
+	errs    *errorList
+	files   map[string]*fileState
+	order   []*fileState
+	pending []string // files being built, each importing the next
+	symbols map[string]*symbol
+	reg     *protoregistry.Files
+}
+
+func (p *Parser) load(filenames []string) (*loader, error) {
+	l := &loader{
+		p:       p,
+		errs:    new(errorList),
+		files:   make(map[string]*fileState),
+		symbols: make(map[string]*symbol),
+		reg:     new(protoregistry.Files),
+	}
+	for _, name := range filenames {
+		l.load(name)
+	}
+	if err := l.errs.err(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// load loads a file, returning nil if it is not found or has errors.
+func (l *loader) load(name string) *fileState {
+	if f, ok := l.files[name]; ok {
+		if f.building {
+			for i, pending := range l.pending {
+				if pending == name {
+					chain := append(append([]string(nil), l.pending[i:]...), name)
+					l.errs.add(name, f.parsed.position(f.fd, locOther), "File recursively imports itself: %s", strings.Join(chain, " -> "))
+					break
+				}
+			}
+			return nil
+		}
+		if f.failed {
+			return nil
+		}
+		return f
+	}
+
+	f := &fileState{name: name}
+	l.files[name] = f
+	src, ok, err := l.p.open(name)
+	switch {
+	case err != nil:
+		l.errs.add(name, noPosition, "%v", err)
+		f.failed = true
+		return nil
+	case ok:
+		f.parsed = parse(name, src, l.errs)
+		if f.parsed == nil {
+			f.failed = true
+			return nil
+		}
+		f.fd = f.parsed.fd
+	default:
+		desc, err := protoregistry.GlobalFiles.FindFileByPath(name)
+		if err != nil {
+			l.errs.add(name, noPosition, "File not found.")
+			f.failed = true
+			return nil
+		}
+		f.desc = desc
+		f.fd = protodesc.ToFileDescriptorProto(desc)
+	}
+
+	f.building = true
+	l.pending = append(l.pending, name)
+	for _, dep := range f.fd.Dependency {
+		f.deps = append(f.deps, l.load(dep))
+	}
+	l.pending = l.pending[:len(l.pending)-1]
+	f.building = false
+
+	if !l.build(f) {
+		f.failed = true
+		return nil
+	}
+	l.order = append(l.order, f)
+	return f
+}
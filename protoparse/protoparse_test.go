@@ -0,0 +1,388 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package protoparse_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoparse"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	_ "github.com/golang/protobuf/internal/testprotos/jsonpb_proto"
+	_ "github.com/golang/protobuf/internal/testprotos/proto2_proto"
+	_ "github.com/golang/protobuf/internal/testprotos/proto3_proto"
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// protocOutput returns the descriptor protoc produced for a file
+// registered by its generated package.
+func protocOutput(t *testing.T, name string) *descpb.FileDescriptorProto {
+	r, err := gzip.NewReader(bytes.NewReader(proto.FileDescriptor(name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := new(descpb.FileDescriptorProto)
+	if err := proto.Unmarshal(b, fd); err != nil {
+		t.Fatal(err)
+	}
+	return fd
+}
+
+func TestParseMatchesProtoc(t *testing.T) {
+	for _, name := range []string{
+		"proto2_proto/test.proto",
+		"proto3_proto/test.proto",
+		"jsonpb_proto/test2.proto",
+		"jsonpb_proto/test3.proto",
+	} {
+		p := &protoparse.Parser{ImportPaths: []string{"../internal/testprotos"}}
+		fds, err := p.ParseFiles(name)
+		if err != nil {
+			t.Errorf("ParseFiles(%q): %v", name, err)
+			continue
+		}
+		if diff := cmp.Diff(protocOutput(t, name), fds[0], protocmp.Transform()); diff != "" {
+			t.Errorf("ParseFiles(%q) mismatch (-protoc +got):\n%s", name, diff)
+		}
+	}
+}
+
+// memory returns an accessor reading the given files.
+func memory(files map[string]string) func(string) (io.ReadCloser, error) {
+	return func(name string) (io.ReadCloser, error) {
+		src, ok := files[filepath.ToSlash(name)]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return ioutil.NopCloser(strings.NewReader(src)), nil
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{{
+		name: "syntax error",
+		files: map[string]string{"a.proto": `
+syntax = "proto3";
+message A {
+  int32 a = 1
+}`},
+		want: `a.proto:5:1: Expected ";".`,
+	}, {
+		name: "undefined type",
+		files: map[string]string{"a.proto": `syntax = "proto3";
+package p;
+message A {
+  B b = 1;
+}`},
+		want: `a.proto:4:3: "B" is not defined.`,
+	}, {
+		name: "not imported",
+		files: map[string]string{
+			"a.proto": `syntax = "proto3";
+package p;
+import "b.proto";
+message A {
+  q.C c = 1;
+}`,
+			"b.proto": `syntax = "proto3";
+package p;
+import "c.proto";`,
+			"c.proto": `syntax = "proto3";
+package q;
+message C {}`,
+		},
+		want: `a.proto:5:3: "q.C" seems to be defined in "c.proto", which is not imported by "a.proto".  To use it here, please add the necessary import.`,
+	}, {
+		name: "innermost scope",
+		files: map[string]string{"a.proto": `syntax = "proto2";
+package foo.bar;
+message foo {}
+message A {
+  optional foo.Baz baz = 1;
+}
+message Baz {}`},
+		want: `a.proto:5:12: "foo.Baz" is resolved to "foo.bar.foo.Baz", which is not defined. The innermost scope is searched first in name resolution. Consider using a leading '.'(i.e., ".foo.Baz") to start from the outermost scope.`,
+	}, {
+		name: "duplicate field number",
+		files: map[string]string{"a.proto": `syntax = "proto3";
+message A {
+  int32 a = 1;
+  int32 b = 1;
+}`},
+		want: `a.proto:4:13: Field number 1 has already been used in "A" by field "a".`,
+	}, {
+		name: "duplicate name",
+		files: map[string]string{"a.proto": `syntax = "proto3";
+package p;
+message A {}
+message A {}`},
+		want: `a.proto:4:9: "A" is already defined in "p".`,
+	}, {
+		name: "enum value scope",
+		files: map[string]string{"a.proto": `syntax = "proto3";
+enum E { X = 0; }
+enum F { X = 0; }`},
+		want: "a.proto:3:10: \"X\" is already defined.\n" +
+			`a.proto:3:10: Note that enum values use C++ scoping rules, meaning that enum values are siblings of their type, not children of it.  Therefore, "X" must be unique within the global scope, not just within "F".`,
+	}, {
+		name: "proto3 first enum value",
+		files: map[string]string{"a.proto": `syntax = "proto3";
+enum E { X = 1; }`},
+		want: `a.proto:2:14: The first enum value must be zero in proto3.`,
+	}, {
+		name: "reserved number",
+		files: map[string]string{"a.proto": `syntax = "proto2";
+message A {
+  reserved 2 to 4;
+  optional int32 a = 3;
+}`},
+		want: `a.proto:4:22: Field "a" uses reserved number 3.`,
+	}, {
+		name: "import not found",
+		files: map[string]string{"a.proto": `syntax = "proto3";
+import "missing.proto";`},
+		want: "missing.proto: File not found.\n" +
+			`a.proto:2:1: Import "missing.proto" was not found or had errors.`,
+	}, {
+		name: "recursive import",
+		files: map[string]string{
+			"a.proto": `syntax = "proto3";
+import "b.proto";`,
+			"b.proto": `syntax = "proto3";
+import "a.proto";`,
+		},
+		want: "a.proto:1:1: File recursively imports itself: a.proto -> b.proto -> a.proto\n" +
+			`b.proto:2:1: Import "a.proto" was not found or had errors.` + "\n" +
+			`a.proto:2:1: Import "b.proto" was not found or had errors.`,
+	}, {
+		name: "unknown option",
+		files: map[string]string{"a.proto": `syntax = "proto3";
+option (foo) = 1;`},
+		want: `a.proto:2:8: Option "(foo)" unknown. Ensure that your proto definition file imports the proto which defines the option.`,
+	}, {
+		name: "option value",
+		files: map[string]string{"a.proto": `syntax = "proto3";
+option optimize_for = FAST;`},
+		want: `a.proto:2:23: Enum type "google.protobuf.FileOptions.OptimizeMode" has no value named "FAST" for option "google.protobuf.FileOptions.optimize_for".`,
+	}, {
+		name: "option set twice",
+		files: map[string]string{"a.proto": `syntax = "proto3";
+option go_package = "a";
+option go_package = "b";`},
+		want: `a.proto:3:8: Option "go_package" was already set.`,
+	}, {
+		name: "packed",
+		files: map[string]string{"a.proto": `syntax = "proto2";
+message A {
+  optional string a = 1 [packed = true];
+}`},
+		want: `a.proto:3:12: [packed = true] can only be specified for repeated primitive fields.`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &protoparse.Parser{Accessor: memory(tt.files)}
+			_, err := p.ParseFiles("a.proto")
+			if err == nil {
+				t.Fatal("ParseFiles succeeded, want error")
+			}
+			if diff := cmp.Diff(tt.want, err.Error()); diff != "" {
+				t.Errorf("error mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCustomOptions(t *testing.T) {
+	files := map[string]string{
+		"opts.proto": `syntax = "proto2";
+package opts;
+import "google/protobuf/descriptor.proto";
+message Rule {
+  optional string name = 1;
+  repeated int32 codes = 2;
+}
+extend google.protobuf.MessageOptions {
+  optional Rule rule = 50000;
+  repeated string tags = 50001;
+}
+extend google.protobuf.FieldOptions {
+  optional sint32 weight = 50000;
+}`,
+		"a.proto": `syntax = "proto3";
+package opts.test;
+import "opts.proto";
+message A {
+  option (rule) = { name: "a" codes: [1, 2] };
+  option (tags) = "x";
+  option (opts.tags) = "y";
+  int32 f = 1 [(weight) = -3, deprecated = true];
+}`,
+	}
+	p := &protoparse.Parser{Accessor: memory(files), IncludeSourceCodeInfo: true}
+	fds, err := p.ParseFiles("a.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := fds[0].MessageType[0]
+	if got := md.GetOptions().GetUninterpretedOption(); len(got) != 0 {
+		t.Errorf("uninterpreted options remain: %v", got)
+	}
+	if !md.Field[0].GetOptions().GetDeprecated() {
+		t.Errorf("field option deprecated not set")
+	}
+
+	// Custom options are left as unknown fields, as in protoc's output.
+	var rule []byte
+	rule = protowire.AppendTag(rule, 1, protowire.BytesType)
+	rule = protowire.AppendString(rule, "a")
+	rule = protowire.AppendTag(rule, 2, protowire.VarintType)
+	rule = protowire.AppendVarint(rule, 1)
+	rule = protowire.AppendTag(rule, 2, protowire.VarintType)
+	rule = protowire.AppendVarint(rule, 2)
+	var want []byte
+	want = protowire.AppendTag(want, 50000, protowire.BytesType)
+	want = protowire.AppendBytes(want, rule)
+	want = protowire.AppendTag(want, 50001, protowire.BytesType)
+	want = protowire.AppendString(want, "x")
+	want = protowire.AppendTag(want, 50001, protowire.BytesType)
+	want = protowire.AppendString(want, "y")
+	if got := proto.MessageReflect(md.Options).GetUnknown(); !bytes.Equal(got, want) {
+		t.Errorf("message options = %x, want %x", got, want)
+	}
+	var weight []byte
+	weight = protowire.AppendTag(weight, 50000, protowire.VarintType)
+	weight = protowire.AppendVarint(weight, protowire.EncodeZigZag(-3))
+	if got := proto.MessageReflect(md.Field[0].Options).GetUnknown(); !bytes.Equal(got, weight) {
+		t.Errorf("field options = %x, want %x", got, weight)
+	}
+
+	// The locations of the options are those of the fields they set.
+	var paths []string
+	for _, loc := range fds[0].GetSourceCodeInfo().GetLocation() {
+		if len(loc.Path) > 3 && loc.Path[0] == 4 && loc.Path[2] == 7 {
+			paths = append(paths, fmt.Sprint(loc.Path))
+		}
+	}
+	wantPaths := []string{"[4 0 7 50000]", "[4 0 7 50001 0]", "[4 0 7 50001 1]"}
+	if diff := cmp.Diff(wantPaths, paths); diff != "" {
+		t.Errorf("option locations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAggregateNegativeNumbers(t *testing.T) {
+	files := map[string]string{
+		"a.proto": `syntax = "proto2";
+import "google/protobuf/descriptor.proto";
+message Range {
+  optional sint64 lo = 1;
+  optional double f = 2;
+  repeated int32 vs = 3;
+}
+extend google.protobuf.MessageOptions {
+  optional Range r = 50000;
+}
+message A {
+  option (r) = { lo: -5 f: -1.5 vs: [-1, 2] };
+}`,
+	}
+	p := &protoparse.Parser{Accessor: memory(files)}
+	fds, err := p.ParseFiles("a.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r []byte
+	r = protowire.AppendTag(r, 1, protowire.VarintType)
+	r = protowire.AppendVarint(r, protowire.EncodeZigZag(-5))
+	r = protowire.AppendTag(r, 2, protowire.Fixed64Type)
+	r = protowire.AppendFixed64(r, math.Float64bits(-1.5))
+	r = protowire.AppendTag(r, 3, protowire.VarintType)
+	r = protowire.AppendVarint(r, uint64(1<<64-1))
+	r = protowire.AppendTag(r, 3, protowire.VarintType)
+	r = protowire.AppendVarint(r, 2)
+	var want []byte
+	want = protowire.AppendTag(want, 50000, protowire.BytesType)
+	want = protowire.AppendBytes(want, r)
+	if got := proto.MessageReflect(fds[0].MessageType[1].Options).GetUnknown(); !bytes.Equal(got, want) {
+		t.Errorf("message options = %x, want %x", got, want)
+	}
+}
+
+func TestSourceCodeInfo(t *testing.T) {
+	files := map[string]string{"a.proto": `syntax = "proto3";
+
+// A is a message.
+message A {
+  int32 a = 1; // The field.
+}
+`}
+	p := &protoparse.Parser{Accessor: memory(files), IncludeSourceCodeInfo: true}
+	fds, err := p.ParseFiles("a.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, loc := range fds[0].GetSourceCodeInfo().GetLocation() {
+		if loc.LeadingComments != nil {
+			got = append(got, "leading: "+loc.GetLeadingComments())
+		}
+		if loc.TrailingComments != nil {
+			got = append(got, "trailing: "+loc.GetTrailingComments())
+		}
+	}
+	want := []string{"leading:  A is a message.\n", "trailing:  The field.\n"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("comments mismatch (-want +got):\n%s", diff)
+	}
+
+	p.IncludeSourceCodeInfo = false
+	if fds, err = p.ParseFiles("a.proto"); err != nil {
+		t.Fatal(err)
+	}
+	if fds[0].SourceCodeInfo != nil {
+		t.Errorf("source code info included with IncludeSourceCodeInfo = false")
+	}
+}
+
+func TestCodeGeneratorRequest(t *testing.T) {
+	p := &protoparse.Parser{ImportPaths: []string{"../internal/testprotos"}}
+	req, err := p.CodeGeneratorRequest("paths=source_relative", "proto3_proto/test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, fd := range req.ProtoFile {
+		names = append(names, fd.GetName())
+	}
+	if last := names[len(names)-1]; last != "proto3_proto/test.proto" {
+		t.Errorf("last file = %q, want the file to generate; files: %v", last, names)
+	}
+	if req.ProtoFile[len(names)-1].SourceCodeInfo == nil {
+		t.Errorf("request has no source code info")
+	}
+	if req.GetParameter() != "paths=source_relative" {
+		t.Errorf("parameter = %q", req.GetParameter())
+	}
+}
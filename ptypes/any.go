@@ -135,11 +135,13 @@ func Is(any *anypb.Any, m proto.Message) bool {
 // the any message contents into a new instance of the underlying message.
 type DynamicAny struct{ proto.Message }
 
+// String formats the underlying message with proto.CompactTextString,
+// which redacts fields according to the policy set by proto.SetStringRedaction.
 func (m DynamicAny) String() string {
 	if m.Message == nil {
 		return "<nil>"
 	}
-	return m.Message.String()
+	return proto.CompactTextString(m.Message)
 }
 func (m DynamicAny) Reset() {
 	if m.Message == nil {
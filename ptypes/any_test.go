@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
 	anypb "github.com/golang/protobuf/ptypes/any"
@@ -164,3 +165,27 @@ func TestAnyReflect(t *testing.T) {
 		t.Errorf("ProtoReflect().Type().Zero().Interface() type mismatch: got %v, want %v", gotType, wantType)
 	}
 }
+
+func TestDynamicAnyStringRedact(t *testing.T) {
+	defer proto.SetStringRedaction(proto.IsDebugRedacted)
+	proto.SetStringRedaction(func(fd protoreflect.FieldDescriptor) bool {
+		return fd.Name() == "name"
+	})
+
+	a, err := MarshalAny(&descriptorpb.FileDescriptorProto{
+		Name:        proto.String("secret.proto"),
+		Package:     proto.String("pkg"),
+		MessageType: []*descriptorpb.DescriptorProto{{Name: proto.String("Secret")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got DynamicAny
+	if err := UnmarshalAny(a, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := `name:[REDACTED] package:"pkg" message_type:<name:[REDACTED] > `
+	if s := got.String(); s != want {
+		t.Errorf("String() = %q, want %q", s, want)
+	}
+}
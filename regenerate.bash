@@ -7,3 +7,11 @@ cd "$(git rev-parse --show-toplevel)"
 set -e
 go run ./internal/cmd/generate-alias -execute
 go test ./protoc-gen-go -regenerate
+
+# The options of this module's plugins are compiled by this module's
+# proto-compile and protoc-gen-go, without protoc.
+tmpdir=$(mktemp -d)
+trap 'rm -rf "$tmpdir"' EXIT
+go build -o "$tmpdir/protoc-gen-go" ./protoc-gen-go
+go run ./cmd/proto-compile -I=. -plugin="$tmpdir/protoc-gen-go" -param=paths=source_relative \
+	serviceconfig/serviceconfig.proto fastpath/fastpath.proto validate/validate.proto
@@ -0,0 +1,513 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package schemagraph analyzes the references between the declarations
+// of a protocol buffer schema.
+//
+// A Graph has a node for each message, enum, service and extension of the
+// schema, and an edge for each reference from one declaration to another:
+// the type of a field, the message extended by an extension, and the input
+// and output of a method. The graph answers which declarations reference
+// a type, and finds unused imports, orphaned messages and recursive types.
+package schemagraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/internal/dynamicext"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	descriptorpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// A Kind is the kind of declaration represented by a node.
+type Kind string
+
+const (
+	Message   Kind = "message"
+	Enum      Kind = "enum"
+	Service   Kind = "service"
+	Extension Kind = "extension"
+)
+
+// A RefKind is the kind of reference represented by an edge.
+type RefKind string
+
+const (
+	// FieldRef is a reference from a message or an extension
+	// to the type of one of its fields.
+	FieldRef RefKind = "field"
+	// ExtendeeRef is a reference from an extension to the message it extends.
+	ExtendeeRef RefKind = "extendee"
+	// InputRef and OutputRef are references from a service
+	// to the input and output types of one of its methods.
+	InputRef  RefKind = "input"
+	OutputRef RefKind = "output"
+)
+
+// A Node is a declaration of the schema.
+type Node struct {
+	Name string `json:"name"` // full name of the declaration
+	Kind Kind   `json:"kind"`
+	File string `json:"file"` // path of the declaring file
+}
+
+// An Edge is a reference from one declaration to another.
+type Edge struct {
+	From string  `json:"from"`
+	To   string  `json:"to"`
+	Kind RefKind `json:"kind"`
+	// Via is the name of the field or method making the reference, if any.
+	Via string `json:"via,omitempty"`
+}
+
+// A Graph is the graph of references between the declarations of a schema.
+//
+// The nodes are ordered by file path, then in order of declaration.
+// The edges are ordered by their source node, then in order of declaration.
+// Map entry messages are not part of the graph: a map field references
+// the type of its values directly.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+
+	files *protoregistry.Files
+	index map[string]int // index of each node by name
+	in    map[string][]int
+	out   map[string][]int
+}
+
+// FromSet returns the graph of the files of a FileDescriptorSet,
+// which must hold all the dependencies of its files.
+func FromSet(fds *descriptorpb.FileDescriptorSet) (*Graph, error) {
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("schemagraph: %v", err)
+	}
+	return New(files), nil
+}
+
+// New returns the graph of the files.
+func New(files *protoregistry.Files) *Graph {
+	g := &Graph{
+		files: files,
+		index: make(map[string]int),
+		in:    make(map[string][]int),
+		out:   make(map[string][]int),
+	}
+	for _, fd := range sortedFiles(files) {
+		g.addMessages(fd.Messages())
+		g.addEnums(fd.Enums())
+		for i := 0; i < fd.Services().Len(); i++ {
+			sd := fd.Services().Get(i)
+			g.addNode(sd, Service)
+			for j := 0; j < sd.Methods().Len(); j++ {
+				md := sd.Methods().Get(j)
+				g.addEdge(sd, md.Input(), InputRef, string(md.Name()))
+				g.addEdge(sd, md.Output(), OutputRef, string(md.Name()))
+			}
+		}
+		g.addExtensions(fd.Extensions())
+	}
+	return g
+}
+
+func sortedFiles(files *protoregistry.Files) []protoreflect.FileDescriptor {
+	var fds []protoreflect.FileDescriptor
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		fds = append(fds, fd)
+		return true
+	})
+	sort.Slice(fds, func(i, j int) bool { return fds[i].Path() < fds[j].Path() })
+	return fds
+}
+
+func (g *Graph) addMessages(mds protoreflect.MessageDescriptors) {
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		if md.IsMapEntry() {
+			continue
+		}
+		g.addNode(md, Message)
+		for j := 0; j < md.Fields().Len(); j++ {
+			fd := md.Fields().Get(j)
+			g.addFieldEdge(md, fd)
+		}
+		g.addMessages(md.Messages())
+		g.addEnums(md.Enums())
+		g.addExtensions(md.Extensions())
+	}
+}
+
+func (g *Graph) addEnums(eds protoreflect.EnumDescriptors) {
+	for i := 0; i < eds.Len(); i++ {
+		g.addNode(eds.Get(i), Enum)
+	}
+}
+
+func (g *Graph) addExtensions(xds protoreflect.ExtensionDescriptors) {
+	for i := 0; i < xds.Len(); i++ {
+		xd := xds.Get(i)
+		g.addNode(xd, Extension)
+		g.addEdge(xd, xd.ContainingMessage(), ExtendeeRef, "")
+		g.addFieldEdge(xd, xd)
+	}
+}
+
+// addFieldEdge adds the reference from d to the type of the field fd, if any.
+func (g *Graph) addFieldEdge(d protoreflect.Descriptor, fd protoreflect.FieldDescriptor) {
+	name := string(fd.Name())
+	if fd.IsMap() {
+		fd = fd.MapValue()
+	}
+	switch {
+	case fd.Message() != nil:
+		g.addEdge(d, fd.Message(), FieldRef, name)
+	case fd.Enum() != nil:
+		g.addEdge(d, fd.Enum(), FieldRef, name)
+	}
+}
+
+func (g *Graph) addNode(d protoreflect.Descriptor, kind Kind) {
+	name := string(d.FullName())
+	g.index[name] = len(g.Nodes)
+	g.Nodes = append(g.Nodes, Node{Name: name, Kind: kind, File: d.ParentFile().Path()})
+}
+
+func (g *Graph) addEdge(from, to protoreflect.Descriptor, kind RefKind, via string) {
+	e := Edge{From: string(from.FullName()), To: string(to.FullName()), Kind: kind, Via: via}
+	g.out[e.From] = append(g.out[e.From], len(g.Edges))
+	g.in[e.To] = append(g.in[e.To], len(g.Edges))
+	g.Edges = append(g.Edges, e)
+}
+
+// Node returns the node of the declaration with the given full name.
+func (g *Graph) Node(name string) (Node, bool) {
+	i, ok := g.index[name]
+	if !ok {
+		return Node{}, false
+	}
+	return g.Nodes[i], true
+}
+
+// References returns the edges to the declaration with the given full name:
+// the references made to it by other declarations, or by itself.
+func (g *Graph) References(name string) []Edge {
+	return g.edges(g.in[name])
+}
+
+// Dependencies returns the edges from the declaration with the given full name:
+// the references it makes to other declarations, or to itself.
+func (g *Graph) Dependencies(name string) []Edge {
+	return g.edges(g.out[name])
+}
+
+func (g *Graph) edges(indexes []int) []Edge {
+	var edges []Edge
+	for _, i := range indexes {
+		edges = append(edges, g.Edges[i])
+	}
+	return edges
+}
+
+// Orphans returns the names of the messages that are not referenced by any
+// other declaration of the schema, in the order of the nodes. Such messages
+// may still be used on their own by programs, or packed into a
+// google.protobuf.Any.
+func (g *Graph) Orphans() []string {
+	var names []string
+	for _, n := range g.Nodes {
+		if n.Kind != Message {
+			continue
+		}
+		referenced := false
+		for _, e := range g.References(n.Name) {
+			if e.From != n.Name {
+				referenced = true
+				break
+			}
+		}
+		if !referenced {
+			names = append(names, n.Name)
+		}
+	}
+	return names
+}
+
+// Recursive returns the groups of messages that reference themselves
+// through their fields, either directly or through the other messages of
+// the group. Each group is sorted by name, and the groups by their first name.
+func (g *Graph) Recursive() [][]string {
+	// Find the strongly connected components with Tarjan's algorithm.
+	var (
+		groups  [][]string
+		stack   []string
+		counter int
+		order   = make(map[string]int) // order of discovery of each node
+		low     = make(map[string]int) // lowest order reachable from each node
+		onStack = make(map[string]bool)
+	)
+	var visit func(name string)
+	visit = func(name string) {
+		counter++
+		order[name] = counter
+		low[name] = counter
+		stack = append(stack, name)
+		onStack[name] = true
+
+		selfLoop := false
+		for _, e := range g.Dependencies(name) {
+			if n, ok := g.Node(e.To); e.Kind != FieldRef || !ok || n.Kind != Message {
+				continue
+			}
+			switch {
+			case e.To == name:
+				selfLoop = true
+			case order[e.To] == 0:
+				visit(e.To)
+				if low[e.To] < low[name] {
+					low[name] = low[e.To]
+				}
+			case onStack[e.To]:
+				if order[e.To] < low[name] {
+					low[name] = order[e.To]
+				}
+			}
+		}
+
+		if low[name] != order[name] {
+			return
+		}
+		var group []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			group = append(group, top)
+			if top == name {
+				break
+			}
+		}
+		if len(group) > 1 || selfLoop {
+			sort.Strings(group)
+			groups = append(groups, group)
+		}
+	}
+	for _, n := range g.Nodes {
+		if n.Kind == Message && order[n.Name] == 0 {
+			visit(n.Name)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+	return groups
+}
+
+// An UnusedImport is an import of a file that no declaration
+// or custom option of the importing file refers to.
+type UnusedImport struct {
+	File   string `json:"file"`
+	Import string `json:"import"`
+	Line   int    `json:"line,omitempty"` // line of the import statement, if known
+}
+
+func (u UnusedImport) String() string {
+	if u.Line > 0 {
+		return fmt.Sprintf("%s:%d: unused import %q", u.File, u.Line, u.Import)
+	}
+	return fmt.Sprintf("%s: unused import %q", u.File, u.Import)
+}
+
+// UnusedImports returns the unused imports of the files of the schema,
+// ordered by file path, then in order of import. An import is used if
+// the importing file refers to a declaration of the imported file, or of
+// a file it publicly imports. Public imports are never reported as unused,
+// since they re-export their files to the importers of the importing file.
+// It reports an error if the custom options cannot be resolved because two
+// extensions of the schema extend the same message with the same number.
+func (g *Graph) UnusedImports() ([]UnusedImport, error) {
+	// Custom options are resolved with the extensions of the schema,
+	// since the options of descriptors built from a FileDescriptorSet
+	// are kept as unknown fields.
+	types, err := dynamicext.Types(g.files)
+	if err != nil {
+		return nil, fmt.Errorf("schemagraph: %v", err)
+	}
+	options := descriptor.OptionReader{Resolver: types}
+
+	var unused []UnusedImport
+	for _, fd := range sortedFiles(g.files) {
+		used := make(map[string]bool) // paths of the files referred to
+		use := func(d protoreflect.Descriptor) {
+			if d != nil && d.ParentFile() != nil {
+				used[d.ParentFile().Path()] = true
+			}
+		}
+		walk(fd, func(d protoreflect.Descriptor) {
+			options.Range(d, func(xt protoreflect.ExtensionType, _ protoreflect.Value) bool {
+				use(xt.TypeDescriptor())
+				return true
+			})
+			switch d := d.(type) {
+			case protoreflect.FieldDescriptor:
+				if d.IsExtension() {
+					use(d.ContainingMessage())
+				}
+				use(d.Message())
+				use(d.Enum())
+			case protoreflect.MethodDescriptor:
+				use(d.Input())
+				use(d.Output())
+			}
+		})
+
+		imports := fd.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			imp := imports.Get(i)
+			if imp.IsPublic || exports(imp.FileDescriptor, used, make(map[string]bool)) {
+				continue
+			}
+			u := UnusedImport{File: fd.Path(), Import: imp.Path()}
+			if loc := fd.SourceLocations().ByPath(protoreflect.SourcePath{fileDependencyTag, int32(i)}); loc.Path != nil {
+				u.Line = loc.StartLine + 1
+			}
+			unused = append(unused, u)
+		}
+	}
+	return unused, nil
+}
+
+// fileDependencyTag is the field number of the dependency field
+// of FileDescriptorProto.
+const fileDependencyTag = 3
+
+// exports reports whether the file fd, or a file it publicly imports,
+// is one of the used files.
+func exports(fd protoreflect.FileDescriptor, used, seen map[string]bool) bool {
+	if used[fd.Path()] {
+		return true
+	}
+	seen[fd.Path()] = true
+	imports := fd.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		imp := imports.Get(i)
+		if imp.IsPublic && !seen[imp.Path()] && exports(imp.FileDescriptor, used, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// walk calls f for the file fd and each declaration within it.
+func walk(d protoreflect.Descriptor, f func(protoreflect.Descriptor)) {
+	f(d)
+	switch d := d.(type) {
+	case protoreflect.FileDescriptor:
+		walkList(d.Messages().Len(), func(i int) protoreflect.Descriptor { return d.Messages().Get(i) }, f)
+		walkList(d.Enums().Len(), func(i int) protoreflect.Descriptor { return d.Enums().Get(i) }, f)
+		walkList(d.Services().Len(), func(i int) protoreflect.Descriptor { return d.Services().Get(i) }, f)
+		walkList(d.Extensions().Len(), func(i int) protoreflect.Descriptor { return d.Extensions().Get(i) }, f)
+	case protoreflect.MessageDescriptor:
+		walkList(d.Fields().Len(), func(i int) protoreflect.Descriptor { return d.Fields().Get(i) }, f)
+		walkList(d.Oneofs().Len(), func(i int) protoreflect.Descriptor { return d.Oneofs().Get(i) }, f)
+		walkList(d.Messages().Len(), func(i int) protoreflect.Descriptor { return d.Messages().Get(i) }, f)
+		walkList(d.Enums().Len(), func(i int) protoreflect.Descriptor { return d.Enums().Get(i) }, f)
+		walkList(d.Extensions().Len(), func(i int) protoreflect.Descriptor { return d.Extensions().Get(i) }, f)
+	case protoreflect.EnumDescriptor:
+		walkList(d.Values().Len(), func(i int) protoreflect.Descriptor { return d.Values().Get(i) }, f)
+	case protoreflect.ServiceDescriptor:
+		walkList(d.Methods().Len(), func(i int) protoreflect.Descriptor { return d.Methods().Get(i) }, f)
+	}
+}
+
+func walkList(n int, get func(int) protoreflect.Descriptor, f func(protoreflect.Descriptor)) {
+	for i := 0; i < n; i++ {
+		walk(get(i), f)
+	}
+}
+
+// WriteJSON writes the graph as a JSON object with the nodes and edges.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	v := struct {
+		Nodes []Node `json:"nodes"`
+		Edges []Edge `json:"edges"`
+	}{g.Nodes, g.Edges}
+	if v.Nodes == nil {
+		v.Nodes = []Node{}
+	}
+	if v.Edges == nil {
+		v.Edges = []Edge{}
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+// WriteDOT writes the graph in the DOT language of Graphviz, with the nodes
+// of each file grouped in a cluster. Edges are labeled with the field or method
+// making the reference, and the references of extensions to the messages
+// they extend are dashed.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	ew := &errWriter{w: w}
+	ew.printf("digraph schema {\n")
+	ew.printf("\trankdir=LR;\n")
+	ew.printf("\tnode [fontname=\"Helvetica\"];\n")
+	for i := 0; i < len(g.Nodes); {
+		file := g.Nodes[i].File
+		ew.printf("\tsubgraph %s {\n", strconv.Quote("cluster_"+file))
+		ew.printf("\t\tlabel=%s;\n", strconv.Quote(file))
+		for ; i < len(g.Nodes) && g.Nodes[i].File == file; i++ {
+			n := g.Nodes[i]
+			ew.printf("\t\t%s [shape=%s];\n", strconv.Quote(n.Name), dotShapes[n.Kind])
+		}
+		ew.printf("\t}\n")
+	}
+	for _, e := range g.Edges {
+		var attrs []string
+		switch e.Kind {
+		case FieldRef:
+			attrs = append(attrs, "label="+strconv.Quote(e.Via))
+		case InputRef, OutputRef:
+			attrs = append(attrs, "label="+strconv.Quote(e.Via+" ("+string(e.Kind)+")"))
+		case ExtendeeRef:
+			attrs = append(attrs, "style=dashed")
+		}
+		ew.printf("\t%s -> %s", strconv.Quote(e.From), strconv.Quote(e.To))
+		if len(attrs) > 0 {
+			ew.printf(" [%s]", strings.Join(attrs, " "))
+		}
+		ew.printf(";\n")
+	}
+	ew.printf("}\n")
+	return ew.err
+}
+
+var dotShapes = map[Kind]string{
+	Message:   "box",
+	Enum:      "ellipse",
+	Service:   "component",
+	Extension: "cds",
+}
+
+// errWriter is an io.Writer that retains the first error.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *errWriter) printf(format string, args ...interface{}) {
+	if w.err == nil {
+		_, w.err = fmt.Fprintf(w.w, format, args...)
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package schemagraph_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/schemagraph"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protodesc"
+
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// schema returns a set holding files with the given contents,
+// which must be ordered so that each file follows its dependencies.
+func schema(t *testing.T, files ...string) *descpb.FileDescriptorSet {
+	fds := new(descpb.FileDescriptorSet)
+	for _, s := range files {
+		fd := new(descpb.FileDescriptorProto)
+		if err := proto.UnmarshalText(s, fd); err != nil {
+			t.Fatal(err)
+		}
+		fds.File = append(fds.File, fd)
+	}
+	return fds
+}
+
+func testGraph(t *testing.T) *schemagraph.Graph {
+	fds := schema(t, `
+		name: "opts.proto"
+		package: "opts"
+		dependency: "google/protobuf/descriptor.proto"
+		extension { name: "note" number: 50000 label: LABEL_OPTIONAL type: TYPE_STRING extendee: ".google.protobuf.MessageOptions" }
+	`, `
+		name: "b.proto"
+		package: "test"
+		message_type { name: "B" extension_range { start: 100 end: 200 } }
+		enum_type { name: "E" value { name: "E_UNKNOWN" number: 0 } }
+	`, `
+		name: "c.proto"
+		package: "test"
+		message_type { name: "C" }
+	`, `
+		name: "d.proto"
+		package: "test"
+		message_type { name: "D" }
+	`, `
+		name: "pub.proto"
+		package: "test"
+		dependency: "d.proto"
+		public_dependency: 0
+	`, `
+		name: "a.proto"
+		package: "test"
+		dependency: ["b.proto", "c.proto", "opts.proto", "pub.proto"]
+		message_type {
+			name: "A"
+			field { name: "b" number: 1 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".test.B" }
+			field { name: "e" number: 2 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".test.E" }
+			field { name: "d" number: 3 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".test.A.DEntry" }
+			field { name: "n" number: 4 label: LABEL_OPTIONAL type: TYPE_INT32 }
+			nested_type {
+				name: "DEntry"
+				field { name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING }
+				field { name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".test.D" }
+				options { map_entry: true }
+			}
+			options {}
+		}
+		message_type {
+			name: "Tree"
+			field { name: "children" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".test.Tree" }
+		}
+		message_type {
+			name: "Ping"
+			field { name: "pong" number: 1 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".test.Pong" }
+		}
+		message_type {
+			name: "Pong"
+			field { name: "ping" number: 1 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".test.Ping" }
+		}
+		service {
+			name: "S"
+			method { name: "Get" input_type: ".test.A" output_type: ".test.B" }
+		}
+		extension { name: "ext" number: 100 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".test.Ping" extendee: ".test.B" }
+		source_code_info {
+			location { path: [3, 1] span: [4, 0, 18] }
+		}
+	`)
+
+	// The custom option of A is kept as an unknown field.
+	a := fds.File[len(fds.File)-1]
+	b := protowire.AppendTag(nil, 50000, protowire.BytesType)
+	a.MessageType[0].Options.ProtoReflect().SetUnknown(protowire.AppendString(b, "note"))
+
+	descFile := protodesc.ToFileDescriptorProto(proto.MessageReflect(new(descpb.FileDescriptorProto)).Descriptor().ParentFile())
+	fds.File = append([]*descpb.FileDescriptorProto{descFile}, fds.File...)
+
+	g, err := schemagraph.FromSet(fds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// withoutWellKnown removes the names declared in google.protobuf.
+func withoutWellKnown(names []string) []string {
+	var out []string
+	for _, name := range names {
+		if !strings.HasPrefix(name, "google.protobuf.") {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func TestGraph(t *testing.T) {
+	g := testGraph(t)
+
+	if n, ok := g.Node("test.A.DEntry"); ok {
+		t.Errorf("Node(test.A.DEntry) = %+v, want map entries excluded", n)
+	}
+	if n, ok := g.Node("test.ext"); !ok || n.Kind != schemagraph.Extension || n.File != "a.proto" {
+		t.Errorf("Node(test.ext) = %+v, %v", n, ok)
+	}
+
+	wantRefs := []schemagraph.Edge{
+		{From: "test.A", To: "test.B", Kind: schemagraph.FieldRef, Via: "b"},
+		{From: "test.S", To: "test.B", Kind: schemagraph.OutputRef, Via: "Get"},
+		{From: "test.ext", To: "test.B", Kind: schemagraph.ExtendeeRef},
+	}
+	if diff := cmp.Diff(wantRefs, g.References("test.B")); diff != "" {
+		t.Errorf("References(test.B) mismatch (-want +got):\n%s", diff)
+	}
+	wantDeps := []schemagraph.Edge{
+		{From: "test.A", To: "test.B", Kind: schemagraph.FieldRef, Via: "b"},
+		{From: "test.A", To: "test.E", Kind: schemagraph.FieldRef, Via: "e"},
+		{From: "test.A", To: "test.D", Kind: schemagraph.FieldRef, Via: "d"},
+	}
+	if diff := cmp.Diff(wantDeps, g.Dependencies("test.A")); diff != "" {
+		t.Errorf("Dependencies(test.A) mismatch (-want +got):\n%s", diff)
+	}
+
+	wantOrphans := []string{"test.Tree", "test.C"}
+	if diff := cmp.Diff(wantOrphans, withoutWellKnown(g.Orphans())); diff != "" {
+		t.Errorf("Orphans() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantRecursive := [][]string{{"test.Ping", "test.Pong"}, {"test.Tree"}}
+	var gotRecursive [][]string
+	for _, group := range g.Recursive() {
+		if group = withoutWellKnown(group); group != nil {
+			gotRecursive = append(gotRecursive, group)
+		}
+	}
+	if diff := cmp.Diff(wantRecursive, gotRecursive); diff != "" {
+		t.Errorf("Recursive() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantUnused := []schemagraph.UnusedImport{{File: "a.proto", Import: "c.proto", Line: 5}}
+	unused, err := g.UnusedImports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(wantUnused, unused); diff != "" {
+		t.Errorf("UnusedImports() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnusedImportsConflictingExtensions(t *testing.T) {
+	g, err := schemagraph.FromSet(schema(t, `
+		name: "m.proto"
+		package: "x"
+		message_type { name: "M" extension_range { start: 10 end: 20 } }
+	`, `
+		name: "a.proto"
+		package: "x"
+		dependency: "m.proto"
+		extension { name: "a" number: 10 label: LABEL_OPTIONAL type: TYPE_STRING extendee: ".x.M" }
+	`, `
+		name: "b.proto"
+		package: "x"
+		dependency: "m.proto"
+		extension { name: "b" number: 10 label: LABEL_OPTIONAL type: TYPE_STRING extendee: ".x.M" }
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unused, err := g.UnusedImports(); err == nil {
+		t.Errorf("UnusedImports() = %v, want an error for the conflicting extensions", unused)
+	}
+}
+
+func TestGraphExport(t *testing.T) {
+	g, err := schemagraph.FromSet(schema(t, `
+		name: "x.proto"
+		package: "x"
+		message_type {
+			name: "M"
+			field { name: "kind" number: 1 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".x.Kind" }
+			extension_range { start: 10 end: 20 }
+		}
+		enum_type { name: "Kind" value { name: "KIND_UNKNOWN" number: 0 } }
+		service { name: "S" method { name: "Do" input_type: ".x.M" output_type: ".x.M" } }
+		extension { name: "more" number: 10 label: LABEL_OPTIONAL type: TYPE_STRING extendee: ".x.M" }
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dot bytes.Buffer
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	wantDOT := `digraph schema {
+	rankdir=LR;
+	node [fontname="Helvetica"];
+	subgraph "cluster_x.proto" {
+		label="x.proto";
+		"x.M" [shape=box];
+		"x.Kind" [shape=ellipse];
+		"x.S" [shape=component];
+		"x.more" [shape=cds];
+	}
+	"x.M" -> "x.Kind" [label="kind"];
+	"x.S" -> "x.M" [label="Do (input)"];
+	"x.S" -> "x.M" [label="Do (output)"];
+	"x.more" -> "x.M" [style=dashed];
+}
+`
+	if diff := cmp.Diff(wantDOT, dot.String()); diff != "" {
+		t.Errorf("WriteDOT() mismatch (-want +got):\n%s", diff)
+	}
+
+	var js bytes.Buffer
+	if err := g.WriteJSON(&js); err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{
+  "nodes": [
+    {
+      "name": "x.M",
+      "kind": "message",
+      "file": "x.proto"
+    },
+    {
+      "name": "x.Kind",
+      "kind": "enum",
+      "file": "x.proto"
+    },
+    {
+      "name": "x.S",
+      "kind": "service",
+      "file": "x.proto"
+    },
+    {
+      "name": "x.more",
+      "kind": "extension",
+      "file": "x.proto"
+    }
+  ],
+  "edges": [
+    {
+      "from": "x.M",
+      "to": "x.Kind",
+      "kind": "field",
+      "via": "kind"
+    },
+    {
+      "from": "x.S",
+      "to": "x.M",
+      "kind": "input",
+      "via": "Do"
+    },
+    {
+      "from": "x.S",
+      "to": "x.M",
+      "kind": "output",
+      "via": "Do"
+    },
+    {
+      "from": "x.more",
+      "to": "x.M",
+      "kind": "extendee"
+    }
+  ]
+}
+`
+	if diff := cmp.Diff(wantJSON, js.String()); diff != "" {
+		t.Errorf("WriteJSON() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package validate
+
+import (
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Reflect is like Message, but validates m and the messages it holds by
+// protobuf reflection only, ignoring their generated Validate methods.
+// It validates dynamic messages, whose descriptors may carry the rules
+// option as an unknown field.
+func Reflect(m protoreflect.Message) error {
+	var e Error
+	if err := appendReflect(&e, "", m); err != nil {
+		return err
+	}
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// appendReflect appends the violations of m to e, with their paths
+// prefixed by prefix.
+func appendReflect(e *Error, prefix string, m protoreflect.Message) error {
+	fds := m.Descriptor().Fields()
+	for i := 0; i < fds.Len(); i++ {
+		fd := fds.Get(i)
+		if fd.IsWeak() {
+			continue
+		}
+		r, err := rulesOf(fd)
+		if err != nil {
+			return err
+		}
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() && !m.Has(fd) {
+			continue
+		}
+		path := prefix + string(fd.Name())
+		if r.GetRequired() && !m.Has(fd) {
+			e.add(path, "is required")
+		}
+		switch {
+		case fd.IsList():
+			l := m.Get(fd).List()
+			r.appendItems(e, path, l.Len())
+			if r.GetUnique() && !unique(l) {
+				e.add(path, "must have unique items")
+			}
+			for j := 0; j < l.Len(); j++ {
+				if err := r.appendValue(e, Index(path, j), fd, l.Get(j)); err != nil {
+					return err
+				}
+			}
+		case fd.IsMap():
+			mp := m.Get(fd).Map()
+			r.appendItems(e, path, mp.Len())
+			for _, k := range sortedKeys(mp) {
+				if err := r.appendValue(e, Key(path, k.Interface()), fd.MapValue(), mp.Get(k)); err != nil {
+					return err
+				}
+			}
+		default:
+			if fd.HasPresence() && !m.Has(fd) {
+				continue
+			}
+			if err := r.appendValue(e, path, fd, m.Get(fd)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Error) add(path, description string) {
+	*e = append(*e, Violation{Field: path, Description: description})
+}
+
+// appendItems appends the violations of the bounds of the number of
+// elements n of a repeated or map field.
+func (r *fieldRules) appendItems(e *Error, path string, n int) {
+	if r.MinItems != nil && uint64(n) < *r.MinItems {
+		e.add(path, "must have at least "+strconv.FormatUint(*r.MinItems, 10)+" items")
+	}
+	if r.MaxItems != nil && uint64(n) > *r.MaxItems {
+		e.add(path, "must have at most "+strconv.FormatUint(*r.MaxItems, 10)+" items")
+	}
+}
+
+// appendValue appends the violations of the value v of a field, or of an
+// element or map value described by fd, and of the message it holds.
+func (r *fieldRules) appendValue(e *Error, path string, fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		m := v.Message()
+		if !m.IsValid() {
+			return nil
+		}
+		r.appendTimeBounds(e, path, m)
+		return appendReflect(e, path+".", m)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n := v.Int()
+		if r.MinInt != nil && n < *r.MinInt {
+			e.add(path, "must be at least "+strconv.FormatInt(*r.MinInt, 10))
+		}
+		if r.MaxInt != nil && n > *r.MaxInt {
+			e.add(path, "must be at most "+strconv.FormatInt(*r.MaxInt, 10))
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n := v.Uint()
+		if r.MinInt != nil && n < uint64(*r.MinInt) {
+			e.add(path, "must be at least "+strconv.FormatInt(*r.MinInt, 10))
+		}
+		if r.MaxInt != nil && n > uint64(*r.MaxInt) {
+			e.add(path, "must be at most "+strconv.FormatInt(*r.MaxInt, 10))
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f := v.Float()
+		if r.MinFloat != nil && !(f >= *r.MinFloat) {
+			e.add(path, "must be at least "+formatFloat(*r.MinFloat))
+		}
+		if r.MaxFloat != nil && !(f <= *r.MaxFloat) {
+			e.add(path, "must be at most "+formatFloat(*r.MaxFloat))
+		}
+	case protoreflect.StringKind:
+		s := v.String()
+		r.appendLen(e, path, uint64(utf8.RuneCountInString(s)), "characters")
+		if r.pattern != nil && !r.pattern.MatchString(s) {
+			e.add(path, "must match the pattern "+strconv.Quote(*r.Pattern))
+		}
+	case protoreflect.BytesKind:
+		r.appendLen(e, path, uint64(len(v.Bytes())), "bytes")
+	case protoreflect.EnumKind:
+		if r.GetDefinedOnly() && fd.Enum().Values().ByNumber(v.Enum()) == nil {
+			e.add(path, "must be a defined enum value")
+		}
+	}
+	return nil
+}
+
+// appendLen appends the violations of the bounds of the length n of a
+// string or bytes value, counted in unit.
+func (r *fieldRules) appendLen(e *Error, path string, n uint64, unit string) {
+	if r.MinLen != nil && n < *r.MinLen {
+		e.add(path, "must be at least "+strconv.FormatUint(*r.MinLen, 10)+" "+unit+" long")
+	}
+	if r.MaxLen != nil && n > *r.MaxLen {
+		e.add(path, "must be at most "+strconv.FormatUint(*r.MaxLen, 10)+" "+unit+" long")
+	}
+}
+
+// appendTimeBounds appends the violations of the bounds of a Duration or
+// Timestamp message m.
+func (r *fieldRules) appendTimeBounds(e *Error, path string, m protoreflect.Message) {
+	if r.MinDuration == nil && r.MaxDuration == nil && r.MinTimestamp == nil && r.MaxTimestamp == nil {
+		return
+	}
+	fds := m.Descriptor().Fields()
+	s := m.Get(fds.ByNumber(1)).Int()
+	n := int32(m.Get(fds.ByNumber(2)).Int())
+	if b := r.MinDuration; b != nil && compareSecondsNanos(s, n, b.GetSeconds(), b.GetNanos()) < 0 {
+		e.add(path, "must be at least "+b.AsDuration().String())
+	}
+	if b := r.MaxDuration; b != nil && compareSecondsNanos(s, n, b.GetSeconds(), b.GetNanos()) > 0 {
+		e.add(path, "must be at most "+b.AsDuration().String())
+	}
+	if b := r.MinTimestamp; b != nil && compareSecondsNanos(s, n, b.GetSeconds(), b.GetNanos()) < 0 {
+		e.add(path, "must be at or after "+b.AsTime().Format(time.RFC3339Nano))
+	}
+	if b := r.MaxTimestamp; b != nil && compareSecondsNanos(s, n, b.GetSeconds(), b.GetNanos()) > 0 {
+		e.add(path, "must be at or before "+b.AsTime().Format(time.RFC3339Nano))
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// unique reports whether the elements of a list of scalars are distinct.
+func unique(l protoreflect.List) bool {
+	seen := make(map[interface{}]bool, l.Len())
+	for i := 0; i < l.Len(); i++ {
+		v := l.Get(i).Interface()
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		if seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+// sortedKeys returns the keys of a map in order.
+func sortedKeys(m protoreflect.Map) []protoreflect.MapKey {
+	var keys []protoreflect.MapKey
+	m.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool {
+		switch x := keys[i].Interface().(type) {
+		case bool:
+			return !x && keys[j].Bool()
+		case int32, int64:
+			return keys[i].Int() < keys[j].Int()
+		case uint32, uint64:
+			return keys[i].Uint() < keys[j].Uint()
+		}
+		return keys[i].String() < keys[j].String()
+	})
+	return keys
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package validate
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sync"
+
+	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/internal/optionscope"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Rules returns the rules given to the field fd by the rules option, or nil
+// if it has none. It reports an error if the rules are invalid, such as rules
+// not applying to the type of the field or bounds out of order.
+func Rules(fd protoreflect.FieldDescriptor) (*FieldRules, error) {
+	r, _, err := readRules(fd)
+	return r, err
+}
+
+// readRules returns the rules of the field fd and their compiled pattern.
+// The rules option is only read in files importing validate.proto.
+func readRules(fd protoreflect.FieldDescriptor) (*FieldRules, *regexp.Regexp, error) {
+	if !optionscope.Visible(fd.ParentFile(), E_Rules) {
+		return nil, nil, nil
+	}
+	v, ok, err := descriptor.OptionReader{}.Get(fd, E_Rules)
+	if err != nil || !ok {
+		return nil, nil, err
+	}
+	r, ok := v.Message().Interface().(*FieldRules)
+	if !ok {
+		// The option was set with another type of the extension,
+		// such as a dynamic one.
+		b, err := protoV2.Marshal(v.Message().Interface())
+		if err != nil {
+			return nil, nil, err
+		}
+		r = new(FieldRules)
+		if err := protoV2.Unmarshal(b, r); err != nil {
+			return nil, nil, err
+		}
+	}
+	re, err := checkRules(fd, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, re, nil
+}
+
+// fieldRules are the rules of a field, with their compiled pattern.
+type fieldRules struct {
+	*FieldRules
+	pattern *regexp.Regexp
+}
+
+// compiledRules caches the fieldRules of the field descriptors, or the error
+// of their rules.
+var compiledRules sync.Map
+
+// noRules are the rules of the fields without the rules option.
+var noRules = &fieldRules{FieldRules: new(FieldRules)}
+
+// rulesOf returns the rules of the field fd.
+func rulesOf(fd protoreflect.FieldDescriptor) (*fieldRules, error) {
+	if v, ok := compiledRules.Load(fd); ok {
+		if err, ok := v.(error); ok {
+			return nil, err
+		}
+		return v.(*fieldRules), nil
+	}
+	var v interface{}
+	r, re, err := readRules(fd)
+	switch {
+	case err != nil:
+		v = err
+	case r != nil:
+		v = &fieldRules{FieldRules: r, pattern: re}
+	default:
+		v = noRules
+	}
+	v, _ = compiledRules.LoadOrStore(fd, v)
+	if err, ok := v.(error); ok {
+		return nil, err
+	}
+	return v.(*fieldRules), nil
+}
+
+// checkRules reports whether the rules r apply to the field fd, and returns
+// their compiled pattern, if any.
+func checkRules(fd protoreflect.FieldDescriptor, r *FieldRules) (*regexp.Regexp, error) {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("validate: invalid rules of field %v: %s", fd.FullName(), fmt.Sprintf(format, args...))
+	}
+	value := fd
+	if fd.IsMap() {
+		value = fd.MapValue()
+	}
+	kind := value.Kind()
+	message := protoreflect.FullName("")
+	if kind == protoreflect.MessageKind || kind == protoreflect.GroupKind {
+		message = value.Message().FullName()
+	}
+
+	if r.MinInt != nil || r.MaxInt != nil {
+		min, max, ok := intRange(kind)
+		if !ok {
+			return nil, invalid("min_int and max_int apply to integer fields")
+		}
+		for _, b := range []*int64{r.MinInt, r.MaxInt} {
+			if b != nil && (*b < min || *b > max) {
+				return nil, invalid("bound %d is out of the range of %v values", *b, kind)
+			}
+		}
+		if r.MinInt != nil && r.MaxInt != nil && *r.MinInt > *r.MaxInt {
+			return nil, invalid("min_int is greater than max_int")
+		}
+	}
+	if r.MinFloat != nil || r.MaxFloat != nil {
+		if kind != protoreflect.FloatKind && kind != protoreflect.DoubleKind {
+			return nil, invalid("min_float and max_float apply to float and double fields")
+		}
+		for _, b := range []*float64{r.MinFloat, r.MaxFloat} {
+			if b != nil && (math.IsNaN(*b) || math.IsInf(*b, 0)) {
+				return nil, invalid("bound %v is not finite", *b)
+			}
+		}
+		if r.MinFloat != nil && r.MaxFloat != nil && *r.MinFloat > *r.MaxFloat {
+			return nil, invalid("min_float is greater than max_float")
+		}
+	}
+	if r.MinLen != nil || r.MaxLen != nil {
+		if kind != protoreflect.StringKind && kind != protoreflect.BytesKind {
+			return nil, invalid("min_len and max_len apply to string and bytes fields")
+		}
+		if r.MinLen != nil && r.MaxLen != nil && *r.MinLen > *r.MaxLen {
+			return nil, invalid("min_len is greater than max_len")
+		}
+	}
+	var re *regexp.Regexp
+	if r.Pattern != nil {
+		if kind != protoreflect.StringKind {
+			return nil, invalid("pattern applies to string fields")
+		}
+		var err error
+		if re, err = regexp.Compile(*r.Pattern); err != nil {
+			return nil, invalid("%v", err)
+		}
+	}
+	if r.GetRequired() {
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			return nil, invalid("required does not apply to fields of oneofs")
+		}
+	}
+	if r.GetDefinedOnly() && kind != protoreflect.EnumKind {
+		return nil, invalid("defined_only applies to enum fields")
+	}
+	if r.MinItems != nil || r.MaxItems != nil {
+		if !fd.IsList() && !fd.IsMap() {
+			return nil, invalid("min_items and max_items apply to repeated and map fields")
+		}
+		if r.MinItems != nil && r.MaxItems != nil && *r.MinItems > *r.MaxItems {
+			return nil, invalid("min_items is greater than max_items")
+		}
+	}
+	if r.GetUnique() && (!fd.IsList() || message != "") {
+		return nil, invalid("unique applies to repeated fields of scalars")
+	}
+	if r.MinDuration != nil || r.MaxDuration != nil {
+		if message != "google.protobuf.Duration" {
+			return nil, invalid("min_duration and max_duration apply to google.protobuf.Duration fields")
+		}
+		if r.MinDuration != nil && r.MaxDuration != nil && compareDurations(r.MinDuration, r.MaxDuration) > 0 {
+			return nil, invalid("min_duration is greater than max_duration")
+		}
+	}
+	if r.MinTimestamp != nil || r.MaxTimestamp != nil {
+		if message != "google.protobuf.Timestamp" {
+			return nil, invalid("min_timestamp and max_timestamp apply to google.protobuf.Timestamp fields")
+		}
+		if r.MinTimestamp != nil && r.MaxTimestamp != nil && compareTimestamps(r.MinTimestamp, r.MaxTimestamp) > 0 {
+			return nil, invalid("min_timestamp is greater than max_timestamp")
+		}
+	}
+	return re, nil
+}
+
+// intRange returns the range of the values of an integer kind, or reports
+// false if the kind is not an integer kind.
+func intRange(kind protoreflect.Kind) (min, max int64, ok bool) {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return math.MinInt32, math.MaxInt32, true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return 0, math.MaxUint32, true
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return math.MinInt64, math.MaxInt64, true
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return 0, math.MaxInt64, true
+	}
+	return 0, 0, false
+}
+
+// compareDurations returns -1, 0 or 1 as x is less than, equal to or greater
+// than y, comparing their seconds, then their nanoseconds.
+func compareDurations(x, y *durationpb.Duration) int {
+	return compareSecondsNanos(x.GetSeconds(), x.GetNanos(), y.GetSeconds(), y.GetNanos())
+}
+
+// compareTimestamps is like compareDurations, for timestamps.
+func compareTimestamps(x, y *timestamppb.Timestamp) int {
+	return compareSecondsNanos(x.GetSeconds(), x.GetNanos(), y.GetSeconds(), y.GetNanos())
+}
+
+func compareSecondsNanos(xs int64, xn int32, ys int64, yn int32) int {
+	switch {
+	case xs < ys || xs == ys && xn < yn:
+		return -1
+	case xs > ys || xn > yn:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package validate checks the values of the fields of messages against the
+// rules given by the options of validate.proto.
+//
+// protoc-gen-go with the plugins=validate parameter generates a Validate
+// method for each message, which checks the rules of its fields without
+// protobuf reflection. Message uses those methods when available, and
+// otherwise checks the rules by protobuf reflection, as Reflect does for
+// dynamic messages.
+//
+// Validation reports every violation of the rules, in order of declaration
+// of the fields and of the elements of repeated fields, with the values of
+// map fields in order of key. The messages held by the fields are validated
+// as well, with the paths of their violations prefixed by the path of the
+// field holding them.
+package validate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// A Violation is a value not satisfying the rules of its field.
+type Violation struct {
+	// Field is the path of the value from the validated message, made of
+	// the names of the fields, the indexes of the elements of repeated fields
+	// and the keys of the values of map fields, such as items[2].name or
+	// labels["env"].
+	Field string
+
+	// Description describes the rule the value does not satisfy,
+	// such as "must be at most 10 characters long".
+	Description string
+}
+
+func (v Violation) String() string {
+	return v.Field + ": " + v.Description
+}
+
+// Error is the error reported for a message with violations of its rules.
+type Error []Violation
+
+func (e Error) Error() string {
+	s := make([]string, len(e))
+	for i, v := range e {
+		s[i] = v.String()
+	}
+	return "validate: " + strings.Join(s, "; ")
+}
+
+// validator is implemented by the messages with a generated Validate method.
+type validator interface {
+	Validate() error
+}
+
+// Message validates m and the messages it holds. It reports an Error listing
+// the violations of the rules, or another error if the rules of a field are
+// invalid, such as rules not applying to the type of the field.
+//
+// The generated Validate method of m is used if there is one, and protobuf
+// reflection otherwise.
+func Message(m proto.Message) error {
+	if v, ok := m.(validator); ok {
+		return v.Validate()
+	}
+	return Reflect(proto.MessageReflect(m))
+}
+
+// AppendMessage validates the message m held by the value at path, as by
+// Message, and appends its violations to e, with their paths prefixed by
+// path. It does nothing if m is nil. It is used by the generated Validate
+// methods.
+func AppendMessage(e *Error, path string, m proto.Message) error {
+	if m == nil || !proto.MessageReflect(m).IsValid() {
+		return nil
+	}
+	err := Message(m)
+	nested, ok := err.(Error)
+	if !ok {
+		return err
+	}
+	for _, v := range nested {
+		*e = append(*e, Violation{Field: path + "." + v.Field, Description: v.Description})
+	}
+	return nil
+}
+
+// Index returns the path of the element i of the repeated field at path.
+func Index(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// Key returns the path of the value for key of the map field at path.
+// String keys are quoted.
+func Key(path string, key interface{}) string {
+	if s, ok := key.(string); ok {
+		return path + "[" + strconv.Quote(s) + "]"
+	}
+	return fmt.Sprintf("%s[%v]", path, key)
+}
@@ -0,0 +1,420 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package validate_test
+
+import (
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/golang/protobuf/internal/testprotos/validate_proto"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoparse"
+	"github.com/golang/protobuf/validate"
+	"github.com/google/go-cmp/cmp"
+	protoV2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// valid returns a message satisfying its rules, modified by f.
+func valid(f func(m *pb.Message)) *pb.Message {
+	m := &pb.Message{
+		Name:    "ab",
+		F64:     10,
+		Fl:      1,
+		Db:      1,
+		B:       true,
+		Opt:     proto.Int64(1),
+		Tags:    []string{"a"},
+		Timeout: &durationpb.Duration{Seconds: 1},
+	}
+	if f != nil {
+		f(m)
+	}
+	return m
+}
+
+func violation(field, description string) validate.Violation {
+	return validate.Violation{Field: field, Description: description}
+}
+
+var validateTests = []struct {
+	desc string
+	m    proto.Message
+	want validate.Error
+}{{
+	desc: "valid",
+	m:    valid(nil),
+}, {
+	desc: "empty",
+	m:    &pb.Message{},
+	want: validate.Error{
+		violation("name", "is required"),
+		violation("name", "must be at least 2 characters long"),
+		violation("name", `must match the pattern "^[a-zé]+$"`),
+		violation("f64", "must be at least 10"),
+		violation("fl", "must be at least 0.1"),
+		violation("db", "is required"),
+		violation("b", "is required"),
+		violation("opt", "is required"),
+		violation("tags", "must have at least 1 items"),
+		violation("timeout", "is required"),
+	},
+}, {
+	desc: "scalars",
+	m: valid(func(m *pb.Message) {
+		m.Name = "ABCDEFGHI"
+		m.I32 = -6
+		m.S64 = -1000000000001
+		m.U32 = 1001
+		m.F64 = 21
+		m.Fl = float32(math.NaN())
+		m.Db = -3
+		m.Bs = []byte("abcd")
+		m.Color = 5
+		m.Opt = proto.Int64(0)
+		m.OptName = proto.String("")
+	}),
+	want: validate.Error{
+		violation("name", "must be at most 8 characters long"),
+		violation("name", `must match the pattern "^[a-zé]+$"`),
+		violation("i32", "must be at least -5"),
+		violation("s64", "must be at least -1000000000000"),
+		violation("u32", "must be at most 1000"),
+		violation("f64", "must be at most 20"),
+		violation("fl", "must be at least 0.1"),
+		violation("fl", "must be at most 1.5"),
+		violation("db", "must be at least -2.5"),
+		violation("bs", "must be at most 3 bytes long"),
+		violation("color", "must be a defined enum value"),
+		violation("opt", "must be at least 1"),
+		violation("opt_name", "must be at least 1 characters long"),
+	},
+}, {
+	desc: "bounds",
+	m: valid(func(m *pb.Message) {
+		m.Name = "éé"
+		m.I32 = 5
+		m.F64 = 20
+		m.Fl = 0.1
+		m.Db = math.Copysign(0, -1)
+		m.Bs = []byte("abc")
+		m.Color = pb.Color_GREEN
+		m.Timeout = &durationpb.Duration{Seconds: 60}
+		m.Time = &timestamppb.Timestamp{Seconds: 4102444800, Nanos: 500}
+	}),
+}, {
+	desc: "repeated",
+	m: valid(func(m *pb.Message) {
+		m.Tags = []string{"a", "a", "toolong", "b"}
+		m.Blobs = [][]byte{{1}, {2}, {1}}
+		m.Floats = []float32{float32(math.NaN()), float32(math.NaN()), 11}
+		m.Colors = []pb.Color{pb.Color_RED, 7, 7}
+		m.Children = []*pb.Message{valid(nil), valid(func(m *pb.Message) { m.Name = "a" }), valid(nil)}
+	}),
+	want: validate.Error{
+		violation("tags", "must have at most 3 items"),
+		violation("tags", "must have unique items"),
+		violation("tags[2]", "must be at most 4 characters long"),
+		violation("blobs", "must have unique items"),
+		violation("floats[0]", "must be at most 10"),
+		violation("floats[1]", "must be at most 10"),
+		violation("floats[2]", "must be at most 10"),
+		violation("colors", "must have unique items"),
+		violation("colors[1]", "must be a defined enum value"),
+		violation("colors[2]", "must be a defined enum value"),
+		violation("children", "must have at most 2 items"),
+		violation("children[1].name", "must be at least 2 characters long"),
+	},
+}, {
+	desc: "maps",
+	m: valid(func(m *pb.Message) {
+		m.Counts = map[string]int32{"b": -1, "a": -2, "c": 1}
+		m.Flags = map[bool]*pb.Message{
+			true:  valid(func(m *pb.Message) { m.B = false }),
+			false: valid(func(m *pb.Message) { m.Tags = nil }),
+		}
+		m.Names = map[int32]string{2: "y", -1: "x", -3: "z"}
+	}),
+	want: validate.Error{
+		violation("counts", "must have at most 2 items"),
+		violation(`counts["a"]`, "must be at least 0"),
+		violation(`counts["b"]`, "must be at least 0"),
+		violation("flags[false].tags", "must have at least 1 items"),
+		violation("flags[true].b", "is required"),
+		violation("names[-3]", `must match the pattern "^x"`),
+		violation("names[2]", `must match the pattern "^x"`),
+	},
+}, {
+	desc: "durations and timestamps",
+	m: valid(func(m *pb.Message) {
+		m.Timeout = &durationpb.Duration{Nanos: 999999}
+		m.Time = &timestamppb.Timestamp{Seconds: 4102444800, Nanos: 501}
+		m.Timeouts = []*durationpb.Duration{{Seconds: 1}, {Seconds: 1, Nanos: 1}, {Seconds: 2}}
+		m.Times = map[uint64]*timestamppb.Timestamp{5: {Seconds: -1}, 2: {Seconds: 0}}
+	}),
+	want: validate.Error{
+		violation("timeout", "must be at least 1ms"),
+		violation("time", "must be at or before 2100-01-01T00:00:00.0000005Z"),
+		violation("timeouts[1]", "must be at most 1s"),
+		violation("timeouts[2]", "must be at most 1s"),
+		violation("times[5]", "must be at or after 1970-01-01T00:00:00Z"),
+	},
+}, {
+	desc: "oneof string",
+	m:    valid(func(m *pb.Message) { m.Kind = &pb.Message_Text{} }),
+	want: validate.Error{violation("text", "must be at least 1 characters long")},
+}, {
+	desc: "oneof integer",
+	m:    valid(func(m *pb.Message) { m.Kind = &pb.Message_Number{Number: 101} }),
+	want: validate.Error{violation("number", "must be at most 100")},
+}, {
+	desc: "oneof message",
+	m: valid(func(m *pb.Message) {
+		m.Kind = &pb.Message_Nested{Nested: valid(func(m *pb.Message) { m.I32 = 6 })}
+	}),
+	want: validate.Error{violation("nested.i32", "must be at most 5")},
+}, {
+	desc: "oneof enum",
+	m:    valid(func(m *pb.Message) { m.Kind = &pb.Message_Shade{Shade: 9} }),
+	want: validate.Error{violation("shade", "must be a defined enum value")},
+}, {
+	desc: "nested",
+	m: valid(func(m *pb.Message) {
+		m.Child = valid(func(m *pb.Message) {
+			m.Child = valid(func(m *pb.Message) { m.Timeout = nil })
+		})
+		m.Legacy = &pb.Legacy{}
+	}),
+	want: validate.Error{
+		violation("child.child.timeout", "is required"),
+		violation("legacy.name", "is required"),
+	},
+}, {
+	desc: "proto2",
+	m: &pb.Legacy{
+		Id:    proto.Int32(0),
+		Name:  proto.String("abcd"),
+		Data:  []byte{},
+		Size:  pb.Legacy_Size(3).Enum(),
+		Ids:   []int32{10, 10},
+		Group: &pb.Legacy_Group{N: proto.Uint64(1)},
+	},
+	want: validate.Error{
+		violation("id", "must be at least 1"),
+		violation("name", "must be at most 3 characters long"),
+		violation("data", "must be at least 1 bytes long"),
+		violation("size", "must be a defined enum value"),
+		violation("ids", "must have unique items"),
+		violation("ids[0]", "must be at most 9"),
+		violation("ids[1]", "must be at most 9"),
+		violation("group.n", "must be at least 2"),
+	},
+}, {
+	desc: "proto2 aliases",
+	m:    &pb.Legacy{Id: proto.Int32(1), Name: proto.String("abc"), Size: pb.Legacy_BIG.Enum()},
+}, {
+	desc: "string without length rules",
+	m:    &pb.Code{Code: "abc", Note: "anything"},
+	want: validate.Error{violation("code", `must match the pattern "^[A-Z]+$"`)},
+}, {
+	desc: "without Validate method",
+	m:    &pb.Conflict{},
+	want: validate.Error{violation("validate", "is required")},
+}, {
+	desc: "holding a message without Validate method",
+	m: &pb.Free{
+		ValidateCount: 1,
+		Message:       valid(func(m *pb.Message) { m.Legacy = &pb.Legacy{} }),
+	},
+	want: validate.Error{violation("message.legacy.name", "is required")},
+}}
+
+func TestValidate(t *testing.T) {
+	for _, tt := range validateTests {
+		check := func(name string, err error) {
+			var got validate.Error
+			if err != nil {
+				var ok bool
+				if got, ok = err.(validate.Error); !ok {
+					t.Errorf("%s: %s: error = %v, want validate.Error", tt.desc, name, err)
+					return
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("%s: %s: violations mismatch (-want +got):\n%s", tt.desc, name, diff)
+			}
+		}
+		check("Message", validate.Message(tt.m))
+		check("Reflect", validate.Reflect(proto.MessageReflect(tt.m)))
+
+		b, err := protoV2.MarshalOptions{AllowPartial: true}.Marshal(proto.MessageV2(tt.m))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dm := dynamicpb.NewMessage(proto.MessageReflect(tt.m).Descriptor())
+		if err := (protoV2.UnmarshalOptions{AllowPartial: true}).Unmarshal(b, dm); err != nil {
+			t.Fatal(err)
+		}
+		check("dynamic", validate.Message(dm))
+	}
+}
+
+func TestError(t *testing.T) {
+	err := validate.Message(valid(func(m *pb.Message) {
+		m.Name = "a"
+		m.Tags = []string{"a", "ab", "abcde"}
+	}))
+	want := "validate: name: must be at least 2 characters long; tags[2]: must be at most 4 characters long"
+	if err == nil || err.Error() != want {
+		t.Errorf("Message() = %v, want %q", err, want)
+	}
+}
+
+// parse returns the descriptor of the message test.M declared by src, whose
+// options are left as unknown fields.
+func parse(t *testing.T, src string) protoreflect.MessageDescriptor {
+	p := &protoparse.Parser{
+		Accessor: func(name string) (io.ReadCloser, error) {
+			if name == "test.proto" {
+				return ioutil.NopCloser(strings.NewReader(src)), nil
+			}
+			return os.Open(filepath.Join("..", name))
+		},
+	}
+	fds, err := p.ParseFileDescriptors("test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fds[0].Messages().ByName("M")
+}
+
+const testHeader = `syntax = "proto3";
+package test;
+import "google/protobuf/duration.proto";
+import "validate/validate.proto";
+`
+
+func TestDynamic(t *testing.T) {
+	md := parse(t, testHeader+`
+message M {
+  repeated int64 n = 1 [(golang.protobuf.validate.rules) = { min_items: 1 max_int: 3 }];
+  map<string, M> m = 2;
+}
+`)
+	m := dynamicpb.NewMessage(md)
+	m.Mutable(md.Fields().ByName("n")).List().Append(protoreflect.ValueOfInt64(4))
+	nested := dynamicpb.NewMessage(md)
+	m.Mutable(md.Fields().ByName("m")).Map().Set(protoreflect.ValueOfString("x").MapKey(), protoreflect.ValueOfMessage(nested))
+	want := validate.Error{
+		violation("n[0]", "must be at most 3"),
+		violation(`m["x"].n`, "must have at least 1 items"),
+	}
+	if diff := cmp.Diff(want, validate.Reflect(m)); diff != "" {
+		t.Errorf("violations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestForeignRules(t *testing.T) {
+	// An option of another schema with the number of the rules option is
+	// not read in a file not importing validate.proto.
+	md := parse(t, `syntax = "proto2";
+package test;
+import "google/protobuf/descriptor.proto";
+message Note {
+  optional sint64 level = 1;
+}
+extend google.protobuf.FieldOptions {
+  optional Note note = 64172;
+}
+message M {
+  optional string f = 1 [(note).level = 1];
+}
+`)
+	if r, err := validate.Rules(md.Fields().ByName("f")); r != nil || err != nil {
+		t.Errorf("Rules() = %v, %v; want nil, nil", r, err)
+	}
+	if err := validate.Reflect(dynamicpb.NewMessage(md)); err != nil {
+		t.Errorf("Reflect() error = %v, want nil", err)
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{{
+		field: `string f = 1 [(golang.protobuf.validate.rules).min_int = 1];`,
+		want:  "min_int and max_int apply to integer fields",
+	}, {
+		field: `int32 f = 1 [(golang.protobuf.validate.rules).max_int = 2147483648];`,
+		want:  "bound 2147483648 is out of the range of int32 values",
+	}, {
+		field: `fixed32 f = 1 [(golang.protobuf.validate.rules).min_int = -1];`,
+		want:  "bound -1 is out of the range of fixed32 values",
+	}, {
+		field: `int64 f = 1 [(golang.protobuf.validate.rules) = { min_int: 2 max_int: 1 }];`,
+		want:  "min_int is greater than max_int",
+	}, {
+		field: `int32 f = 1 [(golang.protobuf.validate.rules).min_float = 1];`,
+		want:  "min_float and max_float apply to float and double fields",
+	}, {
+		field: `double f = 1 [(golang.protobuf.validate.rules).max_float = inf];`,
+		want:  "bound +Inf is not finite",
+	}, {
+		field: `repeated int32 f = 1 [(golang.protobuf.validate.rules).max_len = 1];`,
+		want:  "min_len and max_len apply to string and bytes fields",
+	}, {
+		field: `bytes f = 1 [(golang.protobuf.validate.rules).pattern = "a"];`,
+		want:  "pattern applies to string fields",
+	}, {
+		field: `string f = 1 [(golang.protobuf.validate.rules).pattern = "("];`,
+		want:  "error parsing regexp: missing closing ): `(`",
+	}, {
+		field: `oneof o { string f = 1 [(golang.protobuf.validate.rules).required = true]; }`,
+		want:  "required does not apply to fields of oneofs",
+	}, {
+		field: `int32 f = 1 [(golang.protobuf.validate.rules).defined_only = true];`,
+		want:  "defined_only applies to enum fields",
+	}, {
+		field: `string f = 1 [(golang.protobuf.validate.rules).max_items = 1];`,
+		want:  "min_items and max_items apply to repeated and map fields",
+	}, {
+		field: `map<string, string> f = 1 [(golang.protobuf.validate.rules).unique = true];`,
+		want:  "unique applies to repeated fields of scalars",
+	}, {
+		field: `repeated M f = 1 [(golang.protobuf.validate.rules).unique = true];`,
+		want:  "unique applies to repeated fields of scalars",
+	}, {
+		field: `M f = 1 [(golang.protobuf.validate.rules).max_duration.seconds = 1];`,
+		want:  "min_duration and max_duration apply to google.protobuf.Duration fields",
+	}, {
+		field: `google.protobuf.Duration f = 1 [(golang.protobuf.validate.rules).min_timestamp.seconds = 1];`,
+		want:  "min_timestamp and max_timestamp apply to google.protobuf.Timestamp fields",
+	}, {
+		field: `google.protobuf.Duration f = 1 [(golang.protobuf.validate.rules) = {
+  min_duration { seconds: 2 }
+  max_duration { seconds: 1 nanos: 5 }
+}];`,
+		want: "min_duration is greater than max_duration",
+	}}
+	for _, tt := range tests {
+		md := parse(t, testHeader+"message M {\n  "+tt.field+"\n}\n")
+		want := "validate: invalid rules of field test.M.f: " + tt.want
+		if _, err := validate.Rules(md.Fields().ByName("f")); err == nil || err.Error() != want {
+			t.Errorf("%s: Rules() error = %v, want %q", tt.field, err, want)
+		}
+		if err := validate.Reflect(dynamicpb.NewMessage(md)); err == nil || err.Error() != want {
+			t.Errorf("%s: Reflect() error = %v, want %q", tt.field, err, want)
+		}
+	}
+}